@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/services"
+)
+
+// AuthHandler 认证处理器
+type AuthHandler struct {
+	authService services.AuthService
+}
+
+// NewAuthHandler 创建认证处理器实例
+func NewAuthHandler(authService services.AuthService) *AuthHandler {
+	return &AuthHandler{
+		authService: authService,
+	}
+}
+
+// @Summary 用户登录
+// @Description 使用用户名或邮箱和密码登录，返回访问令牌和刷新令牌
+// @Tags 认证
+// @Accept json
+// @Produce json
+// @Param login body schemas.LoginRequest true "登录信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	// 解析请求体
+	var req schemas.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	tokens, err := h.authService.Login(req, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		respondAuthError(c, "Failed to login: ", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    tokens,
+	})
+}
+
+// @Summary 刷新令牌
+// @Description 使用刷新令牌换取新的访问令牌，刷新令牌同时轮换，旧令牌失效
+// @Tags 认证
+// @Accept json
+// @Produce json
+// @Param refresh body schemas.RefreshTokenRequest true "刷新令牌"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /auth/refresh [post]
+func (h *AuthHandler) RefreshToken(c *gin.Context) {
+	// 解析请求体
+	var req schemas.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	tokens, err := h.authService.RefreshToken(req, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		respondAuthError(c, "Failed to refresh token: ", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    tokens,
+	})
+}
+
+// @Summary 退出登录
+// @Description 撤销当前会话，访问令牌和刷新令牌同时失效
+// @Tags 认证
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	// 调用service方法
+	if err := h.authService.Logout(c.GetString("sessionID")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to logout: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 修改密码
+// @Description 校验原密码后修改当前用户密码，其他登录会话全部失效
+// @Tags 认证
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param password body schemas.PasswordChangeRequest true "密码信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /auth/password/change [post]
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	// 解析请求体
+	var req schemas.PasswordChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	err := h.authService.ChangePassword(c.GetString("userID"), c.GetString("sessionID"), req)
+	if err != nil {
+		respondAuthError(c, "Failed to change password: ", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 重置密码
+// @Description 使用管理员生成的一次性重置令牌设置新密码，用户所有登录会话失效
+// @Tags 认证
+// @Accept json
+// @Produce json
+// @Param password body schemas.PasswordResetRequest true "重置信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /auth/password/reset [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	// 解析请求体
+	var req schemas.PasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	if err := h.authService.ResetPassword(req); err != nil {
+		respondAuthError(c, "Failed to reset password: ", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 生成密码重置令牌
+// @Description 管理员为指定用户生成一次性密码重置令牌，令牌只在本次响应中返回
+// @Tags 系统-权限管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "用户ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/v1/system/users/{id}/password-reset [post]
+func (h *AuthHandler) CreatePasswordReset(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	reset, err := h.authService.CreatePasswordReset(id, c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to create password reset: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    reset,
+	})
+}
+
+// respondAuthError 认证失败返回401，重置令牌无效返回400，其他错误返回500
+func respondAuthError(c *gin.Context, prefix string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrInvalidCredentials),
+		errors.Is(err, services.ErrUserInactive),
+		errors.Is(err, services.ErrInvalidRefreshToken),
+		errors.Is(err, services.ErrSessionInvalid):
+		status = http.StatusUnauthorized
+	case errors.Is(err, services.ErrInvalidResetToken):
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, gin.H{
+		"code":    status,
+		"message": prefix + err.Error(),
+		"data":    nil,
+	})
+}
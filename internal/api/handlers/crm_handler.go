@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/query"
 	"github.com/wu136995/ginx/internal/services"
 )
 
@@ -27,20 +28,29 @@ func NewCRMHandler(crmService services.CRMService) *CRMHandler {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/crm/customers [get]
 func (h *CRMHandler) GetCustomerList(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
-	customers, err := h.crmService.GetCustomerList(req)
+	customers, err := h.crmService.GetCustomerList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Failed to get customer list: " + err.Error(),
 			"data":    nil,
 		})
@@ -205,20 +215,29 @@ func (h *CRMHandler) DeleteCustomer(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/crm/leads [get]
 func (h *CRMHandler) GetLeadList(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
-	leads, err := h.crmService.GetLeadList(req)
+	leads, err := h.crmService.GetLeadList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Failed to get lead list: " + err.Error(),
 			"data":    nil,
 		})
@@ -445,20 +464,29 @@ func (h *CRMHandler) ConvertLead(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/crm/opportunities [get]
 func (h *CRMHandler) GetOpportunityList(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
-	opportunities, err := h.crmService.GetOpportunityList(req)
+	opportunities, err := h.crmService.GetOpportunityList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Failed to get opportunity list: " + err.Error(),
 			"data":    nil,
 		})
@@ -623,14 +651,26 @@ func (h *CRMHandler) DeleteOpportunity(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "销售机会ID"
+// @Param close body schemas.OpportunityCloseRequest true "关闭信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/crm/opportunities/{id}/close [post]
 func (h *CRMHandler) CloseOpportunity(c *gin.Context) {
 	// 获取路径参数
 	id := c.Param("id")
 
+	// 解析请求体
+	var req schemas.OpportunityCloseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
 	// 调用service方法
-	err := h.crmService.CloseOpportunity(id, map[string]interface{}{})
+	err := h.crmService.CloseOpportunity(id, req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
@@ -654,20 +694,29 @@ func (h *CRMHandler) CloseOpportunity(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/crm/campaigns [get]
 func (h *CRMHandler) GetCampaignList(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
-	campaigns, err := h.crmService.GetCampaignList(req)
+	campaigns, err := h.crmService.GetCampaignList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Failed to get campaign list: " + err.Error(),
 			"data":    nil,
 		})
@@ -832,20 +881,29 @@ func (h *CRMHandler) DeleteCampaign(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/crm/activities [get]
 func (h *CRMHandler) GetActivityList(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
-	activities, err := h.crmService.GetActivityList(req)
+	activities, err := h.crmService.GetActivityList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Failed to get activity list: " + err.Error(),
 			"data":    nil,
 		})
@@ -1010,20 +1068,29 @@ func (h *CRMHandler) DeleteActivity(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/crm/service-requests [get]
 func (h *CRMHandler) GetServiceRequestList(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
-	serviceRequests, err := h.crmService.GetServiceRequestList(req)
+	serviceRequests, err := h.crmService.GetServiceRequestList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Failed to get service request list: " + err.Error(),
 			"data":    nil,
 		})
@@ -1219,17 +1286,26 @@ func (h *CRMHandler) ResolveServiceRequest(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param start_date query string false "开始日期（YYYY-MM-DD）"
+// @Param end_date query string false "结束日期（YYYY-MM-DD）"
+// @Param category query string false "客户类别"
+// @Param region query string false "地区"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/crm/reports/customers [get]
 func (h *CRMHandler) GetCustomerReport(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 绑定查询参数
+	var req schemas.CustomerAnalysisReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
-	report, err := h.crmService.GetCustomerAnalysisReport(schemas.CustomerAnalysisReportRequest{})
+	report, err := h.crmService.GetCustomerAnalysisReport(req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
@@ -1252,17 +1328,26 @@ func (h *CRMHandler) GetCustomerReport(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param start_date query string false "开始日期（YYYY-MM-DD）"
+// @Param end_date query string false "结束日期（YYYY-MM-DD）"
+// @Param salesperson_id query string false "销售员ID"
+// @Param customer_id query string false "客户ID"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/crm/reports/sales-pipeline [get]
 func (h *CRMHandler) GetSalesPipelineReport(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 绑定查询参数
+	var req schemas.SalesPipelineReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
-	report, err := h.crmService.GetSalesPipelineReport(schemas.SalesPipelineReportRequest{})
+	report, err := h.crmService.GetSalesPipelineReport(req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
@@ -1285,13 +1370,20 @@ func (h *CRMHandler) GetSalesPipelineReport(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param start_date query string false "开始日期（YYYY-MM-DD）"
+// @Param end_date query string false "结束日期（YYYY-MM-DD）"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/crm/reports/campaign-effectiveness [get]
 func (h *CRMHandler) GetCampaignEffectivenessReport(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 绑定查询参数
+	var req schemas.CRMReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
@@ -1318,13 +1410,20 @@ func (h *CRMHandler) GetCampaignEffectivenessReport(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param start_date query string false "开始日期（YYYY-MM-DD）"
+// @Param end_date query string false "结束日期（YYYY-MM-DD）"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/crm/reports/service-requests [get]
 func (h *CRMHandler) GetServiceRequestReport(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 绑定查询参数
+	var req schemas.CRMReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
@@ -1351,13 +1450,21 @@ func (h *CRMHandler) GetServiceRequestReport(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param report_type query string true "报表类型"
+// @Param start_date query string false "开始日期（YYYY-MM-DD）"
+// @Param end_date query string false "结束日期（YYYY-MM-DD）"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/crm/reports/export [get]
 func (h *CRMHandler) ExportCRMReport(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 绑定查询参数
+	var req schemas.ExportCRMReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
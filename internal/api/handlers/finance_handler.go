@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/query"
 	"github.com/wu136995/ginx/internal/services"
 )
 
@@ -26,20 +28,29 @@ func NewFinanceHandler(financeService services.FinanceService) *FinanceHandler {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/finance/accounts [get]
 func (h *FinanceHandler) GetAccountList(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
-	accounts, err := h.financeService.GetAccountList(req)
+	accounts, err := h.financeService.GetAccountList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Failed to get account list: " + err.Error(),
 			"data":    nil,
 		})
@@ -90,12 +101,12 @@ func (h *FinanceHandler) GetAccountDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param account body map[string]interface{} true "账户信息"
+// @Param account body schemas.AccountCreateRequest true "账户信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/finance/accounts [post]
 func (h *FinanceHandler) CreateAccount(c *gin.Context) {
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.AccountCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -130,7 +141,7 @@ func (h *FinanceHandler) CreateAccount(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "账户ID"
-// @Param account body map[string]interface{} true "账户信息"
+// @Param account body schemas.AccountUpdateRequest true "账户信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/finance/accounts/{id} [put]
 func (h *FinanceHandler) UpdateAccount(c *gin.Context) {
@@ -138,7 +149,7 @@ func (h *FinanceHandler) UpdateAccount(c *gin.Context) {
 	id := c.Param("id")
 
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.AccountUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -153,7 +164,2201 @@ func (h *FinanceHandler) UpdateAccount(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
-			"message": "Failed to update account: " + err.Error(),
+			"message": "Failed to update account: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    account,
+	})
+}
+
+// @Summary 删除账户
+// @Description 根据ID删除账户
+// @Tags 财务-账户管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "账户ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/accounts/{id} [delete]
+func (h *FinanceHandler) DeleteAccount(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.DeleteAccount(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to delete account: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 获取科目明细账
+// @Description 根据已过账凭证获取科目的期初余额、分录明细和期末余额
+// @Tags 财务-账户管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "科目ID"
+// @Param start_date query string false "开始日期（YYYY-MM-DD）"
+// @Param end_date query string false "结束日期（YYYY-MM-DD）"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/accounts/{id}/transactions [get]
+func (h *FinanceHandler) GetAccountTransactions(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 绑定查询参数
+	var req schemas.AccountTransactionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	ledger, err := h.financeService.GetAccountTransactions(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to get account transactions: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    ledger,
+	})
+}
+
+// 凭证管理路由处理函数
+// @Summary 获取凭证列表
+// @Description 获取所有凭证的列表
+// @Tags 财务-凭证管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param reference_type query string false "来源单据类型"
+// @Param reference_id query string false "来源单据ID"
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/vouchers [get]
+func (h *FinanceHandler) GetVoucherList(c *gin.Context) {
+	// 绑定查询参数
+	var req schemas.VoucherListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	vouchers, err := h.financeService.GetVoucherList(req, params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Failed to get voucher list: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    vouchers,
+	})
+}
+
+// @Summary 获取凭证详情
+// @Description 根据ID获取凭证详情
+// @Tags 财务-凭证管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "凭证ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/vouchers/{id} [get]
+func (h *FinanceHandler) GetVoucherDetail(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	voucher, err := h.financeService.GetVoucherDetail(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to get voucher detail: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    voucher,
+	})
+}
+
+// @Summary 创建凭证
+// @Description 创建新凭证
+// @Tags 财务-凭证管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param voucher body schemas.VoucherCreateRequest true "凭证信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/vouchers [post]
+func (h *FinanceHandler) CreateVoucher(c *gin.Context) {
+	// 解析请求体
+	var req schemas.VoucherCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	voucher, err := h.financeService.CreateVoucher(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to create voucher: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    voucher,
+	})
+}
+
+// @Summary 更新凭证
+// @Description 根据ID更新凭证
+// @Tags 财务-凭证管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "凭证ID"
+// @Param voucher body schemas.VoucherUpdateRequest true "凭证信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/vouchers/{id} [put]
+func (h *FinanceHandler) UpdateVoucher(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 解析请求体
+	var req schemas.VoucherUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	voucher, err := h.financeService.UpdateVoucher(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to update voucher: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    voucher,
+	})
+}
+
+// @Summary 删除凭证
+// @Description 根据ID删除凭证
+// @Tags 财务-凭证管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "凭证ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/vouchers/{id} [delete]
+func (h *FinanceHandler) DeleteVoucher(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.DeleteVoucher(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to delete voucher: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 提交凭证
+// @Description 提交凭证
+// @Tags 财务-凭证管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "凭证ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/vouchers/{id}/submit [post]
+func (h *FinanceHandler) SubmitVoucher(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.SubmitVoucher(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to submit voucher: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 审批凭证
+// @Description 审批凭证
+// @Tags 财务-凭证管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "凭证ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/vouchers/{id}/approve [post]
+func (h *FinanceHandler) ApproveVoucher(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.ApproveVoucher(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to approve voucher: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 驳回凭证
+// @Description 驳回已提交或已审批的凭证，驳回后可修改并重新提交
+// @Tags 财务-凭证管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "凭证ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/vouchers/{id}/reject [post]
+func (h *FinanceHandler) RejectVoucher(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.RejectVoucher(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to reject voucher: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 过账凭证
+// @Description 过账凭证
+// @Tags 财务-凭证管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "凭证ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/vouchers/{id}/post [post]
+func (h *FinanceHandler) PostVoucher(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.PostVoucher(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to post voucher: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// 会计期间管理路由处理函数
+// @Summary 获取会计年度列表
+// @Description 获取所有会计年度及其会计期间
+// @Tags 财务-会计期间管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/fiscal-years [get]
+func (h *FinanceHandler) GetFiscalYearList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	years, err := h.financeService.GetFiscalYearList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Failed to get fiscal year list: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    years,
+	})
+}
+
+// @Summary 创建会计年度
+// @Description 创建会计年度并按自然月生成12个会计期间
+// @Tags 财务-会计期间管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param fiscalYear body schemas.FiscalYearCreateRequest true "会计年度信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/fiscal-years [post]
+func (h *FinanceHandler) CreateFiscalYear(c *gin.Context) {
+	// 解析请求体
+	var req schemas.FiscalYearCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	year, err := h.financeService.CreateFiscalYear(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to create fiscal year: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    year,
+	})
+}
+
+// @Summary 获取会计期间列表
+// @Description 获取会计期间列表
+// @Tags 财务-会计期间管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param fiscal_year_id query string false "会计年度ID"
+// @Param status query string false "状态（open, soft_closed, closed）"
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/periods [get]
+func (h *FinanceHandler) GetPeriodList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	periods, err := h.financeService.GetPeriodList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Failed to get period list: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    periods,
+	})
+}
+
+// @Summary 软关账
+// @Description 软关账后业务单据不能再记入该期间，财务凭证仍可调整
+// @Tags 财务-会计期间管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "会计期间ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/periods/{id}/soft-close [post]
+func (h *FinanceHandler) SoftClosePeriod(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.SoftClosePeriod(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to soft-close period: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 期末结账
+// @Description 结转损益到留存收益科目并关闭会计期间
+// @Tags 财务-会计期间管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "会计期间ID"
+// @Param close body schemas.PeriodCloseRequest false "结账参数"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/periods/{id}/close [post]
+func (h *FinanceHandler) ClosePeriod(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 解析请求体，允许为空
+	var req schemas.PeriodCloseRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"code":    400,
+				"message": "Invalid request body: " + err.Error(),
+				"data":    nil,
+			})
+			return
+		}
+	}
+
+	// 调用service方法
+	period, err := h.financeService.ClosePeriod(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to close period: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    period,
+	})
+}
+
+// @Summary 反结账
+// @Description 重新打开会计期间，已关闭期间会冲销期末结转凭证
+// @Tags 财务-会计期间管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "会计期间ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/periods/{id}/reopen [post]
+func (h *FinanceHandler) ReopenPeriod(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.ReopenPeriod(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to reopen period: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// 自动记账规则管理路由处理函数
+// @Summary 获取记账规则列表
+// @Description 获取业务单据自动生成凭证所用的记账规则
+// @Tags 财务-自动记账规则
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param document_type query string false "单据类型（sales_invoice, purchase_invoice, inventory_transaction）"
+// @Param event query string false "业务事件"
+// @Param status query string false "状态（active, inactive）"
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/posting-rules [get]
+func (h *FinanceHandler) GetPostingRuleList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	rules, err := h.financeService.GetPostingRuleList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Failed to get posting rule list: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    rules,
+	})
+}
+
+// @Summary 创建记账规则
+// @Description 按单据类型和业务事件配置借贷科目
+// @Tags 财务-自动记账规则
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rule body schemas.PostingRuleCreateRequest true "记账规则信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/posting-rules [post]
+func (h *FinanceHandler) CreatePostingRule(c *gin.Context) {
+	// 解析请求体
+	var req schemas.PostingRuleCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	rule, err := h.financeService.CreatePostingRule(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to create posting rule: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    rule,
+	})
+}
+
+// @Summary 更新记账规则
+// @Description 根据ID更新记账规则，仅影响之后生成的凭证
+// @Tags 财务-自动记账规则
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "记账规则ID"
+// @Param rule body schemas.PostingRuleUpdateRequest true "记账规则信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/posting-rules/{id} [put]
+func (h *FinanceHandler) UpdatePostingRule(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 解析请求体
+	var req schemas.PostingRuleUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	rule, err := h.financeService.UpdatePostingRule(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to update posting rule: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    rule,
+	})
+}
+
+// @Summary 删除记账规则
+// @Description 根据ID删除记账规则
+// @Tags 财务-自动记账规则
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "记账规则ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/posting-rules/{id} [delete]
+func (h *FinanceHandler) DeletePostingRule(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.DeletePostingRule(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to delete posting rule: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// 税务管理路由处理函数
+// @Summary 获取税码列表
+// @Description 分页获取税码及其各期税率
+// @Tags 财务-税务管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param code query string false "税码"
+// @Param name query string false "税码名称"
+// @Param status query string false "状态（active, inactive）"
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/tax-codes [get]
+func (h *FinanceHandler) GetTaxCodeList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	codes, err := h.financeService.GetTaxCodeList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Failed to get tax code list: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    codes,
+	})
+}
+
+// @Summary 获取税码详情
+// @Description 根据ID获取税码及其各期税率
+// @Tags 财务-税务管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "税码ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/tax-codes/{id} [get]
+func (h *FinanceHandler) GetTaxCodeDetail(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	code, err := h.financeService.GetTaxCodeDetail(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to get tax code detail: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    code,
+	})
+}
+
+// @Summary 创建税码
+// @Description 创建税码并维护按生效日期划分的税率
+// @Tags 财务-税务管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param code body schemas.TaxCodeCreateRequest true "税码信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/tax-codes [post]
+func (h *FinanceHandler) CreateTaxCode(c *gin.Context) {
+	// 解析请求体
+	var req schemas.TaxCodeCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	code, err := h.financeService.CreateTaxCode(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to create tax code: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    code,
+	})
+}
+
+// @Summary 更新税码
+// @Description 根据ID更新税码，传入税率时整体替换，已开具发票不受影响
+// @Tags 财务-税务管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "税码ID"
+// @Param code body schemas.TaxCodeUpdateRequest true "税码信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/tax-codes/{id} [put]
+func (h *FinanceHandler) UpdateTaxCode(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 解析请求体
+	var req schemas.TaxCodeUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	code, err := h.financeService.UpdateTaxCode(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to update tax code: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    code,
+	})
+}
+
+// @Summary 删除税码
+// @Description 根据ID删除未被税码确定规则引用的税码
+// @Tags 财务-税务管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "税码ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/tax-codes/{id} [delete]
+func (h *FinanceHandler) DeleteTaxCode(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.DeleteTaxCode(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to delete tax code: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 获取税码确定规则列表
+// @Description 分页获取按业务方向、纳税状态和税收分类匹配税码的规则
+// @Tags 财务-税务管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param direction query string false "业务方向（sales, purchase）"
+// @Param party_tax_status query string false "往来单位纳税状态"
+// @Param item_tax_category query string false "物料税收分类"
+// @Param status query string false "状态（active, inactive）"
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/tax-rules [get]
+func (h *FinanceHandler) GetTaxRuleList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	rules, err := h.financeService.GetTaxRuleList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Failed to get tax rule list: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    rules,
+	})
+}
+
+// @Summary 创建税码确定规则
+// @Description 按业务方向、往来单位纳税状态和物料税收分类配置税码
+// @Tags 财务-税务管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rule body schemas.TaxRuleCreateRequest true "税码确定规则信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/tax-rules [post]
+func (h *FinanceHandler) CreateTaxRule(c *gin.Context) {
+	// 解析请求体
+	var req schemas.TaxRuleCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	rule, err := h.financeService.CreateTaxRule(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to create tax rule: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    rule,
+	})
+}
+
+// @Summary 更新税码确定规则
+// @Description 根据ID更新税码确定规则，仅影响之后计税的发票
+// @Tags 财务-税务管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "规则ID"
+// @Param rule body schemas.TaxRuleUpdateRequest true "税码确定规则信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/tax-rules/{id} [put]
+func (h *FinanceHandler) UpdateTaxRule(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 解析请求体
+	var req schemas.TaxRuleUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	rule, err := h.financeService.UpdateTaxRule(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to update tax rule: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    rule,
+	})
+}
+
+// @Summary 删除税码确定规则
+// @Description 根据ID删除税码确定规则
+// @Tags 财务-税务管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "规则ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/tax-rules/{id} [delete]
+func (h *FinanceHandler) DeleteTaxRule(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.DeleteTaxRule(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to delete tax rule: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// 外币管理路由处理函数
+// @Summary 获取币种列表
+// @Description 获取币种主数据，本位币标记为is_base
+// @Tags 财务-外币管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param code query string false "币种代码"
+// @Param name query string false "币种名称"
+// @Param status query string false "状态（active, inactive）"
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/currencies [get]
+func (h *FinanceHandler) GetCurrencyList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	currencies, err := h.financeService.GetCurrencyList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Failed to get currency list: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    currencies,
+	})
+}
+
+// @Summary 创建币种
+// @Description 创建外币币种，币种代码使用ISO 4217三位字母代码
+// @Tags 财务-外币管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param currency body schemas.CurrencyCreateRequest true "币种信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/currencies [post]
+func (h *FinanceHandler) CreateCurrency(c *gin.Context) {
+	// 解析请求体
+	var req schemas.CurrencyCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	currency, err := h.financeService.CreateCurrency(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to create currency: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    currency,
+	})
+}
+
+// @Summary 更新币种
+// @Description 根据ID更新币种名称、符号或状态，本位币不能停用
+// @Tags 财务-外币管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "币种ID"
+// @Param currency body schemas.CurrencyUpdateRequest true "币种信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/currencies/{id} [put]
+func (h *FinanceHandler) UpdateCurrency(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 解析请求体
+	var req schemas.CurrencyUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	currency, err := h.financeService.UpdateCurrency(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to update currency: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    currency,
+	})
+}
+
+// @Summary 删除币种
+// @Description 根据ID删除币种，本位币及已维护汇率的币种不能删除
+// @Tags 财务-外币管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "币种ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/currencies/{id} [delete]
+func (h *FinanceHandler) DeleteCurrency(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.DeleteCurrency(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to delete currency: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 获取汇率列表
+// @Description 获取按日期维护的外币汇率，汇率为1单位外币折合本位币的金额
+// @Tags 财务-外币管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param currency_code query string false "币种代码"
+// @Param rate_date query string false "汇率日期，支持范围过滤"
+// @Param source query string false "来源（manual, import）"
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/exchange-rates [get]
+func (h *FinanceHandler) GetExchangeRateList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	rates, err := h.financeService.GetExchangeRateList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Failed to get exchange rate list: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    rates,
+	})
+}
+
+// @Summary 维护汇率
+// @Description 手工维护外币某日汇率，同一币种同一日期已存在时覆盖
+// @Tags 财务-外币管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rate body schemas.ExchangeRateCreateRequest true "汇率信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/exchange-rates [post]
+func (h *FinanceHandler) CreateExchangeRate(c *gin.Context) {
+	// 解析请求体
+	var req schemas.ExchangeRateCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	rate, err := h.financeService.CreateExchangeRate(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to create exchange rate: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    rate,
+	})
+}
+
+// @Summary 删除汇率
+// @Description 根据ID删除汇率，已生成单据的汇率不受影响
+// @Tags 财务-外币管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "汇率ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/exchange-rates/{id} [delete]
+func (h *FinanceHandler) DeleteExchangeRate(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.DeleteExchangeRate(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to delete exchange rate: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 导入汇率
+// @Description 从CSV文件批量导入汇率，列依次为currency、date、rate，任一行校验失败时整批不导入
+// @Tags 财务-外币管理
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "汇率CSV文件"
+// @Param created_by formData string false "导入人"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/exchange-rates/import [post]
+func (h *FinanceHandler) ImportExchangeRates(c *gin.Context) {
+	// 读取上传文件
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request file: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request file: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+	defer file.Close()
+
+	// 调用service方法
+	result, err := h.financeService.ImportExchangeRates(file, c.PostForm("created_by"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to import exchange rates: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    result,
+	})
+}
+
+// @Summary 获取汇兑重估列表
+// @Description 获取各会计期间的期末汇兑重估结果及明细
+// @Tags 财务-外币管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param period_id query string false "会计期间ID"
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/fx-revaluations [get]
+func (h *FinanceHandler) GetFXRevaluationList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	revaluations, err := h.financeService.GetFXRevaluationList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Failed to get fx revaluation list: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    revaluations,
+	})
+}
+
+// @Summary 期末汇兑重估
+// @Description 按期末汇率重估未结清的外币应收应付，重估凭证记在期末日并于下期首日自动冲回
+// @Tags 财务-外币管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "会计期间ID"
+// @Param revaluation body schemas.FXRevaluationRequest false "重估参数"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/periods/{id}/fx-revaluation [post]
+func (h *FinanceHandler) RevaluePeriod(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 解析请求体，允许为空
+	var req schemas.FXRevaluationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"code":    400,
+				"message": "Invalid request body: " + err.Error(),
+				"data":    nil,
+			})
+			return
+		}
+	}
+
+	// 调用service方法
+	revaluation, err := h.financeService.RevaluePeriod(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to revalue period: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    revaluation,
+	})
+}
+
+// 收款管理路由处理函数
+// @Summary 获取收款单列表
+// @Description 获取客户收款单列表，包含核销明细和未核销的预收款金额
+// @Tags 财务-收款管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param code query string false "收款单编号"
+// @Param date query string false "收款日期，支持范围过滤"
+// @Param customer_id query string false "客户ID"
+// @Param status query string false "状态（draft, submitted, approved, rejected, processed）"
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/receipts [get]
+func (h *FinanceHandler) GetReceiptList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	receipts, err := h.financeService.GetReceiptList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Failed to get receipt list: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    receipts,
+	})
+}
+
+// @Summary 获取收款单详情
+// @Description 根据ID获取收款单详情及核销明细
+// @Tags 财务-收款管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "收款单ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/receipts/{id} [get]
+func (h *FinanceHandler) GetReceiptDetail(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	receipt, err := h.financeService.GetReceiptDetail(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to get receipt detail: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    receipt,
+	})
+}
+
+// @Summary 创建收款单
+// @Description 登记客户收款及计划核销的发票，新建收款单为草稿状态
+// @Tags 财务-收款管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param receipt body schemas.CollectionCreateRequest true "收款信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/receipts [post]
+func (h *FinanceHandler) CreateReceipt(c *gin.Context) {
+	// 解析请求体
+	var req schemas.CollectionCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	receipt, err := h.financeService.CreateReceipt(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to create receipt: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    receipt,
+	})
+}
+
+// @Summary 更新收款单
+// @Description 更新草稿或已驳回的收款单，传入核销明细时整体替换
+// @Tags 财务-收款管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "收款单ID"
+// @Param receipt body schemas.CollectionUpdateRequest true "收款信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/receipts/{id} [put]
+func (h *FinanceHandler) UpdateReceipt(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 解析请求体
+	var req schemas.CollectionUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	receipt, err := h.financeService.UpdateReceipt(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to update receipt: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    receipt,
+	})
+}
+
+// @Summary 删除收款单
+// @Description 删除草稿或已驳回的收款单
+// @Tags 财务-收款管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "收款单ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/receipts/{id} [delete]
+func (h *FinanceHandler) DeleteReceipt(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.DeleteReceipt(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to delete receipt: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 提交收款单
+// @Description 提交收款单，按发票当前余额校验核销明细
+// @Tags 财务-收款管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "收款单ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/receipts/{id}/submit [post]
+func (h *FinanceHandler) SubmitReceipt(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.SubmitReceipt(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to submit receipt: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 审批收款单
+// @Description 审批已提交的收款单
+// @Tags 财务-收款管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "收款单ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/receipts/{id}/approve [post]
+func (h *FinanceHandler) ApproveReceipt(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.ApproveReceipt(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to approve receipt: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 驳回收款单
+// @Description 驳回已提交或已审批的收款单
+// @Tags 财务-收款管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "收款单ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/receipts/{id}/reject [post]
+func (h *FinanceHandler) RejectReceipt(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.RejectReceipt(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to reject receipt: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 处理收款单
+// @Description 处理已审批的收款单，生成收款凭证并核销发票，未核销部分作为预收款
+// @Tags 财务-收款管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "收款单ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/receipts/{id}/process [post]
+func (h *FinanceHandler) ProcessReceipt(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.ProcessReceipt(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to process receipt: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 追加核销
+// @Description 将已处理收款的预收款核销到发票，或对发票余额核销坏账
+// @Tags 财务-收款管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "收款单ID"
+// @Param allocation body schemas.CollectionApplyRequest true "核销信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/receipts/{id}/apply [post]
+func (h *FinanceHandler) ApplyReceipt(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 解析请求体
+	var req schemas.CollectionApplyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	receipt, err := h.financeService.ApplyReceipt(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to apply receipt: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    receipt,
+	})
+}
+
+// 付款管理路由处理函数
+// @Summary 获取付款单列表
+// @Description 获取供应商付款单列表，包含核销明细、提前付款折扣和未核销的预付款金额
+// @Tags 财务-付款管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param code query string false "付款单编号"
+// @Param date query string false "付款日期，支持范围过滤"
+// @Param supplier_id query string false "供应商ID"
+// @Param status query string false "状态（draft, submitted, approved, rejected, processed）"
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/payments [get]
+func (h *FinanceHandler) GetPaymentList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	payments, err := h.financeService.GetPaymentList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Failed to get payment list: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    payments,
+	})
+}
+
+// @Summary 获取付款单详情
+// @Description 根据ID获取付款单详情及核销明细
+// @Tags 财务-付款管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "付款单ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/payments/{id} [get]
+func (h *FinanceHandler) GetPaymentDetail(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	payment, err := h.financeService.GetPaymentDetail(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to get payment detail: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    payment,
+	})
+}
+
+// @Summary 创建付款单
+// @Description 登记供应商付款及计划核销的采购发票，新建付款单为草稿状态
+// @Tags 财务-付款管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param payment body schemas.PaymentCreateRequest true "付款信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/payments [post]
+func (h *FinanceHandler) CreatePayment(c *gin.Context) {
+	// 解析请求体
+	var req schemas.PaymentCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	payment, err := h.financeService.CreatePayment(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to create payment: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    payment,
+	})
+}
+
+// @Summary 更新付款单
+// @Description 更新草稿或已驳回的付款单，传入核销明细时整体替换
+// @Tags 财务-付款管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "付款单ID"
+// @Param payment body schemas.PaymentUpdateRequest true "付款信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/payments/{id} [put]
+func (h *FinanceHandler) UpdatePayment(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 解析请求体
+	var req schemas.PaymentUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	payment, err := h.financeService.UpdatePayment(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to update payment: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    payment,
+	})
+}
+
+// @Summary 删除付款单
+// @Description 删除草稿或已驳回的付款单
+// @Tags 财务-付款管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "付款单ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/payments/{id} [delete]
+func (h *FinanceHandler) DeletePayment(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.DeletePayment(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to delete payment: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 提交付款单
+// @Description 提交付款单，按采购发票当前余额校验核销明细
+// @Tags 财务-付款管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "付款单ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/payments/{id}/submit [post]
+func (h *FinanceHandler) SubmitPayment(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.SubmitPayment(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to submit payment: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 审批付款单
+// @Description 审批已提交的付款单
+// @Tags 财务-付款管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "付款单ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/payments/{id}/approve [post]
+func (h *FinanceHandler) ApprovePayment(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.ApprovePayment(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to approve payment: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 驳回付款单
+// @Description 驳回已提交或已审批的付款单
+// @Tags 财务-付款管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "付款单ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/payments/{id}/reject [post]
+func (h *FinanceHandler) RejectPayment(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.financeService.RejectPayment(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to reject payment: " + err.Error(),
 			"data":    nil,
 		})
 		return
@@ -162,29 +2367,29 @@ func (h *FinanceHandler) UpdateAccount(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"code":    200,
 		"message": "success",
-		"data":    account,
+		"data":    nil,
 	})
 }
 
-// @Summary 删除账户
-// @Description 根据ID删除账户
-// @Tags 财务-账户管理
+// @Summary 处理付款单
+// @Description 处理已审批的付款单，生成付款凭证并核销采购发票，有提前付款折扣时生成折扣凭证，未核销部分作为预付款
+// @Tags 财务-付款管理
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param id path string true "账户ID"
+// @Param id path string true "付款单ID"
 // @Success 200 {object} map[string]interface{} "成功"
-// @Router /api/finance/accounts/{id} [delete]
-func (h *FinanceHandler) DeleteAccount(c *gin.Context) {
+// @Router /api/finance/payments/{id}/process [post]
+func (h *FinanceHandler) ProcessPayment(c *gin.Context) {
 	// 获取路径参数
 	id := c.Param("id")
 
 	// 调用service方法
-	err := h.financeService.DeleteAccount(id)
+	err := h.financeService.ProcessPayment(id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
-			"message": "Failed to delete account: " + err.Error(),
+			"message": "Failed to process payment: " + err.Error(),
 			"data":    nil,
 		})
 		return
@@ -197,28 +2402,41 @@ func (h *FinanceHandler) DeleteAccount(c *gin.Context) {
 	})
 }
 
-// 凭证管理路由处理函数
-// @Summary 获取凭证列表
-// @Description 获取所有凭证的列表
-// @Tags 财务-凭证管理
+// 付款运行路由处理函数
+// @Summary 获取付款运行列表
+// @Description 获取付款运行列表，列表不返回付款明细
+// @Tags 财务-付款运行
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param code query string false "付款运行编号"
+// @Param payment_date query string false "付款日期，支持范围过滤"
+// @Param supplier_id query string false "供应商ID"
+// @Param status query string false "状态（proposed, approved, cancelled）"
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
-// @Router /api/finance/vouchers [get]
-func (h *FinanceHandler) GetVoucherList(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+// @Router /api/finance/payment-runs [get]
+func (h *FinanceHandler) GetPaymentRunList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
-	vouchers, err := h.financeService.GetVoucherList(req)
+	runs, err := h.financeService.GetPaymentRunList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
-			"message": "Failed to get voucher list: " + err.Error(),
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Failed to get payment run list: " + err.Error(),
 			"data":    nil,
 		})
 		return
@@ -227,29 +2445,29 @@ func (h *FinanceHandler) GetVoucherList(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"code":    200,
 		"message": "success",
-		"data":    vouchers,
+		"data":    runs,
 	})
 }
 
-// @Summary 获取凭证详情
-// @Description 根据ID获取凭证详情
-// @Tags 财务-凭证管理
+// @Summary 获取付款运行详情
+// @Description 根据ID获取付款运行及付款明细
+// @Tags 财务-付款运行
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param id path string true "凭证ID"
+// @Param id path string true "付款运行ID"
 // @Success 200 {object} map[string]interface{} "成功"
-// @Router /api/finance/vouchers/{id} [get]
-func (h *FinanceHandler) GetVoucherDetail(c *gin.Context) {
+// @Router /api/finance/payment-runs/{id} [get]
+func (h *FinanceHandler) GetPaymentRunDetail(c *gin.Context) {
 	// 获取路径参数
 	id := c.Param("id")
 
 	// 调用service方法
-	voucher, err := h.financeService.GetVoucherDetail(id)
+	run, err := h.financeService.GetPaymentRunDetail(id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
-			"message": "Failed to get voucher detail: " + err.Error(),
+			"message": "Failed to get payment run detail: " + err.Error(),
 			"data":    nil,
 		})
 		return
@@ -258,22 +2476,22 @@ func (h *FinanceHandler) GetVoucherDetail(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"code":    200,
 		"message": "success",
-		"data":    voucher,
+		"data":    run,
 	})
 }
 
-// @Summary 创建凭证
-// @Description 创建新凭证
-// @Tags 财务-凭证管理
+// @Summary 创建付款运行
+// @Description 按到期日、供应商和币种选取已审核的待付采购发票生成付款建议，付款日仍在折扣期内的发票扣除提前付款折扣
+// @Tags 财务-付款运行
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param voucher body map[string]interface{} true "凭证信息"
+// @Param run body schemas.PaymentRunCreateRequest true "付款运行条件"
 // @Success 200 {object} map[string]interface{} "成功"
-// @Router /api/finance/vouchers [post]
-func (h *FinanceHandler) CreateVoucher(c *gin.Context) {
+// @Router /api/finance/payment-runs [post]
+func (h *FinanceHandler) CreatePaymentRun(c *gin.Context) {
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.PaymentRunCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -284,11 +2502,11 @@ func (h *FinanceHandler) CreateVoucher(c *gin.Context) {
 	}
 
 	// 调用service方法
-	voucher, err := h.financeService.CreateVoucher(req)
+	run, err := h.financeService.CreatePaymentRun(req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
-			"message": "Failed to create voucher: " + err.Error(),
+			"message": "Failed to create payment run: " + err.Error(),
 			"data":    nil,
 		})
 		return
@@ -297,26 +2515,26 @@ func (h *FinanceHandler) CreateVoucher(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"code":    200,
 		"message": "success",
-		"data":    voucher,
+		"data":    run,
 	})
 }
 
-// @Summary 更新凭证
-// @Description 根据ID更新凭证
-// @Tags 财务-凭证管理
+// @Summary 调整付款建议
+// @Description 调整建议状态的付款运行，传入明细时整体替换
+// @Tags 财务-付款运行
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param id path string true "凭证ID"
-// @Param voucher body map[string]interface{} true "凭证信息"
+// @Param id path string true "付款运行ID"
+// @Param run body schemas.PaymentRunUpdateRequest true "付款明细"
 // @Success 200 {object} map[string]interface{} "成功"
-// @Router /api/finance/vouchers/{id} [put]
-func (h *FinanceHandler) UpdateVoucher(c *gin.Context) {
+// @Router /api/finance/payment-runs/{id} [put]
+func (h *FinanceHandler) UpdatePaymentRun(c *gin.Context) {
 	// 获取路径参数
 	id := c.Param("id")
 
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.PaymentRunUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -327,11 +2545,11 @@ func (h *FinanceHandler) UpdateVoucher(c *gin.Context) {
 	}
 
 	// 调用service方法
-	voucher, err := h.financeService.UpdateVoucher(id, req)
+	run, err := h.financeService.UpdatePaymentRun(id, req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
-			"message": "Failed to update voucher: " + err.Error(),
+			"message": "Failed to update payment run: " + err.Error(),
 			"data":    nil,
 		})
 		return
@@ -340,60 +2558,41 @@ func (h *FinanceHandler) UpdateVoucher(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"code":    200,
 		"message": "success",
-		"data":    voucher,
+		"data":    run,
 	})
 }
 
-// @Summary 删除凭证
-// @Description 根据ID删除凭证
-// @Tags 财务-凭证管理
+// @Summary 审批付款运行
+// @Description 审批付款建议，按供应商和币种生成并处理付款单，核销采购发票并记录提前付款折扣
+// @Tags 财务-付款运行
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param id path string true "凭证ID"
+// @Param id path string true "付款运行ID"
+// @Param approval body schemas.PaymentRunApproveRequest true "审批信息"
 // @Success 200 {object} map[string]interface{} "成功"
-// @Router /api/finance/vouchers/{id} [delete]
-func (h *FinanceHandler) DeleteVoucher(c *gin.Context) {
+// @Router /api/finance/payment-runs/{id}/approve [post]
+func (h *FinanceHandler) ApprovePaymentRun(c *gin.Context) {
 	// 获取路径参数
 	id := c.Param("id")
 
-	// 调用service方法
-	err := h.financeService.DeleteVoucher(id)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
-			"message": "Failed to delete voucher: " + err.Error(),
+	// 解析请求体
+	var req schemas.PaymentRunApproveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
 			"data":    nil,
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"code":    200,
-		"message": "success",
-		"data":    nil,
-	})
-}
-
-// @Summary 提交凭证
-// @Description 提交凭证
-// @Tags 财务-凭证管理
-// @Accept json
-// @Produce json
-// @Security BearerAuth
-// @Param id path string true "凭证ID"
-// @Success 200 {object} map[string]interface{} "成功"
-// @Router /api/finance/vouchers/{id}/submit [post]
-func (h *FinanceHandler) SubmitVoucher(c *gin.Context) {
-	// 获取路径参数
-	id := c.Param("id")
-
 	// 调用service方法
-	err := h.financeService.SubmitVoucher(id)
+	run, err := h.financeService.ApprovePaymentRun(id, req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
-			"message": "Failed to submit voucher: " + err.Error(),
+			"message": "Failed to approve payment run: " + err.Error(),
 			"data":    nil,
 		})
 		return
@@ -402,29 +2601,29 @@ func (h *FinanceHandler) SubmitVoucher(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"code":    200,
 		"message": "success",
-		"data":    nil,
+		"data":    run,
 	})
 }
 
-// @Summary 审批凭证
-// @Description 审批凭证
-// @Tags 财务-凭证管理
+// @Summary 取消付款运行
+// @Description 取消建议状态的付款运行，其中的发票可进入新的付款运行
+// @Tags 财务-付款运行
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param id path string true "凭证ID"
+// @Param id path string true "付款运行ID"
 // @Success 200 {object} map[string]interface{} "成功"
-// @Router /api/finance/vouchers/{id}/approve [post]
-func (h *FinanceHandler) ApproveVoucher(c *gin.Context) {
+// @Router /api/finance/payment-runs/{id}/cancel [post]
+func (h *FinanceHandler) CancelPaymentRun(c *gin.Context) {
 	// 获取路径参数
 	id := c.Param("id")
 
 	// 调用service方法
-	err := h.financeService.ApproveVoucher(id)
+	err := h.financeService.CancelPaymentRun(id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
-			"message": "Failed to approve voucher: " + err.Error(),
+			"message": "Failed to cancel payment run: " + err.Error(),
 			"data":    nil,
 		})
 		return
@@ -437,25 +2636,25 @@ func (h *FinanceHandler) ApproveVoucher(c *gin.Context) {
 	})
 }
 
-// @Summary 过账凭证
-// @Description 过账凭证
-// @Tags 财务-凭证管理
+// @Summary 导出银行付款文件
+// @Description 将已审批付款运行的付款单导出为ISO 20022 pain.001.001.03 XML银行付款文件
+// @Tags 财务-付款运行
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param id path string true "凭证ID"
+// @Param id path string true "付款运行ID"
 // @Success 200 {object} map[string]interface{} "成功"
-// @Router /api/finance/vouchers/{id}/post [post]
-func (h *FinanceHandler) PostVoucher(c *gin.Context) {
+// @Router /api/finance/payment-runs/{id}/export [get]
+func (h *FinanceHandler) ExportPaymentRun(c *gin.Context) {
 	// 获取路径参数
 	id := c.Param("id")
 
 	// 调用service方法
-	err := h.financeService.SubmitVoucher(id)
+	data, err := h.financeService.ExportPaymentRun(id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
-			"message": "Failed to submit voucher: " + err.Error(),
+			"message": "Failed to export payment run: " + err.Error(),
 			"data":    nil,
 		})
 		return
@@ -464,7 +2663,7 @@ func (h *FinanceHandler) PostVoucher(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"code":    200,
 		"message": "success",
-		"data":    nil,
+		"data":    string(data),
 	})
 }
 
@@ -475,20 +2674,29 @@ func (h *FinanceHandler) PostVoucher(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/finance/budgets [get]
 func (h *FinanceHandler) GetBudgetList(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
-	budgets, err := h.financeService.GetBudgetList(req)
+	budgets, err := h.financeService.GetBudgetList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Failed to get budget list: " + err.Error(),
 			"data":    nil,
 		})
@@ -539,12 +2747,12 @@ func (h *FinanceHandler) GetBudgetDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param budget body map[string]interface{} true "预算信息"
+// @Param budget body schemas.BudgetCreateRequest true "预算信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/finance/budgets [post]
 func (h *FinanceHandler) CreateBudget(c *gin.Context) {
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.BudgetCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -579,7 +2787,7 @@ func (h *FinanceHandler) CreateBudget(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "预算ID"
-// @Param budget body map[string]interface{} true "预算信息"
+// @Param budget body schemas.BudgetUpdateRequest true "预算信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/finance/budgets/{id} [put]
 func (h *FinanceHandler) UpdateBudget(c *gin.Context) {
@@ -587,7 +2795,7 @@ func (h *FinanceHandler) UpdateBudget(c *gin.Context) {
 	id := c.Param("id")
 
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.BudgetUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -722,7 +2930,7 @@ func (h *FinanceHandler) AdjustBudget(c *gin.Context) {
 	id := c.Param("id")
 
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.BudgetAdjustRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -757,20 +2965,29 @@ func (h *FinanceHandler) AdjustBudget(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/finance/fixed-assets [get]
 func (h *FinanceHandler) GetFixedAssetList(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
-	assets, err := h.financeService.GetAssetList(req)
+	assets, err := h.financeService.GetAssetList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Failed to get asset list: " + err.Error(),
 			"data":    nil,
 		})
@@ -821,12 +3038,12 @@ func (h *FinanceHandler) GetFixedAssetDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param asset body map[string]interface{} true "固定资产信息"
+// @Param asset body schemas.AssetCreateRequest true "固定资产信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/finance/fixed-assets [post]
 func (h *FinanceHandler) CreateFixedAsset(c *gin.Context) {
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.AssetCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -861,7 +3078,7 @@ func (h *FinanceHandler) CreateFixedAsset(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "固定资产ID"
-// @Param asset body map[string]interface{} true "固定资产信息"
+// @Param asset body schemas.AssetUpdateRequest true "固定资产信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/finance/fixed-assets/{id} [put]
 func (h *FinanceHandler) UpdateFixedAsset(c *gin.Context) {
@@ -869,7 +3086,7 @@ func (h *FinanceHandler) UpdateFixedAsset(c *gin.Context) {
 	id := c.Param("id")
 
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.AssetUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -935,11 +3152,26 @@ func (h *FinanceHandler) DeleteFixedAsset(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "固定资产ID"
+// @Param depreciation body schemas.DepreciationRequest true "折旧信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/finance/fixed-assets/{id}/depreciate [post]
 func (h *FinanceHandler) DepreciateFixedAsset(c *gin.Context) {
+	// 绑定请求参数
+	var req schemas.DepreciationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 按路径指定的资产计提折旧
+	req.AssetIDs = []string{c.Param("id")}
+
 	// 调用service方法
-	_, err := h.financeService.CalculateDepreciation(map[string]interface{}{})
+	result, err := h.financeService.CalculateDepreciation(req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
@@ -952,7 +3184,7 @@ func (h *FinanceHandler) DepreciateFixedAsset(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"code":    200,
 		"message": "success",
-		"data":    nil,
+		"data":    result,
 	})
 }
 
@@ -963,14 +3195,26 @@ func (h *FinanceHandler) DepreciateFixedAsset(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "固定资产ID"
+// @Param dispose body schemas.AssetDisposeRequest true "处置信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/finance/fixed-assets/{id}/dispose [post]
 func (h *FinanceHandler) DisposeFixedAsset(c *gin.Context) {
 	// 获取路径参数
 	id := c.Param("id")
 
+	// 绑定请求参数
+	var req schemas.AssetDisposeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
 	// 调用service方法
-	err := h.financeService.DisposeAsset(id, map[string]interface{}{})
+	err := h.financeService.DisposeAsset(id, req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
@@ -994,13 +3238,20 @@ func (h *FinanceHandler) DisposeFixedAsset(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param date query string true "报表日期（YYYY-MM-DD）"
+// @Param comparison_date query string false "比较日期，默认上年末"
 // @Success 200 {object} map[string]interface{} "成功"
-// @Router /api/finance/reports/balance-sheet [get]
+// @Router /api/finance/reports/balance [get]
 func (h *FinanceHandler) GetBalanceSheet(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 绑定查询参数
+	var req schemas.BalanceSheetRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
@@ -1027,13 +3278,22 @@ func (h *FinanceHandler) GetBalanceSheet(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param start_date query string true "开始日期（YYYY-MM-DD）"
+// @Param end_date query string true "结束日期（YYYY-MM-DD）"
+// @Param comparison_start_date query string false "比较期间开始日期，默认上一等长期间"
+// @Param comparison_end_date query string false "比较期间结束日期"
 // @Success 200 {object} map[string]interface{} "成功"
-// @Router /api/finance/reports/income-statement [get]
+// @Router /api/finance/reports/profit [get]
 func (h *FinanceHandler) GetIncomeStatement(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 绑定查询参数
+	var req schemas.IncomeStatementRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
@@ -1060,13 +3320,22 @@ func (h *FinanceHandler) GetIncomeStatement(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param start_date query string true "开始日期（YYYY-MM-DD）"
+// @Param end_date query string true "结束日期（YYYY-MM-DD）"
+// @Param comparison_start_date query string false "比较期间开始日期，默认上一等长期间"
+// @Param comparison_end_date query string false "比较期间结束日期"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/finance/reports/cash-flow [get]
 func (h *FinanceHandler) GetCashFlowStatement(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 绑定查询参数
+	var req schemas.CashFlowStatementRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
@@ -1093,13 +3362,20 @@ func (h *FinanceHandler) GetCashFlowStatement(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param start_date query string true "开始日期（YYYY-MM-DD）"
+// @Param end_date query string true "结束日期（YYYY-MM-DD）"
 // @Success 200 {object} map[string]interface{} "成功"
-// @Router /api/finance/reports/trial-balance [get]
+// @Router /api/finance/reports/trial [get]
 func (h *FinanceHandler) GetTrialBalance(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 绑定查询参数
+	var req schemas.TrialBalanceRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
@@ -1120,19 +3396,151 @@ func (h *FinanceHandler) GetTrialBalance(c *gin.Context) {
 	})
 }
 
+// @Summary 获取总账
+// @Description 按科目汇总期初余额、本期借贷发生额和期末余额，并列示已过账分录
+// @Tags 财务-报表管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string true "开始日期（YYYY-MM-DD）"
+// @Param end_date query string true "结束日期（YYYY-MM-DD）"
+// @Param account_id query string false "科目ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/reports/general-ledger [get]
+func (h *FinanceHandler) GetGeneralLedger(c *gin.Context) {
+	// 绑定查询参数
+	var req schemas.GeneralLedgerRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	report, err := h.financeService.GetGeneralLedger(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to get general ledger: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    report,
+	})
+}
+
+// @Summary 获取增值税汇总表
+// @Description 按税码和税率汇总期间内销项税额和进项税额，计算应纳税额
+// @Tags 财务-报表管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string true "开始日期（YYYY-MM-DD）"
+// @Param end_date query string true "结束日期（YYYY-MM-DD）"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/reports/vat-summary [get]
+func (h *FinanceHandler) GetVATSummaryReport(c *gin.Context) {
+	// 绑定查询参数
+	var req schemas.VATReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	report, err := h.financeService.GetVATSummaryReport(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to get VAT summary report: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    report,
+	})
+}
+
+// @Summary 获取应收账龄表
+// @Description 按客户统计截至指定日期的应收账款账龄及未核销预收款，金额为本位币
+// @Tags 财务-报表管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param date query string false "报表日期（YYYY-MM-DD），默认当天"
+// @Param partner_id query string false "客户ID"
+// @Param buckets query string false "账龄分段天数上限，逗号分隔，如30,60,90,120"
+// @Param basis query string false "账龄基准（due_date, invoice_date），默认due_date"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/finance/reports/accounts-receivable [get]
+func (h *FinanceHandler) GetAccountsReceivableReport(c *gin.Context) {
+	// 绑定查询参数
+	var req schemas.AgingReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	report, err := h.financeService.GetAccountsReceivableReport(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to get accounts receivable report: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    report,
+	})
+}
+
 // @Summary 导出财务报表
 // @Description 导出财务相关的报表
 // @Tags 财务-报表管理
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param report_type query string true "报表类型"
+// @Param date query string false "报表日期（YYYY-MM-DD）"
+// @Param start_date query string false "开始日期（YYYY-MM-DD）"
+// @Param end_date query string false "结束日期（YYYY-MM-DD）"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/finance/reports/export [get]
 func (h *FinanceHandler) ExportFinancialReport(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 绑定查询参数
+	var req schemas.ExportFinanceReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/query"
 	"github.com/wu136995/ginx/internal/services"
 )
 
@@ -27,27 +29,27 @@ func NewHRHandler(hrService services.HRService) *HRHandler {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/employees [get]
 func (h *HRHandler) GetEmployeeList(c *gin.Context) {
 	// 解析查询参数
-	req := make(map[string]interface{})
-	if err := c.ShouldBindQuery(&req); err != nil {
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的查询参数"})
 		return
 	}
 
 	// 调用服务
-	employees, err := h.hrService.GetEmployeeList(req)
+	employees, err := h.hrService.GetEmployeeList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "获取员工列表失败"})
+		c.JSON(listErrorStatus(err), gin.H{"error": "获取员工列表失败"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data":  employees,
-		"total": len(employees),
-	})
+	c.JSON(http.StatusOK, gin.H{"data": employees})
 }
 
 // @Summary 获取员工详情
@@ -78,13 +80,13 @@ func (h *HRHandler) GetEmployeeDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param employee body map[string]interface{} true "员工信息"
+// @Param employee body schemas.EmployeeCreateRequest true "员工信息"
 // @Success 201 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/employees [post]
 func (h *HRHandler) CreateEmployee(c *gin.Context) {
-	var req map[string]interface{}
+	var req schemas.EmployeeCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据: " + err.Error()})
 		return
 	}
 
@@ -105,15 +107,15 @@ func (h *HRHandler) CreateEmployee(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "员工ID"
-// @Param employee body map[string]interface{} true "员工信息"
+// @Param employee body schemas.EmployeeUpdateRequest true "员工信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/employees/{id} [put]
 func (h *HRHandler) UpdateEmployee(c *gin.Context) {
 	id := c.Param("id")
 
-	var req map[string]interface{}
+	var req schemas.EmployeeUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据: " + err.Error()})
 		return
 	}
 
@@ -156,27 +158,27 @@ func (h *HRHandler) DeleteEmployee(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/departments [get]
 func (h *HRHandler) GetDepartmentList(c *gin.Context) {
 	// 解析查询参数
-	req := make(map[string]interface{})
-	if err := c.ShouldBindQuery(&req); err != nil {
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的查询参数"})
 		return
 	}
 
 	// 调用服务
-	departments, err := h.hrService.GetDepartmentList(req)
+	departments, err := h.hrService.GetDepartmentList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "获取部门列表失败"})
+		c.JSON(listErrorStatus(err), gin.H{"error": "获取部门列表失败"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data":  departments,
-		"total": len(departments),
-	})
+	c.JSON(http.StatusOK, gin.H{"data": departments})
 }
 
 // @Summary 获取部门详情
@@ -207,13 +209,13 @@ func (h *HRHandler) GetDepartmentDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param department body map[string]interface{} true "部门信息"
+// @Param department body schemas.DepartmentCreateRequest true "部门信息"
 // @Success 201 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/departments [post]
 func (h *HRHandler) CreateDepartment(c *gin.Context) {
-	var req map[string]interface{}
+	var req schemas.DepartmentCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据: " + err.Error()})
 		return
 	}
 
@@ -234,15 +236,15 @@ func (h *HRHandler) CreateDepartment(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "部门ID"
-// @Param department body map[string]interface{} true "部门信息"
+// @Param department body schemas.DepartmentUpdateRequest true "部门信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/departments/{id} [put]
 func (h *HRHandler) UpdateDepartment(c *gin.Context) {
 	id := c.Param("id")
 
-	var req map[string]interface{}
+	var req schemas.DepartmentUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据: " + err.Error()})
 		return
 	}
 
@@ -285,27 +287,27 @@ func (h *HRHandler) DeleteDepartment(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/positions [get]
 func (h *HRHandler) GetPositionList(c *gin.Context) {
 	// 解析查询参数
-	req := make(map[string]interface{})
-	if err := c.ShouldBindQuery(&req); err != nil {
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的查询参数"})
 		return
 	}
 
 	// 调用服务
-	positions, err := h.hrService.GetPositionList(req)
+	positions, err := h.hrService.GetPositionList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "获取职位列表失败"})
+		c.JSON(listErrorStatus(err), gin.H{"error": "获取职位列表失败"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data":  positions,
-		"total": len(positions),
-	})
+	c.JSON(http.StatusOK, gin.H{"data": positions})
 }
 
 // @Summary 获取职位详情
@@ -336,13 +338,13 @@ func (h *HRHandler) GetPositionDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param position body map[string]interface{} true "职位信息"
+// @Param position body schemas.PositionCreateRequest true "职位信息"
 // @Success 201 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/positions [post]
 func (h *HRHandler) CreatePosition(c *gin.Context) {
-	var req map[string]interface{}
+	var req schemas.PositionCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据: " + err.Error()})
 		return
 	}
 
@@ -363,15 +365,15 @@ func (h *HRHandler) CreatePosition(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "职位ID"
-// @Param position body map[string]interface{} true "职位信息"
+// @Param position body schemas.PositionUpdateRequest true "职位信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/positions/{id} [put]
 func (h *HRHandler) UpdatePosition(c *gin.Context) {
 	id := c.Param("id")
 
-	var req map[string]interface{}
+	var req schemas.PositionUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据: " + err.Error()})
 		return
 	}
 
@@ -414,27 +416,27 @@ func (h *HRHandler) DeletePosition(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/attendance [get]
 func (h *HRHandler) GetAttendanceList(c *gin.Context) {
 	// 解析查询参数
-	req := make(map[string]interface{})
-	if err := c.ShouldBindQuery(&req); err != nil {
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的查询参数"})
 		return
 	}
 
 	// 调用服务
-	attendances, err := h.hrService.GetAttendanceList(req)
+	attendances, err := h.hrService.GetAttendanceList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "获取考勤列表失败"})
+		c.JSON(listErrorStatus(err), gin.H{"error": "获取考勤列表失败"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data":  attendances,
-		"total": len(attendances),
-	})
+	c.JSON(http.StatusOK, gin.H{"data": attendances})
 }
 
 // @Summary 获取考勤详情
@@ -465,13 +467,13 @@ func (h *HRHandler) GetAttendanceDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param attendance body map[string]interface{} true "考勤记录信息"
+// @Param attendance body schemas.AttendanceCreateRequest true "考勤记录信息"
 // @Success 201 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/attendance [post]
 func (h *HRHandler) CreateAttendance(c *gin.Context) {
-	var req map[string]interface{}
+	var req schemas.AttendanceCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据: " + err.Error()})
 		return
 	}
 
@@ -492,15 +494,15 @@ func (h *HRHandler) CreateAttendance(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "考勤记录ID"
-// @Param attendance body map[string]interface{} true "考勤记录信息"
+// @Param attendance body schemas.AttendanceUpdateRequest true "考勤记录信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/attendance/{id} [put]
 func (h *HRHandler) UpdateAttendance(c *gin.Context) {
 	id := c.Param("id")
 
-	var req map[string]interface{}
+	var req schemas.AttendanceUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据: " + err.Error()})
 		return
 	}
 
@@ -543,27 +545,27 @@ func (h *HRHandler) DeleteAttendance(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/salary [get]
 func (h *HRHandler) GetSalaryList(c *gin.Context) {
 	// 解析查询参数
-	req := make(map[string]interface{})
-	if err := c.ShouldBindQuery(&req); err != nil {
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的查询参数"})
 		return
 	}
 
 	// 调用服务
-	salaries, err := h.hrService.GetSalaryList(req)
+	salaries, err := h.hrService.GetSalaryList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "获取薪资列表失败"})
+		c.JSON(listErrorStatus(err), gin.H{"error": "获取薪资列表失败"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data":  salaries,
-		"total": len(salaries),
-	})
+	c.JSON(http.StatusOK, gin.H{"data": salaries})
 }
 
 // @Summary 获取薪资详情
@@ -594,13 +596,13 @@ func (h *HRHandler) GetSalaryDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param salary body map[string]interface{} true "薪资记录信息"
+// @Param salary body schemas.SalaryCreateRequest true "薪资记录信息"
 // @Success 201 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/salary [post]
 func (h *HRHandler) CreateSalary(c *gin.Context) {
-	var req map[string]interface{}
+	var req schemas.SalaryCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据: " + err.Error()})
 		return
 	}
 
@@ -621,15 +623,15 @@ func (h *HRHandler) CreateSalary(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "薪资记录ID"
-// @Param salary body map[string]interface{} true "薪资记录信息"
+// @Param salary body schemas.SalaryUpdateRequest true "薪资记录信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/salary/{id} [put]
 func (h *HRHandler) UpdateSalary(c *gin.Context) {
 	id := c.Param("id")
 
-	var req map[string]interface{}
+	var req schemas.SalaryUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据: " + err.Error()})
 		return
 	}
 
@@ -672,27 +674,27 @@ func (h *HRHandler) DeleteSalary(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/training [get]
 func (h *HRHandler) GetTrainingList(c *gin.Context) {
 	// 解析查询参数
-	req := make(map[string]interface{})
-	if err := c.ShouldBindQuery(&req); err != nil {
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的查询参数"})
 		return
 	}
 
 	// 调用服务
-	trainings, err := h.hrService.GetTrainingList(req)
+	trainings, err := h.hrService.GetTrainingList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "获取培训列表失败"})
+		c.JSON(listErrorStatus(err), gin.H{"error": "获取培训列表失败"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data":  trainings,
-		"total": len(trainings),
-	})
+	c.JSON(http.StatusOK, gin.H{"data": trainings})
 }
 
 // @Summary 获取培训详情
@@ -723,13 +725,13 @@ func (h *HRHandler) GetTrainingDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param training body map[string]interface{} true "培训记录信息"
+// @Param training body schemas.TrainingCreateRequest true "培训记录信息"
 // @Success 201 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/training [post]
 func (h *HRHandler) CreateTraining(c *gin.Context) {
-	var req map[string]interface{}
+	var req schemas.TrainingCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据: " + err.Error()})
 		return
 	}
 
@@ -750,15 +752,15 @@ func (h *HRHandler) CreateTraining(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "培训记录ID"
-// @Param training body map[string]interface{} true "培训记录信息"
+// @Param training body schemas.TrainingUpdateRequest true "培训记录信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/training/{id} [put]
 func (h *HRHandler) UpdateTraining(c *gin.Context) {
 	id := c.Param("id")
 
-	var req map[string]interface{}
+	var req schemas.TrainingUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据: " + err.Error()})
 		return
 	}
 
@@ -801,27 +803,27 @@ func (h *HRHandler) DeleteTraining(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/recruitment [get]
 func (h *HRHandler) GetRecruitmentList(c *gin.Context) {
 	// 解析查询参数
-	req := make(map[string]interface{})
-	if err := c.ShouldBindQuery(&req); err != nil {
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的查询参数"})
 		return
 	}
 
 	// 调用服务
-	recruitments, err := h.hrService.GetRecruitmentList(req)
+	recruitments, err := h.hrService.GetRecruitmentList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "获取招聘列表失败"})
+		c.JSON(listErrorStatus(err), gin.H{"error": "获取招聘列表失败"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data":  recruitments,
-		"total": len(recruitments),
-	})
+	c.JSON(http.StatusOK, gin.H{"data": recruitments})
 }
 
 // @Summary 获取招聘详情
@@ -852,13 +854,13 @@ func (h *HRHandler) GetRecruitmentDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param recruitment body map[string]interface{} true "招聘记录信息"
+// @Param recruitment body schemas.RecruitmentCreateRequest true "招聘记录信息"
 // @Success 201 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/recruitment [post]
 func (h *HRHandler) CreateRecruitment(c *gin.Context) {
-	var req map[string]interface{}
+	var req schemas.RecruitmentCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据: " + err.Error()})
 		return
 	}
 
@@ -879,15 +881,15 @@ func (h *HRHandler) CreateRecruitment(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "招聘记录ID"
-// @Param recruitment body map[string]interface{} true "招聘记录信息"
+// @Param recruitment body schemas.RecruitmentUpdateRequest true "招聘记录信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/recruitment/{id} [put]
 func (h *HRHandler) UpdateRecruitment(c *gin.Context) {
 	id := c.Param("id")
 
-	var req map[string]interface{}
+	var req schemas.RecruitmentUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据: " + err.Error()})
 		return
 	}
 
@@ -930,27 +932,27 @@ func (h *HRHandler) DeleteRecruitment(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/performance [get]
 func (h *HRHandler) GetPerformanceList(c *gin.Context) {
 	// 解析查询参数
-	req := make(map[string]interface{})
-	if err := c.ShouldBindQuery(&req); err != nil {
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的查询参数"})
 		return
 	}
 
 	// 调用服务
-	performances, err := h.hrService.GetPerformanceList(req)
+	performances, err := h.hrService.GetPerformanceList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "获取绩效评估列表失败"})
+		c.JSON(listErrorStatus(err), gin.H{"error": "获取绩效评估列表失败"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data":  performances,
-		"total": len(performances),
-	})
+	c.JSON(http.StatusOK, gin.H{"data": performances})
 }
 
 // @Summary 获取绩效评估详情
@@ -981,13 +983,13 @@ func (h *HRHandler) GetPerformanceDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param performance body map[string]interface{} true "绩效评估记录信息"
+// @Param performance body schemas.PerformanceCreateRequest true "绩效评估记录信息"
 // @Success 201 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/performance [post]
 func (h *HRHandler) CreatePerformance(c *gin.Context) {
-	var req map[string]interface{}
+	var req schemas.PerformanceCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据: " + err.Error()})
 		return
 	}
 
@@ -1008,15 +1010,15 @@ func (h *HRHandler) CreatePerformance(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "绩效评估记录ID"
-// @Param performance body map[string]interface{} true "绩效评估记录信息"
+// @Param performance body schemas.PerformanceUpdateRequest true "绩效评估记录信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/v1/hr/performance/{id} [put]
 func (h *HRHandler) UpdatePerformance(c *gin.Context) {
 	id := c.Param("id")
 
-	var req map[string]interface{}
+	var req schemas.PerformanceUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求数据: " + err.Error()})
 		return
 	}
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/query"
 	"github.com/wu136995/ginx/internal/services"
 )
 
@@ -27,11 +28,15 @@ func NewInventoryHandler(inventoryService services.InventoryService) *InventoryH
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/inventory/warehouses [get]
 func (h *InventoryHandler) GetWarehouseList(c *gin.Context) {
-	var req schemas.GetWarehouseListRequest
-	if err := c.ShouldBindQuery(&req); err != nil {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
 			"message": "Invalid request parameters",
@@ -39,10 +44,11 @@ func (h *InventoryHandler) GetWarehouseList(c *gin.Context) {
 		})
 		return
 	}
-	warehouses, err := h.inventoryService.GetWarehouseList(req)
+	warehouses, err := h.inventoryService.GetWarehouseList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Internal Server Error",
 			"error":   err.Error(),
 		})
@@ -188,11 +194,15 @@ func (h *InventoryHandler) DeleteWarehouse(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/inventory/materials [get]
 func (h *InventoryHandler) GetMaterialList(c *gin.Context) {
-	var req schemas.GetItemListRequest
-	if err := c.ShouldBindQuery(&req); err != nil {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
 			"message": "Invalid request parameters",
@@ -200,10 +210,11 @@ func (h *InventoryHandler) GetMaterialList(c *gin.Context) {
 		})
 		return
 	}
-	materials, err := h.inventoryService.GetItemList(req)
+	materials, err := h.inventoryService.GetItemList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Internal Server Error",
 			"error":   err.Error(),
 		})
@@ -342,6 +353,82 @@ func (h *InventoryHandler) DeleteMaterial(c *gin.Context) {
 	})
 }
 
+// @Summary 获取物料成本层
+// @Description 根据物料ID获取成本层明细，先进先出物料返回入库成本层，其他计价方法按现存量返回
+// @Tags 库存-物料管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "物料ID"
+// @Param warehouseId query string false "仓库ID"
+// @Param locationId query string false "库位ID"
+// @Param includeConsumed query bool false "是否包含已消耗成本层"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/inventory/items/{id}/cost-layers [get]
+func (h *InventoryHandler) GetMaterialCostLayers(c *gin.Context) {
+	id := c.Param("id")
+	var req schemas.GetCostLayerListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
+	}
+	layers, err := h.inventoryService.GetItemCostLayers(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    layers,
+	})
+}
+
+// @Summary 物料成本重估
+// @Description 按新单位成本重估物料现存量，标准成本物料同时更新标准成本
+// @Tags 库存-物料管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "物料ID"
+// @Param revaluation body schemas.RevalueItemRequest true "重估信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/inventory/items/{id}/revalue [post]
+func (h *InventoryHandler) RevalueMaterial(c *gin.Context) {
+	id := c.Param("id")
+	var req schemas.RevalueItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
+	}
+	transaction, err := h.inventoryService.RevalueItem(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    transaction,
+	})
+}
+
 // 库存交易路由处理函数
 // @Summary 获取库存交易列表
 // @Description 获取所有库存交易的列表
@@ -349,11 +436,15 @@ func (h *InventoryHandler) DeleteMaterial(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/inventory/transactions [get]
 func (h *InventoryHandler) GetInventoryTransactionList(c *gin.Context) {
-	var req schemas.GetTransactionListRequest
-	if err := c.ShouldBindQuery(&req); err != nil {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
 			"message": "Invalid request parameters",
@@ -361,10 +452,11 @@ func (h *InventoryHandler) GetInventoryTransactionList(c *gin.Context) {
 		})
 		return
 	}
-	transactions, err := h.inventoryService.GetTransactionList(req)
+	transactions, err := h.inventoryService.GetTransactionList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Internal Server Error",
 			"error":   err.Error(),
 		})
@@ -483,11 +575,15 @@ func (h *InventoryHandler) DeleteInventoryTransaction(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/inventory/counts [get]
 func (h *InventoryHandler) GetInventoryCountList(c *gin.Context) {
-	var req schemas.GetCountListRequest
-	if err := c.ShouldBindQuery(&req); err != nil {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
 			"message": "Invalid request parameters",
@@ -495,10 +591,11 @@ func (h *InventoryHandler) GetInventoryCountList(c *gin.Context) {
 		})
 		return
 	}
-	counts, err := h.inventoryService.GetCountList(req)
+	counts, err := h.inventoryService.GetCountList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Internal Server Error",
 			"error":   err.Error(),
 		})
@@ -700,6 +797,439 @@ func (h *InventoryHandler) ApproveInventoryCount(c *gin.Context) {
 	})
 }
 
+// 库存预留及可承诺量路由处理函数
+// @Summary 获取库存预留列表
+// @Description 获取销售订单等来源的硬预留和软预留
+// @Tags 库存-预留管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/inventory/reservations [get]
+func (h *InventoryHandler) GetReservationList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
+	}
+	reservations, err := h.inventoryService.GetReservationList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    reservations,
+	})
+}
+
+// @Summary 获取可承诺量
+// @Description 按物料和仓库计算现存量减预留量加计划入库的可承诺量，并给出满足需求数量的最早承诺日期
+// @Tags 库存-预留管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param itemId query string false "物料ID，与产品ID二选一"
+// @Param productId query string false "销售产品ID"
+// @Param warehouseId query string false "仓库ID"
+// @Param quantity query number false "需求数量"
+// @Param date query string false "需求日期，默认当天"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/inventory/atp [get]
+func (h *InventoryHandler) GetAvailableToPromise(c *gin.Context) {
+	var req schemas.GetAvailableToPromiseRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
+	}
+	atp, err := h.inventoryService.GetAvailableToPromise(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    atp,
+	})
+}
+
+// 自动补货路由处理函数
+// @Summary 获取补货参数列表
+// @Description 获取物料在各仓库的再订货点、最小最大库存等补货参数
+// @Tags 库存-自动补货
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/inventory/replenishment/rules [get]
+func (h *InventoryHandler) GetReplenishmentRuleList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
+	}
+	rules, err := h.inventoryService.GetReplenishmentRuleList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    rules,
+	})
+}
+
+// @Summary 创建补货参数
+// @Description 为物料和仓库创建补货参数，补货方法为reorder_point或min_max
+// @Tags 库存-自动补货
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rule body schemas.CreateReplenishmentRuleRequest true "补货参数"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/inventory/replenishment/rules [post]
+func (h *InventoryHandler) CreateReplenishmentRule(c *gin.Context) {
+	var req schemas.CreateReplenishmentRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
+	}
+	rule, err := h.inventoryService.CreateReplenishmentRule(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    rule,
+	})
+}
+
+// @Summary 更新补货参数
+// @Description 根据ID更新补货参数
+// @Tags 库存-自动补货
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "补货参数ID"
+// @Param rule body schemas.UpdateReplenishmentRuleRequest true "补货参数"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/inventory/replenishment/rules/{id} [put]
+func (h *InventoryHandler) UpdateReplenishmentRule(c *gin.Context) {
+	id := c.Param("id")
+	var req schemas.UpdateReplenishmentRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
+	}
+	rule, err := h.inventoryService.UpdateReplenishmentRule(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    rule,
+	})
+}
+
+// @Summary 删除补货参数
+// @Description 根据ID删除补货参数
+// @Tags 库存-自动补货
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "补货参数ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/inventory/replenishment/rules/{id} [delete]
+func (h *InventoryHandler) DeleteReplenishmentRule(c *gin.Context) {
+	id := c.Param("id")
+	err := h.inventoryService.DeleteReplenishmentRule(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 执行补货
+// @Description 按有效补货参数计算预计可用量，低于触发水位时生成调拨申请和待提交的采购申请，并记录每条补货参数的计算说明；试运行只返回补货建议
+// @Tags 库存-自动补货
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param run body schemas.RunReplenishmentRequest true "补货范围"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/inventory/replenishment/runs [post]
+func (h *InventoryHandler) RunReplenishment(c *gin.Context) {
+	var req schemas.RunReplenishmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
+	}
+	run, err := h.inventoryService.RunReplenishment(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    run,
+	})
+}
+
+// @Summary 获取补货运行列表
+// @Description 获取手工和定时补货运行记录
+// @Tags 库存-自动补货
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/inventory/replenishment/runs [get]
+func (h *InventoryHandler) GetReplenishmentRunList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
+	}
+	runs, err := h.inventoryService.GetReplenishmentRunList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    runs,
+	})
+}
+
+// @Summary 获取补货运行详情
+// @Description 根据ID获取补货运行及每条补货参数的计算明细和说明
+// @Tags 库存-自动补货
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "补货运行ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/inventory/replenishment/runs/{id} [get]
+func (h *InventoryHandler) GetReplenishmentRunDetail(c *gin.Context) {
+	id := c.Param("id")
+	run, err := h.inventoryService.GetReplenishmentRunDetail(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    run,
+	})
+}
+
+// 调拨申请路由处理函数
+// @Summary 获取调拨申请列表
+// @Description 获取补货运行等来源生成的仓库调拨申请
+// @Tags 库存-调拨申请
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/inventory/transfer-requests [get]
+func (h *InventoryHandler) GetTransferRequestList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
+	}
+	requests, err := h.inventoryService.GetTransferRequestList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    requests,
+	})
+}
+
+// @Summary 执行调拨申请
+// @Description 按申请数量生成仓库调拨交易并完成调拨申请
+// @Tags 库存-调拨申请
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "调拨申请ID"
+// @Param request body schemas.CompleteTransferRequestRequest true "调拨信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/inventory/transfer-requests/{id}/complete [post]
+func (h *InventoryHandler) CompleteTransferRequest(c *gin.Context) {
+	id := c.Param("id")
+	var req schemas.CompleteTransferRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
+	}
+	request, err := h.inventoryService.CompleteTransferRequest(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    request,
+	})
+}
+
+// @Summary 取消调拨申请
+// @Description 取消待执行的调拨申请
+// @Tags 库存-调拨申请
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "调拨申请ID"
+// @Param request body schemas.CancelTransferRequestRequest true "取消原因"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/inventory/transfer-requests/{id}/cancel [post]
+func (h *InventoryHandler) CancelTransferRequest(c *gin.Context) {
+	id := c.Param("id")
+	var req schemas.CancelTransferRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
+	}
+	err := h.inventoryService.CancelTransferRequest(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
 // 库存报表路由处理函数
 // @Summary 获取库存状态报表
 // @Description 获取库存状态的报表
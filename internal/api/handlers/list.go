@@ -0,0 +1,16 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/wu136995/ginx/internal/query"
+)
+
+// listErrorStatus 列表查询参数不合法时返回400，其他错误返回500
+func listErrorStatus(err error) int {
+	if errors.Is(err, query.ErrInvalidQuery) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/query"
 	"github.com/wu136995/ginx/internal/services"
 )
 
@@ -26,22 +28,27 @@ func NewProductionHandler(productionService services.ProductionService) *Product
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/production/orders [get]
 func (h *ProductionHandler) GetProductionOrderList(c *gin.Context) {
-	// 实现逻辑
-	req := make(map[string]interface{})
-	// 从查询参数中获取过滤条件
-	if status := c.Query("status"); status != "" {
-		req["status"] = status
-	}
-	if priority := c.Query("priority"); priority != "" {
-		req["priority"] = priority
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
 	}
-	orders, err := h.productionService.GetProductionOrderList(req)
+	orders, err := h.productionService.GetProductionOrderList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Internal Server Error",
 			"error":   err.Error(),
 		})
@@ -88,12 +95,12 @@ func (h *ProductionHandler) GetProductionOrderDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param order body map[string]interface{} true "生产订单信息"
+// @Param order body schemas.CreateProductionOrderRequest true "生产订单信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/production/orders [post]
 func (h *ProductionHandler) CreateProductionOrder(c *gin.Context) {
 	// 实现逻辑
-	var req map[string]interface{}
+	var req schemas.CreateProductionOrderRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -125,13 +132,13 @@ func (h *ProductionHandler) CreateProductionOrder(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "生产订单ID"
-// @Param order body map[string]interface{} true "生产订单信息"
+// @Param order body schemas.UpdateProductionOrderRequest true "生产订单信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/production/orders/{id} [put]
 func (h *ProductionHandler) UpdateProductionOrder(c *gin.Context) {
 	// 实现逻辑
 	id := c.Param("id")
-	var req map[string]interface{}
+	var req schemas.UpdateProductionOrderRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -303,19 +310,27 @@ func (h *ProductionHandler) CloseProductionOrder(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/production/workorders [get]
 func (h *ProductionHandler) GetWorkOrderList(c *gin.Context) {
-	// 实现逻辑
-	req := make(map[string]interface{})
-	// 从查询参数中获取过滤条件
-	if status := c.Query("status"); status != "" {
-		req["status"] = status
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
 	}
-	workOrders, err := h.productionService.GetProductionTicketList(req)
+	workOrders, err := h.productionService.GetProductionTicketList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Internal Server Error",
 			"error":   err.Error(),
 		})
@@ -362,12 +377,12 @@ func (h *ProductionHandler) GetWorkOrderDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param workorder body map[string]interface{} true "生产工单信息"
+// @Param workorder body schemas.CreateProductionTicketRequest true "生产工单信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/production/workorders [post]
 func (h *ProductionHandler) CreateWorkOrder(c *gin.Context) {
 	// 实现逻辑
-	var req map[string]interface{}
+	var req schemas.CreateProductionTicketRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -399,13 +414,13 @@ func (h *ProductionHandler) CreateWorkOrder(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "生产工单ID"
-// @Param workorder body map[string]interface{} true "生产工单信息"
+// @Param workorder body schemas.UpdateProductionTicketRequest true "生产工单信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/production/workorders/{id} [put]
 func (h *ProductionHandler) UpdateWorkOrder(c *gin.Context) {
 	// 实现逻辑
 	id := c.Param("id")
-	var req map[string]interface{}
+	var req schemas.UpdateProductionTicketRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -521,15 +536,27 @@ func (h *ProductionHandler) CompleteWorkOrder(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/production/routings [get]
 func (h *ProductionHandler) GetRoutingList(c *gin.Context) {
-	// 实现逻辑
-	req := make(map[string]interface{})
-	routings, err := h.productionService.GetRoutingList(req)
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
+	}
+	routings, err := h.productionService.GetRoutingList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Internal Server Error",
 			"error":   err.Error(),
 		})
@@ -576,12 +603,12 @@ func (h *ProductionHandler) GetRoutingDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param routing body map[string]interface{} true "工艺路线信息"
+// @Param routing body schemas.CreateRoutingRequest true "工艺路线信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/production/routings [post]
 func (h *ProductionHandler) CreateRouting(c *gin.Context) {
 	// 实现逻辑
-	var req map[string]interface{}
+	var req schemas.CreateRoutingRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -613,13 +640,13 @@ func (h *ProductionHandler) CreateRouting(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "工艺路线ID"
-// @Param routing body map[string]interface{} true "工艺路线信息"
+// @Param routing body schemas.UpdateRoutingRequest true "工艺路线信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/production/routings/{id} [put]
 func (h *ProductionHandler) UpdateRouting(c *gin.Context) {
 	// 实现逻辑
 	id := c.Param("id")
-	var req map[string]interface{}
+	var req schemas.UpdateRoutingRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -679,15 +706,27 @@ func (h *ProductionHandler) DeleteRouting(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/production/workcenters [get]
 func (h *ProductionHandler) GetWorkCenterList(c *gin.Context) {
-	// 实现逻辑
-	req := make(map[string]interface{})
-	workCenters, err := h.productionService.GetWorkCenterList(req)
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
+	}
+	workCenters, err := h.productionService.GetWorkCenterList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Internal Server Error",
 			"error":   err.Error(),
 		})
@@ -734,12 +773,12 @@ func (h *ProductionHandler) GetWorkCenterDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param workcenter body map[string]interface{} true "工作中心信息"
+// @Param workcenter body schemas.CreateWorkCenterRequest true "工作中心信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/production/workcenters [post]
 func (h *ProductionHandler) CreateWorkCenter(c *gin.Context) {
 	// 实现逻辑
-	var req map[string]interface{}
+	var req schemas.CreateWorkCenterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -771,13 +810,13 @@ func (h *ProductionHandler) CreateWorkCenter(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "工作中心ID"
-// @Param workcenter body map[string]interface{} true "工作中心信息"
+// @Param workcenter body schemas.UpdateWorkCenterRequest true "工作中心信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/production/workcenters/{id} [put]
 func (h *ProductionHandler) UpdateWorkCenter(c *gin.Context) {
 	// 实现逻辑
 	id := c.Param("id")
-	var req map[string]interface{}
+	var req schemas.UpdateWorkCenterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -837,11 +876,20 @@ func (h *ProductionHandler) DeleteWorkCenter(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param item_code query string false "物料编码"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/production/mrp [get]
 func (h *ProductionHandler) GetMRPList(c *gin.Context) {
 	// 实现逻辑
-	req := make(map[string]interface{})
+	var req schemas.MRPResultRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Bad Request",
+			"error":   err.Error(),
+		})
+		return
+	}
 	mrpResults, err := h.productionService.GetMRPResults(req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -864,11 +912,20 @@ func (h *ProductionHandler) GetMRPList(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param mrp body schemas.MRPRunRequest true "运行参数"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/production/mrp/run [post]
 func (h *ProductionHandler) RunMRP(c *gin.Context) {
 	// 实现逻辑
-	req := make(map[string]interface{})
+	var req schemas.MRPRunRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Bad Request",
+			"error":   err.Error(),
+		})
+		return
+	}
 	mrpResult, err := h.productionService.RunMRP(req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -896,9 +953,8 @@ func (h *ProductionHandler) RunMRP(c *gin.Context) {
 // @Router /api/production/mrp/{id} [get]
 func (h *ProductionHandler) GetMRPDetail(c *gin.Context) {
 	// 实现逻辑
-	req := make(map[string]interface{})
-	req["id"] = c.Param("id")
-	mrpSuggestions, err := h.productionService.GetMRPSuggestions(req)
+	id := c.Param("id")
+	mrpSuggestions, err := h.productionService.GetMRPSuggestions(id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
@@ -921,11 +977,23 @@ func (h *ProductionHandler) GetMRPDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param start_date query string false "开始日期，格式：YYYY-MM-DD"
+// @Param end_date query string false "结束日期，格式：YYYY-MM-DD"
+// @Param product_name query string false "产品名称"
+// @Param work_center_id query string false "工作中心ID"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/production/reports/orders [get]
 func (h *ProductionHandler) GetProductionOrderReport(c *gin.Context) {
 	// 实现逻辑
-	req := make(map[string]interface{})
+	var req schemas.ProductionReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Bad Request",
+			"error":   err.Error(),
+		})
+		return
+	}
 	report, err := h.productionService.GetProductionPlanReport(req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -948,11 +1016,23 @@ func (h *ProductionHandler) GetProductionOrderReport(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param start_date query string false "开始日期，格式：YYYY-MM-DD"
+// @Param end_date query string false "结束日期，格式：YYYY-MM-DD"
+// @Param product_name query string false "产品名称"
+// @Param work_center_id query string false "工作中心ID"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/production/reports/workorders [get]
 func (h *ProductionHandler) GetWorkOrderReport(c *gin.Context) {
 	// 实现逻辑
-	req := make(map[string]interface{})
+	var req schemas.ProductionReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Bad Request",
+			"error":   err.Error(),
+		})
+		return
+	}
 	report, err := h.productionService.GetProductionExecutionReport(req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -975,11 +1055,23 @@ func (h *ProductionHandler) GetWorkOrderReport(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param start_date query string false "开始日期，格式：YYYY-MM-DD"
+// @Param end_date query string false "结束日期，格式：YYYY-MM-DD"
+// @Param product_name query string false "产品名称"
+// @Param work_center_id query string false "工作中心ID"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/production/reports/workcenter-load [get]
 func (h *ProductionHandler) GetWorkCenterLoadReport(c *gin.Context) {
 	// 实现逻辑
-	req := make(map[string]interface{})
+	var req schemas.ProductionReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Bad Request",
+			"error":   err.Error(),
+		})
+		return
+	}
 	report, err := h.productionService.GetWorkCenterLoadReport(req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -1002,11 +1094,23 @@ func (h *ProductionHandler) GetWorkCenterLoadReport(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param start_date query string false "开始日期，格式：YYYY-MM-DD"
+// @Param end_date query string false "结束日期，格式：YYYY-MM-DD"
+// @Param product_name query string false "产品名称"
+// @Param work_center_id query string false "工作中心ID"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/production/reports/cost [get]
 func (h *ProductionHandler) GetProductionCostReport(c *gin.Context) {
 	// 实现逻辑
-	req := make(map[string]interface{})
+	var req schemas.ProductionReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Bad Request",
+			"error":   err.Error(),
+		})
+		return
+	}
 	report, err := h.productionService.GetProductionCostReport(req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -1029,11 +1133,22 @@ func (h *ProductionHandler) GetProductionCostReport(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param report_type query string true "报表类型（production_plan, production_execution, work_center_load, material_consumption, production_cost）"
+// @Param start_date query string false "开始日期，格式：YYYY-MM-DD"
+// @Param end_date query string false "结束日期，格式：YYYY-MM-DD"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/production/reports/export [get]
 func (h *ProductionHandler) ExportProductionReport(c *gin.Context) {
 	// 实现逻辑
-	req := make(map[string]interface{})
+	var req schemas.ExportProductionReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Bad Request",
+			"error":   err.Error(),
+		})
+		return
+	}
 	reportData, err := h.productionService.ExportProductionReport(req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/query"
 	"github.com/wu136995/ginx/internal/services"
 )
 
@@ -26,20 +29,29 @@ func NewPurchaseHandler(purchaseService services.PurchaseService) *PurchaseHandl
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/suppliers [get]
 func (h *PurchaseHandler) GetSupplierList(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
-	suppliers, err := h.purchaseService.GetSupplierList(req)
+	suppliers, err := h.purchaseService.GetSupplierList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Failed to get supplier list: " + err.Error(),
 			"data":    nil,
 		})
@@ -90,12 +102,12 @@ func (h *PurchaseHandler) GetSupplierDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param supplier body map[string]interface{} true "供应商信息"
+// @Param supplier body schemas.SupplierCreateRequest true "供应商信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/suppliers [post]
 func (h *PurchaseHandler) CreateSupplier(c *gin.Context) {
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.SupplierCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -130,7 +142,7 @@ func (h *PurchaseHandler) CreateSupplier(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "供应商ID"
-// @Param supplier body map[string]interface{} true "供应商信息"
+// @Param supplier body schemas.SupplierUpdateRequest true "供应商信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/suppliers/{id} [put]
 func (h *PurchaseHandler) UpdateSupplier(c *gin.Context) {
@@ -138,7 +150,7 @@ func (h *PurchaseHandler) UpdateSupplier(c *gin.Context) {
 	id := c.Param("id")
 
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.SupplierUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -235,7 +247,7 @@ func (h *PurchaseHandler) GetSupplierContacts(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "供应商ID"
-// @Param contact body map[string]interface{} true "联系人信息"
+// @Param contact body schemas.SupplierContactCreateRequest true "联系人信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/suppliers/{id}/contacts [post]
 func (h *PurchaseHandler) AddSupplierContact(c *gin.Context) {
@@ -243,7 +255,7 @@ func (h *PurchaseHandler) AddSupplierContact(c *gin.Context) {
 	id := c.Param("id")
 
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.SupplierContactCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -271,6 +283,348 @@ func (h *PurchaseHandler) AddSupplierContact(c *gin.Context) {
 	})
 }
 
+// @Summary 评估供应商评分卡
+// @Description 按期间计算供应商准时交货率、质量退货率、价格偏差和交期准确率，按配置权重计算综合评分并保存为历史评分，同一供应商同一期间重复评估时覆盖
+// @Tags 采购-供应商管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.SupplierScorecardEvaluateRequest true "评估期间"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/purchase/suppliers/scorecards [post]
+func (h *PurchaseHandler) EvaluateSupplierScorecards(c *gin.Context) {
+	// 解析请求体
+	var req schemas.SupplierScorecardEvaluateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	scorecards, err := h.purchaseService.EvaluateSupplierScorecards(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to evaluate supplier scorecards: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    scorecards,
+	})
+}
+
+// @Summary 获取供应商历史评分
+// @Description 获取供应商已保存的历史评分卡，默认按评估期间截止日期倒序，用于查看评分趋势
+// @Tags 采购-供应商管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "供应商ID"
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/purchase/suppliers/{id}/scorecards [get]
+func (h *PurchaseHandler) GetSupplierScorecardHistory(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	scorecards, err := h.purchaseService.GetSupplierScorecardHistory(id, params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Failed to get supplier scorecard history: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    scorecards,
+	})
+}
+
+// 采购计划管理路由处理函数
+// @Summary 获取采购计划列表
+// @Description 获取所有采购计划的列表
+// @Tags 采购-采购计划
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/purchase/plans [get]
+func (h *PurchaseHandler) GetPurchasePlanList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	plans, err := h.purchaseService.GetPurchasePlanList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Failed to get purchase plan list: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    plans,
+	})
+}
+
+// @Summary 获取采购计划详情
+// @Description 根据ID获取采购计划详情
+// @Tags 采购-采购计划
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "采购计划ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/purchase/plans/{id} [get]
+func (h *PurchaseHandler) GetPurchasePlanDetail(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	plan, err := h.purchaseService.GetPurchasePlanDetail(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to get purchase plan detail: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    plan,
+	})
+}
+
+// @Summary 创建采购计划
+// @Description 创建新采购计划
+// @Tags 采购-采购计划
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param plan body schemas.PurchasePlanCreateRequest true "采购计划信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/purchase/plans [post]
+func (h *PurchaseHandler) CreatePurchasePlan(c *gin.Context) {
+	// 解析请求体
+	var req schemas.PurchasePlanCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	plan, err := h.purchaseService.CreatePurchasePlan(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to create purchase plan: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    plan,
+	})
+}
+
+// @Summary 按采购预测生成采购计划
+// @Description 按采购预测的各期建议采购量生成草稿采购计划
+// @Tags 采购-采购计划
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param plan body schemas.PurchaseForecastPlanRequest true "预测参数及计划信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/purchase/plans/forecast [post]
+func (h *PurchaseHandler) CreatePurchasePlanFromForecast(c *gin.Context) {
+	// 解析请求体
+	var req schemas.PurchaseForecastPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	plan, err := h.purchaseService.CreatePurchasePlanFromForecast(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to create purchase plan from forecast: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    plan,
+	})
+}
+
+// @Summary 更新采购计划
+// @Description 根据ID更新采购计划
+// @Tags 采购-采购计划
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "采购计划ID"
+// @Param plan body schemas.PurchasePlanUpdateRequest true "采购计划信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/purchase/plans/{id} [put]
+func (h *PurchaseHandler) UpdatePurchasePlan(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 解析请求体
+	var req schemas.PurchasePlanUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	plan, err := h.purchaseService.UpdatePurchasePlan(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to update purchase plan: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    plan,
+	})
+}
+
+// @Summary 删除采购计划
+// @Description 根据ID删除采购计划
+// @Tags 采购-采购计划
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "采购计划ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/purchase/plans/{id} [delete]
+func (h *PurchaseHandler) DeletePurchasePlan(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.purchaseService.DeletePurchasePlan(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to delete purchase plan: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 审批采购计划
+// @Description 审批采购计划
+// @Tags 采购-采购计划
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "采购计划ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/purchase/plans/{id}/approve [post]
+func (h *PurchaseHandler) ApprovePurchasePlan(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.purchaseService.ApprovePurchasePlan(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to approve purchase plan: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
 // 采购申请管理路由处理函数
 // @Summary 获取采购申请列表
 // @Description 获取所有采购申请的列表
@@ -278,20 +632,29 @@ func (h *PurchaseHandler) AddSupplierContact(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/requisitions [get]
 func (h *PurchaseHandler) GetRequisitionList(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
-	requisitions, err := h.purchaseService.GetRequisitionList(req)
+	requisitions, err := h.purchaseService.GetRequisitionList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Failed to get requisition list: " + err.Error(),
 			"data":    nil,
 		})
@@ -342,12 +705,12 @@ func (h *PurchaseHandler) GetRequisitionDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param requisition body map[string]interface{} true "采购申请信息"
+// @Param requisition body schemas.RequisitionCreateRequest true "采购申请信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/requisitions [post]
 func (h *PurchaseHandler) CreateRequisition(c *gin.Context) {
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.RequisitionCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -382,7 +745,7 @@ func (h *PurchaseHandler) CreateRequisition(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "采购申请ID"
-// @Param requisition body map[string]interface{} true "采购申请信息"
+// @Param requisition body schemas.RequisitionUpdateRequest true "采购申请信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/requisitions/{id} [put]
 func (h *PurchaseHandler) UpdateRequisition(c *gin.Context) {
@@ -390,7 +753,7 @@ func (h *PurchaseHandler) UpdateRequisition(c *gin.Context) {
 	id := c.Param("id")
 
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.RequisitionUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -549,20 +912,29 @@ func (h *PurchaseHandler) RejectRequisition(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/orders [get]
 func (h *PurchaseHandler) GetPurchaseOrderList(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
-	orders, err := h.purchaseService.GetPurchaseOrderList(req)
+	orders, err := h.purchaseService.GetPurchaseOrderList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Failed to get purchase order list: " + err.Error(),
 			"data":    nil,
 		})
@@ -613,12 +985,12 @@ func (h *PurchaseHandler) GetPurchaseOrderDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param order body map[string]interface{} true "采购订单信息"
+// @Param order body schemas.PurchaseOrderCreateRequest true "采购订单信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/orders [post]
 func (h *PurchaseHandler) CreatePurchaseOrder(c *gin.Context) {
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.PurchaseOrderCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -653,7 +1025,7 @@ func (h *PurchaseHandler) CreatePurchaseOrder(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "采购订单ID"
-// @Param order body map[string]interface{} true "采购订单信息"
+// @Param order body schemas.PurchaseOrderUpdateRequest true "采购订单信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/orders/{id} [put]
 func (h *PurchaseHandler) UpdatePurchaseOrder(c *gin.Context) {
@@ -661,7 +1033,7 @@ func (h *PurchaseHandler) UpdatePurchaseOrder(c *gin.Context) {
 	id := c.Param("id")
 
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.PurchaseOrderUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -844,6 +1216,45 @@ func (h *PurchaseHandler) ClosePurchaseOrder(c *gin.Context) {
 	})
 }
 
+// @Summary 由采购申请和计划生成采购订单
+// @Description 将已审批采购申请和采购计划未转订单的明细按供应商合并生成待审批采购订单，交货日期按供应商前置时间确定，付款条件取供应商默认值
+// @Tags 采购-采购订单
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.PurchaseOrderConversionRequest true "转单信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/purchase/orders/convert [post]
+func (h *PurchaseHandler) ConvertToPurchaseOrders(c *gin.Context) {
+	// 解析请求体
+	var req schemas.PurchaseOrderConversionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	orders, err := h.purchaseService.ConvertToPurchaseOrders(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to convert to purchase orders: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    orders,
+	})
+}
+
 // 采购收货管理路由处理函数
 // @Summary 获取采购收货列表
 // @Description 获取所有采购收货的列表
@@ -851,20 +1262,29 @@ func (h *PurchaseHandler) ClosePurchaseOrder(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/receipts [get]
 func (h *PurchaseHandler) GetPurchaseReceiptList(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
-	receipts, err := h.purchaseService.GetPurchaseReceiptList(req)
+	receipts, err := h.purchaseService.GetPurchaseReceiptList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Failed to get purchase receipt list: " + err.Error(),
 			"data":    nil,
 		})
@@ -915,12 +1335,12 @@ func (h *PurchaseHandler) GetPurchaseReceiptDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param receipt body map[string]interface{} true "采购收货信息"
+// @Param receipt body schemas.ReceiptCreateRequest true "采购收货信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/receipts [post]
 func (h *PurchaseHandler) CreatePurchaseReceipt(c *gin.Context) {
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.ReceiptCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -955,7 +1375,7 @@ func (h *PurchaseHandler) CreatePurchaseReceipt(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "采购收货ID"
-// @Param receipt body map[string]interface{} true "采购收货信息"
+// @Param receipt body schemas.ReceiptUpdateRequest true "采购收货信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/receipts/{id} [put]
 func (h *PurchaseHandler) UpdatePurchaseReceipt(c *gin.Context) {
@@ -963,7 +1383,7 @@ func (h *PurchaseHandler) UpdatePurchaseReceipt(c *gin.Context) {
 	id := c.Param("id")
 
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.ReceiptUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -1060,20 +1480,29 @@ func (h *PurchaseHandler) CompletePurchaseReceipt(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/invoices [get]
 func (h *PurchaseHandler) GetPurchaseInvoiceList(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
-	invoices, err := h.purchaseService.GetPurchaseInvoiceList(req)
+	invoices, err := h.purchaseService.GetPurchaseInvoiceList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Failed to get purchase invoice list: " + err.Error(),
 			"data":    nil,
 		})
@@ -1124,12 +1553,12 @@ func (h *PurchaseHandler) GetPurchaseInvoiceDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param invoice body map[string]interface{} true "采购发票信息"
+// @Param invoice body schemas.PurchaseInvoiceCreateRequest true "采购发票信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/invoices [post]
 func (h *PurchaseHandler) CreatePurchaseInvoice(c *gin.Context) {
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.PurchaseInvoiceCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -1164,7 +1593,7 @@ func (h *PurchaseHandler) CreatePurchaseInvoice(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "采购发票ID"
-// @Param invoice body map[string]interface{} true "采购发票信息"
+// @Param invoice body schemas.PurchaseInvoiceUpdateRequest true "采购发票信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/invoices/{id} [put]
 func (h *PurchaseHandler) UpdatePurchaseInvoice(c *gin.Context) {
@@ -1172,7 +1601,7 @@ func (h *PurchaseHandler) UpdatePurchaseInvoice(c *gin.Context) {
 	id := c.Param("id")
 
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.PurchaseInvoiceUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -1232,7 +1661,7 @@ func (h *PurchaseHandler) DeletePurchaseInvoice(c *gin.Context) {
 }
 
 // @Summary 验证采购发票
-// @Description 验证采购发票
+// @Description 验证采购发票，按订单单价和收货数量三单匹配，超出容差时发票冻结并返回409
 // @Tags 采购-采购发票
 // @Accept json
 // @Produce json
@@ -1246,6 +1675,14 @@ func (h *PurchaseHandler) VerifyPurchaseInvoice(c *gin.Context) {
 
 	// 调用service方法
 	err := h.purchaseService.VerifyPurchaseInvoice(id)
+	if errors.Is(err, services.ErrInvoiceBlocked) {
+		c.JSON(http.StatusConflict, gin.H{
+			"code":    409,
+			"message": "Invoice Blocked",
+			"error":   err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
@@ -1262,21 +1699,78 @@ func (h *PurchaseHandler) VerifyPurchaseInvoice(c *gin.Context) {
 	})
 }
 
+// @Summary 放行匹配冻结的采购发票
+// @Description 授权人员放行三单匹配冻结的采购发票，放行后发票为已审核状态并生成凭证
+// @Tags 采购-采购发票
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "采购发票ID"
+// @Param request body schemas.ReleaseInvoiceBlockRequest true "放行信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/purchase/invoices/{id}/release-block [post]
+func (h *PurchaseHandler) ReleaseInvoiceBlock(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 绑定请求参数
+	var req schemas.ReleaseInvoiceBlockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	err := h.purchaseService.ReleaseInvoiceBlock(id, c.GetString("userID"), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to release purchase invoice block: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
 // @Summary 支付采购发票
-// @Description 支付采购发票
+// @Description 为已审核的采购发票生成并处理付款单，未指定金额时付清余额并扣除可享受的提前付款折扣
 // @Tags 采购-采购发票
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "采购发票ID"
+// @Param request body schemas.PayPurchaseInvoiceRequest false "付款信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/invoices/{id}/pay [post]
 func (h *PurchaseHandler) PayPurchaseInvoice(c *gin.Context) {
 	// 获取路径参数
 	id := c.Param("id")
 
+	// 解析请求体，允许为空
+	var req schemas.PayPurchaseInvoiceRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"code":    400,
+				"message": "Invalid request body: " + err.Error(),
+				"data":    nil,
+			})
+			return
+		}
+	}
+
 	// 调用service方法
-	err := h.purchaseService.PayPurchaseInvoice(id)
+	err := h.purchaseService.PayPurchaseInvoice(id, req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
@@ -1300,20 +1794,29 @@ func (h *PurchaseHandler) PayPurchaseInvoice(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/returns [get]
 func (h *PurchaseHandler) GetPurchaseReturnList(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
-	returns, err := h.purchaseService.GetPurchaseReturnList(req)
+	returns, err := h.purchaseService.GetPurchaseReturnList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Failed to get purchase return list: " + err.Error(),
 			"data":    nil,
 		})
@@ -1364,12 +1867,12 @@ func (h *PurchaseHandler) GetPurchaseReturnDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param return body map[string]interface{} true "采购退货信息"
+// @Param return body schemas.PurchaseReturnCreateRequest true "采购退货信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/returns [post]
 func (h *PurchaseHandler) CreatePurchaseReturn(c *gin.Context) {
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.PurchaseReturnCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -1404,7 +1907,7 @@ func (h *PurchaseHandler) CreatePurchaseReturn(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "采购退货ID"
-// @Param return body map[string]interface{} true "采购退货信息"
+// @Param return body schemas.PurchaseReturnUpdateRequest true "采购退货信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/returns/{id} [put]
 func (h *PurchaseHandler) UpdatePurchaseReturn(c *gin.Context) {
@@ -1412,7 +1915,7 @@ func (h *PurchaseHandler) UpdatePurchaseReturn(c *gin.Context) {
 	id := c.Param("id")
 
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.PurchaseReturnUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -1509,13 +2012,22 @@ func (h *PurchaseHandler) CompletePurchaseReturn(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param start_date query string false "开始日期（YYYY-MM-DD）"
+// @Param end_date query string false "结束日期（YYYY-MM-DD）"
+// @Param vendor_id query string false "供应商ID"
+// @Param item_id query string false "物料ID"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/reports/summary [get]
 func (h *PurchaseHandler) GetPurchaseSummaryReport(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 绑定查询参数
+	var req schemas.PurchaseReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
@@ -1542,13 +2054,22 @@ func (h *PurchaseHandler) GetPurchaseSummaryReport(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param start_date query string false "开始日期（YYYY-MM-DD）"
+// @Param end_date query string false "结束日期（YYYY-MM-DD）"
+// @Param vendor_id query string false "供应商ID"
+// @Param item_id query string false "物料ID"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/reports/detail [get]
 func (h *PurchaseHandler) GetPurchaseDetailReport(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 绑定查询参数
+	var req schemas.PurchaseReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
@@ -1570,18 +2091,27 @@ func (h *PurchaseHandler) GetPurchaseDetailReport(c *gin.Context) {
 }
 
 // @Summary 获取供应商分析报表
-// @Description 获取供应商分析的报表
+// @Description 按期间实时计算供应商评分卡并按综合评分排名，未指定期间时取最近三个月，附上一次保存的评分用于趋势比较
 // @Tags 采购-报表管理
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param start_date query string false "开始日期（YYYY-MM-DD）"
+// @Param end_date query string false "结束日期（YYYY-MM-DD）"
+// @Param vendor_id query string false "供应商ID"
+// @Param item_id query string false "物料ID"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/reports/supplier-analysis [get]
 func (h *PurchaseHandler) GetSupplierAnalysisReport(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 绑定查询参数
+	var req schemas.PurchaseReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
@@ -1603,18 +2133,28 @@ func (h *PurchaseHandler) GetSupplierAnalysisReport(c *gin.Context) {
 }
 
 // @Summary 获取价格分析报表
-// @Description 获取价格分析的报表
+// @Description 按采购价格历史统计物料的最低、最高、平均和最后价格，按月价格走势、供应商价格比较以及相对目标价的节约或超支金额
 // @Tags 采购-报表管理
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param start_date query string false "开始日期（YYYY-MM-DD），默认截止日期前十二个月"
+// @Param end_date query string false "结束日期（YYYY-MM-DD），默认当天"
+// @Param vendor_id query string false "供应商ID"
+// @Param item_id query string false "物料ID"
+// @Param source_type query string false "价格来源（order, invoice），默认全部"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/reports/price-analysis [get]
 func (h *PurchaseHandler) GetPriceAnalysisReport(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 绑定查询参数
+	var req schemas.PurchaseReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
@@ -1635,19 +2175,190 @@ func (h *PurchaseHandler) GetPriceAnalysisReport(c *gin.Context) {
 	})
 }
 
+// @Summary 获取采购价格历史
+// @Description 获取采购订单审批和采购发票审核时记录的物料采购价格
+// @Tags 采购-报表管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/purchase/price-history [get]
+func (h *PurchaseHandler) GetPriceHistoryList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	histories, err := h.purchaseService.GetPriceHistoryList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Failed to get price history: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    histories,
+	})
+}
+
+// @Summary 获取采购目标价列表
+// @Description 获取物料的采购目标价
+// @Tags 采购-报表管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/purchase/target-prices [get]
+func (h *PurchaseHandler) GetTargetPriceList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	targets, err := h.purchaseService.GetTargetPriceList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Failed to get target prices: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    targets,
+	})
+}
+
+// @Summary 设置采购目标价
+// @Description 设置物料的本位币采购目标价，已存在时更新
+// @Tags 采购-报表管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param item_id path string true "物料ID"
+// @Param target body schemas.TargetPriceRequest true "目标价"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/purchase/target-prices/{item_id} [put]
+func (h *PurchaseHandler) SetTargetPrice(c *gin.Context) {
+	// 获取路径参数
+	itemID := c.Param("item_id")
+
+	// 解析请求体
+	var req schemas.TargetPriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	target, err := h.purchaseService.SetTargetPrice(itemID, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to set target price: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    target,
+	})
+}
+
+// @Summary 删除采购目标价
+// @Description 删除物料的采购目标价
+// @Tags 采购-报表管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param item_id path string true "物料ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/purchase/target-prices/{item_id} [delete]
+func (h *PurchaseHandler) DeleteTargetPrice(c *gin.Context) {
+	// 获取路径参数
+	itemID := c.Param("item_id")
+
+	// 调用service方法
+	err := h.purchaseService.DeleteTargetPrice(itemID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to delete target price: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
 // @Summary 获取采购预测报表
-// @Description 获取采购预测的报表
+// @Description 按历史消耗和未交付销售订单预测各期需求，扣减可用库存和未关闭采购订单后给出各期建议采购量
 // @Tags 采购-报表管理
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param item_id query string false "物料ID"
+// @Param vendor_id query string false "供应商ID，只预测最近向该供应商采购过的物料"
+// @Param method query string false "预测方法（moving_average、exponential_smoothing、seasonal），默认moving_average"
+// @Param bucket query string false "分期（week、month），默认month"
+// @Param start_date query string false "预测起始日期（YYYY-MM-DD），默认当天"
+// @Param periods query int false "预测期数，默认3"
+// @Param history_periods query int false "历史期数，指数平滑默认12，其余默认3"
+// @Param alpha query number false "指数平滑系数，默认0.3"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/reports/forecast [get]
 func (h *PurchaseHandler) GetPurchaseForecastReport(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 绑定查询参数
+	var req schemas.PurchaseForecastRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
@@ -1674,13 +2385,23 @@ func (h *PurchaseHandler) GetPurchaseForecastReport(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param report_type query string true "报表类型"
+// @Param start_date query string false "开始日期（YYYY-MM-DD）"
+// @Param end_date query string false "结束日期（YYYY-MM-DD）"
+// @Param vendor_id query string false "供应商ID"
+// @Param item_id query string false "物料ID"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/purchase/reports/export [get]
 func (h *PurchaseHandler) ExportPurchaseReport(c *gin.Context) {
-	// 从查询参数获取过滤条件
-	req := make(map[string]interface{})
-	for k, v := range c.Request.URL.Query() {
-		req[k] = v[0]
+	// 绑定查询参数
+	var req schemas.ExportPurchaseReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
 	}
 
 	// 调用service方法
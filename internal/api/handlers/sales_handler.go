@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/query"
 	"github.com/wu136995/ginx/internal/services"
 )
 
@@ -26,22 +29,27 @@ func NewSalesHandler(salesService services.SalesService) *SalesHandler {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/sales/customers [get]
 func (h *SalesHandler) GetSalesCustomerList(c *gin.Context) {
-	// 实现逻辑
-	req := make(map[string]interface{})
-	// 从查询参数中获取过滤条件
-	if name := c.Query("name"); name != "" {
-		req["name"] = name
-	}
-	if status := c.Query("status"); status != "" {
-		req["status"] = status
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
 	}
-	customers, err := h.salesService.GetCustomerList(req)
+	customers, err := h.salesService.GetCustomerList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Internal Server Error",
 			"error":   err.Error(),
 		})
@@ -88,12 +96,12 @@ func (h *SalesHandler) GetSalesCustomerDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param customer body map[string]interface{} true "客户信息"
+// @Param customer body schemas.CreateCustomerRequest true "客户信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/sales/customers [post]
 func (h *SalesHandler) CreateSalesCustomer(c *gin.Context) {
 	// 实现逻辑
-	var req map[string]interface{}
+	var req schemas.CreateCustomerRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -125,13 +133,13 @@ func (h *SalesHandler) CreateSalesCustomer(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "客户ID"
-// @Param customer body map[string]interface{} true "客户信息"
+// @Param customer body schemas.UpdateCustomerRequest true "客户信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/sales/customers/{id} [put]
 func (h *SalesHandler) UpdateSalesCustomer(c *gin.Context) {
 	// 实现逻辑
 	id := c.Param("id")
-	var req map[string]interface{}
+	var req schemas.UpdateCustomerRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -212,6 +220,73 @@ func (h *SalesHandler) CustomerCreditEvaluate(c *gin.Context) {
 	})
 }
 
+// @Summary 批量客户信用评估
+// @Description 评估所有启用客户的信用并保存评估记录，供定时任务调用
+// @Tags 销售-客户管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/customers/credit-evaluations [post]
+func (h *SalesHandler) EvaluateCustomerCredits(c *gin.Context) {
+	// 实现逻辑
+	results, err := h.salesService.EvaluateCustomerCredits()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    results,
+	})
+}
+
+// @Summary 获取客户信用评估历史
+// @Description 获取客户历次信用评估记录
+// @Tags 销售-客户管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "客户ID"
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/customers/{id}/credit-evaluations [get]
+func (h *SalesHandler) GetCustomerCreditHistory(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
+	}
+	id := c.Param("id")
+	evaluations, err := h.salesService.GetCustomerCreditHistory(id, params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    evaluations,
+	})
+}
+
 // 销售报价管理路由处理函数
 // @Summary 获取报价单列表
 // @Description 获取所有报价单的列表
@@ -219,22 +294,27 @@ func (h *SalesHandler) CustomerCreditEvaluate(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/sales/quotations [get]
 func (h *SalesHandler) GetQuotationList(c *gin.Context) {
-	// 实现逻辑
-	req := make(map[string]interface{})
-	// 从查询参数中获取过滤条件
-	if customerName := c.Query("customer_name"); customerName != "" {
-		req["customer_name"] = customerName
-	}
-	if status := c.Query("status"); status != "" {
-		req["status"] = status
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
 	}
-	quotations, err := h.salesService.GetQuotationList(req)
+	quotations, err := h.salesService.GetQuotationList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Internal Server Error",
 			"error":   err.Error(),
 		})
@@ -281,12 +361,12 @@ func (h *SalesHandler) GetQuotationDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param quotation body map[string]interface{} true "报价单信息"
+// @Param quotation body schemas.CreateQuotationRequest true "报价单信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/sales/quotations [post]
 func (h *SalesHandler) CreateQuotation(c *gin.Context) {
 	// 实现逻辑
-	var req map[string]interface{}
+	var req schemas.CreateQuotationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -318,13 +398,13 @@ func (h *SalesHandler) CreateQuotation(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "报价单ID"
-// @Param quotation body map[string]interface{} true "报价单信息"
+// @Param quotation body schemas.UpdateQuotationRequest true "报价单信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/sales/quotations/{id} [put]
 func (h *SalesHandler) UpdateQuotation(c *gin.Context) {
 	// 实现逻辑
 	id := c.Param("id")
-	var req map[string]interface{}
+	var req schemas.UpdateQuotationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -440,22 +520,27 @@ func (h *SalesHandler) GenerateOrderFromQuotation(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/sales/orders [get]
 func (h *SalesHandler) GetOrderList(c *gin.Context) {
-	// 实现逻辑
-	req := make(map[string]interface{})
-	// 从查询参数中获取过滤条件
-	if customerName := c.Query("customer_name"); customerName != "" {
-		req["customer_name"] = customerName
-	}
-	if status := c.Query("status"); status != "" {
-		req["status"] = status
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
 	}
-	orders, err := h.salesService.GetOrderList(req)
+	orders, err := h.salesService.GetOrderList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Internal Server Error",
 			"error":   err.Error(),
 		})
@@ -502,12 +587,12 @@ func (h *SalesHandler) GetOrderDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param order body map[string]interface{} true "订单信息"
+// @Param order body schemas.CreateOrderRequest true "订单信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/sales/orders [post]
 func (h *SalesHandler) CreateOrder(c *gin.Context) {
 	// 实现逻辑
-	var req map[string]interface{}
+	var req schemas.CreateOrderRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -539,13 +624,13 @@ func (h *SalesHandler) CreateOrder(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "订单ID"
-// @Param order body map[string]interface{} true "订单信息"
+// @Param order body schemas.UpdateOrderRequest true "订单信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/sales/orders/{id} [put]
 func (h *SalesHandler) UpdateOrder(c *gin.Context) {
 	// 实现逻辑
 	id := c.Param("id")
-	var req map[string]interface{}
+	var req schemas.UpdateOrderRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -610,7 +695,53 @@ func (h *SalesHandler) DeleteOrder(c *gin.Context) {
 func (h *SalesHandler) ApproveOrder(c *gin.Context) {
 	// 实现逻辑
 	id := c.Param("id")
-	err := h.salesService.ApproveOrder(id)
+	err := h.salesService.ApproveOrder(id, c.GetString("userID"))
+	if errors.Is(err, services.ErrCreditHold) {
+		c.JSON(http.StatusConflict, gin.H{
+			"code":    409,
+			"message": "Credit Hold",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 放行信用冻结订单
+// @Description 授权人员放行信用冻结的销售订单，放行后订单为已审批状态
+// @Tags 销售-订单管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "订单ID"
+// @Param request body schemas.ReleaseCreditHoldRequest true "放行信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/orders/{id}/release-credit-hold [post]
+func (h *SalesHandler) ReleaseCreditHold(c *gin.Context) {
+	// 实现逻辑
+	id := c.Param("id")
+	var req schemas.ReleaseCreditHoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Bad Request",
+			"error":   err.Error(),
+		})
+		return
+	}
+	err := h.salesService.ReleaseCreditHold(id, c.GetString("userID"), req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
@@ -654,6 +785,33 @@ func (h *SalesHandler) CancelOrder(c *gin.Context) {
 	})
 }
 
+// @Summary 重新预留库存
+// @Description 释放订单的有效预留并按当前可用量重新分配，到货后可将软预留转为硬预留
+// @Tags 销售-订单管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "订单ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/orders/{id}/reserve [post]
+func (h *SalesHandler) ReserveOrder(c *gin.Context) {
+	id := c.Param("id")
+	err := h.salesService.ReserveOrder(id, c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
 // @Summary 从订单生成发货单
 // @Description 根据订单ID生成发货单
 // @Tags 销售-订单管理
@@ -682,6 +840,34 @@ func (h *SalesHandler) GenerateDeliveryFromOrder(c *gin.Context) {
 	})
 }
 
+// @Summary 从订单生成发票
+// @Description 根据订单ID按已发货未开票数量生成发票
+// @Tags 销售-订单管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "订单ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/orders/{id}/generate-invoice [post]
+func (h *SalesHandler) GenerateInvoiceFromOrder(c *gin.Context) {
+	// 实现逻辑
+	id := c.Param("id")
+	invoice, err := h.salesService.GenerateInvoiceFromOrder(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    invoice,
+	})
+}
+
 // 销售发货管理路由处理函数
 // @Summary 获取发货单列表
 // @Description 获取所有发货单的列表
@@ -689,22 +875,27 @@ func (h *SalesHandler) GenerateDeliveryFromOrder(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/sales/deliveries [get]
 func (h *SalesHandler) GetDeliveryList(c *gin.Context) {
-	// 实现逻辑
-	req := make(map[string]interface{})
-	// 从查询参数中获取过滤条件
-	if orderNo := c.Query("order_no"); orderNo != "" {
-		req["order_no"] = orderNo
-	}
-	if status := c.Query("status"); status != "" {
-		req["status"] = status
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
 	}
-	deliveries, err := h.salesService.GetDeliveryList(req)
+	deliveries, err := h.salesService.GetDeliveryList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Internal Server Error",
 			"error":   err.Error(),
 		})
@@ -751,12 +942,12 @@ func (h *SalesHandler) GetDeliveryDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param delivery body map[string]interface{} true "发货单信息"
+// @Param delivery body schemas.CreateDeliveryRequest true "发货单信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/sales/deliveries [post]
 func (h *SalesHandler) CreateDelivery(c *gin.Context) {
 	// 实现逻辑
-	var req map[string]interface{}
+	var req schemas.CreateDeliveryRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -788,13 +979,13 @@ func (h *SalesHandler) CreateDelivery(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "发货单ID"
-// @Param delivery body map[string]interface{} true "发货单信息"
+// @Param delivery body schemas.UpdateDeliveryRequest true "发货单信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/sales/deliveries/{id} [put]
 func (h *SalesHandler) UpdateDelivery(c *gin.Context) {
 	// 实现逻辑
 	id := c.Param("id")
-	var req map[string]interface{}
+	var req schemas.UpdateDeliveryRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -847,6 +1038,34 @@ func (h *SalesHandler) DeleteDelivery(c *gin.Context) {
 	})
 }
 
+// @Summary 发货出库
+// @Description 确认发货单出库并扣减库存
+// @Tags 销售-发货管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "发货单ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/deliveries/{id}/ship [post]
+func (h *SalesHandler) ShipDelivery(c *gin.Context) {
+	// 实现逻辑
+	id := c.Param("id")
+	err := h.salesService.ShipDelivery(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
 // 销售发票管理路由处理函数
 // @Summary 获取发票列表
 // @Description 获取所有销售发票的列表
@@ -854,22 +1073,27 @@ func (h *SalesHandler) DeleteDelivery(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/sales/invoices [get]
 func (h *SalesHandler) GetInvoiceList(c *gin.Context) {
-	// 实现逻辑
-	req := make(map[string]interface{})
-	// 从查询参数中获取过滤条件
-	if customerName := c.Query("customer_name"); customerName != "" {
-		req["customer_name"] = customerName
-	}
-	if status := c.Query("status"); status != "" {
-		req["status"] = status
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
 	}
-	invoices, err := h.salesService.GetInvoiceList(req)
+	invoices, err := h.salesService.GetInvoiceList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Internal Server Error",
 			"error":   err.Error(),
 		})
@@ -916,12 +1140,12 @@ func (h *SalesHandler) GetInvoiceDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param invoice body map[string]interface{} true "发票信息"
+// @Param invoice body schemas.CreateInvoiceRequest true "发票信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/sales/invoices [post]
 func (h *SalesHandler) CreateInvoice(c *gin.Context) {
 	// 实现逻辑
-	var req map[string]interface{}
+	var req schemas.CreateInvoiceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -953,13 +1177,13 @@ func (h *SalesHandler) CreateInvoice(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "发票ID"
-// @Param invoice body map[string]interface{} true "发票信息"
+// @Param invoice body schemas.UpdateInvoiceRequest true "发票信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/sales/invoices/{id} [put]
 func (h *SalesHandler) UpdateInvoice(c *gin.Context) {
 	// 实现逻辑
 	id := c.Param("id")
-	var req map[string]interface{}
+	var req schemas.UpdateInvoiceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -1013,7 +1237,7 @@ func (h *SalesHandler) DeleteInvoice(c *gin.Context) {
 }
 
 // @Summary 接收发票付款
-// @Description 接收销售发票的付款
+// @Description 按发票登记客户收款并立即核销，超过发票余额的部分作为客户预收款
 // @Tags 销售-发票管理
 // @Accept json
 // @Produce json
@@ -1024,7 +1248,7 @@ func (h *SalesHandler) DeleteInvoice(c *gin.Context) {
 func (h *SalesHandler) ReceiveInvoicePayment(c *gin.Context) {
 	// 实现逻辑
 	id := c.Param("id")
-	var req map[string]interface{}
+	var req schemas.ReceiveInvoicePaymentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -1056,22 +1280,27 @@ func (h *SalesHandler) ReceiveInvoicePayment(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/sales/returns [get]
 func (h *SalesHandler) GetReturnList(c *gin.Context) {
-	// 实现逻辑
-	req := make(map[string]interface{})
-	// 从查询参数中获取过滤条件
-	if customerName := c.Query("customer_name"); customerName != "" {
-		req["customer_name"] = customerName
-	}
-	if status := c.Query("status"); status != "" {
-		req["status"] = status
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
 	}
-	returns, err := h.salesService.GetReturnList(req)
+	returns, err := h.salesService.GetReturnList(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
 			"message": "Internal Server Error",
 			"error":   err.Error(),
 		})
@@ -1118,12 +1347,12 @@ func (h *SalesHandler) GetReturnDetail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param return body map[string]interface{} true "退货单信息"
+// @Param return body schemas.CreateReturnRequest true "退货单信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/sales/returns [post]
 func (h *SalesHandler) CreateReturn(c *gin.Context) {
 	// 实现逻辑
-	var req map[string]interface{}
+	var req schemas.CreateReturnRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -1155,13 +1384,13 @@ func (h *SalesHandler) CreateReturn(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "退货单ID"
-// @Param return body map[string]interface{} true "退货单信息"
+// @Param return body schemas.UpdateReturnRequest true "退货单信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/sales/returns/{id} [put]
 func (h *SalesHandler) UpdateReturn(c *gin.Context) {
 	// 实现逻辑
 	id := c.Param("id")
-	var req map[string]interface{}
+	var req schemas.UpdateReturnRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -1214,26 +1443,19 @@ func (h *SalesHandler) DeleteReturn(c *gin.Context) {
 	})
 }
 
-// 销售报表管理路由处理函数
-// @Summary 获取订单执行报表
-// @Description 获取订单执行情况的报表
-// @Tags 销售-报表管理
+// @Summary 审核退货单
+// @Description 审核销售退货授权，按订单已发未退数量重新校验退货数量
+// @Tags 销售-退货管理
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param id path string true "退货单ID"
 // @Success 200 {object} map[string]interface{} "成功"
-// @Router /api/sales/reports/order-execution [get]
-func (h *SalesHandler) GetOrderExecutionReport(c *gin.Context) {
+// @Router /api/sales/returns/{id}/approve [post]
+func (h *SalesHandler) ApproveReturn(c *gin.Context) {
 	// 实现逻辑
-	req := make(map[string]interface{})
-	// 从查询参数中获取过滤条件
-	if startDate := c.Query("start_date"); startDate != "" {
-		req["start_date"] = startDate
-	}
-	if endDate := c.Query("end_date"); endDate != "" {
-		req["end_date"] = endDate
-	}
-	report, err := h.salesService.GetOrderExecutionReport(req)
+	id := c.Param("id")
+	err := h.salesService.ApproveReturn(id, c.GetString("userID"))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
@@ -1245,29 +1467,23 @@ func (h *SalesHandler) GetOrderExecutionReport(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"code":    200,
 		"message": "success",
-		"data":    report,
+		"data":    nil,
 	})
 }
 
-// @Summary 获取客户分析报表
-// @Description 获取客户分析的报表
-// @Tags 销售-报表管理
+// @Summary 取消退货单
+// @Description 取消尚未收货的销售退货授权
+// @Tags 销售-退货管理
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param id path string true "退货单ID"
 // @Success 200 {object} map[string]interface{} "成功"
-// @Router /api/sales/reports/customer-analysis [get]
-func (h *SalesHandler) GetSalesCustomerAnalysisReport(c *gin.Context) {
+// @Router /api/sales/returns/{id}/cancel [post]
+func (h *SalesHandler) CancelReturn(c *gin.Context) {
 	// 实现逻辑
-	req := make(map[string]interface{})
-	// 从查询参数中获取过滤条件
-	if startDate := c.Query("start_date"); startDate != "" {
-		req["start_date"] = startDate
-	}
-	if endDate := c.Query("end_date"); endDate != "" {
-		req["end_date"] = endDate
-	}
-	report, err := h.salesService.GetCustomerAnalysisReport(req)
+	id := c.Param("id")
+	err := h.salesService.CancelReturn(id, c.GetString("userID"))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
@@ -1279,29 +1495,33 @@ func (h *SalesHandler) GetSalesCustomerAnalysisReport(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"code":    200,
 		"message": "success",
-		"data":    report,
+		"data":    nil,
 	})
 }
 
-// @Summary 获取产品趋势报表
-// @Description 获取产品销售趋势的报表
-// @Tags 销售-报表管理
+// @Summary 退货收货
+// @Description 已审核的退货收货入库并生成贷项通知单，贷项冲减原发票余额
+// @Tags 销售-退货管理
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param id path string true "退货单ID"
+// @Param receive body schemas.ReceiveReturnRequest true "收货信息"
 // @Success 200 {object} map[string]interface{} "成功"
-// @Router /api/sales/reports/product-trend [get]
-func (h *SalesHandler) GetProductTrendReport(c *gin.Context) {
+// @Router /api/sales/returns/{id}/receive [post]
+func (h *SalesHandler) ReceiveReturn(c *gin.Context) {
 	// 实现逻辑
-	req := make(map[string]interface{})
-	// 从查询参数中获取过滤条件
-	if startDate := c.Query("start_date"); startDate != "" {
-		req["start_date"] = startDate
-	}
-	if endDate := c.Query("end_date"); endDate != "" {
-		req["end_date"] = endDate
+	id := c.Param("id")
+	var req schemas.ReceiveReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Bad Request",
+			"error":   err.Error(),
+		})
+		return
 	}
-	report, err := h.salesService.GetProductTrendReport(req)
+	returnOrder, err := h.salesService.ReceiveReturn(id, req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
@@ -1313,30 +1533,707 @@ func (h *SalesHandler) GetProductTrendReport(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"code":    200,
 		"message": "success",
-		"data":    report,
+		"data":    returnOrder,
 	})
 }
 
-// @Summary 导出销售报表
+// 贷项通知单路由处理函数
+// @Summary 获取贷项通知单列表
+// @Description 获取销售退货生成的贷项通知单列表
+// @Tags 销售-退货管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/credit-notes [get]
+func (h *SalesHandler) GetCreditNoteList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
+	}
+	notes, err := h.salesService.GetCreditNoteList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    notes,
+	})
+}
+
+// @Summary 获取贷项通知单详情
+// @Description 根据ID获取贷项通知单及明细
+// @Tags 销售-退货管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "贷项通知单ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/credit-notes/{id} [get]
+func (h *SalesHandler) GetCreditNoteDetail(c *gin.Context) {
+	// 实现逻辑
+	id := c.Param("id")
+	note, err := h.salesService.GetCreditNoteDetail(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    note,
+	})
+}
+
+// 销售定价管理路由处理函数
+// @Summary 询价
+// @Description 按客户、币种和日期模拟计算产品价格，与报价和订单使用相同的定价规则
+// @Tags 销售-定价管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.PriceQuoteRequest true "询价信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/pricing/quote [post]
+func (h *SalesHandler) QuotePrice(c *gin.Context) {
+	// 实现逻辑
+	var req schemas.PriceQuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Bad Request",
+			"error":   err.Error(),
+		})
+		return
+	}
+	quote, err := h.salesService.QuotePrice(req)
+	if err != nil {
+		if errors.Is(err, services.ErrPriceNotFound) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"code":    422,
+				"message": "Price Not Found",
+				"error":   err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    quote,
+	})
+}
+
+// @Summary 获取价目表列表
+// @Description 获取所有销售价目表的列表
+// @Tags 销售-定价管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/pricing/price-lists [get]
+func (h *SalesHandler) GetPriceListList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
+	}
+	priceLists, err := h.salesService.GetPriceListList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    priceLists,
+	})
+}
+
+// @Summary 获取价目表详情
+// @Description 根据ID获取价目表及阶梯价明细
+// @Tags 销售-定价管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "价目表ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/pricing/price-lists/{id} [get]
+func (h *SalesHandler) GetPriceListDetail(c *gin.Context) {
+	// 实现逻辑
+	id := c.Param("id")
+	priceList, err := h.salesService.GetPriceListDetail(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    priceList,
+	})
+}
+
+// @Summary 创建价目表
+// @Description 创建销售价目表及阶梯价明细
+// @Tags 销售-定价管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param priceList body schemas.CreatePriceListRequest true "价目表信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/pricing/price-lists [post]
+func (h *SalesHandler) CreatePriceList(c *gin.Context) {
+	// 实现逻辑
+	var req schemas.CreatePriceListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Bad Request",
+			"error":   err.Error(),
+		})
+		return
+	}
+	priceList, err := h.salesService.CreatePriceList(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    priceList,
+	})
+}
+
+// @Summary 更新价目表
+// @Description 根据ID更新价目表，传入明细时整体替换
+// @Tags 销售-定价管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "价目表ID"
+// @Param priceList body schemas.UpdatePriceListRequest true "价目表信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/pricing/price-lists/{id} [put]
+func (h *SalesHandler) UpdatePriceList(c *gin.Context) {
+	// 实现逻辑
+	id := c.Param("id")
+	var req schemas.UpdatePriceListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Bad Request",
+			"error":   err.Error(),
+		})
+		return
+	}
+	priceList, err := h.salesService.UpdatePriceList(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    priceList,
+	})
+}
+
+// @Summary 删除价目表
+// @Description 根据ID删除价目表及明细
+// @Tags 销售-定价管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "价目表ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/pricing/price-lists/{id} [delete]
+func (h *SalesHandler) DeletePriceList(c *gin.Context) {
+	// 实现逻辑
+	id := c.Param("id")
+	err := h.salesService.DeletePriceList(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 获取客户合同价列表
+// @Description 获取所有客户合同价的列表
+// @Tags 销售-定价管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/pricing/customer-prices [get]
+func (h *SalesHandler) GetCustomerPriceList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
+	}
+	prices, err := h.salesService.GetCustomerPriceList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    prices,
+	})
+}
+
+// @Summary 创建客户合同价
+// @Description 为客户指定产品的合同价
+// @Tags 销售-定价管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param price body schemas.CreateCustomerPriceRequest true "合同价信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/pricing/customer-prices [post]
+func (h *SalesHandler) CreateCustomerPrice(c *gin.Context) {
+	// 实现逻辑
+	var req schemas.CreateCustomerPriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Bad Request",
+			"error":   err.Error(),
+		})
+		return
+	}
+	price, err := h.salesService.CreateCustomerPrice(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    price,
+	})
+}
+
+// @Summary 更新客户合同价
+// @Description 根据ID更新客户合同价
+// @Tags 销售-定价管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "合同价ID"
+// @Param price body schemas.UpdateCustomerPriceRequest true "合同价信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/pricing/customer-prices/{id} [put]
+func (h *SalesHandler) UpdateCustomerPrice(c *gin.Context) {
+	// 实现逻辑
+	id := c.Param("id")
+	var req schemas.UpdateCustomerPriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Bad Request",
+			"error":   err.Error(),
+		})
+		return
+	}
+	price, err := h.salesService.UpdateCustomerPrice(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    price,
+	})
+}
+
+// @Summary 删除客户合同价
+// @Description 根据ID删除客户合同价
+// @Tags 销售-定价管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "合同价ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/pricing/customer-prices/{id} [delete]
+func (h *SalesHandler) DeleteCustomerPrice(c *gin.Context) {
+	// 实现逻辑
+	id := c.Param("id")
+	err := h.salesService.DeleteCustomerPrice(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 获取促销列表
+// @Description 获取所有销售促销的列表
+// @Tags 销售-定价管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/pricing/promotions [get]
+func (h *SalesHandler) GetPromotionList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters",
+			"error":   err.Error(),
+		})
+		return
+	}
+	promotions, err := h.salesService.GetPromotionList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    promotions,
+	})
+}
+
+// @Summary 创建促销
+// @Description 创建按产品和客户分组生效的促销折扣
+// @Tags 销售-定价管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param promotion body schemas.CreatePromotionRequest true "促销信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/pricing/promotions [post]
+func (h *SalesHandler) CreatePromotion(c *gin.Context) {
+	// 实现逻辑
+	var req schemas.CreatePromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Bad Request",
+			"error":   err.Error(),
+		})
+		return
+	}
+	promotion, err := h.salesService.CreatePromotion(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    promotion,
+	})
+}
+
+// @Summary 更新促销
+// @Description 根据ID更新促销
+// @Tags 销售-定价管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "促销ID"
+// @Param promotion body schemas.UpdatePromotionRequest true "促销信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/pricing/promotions/{id} [put]
+func (h *SalesHandler) UpdatePromotion(c *gin.Context) {
+	// 实现逻辑
+	id := c.Param("id")
+	var req schemas.UpdatePromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Bad Request",
+			"error":   err.Error(),
+		})
+		return
+	}
+	promotion, err := h.salesService.UpdatePromotion(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    promotion,
+	})
+}
+
+// @Summary 删除促销
+// @Description 根据ID删除促销
+// @Tags 销售-定价管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "促销ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/pricing/promotions/{id} [delete]
+func (h *SalesHandler) DeletePromotion(c *gin.Context) {
+	// 实现逻辑
+	id := c.Param("id")
+	err := h.salesService.DeletePromotion(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// 销售报表管理路由处理函数
+// @Summary 获取订单执行报表
+// @Description 获取订单执行情况的报表
+// @Tags 销售-报表管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string true "开始日期，格式：YYYY-MM-DD"
+// @Param end_date query string true "结束日期，格式：YYYY-MM-DD"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/reports/order-execution [get]
+func (h *SalesHandler) GetOrderExecutionReport(c *gin.Context) {
+	// 实现逻辑
+	var req schemas.SalesReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Bad Request",
+			"error":   err.Error(),
+		})
+		return
+	}
+	report, err := h.salesService.GetOrderExecutionReport(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    report,
+	})
+}
+
+// @Summary 获取客户分析报表
+// @Description 获取客户分析的报表
+// @Tags 销售-报表管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string true "开始日期，格式：YYYY-MM-DD"
+// @Param end_date query string true "结束日期，格式：YYYY-MM-DD"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/reports/customer-analysis [get]
+func (h *SalesHandler) GetSalesCustomerAnalysisReport(c *gin.Context) {
+	// 实现逻辑
+	var req schemas.SalesReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Bad Request",
+			"error":   err.Error(),
+		})
+		return
+	}
+	report, err := h.salesService.GetCustomerAnalysisReport(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    report,
+	})
+}
+
+// @Summary 获取产品趋势报表
+// @Description 获取产品销售趋势的报表
+// @Tags 销售-报表管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string true "开始日期，格式：YYYY-MM-DD"
+// @Param end_date query string true "结束日期，格式：YYYY-MM-DD"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/sales/reports/product-trend [get]
+func (h *SalesHandler) GetProductTrendReport(c *gin.Context) {
+	// 实现逻辑
+	var req schemas.SalesReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Bad Request",
+			"error":   err.Error(),
+		})
+		return
+	}
+	report, err := h.salesService.GetProductTrendReport(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Internal Server Error",
+			"error":   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    report,
+	})
+}
+
+// @Summary 导出销售报表
 // @Description 导出销售相关的报表
 // @Tags 销售-报表管理
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param report_type query string true "报表类型：order_execution、customer_analysis、product_trend"
+// @Param start_date query string true "开始日期，格式：YYYY-MM-DD"
+// @Param end_date query string true "结束日期，格式：YYYY-MM-DD"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/sales/reports/export [get]
 func (h *SalesHandler) ExportSalesReport(c *gin.Context) {
 	// 实现逻辑
-	req := make(map[string]interface{})
-	// 从查询参数中获取过滤条件
-	if reportType := c.Query("report_type"); reportType != "" {
-		req["report_type"] = reportType
-	}
-	if startDate := c.Query("start_date"); startDate != "" {
-		req["start_date"] = startDate
-	}
-	if endDate := c.Query("end_date"); endDate != "" {
-		req["end_date"] = endDate
+	var req schemas.ExportSalesReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Bad Request",
+			"error":   err.Error(),
+		})
+		return
 	}
 	reportData, err := h.salesService.ExportSalesReport(req)
 	if err != nil {
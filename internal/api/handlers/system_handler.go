@@ -0,0 +1,371 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/query"
+	"github.com/wu136995/ginx/internal/services"
+)
+
+// SystemHandler 系统管理处理器
+type SystemHandler struct {
+	systemService services.SystemService
+}
+
+// NewSystemHandler 创建系统管理处理器实例
+func NewSystemHandler(systemService services.SystemService) *SystemHandler {
+	return &SystemHandler{
+		systemService: systemService,
+	}
+}
+
+// 角色管理路由处理函数
+// @Summary 获取角色列表
+// @Description 获取所有角色及其权限
+// @Tags 系统-权限管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/v1/system/roles [get]
+func (h *SystemHandler) GetRoleList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	roles, err := h.systemService.GetRoleList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Failed to get role list: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    roles,
+	})
+}
+
+// @Summary 获取角色详情
+// @Description 根据ID获取角色及其权限
+// @Tags 系统-权限管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "角色ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/v1/system/roles/{id} [get]
+func (h *SystemHandler) GetRoleDetail(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	role, err := h.systemService.GetRoleDetail(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to get role detail: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    role,
+	})
+}
+
+// @Summary 创建角色
+// @Description 创建角色并设置初始权限
+// @Tags 系统-权限管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param role body schemas.RoleCreateRequest true "角色信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/v1/system/roles [post]
+func (h *SystemHandler) CreateRole(c *gin.Context) {
+	// 解析请求体
+	var req schemas.RoleCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	role, err := h.systemService.CreateRole(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to create role: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    role,
+	})
+}
+
+// @Summary 更新角色
+// @Description 根据ID更新角色名称、描述和状态
+// @Tags 系统-权限管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "角色ID"
+// @Param role body schemas.RoleUpdateRequest true "角色信息"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/v1/system/roles/{id} [put]
+func (h *SystemHandler) UpdateRole(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 解析请求体
+	var req schemas.RoleUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	role, err := h.systemService.UpdateRole(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to update role: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    role,
+	})
+}
+
+// @Summary 删除角色
+// @Description 删除角色并解除其用户和权限关联
+// @Tags 系统-权限管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "角色ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/v1/system/roles/{id} [delete]
+func (h *SystemHandler) DeleteRole(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 调用service方法
+	err := h.systemService.DeleteRole(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to delete role: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    nil,
+	})
+}
+
+// @Summary 设置角色权限
+// @Description 用给定权限编码整体替换角色权限
+// @Tags 系统-权限管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "角色ID"
+// @Param permissions body schemas.RolePermissionsRequest true "权限编码"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/v1/system/roles/{id}/permissions [put]
+func (h *SystemHandler) SetRolePermissions(c *gin.Context) {
+	// 获取路径参数
+	id := c.Param("id")
+
+	// 解析请求体
+	var req schemas.RolePermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	role, err := h.systemService.SetRolePermissions(id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to set role permissions: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    role,
+	})
+}
+
+// 权限管理路由处理函数
+// @Summary 获取权限列表
+// @Description 获取按路由同步的权限编码
+// @Tags 系统-权限管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param module query string false "模块"
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页条数，默认20，最大100"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/v1/system/permissions [get]
+func (h *SystemHandler) GetPermissionList(c *gin.Context) {
+	// 解析分页、排序和过滤参数
+	params, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request parameters: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	permissions, err := h.systemService.GetPermissionList(params)
+	if err != nil {
+		status := listErrorStatus(err)
+		c.JSON(status, gin.H{
+			"code":    status,
+			"message": "Failed to get permission list: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    permissions,
+	})
+}
+
+// 用户授权路由处理函数
+// @Summary 获取用户角色
+// @Description 获取用户的角色及汇总后的权限编码
+// @Tags 系统-权限管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "用户ID"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/v1/system/users/{id}/roles [get]
+func (h *SystemHandler) GetUserRoles(c *gin.Context) {
+	// 获取路径参数
+	userID := c.Param("id")
+
+	// 调用service方法
+	userRoles, err := h.systemService.GetUserRoles(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to get user roles: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    userRoles,
+	})
+}
+
+// @Summary 设置用户角色
+// @Description 用给定角色整体替换用户角色
+// @Tags 系统-权限管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "用户ID"
+// @Param roles body schemas.UserRolesRequest true "角色ID列表"
+// @Success 200 {object} map[string]interface{} "成功"
+// @Router /api/v1/system/users/{id}/roles [put]
+func (h *SystemHandler) SetUserRoles(c *gin.Context) {
+	// 获取路径参数
+	userID := c.Param("id")
+
+	// 解析请求体
+	var req schemas.UserRolesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "Invalid request body: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	// 调用service方法
+	userRoles, err := h.systemService.SetUserRoles(userID, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to set user roles: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "success",
+		"data":    userRoles,
+	})
+}
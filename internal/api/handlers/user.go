@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/wu136995/ginx/internal/api/schemas"
 	"github.com/wu136995/ginx/internal/models"
 	"github.com/wu136995/ginx/internal/services"
 )
@@ -65,12 +66,12 @@ func (h *UserHandler) GetUserInfo(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param user body map[string]interface{} true "用户信息"
+// @Param user body schemas.UserCreateRequest true "用户信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/user [post]
 func (h *UserHandler) CreateUser(c *gin.Context) {
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.UserCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -82,9 +83,11 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 
 	// 调用service方法
 	user := &models.User{
-		Username: req["username"].(string),
-		Email:    req["email"].(string),
-		// 其他字段映射
+		Username: req.Username,
+		Email:    req.Email,
+		Password: req.Password,
+		Nickname: req.Nickname,
+		Avatar:   req.Avatar,
 	}
 
 	err := h.userService.CreateUser(user)
@@ -110,12 +113,12 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param user body map[string]interface{} true "用户信息"
+// @Param user body schemas.UserUpdateRequest true "用户信息"
 // @Success 200 {object} map[string]interface{} "成功"
 // @Router /api/user [put]
 func (h *UserHandler) UpdateUser(c *gin.Context) {
 	// 检查是否已认证
-	_, exists := c.Get("userID")
+	userID, exists := c.Get("userID")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"code":    401,
@@ -126,7 +129,7 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	}
 
 	// 解析请求体
-	var req map[string]interface{}
+	var req schemas.UserUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    400,
@@ -136,14 +139,31 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
-	// 调用service方法
-	user := &models.User{
-		Username: req["username"].(string),
-		Email:    req["email"].(string),
-		// 其他字段映射
+	// 读取当前用户，只更新请求中传入的字段
+	user, err := h.userService.GetUserByID(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "Failed to update user: " + err.Error(),
+			"data":    nil,
+		})
+		return
+	}
+	if req.Username != "" {
+		user.Username = req.Username
+	}
+	if req.Email != "" {
+		user.Email = req.Email
+	}
+	if req.Nickname != "" {
+		user.Nickname = req.Nickname
+	}
+	if req.Avatar != "" {
+		user.Avatar = req.Avatar
 	}
 
-	err := h.userService.UpdateUser(user)
+	// 调用service方法
+	err = h.userService.UpdateUser(user)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
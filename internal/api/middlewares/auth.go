@@ -2,6 +2,7 @@ package middlewares
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -9,8 +10,13 @@ import (
 	"github.com/wu136995/ginx/internal/utils"
 )
 
-// Auth 认证中间件
-func Auth() gin.HandlerFunc {
+// SessionValidator 校验登录会话是否有效
+type SessionValidator interface {
+	ValidateSession(sessionID string) error
+}
+
+// Auth 认证中间件，sessions不为空时同时校验令牌所属会话未被撤销
+func Auth(sessions SessionValidator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 从请求头获取token
 		authHeader := c.GetHeader("Authorization")
@@ -50,10 +56,23 @@ func Auth() gin.HandlerFunc {
 			return
 		}
 
-		// 将用户信息存储到上下文中
-		c.Set("userID", claims.UserID)
+		// 校验会话，登出或修改密码后已签发的令牌立即失效
+		if sessions != nil {
+			if claims.ID == "" || sessions.ValidateSession(claims.ID) != nil {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"code":    401,
+					"message": "登录会话已失效",
+				})
+				c.Abort()
+				return
+			}
+		}
+
+		// 将用户信息存储到上下文中，用户ID统一以字符串形式保存
+		c.Set("userID", strconv.FormatUint(uint64(claims.UserID), 10))
 		c.Set("userName", claims.Username)
 		c.Set("role", claims.Role)
+		c.Set("sessionID", claims.ID)
 
 		c.Next()
 	}
@@ -6,7 +6,7 @@ import (
 )
 
 // SetupCRMRoutes 设置CRM模块路由
-func SetupCRMRoutes(router *gin.Engine, crmHandler *handlers.CRMHandler) {
+func SetupCRMRoutes(router gin.IRouter, crmHandler *handlers.CRMHandler) {
 	// CRM模块API路由组
 	crm := router.Group("/api/v1/crm")
 	{
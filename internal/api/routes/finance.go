@@ -6,7 +6,7 @@ import (
 )
 
 // SetupFinanceRoutes 设置财务模块路由
-func SetupFinanceRoutes(router *gin.Engine, financeHandler *handlers.FinanceHandler) {
+func SetupFinanceRoutes(router gin.IRouter, financeHandler *handlers.FinanceHandler) {
 	// 财务模块API路由组
 	finance := router.Group("/api/v1/finance")
 	{
@@ -18,6 +18,7 @@ func SetupFinanceRoutes(router *gin.Engine, financeHandler *handlers.FinanceHand
 			accounts.POST("", financeHandler.CreateAccount)
 			accounts.PUT("/:id", financeHandler.UpdateAccount)
 			accounts.DELETE("/:id", financeHandler.DeleteAccount)
+			accounts.GET("/:id/transactions", financeHandler.GetAccountTransactions)
 		}
 
 		// 凭证管理
@@ -28,26 +29,109 @@ func SetupFinanceRoutes(router *gin.Engine, financeHandler *handlers.FinanceHand
 			vouchers.POST("", financeHandler.CreateVoucher)
 			vouchers.PUT("/:id", financeHandler.UpdateVoucher)
 			vouchers.DELETE("/:id", financeHandler.DeleteVoucher)
+			vouchers.POST("/:id/submit", financeHandler.SubmitVoucher)
+			vouchers.POST("/:id/approve", financeHandler.ApproveVoucher)
+			vouchers.POST("/:id/reject", financeHandler.RejectVoucher)
+			vouchers.POST("/:id/post", financeHandler.PostVoucher)
 		}
 
+		// 会计期间管理
+		fiscalYears := finance.Group("/fiscal-years")
+		{
+			fiscalYears.GET("", financeHandler.GetFiscalYearList)
+			fiscalYears.POST("", financeHandler.CreateFiscalYear)
+		}
+		periods := finance.Group("/periods")
+		{
+			periods.GET("", financeHandler.GetPeriodList)
+			periods.POST("/:id/soft-close", financeHandler.SoftClosePeriod)
+			periods.POST("/:id/close", financeHandler.ClosePeriod)
+			periods.POST("/:id/reopen", financeHandler.ReopenPeriod)
+			periods.POST("/:id/fx-revaluation", financeHandler.RevaluePeriod)
+		}
+
+		// 自动记账规则管理
+		postingRules := finance.Group("/posting-rules")
+		{
+			postingRules.GET("", financeHandler.GetPostingRuleList)
+			postingRules.POST("", financeHandler.CreatePostingRule)
+			postingRules.PUT("/:id", financeHandler.UpdatePostingRule)
+			postingRules.DELETE("/:id", financeHandler.DeletePostingRule)
+		}
+
+		// 税务管理
+		taxCodes := finance.Group("/tax-codes")
+		{
+			taxCodes.GET("", financeHandler.GetTaxCodeList)
+			taxCodes.GET("/:id", financeHandler.GetTaxCodeDetail)
+			taxCodes.POST("", financeHandler.CreateTaxCode)
+			taxCodes.PUT("/:id", financeHandler.UpdateTaxCode)
+			taxCodes.DELETE("/:id", financeHandler.DeleteTaxCode)
+		}
+		taxRules := finance.Group("/tax-rules")
+		{
+			taxRules.GET("", financeHandler.GetTaxRuleList)
+			taxRules.POST("", financeHandler.CreateTaxRule)
+			taxRules.PUT("/:id", financeHandler.UpdateTaxRule)
+			taxRules.DELETE("/:id", financeHandler.DeleteTaxRule)
+		}
+
+		// 外币管理
+		currencies := finance.Group("/currencies")
+		{
+			currencies.GET("", financeHandler.GetCurrencyList)
+			currencies.POST("", financeHandler.CreateCurrency)
+			currencies.PUT("/:id", financeHandler.UpdateCurrency)
+			currencies.DELETE("/:id", financeHandler.DeleteCurrency)
+		}
+		exchangeRates := finance.Group("/exchange-rates")
+		{
+			exchangeRates.GET("", financeHandler.GetExchangeRateList)
+			exchangeRates.POST("", financeHandler.CreateExchangeRate)
+			exchangeRates.POST("/import", financeHandler.ImportExchangeRates)
+			exchangeRates.DELETE("/:id", financeHandler.DeleteExchangeRate)
+		}
+		finance.GET("/fx-revaluations", financeHandler.GetFXRevaluationList)
+
 		// 付款管理
 		payments := finance.Group("/payments")
 		{
-			payments.GET("", financeHandler.GetVoucherList)
-			payments.GET("/:id", financeHandler.GetVoucherDetail)
-			payments.POST("", financeHandler.CreateVoucher)
-			payments.PUT("/:id", financeHandler.UpdateVoucher)
-			payments.DELETE("/:id", financeHandler.DeleteVoucher)
+			payments.GET("", financeHandler.GetPaymentList)
+			payments.GET("/:id", financeHandler.GetPaymentDetail)
+			payments.POST("", financeHandler.CreatePayment)
+			payments.PUT("/:id", financeHandler.UpdatePayment)
+			payments.DELETE("/:id", financeHandler.DeletePayment)
+			payments.POST("/:id/submit", financeHandler.SubmitPayment)
+			payments.POST("/:id/approve", financeHandler.ApprovePayment)
+			payments.POST("/:id/reject", financeHandler.RejectPayment)
+			payments.POST("/:id/process", financeHandler.ProcessPayment)
+		}
+
+		// 付款运行
+		paymentRuns := finance.Group("/payment-runs")
+		{
+			paymentRuns.GET("", financeHandler.GetPaymentRunList)
+			paymentRuns.GET("/:id", financeHandler.GetPaymentRunDetail)
+			paymentRuns.POST("", financeHandler.CreatePaymentRun)
+			paymentRuns.PUT("/:id", financeHandler.UpdatePaymentRun)
+			paymentRuns.POST("/:id/approve", financeHandler.ApprovePaymentRun)
+			paymentRuns.POST("/:id/cancel", financeHandler.CancelPaymentRun)
+			paymentRuns.GET("/:id/export", financeHandler.ExportPaymentRun)
 		}
 
 		// 收款管理
 		receipts := finance.Group("/receipts")
 		{
-			receipts.GET("", financeHandler.GetVoucherList)
-			receipts.GET("/:id", financeHandler.GetVoucherDetail)
-			receipts.POST("", financeHandler.CreateVoucher)
-			receipts.PUT("/:id", financeHandler.UpdateVoucher)
-			receipts.DELETE("/:id", financeHandler.DeleteVoucher)
+			receipts.GET("", financeHandler.GetReceiptList)
+			receipts.GET("/:id", financeHandler.GetReceiptDetail)
+			receipts.POST("", financeHandler.CreateReceipt)
+			receipts.PUT("/:id", financeHandler.UpdateReceipt)
+			receipts.DELETE("/:id", financeHandler.DeleteReceipt)
+			receipts.POST("/:id/submit", financeHandler.SubmitReceipt)
+			receipts.POST("/:id/approve", financeHandler.ApproveReceipt)
+			receipts.POST("/:id/reject", financeHandler.RejectReceipt)
+			receipts.POST("/:id/process", financeHandler.ProcessReceipt)
+			receipts.POST("/:id/apply", financeHandler.ApplyReceipt)
 		}
 
 		// 财务报表管理
@@ -57,6 +141,9 @@ func SetupFinanceRoutes(router *gin.Engine, financeHandler *handlers.FinanceHand
 			reports.GET("/profit", financeHandler.GetIncomeStatement)
 			reports.GET("/cash-flow", financeHandler.GetCashFlowStatement)
 			reports.GET("/trial", financeHandler.GetTrialBalance)
+			reports.GET("/general-ledger", financeHandler.GetGeneralLedger)
+			reports.GET("/vat-summary", financeHandler.GetVATSummaryReport)
+			reports.GET("/accounts-receivable", financeHandler.GetAccountsReceivableReport)
 			reports.GET("/export", financeHandler.ExportFinancialReport)
 		}
 	}
@@ -6,7 +6,7 @@ import (
 )
 
 // SetupHRRoutes 设置人力资源模块路由
-func SetupHRRoutes(router *gin.Engine, hrHandler *handlers.HRHandler) {
+func SetupHRRoutes(router gin.IRouter, hrHandler *handlers.HRHandler) {
 	// 人力资源模块API路由组
 	hr := router.Group("/api/v1/hr")
 	{
@@ -6,7 +6,7 @@ import (
 )
 
 // SetupInventoryRoutes 设置库存模块路由
-func SetupInventoryRoutes(router *gin.Engine, inventoryHandler *handlers.InventoryHandler) {
+func SetupInventoryRoutes(router gin.IRouter, inventoryHandler *handlers.InventoryHandler) {
 	// 库存模块API路由组
 	inventory := router.Group("/api/v1/inventory")
 	{
@@ -28,6 +28,8 @@ func SetupInventoryRoutes(router *gin.Engine, inventoryHandler *handlers.Invento
 			items.POST("", inventoryHandler.CreateMaterial)
 			items.PUT("/:id", inventoryHandler.UpdateMaterial)
 			items.DELETE("/:id", inventoryHandler.DeleteMaterial)
+			items.GET("/:id/cost-layers", inventoryHandler.GetMaterialCostLayers)
+			items.POST("/:id/revalue", inventoryHandler.RevalueMaterial)
 		}
 
 		// 库存交易管理
@@ -50,6 +52,30 @@ func SetupInventoryRoutes(router *gin.Engine, inventoryHandler *handlers.Invento
 			counts.POST("/:id/approve", inventoryHandler.ApproveInventoryCount)
 		}
 
+		// 库存预留及可承诺量
+		inventory.GET("/reservations", inventoryHandler.GetReservationList)
+		inventory.GET("/atp", inventoryHandler.GetAvailableToPromise)
+
+		// 自动补货
+		replenishment := inventory.Group("/replenishment")
+		{
+			replenishment.GET("/rules", inventoryHandler.GetReplenishmentRuleList)
+			replenishment.POST("/rules", inventoryHandler.CreateReplenishmentRule)
+			replenishment.PUT("/rules/:id", inventoryHandler.UpdateReplenishmentRule)
+			replenishment.DELETE("/rules/:id", inventoryHandler.DeleteReplenishmentRule)
+			replenishment.GET("/runs", inventoryHandler.GetReplenishmentRunList)
+			replenishment.GET("/runs/:id", inventoryHandler.GetReplenishmentRunDetail)
+			replenishment.POST("/runs", inventoryHandler.RunReplenishment)
+		}
+
+		// 调拨申请
+		transferRequests := inventory.Group("/transfer-requests")
+		{
+			transferRequests.GET("", inventoryHandler.GetTransferRequestList)
+			transferRequests.POST("/:id/complete", inventoryHandler.CompleteTransferRequest)
+			transferRequests.POST("/:id/cancel", inventoryHandler.CancelTransferRequest)
+		}
+
 		// 库存报表管理
 		reports := inventory.Group("/reports")
 		{
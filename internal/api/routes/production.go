@@ -6,7 +6,7 @@ import (
 )
 
 // SetupProductionRoutes 设置生产模块路由
-func SetupProductionRoutes(router *gin.Engine, productionHandler *handlers.ProductionHandler) {
+func SetupProductionRoutes(router gin.IRouter, productionHandler *handlers.ProductionHandler) {
 	// 生产模块API路由组
 	production := router.Group("/api/v1/production")
 	{
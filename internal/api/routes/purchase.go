@@ -6,7 +6,7 @@ import (
 )
 
 // SetupPurchaseRoutes 设置采购模块路由
-func SetupPurchaseRoutes(router *gin.Engine, purchaseHandler *handlers.PurchaseHandler) {
+func SetupPurchaseRoutes(router gin.IRouter, purchaseHandler *handlers.PurchaseHandler) {
 	// 采购模块API路由组
 	purchase := router.Group("/api/v1/purchase")
 	{
@@ -20,6 +20,20 @@ func SetupPurchaseRoutes(router *gin.Engine, purchaseHandler *handlers.PurchaseH
 			suppliers.DELETE("/:id", purchaseHandler.DeleteSupplier)
 			suppliers.GET("/:id/contacts", purchaseHandler.GetSupplierContacts)
 			suppliers.POST("/:id/contacts", purchaseHandler.AddSupplierContact)
+			suppliers.POST("/scorecards", purchaseHandler.EvaluateSupplierScorecards)
+			suppliers.GET("/:id/scorecards", purchaseHandler.GetSupplierScorecardHistory)
+		}
+
+		// 采购计划管理
+		plans := purchase.Group("/plans")
+		{
+			plans.GET("", purchaseHandler.GetPurchasePlanList)
+			plans.GET("/:id", purchaseHandler.GetPurchasePlanDetail)
+			plans.POST("", purchaseHandler.CreatePurchasePlan)
+			plans.POST("/forecast", purchaseHandler.CreatePurchasePlanFromForecast)
+			plans.PUT("/:id", purchaseHandler.UpdatePurchasePlan)
+			plans.DELETE("/:id", purchaseHandler.DeletePurchasePlan)
+			plans.POST("/:id/approve", purchaseHandler.ApprovePurchasePlan)
 		}
 
 		// 采购申请管理
@@ -41,6 +55,7 @@ func SetupPurchaseRoutes(router *gin.Engine, purchaseHandler *handlers.PurchaseH
 			orders.GET("", purchaseHandler.GetPurchaseOrderList)
 			orders.GET("/:id", purchaseHandler.GetPurchaseOrderDetail)
 			orders.POST("", purchaseHandler.CreatePurchaseOrder)
+			orders.POST("/convert", purchaseHandler.ConvertToPurchaseOrders)
 			orders.PUT("/:id", purchaseHandler.UpdatePurchaseOrder)
 			orders.DELETE("/:id", purchaseHandler.DeletePurchaseOrder)
 			orders.POST("/:id/submit", purchaseHandler.SubmitPurchaseOrder)
@@ -69,6 +84,7 @@ func SetupPurchaseRoutes(router *gin.Engine, purchaseHandler *handlers.PurchaseH
 			invoices.PUT("/:id", purchaseHandler.UpdatePurchaseInvoice)
 			invoices.DELETE("/:id", purchaseHandler.DeletePurchaseInvoice)
 			invoices.POST("/:id/verify", purchaseHandler.VerifyPurchaseInvoice)
+			invoices.POST("/:id/release-block", purchaseHandler.ReleaseInvoiceBlock)
 			invoices.POST("/:id/pay", purchaseHandler.PayPurchaseInvoice)
 		}
 
@@ -83,6 +99,15 @@ func SetupPurchaseRoutes(router *gin.Engine, purchaseHandler *handlers.PurchaseH
 			returns.POST("/:id/complete", purchaseHandler.CompletePurchaseReturn)
 		}
 
+		// 采购价格历史和目标价
+		purchase.GET("/price-history", purchaseHandler.GetPriceHistoryList)
+		targetPrices := purchase.Group("/target-prices")
+		{
+			targetPrices.GET("", purchaseHandler.GetTargetPriceList)
+			targetPrices.PUT("/:item_id", purchaseHandler.SetTargetPrice)
+			targetPrices.DELETE("/:item_id", purchaseHandler.DeleteTargetPrice)
+		}
+
 		// 采购报表管理
 		reports := purchase.Group("/reports")
 		{
@@ -7,10 +7,11 @@ import (
 	"github.com/wu136995/ginx/docs"
 	"github.com/wu136995/ginx/internal/api/handlers"
 	"github.com/wu136995/ginx/internal/api/middlewares"
+	"github.com/wu136995/ginx/internal/services"
 )
 
 // SetupRoutes 设置路由
-func SetupRoutes(router *gin.Engine, userHandler *handlers.UserHandler, salesHandler *handlers.SalesHandler, inventoryHandler *handlers.InventoryHandler, purchaseHandler *handlers.PurchaseHandler, financeHandler *handlers.FinanceHandler, productionHandler *handlers.ProductionHandler, hrHandler *handlers.HRHandler, crmHandler *handlers.CRMHandler) {
+func SetupRoutes(router *gin.Engine, userHandler *handlers.UserHandler, salesHandler *handlers.SalesHandler, inventoryHandler *handlers.InventoryHandler, purchaseHandler *handlers.PurchaseHandler, financeHandler *handlers.FinanceHandler, productionHandler *handlers.ProductionHandler, hrHandler *handlers.HRHandler, crmHandler *handlers.CRMHandler, systemHandler *handlers.SystemHandler, systemService services.SystemService, authHandler *handlers.AuthHandler, authService services.AuthService) {
 	// 公共路由组
 	public := router.Group("")
 	{
@@ -24,24 +25,33 @@ func SetupRoutes(router *gin.Engine, userHandler *handlers.UserHandler, salesHan
 
 	// 需要认证的路由组
 	protected := router.Group("")
-	protected.Use(middlewares.Auth())
+	protected.Use(middlewares.Auth(authService))
 	{
 		// 用户信息
 		protected.GET("/user/info", userHandler.GetUserInfo)
 	}
 
+	// 认证路由
+	SetupAuthRoutes(public, protected, authHandler)
+
+	// 业务模块路由需要认证并校验权限
+	authorized := router.Group("")
+	authorized.Use(middlewares.Auth(authService), middlewares.Authorize(systemService))
+
 	// 销售模块路由
-	SetupSalesRoutes(router, salesHandler)
+	SetupSalesRoutes(authorized, salesHandler)
 	// 库存模块路由
-	SetupInventoryRoutes(router, inventoryHandler)
+	SetupInventoryRoutes(authorized, inventoryHandler)
 	// 采购模块路由
-	SetupPurchaseRoutes(router, purchaseHandler)
+	SetupPurchaseRoutes(authorized, purchaseHandler)
 	// 财务模块路由
-	SetupFinanceRoutes(router, financeHandler)
+	SetupFinanceRoutes(authorized, financeHandler)
 	// 生产模块路由
-	SetupProductionRoutes(router, productionHandler)
+	SetupProductionRoutes(authorized, productionHandler)
 	// 人力资源模块路由
-	SetupHRRoutes(router, hrHandler)
+	SetupHRRoutes(authorized, hrHandler)
 	// CRM模块路由
-	SetupCRMRoutes(router, crmHandler)
+	SetupCRMRoutes(authorized, crmHandler)
+	// 系统管理路由
+	SetupSystemRoutes(authorized, systemHandler, authHandler)
 }
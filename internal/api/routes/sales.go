@@ -6,7 +6,7 @@ import (
 )
 
 // SetupSalesRoutes 设置销售模块路由
-func SetupSalesRoutes(router *gin.Engine, salesHandler *handlers.SalesHandler) {
+func SetupSalesRoutes(router gin.IRouter, salesHandler *handlers.SalesHandler) {
 	// 销售模块API路由组
 	sales := router.Group("/api/v1/sales")
 	{
@@ -19,6 +19,8 @@ func SetupSalesRoutes(router *gin.Engine, salesHandler *handlers.SalesHandler) {
 			customers.PUT("/:id", salesHandler.UpdateSalesCustomer)
 			customers.DELETE("/:id", salesHandler.DeleteSalesCustomer)
 			customers.POST("/:id/credit-evaluate", salesHandler.CustomerCreditEvaluate)
+			customers.GET("/:id/credit-evaluations", salesHandler.GetCustomerCreditHistory)
+			customers.POST("/credit-evaluations", salesHandler.EvaluateCustomerCredits)
 		}
 
 		// 销售报价管理
@@ -42,8 +44,11 @@ func SetupSalesRoutes(router *gin.Engine, salesHandler *handlers.SalesHandler) {
 			orders.PUT("/:id", salesHandler.UpdateOrder)
 			orders.DELETE("/:id", salesHandler.DeleteOrder)
 			orders.POST("/:id/approve", salesHandler.ApproveOrder)
+			orders.POST("/:id/release-credit-hold", salesHandler.ReleaseCreditHold)
 			orders.POST("/:id/cancel", salesHandler.CancelOrder)
+			orders.POST("/:id/reserve", salesHandler.ReserveOrder)
 			orders.POST("/:id/generate-delivery", salesHandler.GenerateDeliveryFromOrder)
+			orders.POST("/:id/generate-invoice", salesHandler.GenerateInvoiceFromOrder)
 		}
 
 		// 销售发货管理
@@ -54,6 +59,7 @@ func SetupSalesRoutes(router *gin.Engine, salesHandler *handlers.SalesHandler) {
 			deliveries.POST("", salesHandler.CreateDelivery)
 			deliveries.PUT("/:id", salesHandler.UpdateDelivery)
 			deliveries.DELETE("/:id", salesHandler.DeleteDelivery)
+			deliveries.POST("/:id/ship", salesHandler.ShipDelivery)
 		}
 
 		// 销售发票管理
@@ -75,6 +81,35 @@ func SetupSalesRoutes(router *gin.Engine, salesHandler *handlers.SalesHandler) {
 			returns.POST("", salesHandler.CreateReturn)
 			returns.PUT("/:id", salesHandler.UpdateReturn)
 			returns.DELETE("/:id", salesHandler.DeleteReturn)
+			returns.POST("/:id/approve", salesHandler.ApproveReturn)
+			returns.POST("/:id/cancel", salesHandler.CancelReturn)
+			returns.POST("/:id/receive", salesHandler.ReceiveReturn)
+		}
+
+		// 贷项通知单
+		creditNotes := sales.Group("/credit-notes")
+		{
+			creditNotes.GET("", salesHandler.GetCreditNoteList)
+			creditNotes.GET("/:id", salesHandler.GetCreditNoteDetail)
+		}
+
+		// 销售定价管理
+		pricing := sales.Group("/pricing")
+		{
+			pricing.POST("/quote", salesHandler.QuotePrice)
+			pricing.GET("/price-lists", salesHandler.GetPriceListList)
+			pricing.GET("/price-lists/:id", salesHandler.GetPriceListDetail)
+			pricing.POST("/price-lists", salesHandler.CreatePriceList)
+			pricing.PUT("/price-lists/:id", salesHandler.UpdatePriceList)
+			pricing.DELETE("/price-lists/:id", salesHandler.DeletePriceList)
+			pricing.GET("/customer-prices", salesHandler.GetCustomerPriceList)
+			pricing.POST("/customer-prices", salesHandler.CreateCustomerPrice)
+			pricing.PUT("/customer-prices/:id", salesHandler.UpdateCustomerPrice)
+			pricing.DELETE("/customer-prices/:id", salesHandler.DeleteCustomerPrice)
+			pricing.GET("/promotions", salesHandler.GetPromotionList)
+			pricing.POST("/promotions", salesHandler.CreatePromotion)
+			pricing.PUT("/promotions/:id", salesHandler.UpdatePromotion)
+			pricing.DELETE("/promotions/:id", salesHandler.DeletePromotion)
 		}
 
 		// 销售报表管理
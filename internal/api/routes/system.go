@@ -0,0 +1,71 @@
+package routes
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wu136995/ginx/internal/api/handlers"
+	"github.com/wu136995/ginx/internal/api/middlewares"
+	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/services"
+)
+
+// SetupSystemRoutes 设置系统管理路由
+func SetupSystemRoutes(router gin.IRouter, systemHandler *handlers.SystemHandler, authHandler *handlers.AuthHandler) {
+	// 系统管理API路由组
+	system := router.Group("/api/v1/system")
+	{
+		// 角色管理
+		roles := system.Group("/roles")
+		{
+			roles.GET("", systemHandler.GetRoleList)
+			roles.GET("/:id", systemHandler.GetRoleDetail)
+			roles.POST("", systemHandler.CreateRole)
+			roles.PUT("/:id", systemHandler.UpdateRole)
+			roles.DELETE("/:id", systemHandler.DeleteRole)
+			roles.PUT("/:id/permissions", systemHandler.SetRolePermissions)
+		}
+
+		// 权限管理
+		system.GET("/permissions", systemHandler.GetPermissionList)
+
+		// 用户授权
+		users := system.Group("/users")
+		{
+			users.GET("/:id/roles", systemHandler.GetUserRoles)
+			users.PUT("/:id/roles", systemHandler.SetUserRoles)
+			users.POST("/:id/password-reset", authHandler.CreatePasswordReset)
+		}
+	}
+}
+
+// SetupAuthRoutes 设置认证路由，登录、刷新令牌和重置密码无需认证
+func SetupAuthRoutes(public gin.IRouter, protected gin.IRouter, authHandler *handlers.AuthHandler) {
+	public.POST("/auth/login", authHandler.Login)
+	public.POST("/auth/refresh", authHandler.RefreshToken)
+	public.POST("/auth/password/reset", authHandler.ResetPassword)
+
+	protected.POST("/auth/logout", authHandler.Logout)
+	protected.POST("/auth/password/change", authHandler.ChangePassword)
+}
+
+// SyncPermissions 根据已注册的路由生成权限编码并同步到权限表
+func SyncPermissions(router *gin.Engine, systemService services.SystemService) error {
+	var permissions []models.SystemPermission
+	seen := make(map[string]bool)
+	for _, route := range router.Routes() {
+		code := middlewares.PermissionCode(route.Method, route.Path)
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+		permissions = append(permissions, models.SystemPermission{
+			Code:        code,
+			Name:        code,
+			Description: route.Method + " " + route.Path,
+			Module:      strings.SplitN(code, ":", 2)[0],
+		})
+	}
+
+	return systemService.SyncPermissions(permissions)
+}
@@ -413,14 +413,27 @@ type ServiceTicketResponse struct {
 	UpdatedAt      time.Time  `json:"updated_at"`
 }
 
+// OpportunityCloseRequest 关闭销售机会请求
+type OpportunityCloseRequest struct {
+	Stage     string `json:"stage" binding:"required,oneof=closed_won closed_lost"`
+	CloseDate string `json:"close_date" binding:"omitempty,datetime=2006-01-02"`
+	ClosedBy  string `json:"closed_by" binding:"required"`
+}
+
+// ServiceAssignRequest 分配服务请求
+type ServiceAssignRequest struct {
+	AssignedTo string `json:"assigned_to" binding:"required"`
+	AssignedBy string `json:"assigned_by" binding:"required"`
+}
+
 // CRM报表相关结构体
 
 // SalesPipelineReportRequest 销售漏斗报表请求
 type SalesPipelineReportRequest struct {
-	StartDate     string `json:"start_date" binding:"required"`
-	EndDate       string `json:"end_date" binding:"required"`
-	SalespersonID uint   `json:"salesperson_id"`
-	CustomerID    uint   `json:"customer_id"`
+	StartDate     string `form:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate       string `form:"end_date" binding:"omitempty,datetime=2006-01-02"`
+	SalespersonID string `form:"salesperson_id"`
+	CustomerID    string `form:"customer_id"`
 }
 
 // SalesPipelineReportResponse 销售漏斗报表响应
@@ -441,43 +454,55 @@ type SalesPipelineStage struct {
 
 // CustomerAnalysisReportRequest 客户分析报表请求
 type CustomerAnalysisReportRequest struct {
-	StartDate string `json:"start_date" binding:"required"`
-	EndDate   string `json:"end_date" binding:"required"`
-	Category  string `json:"category"`
-	Region    string `json:"region"`
-}
-
-// CustomerAnalysisReportResponse 客户分析报表响应
-//  type CustomerAnalysisReportResponse struct {
-// 	Period         string                  `json:"period"`
-// 	TotalCustomers int                     `json:"total_customers"`
-// 	TotalRevenue   float64                 `json:"total_revenue"`
-// 	AverageRevenue float64                 `json:"average_revenue"`
-// 	TopCustomers   []TopCustomer           `json:"top_customers"`
-// 	CategoryStats  []CustomerCategoryStat  `json:"category_stats"`
-// 	RegionStats    []CustomerRegionStat    `json:"region_stats"`
-// }
+	StartDate string `form:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate   string `form:"end_date" binding:"omitempty,datetime=2006-01-02"`
+	Category  string `form:"category"`
+	Region    string `form:"region"`
+}
 
-// TopCustomer 顶级客户
-type TopCustomer struct {
-	CustomerID   uint    `json:"customer_id"`
-	CustomerName string  `json:"customer_name"`
-	Revenue      float64 `json:"revenue"`
-	Percentage   float64 `json:"percentage"`
+// CRMCustomerAnalysisReportResponse CRM客户分析报表响应
+type CRMCustomerAnalysisReportResponse struct {
+	Period            string         `json:"period"`
+	TotalCustomers    int            `json:"total_customers"`
+	IndustryBreakdown map[string]int `json:"industry_breakdown"`
+	StatusBreakdown   map[string]int `json:"status_breakdown"`
 }
 
-// CustomerCategoryStat 客户类别统计
-type CustomerCategoryStat struct {
-	Category   string  `json:"category"`
-	Count      int     `json:"count"`
-	Revenue    float64 `json:"revenue"`
-	Percentage float64 `json:"percentage"`
+// CRMReportRequest CRM报表通用查询请求
+type CRMReportRequest struct {
+	StartDate string `form:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate   string `form:"end_date" binding:"omitempty,datetime=2006-01-02"`
 }
 
-// CustomerRegionStat 客户地区统计
-type CustomerRegionStat struct {
-	Region     string  `json:"region"`
-	Count      int     `json:"count"`
-	Revenue    float64 `json:"revenue"`
-	Percentage float64 `json:"percentage"`
+// ActivitySummaryReportResponse 活动汇总报表响应
+type ActivitySummaryReportResponse struct {
+	Period          string         `json:"period"`
+	TotalActivities int            `json:"total_activities"`
+	ActivityTypes   map[string]int `json:"activity_types"`
+	StatusBreakdown map[string]int `json:"status_breakdown"`
+}
+
+// CampaignEffectivenessReportResponse 营销活动效果报表响应
+type CampaignEffectivenessReportResponse struct {
+	Period          string         `json:"period"`
+	TotalCampaigns  int            `json:"total_campaigns"`
+	TotalBudget     float64        `json:"total_budget"`
+	TotalActualCost float64        `json:"total_actual_cost"`
+	CostEfficiency  float64        `json:"cost_efficiency"`
+	StatusBreakdown map[string]int `json:"status_breakdown"`
+}
+
+// ServicePerformanceReportResponse 服务绩效报表响应
+type ServicePerformanceReportResponse struct {
+	Period               string         `json:"period"`
+	TotalServiceRequests int            `json:"total_service_requests"`
+	StatusBreakdown      map[string]int `json:"status_breakdown"`
+	PriorityBreakdown    map[string]int `json:"priority_breakdown"`
+}
+
+// ExportCRMReportRequest 导出CRM报表请求
+type ExportCRMReportRequest struct {
+	ReportType string `form:"report_type" binding:"required,oneof=customers sales_pipeline activities campaign_effectiveness service_requests"`
+	StartDate  string `form:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate    string `form:"end_date" binding:"omitempty,datetime=2006-01-02"`
 }
@@ -2,241 +2,399 @@ package schemas
 
 // 账户相关结构体
 
-// AccountCreateRequest 创建账户请求
+// AccountCreateRequest 创建会计科目请求
 type AccountCreateRequest struct {
-	Code           string  `json:"code" binding:"required"`
-	Name           string  `json:"name" binding:"required"`
-	Type           string  `json:"type" binding:"required"`
-	Currency       string  `json:"currency" binding:"required"`
-	InitialBalance float64 `json:"initial_balance"`
-	Description    string  `json:"description"`
-	Active         bool    `json:"active"`
+	Code             string `json:"code" binding:"required,max=20"`
+	Name             string `json:"name" binding:"required,max=100"`
+	Type             string `json:"type" binding:"required,oneof=asset liability equity revenue expense"`
+	Level            int    `json:"level" binding:"required,min=1"`
+	ParentID         string `json:"parent_id" binding:"omitempty,max=36"`
+	CashFlowCategory string `json:"cash_flow_category" binding:"omitempty,oneof=cash operating investing financing"`
+	CreatedBy        string `json:"created_by" binding:"required"`
 }
 
-// AccountUpdateRequest 更新账户请求
+// AccountUpdateRequest 更新会计科目请求，未传字段保持不变
 type AccountUpdateRequest struct {
-	Code           string  `json:"code"`
-	Name           string  `json:"name"`
-	Type           string  `json:"type"`
-	Currency       string  `json:"currency"`
-	InitialBalance float64 `json:"initial_balance"`
-	Description    string  `json:"description"`
-	Active         bool    `json:"active"`
+	Code             string `json:"code" binding:"omitempty,max=20"`
+	Name             string `json:"name" binding:"omitempty,max=100"`
+	Type             string `json:"type" binding:"omitempty,oneof=asset liability equity revenue expense"`
+	Level            *int   `json:"level" binding:"omitempty,min=1"`
+	ParentID         string `json:"parent_id" binding:"omitempty,max=36"`
+	CashFlowCategory string `json:"cash_flow_category" binding:"omitempty,oneof=cash operating investing financing"`
+	UpdatedBy        string `json:"updated_by" binding:"required"`
 }
 
-// AccountResponse 账户响应
+// AccountResponse 会计科目响应
 type AccountResponse struct {
-	ID             uint    `json:"id"`
-	Code           string  `json:"code"`
-	Name           string  `json:"name"`
-	Type           string  `json:"type"`
-	Currency       string  `json:"currency"`
-	InitialBalance float64 `json:"initial_balance"`
-	CurrentBalance float64 `json:"current_balance"`
-	Description    string  `json:"description"`
-	Active         bool    `json:"active"`
-	CreatedAt      string  `json:"created_at"`
-	UpdatedAt      string  `json:"updated_at"`
+	ID               string `json:"id"`
+	Code             string `json:"code"`
+	Name             string `json:"name"`
+	Type             string `json:"type"`
+	Level            int    `json:"level"`
+	ParentID         string `json:"parent_id"`
+	CashFlowCategory string `json:"cash_flow_category"`
+	CreatedBy        string `json:"created_by"`
+	CreatedAt        string `json:"created_at"`
+	UpdatedBy        string `json:"updated_by"`
+	UpdatedAt        string `json:"updated_at"`
 }
 
 // 凭证相关结构体
 
+// VoucherListRequest 获取凭证列表请求
+type VoucherListRequest struct {
+	Code          string `form:"code" json:"code"`
+	Status        string `form:"status" json:"status" binding:"omitempty,oneof=draft submitted approved posted rejected"`
+	StartDate     string `form:"start_date" json:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate       string `form:"end_date" json:"end_date" binding:"omitempty,datetime=2006-01-02"`
+	ReferenceType string `form:"reference_type" json:"reference_type"`
+	ReferenceID   string `form:"reference_id" json:"reference_id"`
+}
+
 // VoucherCreateRequest 创建凭证请求
 type VoucherCreateRequest struct {
-	Code        string               `json:"code" binding:"required"`
-	Date        string               `json:"date" binding:"required"`
-	Description string               `json:"description" binding:"required"`
-	Items       []VoucherItemRequest `json:"items" binding:"required,dive"`
-	Reference   string               `json:"reference"`
-	Remarks     string               `json:"remarks"`
+	Code         string               `json:"code"`
+	Date         string               `json:"date" binding:"required,datetime=2006-01-02"`
+	Description  string               `json:"description" binding:"required"`
+	Currency     string               `json:"currency" binding:"omitempty,len=3"`
+	ExchangeRate float64              `json:"exchange_rate" binding:"omitempty,gt=0"`
+	Items        []VoucherItemRequest `json:"items" binding:"required,min=2,dive"`
+	Reference    string               `json:"reference"`
+	Remarks      string               `json:"remarks"`
 }
 
-// VoucherItemRequest 凭证项目请求
+// VoucherItemRequest 凭证项目请求，借贷金额为本位币，外币凭证可同时记录原币金额
 type VoucherItemRequest struct {
-	AccountID   uint    `json:"account_id" binding:"required"`
-	Debit       float64 `json:"debit"`
-	Credit      float64 `json:"credit"`
-	Description string  `json:"description"`
+	AccountID      string  `json:"account_id" binding:"required"`
+	Debit          float64 `json:"debit" binding:"min=0"`
+	Credit         float64 `json:"credit" binding:"min=0"`
+	CurrencyAmount float64 `json:"currency_amount" binding:"min=0"`
+	Description    string  `json:"description"`
 }
 
 // VoucherUpdateRequest 更新凭证请求
 type VoucherUpdateRequest struct {
-	Date        string               `json:"date"`
-	Description string               `json:"description"`
-	Items       []VoucherItemRequest `json:"items" binding:"omitempty,dive"`
-	Reference   string               `json:"reference"`
-	Remarks     string               `json:"remarks"`
+	Date         string               `json:"date" binding:"omitempty,datetime=2006-01-02"`
+	Description  string               `json:"description"`
+	Currency     string               `json:"currency" binding:"omitempty,len=3"`
+	ExchangeRate float64              `json:"exchange_rate" binding:"omitempty,gt=0"`
+	Items        []VoucherItemRequest `json:"items" binding:"omitempty,min=2,dive"`
+	Reference    string               `json:"reference"`
+	Remarks      string               `json:"remarks"`
 }
 
 // VoucherResponse 凭证响应
 type VoucherResponse struct {
-	ID          uint                  `json:"id"`
-	Code        string                `json:"code"`
-	Date        string                `json:"date"`
-	Description string                `json:"description"`
-	Status      string                `json:"status"`
-	TotalDebit  float64               `json:"total_debit"`
-	TotalCredit float64               `json:"total_credit"`
-	Reference   string                `json:"reference"`
-	Items       []VoucherItemResponse `json:"items"`
-	Remarks     string                `json:"remarks"`
-	CreatedAt   string                `json:"created_at"`
-	UpdatedAt   string                `json:"updated_at"`
+	ID            string                `json:"id"`
+	Code          string                `json:"code"`
+	Date          string                `json:"date"`
+	Description   string                `json:"description"`
+	Status        string                `json:"status"`
+	TotalDebit    float64               `json:"total_debit"`
+	TotalCredit   float64               `json:"total_credit"`
+	Currency      string                `json:"currency"`
+	ExchangeRate  float64               `json:"exchange_rate"`
+	Reference     string                `json:"reference"`
+	ReferenceType string                `json:"reference_type"`
+	ReferenceID   string                `json:"reference_id"`
+	ReversalOf    string                `json:"reversal_of"`
+	Items         []VoucherItemResponse `json:"items"`
+	Remarks       string                `json:"remarks"`
+	SubmittedAt   string                `json:"submitted_at"`
+	ApprovedAt    string                `json:"approved_at"`
+	PostedAt      string                `json:"posted_at"`
+	CreatedAt     string                `json:"created_at"`
+	UpdatedAt     string                `json:"updated_at"`
 }
 
 // VoucherItemResponse 凭证项目响应
 type VoucherItemResponse struct {
-	ID          uint    `json:"id"`
-	VoucherID   uint    `json:"voucher_id"`
-	AccountID   uint    `json:"account_id"`
-	AccountCode string  `json:"account_code"`
-	AccountName string  `json:"account_name"`
-	Debit       float64 `json:"debit"`
-	Credit      float64 `json:"credit"`
-	Description string  `json:"description"`
+	ID             string  `json:"id"`
+	VoucherID      string  `json:"voucher_id"`
+	LineNo         int     `json:"line_no"`
+	AccountID      string  `json:"account_id"`
+	AccountCode    string  `json:"account_code"`
+	AccountName    string  `json:"account_name"`
+	Debit          float64 `json:"debit"`
+	Credit         float64 `json:"credit"`
+	CurrencyAmount float64 `json:"currency_amount"`
+	Description    string  `json:"description"`
 }
 
 // 付款相关结构体
 
-// PaymentCreateRequest 创建付款请求
+// PaymentCreateRequest 创建付款请求，编号为空时自动生成，币种为空时取供应商默认币种
 type PaymentCreateRequest struct {
-	Code          string  `json:"code" binding:"required"`
-	Date          string  `json:"date" binding:"required"`
-	SupplierID    uint    `json:"supplier_id" binding:"required"`
-	Amount        float64 `json:"amount" binding:"required"`
-	Currency      string  `json:"currency" binding:"required"`
-	ExchangeRate  float64 `json:"exchange_rate"`
-	PaymentMethod string  `json:"payment_method" binding:"required"`
-	BankAccountID uint    `json:"bank_account_id"`
-	Reference     string  `json:"reference"`
-	Description   string  `json:"description" binding:"required"`
-	Remarks       string  `json:"remarks"`
-}
-
-// PaymentUpdateRequest 更新付款请求
+	Code          string                     `json:"code" binding:"omitempty,max=20"`
+	Date          string                     `json:"date" binding:"required,datetime=2006-01-02"`
+	SupplierID    string                     `json:"supplier_id" binding:"required"`
+	Amount        float64                    `json:"amount" binding:"gte=0"`
+	Currency      string                     `json:"currency" binding:"omitempty,len=3"`
+	ExchangeRate  float64                    `json:"exchange_rate" binding:"omitempty,gt=0"`
+	PaymentMethod string                     `json:"payment_method" binding:"required"`
+	BankAccountID string                     `json:"bank_account_id"`
+	Reference     string                     `json:"reference"`
+	Description   string                     `json:"description" binding:"required"`
+	Remarks       string                     `json:"remarks"`
+	Allocations   []PaymentAllocationRequest `json:"allocations" binding:"omitempty,dive"`
+	CreatedBy     string                     `json:"created_by"`
+}
+
+// PaymentUpdateRequest 更新付款请求，传入allocations时整体替换核销明细
 type PaymentUpdateRequest struct {
-	Date          string  `json:"date"`
-	SupplierID    uint    `json:"supplier_id"`
-	Amount        float64 `json:"amount"`
-	Currency      string  `json:"currency"`
-	ExchangeRate  float64 `json:"exchange_rate"`
-	PaymentMethod string  `json:"payment_method"`
-	BankAccountID uint    `json:"bank_account_id"`
-	Reference     string  `json:"reference"`
-	Description   string  `json:"description"`
-	Remarks       string  `json:"remarks"`
-}
-
-// PaymentResponse 付款响应
+	Date          string                     `json:"date" binding:"omitempty,datetime=2006-01-02"`
+	SupplierID    string                     `json:"supplier_id"`
+	Amount        *float64                   `json:"amount" binding:"omitempty,gte=0"`
+	Currency      string                     `json:"currency" binding:"omitempty,len=3"`
+	ExchangeRate  *float64                   `json:"exchange_rate" binding:"omitempty,gt=0"`
+	PaymentMethod string                     `json:"payment_method"`
+	BankAccountID string                     `json:"bank_account_id"`
+	Reference     string                     `json:"reference"`
+	Description   string                     `json:"description"`
+	Remarks       string                     `json:"remarks"`
+	Allocations   []PaymentAllocationRequest `json:"allocations" binding:"omitempty,dive"`
+	UpdatedBy     string                     `json:"updated_by"`
+}
+
+// PaymentAllocationRequest 付款核销明细请求，amount为核销的付款金额，discount_amount为享受的提前付款折扣
+type PaymentAllocationRequest struct {
+	InvoiceID      string  `json:"invoice_id" binding:"required"`
+	Amount         float64 `json:"amount" binding:"gte=0"`
+	DiscountAmount float64 `json:"discount_amount" binding:"gte=0"`
+}
+
+// PaymentResponse 付款响应，unapplied_amount为尚未核销的预付款
 type PaymentResponse struct {
-	ID              uint    `json:"id"`
-	Code            string  `json:"code"`
-	Date            string  `json:"date"`
-	SupplierID      uint    `json:"supplier_id"`
-	SupplierName    string  `json:"supplier_name"`
-	Amount          float64 `json:"amount"`
-	Currency        string  `json:"currency"`
-	ExchangeRate    float64 `json:"exchange_rate"`
-	LocalAmount     float64 `json:"local_amount"`
-	PaymentMethod   string  `json:"payment_method"`
-	BankAccountID   uint    `json:"bank_account_id"`
-	BankAccountName string  `json:"bank_account_name"`
-	Status          string  `json:"status"`
-	Reference       string  `json:"reference"`
-	Description     string  `json:"description"`
-	Remarks         string  `json:"remarks"`
-	CreatedAt       string  `json:"created_at"`
-	UpdatedAt       string  `json:"updated_at"`
+	ID              string                      `json:"id"`
+	Code            string                      `json:"code"`
+	Date            string                      `json:"date"`
+	SupplierID      string                      `json:"supplier_id"`
+	SupplierName    string                      `json:"supplier_name"`
+	Amount          float64                     `json:"amount"`
+	Currency        string                      `json:"currency"`
+	ExchangeRate    float64                     `json:"exchange_rate"`
+	LocalAmount     float64                     `json:"local_amount"`
+	AppliedAmount   float64                     `json:"applied_amount"`
+	UnappliedAmount float64                     `json:"unapplied_amount"`
+	DiscountAmount  float64                     `json:"discount_amount"`
+	PaymentMethod   string                      `json:"payment_method"`
+	BankAccountID   string                      `json:"bank_account_id"`
+	BankAccountName string                      `json:"bank_account_name"`
+	RunID           string                      `json:"run_id"`
+	Status          string                      `json:"status"`
+	Reference       string                      `json:"reference"`
+	Description     string                      `json:"description"`
+	Remarks         string                      `json:"remarks"`
+	Allocations     []PaymentAllocationResponse `json:"allocations"`
+	ProcessedAt     string                      `json:"processed_at"`
+	CreatedAt       string                      `json:"created_at"`
+	UpdatedAt       string                      `json:"updated_at"`
+}
+
+// PaymentAllocationResponse 付款核销明细响应
+type PaymentAllocationResponse struct {
+	ID             string  `json:"id"`
+	InvoiceID      string  `json:"invoice_id"`
+	InvoiceNo      string  `json:"invoice_no"`
+	AllocationDate string  `json:"allocation_date"`
+	Amount         float64 `json:"amount"`
+	DiscountAmount float64 `json:"discount_amount"`
+}
+
+// 付款运行相关结构体
+
+// PaymentRunCreateRequest 创建付款运行请求，选取到期日不晚于due_date或在付款日仍可享受提前付款折扣的待付采购发票，
+// 到期日为空时取付款日，供应商和币种为空时不限
+type PaymentRunCreateRequest struct {
+	Code          string `json:"code" binding:"omitempty,max=20"`
+	PaymentDate   string `json:"payment_date" binding:"required,datetime=2006-01-02"`
+	DueDate       string `json:"due_date" binding:"omitempty,datetime=2006-01-02"`
+	SupplierID    string `json:"supplier_id"`
+	Currency      string `json:"currency" binding:"omitempty,len=3"`
+	PaymentMethod string `json:"payment_method"`
+	BankAccountID string `json:"bank_account_id"`
+	Remarks       string `json:"remarks"`
+	CreatedBy     string `json:"created_by"`
+}
+
+// PaymentRunUpdateRequest 调整付款建议请求，传入items时整体替换付款明细
+type PaymentRunUpdateRequest struct {
+	Items     []PaymentRunItemRequest `json:"items" binding:"omitempty,dive"`
+	Remarks   string                  `json:"remarks"`
+	UpdatedBy string                  `json:"updated_by"`
+}
+
+// PaymentRunItemRequest 付款建议明细请求，discount_amount不能超过付款日可享受的提前付款折扣
+type PaymentRunItemRequest struct {
+	InvoiceID      string  `json:"invoice_id" binding:"required"`
+	PaymentAmount  float64 `json:"payment_amount" binding:"gte=0"`
+	DiscountAmount float64 `json:"discount_amount" binding:"gte=0"`
+}
+
+// PaymentRunApproveRequest 审批付款运行请求
+type PaymentRunApproveRequest struct {
+	ApprovedBy string `json:"approved_by" binding:"required"`
+}
+
+// PaymentRunResponse 付款运行响应，合计金额为本位币
+type PaymentRunResponse struct {
+	ID             string                   `json:"id"`
+	Code           string                   `json:"code"`
+	PaymentDate    string                   `json:"payment_date"`
+	DueDate        string                   `json:"due_date"`
+	SupplierID     string                   `json:"supplier_id"`
+	Currency       string                   `json:"currency"`
+	PaymentMethod  string                   `json:"payment_method"`
+	BankAccountID  string                   `json:"bank_account_id"`
+	TotalAmount    float64                  `json:"total_amount"`
+	DiscountAmount float64                  `json:"discount_amount"`
+	Status         string                   `json:"status"`
+	ApprovedBy     string                   `json:"approved_by"`
+	ApprovedAt     string                   `json:"approved_at"`
+	Remarks        string                   `json:"remarks"`
+	Items          []PaymentRunItemResponse `json:"items"`
+	CreatedBy      string                   `json:"created_by"`
+	CreatedAt      string                   `json:"created_at"`
+	UpdatedAt      string                   `json:"updated_at"`
+}
+
+// PaymentRunItemResponse 付款运行明细响应，discount_date为空表示发票没有提前付款折扣
+type PaymentRunItemResponse struct {
+	ID             string  `json:"id"`
+	InvoiceID      string  `json:"invoice_id"`
+	InvoiceNo      string  `json:"invoice_no"`
+	SupplierID     string  `json:"supplier_id"`
+	SupplierName   string  `json:"supplier_name"`
+	Currency       string  `json:"currency"`
+	DueDate        string  `json:"due_date"`
+	DiscountDate   string  `json:"discount_date"`
+	OpenAmount     float64 `json:"open_amount"`
+	DiscountAmount float64 `json:"discount_amount"`
+	PaymentAmount  float64 `json:"payment_amount"`
+	PaymentID      string  `json:"payment_id"`
 }
 
 // 收款相关结构体
 
-// ReceiptCreateRequest 创建收款请求
-//  type ReceiptCreateRequest struct {
-// 	Code           string  `json:"code" binding:"required"`
-// 	Date           string  `json:"date" binding:"required"`
-// 	CustomerID     uint    `json:"customer_id" binding:"required"`
-// 	Amount         float64 `json:"amount" binding:"required"`
-// 	Currency       string  `json:"currency" binding:"required"`
-// 	ExchangeRate   float64 `json:"exchange_rate"`
-// 	PaymentMethod  string  `json:"payment_method" binding:"required"`
-// 	BankAccountID  uint    `json:"bank_account_id"`
-// 	Reference      string  `json:"reference"`
-// 	Description    string  `json:"description" binding:"required"`
-// 	Remarks        string  `json:"remarks"`
-// }
-
-// ReceiptUpdateRequest 更新收款请求
-//  type ReceiptUpdateRequest struct {
-// 	Date           string  `json:"date"`
-// 	CustomerID     uint    `json:"customer_id"`
-// 	Amount         float64 `json:"amount"`
-// 	Currency       string  `json:"currency"`
-// 	ExchangeRate   float64 `json:"exchange_rate"`
-// 	PaymentMethod  string  `json:"payment_method"`
-// 	BankAccountID  uint    `json:"bank_account_id"`
-// 	Reference      string  `json:"reference"`
-// 	Description    string  `json:"description"`
-// 	Remarks        string  `json:"remarks"`
-// }
-
-// ReceiptResponse 收款响应
-//  type ReceiptResponse struct {
-// 	ID             uint    `json:"id"`
-// 	Code           string  `json:"code"`
-// 	Date           string  `json:"date"`
-// 	CustomerID     uint    `json:"customer_id"`
-// 	CustomerName   string  `json:"customer_name"`
-// 	Amount         float64 `json:"amount"`
-// 	Currency       string  `json:"currency"`
-// 	ExchangeRate   float64 `json:"exchange_rate"`
-// 	LocalAmount    float64 `json:"local_amount"`
-// 	PaymentMethod  string  `json:"payment_method"`
-// 	BankAccountID  uint    `json:"bank_account_id"`
-// 	BankAccountName string  `json:"bank_account_name"`
-// 	Status         string  `json:"status"`
-// 	Reference      string  `json:"reference"`
-// 	Description    string  `json:"description"`
-// 	Remarks        string  `json:"remarks"`
-// 	CreatedAt      string  `json:"created_at"`
-// 	UpdatedAt      string  `json:"updated_at"`
-// }
+// CollectionCreateRequest 创建收款请求，编号为空时自动生成，币种为空时取客户默认币种
+type CollectionCreateRequest struct {
+	Code          string                        `json:"code" binding:"omitempty,max=20"`
+	Date          string                        `json:"date" binding:"required,datetime=2006-01-02"`
+	CustomerID    string                        `json:"customer_id" binding:"required"`
+	Amount        float64                       `json:"amount" binding:"gte=0"`
+	Currency      string                        `json:"currency" binding:"omitempty,len=3"`
+	ExchangeRate  float64                       `json:"exchange_rate" binding:"omitempty,gt=0"`
+	PaymentMethod string                        `json:"payment_method" binding:"required"`
+	BankAccountID string                        `json:"bank_account_id"`
+	Reference     string                        `json:"reference"`
+	Description   string                        `json:"description" binding:"required"`
+	Remarks       string                        `json:"remarks"`
+	Allocations   []CollectionAllocationRequest `json:"allocations" binding:"omitempty,dive"`
+	CreatedBy     string                        `json:"created_by"`
+}
+
+// CollectionUpdateRequest 更新收款请求，传入allocations时整体替换核销明细
+type CollectionUpdateRequest struct {
+	Date          string                        `json:"date" binding:"omitempty,datetime=2006-01-02"`
+	CustomerID    string                        `json:"customer_id"`
+	Amount        *float64                      `json:"amount" binding:"omitempty,gte=0"`
+	Currency      string                        `json:"currency" binding:"omitempty,len=3"`
+	ExchangeRate  *float64                      `json:"exchange_rate" binding:"omitempty,gt=0"`
+	PaymentMethod string                        `json:"payment_method"`
+	BankAccountID string                        `json:"bank_account_id"`
+	Reference     string                        `json:"reference"`
+	Description   string                        `json:"description"`
+	Remarks       string                        `json:"remarks"`
+	Allocations   []CollectionAllocationRequest `json:"allocations" binding:"omitempty,dive"`
+	UpdatedBy     string                        `json:"updated_by"`
+}
+
+// CollectionAllocationRequest 收款核销明细请求，amount为核销的收款金额，write_off_amount为核销的坏账或折让金额
+type CollectionAllocationRequest struct {
+	InvoiceID      string  `json:"invoice_id" binding:"required"`
+	Amount         float64 `json:"amount" binding:"gte=0"`
+	WriteOffAmount float64 `json:"write_off_amount" binding:"gte=0"`
+}
+
+// CollectionApplyRequest 已处理收款的追加核销请求，用于将预收款核销到发票或核销坏账，日期为空时取当天
+type CollectionApplyRequest struct {
+	Date        string                        `json:"date" binding:"omitempty,datetime=2006-01-02"`
+	Allocations []CollectionAllocationRequest `json:"allocations" binding:"required,min=1,dive"`
+	CreatedBy   string                        `json:"created_by"`
+}
+
+// CollectionResponse 收款响应，unapplied_amount为尚未核销的预收款
+type CollectionResponse struct {
+	ID              string                         `json:"id"`
+	Code            string                         `json:"code"`
+	Date            string                         `json:"date"`
+	CustomerID      string                         `json:"customer_id"`
+	CustomerName    string                         `json:"customer_name"`
+	Amount          float64                        `json:"amount"`
+	Currency        string                         `json:"currency"`
+	ExchangeRate    float64                        `json:"exchange_rate"`
+	LocalAmount     float64                        `json:"local_amount"`
+	AppliedAmount   float64                        `json:"applied_amount"`
+	UnappliedAmount float64                        `json:"unapplied_amount"`
+	WriteOffAmount  float64                        `json:"write_off_amount"`
+	PaymentMethod   string                         `json:"payment_method"`
+	BankAccountID   string                         `json:"bank_account_id"`
+	BankAccountName string                         `json:"bank_account_name"`
+	Status          string                         `json:"status"`
+	Reference       string                         `json:"reference"`
+	Description     string                         `json:"description"`
+	Remarks         string                         `json:"remarks"`
+	Allocations     []CollectionAllocationResponse `json:"allocations"`
+	ProcessedAt     string                         `json:"processed_at"`
+	CreatedAt       string                         `json:"created_at"`
+	UpdatedAt       string                         `json:"updated_at"`
+}
+
+// CollectionAllocationResponse 收款核销明细响应
+type CollectionAllocationResponse struct {
+	ID             string  `json:"id"`
+	InvoiceID      string  `json:"invoice_id"`
+	InvoiceNo      string  `json:"invoice_no"`
+	AllocationDate string  `json:"allocation_date"`
+	Amount         float64 `json:"amount"`
+	WriteOffAmount float64 `json:"write_off_amount"`
+}
 
 // 固定资产相关结构体
 
 // AssetCreateRequest 创建固定资产请求
 type AssetCreateRequest struct {
-	Code               string  `json:"code" binding:"required"`
-	Name               string  `json:"name" binding:"required"`
+	Code               string  `json:"code" binding:"required,max=20"`
+	Name               string  `json:"name" binding:"required,max=100"`
 	Category           string  `json:"category" binding:"required"`
-	AcquisitionDate    string  `json:"acquisition_date" binding:"required"`
-	Cost               float64 `json:"cost" binding:"required"`
-	SalvageValue       float64 `json:"salvage_value"`
-	UsefulLife         int     `json:"useful_life" binding:"required"`
-	DepreciationMethod string  `json:"depreciation_method" binding:"required"`
+	AcquisitionDate    string  `json:"acquisition_date" binding:"required,datetime=2006-01-02"`
+	Cost               float64 `json:"cost" binding:"required,gt=0"`
+	SalvageValue       float64 `json:"salvage_value" binding:"omitempty,min=0,ltefield=Cost"`
+	UsefulLife         int     `json:"useful_life" binding:"required,gt=0"`
+	DepreciationMethod string  `json:"depreciation_method" binding:"required,oneof=straight_line declining_balance sum_of_years units_of_production"`
 	Location           string  `json:"location"`
-	DepartmentID       uint    `json:"department_id"`
+	DepartmentID       string  `json:"department_id"`
 	Description        string  `json:"description"`
 }
 
 // AssetUpdateRequest 更新固定资产请求
 type AssetUpdateRequest struct {
-	Code               string  `json:"code"`
-	Name               string  `json:"name"`
-	Category           string  `json:"category"`
-	AcquisitionDate    string  `json:"acquisition_date"`
-	Cost               float64 `json:"cost"`
-	SalvageValue       float64 `json:"salvage_value"`
-	UsefulLife         int     `json:"useful_life"`
-	DepreciationMethod string  `json:"depreciation_method"`
-	Location           string  `json:"location"`
-	DepartmentID       uint    `json:"department_id"`
-	Description        string  `json:"description"`
+	Code               string   `json:"code" binding:"omitempty,max=20"`
+	Name               string   `json:"name" binding:"omitempty,max=100"`
+	Category           string   `json:"category"`
+	AcquisitionDate    string   `json:"acquisition_date" binding:"omitempty,datetime=2006-01-02"`
+	Cost               *float64 `json:"cost" binding:"omitempty,gt=0"`
+	SalvageValue       *float64 `json:"salvage_value" binding:"omitempty,min=0"`
+	UsefulLife         *int     `json:"useful_life" binding:"omitempty,gt=0"`
+	DepreciationMethod string   `json:"depreciation_method" binding:"omitempty,oneof=straight_line declining_balance sum_of_years units_of_production"`
+	Location           string   `json:"location"`
+	DepartmentID       string   `json:"department_id"`
+	Description        string   `json:"description"`
 }
 
 // AssetResponse 固定资产响应
 type AssetResponse struct {
-	ID                      uint    `json:"id"`
+	ID                      string  `json:"id"`
 	Code                    string  `json:"code"`
 	Name                    string  `json:"name"`
 	Category                string  `json:"category"`
@@ -248,7 +406,7 @@ type AssetResponse struct {
 	AccumulatedDepreciation float64 `json:"accumulated_depreciation"`
 	NetBookValue            float64 `json:"net_book_value"`
 	Location                string  `json:"location"`
-	DepartmentID            uint    `json:"department_id"`
+	DepartmentID            string  `json:"department_id"`
 	DepartmentName          string  `json:"department_name"`
 	Status                  string  `json:"status"`
 	Description             string  `json:"description"`
@@ -256,69 +414,91 @@ type AssetResponse struct {
 	UpdatedAt               string  `json:"updated_at"`
 }
 
-// AssetTransferRequest 固定资产转移请求
+// AssetTransferRequest 固定资产转移请求，资产ID取自路径
 type AssetTransferRequest struct {
-	AssetID          uint   `json:"asset_id" binding:"required"`
-	TransferDate     string `json:"transfer_date" binding:"required"`
-	FromDepartmentID uint   `json:"from_department_id"`
-	ToDepartmentID   uint   `json:"to_department_id" binding:"required"`
+	TransferDate     string `json:"transfer_date" binding:"required,datetime=2006-01-02"`
+	FromDepartmentID string `json:"from_department_id"`
+	ToDepartmentID   string `json:"to_department_id" binding:"required"`
 	FromLocation     string `json:"from_location"`
 	ToLocation       string `json:"to_location" binding:"required"`
 	Reason           string `json:"reason" binding:"required"`
 }
 
-// AssetDisposeRequest 固定资产处置请求
+// AssetDisposeRequest 固定资产处置请求，资产ID取自路径
 type AssetDisposeRequest struct {
-	AssetID       uint    `json:"asset_id" binding:"required"`
-	DisposeDate   string  `json:"dispose_date" binding:"required"`
-	DisposeMethod string  `json:"dispose_method" binding:"required"`
-	Proceeds      float64 `json:"proceeds"`
+	DisposeDate   string  `json:"dispose_date" binding:"required,datetime=2006-01-02"`
+	DisposeMethod string  `json:"dispose_method" binding:"required,oneof=sale scrap donation"`
+	Proceeds      float64 `json:"proceeds" binding:"omitempty,min=0"`
 	Reason        string  `json:"reason" binding:"required"`
 }
 
-// DepreciationRequest 折旧计算请求
+// DepreciationRequest 折旧计算请求，未指定资产时计提全部在用资产
 type DepreciationRequest struct {
-	Period   string `json:"period" binding:"required"`
-	AssetIDs []uint `json:"asset_ids"`
-	Category string `json:"category"`
+	Period   string   `json:"period" binding:"required,datetime=2006-01"`
+	AssetIDs []string `json:"asset_ids" binding:"omitempty,dive,required"`
+	Category string   `json:"category"`
+}
+
+// DepreciationResponse 折旧计算响应
+type DepreciationResponse struct {
+	Period            string             `json:"period"`
+	TotalDepreciation float64            `json:"total_depreciation"`
+	Items             []DepreciationItem `json:"items"`
+}
+
+// DepreciationItem 单项资产折旧
+type DepreciationItem struct {
+	AssetID                 string  `json:"asset_id"`
+	AssetCode               string  `json:"asset_code"`
+	AssetName               string  `json:"asset_name"`
+	Depreciation            float64 `json:"depreciation"`
+	AccumulatedDepreciation float64 `json:"accumulated_depreciation"`
+	NetBookValue            float64 `json:"net_book_value"`
 }
 
 // 预算相关结构体
 
 // BudgetCreateRequest 创建预算请求
 type BudgetCreateRequest struct {
-	Code         string              `json:"code" binding:"required"`
-	Name         string              `json:"name" binding:"required"`
-	Year         int                 `json:"year" binding:"required"`
-	DepartmentID uint                `json:"department_id"`
-	TotalAmount  float64             `json:"total_amount" binding:"required"`
-	Items        []BudgetItemRequest `json:"items" binding:"required,dive"`
+	Code         string              `json:"code" binding:"required,max=20"`
+	Name         string              `json:"name" binding:"required,max=100"`
+	Year         int                 `json:"year" binding:"required,min=2000,max=9999"`
+	DepartmentID string              `json:"department_id"`
+	TotalAmount  float64             `json:"total_amount" binding:"required,gt=0"`
+	Items        []BudgetItemRequest `json:"items" binding:"required,min=1,dive"`
 	Description  string              `json:"description"`
 }
 
 // BudgetItemRequest 预算项目请求
 type BudgetItemRequest struct {
-	AccountID   uint    `json:"account_id" binding:"required"`
-	Amount      float64 `json:"amount" binding:"required"`
+	AccountID   string  `json:"account_id" binding:"required"`
+	Amount      float64 `json:"amount" binding:"required,gt=0"`
 	Description string  `json:"description"`
 }
 
 // BudgetUpdateRequest 更新预算请求
 type BudgetUpdateRequest struct {
-	Name         string              `json:"name"`
-	DepartmentID uint                `json:"department_id"`
-	TotalAmount  float64             `json:"total_amount"`
+	Name         string              `json:"name" binding:"omitempty,max=100"`
+	DepartmentID string              `json:"department_id"`
+	TotalAmount  *float64            `json:"total_amount" binding:"omitempty,gt=0"`
 	Items        []BudgetItemRequest `json:"items" binding:"omitempty,dive"`
 	Description  string              `json:"description"`
 }
 
+// BudgetAdjustRequest 预算调整请求
+type BudgetAdjustRequest struct {
+	TotalAmount float64             `json:"total_amount" binding:"required,gt=0"`
+	Items       []BudgetItemRequest `json:"items" binding:"omitempty,dive"`
+	Reason      string              `json:"reason" binding:"required"`
+}
+
 // BudgetResponse 预算响应
 type BudgetResponse struct {
-	ID              uint                 `json:"id"`
+	ID              string               `json:"id"`
 	Code            string               `json:"code"`
 	Name            string               `json:"name"`
 	Year            int                  `json:"year"`
-	DepartmentID    uint                 `json:"department_id"`
+	DepartmentID    string               `json:"department_id"`
 	DepartmentName  string               `json:"department_name"`
 	TotalAmount     float64              `json:"total_amount"`
 	UsedAmount      float64              `json:"used_amount"`
@@ -332,9 +512,9 @@ type BudgetResponse struct {
 
 // BudgetItemResponse 预算项目响应
 type BudgetItemResponse struct {
-	ID              uint    `json:"id"`
-	BudgetID        uint    `json:"budget_id"`
-	AccountID       uint    `json:"account_id"`
+	ID              string  `json:"id"`
+	BudgetID        string  `json:"budget_id"`
+	AccountID       string  `json:"account_id"`
 	AccountCode     string  `json:"account_code"`
 	AccountName     string  `json:"account_name"`
 	Amount          float64 `json:"amount"`
@@ -343,32 +523,47 @@ type BudgetItemResponse struct {
 	Description     string  `json:"description"`
 }
 
+// BudgetExecutionResponse 预算执行情况响应
+type BudgetExecutionResponse struct {
+	BudgetID        string               `json:"budget_id"`
+	TotalAmount     float64              `json:"total_amount"`
+	UsedAmount      float64              `json:"used_amount"`
+	RemainingAmount float64              `json:"remaining_amount"`
+	ExecutionRate   float64              `json:"execution_rate"`
+	Items           []BudgetItemResponse `json:"items"`
+}
+
 // 财务报表相关结构体
 
-// BalanceSheetRequest 资产负债表请求
+// BalanceSheetRequest 资产负债表请求，未指定比较日期时与上年末比较
 type BalanceSheetRequest struct {
-	Date           string `json:"date" binding:"required"`
-	ComparisonDate string `json:"comparison_date"`
+	Date           string `form:"date" json:"date" binding:"required,datetime=2006-01-02"`
+	ComparisonDate string `form:"comparison_date" json:"comparison_date" binding:"omitempty,datetime=2006-01-02"`
 }
 
 // BalanceSheetResponse 资产负债表响应
 type BalanceSheetResponse struct {
-	Date             string                  `json:"date"`
-	Assets           []BalanceSheetItem      `json:"assets"`
-	TotalAssets      float64                 `json:"total_assets"`
-	Liabilities      []BalanceSheetItem      `json:"liabilities"`
-	TotalLiabilities float64                 `json:"total_liabilities"`
-	Equity           []BalanceSheetItem      `json:"equity"`
-	TotalEquity      float64                 `json:"total_equity"`
-	Comparison       *BalanceSheetComparison `json:"comparison,omitempty"`
+	Date                      string                  `json:"date"`
+	Assets                    []BalanceSheetItem      `json:"assets"`
+	TotalAssets               float64                 `json:"total_assets"`
+	Liabilities               []BalanceSheetItem      `json:"liabilities"`
+	TotalLiabilities          float64                 `json:"total_liabilities"`
+	Equity                    []BalanceSheetItem      `json:"equity"`
+	TotalEquity               float64                 `json:"total_equity"`
+	TotalLiabilitiesAndEquity float64                 `json:"total_liabilities_and_equity"`
+	Balanced                  bool                    `json:"balanced"`
+	Comparison                *BalanceSheetComparison `json:"comparison,omitempty"`
 }
 
 // BalanceSheetItem 资产负债表项目
 type BalanceSheetItem struct {
-	AccountCode string  `json:"account_code"`
-	AccountName string  `json:"account_name"`
-	Amount      float64 `json:"amount"`
-	Percentage  float64 `json:"percentage"`
+	AccountID        string  `json:"account_id"`
+	AccountCode      string  `json:"account_code"`
+	AccountName      string  `json:"account_name"`
+	Level            int     `json:"level"`
+	Amount           float64 `json:"amount"`
+	ComparisonAmount float64 `json:"comparison_amount"`
+	Percentage       float64 `json:"percentage"`
 }
 
 // BalanceSheetComparison 资产负债表比较
@@ -382,12 +577,12 @@ type BalanceSheetComparison struct {
 	EquityChange     float64 `json:"equity_change"`
 }
 
-// IncomeStatementRequest 利润表请求
+// IncomeStatementRequest 利润表请求，未指定比较期间时与上一等长期间比较
 type IncomeStatementRequest struct {
-	StartDate           string `json:"start_date" binding:"required"`
-	EndDate             string `json:"end_date" binding:"required"`
-	ComparisonStartDate string `json:"comparison_start_date"`
-	ComparisonEndDate   string `json:"comparison_end_date"`
+	StartDate           string `form:"start_date" json:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate             string `form:"end_date" json:"end_date" binding:"required,datetime=2006-01-02"`
+	ComparisonStartDate string `form:"comparison_start_date" json:"comparison_start_date" binding:"omitempty,datetime=2006-01-02"`
+	ComparisonEndDate   string `form:"comparison_end_date" json:"comparison_end_date" binding:"omitempty,datetime=2006-01-02"`
 }
 
 // IncomeStatementResponse 利润表响应
@@ -403,10 +598,13 @@ type IncomeStatementResponse struct {
 
 // IncomeStatementItem 利润表项目
 type IncomeStatementItem struct {
-	AccountCode string  `json:"account_code"`
-	AccountName string  `json:"account_name"`
-	Amount      float64 `json:"amount"`
-	Percentage  float64 `json:"percentage"`
+	AccountID        string  `json:"account_id"`
+	AccountCode      string  `json:"account_code"`
+	AccountName      string  `json:"account_name"`
+	Level            int     `json:"level"`
+	Amount           float64 `json:"amount"`
+	ComparisonAmount float64 `json:"comparison_amount"`
+	Percentage       float64 `json:"percentage"`
 }
 
 // IncomeStatementComparison 利润表比较
@@ -420,28 +618,443 @@ type IncomeStatementComparison struct {
 	IncomeChange  float64 `json:"income_change"`
 }
 
-// CashFlowStatementRequest 现金流量表请求
+// CashFlowStatementRequest 现金流量表请求，未指定比较期间时与上一等长期间比较
 type CashFlowStatementRequest struct {
-	StartDate string `json:"start_date" binding:"required"`
-	EndDate   string `json:"end_date" binding:"required"`
+	StartDate           string `form:"start_date" json:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate             string `form:"end_date" json:"end_date" binding:"required,datetime=2006-01-02"`
+	ComparisonStartDate string `form:"comparison_start_date" json:"comparison_start_date" binding:"omitempty,datetime=2006-01-02"`
+	ComparisonEndDate   string `form:"comparison_end_date" json:"comparison_end_date" binding:"omitempty,datetime=2006-01-02"`
 }
 
-// CashFlowStatementResponse 现金流量表响应
+// CashFlowStatementResponse 现金流量表响应（间接法）
 type CashFlowStatementResponse struct {
-	Period              string         `json:"period"`
-	OperatingActivities []CashFlowItem `json:"operating_activities"`
-	TotalOperating      float64        `json:"total_operating"`
-	InvestingActivities []CashFlowItem `json:"investing_activities"`
-	TotalInvesting      float64        `json:"total_investing"`
-	FinancingActivities []CashFlowItem `json:"financing_activities"`
-	TotalFinancing      float64        `json:"total_financing"`
-	NetChangeCash       float64        `json:"net_change_cash"`
-	BeginningCash       float64        `json:"beginning_cash"`
-	EndingCash          float64        `json:"ending_cash"`
+	Period              string              `json:"period"`
+	OperatingActivities []CashFlowItem      `json:"operating_activities"`
+	TotalOperating      float64             `json:"total_operating"`
+	InvestingActivities []CashFlowItem      `json:"investing_activities"`
+	TotalInvesting      float64             `json:"total_investing"`
+	FinancingActivities []CashFlowItem      `json:"financing_activities"`
+	TotalFinancing      float64             `json:"total_financing"`
+	NetChangeCash       float64             `json:"net_change_cash"`
+	BeginningCash       float64             `json:"beginning_cash"`
+	EndingCash          float64             `json:"ending_cash"`
+	Comparison          *CashFlowComparison `json:"comparison,omitempty"`
 }
 
 // CashFlowItem 现金流量表项目
 type CashFlowItem struct {
+	AccountID        string  `json:"account_id"`
+	AccountCode      string  `json:"account_code"`
+	Description      string  `json:"description"`
+	Amount           float64 `json:"amount"`
+	ComparisonAmount float64 `json:"comparison_amount"`
+}
+
+// CashFlowComparison 现金流量表比较
+type CashFlowComparison struct {
+	Period         string  `json:"period"`
+	TotalOperating float64 `json:"total_operating"`
+	TotalInvesting float64 `json:"total_investing"`
+	TotalFinancing float64 `json:"total_financing"`
+	NetChangeCash  float64 `json:"net_change_cash"`
+	BeginningCash  float64 `json:"beginning_cash"`
+	EndingCash     float64 `json:"ending_cash"`
+}
+
+// AccountTransactionsRequest 科目明细账请求
+type AccountTransactionsRequest struct {
+	StartDate string `form:"start_date" json:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate   string `form:"end_date" json:"end_date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// AccountLedgerResponse 科目明细账响应
+type AccountLedgerResponse struct {
+	AccountID      string               `json:"account_id"`
+	AccountCode    string               `json:"account_code"`
+	AccountName    string               `json:"account_name"`
+	AccountType    string               `json:"account_type"`
+	OpeningBalance float64              `json:"opening_balance"`
+	TotalDebit     float64              `json:"total_debit"`
+	TotalCredit    float64              `json:"total_credit"`
+	ClosingBalance float64              `json:"closing_balance"`
+	Entries        []AccountLedgerEntry `json:"entries"`
+}
+
+// AccountLedgerEntry 科目明细账分录
+type AccountLedgerEntry struct {
+	VoucherID   string  `json:"voucher_id"`
+	VoucherCode string  `json:"voucher_code"`
+	Date        string  `json:"date"`
 	Description string  `json:"description"`
-	Amount      float64 `json:"amount"`
+	Debit       float64 `json:"debit"`
+	Credit      float64 `json:"credit"`
+	Balance     float64 `json:"balance"`
+}
+
+// GeneralLedgerRequest 总账请求
+type GeneralLedgerRequest struct {
+	StartDate string `form:"start_date" json:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate   string `form:"end_date" json:"end_date" binding:"required,datetime=2006-01-02"`
+	AccountID string `form:"account_id" json:"account_id"`
+}
+
+// GeneralLedgerResponse 总账响应
+type GeneralLedgerResponse struct {
+	Period   string                  `json:"period"`
+	Accounts []AccountLedgerResponse `json:"accounts"`
+}
+
+// TrialBalanceRequest 试算平衡表请求
+type TrialBalanceRequest struct {
+	StartDate string `form:"start_date" json:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate   string `form:"end_date" json:"end_date" binding:"required,datetime=2006-01-02"`
+}
+
+// TrialBalanceResponse 试算平衡表响应
+type TrialBalanceResponse struct {
+	Period             string             `json:"period"`
+	Items              []TrialBalanceItem `json:"items"`
+	TotalOpeningDebit  float64            `json:"total_opening_debit"`
+	TotalOpeningCredit float64            `json:"total_opening_credit"`
+	TotalPeriodDebit   float64            `json:"total_period_debit"`
+	TotalPeriodCredit  float64            `json:"total_period_credit"`
+	TotalClosingDebit  float64            `json:"total_closing_debit"`
+	TotalClosingCredit float64            `json:"total_closing_credit"`
+	Balanced           bool               `json:"balanced"`
+}
+
+// TrialBalanceItem 试算平衡表项目
+type TrialBalanceItem struct {
+	AccountID     string  `json:"account_id"`
+	AccountCode   string  `json:"account_code"`
+	AccountName   string  `json:"account_name"`
+	AccountType   string  `json:"account_type"`
+	OpeningDebit  float64 `json:"opening_debit"`
+	OpeningCredit float64 `json:"opening_credit"`
+	PeriodDebit   float64 `json:"period_debit"`
+	PeriodCredit  float64 `json:"period_credit"`
+	ClosingDebit  float64 `json:"closing_debit"`
+	ClosingCredit float64 `json:"closing_credit"`
+}
+
+// 会计期间相关结构体
+
+// FiscalYearCreateRequest 创建会计年度请求，按自然月生成12个会计期间
+type FiscalYearCreateRequest struct {
+	Year       int    `json:"year" binding:"required,min=1900,max=9999"`
+	StartMonth int    `json:"start_month" binding:"omitempty,min=1,max=12"`
+	CreatedBy  string `json:"created_by"`
+}
+
+// FiscalYearResponse 会计年度响应
+type FiscalYearResponse struct {
+	ID        string           `json:"id"`
+	Year      int              `json:"year"`
+	Name      string           `json:"name"`
+	StartDate string           `json:"start_date"`
+	EndDate   string           `json:"end_date"`
+	Status    string           `json:"status"`
+	Periods   []PeriodResponse `json:"periods"`
+	CreatedAt string           `json:"created_at"`
+	UpdatedAt string           `json:"updated_at"`
+}
+
+// PeriodCloseRequest 期末结账请求，未指定科目时使用配置的损益结转科目
+type PeriodCloseRequest struct {
+	RetainedEarningsAccountID string `json:"retained_earnings_account_id"`
+	ClosedBy                  string `json:"closed_by"`
+}
+
+// PeriodResponse 会计期间响应
+type PeriodResponse struct {
+	ID               string `json:"id"`
+	FiscalYearID     string `json:"fiscal_year_id"`
+	PeriodNo         int    `json:"period_no"`
+	Name             string `json:"name"`
+	StartDate        string `json:"start_date"`
+	EndDate          string `json:"end_date"`
+	Status           string `json:"status"`
+	ClosingVoucherID string `json:"closing_voucher_id"`
+	ClosedAt         string `json:"closed_at"`
+}
+
+// 自动记账规则相关结构体
+
+// PostingRuleCreateRequest 创建记账规则请求
+type PostingRuleCreateRequest struct {
+	DocumentType      string `json:"document_type" binding:"required,oneof=sales_invoice purchase_invoice inventory_transaction fx_revaluation sales_receipt sales_return purchase_payment"`
+	Event             string `json:"event" binding:"required,max=50"`
+	Sequence          int    `json:"sequence"`
+	AmountField       string `json:"amount_field" binding:"required"`
+	DebitAccountCode  string `json:"debit_account_code" binding:"required"`
+	CreditAccountCode string `json:"credit_account_code" binding:"required"`
+	Description       string `json:"description" binding:"max=200"`
+	Status            string `json:"status" binding:"omitempty,oneof=active inactive"`
+	CreatedBy         string `json:"created_by"`
+}
+
+// PostingRuleUpdateRequest 更新记账规则请求
+type PostingRuleUpdateRequest struct {
+	Event             string `json:"event" binding:"omitempty,max=50"`
+	Sequence          *int   `json:"sequence"`
+	AmountField       string `json:"amount_field"`
+	DebitAccountCode  string `json:"debit_account_code"`
+	CreditAccountCode string `json:"credit_account_code"`
+	Description       string `json:"description" binding:"max=200"`
+	Status            string `json:"status" binding:"omitempty,oneof=active inactive"`
+	UpdatedBy         string `json:"updated_by"`
+}
+
+// PostingRuleResponse 记账规则响应
+type PostingRuleResponse struct {
+	ID                string `json:"id"`
+	DocumentType      string `json:"document_type"`
+	Event             string `json:"event"`
+	Sequence          int    `json:"sequence"`
+	AmountField       string `json:"amount_field"`
+	DebitAccountCode  string `json:"debit_account_code"`
+	CreditAccountCode string `json:"credit_account_code"`
+	Description       string `json:"description"`
+	Status            string `json:"status"`
+	CreatedAt         string `json:"created_at"`
+	UpdatedAt         string `json:"updated_at"`
+}
+
+// 税务相关结构体
+
+// TaxRateRequest 税率请求，税率为百分比，未指定失效日期表示长期有效
+type TaxRateRequest struct {
+	Rate      float64 `json:"rate" binding:"min=0,max=100"`
+	ValidFrom string  `json:"valid_from" binding:"required,datetime=2006-01-02"`
+	ValidTo   string  `json:"valid_to" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// TaxCodeCreateRequest 创建税码请求
+type TaxCodeCreateRequest struct {
+	Code        string           `json:"code" binding:"required,max=20"`
+	Name        string           `json:"name" binding:"required,max=100"`
+	Description string           `json:"description" binding:"max=200"`
+	Status      string           `json:"status" binding:"omitempty,oneof=active inactive"`
+	Rates       []TaxRateRequest `json:"rates" binding:"required,min=1,dive"`
+	CreatedBy   string           `json:"created_by"`
+}
+
+// TaxCodeUpdateRequest 更新税码请求，传入税率时整体替换原税率
+type TaxCodeUpdateRequest struct {
+	Name        string           `json:"name" binding:"omitempty,max=100"`
+	Description string           `json:"description" binding:"max=200"`
+	Status      string           `json:"status" binding:"omitempty,oneof=active inactive"`
+	Rates       []TaxRateRequest `json:"rates" binding:"omitempty,dive"`
+	UpdatedBy   string           `json:"updated_by"`
+}
+
+// TaxCodeResponse 税码响应
+type TaxCodeResponse struct {
+	ID          string            `json:"id"`
+	Code        string            `json:"code"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Status      string            `json:"status"`
+	Rates       []TaxRateResponse `json:"rates"`
+	CreatedAt   string            `json:"created_at"`
+	UpdatedAt   string            `json:"updated_at"`
+}
+
+// TaxRateResponse 税率响应
+type TaxRateResponse struct {
+	ID        string  `json:"id"`
+	Rate      float64 `json:"rate"`
+	ValidFrom string  `json:"valid_from"`
+	ValidTo   string  `json:"valid_to"`
+}
+
+// TaxRuleCreateRequest 创建税码确定规则请求，纳税状态和税收分类为空表示不限
+type TaxRuleCreateRequest struct {
+	Direction       string `json:"direction" binding:"required,oneof=sales purchase"`
+	PartyTaxStatus  string `json:"party_tax_status" binding:"max=20"`
+	ItemTaxCategory string `json:"item_tax_category" binding:"max=20"`
+	TaxCodeID       string `json:"tax_code_id" binding:"required"`
+	Priority        int    `json:"priority"`
+	Status          string `json:"status" binding:"omitempty,oneof=active inactive"`
+	CreatedBy       string `json:"created_by"`
+}
+
+// TaxRuleUpdateRequest 更新税码确定规则请求
+type TaxRuleUpdateRequest struct {
+	PartyTaxStatus  *string `json:"party_tax_status" binding:"omitempty,max=20"`
+	ItemTaxCategory *string `json:"item_tax_category" binding:"omitempty,max=20"`
+	TaxCodeID       string  `json:"tax_code_id"`
+	Priority        *int    `json:"priority"`
+	Status          string  `json:"status" binding:"omitempty,oneof=active inactive"`
+	UpdatedBy       string  `json:"updated_by"`
+}
+
+// TaxRuleResponse 税码确定规则响应
+type TaxRuleResponse struct {
+	ID              string `json:"id"`
+	Direction       string `json:"direction"`
+	PartyTaxStatus  string `json:"party_tax_status"`
+	ItemTaxCategory string `json:"item_tax_category"`
+	TaxCodeID       string `json:"tax_code_id"`
+	TaxCode         string `json:"tax_code"`
+	Priority        int    `json:"priority"`
+	Status          string `json:"status"`
+	CreatedAt       string `json:"created_at"`
+	UpdatedAt       string `json:"updated_at"`
+}
+
+// VATReportRequest 增值税汇总报表请求
+type VATReportRequest struct {
+	StartDate string `form:"start_date" json:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate   string `form:"end_date" json:"end_date" binding:"required,datetime=2006-01-02"`
+}
+
+// VATReportResponse 增值税汇总报表响应，应纳税额为销项税额减进项税额
+type VATReportResponse struct {
+	StartDate      string          `json:"start_date"`
+	EndDate        string          `json:"end_date"`
+	OutputTax      []VATReportItem `json:"output_tax"`
+	InputTax       []VATReportItem `json:"input_tax"`
+	OutputTaxTotal float64         `json:"output_tax_total"`
+	InputTaxTotal  float64         `json:"input_tax_total"`
+	TaxPayable     float64         `json:"tax_payable"`
+}
+
+// VATReportItem 按税码和税率汇总的税额，未分明细的采购发票税码为空
+type VATReportItem struct {
+	TaxCode      string  `json:"tax_code"`
+	TaxName      string  `json:"tax_name"`
+	TaxRate      float64 `json:"tax_rate"`
+	NetAmount    float64 `json:"net_amount"`
+	TaxAmount    float64 `json:"tax_amount"`
+	InvoiceCount int     `json:"invoice_count"`
+}
+
+// 外币相关结构体
+
+// CurrencyCreateRequest 创建币种请求，币种代码使用ISO 4217三位字母代码
+type CurrencyCreateRequest struct {
+	Code      string `json:"code" binding:"required,len=3,alpha"`
+	Name      string `json:"name" binding:"required,max=50"`
+	Symbol    string `json:"symbol" binding:"max=10"`
+	Status    string `json:"status" binding:"omitempty,oneof=active inactive"`
+	CreatedBy string `json:"created_by"`
+}
+
+// CurrencyUpdateRequest 更新币种请求
+type CurrencyUpdateRequest struct {
+	Name      string `json:"name" binding:"omitempty,max=50"`
+	Symbol    string `json:"symbol" binding:"max=10"`
+	Status    string `json:"status" binding:"omitempty,oneof=active inactive"`
+	UpdatedBy string `json:"updated_by"`
+}
+
+// CurrencyResponse 币种响应
+type CurrencyResponse struct {
+	ID        string `json:"id"`
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	Symbol    string `json:"symbol"`
+	IsBase    bool   `json:"is_base"`
+	Status    string `json:"status"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// ExchangeRateCreateRequest 维护汇率请求，汇率为1单位外币折合本位币的金额，同一币种同一日期重复提交时覆盖
+type ExchangeRateCreateRequest struct {
+	CurrencyCode string  `json:"currency_code" binding:"required,len=3"`
+	RateDate     string  `json:"rate_date" binding:"required,datetime=2006-01-02"`
+	Rate         float64 `json:"rate" binding:"required,gt=0"`
+	CreatedBy    string  `json:"created_by"`
+}
+
+// ExchangeRateResponse 汇率响应
+type ExchangeRateResponse struct {
+	ID           string  `json:"id"`
+	CurrencyCode string  `json:"currency_code"`
+	RateDate     string  `json:"rate_date"`
+	Rate         float64 `json:"rate"`
+	Source       string  `json:"source"`
+	CreatedAt    string  `json:"created_at"`
+	UpdatedAt    string  `json:"updated_at"`
+}
+
+// ExchangeRateImportResponse 汇率导入结果，任一行校验失败时整批不导入
+type ExchangeRateImportResponse struct {
+	Imported int `json:"imported"`
+}
+
+// FXRevaluationRequest 期末汇兑重估请求
+type FXRevaluationRequest struct {
+	CreatedBy string `json:"created_by"`
+}
+
+// FXRevaluationResponse 期末汇兑重估响应，应收差额为正表示应收增值，应付差额为正表示应付增加
+type FXRevaluationResponse struct {
+	ID                string                      `json:"id"`
+	RevaluationNo     string                      `json:"revaluation_no"`
+	PeriodID          string                      `json:"period_id"`
+	PeriodName        string                      `json:"period_name"`
+	RevaluationDate   string                      `json:"revaluation_date"`
+	ReceivableAmount  float64                     `json:"receivable_amount"`
+	PayableAmount     float64                     `json:"payable_amount"`
+	JournalID         string                      `json:"journal_id"`
+	ReversalJournalID string                      `json:"reversal_journal_id"`
+	Items             []FXRevaluationItemResponse `json:"items"`
+	CreatedAt         string                      `json:"created_at"`
+}
+
+// FXRevaluationItemResponse 汇兑重估明细响应
+type FXRevaluationItemResponse struct {
+	DocumentType    string  `json:"document_type"`
+	DocumentID      string  `json:"document_id"`
+	DocumentNo      string  `json:"document_no"`
+	Currency        string  `json:"currency"`
+	OpenAmount      float64 `json:"open_amount"`
+	OriginalRate    float64 `json:"original_rate"`
+	RevaluationRate float64 `json:"revaluation_rate"`
+	CarryingAmount  float64 `json:"carrying_amount"`
+	RevaluedAmount  float64 `json:"revalued_amount"`
+	Difference      float64 `json:"difference"`
+}
+
+// 往来报表相关结构体
+
+// AgingReportRequest 应收/应付账龄报表请求，未指定日期时取当天，未指定分段时按配置的账龄分段；
+// basis为账龄计算基准，due_date按逾期天数（未到期计入current），invoice_date按开票天数
+type AgingReportRequest struct {
+	Date      string `form:"date" json:"date" binding:"omitempty,datetime=2006-01-02"`
+	PartnerID string `form:"partner_id" json:"partner_id"`
+	Buckets   string `form:"buckets" json:"buckets"`
+	Basis     string `form:"basis" json:"basis" binding:"omitempty,oneof=due_date invoice_date"`
+}
+
+// AgingReportResponse 应收/应付账龄报表响应，金额为本位币
+type AgingReportResponse struct {
+	Date    string            `json:"date"`
+	Basis   string            `json:"basis"`
+	Buckets []string          `json:"buckets"`
+	Total   float64           `json:"total"`
+	Items   []AgingReportItem `json:"items"`
+}
+
+// AgingReportItem 往来单位账龄明细，buckets与响应中的分段一一对应，
+// unapplied为尚未核销的预收/预付款，balance为total扣除unapplied后的净额
+type AgingReportItem struct {
+	PartnerID   string    `json:"partner_id"`
+	PartnerName string    `json:"partner_name"`
+	Current     float64   `json:"current"`
+	Buckets     []float64 `json:"buckets"`
+	Total       float64   `json:"total"`
+	Unapplied   float64   `json:"unapplied"`
+	Balance     float64   `json:"balance"`
+}
+
+// ExportFinanceReportRequest 导出财务报表请求
+type ExportFinanceReportRequest struct {
+	ReportType string `form:"report_type" binding:"required,oneof=balance_sheet income_statement cash_flow trial_balance general_ledger accounts_receivable accounts_payable"`
+	Date       string `form:"date" binding:"omitempty,datetime=2006-01-02"`
+	StartDate  string `form:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate    string `form:"end_date" binding:"omitempty,datetime=2006-01-02"`
 }
@@ -4,17 +4,6 @@ import "time"
 
 // 仓库管理相关
 
-// GetWarehouseListRequest 获取仓库列表请求
-type GetWarehouseListRequest struct {
-	Page        int    `form:"page" binding:"omitempty,min=1"`
-	PageSize    int    `form:"pageSize" binding:"omitempty,min=1,max=100"`
-	WarehouseNo string `form:"warehouseNo" binding:"omitempty"`
-	Name        string `form:"name" binding:"omitempty"`
-	Status      string `form:"status" binding:"omitempty,oneof=active inactive"`
-	Type        string `form:"type" binding:"omitempty,oneof=raw_material finished_goods semi_finished tools"`
-	Region      string `form:"region" binding:"omitempty"`
-}
-
 // LocationResponse 库位响应
 type LocationResponse struct {
 	ID           string    `json:"id"`
@@ -34,52 +23,55 @@ type LocationResponse struct {
 
 // WarehouseResponse 仓库响应
 type WarehouseResponse struct {
-	ID           string             `json:"id"`
-	Code         string             `json:"code"`
-	Name         string             `json:"name"`
-	Type         string             `json:"type"`
-	Address      string             `json:"address"`
-	Region       string             `json:"region"`
-	Contact      string             `json:"contact"`
-	Phone        string             `json:"phone"`
-	Description  string             `json:"description,omitempty"`
-	Capacity     int                `json:"capacity,omitempty"`
-	UsedCapacity int                `json:"usedCapacity,omitempty"`
-	Status       string             `json:"status"`
-	Locations    []LocationResponse `json:"locations,omitempty"`
-	CreatedBy    string             `json:"createdBy"`
-	CreatedAt    time.Time          `json:"createdAt"`
-	UpdatedBy    string             `json:"updatedBy"`
-	UpdatedAt    time.Time          `json:"updatedAt"`
+	ID                 string             `json:"id"`
+	Code               string             `json:"code"`
+	Name               string             `json:"name"`
+	Type               string             `json:"type"`
+	Address            string             `json:"address"`
+	Region             string             `json:"region"`
+	Contact            string             `json:"contact"`
+	Phone              string             `json:"phone"`
+	Description        string             `json:"description,omitempty"`
+	Capacity           int                `json:"capacity,omitempty"`
+	UsedCapacity       int                `json:"usedCapacity,omitempty"`
+	AllowNegativeStock bool               `json:"allowNegativeStock"`
+	Status             string             `json:"status"`
+	Locations          []LocationResponse `json:"locations,omitempty"`
+	CreatedBy          string             `json:"createdBy"`
+	CreatedAt          time.Time          `json:"createdAt"`
+	UpdatedBy          string             `json:"updatedBy"`
+	UpdatedAt          time.Time          `json:"updatedAt"`
 }
 
 // CreateWarehouseRequest 创建仓库请求
 type CreateWarehouseRequest struct {
-	ID          string `json:"id" binding:"required"`
-	Code        string `json:"code" binding:"required"`
-	Name        string `json:"name" binding:"required"`
-	Type        string `json:"type" binding:"required,oneof=raw_material finished_goods semi_finished tools"`
-	Address     string `json:"address" binding:"required"`
-	Region      string `json:"region" binding:"required"`
-	Contact     string `json:"contact" binding:"required"`
-	Phone       string `json:"phone" binding:"required"`
-	Description string `json:"description" binding:"omitempty"`
-	Capacity    int    `json:"capacity" binding:"required,min=1"`
-	Status      string `json:"status" binding:"omitempty,oneof=active inactive"`
-	CreatedBy   string `json:"createdBy" binding:"required"`
+	ID                 string `json:"id" binding:"required"`
+	Code               string `json:"code" binding:"required"`
+	Name               string `json:"name" binding:"required"`
+	Type               string `json:"type" binding:"required,oneof=raw_material finished_goods semi_finished tools"`
+	Address            string `json:"address" binding:"required"`
+	Region             string `json:"region" binding:"required"`
+	Contact            string `json:"contact" binding:"required"`
+	Phone              string `json:"phone" binding:"required"`
+	Description        string `json:"description" binding:"omitempty"`
+	Capacity           int    `json:"capacity" binding:"required,min=1"`
+	AllowNegativeStock bool   `json:"allowNegativeStock" binding:"omitempty"`
+	Status             string `json:"status" binding:"omitempty,oneof=active inactive"`
+	CreatedBy          string `json:"createdBy" binding:"required"`
 }
 
 // UpdateWarehouseRequest 更新仓库请求
 type UpdateWarehouseRequest struct {
-	Name        string `json:"name" binding:"omitempty"`
-	Type        string `json:"type" binding:"omitempty,oneof=raw_material finished_goods semi_finished tools"`
-	Address     string `json:"address" binding:"omitempty"`
-	Region      string `json:"region" binding:"omitempty"`
-	Contact     string `json:"contact" binding:"omitempty"`
-	Phone       string `json:"phone" binding:"omitempty"`
-	Description string `json:"description" binding:"omitempty"`
-	Capacity    int    `json:"capacity" binding:"omitempty,min=1"`
-	Status      string `json:"status" binding:"omitempty,oneof=active inactive"`
+	Name               string `json:"name" binding:"omitempty"`
+	Type               string `json:"type" binding:"omitempty,oneof=raw_material finished_goods semi_finished tools"`
+	Address            string `json:"address" binding:"omitempty"`
+	Region             string `json:"region" binding:"omitempty"`
+	Contact            string `json:"contact" binding:"omitempty"`
+	Phone              string `json:"phone" binding:"omitempty"`
+	Description        string `json:"description" binding:"omitempty"`
+	Capacity           int    `json:"capacity" binding:"omitempty,min=1"`
+	AllowNegativeStock *bool  `json:"allowNegativeStock" binding:"omitempty"`
+	Status             string `json:"status" binding:"omitempty,oneof=active inactive"`
 }
 
 // AddWarehouseLocationRequest 添加库位请求
@@ -95,44 +87,38 @@ type AddWarehouseLocationRequest struct {
 
 // 物料管理相关
 
-// GetItemListRequest 获取物料列表请求
-type GetItemListRequest struct {
-	Page     int    `form:"page" binding:"omitempty,min=1"`
-	PageSize int    `form:"pageSize" binding:"omitempty,min=1,max=100"`
-	ItemNo   string `form:"itemNo" binding:"omitempty"`
-	Name     string `form:"name" binding:"omitempty"`
-	SKU      string `form:"sku" binding:"omitempty"`
-	Category string `form:"category" binding:"omitempty"`
-	Status   string `form:"status" binding:"omitempty,oneof=active inactive"`
-	Unit     string `form:"unit" binding:"omitempty"`
-}
-
 // ItemResponse 物料响应
 type ItemResponse struct {
-	ID          string    `json:"id"`
-	ItemNo      string    `json:"itemNo"`
-	Name        string    `json:"name"`
-	Description string    `json:"description,omitempty"`
-	CategoryID  string    `json:"category_id"`
-	Unit        string    `json:"unit"`
-	Type        string    `json:"type"`
-	Status      string    `json:"status"`
-	CreatedBy   string    `json:"createdBy"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedBy   string    `json:"updatedBy"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID           string    `json:"id"`
+	ItemNo       string    `json:"itemNo"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description,omitempty"`
+	CategoryID   string    `json:"category_id"`
+	Unit         string    `json:"unit"`
+	Type         string    `json:"type"`
+	CostMethod   string    `json:"costMethod"`
+	StandardCost float64   `json:"standardCost"`
+	TaxCategory  string    `json:"taxCategory"`
+	Status       string    `json:"status"`
+	CreatedBy    string    `json:"createdBy"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedBy    string    `json:"updatedBy"`
+	UpdatedAt    time.Time `json:"updatedAt"`
 }
 
 // CreateItemRequest 创建物料请求
 type CreateItemRequest struct {
-	ItemNo      string `json:"itemNo" binding:"required"`
-	Name        string `json:"name" binding:"required"`
-	Description string `json:"description" binding:"omitempty"`
-	CategoryID  string `json:"category_id" binding:"required"`
-	Unit        string `json:"unit" binding:"required"`
-	Type        string `json:"type" binding:"required"`
-	Status      string `json:"status" binding:"required"`
-	CreatedBy   string `json:"createdBy" binding:"required"`
+	ItemNo       string  `json:"itemNo" binding:"required"`
+	Name         string  `json:"name" binding:"required"`
+	Description  string  `json:"description" binding:"omitempty"`
+	CategoryID   string  `json:"category_id" binding:"required"`
+	Unit         string  `json:"unit" binding:"required"`
+	Type         string  `json:"type" binding:"required"`
+	CostMethod   string  `json:"costMethod" binding:"omitempty,oneof=moving_average fifo standard"`
+	StandardCost float64 `json:"standardCost" binding:"omitempty,min=0"`
+	TaxCategory  string  `json:"taxCategory" binding:"omitempty,max=20"`
+	Status       string  `json:"status" binding:"required"`
+	CreatedBy    string  `json:"createdBy" binding:"required"`
 }
 
 // UpdateItemRequest 更新物料请求
@@ -143,6 +129,8 @@ type UpdateItemRequest struct {
 	CategoryID  string `json:"category_id" binding:"omitempty"`
 	Unit        string `json:"unit" binding:"omitempty"`
 	Type        string `json:"type" binding:"omitempty"`
+	CostMethod  string `json:"costMethod" binding:"omitempty,oneof=moving_average fifo standard"`
+	TaxCategory string `json:"taxCategory" binding:"omitempty,max=20"`
 	Status      string `json:"status" binding:"omitempty,oneof=active inactive"`
 	UpdatedBy   string `json:"updatedBy" binding:"required"`
 }
@@ -168,25 +156,52 @@ type ItemStockResponse struct {
 	Warehouses []WarehouseStockResponse `json:"warehouses"`
 }
 
-// 库存交易相关
+// GetCostLayerListRequest 获取物料成本层请求
+type GetCostLayerListRequest struct {
+	WarehouseId     string `form:"warehouseId" binding:"omitempty"`
+	LocationId      string `form:"locationId" binding:"omitempty"`
+	IncludeConsumed bool   `form:"includeConsumed" binding:"omitempty"`
+}
+
+// CostLayerResponse 成本层响应
+type CostLayerResponse struct {
+	ID                string  `json:"id,omitempty"`
+	WarehouseId       string  `json:"warehouseId"`
+	LocationId        string  `json:"locationId,omitempty"`
+	TransactionId     string  `json:"transactionId,omitempty"`
+	LayerDate         string  `json:"layerDate,omitempty"`
+	OriginalQuantity  float64 `json:"originalQuantity"`
+	RemainingQuantity float64 `json:"remainingQuantity"`
+	UnitCost          float64 `json:"unitCost"`
+	RemainingCost     float64 `json:"remainingCost"`
+}
 
-// GetTransactionListRequest 获取库存交易列表请求
-type GetTransactionListRequest struct {
-	Page                 int    `form:"page" binding:"omitempty,min=1"`
-	PageSize             int    `form:"pageSize" binding:"omitempty,min=1,max=100"`
-	TransactionNo        string `form:"transactionNo" binding:"omitempty"`
-	Type                 string `form:"type" binding:"omitempty,oneof=purchase sales transfer adjustment production"`
-	ItemId               string `form:"itemId" binding:"omitempty"`
-	WarehouseId          string `form:"warehouseId" binding:"omitempty"`
-	TransactionDateStart string `form:"transactionDateStart" binding:"omitempty,datetime=2006-01-02"`
-	TransactionDateEnd   string `form:"transactionDateEnd" binding:"omitempty,datetime=2006-01-02"`
+// ItemCostLayersResponse 物料成本层响应，非先进先出物料按现存量返回
+type ItemCostLayersResponse struct {
+	ItemId        string              `json:"itemId"`
+	ItemNo        string              `json:"itemNo"`
+	CostMethod    string              `json:"costMethod"`
+	StandardCost  float64             `json:"standardCost"`
+	TotalQuantity float64             `json:"totalQuantity"`
+	TotalCost     float64             `json:"totalCost"`
+	Layers        []CostLayerResponse `json:"layers"`
 }
 
+// RevalueItemRequest 物料成本重估请求
+type RevalueItemRequest struct {
+	WarehouseId     string  `json:"warehouseId" binding:"omitempty"`
+	NewUnitCost     float64 `json:"newUnitCost" binding:"min=0"`
+	TransactionDate string  `json:"transactionDate" binding:"required,datetime=2006-01-02"`
+	Remarks         string  `json:"remarks" binding:"omitempty"`
+}
+
+// 库存交易相关
+
 // TransactionItem 交易明细
 type TransactionItem struct {
 	ItemId         string  `json:"itemId" binding:"required"`
 	Quantity       float64 `json:"quantity" binding:"required"`
-	UnitCost       float64 `json:"unitCost" binding:"required,min=0"`
+	UnitCost       float64 `json:"unitCost" binding:"omitempty,min=0"`
 	LocationId     string  `json:"locationId" binding:"omitempty"`
 	FromLocationId string  `json:"fromLocationId" binding:"omitempty"`
 	ToLocationId   string  `json:"toLocationId" binding:"omitempty"`
@@ -202,6 +217,7 @@ type TransactionItemResponse struct {
 	Unit             string  `json:"unit,omitempty"`
 	UnitCost         float64 `json:"unitCost"`
 	TotalCost        float64 `json:"totalCost,omitempty"`
+	CostVariance     float64 `json:"costVariance,omitempty"`
 	LocationId       string  `json:"locationId,omitempty"`
 	LocationCode     string  `json:"locationCode,omitempty"`
 	FromLocationId   string  `json:"fromLocationId,omitempty"`
@@ -262,18 +278,6 @@ type CreateWarehouseTransferRequest struct {
 
 // 库存盘点相关
 
-// GetCountListRequest 获取盘点列表请求
-type GetCountListRequest struct {
-	Page           int    `form:"page" binding:"omitempty,min=1"`
-	PageSize       int    `form:"pageSize" binding:"omitempty,min=1,max=100"`
-	CountNo        string `form:"countNo" binding:"omitempty"`
-	Type           string `form:"type" binding:"omitempty,oneof=cycle stock_take"`
-	WarehouseId    string `form:"warehouseId" binding:"omitempty"`
-	Status         string `form:"status" binding:"omitempty,oneof=draft in_progress completed cancelled"`
-	StartDateStart string `form:"startDateStart" binding:"omitempty,datetime=2006-01-02"`
-	StartDateEnd   string `form:"startDateEnd" binding:"omitempty,datetime=2006-01-02"`
-}
-
 // CountItem 盘点明细
 type CountItem struct {
 	ItemId         string  `json:"itemId" binding:"required"`
@@ -462,3 +466,232 @@ type ExportInventoryReportRequest struct {
 	WarehouseId string `form:"warehouseId" binding:"omitempty"`
 	ItemId      string `form:"itemId" binding:"omitempty"`
 }
+
+// 库存预留及可承诺量相关结构体
+
+// ReservationResponse 库存预留响应
+type ReservationResponse struct {
+	ID               string  `json:"id"`
+	ItemId           string  `json:"itemId"`
+	ItemCode         string  `json:"itemCode,omitempty"`
+	ItemName         string  `json:"itemName,omitempty"`
+	WarehouseId      string  `json:"warehouseId,omitempty"`
+	WarehouseName    string  `json:"warehouseName,omitempty"`
+	Type             string  `json:"type"`
+	SourceType       string  `json:"sourceType"`
+	SourceId         string  `json:"sourceId"`
+	SourceItemId     string  `json:"sourceItemId"`
+	SourceNo         string  `json:"sourceNo,omitempty"`
+	Quantity         float64 `json:"quantity"`
+	ConsumedQuantity float64 `json:"consumedQuantity"`
+	ReservedQuantity float64 `json:"reservedQuantity"`
+	RequiredDate     string  `json:"requiredDate,omitempty"`
+	Status           string  `json:"status"`
+	ReleasedAt       string  `json:"releasedAt,omitempty"`
+	CreatedBy        string  `json:"createdBy"`
+	CreatedAt        string  `json:"createdAt"`
+}
+
+// GetAvailableToPromiseRequest 获取可承诺量请求，物料可直接指定或通过销售产品关联
+type GetAvailableToPromiseRequest struct {
+	ItemId      string  `form:"itemId" binding:"required_without=ProductId"`
+	ProductId   string  `form:"productId" binding:"omitempty"`
+	WarehouseId string  `form:"warehouseId" binding:"omitempty"`
+	Quantity    float64 `form:"quantity" binding:"omitempty,gt=0"`
+	Date        string  `form:"date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// WarehouseATPResponse 仓库可承诺量响应，仓库ID为空表示未指定仓库的预留和计划入库
+type WarehouseATPResponse struct {
+	WarehouseId          string  `json:"warehouseId"`
+	WarehouseName        string  `json:"warehouseName,omitempty"`
+	OnHandQuantity       float64 `json:"onHandQuantity"`
+	HardReservedQuantity float64 `json:"hardReservedQuantity"`
+	SoftReservedQuantity float64 `json:"softReservedQuantity"`
+	ScheduledReceipts    float64 `json:"scheduledReceipts"`
+	AvailableQuantity    float64 `json:"availableQuantity"`
+	AvailableToPromise   float64 `json:"availableToPromise"`
+}
+
+// ScheduledReceiptResponse 计划入库响应
+type ScheduledReceiptResponse struct {
+	SourceType          string  `json:"sourceType"`
+	SourceId            string  `json:"sourceId"`
+	SourceNo            string  `json:"sourceNo"`
+	WarehouseId         string  `json:"warehouseId,omitempty"`
+	ExpectedDate        string  `json:"expectedDate"`
+	Quantity            float64 `json:"quantity"`
+	CumulativeAvailable float64 `json:"cumulativeAvailable"`
+}
+
+// AvailableToPromiseResponse 可承诺量响应
+type AvailableToPromiseResponse struct {
+	ItemId               string                     `json:"itemId"`
+	ItemCode             string                     `json:"itemCode"`
+	ItemName             string                     `json:"itemName"`
+	WarehouseId          string                     `json:"warehouseId,omitempty"`
+	Date                 string                     `json:"date"`
+	RequestedQuantity    float64                    `json:"requestedQuantity,omitempty"`
+	OnHandQuantity       float64                    `json:"onHandQuantity"`
+	HardReservedQuantity float64                    `json:"hardReservedQuantity"`
+	SoftReservedQuantity float64                    `json:"softReservedQuantity"`
+	ScheduledReceipts    float64                    `json:"scheduledReceipts"`
+	AvailableQuantity    float64                    `json:"availableQuantity"`
+	AvailableOnDate      float64                    `json:"availableOnDate"`
+	AvailableToPromise   float64                    `json:"availableToPromise"`
+	CanPromise           bool                       `json:"canPromise"`
+	PromiseDate          string                     `json:"promiseDate,omitempty"`
+	Warehouses           []WarehouseATPResponse     `json:"warehouses"`
+	Receipts             []ScheduledReceiptResponse `json:"receipts"`
+}
+
+// 自动补货相关结构体
+
+// CreateReplenishmentRuleRequest 创建补货参数请求，同一物料和仓库只能有一条补货参数
+type CreateReplenishmentRuleRequest struct {
+	ItemId            string  `json:"itemId" binding:"required"`
+	WarehouseId       string  `json:"warehouseId" binding:"required"`
+	Method            string  `json:"method" binding:"required,oneof=reorder_point min_max"`
+	ReorderPoint      float64 `json:"reorderPoint" binding:"omitempty,min=0"`
+	SafetyStock       float64 `json:"safetyStock" binding:"omitempty,min=0"`
+	MinQuantity       float64 `json:"minQuantity" binding:"omitempty,min=0"`
+	MaxQuantity       float64 `json:"maxQuantity" binding:"omitempty,min=0"`
+	ReorderQuantity   float64 `json:"reorderQuantity" binding:"omitempty,min=0"`
+	MultipleQuantity  float64 `json:"multipleQuantity" binding:"omitempty,min=0"`
+	PreferredVendorId string  `json:"preferredVendorId" binding:"omitempty"`
+	SourceWarehouseId string  `json:"sourceWarehouseId" binding:"omitempty"`
+	LeadTimeDays      int     `json:"leadTimeDays" binding:"omitempty,min=0"`
+	Status            string  `json:"status" binding:"omitempty,oneof=active inactive"`
+	CreatedBy         string  `json:"createdBy" binding:"required"`
+}
+
+// UpdateReplenishmentRuleRequest 更新补货参数请求，物料和仓库不可修改
+type UpdateReplenishmentRuleRequest struct {
+	Method            string  `json:"method" binding:"required,oneof=reorder_point min_max"`
+	ReorderPoint      float64 `json:"reorderPoint" binding:"omitempty,min=0"`
+	SafetyStock       float64 `json:"safetyStock" binding:"omitempty,min=0"`
+	MinQuantity       float64 `json:"minQuantity" binding:"omitempty,min=0"`
+	MaxQuantity       float64 `json:"maxQuantity" binding:"omitempty,min=0"`
+	ReorderQuantity   float64 `json:"reorderQuantity" binding:"omitempty,min=0"`
+	MultipleQuantity  float64 `json:"multipleQuantity" binding:"omitempty,min=0"`
+	PreferredVendorId string  `json:"preferredVendorId" binding:"omitempty"`
+	SourceWarehouseId string  `json:"sourceWarehouseId" binding:"omitempty"`
+	LeadTimeDays      int     `json:"leadTimeDays" binding:"omitempty,min=0"`
+	Status            string  `json:"status" binding:"omitempty,oneof=active inactive"`
+	UpdatedBy         string  `json:"updatedBy" binding:"required"`
+}
+
+// ReplenishmentRuleResponse 补货参数响应
+type ReplenishmentRuleResponse struct {
+	ID                string  `json:"id"`
+	ItemId            string  `json:"itemId"`
+	ItemCode          string  `json:"itemCode,omitempty"`
+	ItemName          string  `json:"itemName,omitempty"`
+	WarehouseId       string  `json:"warehouseId"`
+	WarehouseName     string  `json:"warehouseName,omitempty"`
+	Method            string  `json:"method"`
+	ReorderPoint      float64 `json:"reorderPoint"`
+	SafetyStock       float64 `json:"safetyStock"`
+	MinQuantity       float64 `json:"minQuantity"`
+	MaxQuantity       float64 `json:"maxQuantity"`
+	ReorderQuantity   float64 `json:"reorderQuantity"`
+	MultipleQuantity  float64 `json:"multipleQuantity"`
+	PreferredVendorId string  `json:"preferredVendorId,omitempty"`
+	SourceWarehouseId string  `json:"sourceWarehouseId,omitempty"`
+	LeadTimeDays      int     `json:"leadTimeDays"`
+	Status            string  `json:"status"`
+	CreatedBy         string  `json:"createdBy"`
+	CreatedAt         string  `json:"createdAt"`
+	UpdatedBy         string  `json:"updatedBy"`
+	UpdatedAt         string  `json:"updatedAt"`
+}
+
+// RunReplenishmentRequest 执行补货请求，可按物料或仓库限定范围，试运行只返回补货建议不生成单据
+type RunReplenishmentRequest struct {
+	ItemId       string `json:"itemId" binding:"omitempty"`
+	WarehouseId  string `json:"warehouseId" binding:"omitempty"`
+	DepartmentId string `json:"departmentId" binding:"omitempty"`
+	DryRun       bool   `json:"dryRun" binding:"omitempty"`
+	CreatedBy    string `json:"createdBy" binding:"required"`
+}
+
+// ReplenishmentRunLineResponse 补货运行明细响应，预计可用量 = 现存量 - 预留量 + 计划入库 + 未转订单请购 + 调入在途 - 调出待发
+type ReplenishmentRunLineResponse struct {
+	ID                string  `json:"id,omitempty"`
+	RuleId            string  `json:"ruleId"`
+	ItemId            string  `json:"itemId"`
+	ItemCode          string  `json:"itemCode,omitempty"`
+	ItemName          string  `json:"itemName,omitempty"`
+	WarehouseId       string  `json:"warehouseId"`
+	Method            string  `json:"method"`
+	OnHandQuantity    float64 `json:"onHandQuantity"`
+	ReservedQuantity  float64 `json:"reservedQuantity"`
+	ScheduledReceipts float64 `json:"scheduledReceipts"`
+	OpenRequisitions  float64 `json:"openRequisitions"`
+	InboundTransfers  float64 `json:"inboundTransfers"`
+	OutboundTransfers float64 `json:"outboundTransfers"`
+	ProjectedQuantity float64 `json:"projectedQuantity"`
+	TriggerLevel      float64 `json:"triggerLevel"`
+	TargetLevel       float64 `json:"targetLevel"`
+	Action            string  `json:"action"`
+	TransferQuantity  float64 `json:"transferQuantity"`
+	PurchaseQuantity  float64 `json:"purchaseQuantity"`
+	SourceWarehouseId string  `json:"sourceWarehouseId,omitempty"`
+	VendorId          string  `json:"vendorId,omitempty"`
+	TransferRequestId string  `json:"transferRequestId,omitempty"`
+	RequisitionItemId string  `json:"requisitionItemId,omitempty"`
+	Explanation       string  `json:"explanation"`
+}
+
+// ReplenishmentRunResponse 补货运行响应
+type ReplenishmentRunResponse struct {
+	ID                string                         `json:"id,omitempty"`
+	RunNo             string                         `json:"runNo,omitempty"`
+	Trigger           string                         `json:"trigger"`
+	DryRun            bool                           `json:"dryRun"`
+	RuleCount         int                            `json:"ruleCount"`
+	PurchaseLineCount int                            `json:"purchaseLineCount"`
+	TransferCount     int                            `json:"transferCount"`
+	RequisitionId     string                         `json:"requisitionId,omitempty"`
+	RequisitionNo     string                         `json:"requisitionNo,omitempty"`
+	StartedAt         string                         `json:"startedAt"`
+	FinishedAt        string                         `json:"finishedAt"`
+	CreatedBy         string                         `json:"createdBy"`
+	Lines             []ReplenishmentRunLineResponse `json:"lines,omitempty"`
+}
+
+// CompleteTransferRequestRequest 执行调拨申请请求，按申请数量生成仓库调拨交易
+type CompleteTransferRequestRequest struct {
+	TransactionDate string `json:"transactionDate" binding:"required,datetime=2006-01-02"`
+	FromLocationId  string `json:"fromLocationId" binding:"omitempty"`
+	ToLocationId    string `json:"toLocationId" binding:"omitempty"`
+	UpdatedBy       string `json:"updatedBy" binding:"required"`
+}
+
+// CancelTransferRequestRequest 取消调拨申请请求
+type CancelTransferRequestRequest struct {
+	Reason    string `json:"reason" binding:"omitempty"`
+	UpdatedBy string `json:"updatedBy" binding:"required"`
+}
+
+// TransferRequestResponse 调拨申请响应
+type TransferRequestResponse struct {
+	ID              string  `json:"id"`
+	RequestNo       string  `json:"requestNo"`
+	ItemId          string  `json:"itemId"`
+	ItemCode        string  `json:"itemCode,omitempty"`
+	ItemName        string  `json:"itemName,omitempty"`
+	FromWarehouseId string  `json:"fromWarehouseId"`
+	ToWarehouseId   string  `json:"toWarehouseId"`
+	Quantity        float64 `json:"quantity"`
+	RequiredDate    string  `json:"requiredDate"`
+	Status          string  `json:"status"`
+	SourceType      string  `json:"sourceType,omitempty"`
+	SourceId        string  `json:"sourceId,omitempty"`
+	TransactionNo   string  `json:"transactionNo,omitempty"`
+	Remarks         string  `json:"remarks,omitempty"`
+	CreatedBy       string  `json:"createdBy"`
+	CreatedAt       string  `json:"createdAt"`
+	UpdatedBy       string  `json:"updatedBy"`
+	UpdatedAt       string  `json:"updatedAt"`
+}
@@ -1,371 +1,333 @@
 package schemas
 
+import "time"
+
 // 生产订单相关结构体
 
-// ProductionOrderCreateRequest 创建生产订单请求
- type ProductionOrderCreateRequest struct {
-	Code           string                          `json:"code" binding:"required"`
-	OrderDate      string                          `json:"order_date" binding:"required"`
-	ProductID      uint                            `json:"product_id" binding:"required"`
-	Quantity       float64                         `json:"quantity" binding:"required"`
-	ScheduledStartDate string                      `json:"scheduled_start_date" binding:"required"`
-	ScheduledEndDate   string                      `json:"scheduled_end_date" binding:"required"`
-	Priority       string                          `json:"priority"`
-	Source         string                          `json:"source"`
-	SourceID       uint                            `json:"source_id"`
-	Items          []ProductionOrderItemRequest    `json:"items" binding:"required,dive"`
-	Remarks        string                          `json:"remarks"`
-}
-
-// ProductionOrderItemRequest 生产订单项目请求
- type ProductionOrderItemRequest struct {
-	MaterialID     uint    `json:"material_id" binding:"required"`
-	Quantity       float64 `json:"quantity" binding:"required"`
-	Unit           string  `json:"unit" binding:"required"`
-}
-
-// ProductionOrderUpdateRequest 更新生产订单请求
- type ProductionOrderUpdateRequest struct {
-	OrderDate      string                          `json:"order_date"`
-	ProductID      uint                            `json:"product_id"`
-	Quantity       float64                         `json:"quantity"`
-	ScheduledStartDate string                      `json:"scheduled_start_date"`
-	ScheduledEndDate   string                      `json:"scheduled_end_date"`
-	Priority       string                          `json:"priority"`
-	Source         string                          `json:"source"`
-	SourceID       uint                            `json:"source_id"`
-	Items          []ProductionOrderItemRequest    `json:"items" binding:"omitempty,dive"`
-	Remarks        string                          `json:"remarks"`
+// CreateProductionOrderRequest 创建生产订单请求
+type CreateProductionOrderRequest struct {
+	OrderNo     string `json:"order_no" binding:"required,max=20"`
+	ProductName string `json:"product_name" binding:"required,max=100"`
+	ItemID      string `json:"item_id" binding:"omitempty"`
+	WarehouseID string `json:"warehouse_id" binding:"omitempty"`
+	Quantity    int    `json:"quantity" binding:"required,gt=0"`
+	Priority    string `json:"priority" binding:"required,oneof=high medium low"`
+	StartDate   string `json:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate     string `json:"end_date" binding:"required,datetime=2006-01-02"`
+	CreatedBy   string `json:"created_by" binding:"required"`
+}
+
+// UpdateProductionOrderRequest 更新生产订单请求，未传字段保持不变
+type UpdateProductionOrderRequest struct {
+	ProductName string `json:"product_name" binding:"omitempty,max=100"`
+	ItemID      string `json:"item_id" binding:"omitempty"`
+	WarehouseID string `json:"warehouse_id" binding:"omitempty"`
+	Quantity    *int   `json:"quantity" binding:"omitempty,gt=0"`
+	Priority    string `json:"priority" binding:"omitempty,oneof=high medium low"`
+	StartDate   string `json:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate     string `json:"end_date" binding:"omitempty,datetime=2006-01-02"`
+	UpdatedBy   string `json:"updated_by" binding:"required"`
 }
 
 // ProductionOrderResponse 生产订单响应
- type ProductionOrderResponse struct {
-	ID             uint                            `json:"id"`
-	Code           string                          `json:"code"`
-	OrderDate      string                          `json:"order_date"`
-	ProductID      uint                            `json:"product_id"`
-	ProductCode    string                          `json:"product_code"`
-	ProductName    string                          `json:"product_name"`
-	Quantity       float64                         `json:"quantity"`
-	ProducedQuantity float64                       `json:"produced_quantity"`
-	ScheduledStartDate string                      `json:"scheduled_start_date"`
-	ScheduledEndDate   string                      `json:"scheduled_end_date"`
-	ActualStartDate    string                      `json:"actual_start_date"`
-	ActualEndDate      string                      `json:"actual_end_date"`
-	Priority       string                          `json:"priority"`
-	Status         string                          `json:"status"`
-	Source         string                          `json:"source"`
-	SourceID       uint                            `json:"source_id"`
-	Items          []ProductionOrderItemResponse   `json:"items"`
-	Remarks        string                          `json:"remarks"`
-	CreatedAt      string                          `json:"created_at"`
-	UpdatedAt      string                          `json:"updated_at"`
-}
-
-// ProductionOrderItemResponse 生产订单项目响应
- type ProductionOrderItemResponse struct {
-	ID             uint    `json:"id"`
-	ProductionOrderID uint    `json:"production_order_id"`
-	MaterialID     uint    `json:"material_id"`
-	MaterialCode   string  `json:"material_code"`
-	MaterialName   string  `json:"material_name"`
-	Quantity       float64 `json:"quantity"`
-	IssuedQuantity float64 `json:"issued_quantity"`
-	Unit           string  `json:"unit"`
+type ProductionOrderResponse struct {
+	ID          string    `json:"id"`
+	OrderNo     string    `json:"order_no"`
+	ProductName string    `json:"product_name"`
+	ItemID      string    `json:"item_id,omitempty"`
+	WarehouseID string    `json:"warehouse_id,omitempty"`
+	Quantity    int       `json:"quantity"`
+	Status      string    `json:"status"`
+	Priority    string    `json:"priority"`
+	StartDate   time.Time `json:"start_date"`
+	EndDate     time.Time `json:"end_date"`
+	CreatedAt   time.Time `json:"created_at"`
+	CreatedBy   string    `json:"created_by"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	UpdatedBy   string    `json:"updated_by"`
 }
 
 // 生产工单相关结构体
 
-// ProductionTicketCreateRequest 创建生产工单请求
- type ProductionTicketCreateRequest struct {
-	Code           string                          `json:"code" binding:"required"`
-	ProductionOrderID uint                          `json:"production_order_id" binding:"required"`
-	RoutingID      uint                            `json:"routing_id" binding:"required"`
-	WorkCenterID   uint                            `json:"work_center_id" binding:"required"`
-	OperationID    uint                            `json:"operation_id" binding:"required"`
-	Quantity       float64                         `json:"quantity" binding:"required"`
-	ScheduledStartDate string                      `json:"scheduled_start_date" binding:"required"`
-	ScheduledEndDate   string                      `json:"scheduled_end_date" binding:"required"`
-	Status         string                          `json:"status"`
-	Remarks        string                          `json:"remarks"`
-}
-
-// ProductionTicketUpdateRequest 更新生产工单请求
- type ProductionTicketUpdateRequest struct {
-	RoutingID      uint                            `json:"routing_id"`
-	WorkCenterID   uint                            `json:"work_center_id"`
-	OperationID    uint                            `json:"operation_id"`
-	Quantity       float64                         `json:"quantity"`
-	ScheduledStartDate string                      `json:"scheduled_start_date"`
-	ScheduledEndDate   string                      `json:"scheduled_end_date"`
-	Status         string                          `json:"status"`
-	Remarks        string                          `json:"remarks"`
+// CreateProductionTicketRequest 创建生产工单请求
+type CreateProductionTicketRequest struct {
+	TicketNo          string    `json:"ticket_no" binding:"required,max=20"`
+	ProductionOrderID string    `json:"production_order_id" binding:"required"`
+	ProductName       string    `json:"product_name" binding:"required,max=100"`
+	Quantity          int       `json:"quantity" binding:"required,gt=0"`
+	WorkCenterID      string    `json:"work_center_id" binding:"required"`
+	StartTime         time.Time `json:"start_time" binding:"required"`
+	EndTime           time.Time `json:"end_time" binding:"required,gtfield=StartTime"`
+	CreatedBy         string    `json:"created_by" binding:"required"`
+}
+
+// UpdateProductionTicketRequest 更新生产工单请求，未传字段保持不变
+type UpdateProductionTicketRequest struct {
+	ProductName  string     `json:"product_name" binding:"omitempty,max=100"`
+	Quantity     *int       `json:"quantity" binding:"omitempty,gt=0"`
+	WorkCenterID string     `json:"work_center_id" binding:"omitempty"`
+	StartTime    *time.Time `json:"start_time" binding:"omitempty"`
+	EndTime      *time.Time `json:"end_time" binding:"omitempty"`
+	UpdatedBy    string     `json:"updated_by" binding:"required"`
 }
 
 // ProductionTicketResponse 生产工单响应
- type ProductionTicketResponse struct {
-	ID             uint                            `json:"id"`
-	Code           string                          `json:"code"`
-	ProductionOrderID uint                          `json:"production_order_id"`
-	ProductionOrderCode string                      `json:"production_order_code"`
-	RoutingID      uint                            `json:"routing_id"`
-	RoutingName    string                          `json:"routing_name"`
-	WorkCenterID   uint                            `json:"work_center_id"`
-	WorkCenterName string                          `json:"work_center_name"`
-	OperationID    uint                            `json:"operation_id"`
-	OperationName  string                          `json:"operation_name"`
-	Quantity       float64                         `json:"quantity"`
-	CompletedQuantity float64                      `json:"completed_quantity"`
-	ScheduledStartDate string                      `json:"scheduled_start_date"`
-	ScheduledEndDate   string                      `json:"scheduled_end_date"`
-	ActualStartDate    string                      `json:"actual_start_date"`
-	ActualEndDate      string                      `json:"actual_end_date"`
-	Status         string                          `json:"status"`
-	Remarks        string                          `json:"remarks"`
-	CreatedAt      string                          `json:"created_at"`
-	UpdatedAt      string                          `json:"updated_at"`
+type ProductionTicketResponse struct {
+	ID                string    `json:"id"`
+	TicketNo          string    `json:"ticket_no"`
+	ProductionOrderID string    `json:"production_order_id"`
+	ProductName       string    `json:"product_name"`
+	Quantity          int       `json:"quantity"`
+	Status            string    `json:"status"`
+	WorkCenterID      string    `json:"work_center_id"`
+	StartTime         time.Time `json:"start_time"`
+	EndTime           time.Time `json:"end_time"`
+	CreatedAt         time.Time `json:"created_at"`
+	CreatedBy         string    `json:"created_by"`
+	UpdatedAt         time.Time `json:"updated_at"`
+	UpdatedBy         string    `json:"updated_by"`
 }
 
 // 工艺路线相关结构体
 
-// RoutingCreateRequest 创建工艺路线请求
- type RoutingCreateRequest struct {
-	Code           string                          `json:"code" binding:"required"`
-	Name           string                          `json:"name" binding:"required"`
-	ProductID      uint                            `json:"product_id" binding:"required"`
-	Description    string                          `json:"description"`
-	Operations     []RoutingOperationRequest       `json:"operations" binding:"required,dive"`
-}
-
-// RoutingOperationRequest 工艺路线工序请求
- type RoutingOperationRequest struct {
-	Sequence       int     `json:"sequence" binding:"required"`
-	Name           string  `json:"name" binding:"required"`
-	WorkCenterID   uint    `json:"work_center_id" binding:"required"`
-	StandardTime   float64 `json:"standard_time" binding:"required"`
-	SetupTime      float64 `json:"setup_time"`
-	RunTime        float64 `json:"run_time"`
-	Description    string  `json:"description"`
+// CreateRoutingRequest 创建工艺路线请求
+type CreateRoutingRequest struct {
+	RoutingNo   string `json:"routing_no" binding:"required,max=20"`
+	ProductName string `json:"product_name" binding:"required,max=100"`
+	Description string `json:"description" binding:"omitempty"`
+	CreatedBy   string `json:"created_by" binding:"required"`
 }
 
-// RoutingUpdateRequest 更新工艺路线请求
- type RoutingUpdateRequest struct {
-	Code           string                          `json:"code"`
-	Name           string                          `json:"name"`
-	ProductID      uint                            `json:"product_id"`
-	Description    string                          `json:"description"`
-	Operations     []RoutingOperationRequest       `json:"operations" binding:"omitempty,dive"`
+// UpdateRoutingRequest 更新工艺路线请求，未传字段保持不变
+type UpdateRoutingRequest struct {
+	ProductName string `json:"product_name" binding:"omitempty,max=100"`
+	Description string `json:"description" binding:"omitempty"`
+	UpdatedBy   string `json:"updated_by" binding:"required"`
 }
 
 // RoutingResponse 工艺路线响应
- type RoutingResponse struct {
-	ID             uint                            `json:"id"`
-	Code           string                          `json:"code"`
-	Name           string                          `json:"name"`
-	ProductID      uint                            `json:"product_id"`
-	ProductCode    string                          `json:"product_code"`
-	ProductName    string                          `json:"product_name"`
-	Description    string                          `json:"description"`
-	Operations     []RoutingOperationResponse      `json:"operations"`
-	CreatedAt      string                          `json:"created_at"`
-	UpdatedAt      string                          `json:"updated_at"`
-}
-
-// RoutingOperationResponse 工艺路线工序响应
- type RoutingOperationResponse struct {
-	ID             uint    `json:"id"`
-	RoutingID      uint    `json:"routing_id"`
-	Sequence       int     `json:"sequence"`
-	Name           string  `json:"name"`
-	WorkCenterID   uint    `json:"work_center_id"`
-	WorkCenterName string  `json:"work_center_name"`
-	StandardTime   float64 `json:"standard_time"`
-	SetupTime      float64 `json:"setup_time"`
-	RunTime        float64 `json:"run_time"`
-	Description    string  `json:"description"`
+type RoutingResponse struct {
+	ID          string    `json:"id"`
+	RoutingNo   string    `json:"routing_no"`
+	ProductName string    `json:"product_name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	CreatedBy   string    `json:"created_by"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	UpdatedBy   string    `json:"updated_by"`
 }
 
 // 工作中心相关结构体
 
-// WorkCenterCreateRequest 创建工作中心请求
- type WorkCenterCreateRequest struct {
-	Code           string  `json:"code" binding:"required"`
-	Name           string  `json:"name" binding:"required"`
-	Type           string  `json:"type" binding:"required"`
-	Capacity       float64 `json:"capacity" binding:"required"`
-	Efficiency     float64 `json:"efficiency"`
-	Location       string  `json:"location"`
-	Description    string  `json:"description"`
-	Active         bool    `json:"active"`
-}
-
-// WorkCenterUpdateRequest 更新工作中心请求
- type WorkCenterUpdateRequest struct {
-	Code           string  `json:"code"`
-	Name           string  `json:"name"`
-	Type           string  `json:"type"`
-	Capacity       float64 `json:"capacity"`
-	Efficiency     float64 `json:"efficiency"`
-	Location       string  `json:"location"`
-	Description    string  `json:"description"`
-	Active         bool    `json:"active"`
+// CreateWorkCenterRequest 创建工作中心请求
+type CreateWorkCenterRequest struct {
+	WorkCenterNo string `json:"work_center_no" binding:"required,max=20"`
+	Name         string `json:"name" binding:"required,max=100"`
+	Description  string `json:"description" binding:"omitempty"`
+	Capacity     int    `json:"capacity" binding:"required,gt=0"`
+	CreatedBy    string `json:"created_by" binding:"required"`
+}
+
+// UpdateWorkCenterRequest 更新工作中心请求，未传字段保持不变
+type UpdateWorkCenterRequest struct {
+	Name        string `json:"name" binding:"omitempty,max=100"`
+	Description string `json:"description" binding:"omitempty"`
+	Capacity    *int   `json:"capacity" binding:"omitempty,gt=0"`
+	UpdatedBy   string `json:"updated_by" binding:"required"`
 }
 
 // WorkCenterResponse 工作中心响应
- type WorkCenterResponse struct {
-	ID             uint    `json:"id"`
-	Code           string  `json:"code"`
-	Name           string  `json:"name"`
-	Type           string  `json:"type"`
-	Capacity       float64 `json:"capacity"`
-	Efficiency     float64 `json:"efficiency"`
-	AvailableCapacity float64 `json:"available_capacity"`
-	LoadPercentage float64 `json:"load_percentage"`
-	Location       string  `json:"location"`
-	Description    string  `json:"description"`
-	Active         bool    `json:"active"`
-	CreatedAt      string  `json:"created_at"`
-	UpdatedAt      string  `json:"updated_at"`
+type WorkCenterResponse struct {
+	ID           string    `json:"id"`
+	WorkCenterNo string    `json:"work_center_no"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	Capacity     int       `json:"capacity"`
+	CreatedAt    time.Time `json:"created_at"`
+	CreatedBy    string    `json:"created_by"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	UpdatedBy    string    `json:"updated_by"`
 }
 
 // WorkCenterCapacityResponse 工作中心产能响应
- type WorkCenterCapacityResponse struct {
-	WorkCenterID   uint    `json:"work_center_id"`
-	WorkCenterName string  `json:"work_center_name"`
-	TotalCapacity  float64 `json:"total_capacity"`
-	UsedCapacity   float64 `json:"used_capacity"`
-	AvailableCapacity float64 `json:"available_capacity"`
-	LoadPercentage float64 `json:"load_percentage"`
-	Period         string  `json:"period"`
-	Details        []WorkCenterCapacityDetail `json:"details"`
-}
-
-// WorkCenterCapacityDetail 工作中心产能详情
- type WorkCenterCapacityDetail struct {
-	Date           string  `json:"date"`
-	TotalCapacity  float64 `json:"total_capacity"`
-	UsedCapacity   float64 `json:"used_capacity"`
-	AvailableCapacity float64 `json:"available_capacity"`
-	LoadPercentage float64 `json:"load_percentage"`
+type WorkCenterCapacityResponse struct {
+	ID                string `json:"id"`
+	WorkCenterNo      string `json:"work_center_no"`
+	Name              string `json:"name"`
+	TotalCapacity     int    `json:"total_capacity"`
+	UsedCapacity      int    `json:"used_capacity"`
+	AvailableCapacity int    `json:"available_capacity"`
+}
+
+// WorkCenterScheduleRequest 工作中心排程查询参数
+type WorkCenterScheduleRequest struct {
+	StartDate string `form:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate   string `form:"end_date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// WorkCenterScheduleResponse 工作中心排程响应
+type WorkCenterScheduleResponse struct {
+	ID           string                     `json:"id"`
+	WorkCenterNo string                     `json:"work_center_no"`
+	Name         string                     `json:"name"`
+	Schedule     []ProductionTicketResponse `json:"schedule"`
 }
 
 // 物料需求计划相关结构体
 
 // MRPRunRequest 运行物料需求计划请求
- type MRPRunRequest struct {
-	StartDate      string `json:"start_date" binding:"required"`
-	EndDate        string `json:"end_date" binding:"required"`
-	PlantID        uint   `json:"plant_id"`
-	ProductIDs     []uint `json:"product_ids"`
-	Regenerate     bool   `json:"regenerate"`
+type MRPRunRequest struct {
+	StartDate    string   `json:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate      string   `json:"end_date" binding:"required,datetime=2006-01-02"`
+	ProductNames []string `json:"product_names" binding:"omitempty"`
+}
+
+// MRPRunResponse 物料需求计划运行结果
+type MRPRunResponse struct {
+	RunID       string    `json:"run_id"`
+	RunDate     time.Time `json:"run_date"`
+	Status      string    `json:"status"`
+	TotalItems  int       `json:"total_items"`
+	Suggestions int       `json:"suggestions"`
+	Errors      int       `json:"errors"`
+}
+
+// MRPResultRequest 物料需求计划结果查询参数
+type MRPResultRequest struct {
+	ItemCode string `form:"item_code"`
 }
 
 // MRPResultResponse 物料需求计划结果响应
- type MRPResultResponse struct {
-	RunID          uint                `json:"run_id"`
-	RunDate        string              `json:"run_date"`
-	StartDate      string              `json:"start_date"`
-	EndDate        string              `json:"end_date"`
-	TotalProducts  int                 `json:"total_products"`
-	TotalMaterials int                 `json:"total_materials"`
-	Suggestions    []MRPSuggestionResponse `json:"suggestions"`
+type MRPResultResponse struct {
+	ID             string    `json:"id"`
+	ItemCode       string    `json:"item_code"`
+	ItemName       string    `json:"item_name"`
+	CurrentStock   int       `json:"current_stock"`
+	Demand         int       `json:"demand"`
+	Supply         int       `json:"supply"`
+	NetRequirement int       `json:"net_requirement"`
+	SuggestedQty   int       `json:"suggested_qty"`
+	SuggestedDate  time.Time `json:"suggested_date"`
 }
 
 // MRPSuggestionResponse 物料需求计划建议响应
- type MRPSuggestionResponse struct {
-	ID             uint    `json:"id"`
-	MRPRunID       uint    `json:"mrp_run_id"`
-	Type           string  `json:"type"` // purchase, production, transfer
-	MaterialID     uint    `json:"material_id"`
-	MaterialCode   string  `json:"material_code"`
-	MaterialName   string  `json:"material_name"`
-	Quantity       float64 `json:"quantity"`
-	RequiredDate   string  `json:"required_date"`
-	CurrentStock   float64 `json:"current_stock"`
-	Shortage       float64 `json:"shortage"`
-	SuggestedAction string  `json:"suggested_action"`
+type MRPSuggestionResponse struct {
+	ID             string    `json:"id"`
+	ItemCode       string    `json:"item_code"`
+	ItemName       string    `json:"item_name"`
+	SuggestionType string    `json:"suggestion_type"` // purchase, production
+	SuggestedQty   int       `json:"suggested_qty"`
+	SuggestedDate  time.Time `json:"suggested_date"`
+	Supplier       string    `json:"supplier,omitempty"`
+	WorkCenter     string    `json:"work_center,omitempty"`
 }
 
 // 生产报表相关结构体
 
-// ProductionPlanReportRequest 生产计划报表请求
- type ProductionPlanReportRequest struct {
-	StartDate      string `json:"start_date" binding:"required"`
-	EndDate        string `json:"end_date" binding:"required"`
-	ProductID      uint   `json:"product_id"`
-	WorkCenterID   uint   `json:"work_center_id"`
-	Status         string `json:"status"`
+// ProductionReportRequest 生产报表查询参数
+type ProductionReportRequest struct {
+	StartDate    string `form:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate      string `form:"end_date" binding:"omitempty,datetime=2006-01-02"`
+	ProductName  string `form:"product_name"`
+	WorkCenterID string `form:"work_center_id"`
 }
 
-// ProductionPlanReportResponse 生产计划报表响应
- type ProductionPlanReportResponse struct {
-	Period         string                      `json:"period"`
-	TotalOrders    int                         `json:"total_orders"`
-	TotalQuantity  float64                     `json:"total_quantity"`
-	Orders         []ProductionPlanOrderResponse `json:"orders"`
+// ExportProductionReportRequest 导出生产报表查询参数
+type ExportProductionReportRequest struct {
+	ReportType string `form:"report_type" binding:"required,oneof=production_plan production_execution work_center_load material_consumption production_cost"`
+	StartDate  string `form:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate    string `form:"end_date" binding:"omitempty,datetime=2006-01-02"`
 }
 
-// ProductionPlanOrderResponse 生产计划订单响应
- type ProductionPlanOrderResponse struct {
-	OrderID        uint    `json:"order_id"`
-	OrderCode      string  `json:"order_code"`
-	ProductName    string  `json:"product_name"`
-	Quantity       float64 `json:"quantity"`
-	ScheduledStart string  `json:"scheduled_start"`
-	ScheduledEnd   string  `json:"scheduled_end"`
-	Status         string  `json:"status"`
-	Priority       string  `json:"priority"`
+// ProductionPlanReportResponse 生产计划报表响应
+type ProductionPlanReportResponse struct {
+	ReportType    string                     `json:"report_type"`
+	ReportDate    time.Time                  `json:"report_date"`
+	Period        string                     `json:"period"`
+	TotalOrders   int                        `json:"total_orders"`
+	TotalQuantity int                        `json:"total_quantity"`
+	Orders        []ProductionPlanReportItem `json:"orders"`
 }
 
-// ProductionExecutionReportRequest 生产执行报表请求
- type ProductionExecutionReportRequest struct {
-	StartDate      string `json:"start_date" binding:"required"`
-	EndDate        string `json:"end_date" binding:"required"`
-	ProductID      uint   `json:"product_id"`
-	WorkCenterID   uint   `json:"work_center_id"`
+// ProductionPlanReportItem 生产计划报表明细
+type ProductionPlanReportItem struct {
+	OrderNo     string    `json:"order_no"`
+	ProductName string    `json:"product_name"`
+	Quantity    int       `json:"quantity"`
+	StartDate   time.Time `json:"start_date"`
+	EndDate     time.Time `json:"end_date"`
+	Status      string    `json:"status"`
 }
 
 // ProductionExecutionReportResponse 生产执行报表响应
- type ProductionExecutionReportResponse struct {
-	Period         string                        `json:"period"`
-	TotalOrders    int                           `json:"total_orders"`
-	TotalQuantity  float64                       `json:"total_quantity"`
-	ProducedQuantity float64                     `json:"produced_quantity"`
-	Efficiency     float64                       `json:"efficiency"`
-	Orders         []ProductionExecutionOrderResponse `json:"orders"`
-}
-
-// ProductionExecutionOrderResponse 生产执行订单响应
- type ProductionExecutionOrderResponse struct {
-	OrderID        uint    `json:"order_id"`
-	OrderCode      string  `json:"order_code"`
-	ProductName    string  `json:"product_name"`
-	Quantity       float64 `json:"quantity"`
-	ProducedQuantity float64 `json:"produced_quantity"`
-	ActualStart    string  `json:"actual_start"`
-	ActualEnd      string  `json:"actual_end"`
-	Status         string  `json:"status"`
-}
-
-// WorkCenterLoadReportRequest 工作中心负荷报表请求
- type WorkCenterLoadReportRequest struct {
-	StartDate      string `json:"start_date" binding:"required"`
-	EndDate        string `json:"end_date" binding:"required"`
-	WorkCenterID   uint   `json:"work_center_id"`
+type ProductionExecutionReportResponse struct {
+	ReportType       string                          `json:"report_type"`
+	ReportDate       time.Time                       `json:"report_date"`
+	Period           string                          `json:"period"`
+	TotalTickets     int                             `json:"total_tickets"`
+	CompletedTickets int                             `json:"completed_tickets"`
+	CompletionRate   float64                         `json:"completion_rate"`
+	Tickets          []ProductionExecutionReportItem `json:"tickets"`
+}
+
+// ProductionExecutionReportItem 生产执行报表明细
+type ProductionExecutionReportItem struct {
+	TicketNo    string    `json:"ticket_no"`
+	ProductName string    `json:"product_name"`
+	Quantity    int       `json:"quantity"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	Status      string    `json:"status"`
 }
 
 // WorkCenterLoadReportResponse 工作中心负荷报表响应
- type WorkCenterLoadReportResponse struct {
-	Period         string                      `json:"period"`
-	WorkCenters    []WorkCenterLoadDetail      `json:"work_centers"`
-}
-
-// WorkCenterLoadDetail 工作中心负荷详情
- type WorkCenterLoadDetail struct {
-	WorkCenterID   uint    `json:"work_center_id"`
-	WorkCenterName string  `json:"work_center_name"`
-	TotalCapacity  float64 `json:"total_capacity"`
-	UsedCapacity   float64 `json:"used_capacity"`
-	LoadPercentage float64 `json:"load_percentage"`
-	Status         string  `json:"status"` // underloaded, normal, overloaded
-}
\ No newline at end of file
+type WorkCenterLoadReportResponse struct {
+	ReportType       string                     `json:"report_type"`
+	ReportDate       time.Time                  `json:"report_date"`
+	Period           string                     `json:"period"`
+	TotalWorkCenters int                        `json:"total_work_centers"`
+	WorkCenters      []WorkCenterLoadReportItem `json:"work_centers"`
+}
+
+// WorkCenterLoadReportItem 工作中心负荷报表明细
+type WorkCenterLoadReportItem struct {
+	WorkCenterNo  string  `json:"work_center_no"`
+	Name          string  `json:"name"`
+	TotalCapacity int     `json:"total_capacity"`
+	UsedCapacity  int     `json:"used_capacity"`
+	LoadRate      float64 `json:"load_rate"`
+}
+
+// MaterialConsumptionReportResponse 物料消耗报表响应
+type MaterialConsumptionReportResponse struct {
+	ReportType     string                          `json:"report_type"`
+	ReportDate     time.Time                       `json:"report_date"`
+	Period         string                          `json:"period"`
+	TotalMaterials int                             `json:"total_materials"`
+	Materials      []MaterialConsumptionReportItem `json:"materials"`
+}
+
+// MaterialConsumptionReportItem 物料消耗报表明细
+type MaterialConsumptionReportItem struct {
+	MaterialCode string  `json:"material_code"`
+	MaterialName string  `json:"material_name"`
+	Consumption  int     `json:"consumption"`
+	Unit         string  `json:"unit"`
+	Cost         float64 `json:"cost"`
+}
+
+// ProductionCostReportResponse 生产成本报表响应
+type ProductionCostReportResponse struct {
+	ReportType    string                     `json:"report_type"`
+	ReportDate    time.Time                  `json:"report_date"`
+	Period        string                     `json:"period"`
+	TotalCost     float64                    `json:"total_cost"`
+	CostBreakdown []ProductionCostReportItem `json:"cost_breakdown"`
+}
+
+// ProductionCostReportItem 生产成本构成
+type ProductionCostReportItem struct {
+	CostType   string  `json:"cost_type"`
+	Amount     float64 `json:"amount"`
+	Percentage float64 `json:"percentage"`
+}
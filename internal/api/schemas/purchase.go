@@ -3,71 +3,88 @@ package schemas
 // 供应商相关结构体
 
 // SupplierCreateRequest 创建供应商请求
- type SupplierCreateRequest struct {
-	Code        string `json:"code" binding:"required"`
-	Name        string `json:"name" binding:"required"`
-	ContactName string `json:"contact_name" binding:"required"`
-	Phone       string `json:"phone" binding:"required"`
-	Email       string `json:"email" binding:"required,email"`
-	Address     string `json:"address" binding:"required"`
-	TaxNumber   string `json:"tax_number"`
-	BankName    string `json:"bank_name"`
-	BankAccount string `json:"bank_account"`
-	Category    string `json:"category"`
-	Rating      int    `json:"rating"`
-	Remarks     string `json:"remarks"`
-}
-
-// SupplierUpdateRequest 更新供应商请求
- type SupplierUpdateRequest struct {
-	Code        string `json:"code"`
-	Name        string `json:"name"`
-	ContactName string `json:"contact_name"`
-	Phone       string `json:"phone"`
-	Email       string `json:"email" binding:"omitempty,email"`
-	Address     string `json:"address"`
-	TaxNumber   string `json:"tax_number"`
-	BankName    string `json:"bank_name"`
-	BankAccount string `json:"bank_account"`
-	Category    string `json:"category"`
-	Rating      int    `json:"rating"`
-	Remarks     string `json:"remarks"`
+type SupplierCreateRequest struct {
+	VendorNo      string  `json:"vendor_no" binding:"required,max=20"`
+	Name          string  `json:"name" binding:"required,max=100"`
+	ContactPerson string  `json:"contact_person" binding:"omitempty,max=50"`
+	Phone         string  `json:"phone" binding:"omitempty,max=20"`
+	Email         string  `json:"email" binding:"omitempty,email,max=100"`
+	Address       string  `json:"address" binding:"omitempty,max=255"`
+	TaxNo         string  `json:"tax_no" binding:"omitempty,max=30"`
+	BankName      string  `json:"bank_name" binding:"omitempty,max=100"`
+	BankAccount   string  `json:"bank_account" binding:"omitempty,max=50"`
+	Category      string  `json:"category" binding:"omitempty,max=50"`
+	CreditLimit   float64 `json:"credit_limit" binding:"omitempty,min=0"`
+	LeadTime      int     `json:"lead_time" binding:"omitempty,min=0"`
+	TaxStatus     string  `json:"tax_status" binding:"omitempty,max=20"`
+	Currency      string  `json:"currency" binding:"omitempty,len=3"`
+	PaymentTerms  string  `json:"payment_terms" binding:"omitempty,max=50"`
+	Remarks       string  `json:"remarks"`
+	Status        string  `json:"status" binding:"omitempty,oneof=active inactive"`
+	CreatedBy     string  `json:"created_by" binding:"required"`
+}
+
+// SupplierUpdateRequest 更新供应商请求，未传字段保持不变
+type SupplierUpdateRequest struct {
+	Name          string   `json:"name" binding:"omitempty,max=100"`
+	ContactPerson string   `json:"contact_person" binding:"omitempty,max=50"`
+	Phone         string   `json:"phone" binding:"omitempty,max=20"`
+	Email         string   `json:"email" binding:"omitempty,email,max=100"`
+	Address       string   `json:"address" binding:"omitempty,max=255"`
+	TaxNo         string   `json:"tax_no" binding:"omitempty,max=30"`
+	BankName      string   `json:"bank_name" binding:"omitempty,max=100"`
+	BankAccount   string   `json:"bank_account" binding:"omitempty,max=50"`
+	Category      string   `json:"category" binding:"omitempty,max=50"`
+	CreditLimit   *float64 `json:"credit_limit" binding:"omitempty,min=0"`
+	LeadTime      *int     `json:"lead_time" binding:"omitempty,min=0"`
+	TaxStatus     string   `json:"tax_status" binding:"omitempty,max=20"`
+	Currency      string   `json:"currency" binding:"omitempty,len=3"`
+	PaymentTerms  string   `json:"payment_terms" binding:"omitempty,max=50"`
+	Remarks       string   `json:"remarks"`
+	Status        string   `json:"status" binding:"omitempty,oneof=active inactive"`
+	UpdatedBy     string   `json:"updated_by" binding:"required"`
 }
 
 // SupplierResponse 供应商响应
- type SupplierResponse struct {
-	ID          uint   `json:"id"`
-	Code        string `json:"code"`
-	Name        string `json:"name"`
-	ContactName string `json:"contact_name"`
-	Phone       string `json:"phone"`
-	Email       string `json:"email"`
-	Address     string `json:"address"`
-	TaxNumber   string `json:"tax_number"`
-	BankName    string `json:"bank_name"`
-	BankAccount string `json:"bank_account"`
-	Category    string `json:"category"`
-	Rating      int    `json:"rating"`
-	Remarks     string `json:"remarks"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
-}
-
-// SupplierContactCreateRequest 创建供应商联系人请求
- type SupplierContactCreateRequest struct {
-	SupplierID  uint   `json:"supplier_id" binding:"required"`
-	Name        string `json:"name" binding:"required"`
-	Position    string `json:"position"`
-	Phone       string `json:"phone" binding:"required"`
-	Email       string `json:"email" binding:"required,email"`
-	IsPrimary   bool   `json:"is_primary"`
-	Remarks     string `json:"remarks"`
+type SupplierResponse struct {
+	ID            string  `json:"id"`
+	VendorNo      string  `json:"vendor_no"`
+	Name          string  `json:"name"`
+	ContactPerson string  `json:"contact_person"`
+	Phone         string  `json:"phone"`
+	Email         string  `json:"email"`
+	Address       string  `json:"address"`
+	TaxNo         string  `json:"tax_no"`
+	BankName      string  `json:"bank_name"`
+	BankAccount   string  `json:"bank_account"`
+	Category      string  `json:"category"`
+	CreditLimit   float64 `json:"credit_limit"`
+	LeadTime      int     `json:"lead_time"`
+	TaxStatus     string  `json:"tax_status"`
+	Currency      string  `json:"currency"`
+	PaymentTerms  string  `json:"payment_terms"`
+	Remarks       string  `json:"remarks"`
+	Status        string  `json:"status"`
+	CreatedBy     string  `json:"created_by"`
+	CreatedAt     string  `json:"created_at"`
+	UpdatedBy     string  `json:"updated_by"`
+	UpdatedAt     string  `json:"updated_at"`
+}
+
+// SupplierContactCreateRequest 创建供应商联系人请求，供应商ID取自路径
+type SupplierContactCreateRequest struct {
+	Name      string `json:"name" binding:"required,max=50"`
+	Position  string `json:"position"`
+	Phone     string `json:"phone" binding:"required,max=20"`
+	Email     string `json:"email" binding:"omitempty,email"`
+	IsPrimary bool   `json:"is_primary"`
+	Remarks   string `json:"remarks"`
 }
 
 // SupplierContactResponse 供应商联系人响应
- type SupplierContactResponse struct {
-	ID         uint   `json:"id"`
-	SupplierID uint   `json:"supplier_id"`
+type SupplierContactResponse struct {
+	ID         string `json:"id"`
+	SupplierID string `json:"supplier_id"`
 	Name       string `json:"name"`
 	Position   string `json:"position"`
 	Phone      string `json:"phone"`
@@ -78,382 +95,731 @@ package schemas
 	UpdatedAt  string `json:"updated_at"`
 }
 
+// 采购计划相关结构体
+
+// PurchasePlanCreateRequest 创建采购计划请求，计划金额按明细汇总
+type PurchasePlanCreateRequest struct {
+	PlanNo      string                    `json:"plan_no" binding:"required,max=20"`
+	Name        string                    `json:"name" binding:"required,max=100"`
+	Description string                    `json:"description"`
+	StartDate   string                    `json:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate     string                    `json:"end_date" binding:"required,datetime=2006-01-02"`
+	Items       []PurchasePlanItemRequest `json:"items" binding:"required,min=1,dive"`
+	CreatedBy   string                    `json:"created_by" binding:"required"`
+}
+
+// PurchasePlanItemRequest 采购计划项目请求
+type PurchasePlanItemRequest struct {
+	ItemID         string  `json:"item_id" binding:"required"`
+	VendorID       string  `json:"vendor_id"`
+	Quantity       float64 `json:"quantity" binding:"required,gt=0"`
+	EstimatedPrice float64 `json:"estimated_price" binding:"omitempty,min=0"`
+	NeedDate       string  `json:"need_date" binding:"required,datetime=2006-01-02"`
+}
+
+// PurchasePlanUpdateRequest 更新采购计划请求，传入明细时整体替换
+type PurchasePlanUpdateRequest struct {
+	Name        string                    `json:"name" binding:"omitempty,max=100"`
+	Description string                    `json:"description"`
+	StartDate   string                    `json:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate     string                    `json:"end_date" binding:"omitempty,datetime=2006-01-02"`
+	Items       []PurchasePlanItemRequest `json:"items" binding:"omitempty,dive"`
+	UpdatedBy   string                    `json:"updated_by" binding:"required"`
+}
+
+// PurchaseForecastPlanRequest 按采购预测建议生成采购计划请求
+type PurchaseForecastPlanRequest struct {
+	PurchaseForecastRequest
+	PlanNo      string `json:"plan_no" binding:"omitempty,max=20"` // 不传时自动生成
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description"`
+	CreatedBy   string `json:"created_by" binding:"required"`
+}
+
+// PurchasePlanResponse 采购计划响应
+type PurchasePlanResponse struct {
+	ID          string                     `json:"id"`
+	PlanNo      string                     `json:"plan_no"`
+	Name        string                     `json:"name"`
+	Description string                     `json:"description"`
+	StartDate   string                     `json:"start_date"`
+	EndDate     string                     `json:"end_date"`
+	TotalAmount float64                    `json:"total_amount"`
+	Status      string                     `json:"status"`
+	Items       []PurchasePlanItemResponse `json:"items"`
+	CreatedBy   string                     `json:"created_by"`
+	CreatedAt   string                     `json:"created_at"`
+	UpdatedBy   string                     `json:"updated_by"`
+	UpdatedAt   string                     `json:"updated_at"`
+}
+
+// PurchasePlanItemResponse 采购计划项目响应
+type PurchasePlanItemResponse struct {
+	ID                string  `json:"id"`
+	PlanID            string  `json:"plan_id"`
+	ItemID            string  `json:"item_id"`
+	VendorID          string  `json:"vendor_id"`
+	Quantity          float64 `json:"quantity"`
+	OrderedQuantity   float64 `json:"ordered_quantity"`
+	RemainingQuantity float64 `json:"remaining_quantity"`
+	EstimatedPrice    float64 `json:"estimated_price"`
+	EstimatedAmount   float64 `json:"estimated_amount"`
+	NeedDate          string  `json:"need_date"`
+}
+
 // 采购申请相关结构体
 
 // RequisitionCreateRequest 创建采购申请请求
- type RequisitionCreateRequest struct {
-	Code        string                    `json:"code" binding:"required"`
-	DepartmentID uint                     `json:"department_id" binding:"required"`
-	ApplicantID  uint                     `json:"applicant_id" binding:"required"`
-	ApplicationDate string                 `json:"application_date" binding:"required"`
-	EstimatedAmount float64               `json:"estimated_amount" binding:"required"`
-	Reason      string                    `json:"reason" binding:"required"`
-	Items       []RequisitionItemRequest `json:"items" binding:"required,dive"`
-	Remarks     string                    `json:"remarks"`
-}
-
-// RequisitionItemRequest 采购申请项目请求
- type RequisitionItemRequest struct {
-	MaterialID  uint    `json:"material_id" binding:"required"`
-	Quantity    float64 `json:"quantity" binding:"required"`
-	EstimatedPrice float64 `json:"estimated_price" binding:"required"`
-	RequiredDate string `json:"required_date" binding:"required"`
-	Purpose     string `json:"purpose" binding:"required"`
-}
-
-// RequisitionUpdateRequest 更新采购申请请求
- type RequisitionUpdateRequest struct {
-	DepartmentID uint                     `json:"department_id"`
-	ApplicantID  uint                     `json:"applicant_id"`
-	ApplicationDate string                 `json:"application_date"`
-	EstimatedAmount float64               `json:"estimated_amount"`
-	Reason      string                    `json:"reason"`
-	Items       []RequisitionItemRequest `json:"items" binding:"omitempty,dive"`
-	Remarks     string                    `json:"remarks"`
+type RequisitionCreateRequest struct {
+	RequisitionNo   string                   `json:"requisition_no" binding:"required,max=20"`
+	DepartmentID    string                   `json:"department_id" binding:"required"`
+	ApplicantID     string                   `json:"applicant_id" binding:"required"`
+	ApplicationDate string                   `json:"application_date" binding:"required,datetime=2006-01-02"`
+	Reason          string                   `json:"reason" binding:"required"`
+	Items           []RequisitionItemRequest `json:"items" binding:"required,min=1,dive"`
+	Remarks         string                   `json:"remarks"`
+	CreatedBy       string                   `json:"created_by" binding:"required"`
+}
+
+// RequisitionItemRequest 采购申请项目请求，可指定建议供应商
+type RequisitionItemRequest struct {
+	ItemID         string  `json:"item_id" binding:"required"`
+	VendorID       string  `json:"vendor_id"`
+	WarehouseID    string  `json:"warehouse_id"`
+	Quantity       float64 `json:"quantity" binding:"required,gt=0"`
+	EstimatedPrice float64 `json:"estimated_price" binding:"omitempty,min=0"`
+	RequiredDate   string  `json:"required_date" binding:"required,datetime=2006-01-02"`
+	Purpose        string  `json:"purpose"`
+}
+
+// RequisitionUpdateRequest 更新采购申请请求，传入明细时整体替换
+type RequisitionUpdateRequest struct {
+	DepartmentID    string                   `json:"department_id"`
+	ApplicantID     string                   `json:"applicant_id"`
+	ApplicationDate string                   `json:"application_date" binding:"omitempty,datetime=2006-01-02"`
+	Reason          string                   `json:"reason"`
+	Items           []RequisitionItemRequest `json:"items" binding:"omitempty,dive"`
+	Remarks         string                   `json:"remarks"`
+	UpdatedBy       string                   `json:"updated_by" binding:"required"`
 }
 
 // RequisitionResponse 采购申请响应
- type RequisitionResponse struct {
-	ID             uint                    `json:"id"`
-	Code           string                  `json:"code"`
-	DepartmentID   uint                    `json:"department_id"`
-	DepartmentName string                  `json:"department_name"`
-	ApplicantID    uint                    `json:"applicant_id"`
-	ApplicantName  string                  `json:"applicant_name"`
-	ApplicationDate string                  `json:"application_date"`
-	EstimatedAmount float64                `json:"estimated_amount"`
-	ActualAmount   float64                 `json:"actual_amount"`
-	Status         string                  `json:"status"`
-	Reason         string                  `json:"reason"`
-	Items          []RequisitionItemResponse `json:"items"`
-	Remarks        string                  `json:"remarks"`
-	CreatedAt      string                  `json:"created_at"`
-	UpdatedAt      string                  `json:"updated_at"`
+type RequisitionResponse struct {
+	ID              string                    `json:"id"`
+	RequisitionNo   string                    `json:"requisition_no"`
+	DepartmentID    string                    `json:"department_id"`
+	ApplicantID     string                    `json:"applicant_id"`
+	ApplicationDate string                    `json:"application_date"`
+	EstimatedAmount float64                   `json:"estimated_amount"`
+	Status          string                    `json:"status"`
+	Reason          string                    `json:"reason"`
+	Items           []RequisitionItemResponse `json:"items"`
+	Remarks         string                    `json:"remarks"`
+	CreatedBy       string                    `json:"created_by"`
+	CreatedAt       string                    `json:"created_at"`
+	UpdatedBy       string                    `json:"updated_by"`
+	UpdatedAt       string                    `json:"updated_at"`
 }
 
 // RequisitionItemResponse 采购申请项目响应
- type RequisitionItemResponse struct {
-	ID           uint    `json:"id"`
-	RequisitionID uint    `json:"requisition_id"`
-	MaterialID   uint    `json:"material_id"`
-	MaterialCode string  `json:"material_code"`
-	MaterialName string  `json:"material_name"`
-	Quantity     float64 `json:"quantity"`
-	EstimatedPrice float64 `json:"estimated_price"`
-	ActualPrice  float64 `json:"actual_price"`
-	RequiredDate string  `json:"required_date"`
-	Purpose      string  `json:"purpose"`
+type RequisitionItemResponse struct {
+	ID                string  `json:"id"`
+	RequisitionID     string  `json:"requisition_id"`
+	ItemID            string  `json:"item_id"`
+	VendorID          string  `json:"vendor_id"`
+	WarehouseID       string  `json:"warehouse_id"`
+	Quantity          float64 `json:"quantity"`
+	OrderedQuantity   float64 `json:"ordered_quantity"`
+	RemainingQuantity float64 `json:"remaining_quantity"`
+	EstimatedPrice    float64 `json:"estimated_price"`
+	EstimatedAmount   float64 `json:"estimated_amount"`
+	RequiredDate      string  `json:"required_date"`
+	Purpose           string  `json:"purpose"`
 }
 
 // 采购订单相关结构体
 
-// PurchaseOrderCreateRequest 创建采购订单请求
- type PurchaseOrderCreateRequest struct {
-	Code        string                     `json:"code" binding:"required"`
-	SupplierID  uint                       `json:"supplier_id" binding:"required"`
-	RequisitionID uint                     `json:"requisition_id"`
-	OrderDate   string                     `json:"order_date" binding:"required"`
-	ExpectedDeliveryDate string            `json:"expected_delivery_date" binding:"required"`
-	TotalAmount float64                    `json:"total_amount" binding:"required"`
-	TaxAmount   float64                    `json:"tax_amount"`
-	GrandTotal  float64                    `json:"grand_total" binding:"required"`
-	PaymentTerm string                     `json:"payment_term"`
-	DeliveryAddress string                 `json:"delivery_address"`
-	Items       []PurchaseOrderItemRequest `json:"items" binding:"required,dive"`
-	Remarks     string                     `json:"remarks"`
+// PurchaseOrderCreateRequest 创建采购订单请求，订单金额按明细汇总，未指定币种时取供应商默认币种
+type PurchaseOrderCreateRequest struct {
+	OrderNo      string                     `json:"order_no" binding:"required,max=20"`
+	VendorID     string                     `json:"vendor_id" binding:"required"`
+	OrderDate    string                     `json:"order_date" binding:"required,datetime=2006-01-02"`
+	DeliveryDate string                     `json:"delivery_date" binding:"omitempty,datetime=2006-01-02"`
+	Currency     string                     `json:"currency" binding:"omitempty,len=3"`
+	ExchangeRate float64                    `json:"exchange_rate" binding:"omitempty,gt=0"`
+	PaymentTerms string                     `json:"payment_terms" binding:"omitempty,max=50"`
+	Items        []PurchaseOrderItemRequest `json:"items" binding:"required,min=1,dive"`
+	Remarks      string                     `json:"remarks"`
+	CreatedBy    string                     `json:"created_by" binding:"required"`
 }
 
 // PurchaseOrderItemRequest 采购订单项目请求
- type PurchaseOrderItemRequest struct {
-	MaterialID  uint    `json:"material_id" binding:"required"`
-	Quantity    float64 `json:"quantity" binding:"required"`
-	UnitPrice   float64 `json:"unit_price" binding:"required"`
-	TaxRate     float64 `json:"tax_rate"`
-	Subtotal    float64 `json:"subtotal" binding:"required"`
-	DeliveryDate string `json:"delivery_date"`
-}
-
-// PurchaseOrderUpdateRequest 更新采购订单请求
- type PurchaseOrderUpdateRequest struct {
-	SupplierID  uint                       `json:"supplier_id"`
-	OrderDate   string                     `json:"order_date"`
-	ExpectedDeliveryDate string            `json:"expected_delivery_date"`
-	TotalAmount float64                    `json:"total_amount"`
-	TaxAmount   float64                    `json:"tax_amount"`
-	GrandTotal  float64                    `json:"grand_total"`
-	PaymentTerm string                     `json:"payment_term"`
-	DeliveryAddress string                 `json:"delivery_address"`
-	Items       []PurchaseOrderItemRequest `json:"items" binding:"omitempty,dive"`
-	Remarks     string                     `json:"remarks"`
+type PurchaseOrderItemRequest struct {
+	ItemID      string  `json:"item_id" binding:"required"`
+	Quantity    float64 `json:"quantity" binding:"required,gt=0"`
+	UnitPrice   float64 `json:"unit_price" binding:"min=0"`
+	Discount    float64 `json:"discount" binding:"omitempty,min=0"`
+	WarehouseID string  `json:"warehouse_id"`
+}
+
+// PurchaseOrderUpdateRequest 更新采购订单请求，传入明细时整体替换
+type PurchaseOrderUpdateRequest struct {
+	VendorID     string                     `json:"vendor_id"`
+	OrderDate    string                     `json:"order_date" binding:"omitempty,datetime=2006-01-02"`
+	DeliveryDate string                     `json:"delivery_date" binding:"omitempty,datetime=2006-01-02"`
+	Currency     string                     `json:"currency" binding:"omitempty,len=3"`
+	ExchangeRate float64                    `json:"exchange_rate" binding:"omitempty,gt=0"`
+	PaymentTerms string                     `json:"payment_terms" binding:"omitempty,max=50"`
+	Items        []PurchaseOrderItemRequest `json:"items" binding:"omitempty,dive"`
+	Remarks      string                     `json:"remarks"`
+	UpdatedBy    string                     `json:"updated_by" binding:"required"`
 }
 
 // PurchaseOrderResponse 采购订单响应
- type PurchaseOrderResponse struct {
-	ID                   uint                      `json:"id"`
-	Code                 string                    `json:"code"`
-	SupplierID           uint                      `json:"supplier_id"`
-	SupplierName         string                    `json:"supplier_name"`
-	RequisitionID        uint                      `json:"requisition_id"`
-	OrderDate            string                    `json:"order_date"`
-	ExpectedDeliveryDate string                    `json:"expected_delivery_date"`
-	ActualDeliveryDate   string                    `json:"actual_delivery_date"`
-	TotalAmount          float64                   `json:"total_amount"`
-	TaxAmount            float64                   `json:"tax_amount"`
-	GrandTotal           float64                   `json:"grand_total"`
-	PaidAmount           float64                   `json:"paid_amount"`
-	Status               string                    `json:"status"`
-	PaymentTerm          string                    `json:"payment_term"`
-	DeliveryAddress      string                    `json:"delivery_address"`
-	Items                []PurchaseOrderItemResponse `json:"items"`
-	Remarks              string                    `json:"remarks"`
-	CreatedAt            string                    `json:"created_at"`
-	UpdatedAt            string                    `json:"updated_at"`
+type PurchaseOrderResponse struct {
+	ID              string                      `json:"id"`
+	OrderNo         string                      `json:"order_no"`
+	VendorID        string                      `json:"vendor_id"`
+	OrderDate       string                      `json:"order_date"`
+	DeliveryDate    string                      `json:"delivery_date"`
+	TotalAmount     float64                     `json:"total_amount"`
+	Currency        string                      `json:"currency"`
+	ExchangeRate    float64                     `json:"exchange_rate"`
+	BaseTotalAmount float64                     `json:"base_total_amount"`
+	Status          string                      `json:"status"`
+	PaymentTerms    string                      `json:"payment_terms"`
+	Items           []PurchaseOrderItemResponse `json:"items"`
+	Remarks         string                      `json:"remarks"`
+	CreatedBy       string                      `json:"created_by"`
+	CreatedAt       string                      `json:"created_at"`
+	UpdatedBy       string                      `json:"updated_by"`
+	UpdatedAt       string                      `json:"updated_at"`
 }
 
 // PurchaseOrderItemResponse 采购订单项目响应
- type PurchaseOrderItemResponse struct {
-	ID            uint    `json:"id"`
-	PurchaseOrderID uint    `json:"purchase_order_id"`
-	MaterialID    uint    `json:"material_id"`
-	MaterialCode  string  `json:"material_code"`
-	MaterialName  string  `json:"material_name"`
-	Quantity      float64 `json:"quantity"`
-	ReceivedQuantity float64 `json:"received_quantity"`
-	UnitPrice     float64 `json:"unit_price"`
-	TaxRate       float64 `json:"tax_rate"`
-	Subtotal      float64 `json:"subtotal"`
-	DeliveryDate  string  `json:"delivery_date"`
+type PurchaseOrderItemResponse struct {
+	ID                string  `json:"id"`
+	OrderID           string  `json:"order_id"`
+	ItemID            string  `json:"item_id"`
+	Quantity          float64 `json:"quantity"`
+	UnitPrice         float64 `json:"unit_price"`
+	Discount          float64 `json:"discount"`
+	Amount            float64 `json:"amount"`
+	ReceivedQuantity  float64 `json:"received_quantity"`
+	WarehouseID       string  `json:"warehouse_id,omitempty"`
+	RequisitionItemID string  `json:"requisition_item_id,omitempty"`
+	PlanItemID        string  `json:"plan_item_id,omitempty"`
+}
+
+// PurchaseOrderConversionRequest 由已审批的采购申请和采购计划生成采购订单请求，至少指定一个申请或计划；
+// 明细未指定建议供应商时取vendor_id，仍未确定时取该物料最近一次采购的供应商
+type PurchaseOrderConversionRequest struct {
+	RequisitionIDs []string `json:"requisition_ids"`
+	PlanIDs        []string `json:"plan_ids"`
+	VendorID       string   `json:"vendor_id"`
+	OrderDate      string   `json:"order_date" binding:"omitempty,datetime=2006-01-02"`
+	WarehouseID    string   `json:"warehouse_id"`
+	CreatedBy      string   `json:"created_by" binding:"required"`
 }
 
 // 采购收货相关结构体
 
-// ReceiptCreateRequest 创建采购收货请求
- type ReceiptCreateRequest struct {
-	Code           string                   `json:"code" binding:"required"`
-	PurchaseOrderID uint                     `json:"purchase_order_id" binding:"required"`
-	ReceiptDate    string                   `json:"receipt_date" binding:"required"`
-	WarehouseID    uint                     `json:"warehouse_id" binding:"required"`
-	TotalQuantity  float64                  `json:"total_quantity" binding:"required"`
-	Items          []ReceiptItemRequest     `json:"items" binding:"required,dive"`
-	Remarks        string                   `json:"remarks"`
+// ReceiptCreateRequest 创建采购收货请求，物料和单价取自订单明细
+type ReceiptCreateRequest struct {
+	ReceiptNo   string               `json:"receipt_no" binding:"required,max=20"`
+	OrderID     string               `json:"order_id" binding:"required"`
+	ReceiptDate string               `json:"receipt_date" binding:"required,datetime=2006-01-02"`
+	Items       []ReceiptItemRequest `json:"items" binding:"required,min=1,dive"`
+	Remarks     string               `json:"remarks"`
+	CreatedBy   string               `json:"created_by" binding:"required"`
 }
 
 // ReceiptItemRequest 采购收货项目请求
- type ReceiptItemRequest struct {
-	PurchaseOrderItemID uint    `json:"purchase_order_item_id" binding:"required"`
-	Quantity            float64 `json:"quantity" binding:"required"`
-	BatchNumber         string  `json:"batch_number"`
-	ExpiryDate          string  `json:"expiry_date"`
-	Remarks             string  `json:"remarks"`
+type ReceiptItemRequest struct {
+	OrderItemID string  `json:"order_item_id" binding:"required"`
+	Quantity    float64 `json:"quantity" binding:"required,gt=0"`
+	WarehouseID string  `json:"warehouse_id" binding:"required"`
+	LocationID  string  `json:"location_id"`
 }
 
 // ReceiptUpdateRequest 更新采购收货请求
- type ReceiptUpdateRequest struct {
-	ReceiptDate    string                   `json:"receipt_date"`
-	WarehouseID    uint                     `json:"warehouse_id"`
-	TotalQuantity  float64                  `json:"total_quantity"`
-	Items          []ReceiptItemRequest     `json:"items" binding:"omitempty,dive"`
-	Remarks        string                   `json:"remarks"`
+type ReceiptUpdateRequest struct {
+	ReceiptDate string `json:"receipt_date" binding:"omitempty,datetime=2006-01-02"`
+	Remarks     string `json:"remarks"`
+	UpdatedBy   string `json:"updated_by" binding:"required"`
 }
 
 // ReceiptResponse 采购收货响应
- type ReceiptResponse struct {
-	ID              uint                      `json:"id"`
-	Code            string                    `json:"code"`
-	PurchaseOrderID uint                      `json:"purchase_order_id"`
-	PurchaseOrderCode string                  `json:"purchase_order_code"`
-	SupplierName    string                    `json:"supplier_name"`
-	ReceiptDate     string                    `json:"receipt_date"`
-	WarehouseID     uint                      `json:"warehouse_id"`
-	WarehouseName   string                    `json:"warehouse_name"`
-	TotalQuantity   float64                   `json:"total_quantity"`
-	Status          string                    `json:"status"`
-	Items           []ReceiptItemResponse     `json:"items"`
-	Remarks         string                    `json:"remarks"`
-	CreatedAt       string                    `json:"created_at"`
-	UpdatedAt       string                    `json:"updated_at"`
+type ReceiptResponse struct {
+	ID            string                `json:"id"`
+	ReceiptNo     string                `json:"receipt_no"`
+	OrderID       string                `json:"order_id"`
+	VendorID      string                `json:"vendor_id"`
+	ReceiptDate   string                `json:"receipt_date"`
+	TotalQuantity float64               `json:"total_quantity"`
+	TotalAmount   float64               `json:"total_amount"`
+	Status        string                `json:"status"`
+	Items         []ReceiptItemResponse `json:"items"`
+	Remarks       string                `json:"remarks"`
+	CreatedBy     string                `json:"created_by"`
+	CreatedAt     string                `json:"created_at"`
+	UpdatedBy     string                `json:"updated_by"`
+	UpdatedAt     string                `json:"updated_at"`
 }
 
 // ReceiptItemResponse 采购收货项目响应
- type ReceiptItemResponse struct {
-	ID                  uint    `json:"id"`
-	ReceiptID           uint    `json:"receipt_id"`
-	PurchaseOrderItemID uint    `json:"purchase_order_item_id"`
-	MaterialID          uint    `json:"material_id"`
-	MaterialCode        string  `json:"material_code"`
-	MaterialName        string  `json:"material_name"`
-	Quantity            float64 `json:"quantity"`
-	BatchNumber         string  `json:"batch_number"`
-	ExpiryDate          string  `json:"expiry_date"`
-	Remarks             string  `json:"remarks"`
+type ReceiptItemResponse struct {
+	ID          string  `json:"id"`
+	ReceiptID   string  `json:"receipt_id"`
+	OrderItemID string  `json:"order_item_id"`
+	ItemID      string  `json:"item_id"`
+	Quantity    float64 `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	Amount      float64 `json:"amount"`
+	WarehouseID string  `json:"warehouse_id"`
+	LocationID  string  `json:"location_id"`
 }
 
 // 采购发票相关结构体
 
-// PurchaseInvoiceCreateRequest 创建采购发票请求
- type PurchaseInvoiceCreateRequest struct {
-	Code           string                     `json:"code" binding:"required"`
-	PurchaseOrderID uint                       `json:"purchase_order_id" binding:"required"`
-	InvoiceDate    string                     `json:"invoice_date" binding:"required"`
-	InvoiceNumber  string                     `json:"invoice_number" binding:"required"`
-	TotalAmount    float64                    `json:"total_amount" binding:"required"`
-	TaxAmount      float64                    `json:"tax_amount"`
-	GrandTotal     float64                    `json:"grand_total" binding:"required"`
-	DueDate        string                     `json:"due_date"`
-	Items          []PurchaseInvoiceItemRequest `json:"items" binding:"required,dive"`
-	Remarks        string                     `json:"remarks"`
-}
-
-// PurchaseInvoiceItemRequest 采购发票项目请求
- type PurchaseInvoiceItemRequest struct {
-	MaterialID  uint    `json:"material_id" binding:"required"`
-	Quantity    float64 `json:"quantity" binding:"required"`
-	UnitPrice   float64 `json:"unit_price" binding:"required"`
-	TaxRate     float64 `json:"tax_rate"`
-	Subtotal    float64 `json:"subtotal" binding:"required"`
-}
-
-// PurchaseInvoiceUpdateRequest 更新采购发票请求
- type PurchaseInvoiceUpdateRequest struct {
-	InvoiceDate    string                     `json:"invoice_date"`
-	InvoiceNumber  string                     `json:"invoice_number"`
-	TotalAmount    float64                    `json:"total_amount"`
-	TaxAmount      float64                    `json:"tax_amount"`
-	GrandTotal     float64                    `json:"grand_total"`
-	DueDate        string                     `json:"due_date"`
-	Items          []PurchaseInvoiceItemRequest `json:"items" binding:"omitempty,dive"`
-	Remarks        string                     `json:"remarks"`
+// PurchaseInvoiceCreateRequest 创建采购发票请求，供应商和币种取自采购订单，未指定汇率时按发票日期取汇率；
+// 传入明细时总金额和税额按明细计税得出，否则必须传入总金额
+type PurchaseInvoiceCreateRequest struct {
+	InvoiceNo        string                       `json:"invoice_no" binding:"required,max=20"`
+	OrderID          string                       `json:"order_id" binding:"required"`
+	ReceiptID        string                       `json:"receipt_id"`
+	InvoiceDate      string                       `json:"invoice_date" binding:"required,datetime=2006-01-02"`
+	DueDate          string                       `json:"due_date" binding:"omitempty,datetime=2006-01-02"`
+	TotalAmount      float64                      `json:"total_amount" binding:"omitempty,gt=0"`
+	TaxAmount        float64                      `json:"tax_amount" binding:"omitempty,min=0"`
+	PriceIncludesTax *bool                        `json:"price_includes_tax"`
+	ExchangeRate     float64                      `json:"exchange_rate" binding:"omitempty,gt=0"`
+	PaymentTerms     string                       `json:"payment_terms" binding:"omitempty,max=50"`
+	Items            []PurchaseInvoiceItemRequest `json:"items" binding:"omitempty,dive"`
+	Remarks          string                       `json:"remarks"`
+	CreatedBy        string                       `json:"created_by" binding:"required"`
+}
+
+// PurchaseInvoiceItemRequest 采购发票项目请求，未指定订单明细时按物料匹配采购订单明细
+type PurchaseInvoiceItemRequest struct {
+	OrderItemID string  `json:"order_item_id"`
+	ItemID      string  `json:"item_id" binding:"required"`
+	Quantity    float64 `json:"quantity" binding:"required,gt=0"`
+	UnitPrice   float64 `json:"unit_price" binding:"min=0"`
+	Discount    float64 `json:"discount" binding:"omitempty,min=0"`
+}
+
+// PurchaseInvoiceUpdateRequest 更新采购发票请求，有明细的发票金额和税额不能手工修改
+type PurchaseInvoiceUpdateRequest struct {
+	ReceiptID    string   `json:"receipt_id"`
+	InvoiceDate  string   `json:"invoice_date" binding:"omitempty,datetime=2006-01-02"`
+	DueDate      string   `json:"due_date" binding:"omitempty,datetime=2006-01-02"`
+	TotalAmount  *float64 `json:"total_amount" binding:"omitempty,gt=0"`
+	TaxAmount    *float64 `json:"tax_amount" binding:"omitempty,min=0"`
+	ExchangeRate float64  `json:"exchange_rate" binding:"omitempty,gt=0"`
+	PaymentTerms string   `json:"payment_terms" binding:"omitempty,max=50"`
+	Remarks      string   `json:"remarks"`
+	UpdatedBy    string   `json:"updated_by" binding:"required"`
 }
 
 // PurchaseInvoiceResponse 采购发票响应
- type PurchaseInvoiceResponse struct {
-	ID              uint                        `json:"id"`
-	Code            string                      `json:"code"`
-	PurchaseOrderID uint                        `json:"purchase_order_id"`
-	PurchaseOrderCode string                    `json:"purchase_order_code"`
-	SupplierID      uint                        `json:"supplier_id"`
-	SupplierName    string                      `json:"supplier_name"`
-	InvoiceDate     string                      `json:"invoice_date"`
-	InvoiceNumber   string                      `json:"invoice_number"`
-	TotalAmount     float64                     `json:"total_amount"`
-	TaxAmount       float64                     `json:"tax_amount"`
-	GrandTotal      float64                     `json:"grand_total"`
-	PaidAmount      float64                     `json:"paid_amount"`
-	DueDate         string                      `json:"due_date"`
-	Status          string                      `json:"status"`
-	Items           []PurchaseInvoiceItemResponse `json:"items"`
-	Remarks         string                      `json:"remarks"`
-	CreatedAt       string                      `json:"created_at"`
-	UpdatedAt       string                      `json:"updated_at"`
+type PurchaseInvoiceResponse struct {
+	ID                 string                               `json:"id"`
+	InvoiceNo          string                               `json:"invoice_no"`
+	OrderID            string                               `json:"order_id"`
+	ReceiptID          string                               `json:"receipt_id"`
+	VendorID           string                               `json:"vendor_id"`
+	InvoiceDate        string                               `json:"invoice_date"`
+	DueDate            string                               `json:"due_date"`
+	TotalAmount        float64                              `json:"total_amount"`
+	PaidAmount         float64                              `json:"paid_amount"`
+	DiscountAmount     float64                              `json:"discount_amount"`
+	TaxAmount          float64                              `json:"tax_amount"`
+	PriceIncludesTax   bool                                 `json:"price_includes_tax"`
+	Currency           string                               `json:"currency"`
+	ExchangeRate       float64                              `json:"exchange_rate"`
+	BaseTotalAmount    float64                              `json:"base_total_amount"`
+	BaseTaxAmount      float64                              `json:"base_tax_amount"`
+	Status             string                               `json:"status"`
+	BlockReason        string                               `json:"block_reason"`
+	BlockReleasedBy    string                               `json:"block_released_by"`
+	BlockReleasedAt    string                               `json:"block_released_at"`
+	BlockReleaseReason string                               `json:"block_release_reason"`
+	PaymentTerms       string                               `json:"payment_terms"`
+	Items              []PurchaseInvoiceItemResponse        `json:"items"`
+	Discrepancies      []PurchaseInvoiceDiscrepancyResponse `json:"discrepancies"`
+	Remarks            string                               `json:"remarks"`
+	CreatedBy          string                               `json:"created_by"`
+	CreatedAt          string                               `json:"created_at"`
+	UpdatedBy          string                               `json:"updated_by"`
+	UpdatedAt          string                               `json:"updated_at"`
+}
+
+// PayPurchaseInvoiceRequest 采购发票付款请求，金额为空时付清余额，日期为空时取当天
+type PayPurchaseInvoiceRequest struct {
+	Amount        float64 `json:"amount" binding:"omitempty,gt=0"`
+	PaymentDate   string  `json:"payment_date" binding:"omitempty,datetime=2006-01-02"`
+	PaymentMethod string  `json:"payment_method"`
+	BankAccountID string  `json:"bank_account_id"`
+	Remarks       string  `json:"remarks"`
+	CreatedBy     string  `json:"created_by"`
 }
 
 // PurchaseInvoiceItemResponse 采购发票项目响应
- type PurchaseInvoiceItemResponse struct {
-	ID            uint    `json:"id"`
-	PurchaseInvoiceID uint    `json:"purchase_invoice_id"`
-	MaterialID    uint    `json:"material_id"`
-	MaterialCode  string  `json:"material_code"`
-	MaterialName  string  `json:"material_name"`
-	Quantity      float64 `json:"quantity"`
-	UnitPrice     float64 `json:"unit_price"`
-	TaxRate       float64 `json:"tax_rate"`
-	Subtotal      float64 `json:"subtotal"`
+type PurchaseInvoiceItemResponse struct {
+	ID          string  `json:"id"`
+	InvoiceID   string  `json:"invoice_id"`
+	OrderItemID string  `json:"order_item_id"`
+	ItemID      string  `json:"item_id"`
+	Quantity    float64 `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	Discount    float64 `json:"discount"`
+	Amount      float64 `json:"amount"`
+	TaxCodeID   string  `json:"tax_code_id"`
+	TaxRate     float64 `json:"tax_rate"`
+	NetAmount   float64 `json:"net_amount"`
+	TaxAmount   float64 `json:"tax_amount"`
+}
+
+// PurchaseInvoiceDiscrepancyResponse 采购发票三单匹配差异响应
+type PurchaseInvoiceDiscrepancyResponse struct {
+	ID              string  `json:"id"`
+	InvoiceItemID   string  `json:"invoice_item_id"`
+	OrderItemID     string  `json:"order_item_id"`
+	Type            string  `json:"type"`
+	Expected        float64 `json:"expected"`
+	Actual          float64 `json:"actual"`
+	Variance        float64 `json:"variance"`
+	VariancePercent float64 `json:"variance_percent"`
+	Tolerance       float64 `json:"tolerance"`
+	Reason          string  `json:"reason"`
+}
+
+// ReleaseInvoiceBlockRequest 放行匹配冻结的采购发票请求，放行人取当前登录用户
+type ReleaseInvoiceBlockRequest struct {
+	Reason string `json:"reason" binding:"required,max=255"`
 }
 
 // 采购退货相关结构体
 
-// PurchaseReturnCreateRequest 创建采购退货请求
- type PurchaseReturnCreateRequest struct {
-	Code           string                      `json:"code" binding:"required"`
-	PurchaseOrderID uint                        `json:"purchase_order_id" binding:"required"`
-	ReceiptID      uint                        `json:"receipt_id" binding:"required"`
-	ReturnDate     string                      `json:"return_date" binding:"required"`
-	TotalQuantity  float64                     `json:"total_quantity" binding:"required"`
-	TotalAmount    float64                     `json:"total_amount" binding:"required"`
-	Reason         string                      `json:"reason" binding:"required"`
-	Items          []PurchaseReturnItemRequest `json:"items" binding:"required,dive"`
-	Remarks        string                      `json:"remarks"`
+// PurchaseReturnCreateRequest 创建采购退货请求，数量和金额按明细汇总
+type PurchaseReturnCreateRequest struct {
+	ReturnNo   string                      `json:"return_no" binding:"required,max=20"`
+	OrderID    string                      `json:"order_id" binding:"required"`
+	ReceiptID  string                      `json:"receipt_id"`
+	ReturnDate string                      `json:"return_date" binding:"required,datetime=2006-01-02"`
+	Reason     string                      `json:"reason" binding:"required"`
+	Items      []PurchaseReturnItemRequest `json:"items" binding:"required,min=1,dive"`
+	Remarks    string                      `json:"remarks"`
+	CreatedBy  string                      `json:"created_by" binding:"required"`
 }
 
 // PurchaseReturnItemRequest 采购退货项目请求
- type PurchaseReturnItemRequest struct {
-	ReceiptItemID uint    `json:"receipt_item_id" binding:"required"`
-	Quantity      float64 `json:"quantity" binding:"required"`
-	UnitPrice     float64 `json:"unit_price" binding:"required"`
-	Subtotal      float64 `json:"subtotal" binding:"required"`
-	Reason        string  `json:"reason"`
+type PurchaseReturnItemRequest struct {
+	ItemID      string  `json:"item_id" binding:"required"`
+	Quantity    float64 `json:"quantity" binding:"required,gt=0"`
+	UnitPrice   float64 `json:"unit_price" binding:"min=0"`
+	WarehouseID string  `json:"warehouse_id" binding:"required"`
 }
 
 // PurchaseReturnUpdateRequest 更新采购退货请求
- type PurchaseReturnUpdateRequest struct {
-	ReturnDate     string                      `json:"return_date"`
-	TotalQuantity  float64                     `json:"total_quantity"`
-	TotalAmount    float64                     `json:"total_amount"`
-	Reason         string                      `json:"reason"`
-	Items          []PurchaseReturnItemRequest `json:"items" binding:"omitempty,dive"`
-	Remarks        string                      `json:"remarks"`
+type PurchaseReturnUpdateRequest struct {
+	ReturnDate string `json:"return_date" binding:"omitempty,datetime=2006-01-02"`
+	Reason     string `json:"reason"`
+	Remarks    string `json:"remarks"`
+	UpdatedBy  string `json:"updated_by" binding:"required"`
 }
 
 // PurchaseReturnResponse 采购退货响应
- type PurchaseReturnResponse struct {
-	ID              uint                         `json:"id"`
-	Code            string                       `json:"code"`
-	PurchaseOrderID uint                         `json:"purchase_order_id"`
-	PurchaseOrderCode string                     `json:"purchase_order_code"`
-	ReceiptID       uint                         `json:"receipt_id"`
-	ReceiptCode     string                       `json:"receipt_code"`
-	SupplierID      uint                         `json:"supplier_id"`
-	SupplierName    string                       `json:"supplier_name"`
-	ReturnDate      string                       `json:"return_date"`
-	TotalQuantity   float64                      `json:"total_quantity"`
-	TotalAmount     float64                      `json:"total_amount"`
-	Status          string                       `json:"status"`
-	Reason          string                       `json:"reason"`
-	Items           []PurchaseReturnItemResponse `json:"items"`
-	Remarks         string                       `json:"remarks"`
-	CreatedAt       string                       `json:"created_at"`
-	UpdatedAt       string                       `json:"updated_at"`
+type PurchaseReturnResponse struct {
+	ID            string                       `json:"id"`
+	ReturnNo      string                       `json:"return_no"`
+	OrderID       string                       `json:"order_id"`
+	ReceiptID     string                       `json:"receipt_id"`
+	VendorID      string                       `json:"vendor_id"`
+	ReturnDate    string                       `json:"return_date"`
+	TotalQuantity float64                      `json:"total_quantity"`
+	TotalAmount   float64                      `json:"total_amount"`
+	Status        string                       `json:"status"`
+	Reason        string                       `json:"reason"`
+	Items         []PurchaseReturnItemResponse `json:"items"`
+	Remarks       string                       `json:"remarks"`
+	CreatedBy     string                       `json:"created_by"`
+	CreatedAt     string                       `json:"created_at"`
+	UpdatedBy     string                       `json:"updated_by"`
+	UpdatedAt     string                       `json:"updated_at"`
 }
 
 // PurchaseReturnItemResponse 采购退货项目响应
- type PurchaseReturnItemResponse struct {
-	ID             uint    `json:"id"`
-	PurchaseReturnID uint    `json:"purchase_return_id"`
-	ReceiptItemID  uint    `json:"receipt_item_id"`
-	MaterialID     uint    `json:"material_id"`
-	MaterialCode   string  `json:"material_code"`
-	MaterialName   string  `json:"material_name"`
-	Quantity       float64 `json:"quantity"`
-	UnitPrice      float64 `json:"unit_price"`
-	Subtotal       float64 `json:"subtotal"`
-	Reason         string  `json:"reason"`
+type PurchaseReturnItemResponse struct {
+	ID          string  `json:"id"`
+	ReturnID    string  `json:"return_id"`
+	ItemID      string  `json:"item_id"`
+	Quantity    float64 `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	Amount      float64 `json:"amount"`
+	WarehouseID string  `json:"warehouse_id"`
 }
 
 // 采购报表相关结构体
 
-// PurchaseSummaryReportRequest 采购汇总报表请求
- type PurchaseSummaryReportRequest struct {
-	StartDate     string `json:"start_date" binding:"required"`
-	EndDate       string `json:"end_date" binding:"required"`
-	SupplierID    uint   `json:"supplier_id"`
-	Category      string `json:"category"`
+// PurchaseReportRequest 采购报表查询请求
+type PurchaseReportRequest struct {
+	StartDate  string `form:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate    string `form:"end_date" binding:"omitempty,datetime=2006-01-02"`
+	VendorID   string `form:"vendor_id"`
+	ItemID     string `form:"item_id"`
+	SourceType string `form:"source_type" binding:"omitempty,oneof=order invoice"` // 价格分析的价格来源，不传时包括订单和发票
+}
+
+// PurchaseForecastRequest 采购预测请求
+type PurchaseForecastRequest struct {
+	ItemID         string  `form:"item_id" json:"item_id"`
+	VendorID       string  `form:"vendor_id" json:"vendor_id"` // 只预测最近向该供应商采购过的物料
+	Method         string  `form:"method" json:"method" binding:"omitempty,oneof=moving_average exponential_smoothing seasonal"`
+	Bucket         string  `form:"bucket" json:"bucket" binding:"omitempty,oneof=week month"`
+	StartDate      string  `form:"start_date" json:"start_date" binding:"omitempty,datetime=2006-01-02"` // 预测起始日期，默认当天
+	Periods        int     `form:"periods" json:"periods" binding:"omitempty,min=1,max=24"`              // 预测期数，默认3
+	HistoryPeriods int     `form:"history_periods" json:"history_periods" binding:"omitempty,min=1,max=36"`
+	Alpha          float64 `form:"alpha" json:"alpha" binding:"omitempty,gt=0,lte=1"` // 指数平滑系数，默认0.3
+}
+
+// ExportPurchaseReportRequest 导出采购报表请求
+type ExportPurchaseReportRequest struct {
+	ReportType string `form:"report_type" binding:"required,oneof=summary detail supplier_analysis price_analysis forecast"`
+	StartDate  string `form:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate    string `form:"end_date" binding:"omitempty,datetime=2006-01-02"`
+	VendorID   string `form:"vendor_id"`
+	ItemID     string `form:"item_id"`
 }
 
 // PurchaseSummaryReportResponse 采购汇总报表响应
- type PurchaseSummaryReportResponse struct {
-	Period        string  `json:"period"`
-	TotalOrders   int     `json:"total_orders"`
-	TotalAmount   float64 `json:"total_amount"`
-	TotalTax      float64 `json:"total_tax"`
-	GrandTotal    float64 `json:"grand_total"`
+type PurchaseSummaryReportResponse struct {
+	Period        string                 `json:"period"`
+	TotalOrders   int                    `json:"total_orders"`
+	TotalAmount   float64                `json:"total_amount"`
 	SupplierStats []SupplierStatResponse `json:"supplier_stats"`
 }
 
 // SupplierStatResponse 供应商统计响应
- type SupplierStatResponse struct {
-	SupplierID   uint    `json:"supplier_id"`
-	SupplierName string  `json:"supplier_name"`
-	OrderCount   int     `json:"order_count"`
-	TotalAmount  float64 `json:"total_amount"`
-	Percentage   float64 `json:"percentage"`
-}
\ No newline at end of file
+type SupplierStatResponse struct {
+	VendorID    string  `json:"vendor_id"`
+	VendorName  string  `json:"vendor_name"`
+	OrderCount  int     `json:"order_count"`
+	TotalAmount float64 `json:"total_amount"`
+	Percentage  float64 `json:"percentage"`
+}
+
+// PurchaseDetailReportResponse 采购明细报表响应
+type PurchaseDetailReportResponse struct {
+	Period string                     `json:"period"`
+	Items  []PurchaseDetailReportItem `json:"items"`
+}
+
+// PurchaseDetailReportItem 采购明细报表行
+type PurchaseDetailReportItem struct {
+	OrderID          string  `json:"order_id"`
+	OrderNo          string  `json:"order_no"`
+	OrderDate        string  `json:"order_date"`
+	VendorID         string  `json:"vendor_id"`
+	VendorName       string  `json:"vendor_name"`
+	ItemID           string  `json:"item_id"`
+	Quantity         float64 `json:"quantity"`
+	UnitPrice        float64 `json:"unit_price"`
+	Amount           float64 `json:"amount"`
+	ReceivedQuantity float64 `json:"received_quantity"`
+}
+
+// SupplierAnalysisReportResponse 供应商分析报表响应，供应商按综合评分从高到低排名
+type SupplierAnalysisReportResponse struct {
+	Period    string                      `json:"period"`
+	Weights   SupplierScorecardWeights    `json:"weights"`
+	Suppliers []SupplierScorecardResponse `json:"suppliers"`
+}
+
+// SupplierScorecardWeights 综合评分各项指标权重
+type SupplierScorecardWeights struct {
+	OnTime   float64 `json:"on_time"`
+	Quality  float64 `json:"quality"`
+	Price    float64 `json:"price"`
+	LeadTime float64 `json:"lead_time"`
+}
+
+// SupplierScorecardEvaluateRequest 供应商评分卡评估请求，评估结果按供应商和期间保存为历史评分
+type SupplierScorecardEvaluateRequest struct {
+	StartDate string `json:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate   string `json:"end_date" binding:"omitempty,datetime=2006-01-02"`
+	VendorID  string `json:"vendor_id"`
+	CreatedBy string `json:"created_by"`
+}
+
+// SupplierScorecardResponse 供应商评分卡响应，比率和评分均为百分制
+type SupplierScorecardResponse struct {
+	ID                   string   `json:"id,omitempty"`
+	Rank                 int      `json:"rank,omitempty"`
+	VendorID             string   `json:"vendor_id"`
+	VendorName           string   `json:"vendor_name"`
+	PeriodStart          string   `json:"period_start"`
+	PeriodEnd            string   `json:"period_end"`
+	ReceiptCount         int      `json:"receipt_count"`
+	OnTimeCount          int      `json:"on_time_count"`
+	OnTimeRate           float64  `json:"on_time_rate"`
+	ReceivedQuantity     float64  `json:"received_quantity"`
+	ReturnedQuantity     float64  `json:"returned_quantity"`
+	QualityRejectRate    float64  `json:"quality_reject_rate"`
+	InvoicedAmount       float64  `json:"invoiced_amount"`
+	PriceVarianceAmount  float64  `json:"price_variance_amount"`
+	PriceVariance        float64  `json:"price_variance"`
+	AvgLeadTimeDays      float64  `json:"avg_lead_time_days"`
+	PromisedLeadTimeDays float64  `json:"promised_lead_time_days"`
+	LeadTimeAccuracy     float64  `json:"lead_time_accuracy"`
+	OverallRating        float64  `json:"overall_rating"`
+	PreviousRating       *float64 `json:"previous_rating,omitempty"` // 该供应商早于本期间的最近一次历史评分
+	RatingChange         *float64 `json:"rating_change,omitempty"`
+	CreatedAt            string   `json:"created_at,omitempty"`
+	CreatedBy            string   `json:"created_by,omitempty"`
+}
+
+// PriceAnalysisReportResponse 价格分析报表响应，价格均为折合本位币的折扣后单价
+type PriceAnalysisReportResponse struct {
+	Period   string              `json:"period"`
+	Currency string              `json:"currency"`
+	Items    []PriceAnalysisItem `json:"items"`
+}
+
+// PriceAnalysisItem 物料采购价格统计，平均价按数量加权；节约金额为目标价与实际单价之差乘以数量，负数表示超支
+type PriceAnalysisItem struct {
+	ItemID        string                  `json:"item_id"`
+	ItemCode      string                  `json:"item_code"`
+	ItemName      string                  `json:"item_name"`
+	RecordCount   int                     `json:"record_count"`
+	TotalQuantity float64                 `json:"total_quantity"`
+	TotalAmount   float64                 `json:"total_amount"`
+	MinPrice      float64                 `json:"min_price"`
+	MaxPrice      float64                 `json:"max_price"`
+	AvgPrice      float64                 `json:"avg_price"`
+	FirstPrice    float64                 `json:"first_price"`
+	LastPrice     float64                 `json:"last_price"`
+	LastPriceDate string                  `json:"last_price_date"`
+	LastVendorID  string                  `json:"last_vendor_id"`
+	PriceChange   float64                 `json:"price_change"` // 最后价格相对期间首次价格的变动百分比
+	TargetPrice   *float64                `json:"target_price,omitempty"`
+	SavingsAmount *float64                `json:"savings_amount,omitempty"`
+	SavingsRate   *float64                `json:"savings_rate,omitempty"` // 节约金额占按目标价计算金额的百分比
+	Trend         []PriceTrendPoint       `json:"trend"`
+	Vendors       []VendorPriceComparison `json:"vendors"`
+}
+
+// PriceTrendPoint 物料按月汇总的价格走势
+type PriceTrendPoint struct {
+	Period      string  `json:"period"`
+	RecordCount int     `json:"record_count"`
+	Quantity    float64 `json:"quantity"`
+	MinPrice    float64 `json:"min_price"`
+	MaxPrice    float64 `json:"max_price"`
+	AvgPrice    float64 `json:"avg_price"`
+}
+
+// VendorPriceComparison 同一物料各供应商的价格比较，按平均价从低到高排列
+type VendorPriceComparison struct {
+	VendorID      string   `json:"vendor_id"`
+	VendorName    string   `json:"vendor_name"`
+	RecordCount   int      `json:"record_count"`
+	Quantity      float64  `json:"quantity"`
+	MinPrice      float64  `json:"min_price"`
+	MaxPrice      float64  `json:"max_price"`
+	AvgPrice      float64  `json:"avg_price"`
+	LastPrice     float64  `json:"last_price"`
+	LastPriceDate string   `json:"last_price_date"`
+	PremiumToBest float64  `json:"premium_to_best"` // 平均价高于最低平均价供应商的百分比
+	SavingsAmount *float64 `json:"savings_amount,omitempty"`
+}
+
+// PriceHistoryResponse 采购价格历史响应
+type PriceHistoryResponse struct {
+	ID            string  `json:"id"`
+	VendorID      string  `json:"vendor_id"`
+	VendorName    string  `json:"vendor_name"`
+	ItemID        string  `json:"item_id"`
+	ItemName      string  `json:"item_name"`
+	SourceType    string  `json:"source_type"`
+	SourceID      string  `json:"source_id"`
+	SourceItemID  string  `json:"source_item_id"`
+	SourceNo      string  `json:"source_no"`
+	Quantity      float64 `json:"quantity"`
+	UnitPrice     float64 `json:"unit_price"`
+	Currency      string  `json:"currency"`
+	ExchangeRate  float64 `json:"exchange_rate"`
+	BaseUnitPrice float64 `json:"base_unit_price"`
+	PriceDate     string  `json:"price_date"`
+	CreatedAt     string  `json:"created_at"`
+}
+
+// TargetPriceRequest 设置物料采购目标价请求，目标价为本位币单价
+type TargetPriceRequest struct {
+	TargetPrice float64 `json:"target_price" binding:"required,gt=0"`
+	Remarks     string  `json:"remarks"`
+	UpdatedBy   string  `json:"updated_by" binding:"required"`
+}
+
+// TargetPriceResponse 物料采购目标价响应
+type TargetPriceResponse struct {
+	ID          string  `json:"id"`
+	ItemID      string  `json:"item_id"`
+	ItemCode    string  `json:"item_code"`
+	ItemName    string  `json:"item_name"`
+	TargetPrice float64 `json:"target_price"`
+	Remarks     string  `json:"remarks"`
+	CreatedBy   string  `json:"created_by"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedBy   string  `json:"updated_by"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+// PurchaseForecastReportResponse 采购预测报表响应
+type PurchaseForecastReportResponse struct {
+	Period         string                 `json:"period"`
+	HistoryPeriod  string                 `json:"history_period"`
+	Method         string                 `json:"method"`
+	Bucket         string                 `json:"bucket"`
+	HistoryPeriods int                    `json:"history_periods"`
+	Alpha          float64                `json:"alpha,omitempty"`
+	Items          []PurchaseForecastItem `json:"items"`
+}
+
+// PurchaseForecastItem 物料采购预测
+type PurchaseForecastItem struct {
+	ItemID            string                    `json:"item_id"`
+	ItemCode          string                    `json:"item_code"`
+	ItemName          string                    `json:"item_name"`
+	Unit              string                    `json:"unit"`
+	OnHandQuantity    float64                   `json:"on_hand_quantity"`
+	VendorID          string                    `json:"vendor_id"`
+	LeadTimeDays      int                       `json:"lead_time_days"`
+	EstimatedPrice    float64                   `json:"estimated_price"`
+	ForecastQuantity  float64                   `json:"forecast_quantity"`
+	SuggestedQuantity float64                   `json:"suggested_quantity"`
+	SuggestedDate     string                    `json:"suggested_date"` // 首个建议采购期的最迟下单日期
+	History           []PurchaseForecastHistory `json:"history"`
+	Buckets           []PurchaseForecastBucket  `json:"buckets"`
+}
+
+// PurchaseForecastHistory 历史期间的消耗量
+type PurchaseForecastHistory struct {
+	StartDate string  `json:"start_date"`
+	EndDate   string  `json:"end_date"`
+	Quantity  float64 `json:"quantity"`
+}
+
+// PurchaseForecastBucket 预测期间的需求、供给和建议采购量
+type PurchaseForecastBucket struct {
+	StartDate          string  `json:"start_date"`
+	EndDate            string  `json:"end_date"`
+	ForecastQuantity   float64 `json:"forecast_quantity"`
+	SalesDemand        float64 `json:"sales_demand"`
+	DemandQuantity     float64 `json:"demand_quantity"`
+	ScheduledReceipts  float64 `json:"scheduled_receipts"`
+	SuggestedQuantity  float64 `json:"suggested_quantity"`
+	OrderDate          string  `json:"order_date,omitempty"`
+	ProjectedAvailable float64 `json:"projected_available"`
+}
@@ -9,167 +9,169 @@ type Response struct {
 	Data    interface{} `json:"data"`
 }
 
-// 分页响应结构
-type PaginatedResponse struct {
-	List     interface{} `json:"list"`
-	Total    int64       `json:"total"`
-	Page     int         `json:"page"`
-	PageSize int         `json:"pageSize"`
-}
-
 // 客户管理相关
 
-// GetCustomerListRequest 获取客户列表请求
-
-type GetCustomerListRequest struct {
-	Page       int    `form:"page" binding:"omitempty,min=1"`
-	PageSize   int    `form:"pageSize" binding:"omitempty,min=1,max=100"`
-	CustomerNo string `form:"customerNo" binding:"omitempty"`
-	Name       string `form:"name" binding:"omitempty"`
-	Contact    string `form:"contact" binding:"omitempty"`
-	Phone      string `form:"phone" binding:"omitempty"`
-	Status     string `form:"status" binding:"omitempty,oneof=active inactive"`
-	Category   string `form:"category" binding:"omitempty"`
-	Region     string `form:"region" binding:"omitempty"`
-}
-
 // CustomerResponse 客户响应
 
 type CustomerResponse struct {
-	ID           string    `json:"id"`
-	CustomerNo   string    `json:"customerNo"`
-	Name         string    `json:"name"`
-	Contact      string    `json:"contact"`
-	Phone        string    `json:"phone"`
-	Email        string    `json:"email"`
-	Address      string    `json:"address"`
-	TaxNo        string    `json:"taxNo"`
-	BankName     string    `json:"bankName,omitempty"`
-	BankAccount  string    `json:"bankAccount,omitempty"`
-	Category     string    `json:"category"`
-	Region       string    `json:"region"`
-	CreditLimit  float64   `json:"creditLimit,omitempty"`
-	CreditDays   int       `json:"creditDays,omitempty"`
-	Remarks      string    `json:"remarks,omitempty"`
-	Status       string    `json:"status"`
-	CreatedBy    string    `json:"createdBy"`
-	CreatedAt    time.Time `json:"createdAt"`
-	UpdatedBy    string    `json:"updatedBy"`
-	UpdatedAt    time.Time `json:"updatedAt"`
+	ID            string    `json:"id"`
+	CustomerNo    string    `json:"customerNo"`
+	Name          string    `json:"name"`
+	Contact       string    `json:"contact"`
+	Phone         string    `json:"phone"`
+	Email         string    `json:"email"`
+	Address       string    `json:"address"`
+	TaxNo         string    `json:"taxNo"`
+	BankName      string    `json:"bankName,omitempty"`
+	BankAccount   string    `json:"bankAccount,omitempty"`
+	Category      string    `json:"category"`
+	Region        string    `json:"region"`
+	CreditLimit   float64   `json:"creditLimit"`
+	CreditBalance float64   `json:"creditBalance"`
+	CreditDays    int       `json:"creditDays"`
+	TaxStatus     string    `json:"taxStatus"`
+	Currency      string    `json:"currency"`
+	PaymentTerms  string    `json:"paymentTerms,omitempty"`
+	Remarks       string    `json:"remarks,omitempty"`
+	Status        string    `json:"status"`
+	CreatedBy     string    `json:"createdBy"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedBy     string    `json:"updatedBy"`
+	UpdatedAt     time.Time `json:"updatedAt"`
 }
 
 // CreateCustomerRequest 创建客户请求
 
 type CreateCustomerRequest struct {
-	Name        string  `json:"name" binding:"required"`
-	Contact     string  `json:"contact" binding:"required"`
-	Phone       string  `json:"phone" binding:"required"`
-	Email       string  `json:"email" binding:"omitempty,email"`
-	Address     string  `json:"address" binding:"omitempty"`
-	TaxNo       string  `json:"taxNo" binding:"omitempty"`
-	BankName    string  `json:"bankName" binding:"omitempty"`
-	BankAccount string  `json:"bankAccount" binding:"omitempty"`
-	Category    string  `json:"category" binding:"required"`
-	Region      string  `json:"region" binding:"required"`
-	CreditLimit float64 `json:"creditLimit" binding:"omitempty,min=0"`
-	CreditDays  int     `json:"creditDays" binding:"omitempty,min=0"`
-	Remarks     string  `json:"remarks" binding:"omitempty"`
+	CustomerNo   string  `json:"customerNo" binding:"required,max=20"`
+	Name         string  `json:"name" binding:"required,max=100"`
+	Contact      string  `json:"contact" binding:"required"`
+	Phone        string  `json:"phone" binding:"required"`
+	Email        string  `json:"email" binding:"omitempty,email"`
+	Address      string  `json:"address" binding:"omitempty"`
+	TaxNo        string  `json:"taxNo" binding:"omitempty"`
+	BankName     string  `json:"bankName" binding:"omitempty"`
+	BankAccount  string  `json:"bankAccount" binding:"omitempty"`
+	Category     string  `json:"category" binding:"omitempty"`
+	Region       string  `json:"region" binding:"omitempty"`
+	CreditLimit  float64 `json:"creditLimit" binding:"omitempty,min=0"`
+	CreditDays   int     `json:"creditDays" binding:"omitempty,min=0"`
+	TaxStatus    string  `json:"taxStatus" binding:"omitempty,max=20"`
+	Currency     string  `json:"currency" binding:"omitempty,len=3"`
+	PaymentTerms string  `json:"paymentTerms" binding:"omitempty"`
+	Remarks      string  `json:"remarks" binding:"omitempty"`
+	Status       string  `json:"status" binding:"omitempty,oneof=active inactive"`
+	CreatedBy    string  `json:"createdBy" binding:"required"`
 }
 
 // UpdateCustomerRequest 更新客户请求
 
 type UpdateCustomerRequest struct {
-	Name        string  `json:"name" binding:"omitempty"`
-	Contact     string  `json:"contact" binding:"omitempty"`
-	Phone       string  `json:"phone" binding:"omitempty"`
-	Email       string  `json:"email" binding:"omitempty,email"`
-	Address     string  `json:"address" binding:"omitempty"`
-	TaxNo       string  `json:"taxNo" binding:"omitempty"`
-	BankName    string  `json:"bankName" binding:"omitempty"`
-	BankAccount string  `json:"bankAccount" binding:"omitempty"`
-	Category    string  `json:"category" binding:"omitempty"`
-	Region      string  `json:"region" binding:"omitempty"`
-	CreditLimit float64 `json:"creditLimit" binding:"omitempty,min=0"`
-	CreditDays  int     `json:"creditDays" binding:"omitempty,min=0"`
-	Remarks     string  `json:"remarks" binding:"omitempty"`
-	Status      string  `json:"status" binding:"omitempty,oneof=active inactive"`
-}
-
-// CustomerCreditEvaluateRequest 客户信用评估请求
-
-type CustomerCreditEvaluateRequest struct {
-	EvaluationDate string `json:"evaluationDate" binding:"required,datetime=2006-01-02"`
-	CreditScore    int    `json:"creditScore" binding:"required,min=0,max=100"`
-	RiskLevel      string `json:"riskLevel" binding:"required,oneof=low medium high"`
-	Remarks        string `json:"remarks" binding:"omitempty"`
+	Name         string   `json:"name" binding:"omitempty,max=100"`
+	Contact      string   `json:"contact" binding:"omitempty"`
+	Phone        string   `json:"phone" binding:"omitempty"`
+	Email        string   `json:"email" binding:"omitempty,email"`
+	Address      string   `json:"address" binding:"omitempty"`
+	TaxNo        string   `json:"taxNo" binding:"omitempty"`
+	BankName     string   `json:"bankName" binding:"omitempty"`
+	BankAccount  string   `json:"bankAccount" binding:"omitempty"`
+	Category     string   `json:"category" binding:"omitempty"`
+	Region       string   `json:"region" binding:"omitempty"`
+	CreditLimit  *float64 `json:"creditLimit" binding:"omitempty,min=0"`
+	CreditDays   *int     `json:"creditDays" binding:"omitempty,min=0"`
+	TaxStatus    string   `json:"taxStatus" binding:"omitempty,max=20"`
+	Currency     string   `json:"currency" binding:"omitempty,len=3"`
+	PaymentTerms string   `json:"paymentTerms" binding:"omitempty"`
+	Remarks      string   `json:"remarks" binding:"omitempty"`
+	Status       string   `json:"status" binding:"omitempty,oneof=active inactive"`
+	UpdatedBy    string   `json:"updatedBy" binding:"required"`
+}
+
+// CustomerCreditResponse 客户信用评估响应
+
+type CustomerCreditResponse struct {
+	CustomerId          string    `json:"customerId"`
+	CustomerName        string    `json:"customerName"`
+	CreditLimit         float64   `json:"creditLimit"`
+	CreditBalance       float64   `json:"creditBalance"`
+	AvailableCredit     float64   `json:"availableCredit"`
+	CreditDays          int       `json:"creditDays"`
+	OpenOrderAmount     float64   `json:"openOrderAmount"`
+	UnpaidInvoiceAmount float64   `json:"unpaidInvoiceAmount"`
+	OverdueAmount       float64   `json:"overdueAmount"`
+	OverdueDays         int       `json:"overdueDays"`
+	CreditScore         int       `json:"creditScore"`
+	RiskLevel           string    `json:"riskLevel"`
+	EvaluationDate      time.Time `json:"evaluationDate"`
+}
+
+// CustomerCreditEvaluationResponse 客户信用评估历史记录
+
+type CustomerCreditEvaluationResponse struct {
+	ID             string    `json:"id"`
+	CustomerId     string    `json:"customerId"`
+	EvaluationDate string    `json:"evaluationDate"`
+	CreditScore    int       `json:"creditScore"`
+	RiskLevel      string    `json:"riskLevel"`
+	CreditLimit    float64   `json:"creditLimit"`
+	Exposure       float64   `json:"exposure"`
+	OverdueAmount  float64   `json:"overdueAmount"`
+	OverdueDays    int       `json:"overdueDays"`
+	Remarks        string    `json:"remarks,omitempty"`
+	CreatedBy      string    `json:"createdBy"`
+	CreatedAt      time.Time `json:"createdAt"`
 }
 
 // 销售报价相关
 
-// GetQuotationListRequest 获取销售报价列表请求
-
-type GetQuotationListRequest struct {
-	Page             int    `form:"page" binding:"omitempty,min=1"`
-	PageSize         int    `form:"pageSize" binding:"omitempty,min=1,max=100"`
-	QuotationNo      string `form:"quotationNo" binding:"omitempty"`
-	CustomerId       string `form:"customerId" binding:"omitempty"`
-	CustomerName     string `form:"customerName" binding:"omitempty"`
-	Status           string `form:"status" binding:"omitempty,oneof=draft submitted approved rejected expired"`
-	CreateDateStart  string `form:"createDateStart" binding:"omitempty,datetime=2006-01-02"`
-	CreateDateEnd    string `form:"createDateEnd" binding:"omitempty,datetime=2006-01-02"`
-	ExpiryDateStart  string `form:"expiryDateStart" binding:"omitempty,datetime=2006-01-02"`
-	ExpiryDateEnd    string `form:"expiryDateEnd" binding:"omitempty,datetime=2006-01-02"`
-}
-
-// QuotationItem 报价单明细
+// QuotationItem 报价单明细，单价和折扣由定价服务确定
 
 type QuotationItem struct {
-	ID            string  `json:"id,omitempty"`
-	ProductId     string  `json:"productId" binding:"required"`
-	ProductCode   string  `json:"productCode,omitempty"`
-	ProductName   string  `json:"productName,omitempty"`
-	Specification string  `json:"specification,omitempty"`
-	Unit          string  `json:"unit,omitempty"`
-	Quantity      float64 `json:"quantity" binding:"required,min=0.0001"`
-	UnitPrice     float64 `json:"unitPrice" binding:"required,min=0.01"`
-	Amount        float64 `json:"amount,omitempty"`
-	Remark        string  `json:"remark" binding:"omitempty"`
+	ID          string  `json:"id,omitempty"`
+	ProductId   string  `json:"productId" binding:"required"`
+	ProductCode string  `json:"productCode,omitempty"`
+	ProductName string  `json:"productName,omitempty"`
+	Unit        string  `json:"unit,omitempty"`
+	Quantity    float64 `json:"quantity" binding:"required,gt=0"`
+	UnitPrice   float64 `json:"unitPrice"`
+	Discount    float64 `json:"discount"`
+	Amount      float64 `json:"amount,omitempty"`
 }
 
 // QuotationResponse 报价单响应
 
 type QuotationResponse struct {
-	ID             string          `json:"id"`
-	QuotationNo    string          `json:"quotationNo"`
-	CustomerId     string          `json:"customerId"`
-	CustomerName   string          `json:"customerName"`
-	Contact        string          `json:"contact"`
-	Phone          string          `json:"phone"`
-	Address        string          `json:"address,omitempty"`
-	QuotationDate  string          `json:"quotationDate"`
-	ExpiryDate     string          `json:"expiryDate"`
-	PaymentTerms   string          `json:"paymentTerms,omitempty"`
-	ShippingTerms  string          `json:"shippingTerms,omitempty"`
-	Remarks        string          `json:"remarks,omitempty"`
-	TotalAmount    float64         `json:"totalAmount"`
-	Status         string          `json:"status"`
-	Items          []QuotationItem `json:"items,omitempty"`
-	CreatedBy      string          `json:"createdBy"`
-	CreatedAt      time.Time       `json:"createdAt"`
-	UpdatedBy      string          `json:"updatedBy"`
-	UpdatedAt      time.Time       `json:"updatedAt"`
+	ID              string          `json:"id"`
+	QuotationNo     string          `json:"quotationNo"`
+	CustomerId      string          `json:"customerId"`
+	CustomerName    string          `json:"customerName,omitempty"`
+	QuotationDate   string          `json:"quotationDate"`
+	ExpiryDate      string          `json:"expiryDate"`
+	Remarks         string          `json:"remarks,omitempty"`
+	TotalAmount     float64         `json:"totalAmount"`
+	Currency        string          `json:"currency"`
+	ExchangeRate    float64         `json:"exchangeRate"`
+	BaseTotalAmount float64         `json:"baseTotalAmount"`
+	Status          string          `json:"status"`
+	Items           []QuotationItem `json:"items,omitempty"`
+	CreatedBy       string          `json:"createdBy"`
+	CreatedAt       time.Time       `json:"createdAt"`
+	UpdatedBy       string          `json:"updatedBy"`
+	UpdatedAt       time.Time       `json:"updatedAt"`
 }
 
 // CreateQuotationRequest 创建销售报价请求
 
 type CreateQuotationRequest struct {
+	QuotationNo   string          `json:"quotationNo" binding:"required,max=20"`
 	CustomerId    string          `json:"customerId" binding:"required"`
 	QuotationDate string          `json:"quotationDate" binding:"required,datetime=2006-01-02"`
 	ExpiryDate    string          `json:"expiryDate" binding:"required,datetime=2006-01-02"`
-	PaymentTerms  string          `json:"paymentTerms" binding:"omitempty"`
-	ShippingTerms string          `json:"shippingTerms" binding:"omitempty"`
+	Currency      string          `json:"currency" binding:"omitempty,len=3"`
+	ExchangeRate  float64         `json:"exchangeRate" binding:"omitempty,gt=0"`
 	Remarks       string          `json:"remarks" binding:"omitempty"`
-	Items         []QuotationItem `json:"items" binding:"required,dive"`
+	Items         []QuotationItem `json:"items" binding:"required,min=1,dive"`
+	CreatedBy     string          `json:"createdBy" binding:"required"`
 }
 
 // UpdateQuotationRequest 更新销售报价请求
@@ -178,234 +180,156 @@ type UpdateQuotationRequest struct {
 	CustomerId    string          `json:"customerId" binding:"omitempty"`
 	QuotationDate string          `json:"quotationDate" binding:"omitempty,datetime=2006-01-02"`
 	ExpiryDate    string          `json:"expiryDate" binding:"omitempty,datetime=2006-01-02"`
-	PaymentTerms  string          `json:"paymentTerms" binding:"omitempty"`
-	ShippingTerms string          `json:"shippingTerms" binding:"omitempty"`
+	Currency      string          `json:"currency" binding:"omitempty,len=3"`
+	ExchangeRate  float64         `json:"exchangeRate" binding:"omitempty,gt=0"`
 	Remarks       string          `json:"remarks" binding:"omitempty"`
 	Items         []QuotationItem `json:"items" binding:"omitempty,dive"`
-}
-
-// ApproveQuotationRequest 审批销售报价请求
-
-type ApproveQuotationRequest struct {
-	Approved bool   `json:"approved" binding:"required"`
-	Remarks  string `json:"remarks" binding:"omitempty"`
-}
-
-// GenerateOrderFromQuotationRequest 从报价单生成销售订单请求
-
-type GenerateOrderFromQuotationRequest struct {
-	OrderDate    string `json:"orderDate" binding:"required,datetime=2006-01-02"`
-	DeliveryDate string `json:"deliveryDate" binding:"required,datetime=2006-01-02"`
+	UpdatedBy     string          `json:"updatedBy" binding:"required"`
 }
 
 // 销售订单相关
 
-// GetOrderListRequest 获取销售订单列表请求
-
-type GetOrderListRequest struct {
-	Page             int     `form:"page" binding:"omitempty,min=1"`
-	PageSize         int     `form:"pageSize" binding:"omitempty,min=1,max=100"`
-	OrderNo          string  `form:"orderNo" binding:"omitempty"`
-	CustomerId       string  `form:"customerId" binding:"omitempty"`
-	CustomerName     string  `form:"customerName" binding:"omitempty"`
-	Status           string  `form:"status" binding:"omitempty,oneof=draft approved partially_delivered fully_delivered cancelled"`
-	CreateDateStart  string  `form:"createDateStart" binding:"omitempty,datetime=2006-01-02"`
-	CreateDateEnd    string  `form:"createDateEnd" binding:"omitempty,datetime=2006-01-02"`
-	DeliveryDateStart string `form:"deliveryDateStart" binding:"omitempty,datetime=2006-01-02"`
-	DeliveryDateEnd   string `form:"deliveryDateEnd" binding:"omitempty,datetime=2006-01-02"`
-	TotalAmountStart  float64 `form:"totalAmountStart" binding:"omitempty,min=0"`
-	TotalAmountEnd    float64 `form:"totalAmountEnd" binding:"omitempty,min=0"`
-}
-
-// OrderItem 订单明细
+// OrderItem 订单明细，单价和折扣由定价服务确定
 
 type OrderItem struct {
-	ID                string  `json:"id,omitempty"`
-	ProductId         string  `json:"productId" binding:"required"`
-	ProductCode       string  `json:"productCode,omitempty"`
-	ProductName       string  `json:"productName,omitempty"`
-	Specification     string  `json:"specification,omitempty"`
-	Unit              string  `json:"unit,omitempty"`
-	Quantity          float64 `json:"quantity" binding:"required,min=0.0001"`
-	UnitPrice         float64 `json:"unitPrice" binding:"required,min=0.01"`
-	Amount            float64 `json:"amount,omitempty"`
-	DeliveredQuantity float64 `json:"deliveredQuantity,omitempty"`
-	Remark            string  `json:"remark" binding:"omitempty"`
+	ID              string  `json:"id,omitempty"`
+	ProductId       string  `json:"productId" binding:"required"`
+	ProductCode     string  `json:"productCode,omitempty"`
+	ProductName     string  `json:"productName,omitempty"`
+	Unit            string  `json:"unit,omitempty"`
+	Quantity        float64 `json:"quantity" binding:"required,gt=0"`
+	UnitPrice       float64 `json:"unitPrice"`
+	Discount        float64 `json:"discount"`
+	Amount          float64 `json:"amount,omitempty"`
+	ShippedQuantity float64 `json:"shippedQuantity,omitempty"`
+	WarehouseId     string  `json:"warehouseId,omitempty"`
 }
 
 // OrderResponse 订单响应
 
 type OrderResponse struct {
-	ID            string      `json:"id"`
-	OrderNo       string      `json:"orderNo"`
-	CustomerId    string      `json:"customerId"`
-	CustomerName  string      `json:"customerName"`
-	Contact       string      `json:"contact"`
-	Phone         string      `json:"phone"`
-	Address       string      `json:"address,omitempty"`
-	OrderDate     string      `json:"orderDate"`
-	DeliveryDate  string      `json:"deliveryDate"`
-	PaymentTerms  string      `json:"paymentTerms,omitempty"`
-	ShippingTerms string      `json:"shippingTerms,omitempty"`
-	Remarks       string      `json:"remarks,omitempty"`
-	TotalAmount   float64     `json:"totalAmount"`
-	Status        string      `json:"status"`
-	Items         []OrderItem `json:"items,omitempty"`
-	CreatedBy     string      `json:"createdBy"`
-	CreatedAt     time.Time   `json:"createdAt"`
-	UpdatedBy     string      `json:"updatedBy"`
-	UpdatedAt     time.Time   `json:"updatedAt"`
+	ID                  string      `json:"id"`
+	OrderNo             string      `json:"orderNo"`
+	CustomerId          string      `json:"customerId"`
+	CustomerName        string      `json:"customerName,omitempty"`
+	QuotationId         string      `json:"quotationId,omitempty"`
+	OrderDate           string      `json:"orderDate"`
+	DeliveryDate        string      `json:"deliveryDate,omitempty"`
+	Remarks             string      `json:"remarks,omitempty"`
+	TotalAmount         float64     `json:"totalAmount"`
+	Currency            string      `json:"currency"`
+	ExchangeRate        float64     `json:"exchangeRate"`
+	BaseTotalAmount     float64     `json:"baseTotalAmount"`
+	Status              string      `json:"status"`
+	CreditHoldReason    string      `json:"creditHoldReason,omitempty"`
+	CreditReleasedBy    string      `json:"creditReleasedBy,omitempty"`
+	CreditReleasedAt    string      `json:"creditReleasedAt,omitempty"`
+	CreditReleaseReason string      `json:"creditReleaseReason,omitempty"`
+	Items               []OrderItem `json:"items,omitempty"`
+	CreatedBy           string      `json:"createdBy"`
+	CreatedAt           time.Time   `json:"createdAt"`
+	UpdatedBy           string      `json:"updatedBy"`
+	UpdatedAt           time.Time   `json:"updatedAt"`
 }
 
 // CreateOrderRequest 创建销售订单请求
 
 type CreateOrderRequest struct {
-	CustomerId    string      `json:"customerId" binding:"required"`
-	OrderDate     string      `json:"orderDate" binding:"required,datetime=2006-01-02"`
-	DeliveryDate  string      `json:"deliveryDate" binding:"required,datetime=2006-01-02"`
-	PaymentTerms  string      `json:"paymentTerms" binding:"omitempty"`
-	ShippingTerms string      `json:"shippingTerms" binding:"omitempty"`
-	Remarks       string      `json:"remarks" binding:"omitempty"`
-	Items         []OrderItem `json:"items" binding:"required,dive"`
+	OrderNo      string      `json:"orderNo" binding:"required,max=20"`
+	CustomerId   string      `json:"customerId" binding:"required"`
+	OrderDate    string      `json:"orderDate" binding:"required,datetime=2006-01-02"`
+	DeliveryDate string      `json:"deliveryDate" binding:"omitempty,datetime=2006-01-02"`
+	Currency     string      `json:"currency" binding:"omitempty,len=3"`
+	ExchangeRate float64     `json:"exchangeRate" binding:"omitempty,gt=0"`
+	Remarks      string      `json:"remarks" binding:"omitempty"`
+	Items        []OrderItem `json:"items" binding:"required,min=1,dive"`
+	CreatedBy    string      `json:"createdBy" binding:"required"`
 }
 
 // UpdateOrderRequest 更新销售订单请求
 
 type UpdateOrderRequest struct {
-	CustomerId    string      `json:"customerId" binding:"omitempty"`
-	OrderDate     string      `json:"orderDate" binding:"omitempty,datetime=2006-01-02"`
-	DeliveryDate  string      `json:"deliveryDate" binding:"omitempty,datetime=2006-01-02"`
-	PaymentTerms  string      `json:"paymentTerms" binding:"omitempty"`
-	ShippingTerms string      `json:"shippingTerms" binding:"omitempty"`
-	Remarks       string      `json:"remarks" binding:"omitempty"`
-	Items         []OrderItem `json:"items" binding:"omitempty,dive"`
+	CustomerId   string      `json:"customerId" binding:"omitempty"`
+	OrderDate    string      `json:"orderDate" binding:"omitempty,datetime=2006-01-02"`
+	DeliveryDate string      `json:"deliveryDate" binding:"omitempty,datetime=2006-01-02"`
+	Currency     string      `json:"currency" binding:"omitempty,len=3"`
+	ExchangeRate float64     `json:"exchangeRate" binding:"omitempty,gt=0"`
+	Remarks      string      `json:"remarks" binding:"omitempty"`
+	Items        []OrderItem `json:"items" binding:"omitempty,dive"`
+	UpdatedBy    string      `json:"updatedBy" binding:"required"`
 }
 
-// ApproveOrderRequest 审批销售订单请求
+// ReleaseCreditHoldRequest 放行信用冻结订单请求，放行人取当前登录用户
 
-type ApproveOrderRequest struct {
-	Approved bool   `json:"approved" binding:"required"`
-	Remarks  string `json:"remarks" binding:"omitempty"`
+type ReleaseCreditHoldRequest struct {
+	Reason string `json:"reason" binding:"required,max=255"`
 }
 
-// CancelOrderRequest 取消销售订单请求
-
-type CancelOrderRequest struct {
-	Reason string `json:"reason" binding:"required"`
-}
+// 销售发货相关
 
 // DeliveryItem 发货单明细
 
 type DeliveryItem struct {
-	OrderItemId       string  `json:"orderItemId" binding:"required"`
-	DeliveredQuantity float64 `json:"deliveredQuantity" binding:"required,min=0.0001"`
-	Remark            string  `json:"remark" binding:"omitempty"`
-}
-
-// GenerateDeliveryFromOrderRequest 从销售订单生成发货单请求
-
-type GenerateDeliveryFromOrderRequest struct {
-	DeliveryDate string         `json:"deliveryDate" binding:"required,datetime=2006-01-02"`
-	WarehouseId  string         `json:"warehouseId" binding:"required"`
-	Items        []DeliveryItem `json:"items" binding:"required,dive"`
-}
-
-// 销售发货相关
-
-// GetDeliveryListRequest 获取销售发货列表请求
-
-type GetDeliveryListRequest struct {
-	Page             int    `form:"page" binding:"omitempty,min=1"`
-	PageSize         int    `form:"pageSize" binding:"omitempty,min=1,max=100"`
-	DeliveryNo       string `form:"deliveryNo" binding:"omitempty"`
-	OrderId          string `form:"orderId" binding:"omitempty"`
-	OrderNo          string `form:"orderNo" binding:"omitempty"`
-	CustomerId       string `form:"customerId" binding:"omitempty"`
-	CustomerName     string `form:"customerName" binding:"omitempty"`
-	DeliveryDateStart string `form:"deliveryDateStart" binding:"omitempty,datetime=2006-01-02"`
-	DeliveryDateEnd   string `form:"deliveryDateEnd" binding:"omitempty,datetime=2006-01-02"`
+	OrderItemId string  `json:"orderItemId" binding:"required"`
+	Quantity    float64 `json:"quantity" binding:"required,gt=0"`
+	WarehouseId string  `json:"warehouseId" binding:"required"`
+	LocationId  string  `json:"locationId" binding:"omitempty"`
 }
 
 // DeliveryItemResponse 发货单明细响应
 
 type DeliveryItemResponse struct {
-	ID                string  `json:"id,omitempty"`
-	OrderItemId       string  `json:"orderItemId"`
-	ProductId         string  `json:"productId"`
-	ProductCode       string  `json:"productCode,omitempty"`
-	ProductName       string  `json:"productName,omitempty"`
-	Specification     string  `json:"specification,omitempty"`
-	Unit              string  `json:"unit,omitempty"`
-	OrderedQuantity   float64 `json:"orderedQuantity"`
-	DeliveredQuantity float64 `json:"deliveredQuantity"`
-	Remark            string  `json:"remark,omitempty"`
+	ID          string  `json:"id"`
+	OrderItemId string  `json:"orderItemId"`
+	ProductId   string  `json:"productId"`
+	ProductCode string  `json:"productCode,omitempty"`
+	ProductName string  `json:"productName,omitempty"`
+	Quantity    float64 `json:"quantity"`
+	WarehouseId string  `json:"warehouseId"`
+	LocationId  string  `json:"locationId,omitempty"`
 }
 
 // DeliveryResponse 发货单响应
 
 type DeliveryResponse struct {
-	ID            string                `json:"id"`
-	DeliveryNo    string                `json:"deliveryNo"`
-	OrderId       string                `json:"orderId"`
-	OrderNo       string                `json:"orderNo"`
-	CustomerId    string                `json:"customerId"`
-	CustomerName  string                `json:"customerName"`
-	Contact       string                `json:"contact"`
-	Phone         string                `json:"phone"`
-	Address       string                `json:"address,omitempty"`
-	DeliveryDate  string                `json:"deliveryDate"`
-	WarehouseId   string                `json:"warehouseId"`
-	WarehouseName string                `json:"warehouseName,omitempty"`
-	Carrier       string                `json:"carrier,omitempty"`
-	TrackingNo    string                `json:"trackingNo,omitempty"`
-	Remarks       string                `json:"remarks,omitempty"`
-	Status        string                `json:"status"`
-	TotalQuantity float64               `json:"totalQuantity,omitempty"`
+	ID            string                 `json:"id"`
+	DeliveryNo    string                 `json:"deliveryNo"`
+	OrderId       string                 `json:"orderId"`
+	CustomerId    string                 `json:"customerId"`
+	CustomerName  string                 `json:"customerName,omitempty"`
+	DeliveryDate  string                 `json:"deliveryDate"`
+	TotalQuantity float64                `json:"totalQuantity"`
+	Remarks       string                 `json:"remarks,omitempty"`
+	Status        string                 `json:"status"`
 	Items         []DeliveryItemResponse `json:"items,omitempty"`
-	CreatedBy     string                `json:"createdBy"`
-	CreatedAt     time.Time             `json:"createdAt"`
-	UpdatedBy     string                `json:"updatedBy"`
-	UpdatedAt     time.Time             `json:"updatedAt"`
+	CreatedBy     string                 `json:"createdBy"`
+	CreatedAt     time.Time              `json:"createdAt"`
+	UpdatedBy     string                 `json:"updatedBy"`
+	UpdatedAt     time.Time              `json:"updatedAt"`
 }
 
 // CreateDeliveryRequest 创建销售发货请求
 
 type CreateDeliveryRequest struct {
-	OrderId     string         `json:"orderId" binding:"required"`
+	DeliveryNo   string         `json:"deliveryNo" binding:"required,max=20"`
+	OrderId      string         `json:"orderId" binding:"required"`
 	DeliveryDate string         `json:"deliveryDate" binding:"required,datetime=2006-01-02"`
-	WarehouseId  string         `json:"warehouseId" binding:"required"`
-	Carrier      string         `json:"carrier" binding:"omitempty"`
-	TrackingNo   string         `json:"trackingNo" binding:"omitempty"`
 	Remarks      string         `json:"remarks" binding:"omitempty"`
-	Items        []DeliveryItem `json:"items" binding:"required,dive"`
+	Items        []DeliveryItem `json:"items" binding:"required,min=1,dive"`
+	CreatedBy    string         `json:"createdBy" binding:"required"`
 }
 
 // UpdateDeliveryRequest 更新销售发货请求
 
 type UpdateDeliveryRequest struct {
-	Carrier    string         `json:"carrier" binding:"omitempty"`
-	TrackingNo string         `json:"trackingNo" binding:"omitempty"`
-	Remarks    string         `json:"remarks" binding:"omitempty"`
-	Items      []DeliveryItem `json:"items" binding:"omitempty,dive"`
+	DeliveryDate string         `json:"deliveryDate" binding:"omitempty,datetime=2006-01-02"`
+	Remarks      string         `json:"remarks" binding:"omitempty"`
+	Items        []DeliveryItem `json:"items" binding:"omitempty,dive"`
+	UpdatedBy    string         `json:"updatedBy" binding:"required"`
 }
 
 // 销售发票相关
 
-// GetInvoiceListRequest 获取销售发票列表请求
-
-type GetInvoiceListRequest struct {
-	Page             int    `form:"page" binding:"omitempty,min=1"`
-	PageSize         int    `form:"pageSize" binding:"omitempty,min=1,max=100"`
-	InvoiceNo        string `form:"invoiceNo" binding:"omitempty"`
-	CustomerId       string `form:"customerId" binding:"omitempty"`
-	CustomerName     string `form:"customerName" binding:"omitempty"`
-	OrderId          string `form:"orderId" binding:"omitempty"`
-	OrderNo          string `form:"orderNo" binding:"omitempty"`
-	InvoiceDateStart string `form:"invoiceDateStart" binding:"omitempty,datetime=2006-01-02"`
-	InvoiceDateEnd   string `form:"invoiceDateEnd" binding:"omitempty,datetime=2006-01-02"`
-	Status           string `form:"status" binding:"omitempty,oneof=unpaid partially_paid fully_paid"`
-}
-
 // InvoiceItem 发票明细
 
 type InvoiceItem struct {
@@ -413,158 +337,406 @@ type InvoiceItem struct {
 	ProductId   string  `json:"productId" binding:"required"`
 	ProductCode string  `json:"productCode,omitempty"`
 	ProductName string  `json:"productName,omitempty"`
-	Specification string `json:"specification,omitempty"`
-	Quantity    float64 `json:"quantity" binding:"required,min=0.0001"`
-	UnitPrice   float64 `json:"unitPrice" binding:"required,min=0.01"`
-	TaxRate     float64 `json:"taxRate" binding:"required,min=0"`
+	Quantity    float64 `json:"quantity" binding:"required,gt=0"`
+	UnitPrice   float64 `json:"unitPrice" binding:"required,gt=0"`
+	Discount    float64 `json:"discount" binding:"omitempty,min=0"`
+	Amount      float64 `json:"amount,omitempty"`
+	TaxCodeId   string  `json:"taxCodeId,omitempty"`
+	TaxRate     float64 `json:"taxRate,omitempty"`
+	NetAmount   float64 `json:"netAmount,omitempty"`
 	TaxAmount   float64 `json:"taxAmount,omitempty"`
-	TotalAmount float64 `json:"totalAmount,omitempty"`
 }
 
 // InvoiceResponse 发票响应
 
 type InvoiceResponse struct {
-	ID             string        `json:"id"`
-	InvoiceNo      string        `json:"invoiceNo"`
-	CustomerId     string        `json:"customerId"`
-	CustomerName   string        `json:"customerName"`
-	CustomerTaxNo  string        `json:"customerTaxNo,omitempty"`
-	OrderId        string        `json:"orderId"`
-	OrderNo        string        `json:"orderNo"`
-	InvoiceDate    string        `json:"invoiceDate"`
-	DueDate        string        `json:"dueDate"`
-	TotalAmount    float64       `json:"totalAmount"`
-	TaxAmount      float64       `json:"taxAmount,omitempty"`
-	NetAmount      float64       `json:"netAmount,omitempty"`
-	PaidAmount     float64       `json:"paidAmount"`
-	BalanceAmount  float64       `json:"balanceAmount"`
-	Status         string        `json:"status"`
-	Items          []InvoiceItem `json:"items,omitempty"`
-	CreatedBy      string        `json:"createdBy"`
-	CreatedAt      time.Time     `json:"createdAt"`
-	UpdatedBy      string        `json:"updatedBy"`
-	UpdatedAt      time.Time     `json:"updatedAt"`
+	ID               string        `json:"id"`
+	InvoiceNo        string        `json:"invoiceNo"`
+	OrderId          string        `json:"orderId"`
+	CustomerId       string        `json:"customerId"`
+	CustomerName     string        `json:"customerName,omitempty"`
+	InvoiceDate      string        `json:"invoiceDate"`
+	DueDate          string        `json:"dueDate"`
+	TotalAmount      float64       `json:"totalAmount"`
+	TaxAmount        float64       `json:"taxAmount"`
+	PriceIncludesTax bool          `json:"priceIncludesTax"`
+	Currency         string        `json:"currency"`
+	ExchangeRate     float64       `json:"exchangeRate"`
+	BaseTotalAmount  float64       `json:"baseTotalAmount"`
+	BaseTaxAmount    float64       `json:"baseTaxAmount"`
+	PaidAmount       float64       `json:"paidAmount"`
+	WriteOffAmount   float64       `json:"writeOffAmount"`
+	CreditedAmount   float64       `json:"creditedAmount"`
+	BalanceAmount    float64       `json:"balanceAmount"`
+	Remarks          string        `json:"remarks,omitempty"`
+	Status           string        `json:"status"`
+	Items            []InvoiceItem `json:"items,omitempty"`
+	CreatedBy        string        `json:"createdBy"`
+	CreatedAt        time.Time     `json:"createdAt"`
+	UpdatedBy        string        `json:"updatedBy"`
+	UpdatedAt        time.Time     `json:"updatedAt"`
 }
 
 // CreateInvoiceRequest 创建销售发票请求
 
 type CreateInvoiceRequest struct {
-	CustomerId  string        `json:"customerId" binding:"required"`
-	OrderId     string        `json:"orderId" binding:"required"`
-	InvoiceDate string        `json:"invoiceDate" binding:"required,datetime=2006-01-02"`
-	DueDate     string        `json:"dueDate" binding:"required,datetime=2006-01-02"`
-	Items       []InvoiceItem `json:"items" binding:"required,dive"`
+	InvoiceNo        string        `json:"invoiceNo" binding:"required,max=20"`
+	OrderId          string        `json:"orderId" binding:"required"`
+	InvoiceDate      string        `json:"invoiceDate" binding:"required,datetime=2006-01-02"`
+	DueDate          string        `json:"dueDate" binding:"required,datetime=2006-01-02"`
+	PriceIncludesTax *bool         `json:"priceIncludesTax"`
+	ExchangeRate     float64       `json:"exchangeRate" binding:"omitempty,gt=0"`
+	Remarks          string        `json:"remarks" binding:"omitempty"`
+	Items            []InvoiceItem `json:"items" binding:"required,min=1,dive"`
+	CreatedBy        string        `json:"createdBy" binding:"required"`
 }
 
 // UpdateInvoiceRequest 更新销售发票请求
 
 type UpdateInvoiceRequest struct {
-	DueDate string        `json:"dueDate" binding:"omitempty,datetime=2006-01-02"`
-	Items   []InvoiceItem `json:"items" binding:"omitempty,dive"`
+	DueDate   string        `json:"dueDate" binding:"omitempty,datetime=2006-01-02"`
+	Remarks   string        `json:"remarks" binding:"omitempty"`
+	Items     []InvoiceItem `json:"items" binding:"omitempty,dive"`
+	UpdatedBy string        `json:"updatedBy" binding:"required"`
 }
 
-// ReceiveInvoicePaymentRequest 标记发票已收款请求
+// ReceiveInvoicePaymentRequest 发票收款请求
 
 type ReceiveInvoicePaymentRequest struct {
-	Amount        float64 `json:"amount" binding:"required,min=0.01"`
+	Amount        float64 `json:"amount" binding:"required,gt=0"`
 	ReceiveDate   string  `json:"receiveDate" binding:"required,datetime=2006-01-02"`
 	PaymentMethod string  `json:"paymentMethod" binding:"required"`
 	Remarks       string  `json:"remarks" binding:"omitempty"`
+	CreatedBy     string  `json:"createdBy" binding:"required"`
 }
 
 // 销售退货相关
 
-// GetReturnListRequest 获取销售退货列表请求
-
-type GetReturnListRequest struct {
-	Page             int    `form:"page" binding:"omitempty,min=1"`
-	PageSize         int    `form:"pageSize" binding:"omitempty,min=1,max=100"`
-	ReturnNo         string `form:"returnNo" binding:"omitempty"`
-	CustomerId       string `form:"customerId" binding:"omitempty"`
-	CustomerName     string `form:"customerName" binding:"omitempty"`
-	OrderId          string `form:"orderId" binding:"omitempty"`
-	OrderNo          string `form:"orderNo" binding:"omitempty"`
-	ReturnDateStart string `form:"returnDateStart" binding:"omitempty,datetime=2006-01-02"`
-	ReturnDateEnd   string `form:"returnDateEnd" binding:"omitempty,datetime=2006-01-02"`
-}
-
-// ReturnItem 退货单明细
+// ReturnItem 退货单明细，按订单明细退货，未指定单价时取订单明细折后单价；
+// quarantine为true且未指定库位时收货到仓库的隔离库位
 
 type ReturnItem struct {
+	ID          string  `json:"id,omitempty"`
 	OrderItemId string  `json:"orderItemId" binding:"required"`
-	Quantity    float64 `json:"quantity" binding:"required,min=0.0001"`
-	UnitPrice   float64 `json:"unitPrice" binding:"required,min=0.01"`
+	ProductId   string  `json:"productId,omitempty"`
+	ProductCode string  `json:"productCode,omitempty"`
+	ProductName string  `json:"productName,omitempty"`
+	Quantity    float64 `json:"quantity" binding:"required,gt=0"`
+	UnitPrice   float64 `json:"unitPrice" binding:"omitempty,gt=0"`
 	Amount      float64 `json:"amount,omitempty"`
-	Reason      string  `json:"reason" binding:"required"`
+	WarehouseId string  `json:"warehouseId" binding:"omitempty"`
+	LocationId  string  `json:"locationId" binding:"omitempty"`
+	Quarantine  bool    `json:"quarantine"`
 }
 
-// ReturnItemResponse 退货单明细响应
+// ReturnResponse 退货单响应
 
-type ReturnItemResponse struct {
-	ID          string  `json:"id,omitempty"`
-	OrderItemId string  `json:"orderItemId"`
+type ReturnResponse struct {
+	ID           string              `json:"id"`
+	ReturnNo     string              `json:"returnNo"`
+	OrderId      string              `json:"orderId"`
+	CustomerId   string              `json:"customerId"`
+	CustomerName string              `json:"customerName,omitempty"`
+	InvoiceId    string              `json:"invoiceId,omitempty"`
+	ReturnDate   string              `json:"returnDate"`
+	Reason       string              `json:"reason"`
+	Remarks      string              `json:"remarks,omitempty"`
+	TotalAmount  float64             `json:"totalAmount"`
+	Currency     string              `json:"currency"`
+	ExchangeRate float64             `json:"exchangeRate"`
+	Status       string              `json:"status"`
+	Items        []ReturnItem        `json:"items,omitempty"`
+	CreditNote   *CreditNoteResponse `json:"creditNote,omitempty"`
+	CreatedBy    string              `json:"createdBy"`
+	CreatedAt    time.Time           `json:"createdAt"`
+	UpdatedBy    string              `json:"updatedBy"`
+	UpdatedAt    time.Time           `json:"updatedAt"`
+}
+
+// CreateReturnRequest 创建销售退货请求，invoiceId为退货冲减的原发票
+
+type CreateReturnRequest struct {
+	ReturnNo   string       `json:"returnNo" binding:"required,max=20"`
+	OrderId    string       `json:"orderId" binding:"required"`
+	InvoiceId  string       `json:"invoiceId" binding:"omitempty"`
+	ReturnDate string       `json:"returnDate" binding:"required,datetime=2006-01-02"`
+	Reason     string       `json:"reason" binding:"required"`
+	Remarks    string       `json:"remarks" binding:"omitempty"`
+	Items      []ReturnItem `json:"items" binding:"required,min=1,dive"`
+	CreatedBy  string       `json:"createdBy" binding:"required"`
+}
+
+// UpdateReturnRequest 更新销售退货请求
+
+type UpdateReturnRequest struct {
+	InvoiceId  string       `json:"invoiceId" binding:"omitempty"`
+	ReturnDate string       `json:"returnDate" binding:"omitempty,datetime=2006-01-02"`
+	Reason     string       `json:"reason" binding:"omitempty"`
+	Remarks    string       `json:"remarks" binding:"omitempty"`
+	Items      []ReturnItem `json:"items" binding:"omitempty,dive"`
+	UpdatedBy  string       `json:"updatedBy" binding:"required"`
+}
+
+// ReceiveReturnRequest 退货收货请求，creditNoteNo为空时自动生成贷项通知单编号
+
+type ReceiveReturnRequest struct {
+	ReceiveDate  string `json:"receiveDate" binding:"required,datetime=2006-01-02"`
+	CreditNoteNo string `json:"creditNoteNo" binding:"omitempty,max=20"`
+	Remarks      string `json:"remarks" binding:"omitempty"`
+	CreatedBy    string `json:"createdBy" binding:"required"`
+}
+
+// CreditNoteItem 贷项通知单明细
+
+type CreditNoteItem struct {
+	ID          string  `json:"id"`
 	ProductId   string  `json:"productId"`
 	ProductCode string  `json:"productCode,omitempty"`
 	ProductName string  `json:"productName,omitempty"`
-	Specification string `json:"specification,omitempty"`
-	Unit        string  `json:"unit,omitempty"`
 	Quantity    float64 `json:"quantity"`
 	UnitPrice   float64 `json:"unitPrice"`
 	Amount      float64 `json:"amount"`
-	Reason      string  `json:"reason"`
+	TaxCodeId   string  `json:"taxCodeId,omitempty"`
+	TaxRate     float64 `json:"taxRate"`
+	NetAmount   float64 `json:"netAmount"`
+	TaxAmount   float64 `json:"taxAmount"`
+}
+
+// CreditNoteResponse 贷项通知单响应
+
+type CreditNoteResponse struct {
+	ID               string           `json:"id"`
+	CreditNoteNo     string           `json:"creditNoteNo"`
+	ReturnId         string           `json:"returnId"`
+	OrderId          string           `json:"orderId"`
+	CustomerId       string           `json:"customerId"`
+	CustomerName     string           `json:"customerName,omitempty"`
+	InvoiceId        string           `json:"invoiceId,omitempty"`
+	CreditDate       string           `json:"creditDate"`
+	TotalAmount      float64          `json:"totalAmount"`
+	TaxAmount        float64          `json:"taxAmount"`
+	PriceIncludesTax bool             `json:"priceIncludesTax"`
+	Currency         string           `json:"currency"`
+	ExchangeRate     float64          `json:"exchangeRate"`
+	BaseTotalAmount  float64          `json:"baseTotalAmount"`
+	BaseTaxAmount    float64          `json:"baseTaxAmount"`
+	AppliedAmount    float64          `json:"appliedAmount"`
+	UnappliedAmount  float64          `json:"unappliedAmount"`
+	Status           string           `json:"status"`
+	Remarks          string           `json:"remarks,omitempty"`
+	Items            []CreditNoteItem `json:"items,omitempty"`
+	CreatedBy        string           `json:"createdBy"`
+	CreatedAt        time.Time        `json:"createdAt"`
+}
+
+// 销售定价相关
+
+// PriceListItem 价目表明细，同一产品按起订数量设置阶梯价
+
+type PriceListItem struct {
+	ID          string  `json:"id,omitempty"`
+	ProductId   string  `json:"productId" binding:"required"`
+	ProductName string  `json:"productName,omitempty"`
+	MinQuantity float64 `json:"minQuantity" binding:"omitempty,min=0"`
+	UnitPrice   float64 `json:"unitPrice" binding:"required,gt=0"`
+}
+
+// PriceListResponse 价目表响应
+
+type PriceListResponse struct {
+	ID            string          `json:"id"`
+	Code          string          `json:"code"`
+	Name          string          `json:"name"`
+	Currency      string          `json:"currency"`
+	CustomerGroup string          `json:"customerGroup,omitempty"`
+	ValidFrom     string          `json:"validFrom"`
+	ValidTo       string          `json:"validTo,omitempty"`
+	Priority      int             `json:"priority"`
+	Status        string          `json:"status"`
+	Remarks       string          `json:"remarks,omitempty"`
+	Items         []PriceListItem `json:"items,omitempty"`
+	CreatedBy     string          `json:"createdBy"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	UpdatedBy     string          `json:"updatedBy"`
+	UpdatedAt     time.Time       `json:"updatedAt"`
+}
+
+// CreatePriceListRequest 创建价目表请求
+
+type CreatePriceListRequest struct {
+	Code          string          `json:"code" binding:"required,max=20"`
+	Name          string          `json:"name" binding:"required,max=100"`
+	Currency      string          `json:"currency" binding:"omitempty,len=3"`
+	CustomerGroup string          `json:"customerGroup" binding:"omitempty,max=50"`
+	ValidFrom     string          `json:"validFrom" binding:"required,datetime=2006-01-02"`
+	ValidTo       string          `json:"validTo" binding:"omitempty,datetime=2006-01-02"`
+	Priority      int             `json:"priority" binding:"omitempty"`
+	Status        string          `json:"status" binding:"omitempty,oneof=active inactive"`
+	Remarks       string          `json:"remarks" binding:"omitempty"`
+	Items         []PriceListItem `json:"items" binding:"required,min=1,dive"`
+	CreatedBy     string          `json:"createdBy" binding:"required"`
 }
 
-// ReturnResponse 退货单响应
+// UpdatePriceListRequest 更新价目表请求
 
-type ReturnResponse struct {
-	ID          string              `json:"id"`
-	ReturnNo    string              `json:"returnNo"`
-	CustomerId  string              `json:"customerId"`
-	CustomerName string             `json:"customerName"`
-	Contact     string              `json:"contact"`
-	Phone       string              `json:"phone"`
-	OrderId     string              `json:"orderId"`
-	OrderNo     string              `json:"orderNo"`
-	ReturnDate  string              `json:"returnDate"`
-	Reason      string              `json:"reason"`
-	Remarks     string              `json:"remarks,omitempty"`
-	TotalAmount float64             `json:"totalAmount"`
-	Status      string              `json:"status"`
-	Items       []ReturnItemResponse `json:"items,omitempty"`
-	CreatedBy   string              `json:"createdBy"`
-	CreatedAt   time.Time           `json:"createdAt"`
-	UpdatedBy   string              `json:"updatedBy"`
-	UpdatedAt   time.Time           `json:"updatedAt"`
-}
-
-// CreateReturnRequest 创建销售退货请求
+type UpdatePriceListRequest struct {
+	Name          string          `json:"name" binding:"omitempty,max=100"`
+	CustomerGroup *string         `json:"customerGroup" binding:"omitempty,max=50"`
+	ValidFrom     string          `json:"validFrom" binding:"omitempty,datetime=2006-01-02"`
+	ValidTo       string          `json:"validTo" binding:"omitempty,datetime=2006-01-02"`
+	Priority      *int            `json:"priority" binding:"omitempty"`
+	Status        string          `json:"status" binding:"omitempty,oneof=active inactive"`
+	Remarks       string          `json:"remarks" binding:"omitempty"`
+	Items         []PriceListItem `json:"items" binding:"omitempty,dive"`
+	UpdatedBy     string          `json:"updatedBy" binding:"required"`
+}
 
-type CreateReturnRequest struct {
-	CustomerId  string       `json:"customerId" binding:"required"`
-	OrderId     string       `json:"orderId" binding:"required"`
-	ReturnDate  string       `json:"returnDate" binding:"required,datetime=2006-01-02"`
-	Reason      string       `json:"reason" binding:"required"`
-	Remarks     string       `json:"remarks" binding:"omitempty"`
-	Items       []ReturnItem `json:"items" binding:"required,dive"`
+// CustomerPriceResponse 客户合同价响应
+
+type CustomerPriceResponse struct {
+	ID           string    `json:"id"`
+	CustomerId   string    `json:"customerId"`
+	CustomerName string    `json:"customerName,omitempty"`
+	ProductId    string    `json:"productId"`
+	ProductName  string    `json:"productName,omitempty"`
+	Currency     string    `json:"currency"`
+	MinQuantity  float64   `json:"minQuantity"`
+	UnitPrice    float64   `json:"unitPrice"`
+	ValidFrom    string    `json:"validFrom"`
+	ValidTo      string    `json:"validTo,omitempty"`
+	ContractNo   string    `json:"contractNo,omitempty"`
+	Status       string    `json:"status"`
+	CreatedBy    string    `json:"createdBy"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedBy    string    `json:"updatedBy"`
+	UpdatedAt    time.Time `json:"updatedAt"`
 }
 
-// UpdateReturnRequest 更新销售退货请求
+// CreateCustomerPriceRequest 创建客户合同价请求
 
-type UpdateReturnRequest struct {
-	Reason  string       `json:"reason" binding:"omitempty"`
-	Remarks string       `json:"remarks" binding:"omitempty"`
-	Items   []ReturnItem `json:"items" binding:"omitempty,dive"`
+type CreateCustomerPriceRequest struct {
+	CustomerId  string  `json:"customerId" binding:"required"`
+	ProductId   string  `json:"productId" binding:"required"`
+	Currency    string  `json:"currency" binding:"omitempty,len=3"`
+	MinQuantity float64 `json:"minQuantity" binding:"omitempty,min=0"`
+	UnitPrice   float64 `json:"unitPrice" binding:"required,gt=0"`
+	ValidFrom   string  `json:"validFrom" binding:"required,datetime=2006-01-02"`
+	ValidTo     string  `json:"validTo" binding:"omitempty,datetime=2006-01-02"`
+	ContractNo  string  `json:"contractNo" binding:"omitempty,max=50"`
+	Status      string  `json:"status" binding:"omitempty,oneof=active inactive"`
+	CreatedBy   string  `json:"createdBy" binding:"required"`
+}
+
+// UpdateCustomerPriceRequest 更新客户合同价请求
+
+type UpdateCustomerPriceRequest struct {
+	MinQuantity *float64 `json:"minQuantity" binding:"omitempty,min=0"`
+	UnitPrice   float64  `json:"unitPrice" binding:"omitempty,gt=0"`
+	ValidFrom   string   `json:"validFrom" binding:"omitempty,datetime=2006-01-02"`
+	ValidTo     string   `json:"validTo" binding:"omitempty,datetime=2006-01-02"`
+	ContractNo  string   `json:"contractNo" binding:"omitempty,max=50"`
+	Status      string   `json:"status" binding:"omitempty,oneof=active inactive"`
+	UpdatedBy   string   `json:"updatedBy" binding:"required"`
+}
+
+// PromotionResponse 促销响应
+
+type PromotionResponse struct {
+	ID              string    `json:"id"`
+	Code            string    `json:"code"`
+	Name            string    `json:"name"`
+	ProductId       string    `json:"productId,omitempty"`
+	CustomerGroup   string    `json:"customerGroup,omitempty"`
+	MinQuantity     float64   `json:"minQuantity"`
+	DiscountPercent float64   `json:"discountPercent"`
+	ValidFrom       string    `json:"validFrom"`
+	ValidTo         string    `json:"validTo,omitempty"`
+	Status          string    `json:"status"`
+	CreatedBy       string    `json:"createdBy"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedBy       string    `json:"updatedBy"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+// CreatePromotionRequest 创建促销请求
+
+type CreatePromotionRequest struct {
+	Code            string  `json:"code" binding:"required,max=20"`
+	Name            string  `json:"name" binding:"required,max=100"`
+	ProductId       string  `json:"productId" binding:"omitempty"`
+	CustomerGroup   string  `json:"customerGroup" binding:"omitempty,max=50"`
+	MinQuantity     float64 `json:"minQuantity" binding:"omitempty,min=0"`
+	DiscountPercent float64 `json:"discountPercent" binding:"required,gt=0,lte=100"`
+	ValidFrom       string  `json:"validFrom" binding:"required,datetime=2006-01-02"`
+	ValidTo         string  `json:"validTo" binding:"omitempty,datetime=2006-01-02"`
+	Status          string  `json:"status" binding:"omitempty,oneof=active inactive"`
+	CreatedBy       string  `json:"createdBy" binding:"required"`
+}
+
+// UpdatePromotionRequest 更新促销请求
+
+type UpdatePromotionRequest struct {
+	Name            string   `json:"name" binding:"omitempty,max=100"`
+	MinQuantity     *float64 `json:"minQuantity" binding:"omitempty,min=0"`
+	DiscountPercent float64  `json:"discountPercent" binding:"omitempty,gt=0,lte=100"`
+	ValidFrom       string   `json:"validFrom" binding:"omitempty,datetime=2006-01-02"`
+	ValidTo         string   `json:"validTo" binding:"omitempty,datetime=2006-01-02"`
+	Status          string   `json:"status" binding:"omitempty,oneof=active inactive"`
+	UpdatedBy       string   `json:"updatedBy" binding:"required"`
+}
+
+// PriceQuoteLine 询价明细
+
+type PriceQuoteLine struct {
+	ProductId string  `json:"productId" binding:"required"`
+	Quantity  float64 `json:"quantity" binding:"required,gt=0"`
+}
+
+// PriceQuoteRequest 询价请求，日期为空时按当天计算
+
+type PriceQuoteRequest struct {
+	CustomerId string           `json:"customerId" binding:"required"`
+	Currency   string           `json:"currency" binding:"omitempty,len=3"`
+	Date       string           `json:"date" binding:"omitempty,datetime=2006-01-02"`
+	Items      []PriceQuoteLine `json:"items" binding:"required,min=1,dive"`
+}
+
+// PriceQuoteItem 询价结果明细
+
+type PriceQuoteItem struct {
+	ProductId       string  `json:"productId"`
+	ProductName     string  `json:"productName,omitempty"`
+	Quantity        float64 `json:"quantity"`
+	ListPrice       float64 `json:"listPrice"`
+	UnitPrice       float64 `json:"unitPrice"`
+	PriceSource     string  `json:"priceSource"`
+	PriceListId     string  `json:"priceListId,omitempty"`
+	CustomerPriceId string  `json:"customerPriceId,omitempty"`
+	PromotionId     string  `json:"promotionId,omitempty"`
+	DiscountPercent float64 `json:"discountPercent"`
+	Discount        float64 `json:"discount"`
+	Amount          float64 `json:"amount"`
+}
+
+// PriceQuoteResponse 询价响应
+
+type PriceQuoteResponse struct {
+	CustomerId  string           `json:"customerId"`
+	Currency    string           `json:"currency"`
+	Date        string           `json:"date"`
+	Items       []PriceQuoteItem `json:"items"`
+	TotalAmount float64          `json:"totalAmount"`
 }
 
 // 销售报表相关
 
-// GetOrderExecutionReportRequest 获取销售订单执行报表请求
+// SalesReportRequest 销售报表查询请求
 
-type GetOrderExecutionReportRequest struct {
-	StartDate  string `form:"startDate" binding:"required,datetime=2006-01-02"`
-	EndDate    string `form:"endDate" binding:"required,datetime=2006-01-02"`
-	CustomerId string `form:"customerId" binding:"omitempty"`
-	ProductId  string `form:"productId" binding:"omitempty"`
+type SalesReportRequest struct {
+	StartDate  string `form:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate    string `form:"end_date" binding:"required,datetime=2006-01-02"`
+	CustomerId string `form:"customer_id" binding:"omitempty"`
+	ProductId  string `form:"product_id" binding:"omitempty"`
 }
 
 // OrderExecutionReportItem 订单执行报表项
@@ -582,43 +754,28 @@ type OrderExecutionReportItem struct {
 // OrderExecutionReportResponse 订单执行报表响应
 
 type OrderExecutionReportResponse struct {
-	Period       string                    `json:"period"`
-	TotalOrders  int                       `json:"totalOrders"`
-	TotalAmount  float64                   `json:"totalAmount"`
-	Orders       []OrderExecutionReportItem `json:"orders"`
-}
-
-// GetCustomerAnalysisReportRequest 获取客户销售分析报表请求
-
-type GetCustomerAnalysisReportRequest struct {
-	StartDate string `form:"startDate" binding:"required,datetime=2006-01-02"`
-	EndDate   string `form:"endDate" binding:"required,datetime=2006-01-02"`
+	Period      string                     `json:"period"`
+	TotalOrders int                        `json:"totalOrders"`
+	TotalAmount float64                    `json:"totalAmount"`
+	Orders      []OrderExecutionReportItem `json:"orders"`
 }
 
 // CustomerAnalysisReportItem 客户销售分析报表项
 
 type CustomerAnalysisReportItem struct {
-	CustomerId      string  `json:"customerId"`
-	CustomerName    string  `json:"customerName"`
-	TotalOrders     int     `json:"totalOrders"`
-	TotalAmount     float64 `json:"totalAmount"`
-	AvgOrderAmount  float64 `json:"avgOrderAmount"`
-	PaymentRate     float64 `json:"paymentRate"`
+	CustomerId     string  `json:"customerId"`
+	CustomerName   string  `json:"customerName"`
+	TotalOrders    int     `json:"totalOrders"`
+	TotalAmount    float64 `json:"totalAmount"`
+	AvgOrderAmount float64 `json:"avgOrderAmount"`
+	PaymentRate    float64 `json:"paymentRate"`
 }
 
 // CustomerAnalysisReportResponse 客户销售分析报表响应
 
 type CustomerAnalysisReportResponse struct {
-	Period     string                        `json:"period"`
-	Customers  []CustomerAnalysisReportItem  `json:"customers"`
-}
-
-// GetProductTrendReportRequest 获取产品销售趋势报表请求
-
-type GetProductTrendReportRequest struct {
-	StartDate string `form:"startDate" binding:"required,datetime=2006-01-02"`
-	EndDate   string `form:"endDate" binding:"required,datetime=2006-01-02"`
-	ProductId string `form:"productId" binding:"omitempty"`
+	Period    string                       `json:"period"`
+	Customers []CustomerAnalysisReportItem `json:"customers"`
 }
 
 // ProductTrendReportItem 产品销售趋势报表项
@@ -632,16 +789,16 @@ type ProductTrendReportItem struct {
 // ProductTrendReportResponse 产品销售趋势报表响应
 
 type ProductTrendReportResponse struct {
-	Period  string                      `json:"period"`
-	Trends  []ProductTrendReportItem    `json:"trends"`
+	Period string                   `json:"period"`
+	Trends []ProductTrendReportItem `json:"trends"`
 }
 
 // ExportSalesReportRequest 导出销售报表请求
 
 type ExportSalesReportRequest struct {
-	Type       string `form:"type" binding:"required,oneof=order_execution customer_analysis product_trend"`
-	StartDate  string `form:"startDate" binding:"required,datetime=2006-01-02"`
-	EndDate    string `form:"endDate" binding:"required,datetime=2006-01-02"`
-	CustomerId string `form:"customerId" binding:"omitempty"`
-	ProductId  string `form:"productId" binding:"omitempty"`
+	ReportType string `form:"report_type" binding:"required,oneof=order_execution customer_analysis product_trend"`
+	StartDate  string `form:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate    string `form:"end_date" binding:"required,datetime=2006-01-02"`
+	CustomerId string `form:"customer_id" binding:"omitempty"`
+	ProductId  string `form:"product_id" binding:"omitempty"`
 }
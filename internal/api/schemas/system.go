@@ -0,0 +1,123 @@
+package schemas
+
+// 角色相关结构体
+
+// RoleCreateRequest 创建角色请求
+type RoleCreateRequest struct {
+	Code            string   `json:"code" binding:"required,max=20"`
+	Name            string   `json:"name" binding:"required,max=100"`
+	Description     string   `json:"description"`
+	Status          string   `json:"status" binding:"omitempty,oneof=active inactive"`
+	PermissionCodes []string `json:"permission_codes"`
+	CreatedBy       string   `json:"created_by"`
+}
+
+// RoleUpdateRequest 更新角色请求
+type RoleUpdateRequest struct {
+	Name        string `json:"name" binding:"max=100"`
+	Description string `json:"description"`
+	Status      string `json:"status" binding:"omitempty,oneof=active inactive"`
+	UpdatedBy   string `json:"updated_by"`
+}
+
+// RolePermissionsRequest 设置角色权限请求，支持 模块:* 和 模块:资源:* 通配
+type RolePermissionsRequest struct {
+	PermissionCodes []string `json:"permission_codes" binding:"required"`
+	UpdatedBy       string   `json:"updated_by"`
+}
+
+// RoleResponse 角色响应
+type RoleResponse struct {
+	ID              string   `json:"id"`
+	Code            string   `json:"code"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	Status          string   `json:"status"`
+	PermissionCodes []string `json:"permission_codes"`
+	CreatedAt       string   `json:"created_at"`
+	UpdatedAt       string   `json:"updated_at"`
+}
+
+// 权限相关结构体
+
+// PermissionResponse 权限响应
+type PermissionResponse struct {
+	ID          string `json:"id"`
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Module      string `json:"module"`
+}
+
+// UserRolesRequest 设置用户角色请求
+type UserRolesRequest struct {
+	RoleIDs   []string `json:"role_ids" binding:"required"`
+	CreatedBy string   `json:"created_by"`
+}
+
+// UserRolesResponse 用户角色响应
+type UserRolesResponse struct {
+	UserID          string         `json:"user_id"`
+	Roles           []RoleResponse `json:"roles"`
+	PermissionCodes []string       `json:"permission_codes"`
+}
+
+// 认证相关结构体
+
+// LoginRequest 登录请求，账号可以是用户名或邮箱
+type LoginRequest struct {
+	Account  string `json:"account" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// RefreshTokenRequest 刷新令牌请求
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// TokenResponse 令牌响应
+type TokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int    `json:"expires_in"`
+	RefreshToken     string `json:"refresh_token"`
+	RefreshExpiresAt string `json:"refresh_expires_at"`
+}
+
+// PasswordChangeRequest 修改密码请求
+type PasswordChangeRequest struct {
+	OldPassword string `json:"old_password" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=8,max=72"`
+}
+
+// PasswordResetRequest 使用重置令牌设置新密码请求
+type PasswordResetRequest struct {
+	ResetToken  string `json:"reset_token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=8,max=72"`
+}
+
+// PasswordResetTokenResponse 密码重置令牌响应，令牌只在生成时返回一次
+type PasswordResetTokenResponse struct {
+	UserID     string `json:"user_id"`
+	ResetToken string `json:"reset_token"`
+	ExpiresAt  string `json:"expires_at"`
+}
+
+// 用户相关结构体
+
+// UserCreateRequest 创建用户请求
+type UserCreateRequest struct {
+	Username string `json:"username" binding:"required,max=50"`
+	Email    string `json:"email" binding:"required,email,max=100"`
+	Password string `json:"password" binding:"required,min=8,max=72"`
+	Nickname string `json:"nickname" binding:"omitempty,max=50"`
+	Avatar   string `json:"avatar" binding:"omitempty,max=255"`
+}
+
+// UserUpdateRequest 更新当前用户请求，未传字段保持不变
+type UserUpdateRequest struct {
+	Username string `json:"username" binding:"omitempty,max=50"`
+	Email    string `json:"email" binding:"omitempty,email,max=100"`
+	Nickname string `json:"nickname" binding:"omitempty,max=50"`
+	Avatar   string `json:"avatar" binding:"omitempty,max=255"`
+}
@@ -8,10 +8,14 @@ import (
 
 // 应用配置
 type AppConfig struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
-	JWT      JWTConfig      `mapstructure:"jwt"`
-	Data     DataConfig     `mapstructure:"data"` // 新增数据配置
+	Server    ServerConfig    `mapstructure:"server"`
+	Database  DatabaseConfig  `mapstructure:"database"`
+	JWT       JWTConfig       `mapstructure:"jwt"`
+	Data      DataConfig      `mapstructure:"data"` // 新增数据配置
+	Finance   FinanceConfig   `mapstructure:"finance"`
+	Sales     SalesConfig     `mapstructure:"sales"`
+	Purchase  PurchaseConfig  `mapstructure:"purchase"`
+	Inventory InventoryConfig `mapstructure:"inventory"`
 }
 
 // 服务器配置
@@ -33,8 +37,11 @@ type DatabaseConfig struct {
 
 // JWT配置
 type JWTConfig struct {
-	Secret      string        `mapstructure:"secret"`
-	ExpireHours time.Duration `mapstructure:"expireHours"`
+	Secret               string        `mapstructure:"secret"`
+	ExpireHours          time.Duration `mapstructure:"expireHours"`
+	AccessTokenMinutes   int           `mapstructure:"accessTokenMinutes"`   // 访问令牌有效期（分钟）
+	RefreshTokenHours    int           `mapstructure:"refreshTokenHours"`    // 刷新令牌有效期（小时），每次刷新后重新计算
+	PasswordResetMinutes int           `mapstructure:"passwordResetMinutes"` // 密码重置令牌有效期（分钟）
 }
 
 // 数据配置（新增）
@@ -45,6 +52,49 @@ type DataConfig struct {
 	ColdStoragePath string        `mapstructure:"coldStoragePath"` // 冷存储路径
 }
 
+// 财务配置
+type FinanceConfig struct {
+	RetainedEarningsAccount string            `mapstructure:"retainedEarningsAccount"` // 期末结转损益的科目编码
+	BaseCurrency            string            `mapstructure:"baseCurrency"`            // 本位币，账簿和报表按本位币记账
+	TaxRounding             string            `mapstructure:"taxRounding"`             // 税额舍入方式：line按行舍入，document按单据同一税率汇总后舍入
+	PricesIncludeTax        bool              `mapstructure:"pricesIncludeTax"`        // 发票未指定时单价是否含税
+	AgingBuckets            []int             `mapstructure:"agingBuckets"`            // 账龄分段的天数上限，超过最后一段的计入最后的超期段
+	PaymentBank             PaymentBankConfig `mapstructure:"paymentBank"`             // 付款运行导出银行付款文件时的付款方账户
+}
+
+// 付款方账户，导出pain.001付款文件时作为付款人信息
+type PaymentBankConfig struct {
+	Name    string `mapstructure:"name"`    // 付款人名称
+	Account string `mapstructure:"account"` // 付款账号，符合IBAN格式时按IBAN导出
+	BIC     string `mapstructure:"bic"`     // 开户行BIC，为空时导出为NOTPROVIDED
+}
+
+// 销售配置
+type SalesConfig struct {
+	CreditOverdueDays int `mapstructure:"creditOverdueDays"` // 审批订单时允许的最长逾期天数，超过时信用冻结
+}
+
+// 采购配置
+type PurchaseConfig struct {
+	PriceTolerance    float64          `mapstructure:"priceTolerance"`    // 发票审核时单价与订单单价允许的偏差百分比
+	QuantityTolerance float64          `mapstructure:"quantityTolerance"` // 发票审核时开票数量超过待开票收货数量允许的百分比
+	OnTimeGraceDays   int              `mapstructure:"onTimeGraceDays"`   // 供应商评分时晚于承诺交货日期仍视为准时的天数
+	ScorecardWeights  ScorecardWeights `mapstructure:"scorecardWeights"`  // 供应商综合评分的指标权重
+}
+
+// 供应商评分指标权重，无数据的指标不参与加权
+type ScorecardWeights struct {
+	OnTime   float64 `mapstructure:"onTime"`   // 准时交货率
+	Quality  float64 `mapstructure:"quality"`  // 质量合格率（1-退货率）
+	Price    float64 `mapstructure:"price"`    // 价格差异
+	LeadTime float64 `mapstructure:"leadTime"` // 前置时间准确度
+}
+
+// 库存配置
+type InventoryConfig struct {
+	ReplenishmentInterval time.Duration `mapstructure:"replenishmentInterval"` // 定时补货运行间隔（秒），0表示不定时运行
+}
+
 // 全局配置实例
 var appConfig AppConfig
 
@@ -62,11 +112,29 @@ func LoadConfig() error {
 	viper.SetDefault("database.charset", "utf8mb4")
 	viper.SetDefault("jwt.secret", "your-secret-key")
 	viper.SetDefault("jwt.expireHours", 24)
+	viper.SetDefault("jwt.accessTokenMinutes", 15)
+	viper.SetDefault("jwt.refreshTokenHours", 168)
+	viper.SetDefault("jwt.passwordResetMinutes", 30)
 	// 新增数据配置默认值
 	viper.SetDefault("data.hotThreshold", 3600)   // 1小时
 	viper.SetDefault("data.coldThreshold", 86400) // 24小时
 	viper.SetDefault("data.migrateInterval", 300) // 5分钟
 	viper.SetDefault("data.coldStoragePath", "./cold_data")
+	viper.SetDefault("finance.retainedEarningsAccount", "4104")
+	viper.SetDefault("finance.baseCurrency", "CNY")
+	viper.SetDefault("finance.taxRounding", "line")
+	viper.SetDefault("finance.pricesIncludeTax", false)
+	viper.SetDefault("finance.agingBuckets", []int{30, 60, 90})
+	viper.SetDefault("finance.paymentBank.name", "本公司")
+	viper.SetDefault("sales.creditOverdueDays", 30)
+	viper.SetDefault("purchase.priceTolerance", 2)
+	viper.SetDefault("purchase.quantityTolerance", 0)
+	viper.SetDefault("purchase.onTimeGraceDays", 0)
+	viper.SetDefault("purchase.scorecardWeights.onTime", 40)
+	viper.SetDefault("purchase.scorecardWeights.quality", 30)
+	viper.SetDefault("purchase.scorecardWeights.price", 20)
+	viper.SetDefault("purchase.scorecardWeights.leadTime", 10)
+	viper.SetDefault("inventory.replenishmentInterval", 86400) // 每天
 
 	// 读取配置文件
 	viper.SetConfigName("config")
@@ -8,12 +8,100 @@ import (
 
 // FinanceAccount 会计科目表模型
 type FinanceAccount struct {
+	ID               string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	Code             string         `json:"code" gorm:"unique;not null;type:varchar(20)"`
+	Name             string         `json:"name" gorm:"not null;type:varchar(100)"`
+	Type             string         `json:"type" gorm:"not null;type:varchar(20)"`
+	Level            int            `json:"level" gorm:"not null;type:int"`
+	ParentID         string         `json:"parent_id" gorm:"type:varchar(36)"`
+	CashFlowCategory string         `json:"cash_flow_category" gorm:"type:varchar(20)"`
+	CreatedBy        string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt        time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy        string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt        time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt        gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Parent   *FinanceAccount  `json:"parent,omitempty" gorm:"foreignKey:ParentID"`
+	Children []FinanceAccount `json:"children,omitempty" gorm:"foreignKey:ParentID"`
+}
+
+// TableName 指定表名
+func (FinanceAccount) TableName() string {
+	return "finance_accounts"
+}
+
+// FinanceJournal 凭证表模型
+type FinanceJournal struct {
+	ID            string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	JournalNo     string         `json:"journal_no" gorm:"unique;not null;type:varchar(20)"`
+	Date          time.Time      `json:"date" gorm:"not null;type:date;index"`
+	Reference     string         `json:"reference" gorm:"type:varchar(100)"`
+	ReferenceType string         `json:"reference_type" gorm:"type:varchar(50);index:idx_journal_reference"`
+	ReferenceID   string         `json:"reference_id" gorm:"type:varchar(36);index:idx_journal_reference"`
+	ReversalOf    string         `json:"reversal_of" gorm:"type:varchar(36);index"`
+	Description   string         `json:"description" gorm:"type:text"`
+	TotalDebit    float64        `json:"total_debit" gorm:"not null;type:decimal(18,2)"`
+	TotalCredit   float64        `json:"total_credit" gorm:"not null;type:decimal(18,2)"`
+	Currency      string         `json:"currency" gorm:"type:varchar(3)"`
+	ExchangeRate  float64        `json:"exchange_rate" gorm:"type:decimal(18,6);default:1"`
+	Status        string         `json:"status" gorm:"type:varchar(20);default:'draft'"`
+	Remarks       string         `json:"remarks" gorm:"type:text"`
+	SubmittedBy   string         `json:"submitted_by" gorm:"type:varchar(36)"`
+	SubmittedAt   *time.Time     `json:"submitted_at"`
+	ApprovedBy    string         `json:"approved_by" gorm:"type:varchar(36)"`
+	ApprovedAt    *time.Time     `json:"approved_at"`
+	PostedBy      string         `json:"posted_by" gorm:"type:varchar(36)"`
+	PostedAt      *time.Time     `json:"posted_at"`
+	CreatedBy     string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt     time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy     string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt     time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt     gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Items []FinanceJournalItem `json:"items,omitempty" gorm:"foreignKey:JournalID"`
+}
+
+// TableName 指定表名
+func (FinanceJournal) TableName() string {
+	return "finance_journals"
+}
+
+// FinanceJournalItem 凭证明细表模型
+type FinanceJournalItem struct {
+	ID          string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	JournalID   string         `json:"journal_id" gorm:"not null;type:varchar(36);index"`
+	LineNo      int            `json:"line_no" gorm:"not null;type:int"`
+	AccountID   string         `json:"account_id" gorm:"not null;type:varchar(36);index"`
+	Description string         `json:"description" gorm:"type:text"`
+	Debit       float64        `json:"debit" gorm:"type:decimal(18,2);default:0"`
+	Credit      float64        `json:"credit" gorm:"type:decimal(18,2);default:0"`
+	CurrencyAmount float64     `json:"currency_amount" gorm:"type:decimal(18,2);default:0"`
+	CreatedBy   string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy   string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Journal FinanceJournal `json:"journal,omitempty" gorm:"foreignKey:JournalID"`
+	Account FinanceAccount `json:"account,omitempty" gorm:"foreignKey:AccountID"`
+}
+
+// TableName 指定表名
+func (FinanceJournalItem) TableName() string {
+	return "finance_journal_items"
+}
+
+// FinanceFiscalYear 会计年度表模型
+type FinanceFiscalYear struct {
 	ID        string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
-	Code      string         `json:"code" gorm:"unique;not null;type:varchar(20)"`
-	Name      string         `json:"name" gorm:"not null;type:varchar(100)"`
-	Type      string         `json:"type" gorm:"not null;type:varchar(20)"`
-	Level     int            `json:"level" gorm:"not null;type:int"`
-	ParentID  string         `json:"parent_id" gorm:"type:varchar(36)"`
+	Year      int            `json:"year" gorm:"unique;not null;type:int"`
+	Name      string         `json:"name" gorm:"not null;type:varchar(50)"`
+	StartDate time.Time      `json:"start_date" gorm:"not null;type:date"`
+	EndDate   time.Time      `json:"end_date" gorm:"not null;type:date"`
+	Status    string         `json:"status" gorm:"type:varchar(20);default:'open'"`
 	CreatedBy string         `json:"created_by" gorm:"type:varchar(36)"`
 	CreatedAt time.Time      `json:"created_at" gorm:"not null"`
 	UpdatedBy string         `json:"updated_by" gorm:"type:varchar(36)"`
@@ -21,11 +109,394 @@ type FinanceAccount struct {
 	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 
 	// 关联
-	Parent   *FinanceAccount `json:"parent,omitempty" gorm:"foreignKey:ParentID"`
-	Children []FinanceAccount `json:"children,omitempty" gorm:"foreignKey:ParentID"`
+	Periods []FinancePeriod `json:"periods,omitempty" gorm:"foreignKey:FiscalYearID"`
 }
 
 // TableName 指定表名
-func (FinanceAccount) TableName() string {
-	return "finance_accounts"
+func (FinanceFiscalYear) TableName() string {
+	return "finance_fiscal_years"
+}
+
+// FinancePeriod 会计期间表模型
+type FinancePeriod struct {
+	ID               string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	FiscalYearID     string         `json:"fiscal_year_id" gorm:"not null;type:varchar(36);index"`
+	PeriodNo         int            `json:"period_no" gorm:"not null;type:int"`
+	Name             string         `json:"name" gorm:"not null;type:varchar(20)"`
+	StartDate        time.Time      `json:"start_date" gorm:"not null;type:date;index:idx_period_dates"`
+	EndDate          time.Time      `json:"end_date" gorm:"not null;type:date;index:idx_period_dates"`
+	Status           string         `json:"status" gorm:"type:varchar(20);default:'open'"`
+	ClosingJournalID string         `json:"closing_journal_id" gorm:"type:varchar(36)"`
+	ClosedBy         string         `json:"closed_by" gorm:"type:varchar(36)"`
+	ClosedAt         *time.Time     `json:"closed_at"`
+	CreatedBy        string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt        time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy        string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt        time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt        gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	FiscalYear FinanceFiscalYear `json:"fiscal_year,omitempty" gorm:"foreignKey:FiscalYearID"`
+}
+
+// TableName 指定表名
+func (FinancePeriod) TableName() string {
+	return "finance_periods"
+}
+
+// FinancePostingRule 自动记账规则表模型，按单据类型和业务事件将金额映射到借贷科目
+type FinancePostingRule struct {
+	ID                string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	DocumentType      string         `json:"document_type" gorm:"not null;type:varchar(50);index:idx_posting_rule_event"`
+	Event             string         `json:"event" gorm:"not null;type:varchar(50);index:idx_posting_rule_event"`
+	Sequence          int            `json:"sequence" gorm:"type:int;default:0"`
+	AmountField       string         `json:"amount_field" gorm:"not null;type:varchar(20)"`
+	DebitAccountCode  string         `json:"debit_account_code" gorm:"not null;type:varchar(20)"`
+	CreditAccountCode string         `json:"credit_account_code" gorm:"not null;type:varchar(20)"`
+	Description       string         `json:"description" gorm:"type:varchar(200)"`
+	Status            string         `json:"status" gorm:"type:varchar(20);default:'active'"`
+	CreatedBy         string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt         time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy         string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt         time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt         gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
+
+// TableName 指定表名
+func (FinancePostingRule) TableName() string {
+	return "finance_posting_rules"
+}
+
+// FinanceTaxCode 税码表模型，税率按生效日期维护在税率表
+type FinanceTaxCode struct {
+	ID          string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	Code        string         `json:"code" gorm:"unique;not null;type:varchar(20)"`
+	Name        string         `json:"name" gorm:"not null;type:varchar(100)"`
+	Description string         `json:"description" gorm:"type:varchar(200)"`
+	Status      string         `json:"status" gorm:"type:varchar(20);default:'active'"`
+	CreatedBy   string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy   string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Rates []FinanceTaxRate `json:"rates,omitempty" gorm:"foreignKey:TaxCodeID"`
+}
+
+// TableName 指定表名
+func (FinanceTaxCode) TableName() string {
+	return "finance_tax_codes"
+}
+
+// FinanceTaxRate 税率表模型，税率为百分比
+type FinanceTaxRate struct {
+	ID        string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	TaxCodeID string         `json:"tax_code_id" gorm:"not null;type:varchar(36);index"`
+	Rate      float64        `json:"rate" gorm:"not null;type:decimal(7,4)"`
+	ValidFrom time.Time      `json:"valid_from" gorm:"not null;type:date"`
+	ValidTo   *time.Time     `json:"valid_to" gorm:"type:date"`
+	CreatedBy string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
+
+// TableName 指定表名
+func (FinanceTaxRate) TableName() string {
+	return "finance_tax_rates"
+}
+
+// FinanceTaxRule 税码确定规则表模型，按业务方向、往来单位纳税状态和物料税收分类匹配税码，
+// 纳税状态和税收分类为空表示不限
+type FinanceTaxRule struct {
+	ID              string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	Direction       string         `json:"direction" gorm:"not null;type:varchar(20);index"`
+	PartyTaxStatus  string         `json:"party_tax_status" gorm:"type:varchar(20)"`
+	ItemTaxCategory string         `json:"item_tax_category" gorm:"type:varchar(20)"`
+	TaxCodeID       string         `json:"tax_code_id" gorm:"not null;type:varchar(36)"`
+	Priority        int            `json:"priority" gorm:"type:int;default:0"`
+	Status          string         `json:"status" gorm:"type:varchar(20);default:'active'"`
+	CreatedBy       string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt       time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy       string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt       time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt       gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	TaxCode FinanceTaxCode `json:"tax_code,omitempty" gorm:"foreignKey:TaxCodeID"`
+}
+
+// TableName 指定表名
+func (FinanceTaxRule) TableName() string {
+	return "finance_tax_rules"
+}
+
+// FinanceCurrency 币种表模型
+type FinanceCurrency struct {
+	ID        string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	Code      string         `json:"code" gorm:"unique;not null;type:varchar(3)"`
+	Name      string         `json:"name" gorm:"not null;type:varchar(50)"`
+	Symbol    string         `json:"symbol" gorm:"type:varchar(10)"`
+	Status    string         `json:"status" gorm:"type:varchar(20);default:'active'"`
+	CreatedBy string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
+
+// TableName 指定表名
+func (FinanceCurrency) TableName() string {
+	return "finance_currencies"
+}
+
+// FinanceExchangeRate 汇率表模型，汇率为1单位外币折合本位币的金额，同一币种每天一条
+type FinanceExchangeRate struct {
+	ID           string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	CurrencyCode string         `json:"currency_code" gorm:"not null;type:varchar(3);uniqueIndex:idx_exchange_rate_date"`
+	RateDate     time.Time      `json:"rate_date" gorm:"not null;type:date;uniqueIndex:idx_exchange_rate_date"`
+	Rate         float64        `json:"rate" gorm:"not null;type:decimal(18,6)"`
+	Source       string         `json:"source" gorm:"type:varchar(20);default:'manual'"`
+	CreatedBy    string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt    time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy    string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt    time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt    gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
+
+// TableName 指定表名
+func (FinanceExchangeRate) TableName() string {
+	return "finance_exchange_rates"
+}
+
+// FinanceFXRevaluation 期末汇兑重估表模型，每个会计期间一次，重估凭证于下期首日自动冲回
+type FinanceFXRevaluation struct {
+	ID                string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	RevaluationNo     string         `json:"revaluation_no" gorm:"unique;not null;type:varchar(30)"`
+	PeriodID          string         `json:"period_id" gorm:"unique;not null;type:varchar(36)"`
+	RevaluationDate   time.Time      `json:"revaluation_date" gorm:"not null;type:date"`
+	ReceivableAmount  float64        `json:"receivable_amount" gorm:"type:decimal(18,2);default:0"`
+	PayableAmount     float64        `json:"payable_amount" gorm:"type:decimal(18,2);default:0"`
+	JournalID         string         `json:"journal_id" gorm:"type:varchar(36)"`
+	ReversalJournalID string         `json:"reversal_journal_id" gorm:"type:varchar(36)"`
+	CreatedBy         string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt         time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy         string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt         time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt         gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Period FinancePeriod              `json:"period,omitempty" gorm:"foreignKey:PeriodID"`
+	Items  []FinanceFXRevaluationItem `json:"items,omitempty" gorm:"foreignKey:RevaluationID"`
+}
+
+// TableName 指定表名
+func (FinanceFXRevaluation) TableName() string {
+	return "finance_fx_revaluations"
+}
+
+// FinanceFXRevaluationItem 汇兑重估明细表模型，按未结清的外币发票逐张计算汇兑差额
+type FinanceFXRevaluationItem struct {
+	ID              string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	RevaluationID   string         `json:"revaluation_id" gorm:"not null;type:varchar(36);index"`
+	DocumentType    string         `json:"document_type" gorm:"not null;type:varchar(50)"`
+	DocumentID      string         `json:"document_id" gorm:"not null;type:varchar(36)"`
+	DocumentNo      string         `json:"document_no" gorm:"type:varchar(20)"`
+	Currency        string         `json:"currency" gorm:"not null;type:varchar(3)"`
+	OpenAmount      float64        `json:"open_amount" gorm:"type:decimal(18,2);default:0"`
+	OriginalRate    float64        `json:"original_rate" gorm:"type:decimal(18,6);default:0"`
+	RevaluationRate float64        `json:"revaluation_rate" gorm:"type:decimal(18,6);default:0"`
+	CarryingAmount  float64        `json:"carrying_amount" gorm:"type:decimal(18,2);default:0"`
+	RevaluedAmount  float64        `json:"revalued_amount" gorm:"type:decimal(18,2);default:0"`
+	Difference      float64        `json:"difference" gorm:"type:decimal(18,2);default:0"`
+	CreatedBy       string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt       time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy       string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt       time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt       gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
+
+// TableName 指定表名
+func (FinanceFXRevaluationItem) TableName() string {
+	return "finance_fx_revaluation_items"
+}
+
+// FinanceReceipt 客户收款单模型，处理后按核销明细冲减销售发票余额，未核销部分作为客户预收款
+type FinanceReceipt struct {
+	ID            string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	ReceiptNo     string         `json:"receipt_no" gorm:"unique;not null;type:varchar(20)"`
+	ReceiptDate   time.Time      `json:"receipt_date" gorm:"not null;type:date"`
+	CustomerID    string         `json:"customer_id" gorm:"not null;type:varchar(36);index"`
+	Amount        float64        `json:"amount" gorm:"not null;type:decimal(18,2)"`
+	Currency      string         `json:"currency" gorm:"type:varchar(3)"`
+	ExchangeRate  float64        `json:"exchange_rate" gorm:"type:decimal(18,6);default:1"`
+	BaseAmount    float64        `json:"base_amount" gorm:"type:decimal(18,2);default:0"`
+	AppliedAmount float64        `json:"applied_amount" gorm:"type:decimal(18,2);default:0"`
+	PaymentMethod string         `json:"payment_method" gorm:"type:varchar(50)"`
+	BankAccountID string         `json:"bank_account_id" gorm:"type:varchar(36)"`
+	Reference     string         `json:"reference" gorm:"type:varchar(100)"`
+	Description   string         `json:"description" gorm:"type:text"`
+	Remarks       string         `json:"remarks" gorm:"type:text"`
+	Status        string         `json:"status" gorm:"type:varchar(20);default:'draft'"`
+	ProcessedBy   string         `json:"processed_by" gorm:"type:varchar(36)"`
+	ProcessedAt   *time.Time     `json:"processed_at"`
+	CreatedBy     string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt     time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy     string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt     time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt     gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Customer    SalesCustomer              `json:"customer,omitempty" gorm:"foreignKey:CustomerID"`
+	Allocations []FinanceReceiptAllocation `json:"allocations,omitempty" gorm:"foreignKey:ReceiptID"`
+}
+
+// TableName 指定表名
+func (FinanceReceipt) TableName() string {
+	return "finance_receipts"
+}
+
+// FinanceReceiptAllocation 收款核销明细模型，Amount为核销的收款金额，WriteOffAmount为同时核销的坏账或折让金额
+type FinanceReceiptAllocation struct {
+	ID             string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	ReceiptID      string         `json:"receipt_id" gorm:"not null;type:varchar(36);index"`
+	InvoiceID      string         `json:"invoice_id" gorm:"not null;type:varchar(36);index"`
+	AllocationDate time.Time      `json:"allocation_date" gorm:"not null;type:date"`
+	Amount         float64        `json:"amount" gorm:"type:decimal(18,2);default:0"`
+	WriteOffAmount float64        `json:"write_off_amount" gorm:"type:decimal(18,2);default:0"`
+	CreatedBy      string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt      time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy      string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt      time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt      gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Invoice SalesInvoice `json:"invoice,omitempty" gorm:"foreignKey:InvoiceID"`
+}
+
+// TableName 指定表名
+func (FinanceReceiptAllocation) TableName() string {
+	return "finance_receipt_allocations"
+}
+
+// FinancePayment 供应商付款单模型，处理后按核销明细冲减采购发票余额，未核销部分作为供应商预付款
+type FinancePayment struct {
+	ID            string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	PaymentNo     string         `json:"payment_no" gorm:"unique;not null;type:varchar(20)"`
+	PaymentDate   time.Time      `json:"payment_date" gorm:"not null;type:date"`
+	VendorID      string         `json:"vendor_id" gorm:"not null;type:varchar(36);index"`
+	Amount        float64        `json:"amount" gorm:"not null;type:decimal(18,2)"`
+	Currency      string         `json:"currency" gorm:"type:varchar(3)"`
+	ExchangeRate  float64        `json:"exchange_rate" gorm:"type:decimal(18,6);default:1"`
+	BaseAmount    float64        `json:"base_amount" gorm:"type:decimal(18,2);default:0"`
+	AppliedAmount float64        `json:"applied_amount" gorm:"type:decimal(18,2);default:0"`
+	PaymentMethod string         `json:"payment_method" gorm:"type:varchar(50)"`
+	BankAccountID string         `json:"bank_account_id" gorm:"type:varchar(36)"`
+	Reference     string         `json:"reference" gorm:"type:varchar(100)"`
+	Description   string         `json:"description" gorm:"type:text"`
+	Remarks       string         `json:"remarks" gorm:"type:text"`
+	RunID         string         `json:"run_id" gorm:"type:varchar(36);index"`
+	Status        string         `json:"status" gorm:"type:varchar(20);default:'draft'"`
+	ProcessedBy   string         `json:"processed_by" gorm:"type:varchar(36)"`
+	ProcessedAt   *time.Time     `json:"processed_at"`
+	CreatedBy     string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt     time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy     string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt     time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt     gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Vendor      PurchaseVendor             `json:"vendor,omitempty" gorm:"foreignKey:VendorID"`
+	Allocations []FinancePaymentAllocation `json:"allocations,omitempty" gorm:"foreignKey:PaymentID"`
+}
+
+// TableName 指定表名
+func (FinancePayment) TableName() string {
+	return "finance_payments"
+}
+
+// FinancePaymentAllocation 付款核销明细模型，Amount为核销的付款金额，DiscountAmount为同时冲减的提前付款折扣
+type FinancePaymentAllocation struct {
+	ID             string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	PaymentID      string         `json:"payment_id" gorm:"not null;type:varchar(36);index"`
+	InvoiceID      string         `json:"invoice_id" gorm:"not null;type:varchar(36);index"`
+	AllocationDate time.Time      `json:"allocation_date" gorm:"not null;type:date"`
+	Amount         float64        `json:"amount" gorm:"type:decimal(18,2);default:0"`
+	DiscountAmount float64        `json:"discount_amount" gorm:"type:decimal(18,2);default:0"`
+	CreatedBy      string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt      time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy      string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt      time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt      gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Invoice PurchaseInvoice `json:"invoice,omitempty" gorm:"foreignKey:InvoiceID"`
+}
+
+// TableName 指定表名
+func (FinancePaymentAllocation) TableName() string {
+	return "finance_payment_allocations"
+}
+
+// FinancePaymentRun 付款运行模型，按到期日和提前付款折扣选取待付采购发票形成付款建议，
+// 审批后按供应商和币种生成付款单；合计金额按付款日汇率折算为本位币
+type FinancePaymentRun struct {
+	ID             string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	RunNo          string         `json:"run_no" gorm:"unique;not null;type:varchar(20)"`
+	PaymentDate    time.Time      `json:"payment_date" gorm:"not null;type:date"`
+	DueDate        time.Time      `json:"due_date" gorm:"not null;type:date"`
+	VendorID       string         `json:"vendor_id" gorm:"type:varchar(36)"`
+	Currency       string         `json:"currency" gorm:"type:varchar(3)"`
+	PaymentMethod  string         `json:"payment_method" gorm:"type:varchar(50)"`
+	BankAccountID  string         `json:"bank_account_id" gorm:"type:varchar(36)"`
+	TotalAmount    float64        `json:"total_amount" gorm:"type:decimal(18,2);default:0"`
+	DiscountAmount float64        `json:"discount_amount" gorm:"type:decimal(18,2);default:0"`
+	Status         string         `json:"status" gorm:"type:varchar(20);default:'proposed'"`
+	ApprovedBy     string         `json:"approved_by" gorm:"type:varchar(36)"`
+	ApprovedAt     *time.Time     `json:"approved_at"`
+	Remarks        string         `json:"remarks" gorm:"type:text"`
+	CreatedBy      string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt      time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy      string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt      time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt      gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Items []FinancePaymentRunItem `json:"items,omitempty" gorm:"foreignKey:RunID"`
+}
+
+// TableName 指定表名
+func (FinancePaymentRun) TableName() string {
+	return "finance_payment_runs"
+}
+
+// FinancePaymentRunItem 付款运行明细模型，每张发票一行，OpenAmount为建议时的发票余额，审批后记录生成的付款单
+type FinancePaymentRunItem struct {
+	ID             string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	RunID          string         `json:"run_id" gorm:"not null;type:varchar(36);index"`
+	InvoiceID      string         `json:"invoice_id" gorm:"not null;type:varchar(36);index"`
+	VendorID       string         `json:"vendor_id" gorm:"not null;type:varchar(36)"`
+	Currency       string         `json:"currency" gorm:"type:varchar(3)"`
+	DueDate        time.Time      `json:"due_date" gorm:"not null;type:date"`
+	DiscountDate   *time.Time     `json:"discount_date" gorm:"type:date"`
+	OpenAmount     float64        `json:"open_amount" gorm:"type:decimal(18,2);default:0"`
+	DiscountAmount float64        `json:"discount_amount" gorm:"type:decimal(18,2);default:0"`
+	PaymentAmount  float64        `json:"payment_amount" gorm:"type:decimal(18,2);default:0"`
+	PaymentID      string         `json:"payment_id" gorm:"type:varchar(36)"`
+	CreatedBy      string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt      time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy      string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt      time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt      gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Invoice PurchaseInvoice `json:"invoice,omitempty" gorm:"foreignKey:InvoiceID"`
+	Vendor  PurchaseVendor  `json:"vendor,omitempty" gorm:"foreignKey:VendorID"`
+}
+
+// TableName 指定表名
+func (FinancePaymentRunItem) TableName() string {
+	return "finance_payment_run_items"
 }
@@ -19,6 +19,7 @@ type InventoryWarehouse struct {
 	Description   string         `json:"description" gorm:"type:text"`
 	Capacity      int            `json:"capacity" gorm:"type:int;default:0"`
 	UsedCapacity  int            `json:"used_capacity" gorm:"type:int;default:0"`
+	AllowNegativeStock bool      `json:"allow_negative_stock" gorm:"default:false"`
 	Status        string         `json:"status" gorm:"type:varchar(20);default:'active'"`
 	CreatedBy     string         `json:"created_by" gorm:"type:varchar(36)"`
 	CreatedAt     time.Time      `json:"created_at" gorm:"not null"`
@@ -74,6 +75,9 @@ type InventoryItem struct {
 	CategoryID  string         `json:"category_id" gorm:"type:varchar(36)"`
 	Unit        string         `json:"unit" gorm:"not null;type:varchar(10)"`
 	Type        string         `json:"type" gorm:"not null;type:varchar(20)"`
+	CostMethod  string         `json:"cost_method" gorm:"type:varchar(20);default:'moving_average'"`
+	StandardCost float64       `json:"standard_cost" gorm:"type:decimal(18,2);default:0"`
+	TaxCategory string         `json:"tax_category" gorm:"type:varchar(20);default:'standard'"`
 	Status      string         `json:"status" gorm:"type:varchar(20);default:'active'"`
 	CreatedBy   string         `json:"created_by" gorm:"type:varchar(36)"`
 	CreatedAt   time.Time      `json:"created_at" gorm:"not null"`
@@ -86,6 +90,7 @@ type InventoryItem struct {
 	OnHandItems  []InventoryOnHand      `json:"on_hand_items,omitempty" gorm:"foreignKey:ItemID"`
 	Transactions []InventoryTransaction `json:"transactions,omitempty" gorm:"foreignKey:ItemID"`
 	CountItems   []InventoryCountItem   `json:"count_items,omitempty" gorm:"foreignKey:ItemID"`
+	CostLayers   []InventoryCostLayer   `json:"cost_layers,omitempty" gorm:"foreignKey:ItemID"`
 }
 
 // TableName 指定表名
@@ -119,11 +124,11 @@ func (InventoryItemCategory) TableName() string {
 // InventoryOnHand 库存表模型
 type InventoryOnHand struct {
 	ID          string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
-	ItemID      string         `json:"item_id" gorm:"not null;type:varchar(36)"`
-	WarehouseID string         `json:"warehouse_id" gorm:"not null;type:varchar(36)"`
-	LocationID  string         `json:"location_id" gorm:"type:varchar(36)"`
+	ItemID      string         `json:"item_id" gorm:"not null;type:varchar(36);uniqueIndex:idx_inventory_on_hand_location"`
+	WarehouseID string         `json:"warehouse_id" gorm:"not null;type:varchar(36);uniqueIndex:idx_inventory_on_hand_location"`
+	LocationID  string         `json:"location_id" gorm:"type:varchar(36);uniqueIndex:idx_inventory_on_hand_location"`
 	Quantity    float64        `json:"quantity" gorm:"not null;type:decimal(18,4)"`
-	UnitCost    float64        `json:"unit_cost" gorm:"not null;type:decimal(18,2)"`
+	UnitCost    float64        `json:"unit_cost" gorm:"not null;type:decimal(18,6)"`
 	TotalCost   float64        `json:"total_cost" gorm:"not null;type:decimal(18,2)"`
 	CreatedBy   string         `json:"created_by" gorm:"type:varchar(36)"`
 	CreatedAt   time.Time      `json:"created_at" gorm:"not null"`
@@ -151,8 +156,11 @@ type InventoryTransaction struct {
 	LocationID    string         `json:"location_id" gorm:"type:varchar(36)"`
 	Type          string         `json:"type" gorm:"not null;type:varchar(20)"`
 	Quantity      float64        `json:"quantity" gorm:"not null;type:decimal(18,4)"`
-	UnitCost      float64        `json:"unit_cost" gorm:"not null;type:decimal(18,2)"`
+	UnitCost      float64        `json:"unit_cost" gorm:"not null;type:decimal(18,6)"`
 	TotalCost     float64        `json:"total_cost" gorm:"not null;type:decimal(18,2)"`
+	BalanceQuantity float64      `json:"balance_quantity" gorm:"type:decimal(18,4);default:0"`
+	BalanceCost   float64        `json:"balance_cost" gorm:"type:decimal(18,2);default:0"`
+	CostVariance  float64        `json:"cost_variance" gorm:"type:decimal(18,2);default:0"`
 	ReferenceType string         `json:"reference_type" gorm:"type:varchar(50)"`
 	ReferenceID   string         `json:"reference_id" gorm:"type:varchar(36)"`
 	TransactionDate time.Time    `json:"transaction_date" gorm:"not null"`
@@ -172,6 +180,35 @@ func (InventoryTransaction) TableName() string {
 	return "inventory_transactions"
 }
 
+// InventoryCostLayer 库存成本层表模型（先进先出计价）
+type InventoryCostLayer struct {
+	ID                string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	ItemID            string         `json:"item_id" gorm:"not null;type:varchar(36);index:idx_cost_layer_stock"`
+	WarehouseID       string         `json:"warehouse_id" gorm:"not null;type:varchar(36);index:idx_cost_layer_stock"`
+	LocationID        string         `json:"location_id" gorm:"type:varchar(36);index:idx_cost_layer_stock"`
+	TransactionID     string         `json:"transaction_id" gorm:"not null;type:varchar(36)"`
+	LayerDate         time.Time      `json:"layer_date" gorm:"not null"`
+	OriginalQuantity  float64        `json:"original_quantity" gorm:"not null;type:decimal(18,4)"`
+	RemainingQuantity float64        `json:"remaining_quantity" gorm:"not null;type:decimal(18,4)"`
+	UnitCost          float64        `json:"unit_cost" gorm:"not null;type:decimal(18,6)"`
+	CreatedBy         string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt         time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy         string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt         time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt         gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Item        InventoryItem        `json:"item,omitempty" gorm:"foreignKey:ItemID"`
+	Warehouse   InventoryWarehouse   `json:"warehouse,omitempty" gorm:"foreignKey:WarehouseID"`
+	Location    *InventoryLocation   `json:"location,omitempty" gorm:"foreignKey:LocationID"`
+	Transaction InventoryTransaction `json:"transaction,omitempty" gorm:"foreignKey:TransactionID"`
+}
+
+// TableName 指定表名
+func (InventoryCostLayer) TableName() string {
+	return "inventory_cost_layers"
+}
+
 // InventoryCount 库存盘点表模型
 type InventoryCount struct {
 	ID            string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
@@ -207,7 +244,7 @@ type InventoryCountItem struct {
 	SystemQuantity float64       `json:"system_quantity" gorm:"not null;type:decimal(18,4)"`
 	ActualQuantity float64       `json:"actual_quantity" gorm:"not null;type:decimal(18,4)"`
 	VarianceQuantity float64     `json:"variance_quantity" gorm:"not null;type:decimal(18,4)"`
-	UnitCost      float64        `json:"unit_cost" gorm:"not null;type:decimal(18,2)"`
+	UnitCost      float64        `json:"unit_cost" gorm:"not null;type:decimal(18,6)"`
 	VarianceAmount float64       `json:"variance_amount" gorm:"not null;type:decimal(18,2)"`
 	Remarks       string         `json:"remarks" gorm:"type:text"`
 	CreatedBy     string         `json:"created_by" gorm:"type:varchar(36)"`
@@ -226,3 +263,159 @@ type InventoryCountItem struct {
 func (InventoryCountItem) TableName() string {
 	return "inventory_count_items"
 }
+
+// InventoryReservation 库存预留表模型，硬预留占用仓库现存量，软预留占用未来到货
+type InventoryReservation struct {
+	ID               string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	ItemID           string         `json:"item_id" gorm:"not null;type:varchar(36);index:idx_reservation_stock"`
+	WarehouseID      string         `json:"warehouse_id" gorm:"type:varchar(36);index:idx_reservation_stock"`
+	Type             string         `json:"type" gorm:"not null;type:varchar(20)"` // hard, soft
+	SourceType       string         `json:"source_type" gorm:"not null;type:varchar(30)"` // sales_order
+	SourceID         string         `json:"source_id" gorm:"not null;type:varchar(36);index"`
+	SourceItemID     string         `json:"source_item_id" gorm:"not null;type:varchar(36);index"`
+	SourceNo         string         `json:"source_no" gorm:"type:varchar(50)"`
+	Quantity         float64        `json:"quantity" gorm:"not null;type:decimal(18,4)"`
+	ConsumedQuantity float64        `json:"consumed_quantity" gorm:"type:decimal(18,4);default:0"`
+	RequiredDate     *time.Time     `json:"required_date" gorm:"type:date"`
+	Status           string         `json:"status" gorm:"type:varchar(20);default:'active'"` // active, consumed, released
+	ReleasedAt       *time.Time     `json:"released_at"`
+	CreatedBy        string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt        time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy        string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt        time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt        gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Item      InventoryItem       `json:"item,omitempty" gorm:"foreignKey:ItemID"`
+	Warehouse *InventoryWarehouse `json:"warehouse,omitempty" gorm:"foreignKey:WarehouseID"`
+}
+
+// TableName 指定表名
+func (InventoryReservation) TableName() string {
+	return "inventory_reservations"
+}
+
+// InventoryReplenishmentRule 补货参数表模型，按物料和仓库设置再订货点或最小最大库存，
+// 指定供货仓库时优先从供货仓库调拨，不足部分向首选供应商请购
+type InventoryReplenishmentRule struct {
+	ID                string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	ItemID            string         `json:"item_id" gorm:"not null;type:varchar(36);index:idx_replenishment_rule_stock"`
+	WarehouseID       string         `json:"warehouse_id" gorm:"not null;type:varchar(36);index:idx_replenishment_rule_stock"`
+	Method            string         `json:"method" gorm:"not null;type:varchar(20)"` // reorder_point, min_max
+	ReorderPoint      float64        `json:"reorder_point" gorm:"type:decimal(18,4);default:0"`
+	SafetyStock       float64        `json:"safety_stock" gorm:"type:decimal(18,4);default:0"`
+	MinQuantity       float64        `json:"min_quantity" gorm:"type:decimal(18,4);default:0"`
+	MaxQuantity       float64        `json:"max_quantity" gorm:"type:decimal(18,4);default:0"`
+	ReorderQuantity   float64        `json:"reorder_quantity" gorm:"type:decimal(18,4);default:0"`  // 再订货点法的固定批量
+	MultipleQuantity  float64        `json:"multiple_quantity" gorm:"type:decimal(18,4);default:0"` // 补货数量向上取整的批量倍数
+	PreferredVendorID string         `json:"preferred_vendor_id" gorm:"type:varchar(36)"`
+	SourceWarehouseID string         `json:"source_warehouse_id" gorm:"type:varchar(36)"`
+	LeadTimeDays      int            `json:"lead_time_days" gorm:"type:int;default:0"`
+	Status            string         `json:"status" gorm:"type:varchar(20);default:'active'"`
+	CreatedBy         string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt         time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy         string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt         time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt         gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Item      InventoryItem      `json:"item,omitempty" gorm:"foreignKey:ItemID"`
+	Warehouse InventoryWarehouse `json:"warehouse,omitempty" gorm:"foreignKey:WarehouseID"`
+}
+
+// TableName 指定表名
+func (InventoryReplenishmentRule) TableName() string {
+	return "inventory_replenishment_rules"
+}
+
+// InventoryReplenishmentRun 补货运行表模型，记录每次手动或定时补货的结果
+type InventoryReplenishmentRun struct {
+	ID                string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	RunNo             string         `json:"run_no" gorm:"unique;not null;type:varchar(20)"`
+	TriggerType       string         `json:"trigger_type" gorm:"not null;type:varchar(20)"` // manual, scheduled
+	RuleCount         int            `json:"rule_count" gorm:"type:int;default:0"`
+	PurchaseLineCount int            `json:"purchase_line_count" gorm:"type:int;default:0"`
+	TransferCount     int            `json:"transfer_count" gorm:"type:int;default:0"`
+	RequisitionID     string         `json:"requisition_id" gorm:"type:varchar(36)"`
+	StartedAt         time.Time      `json:"started_at" gorm:"not null"`
+	FinishedAt        time.Time      `json:"finished_at" gorm:"not null"`
+	CreatedBy         string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt         time.Time      `json:"created_at" gorm:"not null"`
+	DeletedAt         gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Lines []InventoryReplenishmentRunLine `json:"lines,omitempty" gorm:"foreignKey:RunID"`
+}
+
+// TableName 指定表名
+func (InventoryReplenishmentRun) TableName() string {
+	return "inventory_replenishment_runs"
+}
+
+// InventoryReplenishmentRunLine 补货运行明细表模型，逐条补货参数记录预计可用量的构成、触发水位和处理结果
+type InventoryReplenishmentRunLine struct {
+	ID                string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	RunID             string         `json:"run_id" gorm:"not null;type:varchar(36);index"`
+	RuleID            string         `json:"rule_id" gorm:"not null;type:varchar(36)"`
+	ItemID            string         `json:"item_id" gorm:"not null;type:varchar(36)"`
+	WarehouseID       string         `json:"warehouse_id" gorm:"not null;type:varchar(36)"`
+	Method            string         `json:"method" gorm:"not null;type:varchar(20)"`
+	OnHandQuantity    float64        `json:"on_hand_quantity" gorm:"type:decimal(18,4);default:0"`
+	ReservedQuantity  float64        `json:"reserved_quantity" gorm:"type:decimal(18,4);default:0"`
+	ScheduledReceipts float64        `json:"scheduled_receipts" gorm:"type:decimal(18,4);default:0"`
+	OpenRequisitions  float64        `json:"open_requisitions" gorm:"type:decimal(18,4);default:0"`
+	InboundTransfers  float64        `json:"inbound_transfers" gorm:"type:decimal(18,4);default:0"`
+	OutboundTransfers float64        `json:"outbound_transfers" gorm:"type:decimal(18,4);default:0"`
+	ProjectedQuantity float64        `json:"projected_quantity" gorm:"type:decimal(18,4);default:0"`
+	TriggerLevel      float64        `json:"trigger_level" gorm:"type:decimal(18,4);default:0"`
+	TargetLevel       float64        `json:"target_level" gorm:"type:decimal(18,4);default:0"`
+	Action            string         `json:"action" gorm:"not null;type:varchar(20)"` // none, transfer, purchase, transfer_purchase, skipped
+	TransferQuantity  float64        `json:"transfer_quantity" gorm:"type:decimal(18,4);default:0"`
+	PurchaseQuantity  float64        `json:"purchase_quantity" gorm:"type:decimal(18,4);default:0"`
+	SourceWarehouseID string         `json:"source_warehouse_id" gorm:"type:varchar(36)"`
+	VendorID          string         `json:"vendor_id" gorm:"type:varchar(36)"`
+	TransferRequestID string         `json:"transfer_request_id" gorm:"type:varchar(36)"`
+	RequisitionItemID string         `json:"requisition_item_id" gorm:"type:varchar(36)"`
+	Explanation       string         `json:"explanation" gorm:"type:text"`
+	CreatedAt         time.Time      `json:"created_at" gorm:"not null"`
+	DeletedAt         gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Item InventoryItem `json:"item,omitempty" gorm:"foreignKey:ItemID"`
+}
+
+// TableName 指定表名
+func (InventoryReplenishmentRunLine) TableName() string {
+	return "inventory_replenishment_run_lines"
+}
+
+// InventoryTransferRequest 调拨申请表模型，由补货运行生成，执行后按申请数量生成仓库调拨交易
+type InventoryTransferRequest struct {
+	ID              string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	RequestNo       string         `json:"request_no" gorm:"unique;not null;type:varchar(20)"`
+	ItemID          string         `json:"item_id" gorm:"not null;type:varchar(36);index"`
+	FromWarehouseID string         `json:"from_warehouse_id" gorm:"not null;type:varchar(36)"`
+	ToWarehouseID   string         `json:"to_warehouse_id" gorm:"not null;type:varchar(36)"`
+	Quantity        float64        `json:"quantity" gorm:"not null;type:decimal(18,4)"`
+	RequiredDate    time.Time      `json:"required_date" gorm:"not null;type:date"`
+	Status          string         `json:"status" gorm:"type:varchar(20);default:'pending'"` // pending, completed, cancelled
+	SourceType      string         `json:"source_type" gorm:"type:varchar(30)"`              // replenishment
+	SourceID        string         `json:"source_id" gorm:"type:varchar(36)"`
+	TransactionNo   string         `json:"transaction_no" gorm:"type:varchar(20)"`
+	Remarks         string         `json:"remarks" gorm:"type:text"`
+	CreatedBy       string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt       time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy       string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt       time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt       gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Item          InventoryItem      `json:"item,omitempty" gorm:"foreignKey:ItemID"`
+	FromWarehouse InventoryWarehouse `json:"from_warehouse,omitempty" gorm:"foreignKey:FromWarehouseID"`
+	ToWarehouse   InventoryWarehouse `json:"to_warehouse,omitempty" gorm:"foreignKey:ToWarehouseID"`
+}
+
+// TableName 指定表名
+func (InventoryTransferRequest) TableName() string {
+	return "inventory_transfer_requests"
+}
@@ -11,6 +11,14 @@ var AllModels = []interface{}{
 	// 用户模型
 	&User{},
 
+	// 系统模型
+	&SystemRole{},
+	&SystemUserRole{},
+	&SystemPermission{},
+	&SystemRolePermission{},
+	&SystemUserSession{},
+	&SystemPasswordReset{},
+
 	// CRM模型
 	&CRMAccount{},
 	&CRMContact{},
@@ -35,7 +43,13 @@ var AllModels = []interface{}{
 	&SalesInvoiceItem{},
 	&SalesReturn{},
 	&SalesReturnItem{},
+	&SalesCreditNote{},
+	&SalesCreditNoteItem{},
 	&SalesCustomerCredit{},
+	&SalesPriceList{},
+	&SalesPriceListItem{},
+	&SalesCustomerPrice{},
+	&SalesPromotion{},
 
 	// 库存模型
 	&InventoryWarehouse{},
@@ -44,24 +58,54 @@ var AllModels = []interface{}{
 	&InventoryItemCategory{},
 	&InventoryOnHand{},
 	&InventoryTransaction{},
+	&InventoryCostLayer{},
 	&InventoryCount{},
 	&InventoryCountItem{},
+	&InventoryReservation{},
+	&InventoryReplenishmentRule{},
+	&InventoryReplenishmentRun{},
+	&InventoryReplenishmentRunLine{},
+	&InventoryTransferRequest{},
 
 	// 采购模型
 	&PurchaseVendor{},
+	&PurchaseVendorScorecard{},
+	&PurchasePriceHistory{},
+	&PurchaseTargetPrice{},
 	&PurchasePlan{},
 	&PurchasePlanItem{},
+	&PurchaseRequisition{},
+	&PurchaseRequisitionItem{},
 	&PurchaseOrder{},
 	&PurchaseOrderItem{},
 	&PurchaseReceipt{},
 	&PurchaseReceiptItem{},
 	&PurchaseInvoice{},
 	&PurchaseInvoiceItem{},
+	&PurchaseInvoiceDiscrepancy{},
 	&PurchaseReturn{},
 	&PurchaseReturnItem{},
 
 	// 财务模型
 	&FinanceAccount{},
+	&FinanceJournal{},
+	&FinanceJournalItem{},
+	&FinanceFiscalYear{},
+	&FinancePeriod{},
+	&FinancePostingRule{},
+	&FinanceTaxCode{},
+	&FinanceTaxRate{},
+	&FinanceTaxRule{},
+	&FinanceCurrency{},
+	&FinanceExchangeRate{},
+	&FinanceFXRevaluation{},
+	&FinanceFXRevaluationItem{},
+	&FinanceReceipt{},
+	&FinanceReceiptAllocation{},
+	&FinancePayment{},
+	&FinancePaymentAllocation{},
+	&FinancePaymentRun{},
+	&FinancePaymentRunItem{},
 
 	// 生产模型
 	&ProductionOrder{},
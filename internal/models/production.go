@@ -9,6 +9,8 @@ type ProductionOrder struct {
 	ID           string    `json:"id" gorm:"primaryKey;type:varchar(36)"`
 	OrderNo      string    `json:"order_no" gorm:"unique;not null;type:varchar(20)"`
 	ProductName  string    `json:"product_name" gorm:"not null;type:varchar(100)"`
+	ItemID       string    `json:"item_id" gorm:"type:varchar(36)"`      // 产出物料，用于可承诺量计算
+	WarehouseID  string    `json:"warehouse_id" gorm:"type:varchar(36)"` // 计划入库仓库
 	Quantity     int       `json:"quantity" gorm:"not null"`
 	Status       string    `json:"status" gorm:"not null;type:varchar(20)"` // pending, approved, in_progress, completed, cancelled
 	Priority     string    `json:"priority" gorm:"not null;type:varchar(20)"` // high, medium, low
@@ -21,6 +21,8 @@ type PurchaseVendor struct {
 	Category      string         `json:"category" gorm:"type:varchar(50)"`
 	CreditLimit   float64        `json:"credit_limit" gorm:"type:decimal(18,2);default:0"`
 	LeadTime      int            `json:"lead_time" gorm:"type:int;default:0"`
+	TaxStatus     string         `json:"tax_status" gorm:"type:varchar(20);default:'taxable'"`
+	Currency      string         `json:"currency" gorm:"type:varchar(3)"`
 	PaymentTerms  string         `json:"payment_terms" gorm:"type:varchar(50)"`
 	Remarks       string         `json:"remarks" gorm:"type:text"`
 	Status        string         `json:"status" gorm:"type:varchar(20);default:'active'"`
@@ -42,6 +44,90 @@ func (PurchaseVendor) TableName() string {
 	return "purchase_vendors"
 }
 
+// PurchaseVendorScorecard 供应商评分卡表模型，按评估期间保存各项指标和加权综合评分，用于供应商排名和趋势分析
+type PurchaseVendorScorecard struct {
+	ID                   string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	VendorID             string         `json:"vendor_id" gorm:"not null;type:varchar(36);index"`
+	PeriodStart          time.Time      `json:"period_start" gorm:"not null;type:date"`
+	PeriodEnd            time.Time      `json:"period_end" gorm:"not null;type:date"`
+	ReceiptCount         int            `json:"receipt_count" gorm:"type:int;default:0"`
+	OnTimeCount          int            `json:"on_time_count" gorm:"type:int;default:0"`
+	ReceivedQuantity     float64        `json:"received_quantity" gorm:"type:decimal(18,4);default:0"`
+	ReturnedQuantity     float64        `json:"returned_quantity" gorm:"type:decimal(18,4);default:0"`
+	InvoicedAmount       float64        `json:"invoiced_amount" gorm:"type:decimal(18,2);default:0"`       // 已匹配发票行按订单单价计算的金额
+	PriceVarianceAmount  float64        `json:"price_variance_amount" gorm:"type:decimal(18,2);default:0"` // 发票单价高于订单单价的金额，低于时为负数
+	AvgLeadTimeDays      float64        `json:"avg_lead_time_days" gorm:"type:decimal(9,2);default:0"`
+	PromisedLeadTimeDays float64        `json:"promised_lead_time_days" gorm:"type:decimal(9,2);default:0"`
+	OnTimeRate           float64        `json:"on_time_rate" gorm:"type:decimal(7,2);default:0"`
+	QualityRejectRate    float64        `json:"quality_reject_rate" gorm:"type:decimal(7,2);default:0"`
+	PriceVariance        float64        `json:"price_variance" gorm:"type:decimal(9,2);default:0"`
+	LeadTimeAccuracy     float64        `json:"lead_time_accuracy" gorm:"type:decimal(7,2);default:0"`
+	OverallRating        float64        `json:"overall_rating" gorm:"type:decimal(7,2);default:0"`
+	CreatedBy            string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt            time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy            string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt            time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt            gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Vendor PurchaseVendor `json:"vendor,omitempty" gorm:"foreignKey:VendorID"`
+}
+
+// TableName 指定表名
+func (PurchaseVendorScorecard) TableName() string {
+	return "purchase_vendor_scorecards"
+}
+
+// PurchasePriceHistory 采购价格历史表模型，采购订单审批和采购发票审核时逐行记录折扣后单价，用于价格分析
+type PurchasePriceHistory struct {
+	ID            string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	VendorID      string         `json:"vendor_id" gorm:"not null;type:varchar(36);index"`
+	ItemID        string         `json:"item_id" gorm:"not null;type:varchar(36);index"`
+	SourceType    string         `json:"source_type" gorm:"not null;type:varchar(20)"` // order, invoice
+	SourceID      string         `json:"source_id" gorm:"not null;type:varchar(36);index"`
+	SourceItemID  string         `json:"source_item_id" gorm:"type:varchar(36)"`
+	SourceNo      string         `json:"source_no" gorm:"type:varchar(50)"`
+	Quantity      float64        `json:"quantity" gorm:"not null;type:decimal(18,4)"`
+	UnitPrice     float64        `json:"unit_price" gorm:"not null;type:decimal(18,2)"` // 单据币种的不含税折扣后单价
+	Currency      string         `json:"currency" gorm:"type:varchar(3)"`
+	ExchangeRate  float64        `json:"exchange_rate" gorm:"type:decimal(18,6);default:1"`
+	BaseUnitPrice float64        `json:"base_unit_price" gorm:"not null;type:decimal(18,2)"` // 折合本位币的单价
+	PriceDate     time.Time      `json:"price_date" gorm:"not null;type:date"`
+	CreatedBy     string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt     time.Time      `json:"created_at" gorm:"not null"`
+	DeletedAt     gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Vendor PurchaseVendor `json:"vendor,omitempty" gorm:"foreignKey:VendorID"`
+	Item   InventoryItem  `json:"item,omitempty" gorm:"foreignKey:ItemID"`
+}
+
+// TableName 指定表名
+func (PurchasePriceHistory) TableName() string {
+	return "purchase_price_histories"
+}
+
+// PurchaseTargetPrice 采购目标价表模型，每个物料一条本位币目标单价，价格分析按目标价计算节约或超支金额
+type PurchaseTargetPrice struct {
+	ID          string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	ItemID      string         `json:"item_id" gorm:"not null;type:varchar(36);index"`
+	TargetPrice float64        `json:"target_price" gorm:"not null;type:decimal(18,2)"`
+	Remarks     string         `json:"remarks" gorm:"type:text"`
+	CreatedBy   string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy   string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Item InventoryItem `json:"item,omitempty" gorm:"foreignKey:ItemID"`
+}
+
+// TableName 指定表名
+func (PurchaseTargetPrice) TableName() string {
+	return "purchase_target_prices"
+}
+
 // PurchasePlan 采购计划体表模型
 type PurchasePlan struct {
 	ID           string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
@@ -72,7 +158,9 @@ type PurchasePlanItem struct {
 	ID             string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
 	PlanID         string         `json:"plan_id" gorm:"not null;type:varchar(36)"`
 	ItemID         string         `json:"item_id" gorm:"not null;type:varchar(36)"`
+	VendorID       string         `json:"vendor_id" gorm:"type:varchar(36)"` // 建议供应商
 	Quantity       float64        `json:"quantity" gorm:"not null;type:decimal(18,4)"`
+	OrderedQuantity float64       `json:"ordered_quantity" gorm:"type:decimal(18,4);default:0"` // 已转采购订单数量
 	EstimatedPrice float64        `json:"estimated_price" gorm:"not null;type:decimal(18,2)"`
 	EstimatedAmount float64       `json:"estimated_amount" gorm:"not null;type:decimal(18,2)"`
 	NeedDate       time.Time      `json:"need_date" gorm:"not null;type:date"`
@@ -92,6 +180,61 @@ func (PurchasePlanItem) TableName() string {
 	return "purchase_plan_items"
 }
 
+// PurchaseRequisition 采购申请表模型
+type PurchaseRequisition struct {
+	ID              string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	RequisitionNo   string         `json:"requisition_no" gorm:"unique;not null;type:varchar(20)"`
+	DepartmentID    string         `json:"department_id" gorm:"not null;type:varchar(36)"`
+	ApplicantID     string         `json:"applicant_id" gorm:"not null;type:varchar(36)"`
+	ApplicationDate time.Time      `json:"application_date" gorm:"not null;type:date"`
+	EstimatedAmount float64        `json:"estimated_amount" gorm:"type:decimal(18,2);default:0"`
+	Status          string         `json:"status" gorm:"type:varchar(20);default:'pending'"`
+	Reason          string         `json:"reason" gorm:"type:text"`
+	Remarks         string         `json:"remarks" gorm:"type:text"`
+	CreatedBy       string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt       time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy       string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt       time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt       gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Items []PurchaseRequisitionItem `json:"items,omitempty" gorm:"foreignKey:RequisitionID"`
+}
+
+// TableName 指定表名
+func (PurchaseRequisition) TableName() string {
+	return "purchase_requisitions"
+}
+
+// PurchaseRequisitionItem 采购申请明细表模型
+type PurchaseRequisitionItem struct {
+	ID              string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	RequisitionID   string         `json:"requisition_id" gorm:"not null;type:varchar(36)"`
+	ItemID          string         `json:"item_id" gorm:"not null;type:varchar(36)"`
+	VendorID        string         `json:"vendor_id" gorm:"type:varchar(36)"`    // 建议供应商
+	WarehouseID     string         `json:"warehouse_id" gorm:"type:varchar(36)"` // 收货仓库
+	Quantity        float64        `json:"quantity" gorm:"not null;type:decimal(18,4)"`
+	OrderedQuantity float64        `json:"ordered_quantity" gorm:"type:decimal(18,4);default:0"` // 已转采购订单数量
+	EstimatedPrice  float64        `json:"estimated_price" gorm:"type:decimal(18,2);default:0"`
+	EstimatedAmount float64        `json:"estimated_amount" gorm:"type:decimal(18,2);default:0"`
+	RequiredDate    time.Time      `json:"required_date" gorm:"not null;type:date"`
+	Purpose         string         `json:"purpose" gorm:"type:varchar(255)"`
+	CreatedBy       string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt       time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy       string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt       time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt       gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Requisition PurchaseRequisition `json:"requisition,omitempty" gorm:"foreignKey:RequisitionID"`
+	Item        InventoryItem       `json:"item,omitempty" gorm:"foreignKey:ItemID"`
+}
+
+// TableName 指定表名
+func (PurchaseRequisitionItem) TableName() string {
+	return "purchase_requisition_items"
+}
+
 // PurchaseOrder 采购订单表模型
 type PurchaseOrder struct {
 	ID           string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
@@ -100,6 +243,9 @@ type PurchaseOrder struct {
 	OrderDate    time.Time      `json:"order_date" gorm:"not null;type:date"`
 	DeliveryDate *time.Time     `json:"delivery_date" gorm:"type:date"`
 	TotalAmount  float64        `json:"total_amount" gorm:"not null;type:decimal(18,2)"`
+	Currency     string         `json:"currency" gorm:"type:varchar(3)"`
+	ExchangeRate float64        `json:"exchange_rate" gorm:"type:decimal(18,6);default:1"`
+	BaseTotalAmount float64     `json:"base_total_amount" gorm:"type:decimal(18,2);default:0"`
 	Status       string         `json:"status" gorm:"type:varchar(20);default:'pending'"`
 	PaymentTerms string         `json:"payment_terms" gorm:"type:varchar(50)"`
 	Remarks      string         `json:"remarks" gorm:"type:text"`
@@ -132,6 +278,9 @@ type PurchaseOrderItem struct {
 	Discount        float64        `json:"discount" gorm:"type:decimal(18,2);default:0"`
 	Amount          float64        `json:"amount" gorm:"not null;type:decimal(18,2)"`
 	ReceivedQuantity float64       `json:"received_quantity" gorm:"type:decimal(18,4);default:0"`
+	WarehouseID     string         `json:"warehouse_id" gorm:"type:varchar(36)"` // 计划入库仓库
+	RequisitionItemID string       `json:"requisition_item_id" gorm:"type:varchar(36)"` // 来源采购申请明细
+	PlanItemID      string         `json:"plan_item_id" gorm:"type:varchar(36)"` // 来源采购计划明细
 	CreatedBy       string         `json:"created_by" gorm:"type:varchar(36)"`
 	CreatedAt       time.Time      `json:"created_at" gorm:"not null"`
 	UpdatedBy       string         `json:"updated_by" gorm:"type:varchar(36)"`
@@ -218,8 +367,18 @@ type PurchaseInvoice struct {
 	DueDate     time.Time      `json:"due_date" gorm:"not null;type:date"`
 	TotalAmount float64        `json:"total_amount" gorm:"not null;type:decimal(18,2)"`
 	PaidAmount  float64        `json:"paid_amount" gorm:"type:decimal(18,2);default:0"`
+	DiscountAmount float64     `json:"discount_amount" gorm:"type:decimal(18,2);default:0"`
 	TaxAmount   float64        `json:"tax_amount" gorm:"type:decimal(18,2);default:0"`
+	PriceIncludesTax bool      `json:"price_includes_tax" gorm:"default:false"`
+	Currency     string        `json:"currency" gorm:"type:varchar(3)"`
+	ExchangeRate float64       `json:"exchange_rate" gorm:"type:decimal(18,6);default:1"`
+	BaseTotalAmount float64    `json:"base_total_amount" gorm:"type:decimal(18,2);default:0"`
+	BaseTaxAmount   float64    `json:"base_tax_amount" gorm:"type:decimal(18,2);default:0"`
 	Status      string         `json:"status" gorm:"type:varchar(20);default:'unpaid'"`
+	BlockReason        string     `json:"block_reason" gorm:"type:varchar(500)"`
+	BlockReleasedBy    string     `json:"block_released_by" gorm:"type:varchar(36)"`
+	BlockReleasedAt    *time.Time `json:"block_released_at"`
+	BlockReleaseReason string     `json:"block_release_reason" gorm:"type:varchar(255)"`
 	PaymentTerms string         `json:"payment_terms" gorm:"type:varchar(50)"`
 	Remarks     string         `json:"remarks" gorm:"type:text"`
 	CreatedBy   string         `json:"created_by" gorm:"type:varchar(36)"`
@@ -232,6 +391,7 @@ type PurchaseInvoice struct {
 	Order    PurchaseOrder      `json:"order,omitempty" gorm:"foreignKey:OrderID"`
 	Vendor   PurchaseVendor     `json:"vendor,omitempty" gorm:"foreignKey:VendorID"`
 	Items    []PurchaseInvoiceItem `json:"items,omitempty" gorm:"foreignKey:InvoiceID"`
+	Discrepancies []PurchaseInvoiceDiscrepancy `json:"discrepancies,omitempty" gorm:"foreignKey:InvoiceID"`
 }
 
 // TableName 指定表名
@@ -243,11 +403,16 @@ func (PurchaseInvoice) TableName() string {
 type PurchaseInvoiceItem struct {
 	ID        string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
 	InvoiceID string         `json:"invoice_id" gorm:"not null;type:varchar(36)"`
+	OrderItemID string       `json:"order_item_id" gorm:"type:varchar(36)"` // 对应的采购订单明细
 	ItemID    string         `json:"item_id" gorm:"not null;type:varchar(36)"`
 	Quantity  float64        `json:"quantity" gorm:"not null;type:decimal(18,4)"`
 	UnitPrice float64        `json:"unit_price" gorm:"not null;type:decimal(18,2)"`
 	Discount  float64        `json:"discount" gorm:"type:decimal(18,2);default:0"`
 	Amount    float64        `json:"amount" gorm:"not null;type:decimal(18,2)"`
+	TaxCodeID string         `json:"tax_code_id" gorm:"type:varchar(36)"`
+	TaxRate   float64        `json:"tax_rate" gorm:"type:decimal(7,4);default:0"`
+	NetAmount float64        `json:"net_amount" gorm:"type:decimal(18,2);default:0"`
+	TaxAmount float64        `json:"tax_amount" gorm:"type:decimal(18,2);default:0"`
 	CreatedBy string         `json:"created_by" gorm:"type:varchar(36)"`
 	CreatedAt time.Time      `json:"created_at" gorm:"not null"`
 	UpdatedBy string         `json:"updated_by" gorm:"type:varchar(36)"`
@@ -256,6 +421,7 @@ type PurchaseInvoiceItem struct {
 
 	// 关联
 	Invoice PurchaseInvoice `json:"invoice,omitempty" gorm:"foreignKey:InvoiceID"`
+	OrderItem *PurchaseOrderItem `json:"order_item,omitempty" gorm:"foreignKey:OrderItemID"`
 	Item    InventoryItem   `json:"item,omitempty" gorm:"foreignKey:ItemID"`
 }
 
@@ -264,6 +430,31 @@ func (PurchaseInvoiceItem) TableName() string {
 	return "purchase_invoice_items"
 }
 
+// PurchaseInvoiceDiscrepancy 采购发票三单匹配差异表模型，发票审核时按订单单价和收货数量核对发票明细，超出容差的记录差异并冻结发票
+type PurchaseInvoiceDiscrepancy struct {
+	ID              string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	InvoiceID       string         `json:"invoice_id" gorm:"not null;type:varchar(36);index"`
+	InvoiceItemID   string         `json:"invoice_item_id" gorm:"type:varchar(36)"`
+	OrderItemID     string         `json:"order_item_id" gorm:"type:varchar(36)"`
+	Type            string         `json:"type" gorm:"not null;type:varchar(20)"` // price单价差异、quantity数量差异、unmatched无对应订单明细、no_items发票无明细
+	Expected        float64        `json:"expected" gorm:"type:decimal(18,4);default:0"`
+	Actual          float64        `json:"actual" gorm:"type:decimal(18,4);default:0"`
+	Variance        float64        `json:"variance" gorm:"type:decimal(18,4);default:0"`
+	VariancePercent float64        `json:"variance_percent" gorm:"type:decimal(9,4);default:0"`
+	Tolerance       float64        `json:"tolerance" gorm:"type:decimal(9,4);default:0"`
+	Reason          string         `json:"reason" gorm:"type:varchar(255)"`
+	CreatedBy       string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt       time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy       string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt       time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt       gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
+
+// TableName 指定表名
+func (PurchaseInvoiceDiscrepancy) TableName() string {
+	return "purchase_invoice_discrepancies"
+}
+
 // PurchaseReturn 采购退货单表模型
 type PurchaseReturn struct {
 	ID          string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
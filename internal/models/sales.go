@@ -23,6 +23,8 @@ type SalesCustomer struct {
 	CreditLimit   float64        `json:"credit_limit" gorm:"type:decimal(18,2);default:0"`
 	CreditBalance float64        `json:"credit_balance" gorm:"type:decimal(18,2);default:0"`
 	CreditDays    int            `json:"credit_days" gorm:"type:int;default:0"`
+	TaxStatus     string         `json:"tax_status" gorm:"type:varchar(20);default:'taxable'"`
+	Currency      string         `json:"currency" gorm:"type:varchar(3)"`
 	PaymentTerms  string         `json:"payment_terms" gorm:"type:varchar(50)"`
 	Remarks       string         `json:"remarks" gorm:"type:text"`
 	Status        string         `json:"status" gorm:"type:varchar(20);default:'active'"`
@@ -53,8 +55,10 @@ type SalesProduct struct {
 	Name        string         `json:"name" gorm:"not null;type:varchar(100)"`
 	Description string         `json:"description" gorm:"type:text"`
 	CategoryID  string         `json:"category_id" gorm:"type:varchar(36)"`
+	ItemID      string         `json:"item_id" gorm:"type:varchar(36)"`
 	Unit        string         `json:"unit" gorm:"not null;type:varchar(10)"`
 	Price       float64        `json:"price" gorm:"not null;type:decimal(18,2)"`
+	TaxCategory string         `json:"tax_category" gorm:"type:varchar(20);default:'standard'"`
 	Status      string         `json:"status" gorm:"type:varchar(20);default:'active'"`
 	CreatedBy   string         `json:"created_by" gorm:"type:varchar(36)"`
 	CreatedAt   time.Time      `json:"created_at" gorm:"not null"`
@@ -64,6 +68,7 @@ type SalesProduct struct {
 
 	// 关联
 	Category    *SalesProductCategory `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+	Item        *InventoryItem        `json:"item,omitempty" gorm:"foreignKey:ItemID"`
 	QuoteItems  []SalesQuoteItem      `json:"quote_items,omitempty" gorm:"foreignKey:ProductID"`
 	OrderItems  []SalesOrderItem      `json:"order_items,omitempty" gorm:"foreignKey:ProductID"`
 	DeliveryItems []SalesDeliveryItem `json:"delivery_items,omitempty" gorm:"foreignKey:ProductID"`
@@ -107,6 +112,9 @@ type SalesQuote struct {
 	QuoteDate  time.Time      `json:"quote_date" gorm:"not null;type:date"`
 	ValidUntil time.Time      `json:"valid_until" gorm:"not null;type:date"`
 	TotalAmount float64       `json:"total_amount" gorm:"not null;type:decimal(18,2)"`
+	Currency     string       `json:"currency" gorm:"type:varchar(3)"`
+	ExchangeRate float64      `json:"exchange_rate" gorm:"type:decimal(18,6);default:1"`
+	BaseTotalAmount float64   `json:"base_total_amount" gorm:"type:decimal(18,2);default:0"`
 	Status     string         `json:"status" gorm:"type:varchar(20);default:'draft'"`
 	Remarks    string         `json:"remarks" gorm:"type:text"`
 	CreatedBy  string         `json:"created_by" gorm:"type:varchar(36)"`
@@ -160,7 +168,14 @@ type SalesOrder struct {
 	OrderDate  time.Time      `json:"order_date" gorm:"not null;type:date"`
 	DeliveryDate *time.Time   `json:"delivery_date" gorm:"type:date"`
 	TotalAmount float64       `json:"total_amount" gorm:"not null;type:decimal(18,2)"`
+	Currency     string       `json:"currency" gorm:"type:varchar(3)"`
+	ExchangeRate float64      `json:"exchange_rate" gorm:"type:decimal(18,6);default:1"`
+	BaseTotalAmount float64   `json:"base_total_amount" gorm:"type:decimal(18,2);default:0"`
 	Status     string         `json:"status" gorm:"type:varchar(20);default:'pending'"`
+	CreditHoldReason    string     `json:"credit_hold_reason" gorm:"type:varchar(255)"`
+	CreditReleasedBy    string     `json:"credit_released_by" gorm:"type:varchar(36)"`
+	CreditReleasedAt    *time.Time `json:"credit_released_at"`
+	CreditReleaseReason string     `json:"credit_release_reason" gorm:"type:varchar(255)"`
 	Remarks    string         `json:"remarks" gorm:"type:text"`
 	CreatedBy  string         `json:"created_by" gorm:"type:varchar(36)"`
 	CreatedAt  time.Time      `json:"created_at" gorm:"not null"`
@@ -192,6 +207,7 @@ type SalesOrderItem struct {
 	Discount        float64        `json:"discount" gorm:"type:decimal(18,2);default:0"`
 	Amount          float64        `json:"amount" gorm:"not null;type:decimal(18,2)"`
 	ShippedQuantity float64        `json:"shipped_quantity" gorm:"type:decimal(18,4);default:0"`
+	WarehouseID     string         `json:"warehouse_id" gorm:"type:varchar(36)"` // 指定发货仓库，为空时按各仓库可用量预留
 	CreatedBy       string         `json:"created_by" gorm:"type:varchar(36)"`
 	CreatedAt       time.Time      `json:"created_at" gorm:"not null"`
 	UpdatedBy       string         `json:"updated_by" gorm:"type:varchar(36)"`
@@ -243,6 +259,8 @@ type SalesDeliveryItem struct {
 	OrderItemID string         `json:"order_item_id" gorm:"not null;type:varchar(36)"`
 	ProductID   string         `json:"product_id" gorm:"not null;type:varchar(36)"`
 	Quantity    float64        `json:"quantity" gorm:"not null;type:decimal(18,4)"`
+	WarehouseID string         `json:"warehouse_id" gorm:"type:varchar(36)"`
+	LocationID  string         `json:"location_id" gorm:"type:varchar(36)"`
 	CreatedBy   string         `json:"created_by" gorm:"type:varchar(36)"`
 	CreatedAt   time.Time      `json:"created_at" gorm:"not null"`
 	UpdatedBy   string         `json:"updated_by" gorm:"type:varchar(36)"`
@@ -253,6 +271,8 @@ type SalesDeliveryItem struct {
 	Delivery    SalesDelivery   `json:"delivery,omitempty" gorm:"foreignKey:DeliveryID"`
 	OrderItem   SalesOrderItem  `json:"order_item,omitempty" gorm:"foreignKey:OrderItemID"`
 	Product     SalesProduct    `json:"product,omitempty" gorm:"foreignKey:ProductID"`
+	Warehouse   *InventoryWarehouse `json:"warehouse,omitempty" gorm:"foreignKey:WarehouseID"`
+	Location    *InventoryLocation  `json:"location,omitempty" gorm:"foreignKey:LocationID"`
 }
 
 // TableName 指定表名
@@ -269,7 +289,15 @@ type SalesInvoice struct {
 	InvoiceDate time.Time      `json:"invoice_date" gorm:"not null;type:date"`
 	DueDate     time.Time      `json:"due_date" gorm:"not null;type:date"`
 	TotalAmount float64        `json:"total_amount" gorm:"not null;type:decimal(18,2)"`
+	TaxAmount   float64        `json:"tax_amount" gorm:"type:decimal(18,2);default:0"`
+	PriceIncludesTax bool      `json:"price_includes_tax" gorm:"default:false"`
+	Currency     string        `json:"currency" gorm:"type:varchar(3)"`
+	ExchangeRate float64       `json:"exchange_rate" gorm:"type:decimal(18,6);default:1"`
+	BaseTotalAmount float64    `json:"base_total_amount" gorm:"type:decimal(18,2);default:0"`
+	BaseTaxAmount   float64    `json:"base_tax_amount" gorm:"type:decimal(18,2);default:0"`
 	PaidAmount  float64        `json:"paid_amount" gorm:"type:decimal(18,2);default:0"`
+	WriteOffAmount float64     `json:"write_off_amount" gorm:"type:decimal(18,2);default:0"`
+	CreditedAmount float64     `json:"credited_amount" gorm:"type:decimal(18,2);default:0"`
 	Status      string         `json:"status" gorm:"type:varchar(20);default:'unpaid'"`
 	Remarks     string         `json:"remarks" gorm:"type:text"`
 	CreatedBy   string         `json:"created_by" gorm:"type:varchar(36)"`
@@ -298,6 +326,10 @@ type SalesInvoiceItem struct {
 	UnitPrice float64        `json:"unit_price" gorm:"not null;type:decimal(18,2)"`
 	Discount  float64        `json:"discount" gorm:"type:decimal(18,2);default:0"`
 	Amount    float64        `json:"amount" gorm:"not null;type:decimal(18,2)"`
+	TaxCodeID string         `json:"tax_code_id" gorm:"type:varchar(36)"`
+	TaxRate   float64        `json:"tax_rate" gorm:"type:decimal(7,4);default:0"`
+	NetAmount float64        `json:"net_amount" gorm:"type:decimal(18,2);default:0"`
+	TaxAmount float64        `json:"tax_amount" gorm:"type:decimal(18,2);default:0"`
 	CreatedBy string         `json:"created_by" gorm:"type:varchar(36)"`
 	CreatedAt time.Time      `json:"created_at" gorm:"not null"`
 	UpdatedBy string         `json:"updated_by" gorm:"type:varchar(36)"`
@@ -320,8 +352,11 @@ type SalesReturn struct {
 	ReturnNo   string         `json:"return_no" gorm:"unique;not null;type:varchar(20)"`
 	OrderID    string         `json:"order_id" gorm:"not null;type:varchar(36)"`
 	CustomerID string         `json:"customer_id" gorm:"not null;type:varchar(36)"`
+	InvoiceID  string         `json:"invoice_id" gorm:"type:varchar(36)"`
 	ReturnDate time.Time      `json:"return_date" gorm:"not null;type:date"`
 	TotalAmount float64       `json:"total_amount" gorm:"not null;type:decimal(18,2)"`
+	Currency     string       `json:"currency" gorm:"type:varchar(3)"`
+	ExchangeRate float64      `json:"exchange_rate" gorm:"type:decimal(18,6);default:1"`
 	Status     string         `json:"status" gorm:"type:varchar(20);default:'pending'"`
 	Reason     string         `json:"reason" gorm:"type:text"`
 	Remarks    string         `json:"remarks" gorm:"type:text"`
@@ -334,7 +369,9 @@ type SalesReturn struct {
 	// 关联
 	Order     SalesOrder      `json:"order,omitempty" gorm:"foreignKey:OrderID"`
 	Customer  SalesCustomer   `json:"customer,omitempty" gorm:"foreignKey:CustomerID"`
+	Invoice   *SalesInvoice   `json:"invoice,omitempty" gorm:"foreignKey:InvoiceID"`
 	Items     []SalesReturnItem `json:"items,omitempty" gorm:"foreignKey:ReturnID"`
+	CreditNote *SalesCreditNote `json:"credit_note,omitempty" gorm:"foreignKey:ReturnID"`
 }
 
 // TableName 指定表名
@@ -346,10 +383,14 @@ func (SalesReturn) TableName() string {
 type SalesReturnItem struct {
 	ID        string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
 	ReturnID  string         `json:"return_id" gorm:"not null;type:varchar(36)"`
+	OrderItemID string       `json:"order_item_id" gorm:"type:varchar(36)"`
 	ProductID string         `json:"product_id" gorm:"not null;type:varchar(36)"`
 	Quantity  float64        `json:"quantity" gorm:"not null;type:decimal(18,4)"`
 	UnitPrice float64        `json:"unit_price" gorm:"not null;type:decimal(18,2)"`
 	Amount    float64        `json:"amount" gorm:"not null;type:decimal(18,2)"`
+	WarehouseID string       `json:"warehouse_id" gorm:"type:varchar(36)"`
+	LocationID  string       `json:"location_id" gorm:"type:varchar(36)"`
+	Quarantine  bool         `json:"quarantine" gorm:"default:false"`
 	CreatedBy string         `json:"created_by" gorm:"type:varchar(36)"`
 	CreatedAt time.Time      `json:"created_at" gorm:"not null"`
 	UpdatedBy string         `json:"updated_by" gorm:"type:varchar(36)"`
@@ -359,6 +400,8 @@ type SalesReturnItem struct {
 	// 关联
 	Return   SalesReturn   `json:"return,omitempty" gorm:"foreignKey:ReturnID"`
 	Product  SalesProduct  `json:"product,omitempty" gorm:"foreignKey:ProductID"`
+	Warehouse *InventoryWarehouse `json:"warehouse,omitempty" gorm:"foreignKey:WarehouseID"`
+	Location  *InventoryLocation  `json:"location,omitempty" gorm:"foreignKey:LocationID"`
 }
 
 // TableName 指定表名
@@ -366,6 +409,72 @@ func (SalesReturnItem) TableName() string {
 	return "sales_return_items"
 }
 
+// SalesCreditNote 销售贷项通知单表模型，退货收货时生成，冲减原发票余额，未冲减部分为客户贷项余额
+type SalesCreditNote struct {
+	ID           string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	CreditNoteNo string         `json:"credit_note_no" gorm:"unique;not null;type:varchar(20)"`
+	ReturnID     string         `json:"return_id" gorm:"not null;type:varchar(36);index"`
+	OrderID      string         `json:"order_id" gorm:"not null;type:varchar(36)"`
+	CustomerID   string         `json:"customer_id" gorm:"not null;type:varchar(36);index"`
+	InvoiceID    string         `json:"invoice_id" gorm:"type:varchar(36);index"`
+	CreditDate   time.Time      `json:"credit_date" gorm:"not null;type:date"`
+	TotalAmount  float64        `json:"total_amount" gorm:"not null;type:decimal(18,2)"`
+	TaxAmount    float64        `json:"tax_amount" gorm:"type:decimal(18,2);default:0"`
+	PriceIncludesTax bool       `json:"price_includes_tax" gorm:"default:false"`
+	Currency     string         `json:"currency" gorm:"type:varchar(3)"`
+	ExchangeRate float64        `json:"exchange_rate" gorm:"type:decimal(18,6);default:1"`
+	BaseTotalAmount float64     `json:"base_total_amount" gorm:"type:decimal(18,2);default:0"`
+	BaseTaxAmount   float64     `json:"base_tax_amount" gorm:"type:decimal(18,2);default:0"`
+	AppliedAmount   float64     `json:"applied_amount" gorm:"type:decimal(18,2);default:0"`
+	Status       string         `json:"status" gorm:"type:varchar(20);default:'open'"`
+	Remarks      string         `json:"remarks" gorm:"type:text"`
+	CreatedBy    string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt    time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy    string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt    time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt    gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Return    SalesReturn     `json:"return,omitempty" gorm:"foreignKey:ReturnID"`
+	Customer  SalesCustomer   `json:"customer,omitempty" gorm:"foreignKey:CustomerID"`
+	Invoice   *SalesInvoice   `json:"invoice,omitempty" gorm:"foreignKey:InvoiceID"`
+	Items     []SalesCreditNoteItem `json:"items,omitempty" gorm:"foreignKey:CreditNoteID"`
+}
+
+// TableName 指定表名
+func (SalesCreditNote) TableName() string {
+	return "sales_credit_notes"
+}
+
+// SalesCreditNoteItem 销售贷项通知单明细表模型
+type SalesCreditNoteItem struct {
+	ID           string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	CreditNoteID string         `json:"credit_note_id" gorm:"not null;type:varchar(36);index"`
+	ReturnItemID string         `json:"return_item_id" gorm:"type:varchar(36)"`
+	ProductID    string         `json:"product_id" gorm:"not null;type:varchar(36)"`
+	Quantity     float64        `json:"quantity" gorm:"not null;type:decimal(18,4)"`
+	UnitPrice    float64        `json:"unit_price" gorm:"not null;type:decimal(18,2)"`
+	Amount       float64        `json:"amount" gorm:"not null;type:decimal(18,2)"`
+	TaxCodeID    string         `json:"tax_code_id" gorm:"type:varchar(36)"`
+	TaxRate      float64        `json:"tax_rate" gorm:"type:decimal(7,4);default:0"`
+	NetAmount    float64        `json:"net_amount" gorm:"type:decimal(18,2);default:0"`
+	TaxAmount    float64        `json:"tax_amount" gorm:"type:decimal(18,2);default:0"`
+	CreatedBy    string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt    time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy    string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt    time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt    gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	CreditNote SalesCreditNote `json:"credit_note,omitempty" gorm:"foreignKey:CreditNoteID"`
+	Product    SalesProduct    `json:"product,omitempty" gorm:"foreignKey:ProductID"`
+}
+
+// TableName 指定表名
+func (SalesCreditNoteItem) TableName() string {
+	return "sales_credit_note_items"
+}
+
 // SalesCustomerCredit 客户信用评估表模型
 type SalesCustomerCredit struct {
 	ID            string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
@@ -373,6 +482,10 @@ type SalesCustomerCredit struct {
 	EvaluationDate time.Time     `json:"evaluation_date" gorm:"not null;type:date"`
 	CreditScore   int            `json:"credit_score" gorm:"not null;type:int"`
 	RiskLevel     string         `json:"risk_level" gorm:"not null;type:varchar(20)"`
+	CreditLimit   float64        `json:"credit_limit" gorm:"type:decimal(18,2);default:0"`
+	Exposure      float64        `json:"exposure" gorm:"type:decimal(18,2);default:0"`
+	OverdueAmount float64        `json:"overdue_amount" gorm:"type:decimal(18,2);default:0"`
+	OverdueDays   int            `json:"overdue_days" gorm:"type:int;default:0"`
 	Remarks       string         `json:"remarks" gorm:"type:text"`
 	CreatedBy     string         `json:"created_by" gorm:"type:varchar(36)"`
 	CreatedAt     time.Time      `json:"created_at" gorm:"not null"`
@@ -388,3 +501,105 @@ type SalesCustomerCredit struct {
 func (SalesCustomerCredit) TableName() string {
 	return "sales_customer_credits"
 }
+
+// SalesPriceList 销售价目表模型，按币种、客户分组和有效期确定适用范围
+type SalesPriceList struct {
+	ID            string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	Code          string         `json:"code" gorm:"unique;not null;type:varchar(20)"`
+	Name          string         `json:"name" gorm:"not null;type:varchar(100)"`
+	Currency      string         `json:"currency" gorm:"not null;type:varchar(3);default:'CNY'"`
+	CustomerGroup string         `json:"customer_group" gorm:"type:varchar(50)"`
+	ValidFrom     time.Time      `json:"valid_from" gorm:"not null;type:date"`
+	ValidTo       *time.Time     `json:"valid_to" gorm:"type:date"`
+	Priority      int            `json:"priority" gorm:"type:int;default:0"`
+	Status        string         `json:"status" gorm:"type:varchar(20);default:'active'"`
+	Remarks       string         `json:"remarks" gorm:"type:text"`
+	CreatedBy     string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt     time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy     string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt     time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt     gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Items []SalesPriceListItem `json:"items,omitempty" gorm:"foreignKey:PriceListID"`
+}
+
+// TableName 指定表名
+func (SalesPriceList) TableName() string {
+	return "sales_price_lists"
+}
+
+// SalesPriceListItem 销售价目表明细模型，同一产品按起订数量设置多档阶梯价
+type SalesPriceListItem struct {
+	ID          string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	PriceListID string         `json:"price_list_id" gorm:"not null;type:varchar(36);index"`
+	ProductID   string         `json:"product_id" gorm:"not null;type:varchar(36);index"`
+	MinQuantity float64        `json:"min_quantity" gorm:"type:decimal(18,4);default:0"`
+	UnitPrice   float64        `json:"unit_price" gorm:"not null;type:decimal(18,2)"`
+	CreatedBy   string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy   string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	PriceList SalesPriceList `json:"price_list,omitempty" gorm:"foreignKey:PriceListID"`
+	Product   SalesProduct   `json:"product,omitempty" gorm:"foreignKey:ProductID"`
+}
+
+// TableName 指定表名
+func (SalesPriceListItem) TableName() string {
+	return "sales_price_list_items"
+}
+
+// SalesCustomerPrice 客户合同价模型，优先于价目表
+type SalesCustomerPrice struct {
+	ID          string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	CustomerID  string         `json:"customer_id" gorm:"not null;type:varchar(36);index"`
+	ProductID   string         `json:"product_id" gorm:"not null;type:varchar(36);index"`
+	Currency    string         `json:"currency" gorm:"not null;type:varchar(3);default:'CNY'"`
+	MinQuantity float64        `json:"min_quantity" gorm:"type:decimal(18,4);default:0"`
+	UnitPrice   float64        `json:"unit_price" gorm:"not null;type:decimal(18,2)"`
+	ValidFrom   time.Time      `json:"valid_from" gorm:"not null;type:date"`
+	ValidTo     *time.Time     `json:"valid_to" gorm:"type:date"`
+	ContractNo  string         `json:"contract_no" gorm:"type:varchar(50)"`
+	Status      string         `json:"status" gorm:"type:varchar(20);default:'active'"`
+	CreatedBy   string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy   string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	Customer SalesCustomer `json:"customer,omitempty" gorm:"foreignKey:CustomerID"`
+	Product  SalesProduct  `json:"product,omitempty" gorm:"foreignKey:ProductID"`
+}
+
+// TableName 指定表名
+func (SalesCustomerPrice) TableName() string {
+	return "sales_customer_prices"
+}
+
+// SalesPromotion 销售促销模型，产品和客户分组为空表示不限
+type SalesPromotion struct {
+	ID              string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	Code            string         `json:"code" gorm:"unique;not null;type:varchar(20)"`
+	Name            string         `json:"name" gorm:"not null;type:varchar(100)"`
+	ProductID       string         `json:"product_id" gorm:"type:varchar(36);index"`
+	CustomerGroup   string         `json:"customer_group" gorm:"type:varchar(50)"`
+	MinQuantity     float64        `json:"min_quantity" gorm:"type:decimal(18,4);default:0"`
+	DiscountPercent float64        `json:"discount_percent" gorm:"not null;type:decimal(5,2)"`
+	ValidFrom       time.Time      `json:"valid_from" gorm:"not null;type:date"`
+	ValidTo         *time.Time     `json:"valid_to" gorm:"type:date"`
+	Status          string         `json:"status" gorm:"type:varchar(20);default:'active'"`
+	CreatedBy       string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt       time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy       string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt       time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt       gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
+
+// TableName 指定表名
+func (SalesPromotion) TableName() string {
+	return "sales_promotions"
+}
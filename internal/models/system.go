@@ -0,0 +1,117 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SystemRole 角色表模型
+type SystemRole struct {
+	ID          string         `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	Code        string         `json:"code" gorm:"unique;not null;type:varchar(20)"`
+	Name        string         `json:"name" gorm:"not null;type:varchar(100)"`
+	Description string         `json:"description" gorm:"type:text"`
+	Status      string         `json:"status" gorm:"type:varchar(20);default:'active'"`
+	CreatedBy   string         `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedBy   string         `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// 关联
+	RolePermissions []SystemRolePermission `json:"role_permissions,omitempty" gorm:"foreignKey:RoleID"`
+}
+
+// TableName 指定表名
+func (SystemRole) TableName() string {
+	return "system_roles"
+}
+
+// SystemUserRole 用户角色表模型，UserID为用户ID的字符串形式
+type SystemUserRole struct {
+	ID        string    `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	UserID    string    `json:"user_id" gorm:"not null;type:varchar(36);index"`
+	RoleID    string    `json:"role_id" gorm:"not null;type:varchar(36);index"`
+	CreatedBy string    `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null"`
+
+	// 关联
+	Role SystemRole `json:"role,omitempty" gorm:"foreignKey:RoleID"`
+}
+
+// TableName 指定表名
+func (SystemUserRole) TableName() string {
+	return "system_user_roles"
+}
+
+// SystemPermission 权限表模型，编码格式为 模块:资源:操作
+type SystemPermission struct {
+	ID          string    `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	Code        string    `json:"code" gorm:"unique;not null;type:varchar(50)"`
+	Name        string    `json:"name" gorm:"not null;type:varchar(100)"`
+	Description string    `json:"description" gorm:"type:text"`
+	Module      string    `json:"module" gorm:"not null;type:varchar(50);index"`
+	CreatedBy   string    `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt   time.Time `json:"created_at" gorm:"not null"`
+	UpdatedBy   string    `json:"updated_by" gorm:"type:varchar(36)"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"not null"`
+}
+
+// TableName 指定表名
+func (SystemPermission) TableName() string {
+	return "system_permissions"
+}
+
+// SystemRolePermission 角色权限表模型
+type SystemRolePermission struct {
+	ID           string    `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	RoleID       string    `json:"role_id" gorm:"not null;type:varchar(36);index"`
+	PermissionID string    `json:"permission_id" gorm:"not null;type:varchar(36);index"`
+	CreatedBy    string    `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt    time.Time `json:"created_at" gorm:"not null"`
+
+	// 关联
+	Permission SystemPermission `json:"permission,omitempty" gorm:"foreignKey:PermissionID"`
+}
+
+// TableName 指定表名
+func (SystemRolePermission) TableName() string {
+	return "system_role_permissions"
+}
+
+// SystemUserSession 用户登录会话表模型，访问令牌通过会话ID关联，刷新令牌每次使用后轮换
+type SystemUserSession struct {
+	ID                string     `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	UserID            string     `json:"user_id" gorm:"not null;type:varchar(36);index"`
+	RefreshTokenHash  string     `json:"-" gorm:"not null;type:varchar(64)"`
+	PreviousTokenHash string     `json:"-" gorm:"type:varchar(64)"` // 上一次轮换前的刷新令牌摘要，用于识别令牌重放
+	ExpiresAt         time.Time  `json:"expires_at" gorm:"not null"`
+	RefreshedAt       *time.Time `json:"refreshed_at"`
+	RevokedAt         *time.Time `json:"revoked_at"`
+	ClientIP          string     `json:"client_ip" gorm:"type:varchar(50)"`
+	UserAgent         string     `json:"user_agent" gorm:"type:varchar(255)"`
+	CreatedAt         time.Time  `json:"created_at" gorm:"not null"`
+	UpdatedAt         time.Time  `json:"updated_at" gorm:"not null"`
+}
+
+// TableName 指定表名
+func (SystemUserSession) TableName() string {
+	return "system_user_sessions"
+}
+
+// SystemPasswordReset 密码重置令牌表模型，只保存令牌摘要
+type SystemPasswordReset struct {
+	ID        string     `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	UserID    string     `json:"user_id" gorm:"not null;type:varchar(36);index"`
+	TokenHash string     `json:"-" gorm:"unique;not null;type:varchar(64)"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedBy string     `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt time.Time  `json:"created_at" gorm:"not null"`
+}
+
+// TableName 指定表名
+func (SystemPasswordReset) TableName() string {
+	return "system_password_resets"
+}
@@ -0,0 +1,158 @@
+// Package query 为列表接口提供统一的分页、排序和过滤。
+//
+// 查询参数格式：
+//
+//	page=1&page_size=20            分页，page_size默认20，最大100
+//	sort=-order_date,customer_no   排序，前缀 - 表示降序
+//	status=active                  等于
+//	name[like]=华东                模糊匹配
+//	status[in]=draft,approved      取值列表
+//	order_date[gte]=2024-01-01     大于等于，另有 lte
+//	total_amount[between]=100,500  区间，包含两端
+//
+// 可过滤和排序的字段由各模型的 Spec 白名单限定。
+package query
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 分页默认值
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// 过滤操作符
+const (
+	OpEq      = "eq"
+	OpLike    = "like"
+	OpIn      = "in"
+	OpGte     = "gte"
+	OpLte     = "lte"
+	OpBetween = "between"
+)
+
+// ErrInvalidQuery 查询参数不合法，处理器据此返回400
+var ErrInvalidQuery = errors.New("invalid query")
+
+// Params 列表查询参数
+type Params struct {
+	Page     int
+	PageSize int
+	Sort     string
+	Filters  []Filter
+}
+
+// Filter 过滤条件，Op为空表示未显式指定操作符的等值条件
+type Filter struct {
+	Field string
+	Op    string
+	Value string
+}
+
+// Parse 从URL查询参数解析分页、排序和过滤条件，字段是否允许在应用到模型时校验
+func Parse(values url.Values) (Params, error) {
+	params := Params{Page: 1, PageSize: DefaultPageSize}
+
+	// 按参数名排序，保证生成的SQL稳定
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := strings.TrimSpace(values.Get(key))
+		if value == "" {
+			continue
+		}
+
+		switch key {
+		case "page":
+			page, err := strconv.Atoi(value)
+			if err != nil || page < 1 {
+				return Params{}, fmt.Errorf("%w: page must be a positive integer", ErrInvalidQuery)
+			}
+			params.Page = page
+		case "page_size", "pageSize":
+			size, err := strconv.Atoi(value)
+			if err != nil || size < 1 || size > MaxPageSize {
+				return Params{}, fmt.Errorf("%w: page_size must be between 1 and %d", ErrInvalidQuery, MaxPageSize)
+			}
+			params.PageSize = size
+		case "sort":
+			params.Sort = value
+		default:
+			field, op := key, ""
+			if i := strings.Index(key, "["); i > 0 && strings.HasSuffix(key, "]") {
+				field, op = key[:i], key[i+1:len(key)-1]
+				if !validOp(op) {
+					return Params{}, fmt.Errorf("%w: unsupported operator %s", ErrInvalidQuery, op)
+				}
+			}
+			params.Filters = append(params.Filters, Filter{Field: field, Op: op, Value: value})
+		}
+	}
+
+	return params, nil
+}
+
+// Offset 当前页的偏移量
+func (p Params) Offset() int {
+	return (p.page() - 1) * p.size()
+}
+
+// page 规范化后的页码
+func (p Params) page() int {
+	if p.Page < 1 {
+		return 1
+	}
+	return p.Page
+}
+
+// size 规范化后的每页条数
+func (p Params) size() int {
+	if p.PageSize < 1 {
+		return DefaultPageSize
+	}
+	if p.PageSize > MaxPageSize {
+		return MaxPageSize
+	}
+	return p.PageSize
+}
+
+// Page 分页结果
+type Page[T any] struct {
+	Items    []T   `json:"items"`
+	Total    int64 `json:"total"`
+	Page     int   `json:"page"`
+	PageSize int   `json:"page_size"`
+}
+
+// NewPage 创建分页结果
+func NewPage[T any](items []T, total int64, params Params) *Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return &Page[T]{
+		Items:    items,
+		Total:    total,
+		Page:     params.page(),
+		PageSize: params.size(),
+	}
+}
+
+// validOp 判断操作符是否支持
+func validOp(op string) bool {
+	switch op {
+	case OpEq, OpLike, OpIn, OpGte, OpLte, OpBetween:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Filter 过滤条件scope，未在白名单中的等值参数忽略，显式指定操作符的未知字段报错
+func (p Params) Filter(spec Spec) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, filter := range p.Filters {
+			field, ok := spec.fields[filter.Field]
+			if !ok {
+				if filter.Op == "" {
+					continue
+				}
+				db.AddError(fmt.Errorf("%w: field %s is not filterable", ErrInvalidQuery, filter.Field))
+				return db
+			}
+
+			exprs, err := field.conditions(filter)
+			if err != nil {
+				db.AddError(err)
+				return db
+			}
+			for _, expr := range exprs {
+				db = db.Where(expr)
+			}
+		}
+		return db
+	}
+}
+
+// Order 排序scope，未指定sort时使用白名单的默认排序
+func (p Params) Order(spec Spec) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		sort := p.Sort
+		if sort == "" {
+			sort = spec.defaultSort
+		}
+
+		for _, item := range splitValues(sort) {
+			desc := strings.HasPrefix(item, "-")
+			name := strings.TrimPrefix(item, "-")
+			field, ok := spec.fields[name]
+			if !ok {
+				db.AddError(fmt.Errorf("%w: field %s is not sortable", ErrInvalidQuery, name))
+				return db
+			}
+			db = db.Order(clause.OrderByColumn{Column: column(field.Column), Desc: desc})
+		}
+		return db
+	}
+}
+
+// Paginate 分页scope
+func (p Params) Paginate() func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Offset(p.Offset()).Limit(p.size())
+	}
+}
+
+// Find 统计符合过滤条件的总数，并按排序和分页读取当前页数据到dest。
+// db可携带服务层的固定条件；scopes只作用于读取数据，用于Preload等不能参与统计的条件。
+func Find(db *gorm.DB, params Params, spec Spec, dest interface{}, scopes ...func(*gorm.DB) *gorm.DB) (int64, error) {
+	filtered := db.Scopes(params.Filter(spec)).Session(&gorm.Session{})
+
+	var total int64
+	if err := filtered.Model(dest).Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	result := filtered.Scopes(scopes...).Scopes(params.Order(spec), params.Paginate()).Find(dest)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return total, nil
+}
+
+// conditions 将过滤条件转换为SQL表达式
+func (f Field) conditions(filter Filter) ([]clause.Expression, error) {
+	op := filter.Op
+	if op == "" {
+		op = OpEq
+	}
+	if !f.allows(op) {
+		return nil, fmt.Errorf("%w: operator %s is not supported on %s", ErrInvalidQuery, op, f.Name)
+	}
+
+	col := column(f.Column)
+	if f.Type == TypeDate {
+		return f.dateConditions(col, op, filter.Value)
+	}
+
+	switch op {
+	case OpLike:
+		return []clause.Expression{clause.Like{Column: col, Value: "%" + escapeLike(filter.Value) + "%"}}, nil
+	case OpIn:
+		values, err := f.parseList(filter.Value, 0)
+		if err != nil {
+			return nil, err
+		}
+		return []clause.Expression{clause.IN{Column: col, Values: values}}, nil
+	case OpBetween:
+		values, err := f.parseList(filter.Value, 2)
+		if err != nil {
+			return nil, err
+		}
+		return []clause.Expression{clause.Gte{Column: col, Value: values[0]}, clause.Lte{Column: col, Value: values[1]}}, nil
+	}
+
+	value, err := f.parse(filter.Value)
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+	case OpGte:
+		return []clause.Expression{clause.Gte{Column: col, Value: value}}, nil
+	case OpLte:
+		return []clause.Expression{clause.Lte{Column: col, Value: value}}, nil
+	default:
+		return []clause.Expression{clause.Eq{Column: col, Value: value}}, nil
+	}
+}
+
+// dateConditions 日期条件，只有日期部分的上界按当天结束计算
+func (f Field) dateConditions(col clause.Column, op, value string) ([]clause.Expression, error) {
+	bounds := []string{value, value}
+	if op == OpBetween {
+		bounds = splitValues(value)
+		if len(bounds) != 2 {
+			return nil, fmt.Errorf("%w: %s[between] requires two values", ErrInvalidQuery, f.Name)
+		}
+	}
+
+	from, _, err := f.parseDate(bounds[0])
+	if err != nil {
+		return nil, err
+	}
+	to, wholeDay, err := f.parseDate(bounds[1])
+	if err != nil {
+		return nil, err
+	}
+
+	var upper clause.Expression = clause.Lte{Column: col, Value: to}
+	if wholeDay {
+		upper = clause.Lt{Column: col, Value: to.AddDate(0, 0, 1)}
+	}
+
+	switch op {
+	case OpGte:
+		return []clause.Expression{clause.Gte{Column: col, Value: from}}, nil
+	case OpLte:
+		return []clause.Expression{upper}, nil
+	case OpEq:
+		if !wholeDay {
+			return []clause.Expression{clause.Eq{Column: col, Value: from}}, nil
+		}
+	}
+	return []clause.Expression{clause.Gte{Column: col, Value: from}, upper}, nil
+}
+
+// parseList 解析逗号分隔的取值列表，count大于0时要求取值个数一致
+func (f Field) parseList(value string, count int) ([]interface{}, error) {
+	parts := splitValues(value)
+	if len(parts) == 0 || (count > 0 && len(parts) != count) {
+		return nil, fmt.Errorf("%w: invalid value list for %s", ErrInvalidQuery, f.Name)
+	}
+
+	values := make([]interface{}, len(parts))
+	for i, part := range parts {
+		parsed, err := f.parse(part)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = parsed
+	}
+	return values, nil
+}
+
+// column 列名支持 表名.列名 形式
+func column(name string) clause.Column {
+	if table, col, ok := strings.Cut(name, "."); ok {
+		return clause.Column{Table: table, Name: col}
+	}
+	return clause.Column{Name: name}
+}
+
+// escapeLike 转义LIKE通配符
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
@@ -0,0 +1,130 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldType 字段类型，决定可用的操作符和取值解析方式
+type FieldType int
+
+// 字段类型
+const (
+	TypeText FieldType = iota
+	TypeNumber
+	TypeDate
+	TypeBool
+)
+
+// Field 白名单字段，Name为查询参数名，Column为数据库列名
+type Field struct {
+	Name   string
+	Column string
+	Type   FieldType
+}
+
+// Text 文本字段，支持 eq、like、in
+func Text(column string) Field {
+	return Field{Name: column, Column: column, Type: TypeText}
+}
+
+// Number 数值字段，支持 eq、in、gte、lte、between
+func Number(column string) Field {
+	return Field{Name: column, Column: column, Type: TypeNumber}
+}
+
+// Date 日期字段，支持 eq、gte、lte、between，只有日期部分时按整天匹配
+func Date(column string) Field {
+	return Field{Name: column, Column: column, Type: TypeDate}
+}
+
+// Bool 布尔字段，只支持 eq
+func Bool(column string) Field {
+	return Field{Name: column, Column: column, Type: TypeBool}
+}
+
+// As 指定与列名不同的查询参数名
+func (f Field) As(name string) Field {
+	f.Name = name
+	return f
+}
+
+// Spec 模型的查询白名单
+type Spec struct {
+	fields      map[string]Field
+	defaultSort string
+}
+
+// NewSpec 创建查询白名单，defaultSort格式与sort参数相同，未指定sort时使用
+func NewSpec(defaultSort string, fields ...Field) Spec {
+	spec := Spec{
+		fields:      make(map[string]Field, len(fields)),
+		defaultSort: defaultSort,
+	}
+	for _, field := range fields {
+		spec.fields[field.Name] = field
+	}
+	return spec
+}
+
+// allows 判断字段类型是否支持操作符
+func (f Field) allows(op string) bool {
+	switch f.Type {
+	case TypeText:
+		return op == OpEq || op == OpLike || op == OpIn
+	case TypeNumber:
+		return op != OpLike
+	case TypeDate:
+		return op == OpEq || op == OpGte || op == OpLte || op == OpBetween
+	default:
+		return op == OpEq
+	}
+}
+
+// parse 按字段类型解析单个取值
+func (f Field) parse(value string) (interface{}, error) {
+	switch f.Type {
+	case TypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s must be a number", ErrInvalidQuery, f.Name)
+		}
+		return number, nil
+	case TypeBool:
+		flag, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s must be a boolean", ErrInvalidQuery, f.Name)
+		}
+		return flag, nil
+	default:
+		return value, nil
+	}
+}
+
+// 支持的日期格式
+var dateLayouts = []string{"2006-01-02", "2006-01-02 15:04:05", time.RFC3339}
+
+// parseDate 解析日期，wholeDay表示取值只有日期部分
+func (f Field) parseDate(value string) (t time.Time, wholeDay bool, err error) {
+	for i, layout := range dateLayouts {
+		t, err = time.ParseInLocation(layout, value, time.Local)
+		if err == nil {
+			return t, i == 0, nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("%w: %s must be a date like 2006-01-02", ErrInvalidQuery, f.Name)
+}
+
+// splitValues 拆分逗号分隔的取值
+func splitValues(value string) []string {
+	parts := strings.Split(value, ",")
+	values := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
@@ -0,0 +1,362 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/config"
+	"github.com/wu136995/ginx/internal/database"
+	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 认证错误，处理器据此返回401或400
+var (
+	ErrInvalidCredentials  = errors.New("invalid account or password")
+	ErrUserInactive        = errors.New("user is not active")
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrSessionInvalid      = errors.New("session is revoked or expired")
+	ErrInvalidResetToken   = errors.New("invalid or expired password reset token")
+)
+
+// AuthService 认证服务接口
+type AuthService interface {
+	Login(req schemas.LoginRequest, clientIP, userAgent string) (*schemas.TokenResponse, error)
+	RefreshToken(req schemas.RefreshTokenRequest, clientIP, userAgent string) (*schemas.TokenResponse, error)
+	Logout(sessionID string) error
+	ValidateSession(sessionID string) error
+	ChangePassword(userID, sessionID string, req schemas.PasswordChangeRequest) error
+	CreatePasswordReset(userID, createdBy string) (*schemas.PasswordResetTokenResponse, error)
+	ResetPassword(req schemas.PasswordResetRequest) error
+}
+
+// authService 认证服务实现
+type authService struct {
+	db *gorm.DB
+}
+
+// NewAuthService 创建认证服务实例
+func NewAuthService() AuthService {
+	return &authService{
+		db: database.GetDB(),
+	}
+}
+
+// Login 校验账号密码并创建登录会话
+func (s *authService) Login(req schemas.LoginRequest, clientIP, userAgent string) (*schemas.TokenResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 按用户名或邮箱读取用户
+	var user models.User
+	result := s.db.Where("username = ? OR email = ?", req.Account, req.Account).First(&user)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, result.Error
+	}
+	if !utils.CheckPassword(req.Password, user.Password) {
+		return nil, ErrInvalidCredentials
+	}
+	if user.Status != "" && user.Status != "active" {
+		return nil, ErrUserInactive
+	}
+
+	// 创建会话并记录登录时间
+	now := time.Now()
+	refreshSecret := utils.GenerateSecureToken()
+	session := models.SystemUserSession{
+		ID:               utils.GenerateID(),
+		UserID:           userIDString(user.ID),
+		RefreshTokenHash: utils.HashToken(refreshSecret),
+		ExpiresAt:        now.Add(refreshTokenTTL()),
+		ClientIP:         clientIP,
+		UserAgent:        truncate(userAgent, 255),
+	}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&session).Error; err != nil {
+			return err
+		}
+		return tx.Model(&user).Update("last_login_at", now).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return issueTokens(user, session, refreshSecret)
+}
+
+// RefreshToken 轮换刷新令牌并签发新的访问令牌，已轮换的旧令牌再次使用时撤销整个会话
+func (s *authService) RefreshToken(req schemas.RefreshTokenRequest, clientIP, userAgent string) (*schemas.TokenResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 刷新令牌格式为 会话ID.随机串
+	sessionID, secret, ok := strings.Cut(req.RefreshToken, ".")
+	if !ok || sessionID == "" || secret == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	var response *schemas.TokenResponse
+	reused := false
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// 锁定会话，防止同一令牌并发刷新
+		var session models.SystemUserSession
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&session, "id = ?", sessionID)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return ErrInvalidRefreshToken
+			}
+			return result.Error
+		}
+		now := time.Now()
+		if session.RevokedAt != nil || !now.Before(session.ExpiresAt) {
+			return ErrInvalidRefreshToken
+		}
+
+		// 摘要与上一次轮换前的令牌一致说明已轮换的有效令牌被再次使用，视为泄露并撤销会话；
+		// 其他不一致的令牌直接拒绝，会话ID即访问令牌的jti，仅凭会话ID不能撤销会话
+		if hash := utils.HashToken(secret); session.RefreshTokenHash != hash {
+			if session.PreviousTokenHash == "" || session.PreviousTokenHash != hash {
+				return ErrInvalidRefreshToken
+			}
+			reused = true
+			return tx.Model(&session).Update("revoked_at", now).Error
+		}
+
+		var user models.User
+		if err := tx.First(&user, "id = ?", session.UserID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvalidRefreshToken
+			}
+			return err
+		}
+		if user.Status != "" && user.Status != "active" {
+			return ErrUserInactive
+		}
+
+		// 轮换刷新令牌并延长会话有效期
+		refreshSecret := utils.GenerateSecureToken()
+		session.PreviousTokenHash = session.RefreshTokenHash
+		session.RefreshTokenHash = utils.HashToken(refreshSecret)
+		session.ExpiresAt = now.Add(refreshTokenTTL())
+		session.RefreshedAt = &now
+		session.ClientIP = clientIP
+		session.UserAgent = truncate(userAgent, 255)
+		if err := tx.Save(&session).Error; err != nil {
+			return err
+		}
+
+		var err error
+		response, err = issueTokens(user, session, refreshSecret)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	return response, nil
+}
+
+// Logout 撤销会话，会话下已签发的访问令牌随之失效
+func (s *authService) Logout(sessionID string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	result := s.db.Model(&models.SystemUserSession{}).
+		Where("id = ? AND revoked_at IS NULL", sessionID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+// ValidateSession 校验会话未撤销且未过期
+func (s *authService) ValidateSession(sessionID string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	var session models.SystemUserSession
+	result := s.db.First(&session, "id = ?", sessionID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return ErrSessionInvalid
+		}
+		return result.Error
+	}
+	if session.RevokedAt != nil || !time.Now().Before(session.ExpiresAt) {
+		return ErrSessionInvalid
+	}
+
+	return nil
+}
+
+// ChangePassword 校验原密码后修改密码，并撤销当前会话以外的所有会话
+func (s *authService) ChangePassword(userID, sessionID string, req schemas.PasswordChangeRequest) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 从数据库读取用户
+	var user models.User
+	result := s.db.First(&user, "id = ?", userID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if !utils.CheckPassword(req.OldPassword, user.Password) {
+		return ErrInvalidCredentials
+	}
+
+	hash, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&user).Update("password", hash).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.SystemUserSession{}).
+			Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, sessionID).
+			Update("revoked_at", time.Now()).Error
+	})
+}
+
+// CreatePasswordReset 为用户生成一次性密码重置令牌，之前未使用的令牌同时作废
+func (s *authService) CreatePasswordReset(userID, createdBy string) (*schemas.PasswordResetTokenResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 确认用户存在
+	var user models.User
+	result := s.db.First(&user, "id = ?", userID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	now := time.Now()
+	token := utils.GenerateSecureToken()
+	reset := models.SystemPasswordReset{
+		ID:        utils.GenerateID(),
+		UserID:    userID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: now.Add(time.Duration(config.GetAppConfig().JWT.PasswordResetMinutes) * time.Minute),
+		CreatedBy: createdBy,
+	}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.SystemPasswordReset{}).
+			Where("user_id = ? AND used_at IS NULL", userID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		return tx.Create(&reset).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &schemas.PasswordResetTokenResponse{
+		UserID:     userID,
+		ResetToken: token,
+		ExpiresAt:  reset.ExpiresAt.Format("2006-01-02 15:04:05"),
+	}, nil
+}
+
+// ResetPassword 使用重置令牌设置新密码，并撤销用户的所有会话
+func (s *authService) ResetPassword(req schemas.PasswordResetRequest) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	hash, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		// 锁定重置令牌，保证只能使用一次
+		var reset models.SystemPasswordReset
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&reset, "token_hash = ?", utils.HashToken(req.ResetToken))
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return ErrInvalidResetToken
+			}
+			return result.Error
+		}
+		now := time.Now()
+		if reset.UsedAt != nil || !now.Before(reset.ExpiresAt) {
+			return ErrInvalidResetToken
+		}
+
+		if err := tx.Model(&reset).Update("used_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.User{}).Where("id = ?", reset.UserID).Update("password", hash).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.SystemUserSession{}).
+			Where("user_id = ? AND revoked_at IS NULL", reset.UserID).
+			Update("revoked_at", now).Error
+	})
+}
+
+// issueTokens 为会话签发访问令牌，并返回 会话ID.随机串 格式的刷新令牌
+func issueTokens(user models.User, session models.SystemUserSession, refreshSecret string) (*schemas.TokenResponse, error) {
+	jwtConfig := config.GetAppConfig().JWT
+	ttl := time.Duration(jwtConfig.AccessTokenMinutes) * time.Minute
+	accessToken, err := utils.GenerateAccessToken(user.ID, user.Username, user.Role, session.ID, jwtConfig.Secret, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schemas.TokenResponse{
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int(ttl.Seconds()),
+		RefreshToken:     session.ID + "." + refreshSecret,
+		RefreshExpiresAt: session.ExpiresAt.Format("2006-01-02 15:04:05"),
+	}, nil
+}
+
+// refreshTokenTTL 刷新令牌有效期
+func refreshTokenTTL() time.Duration {
+	return time.Duration(config.GetAppConfig().JWT.RefreshTokenHours) * time.Hour
+}
+
+// userIDString 用户ID的字符串形式，用于会话和授权表
+func userIDString(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// truncate 按字节截断字符串，避免超出字段长度
+func truncate(value string, size int) string {
+	if len(value) <= size {
+		return value
+	}
+	return value[:size]
+}
@@ -7,13 +7,14 @@ import (
 	"github.com/wu136995/ginx/internal/api/schemas"
 	"github.com/wu136995/ginx/internal/database"
 	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/query"
 	"gorm.io/gorm"
 )
 
 // CRMService CRM服务接口
 type CRMService interface {
 	// 客户管理
-	GetCustomerList(req map[string]interface{}) ([]schemas.CustomerContactResponse, error)
+	GetCustomerList(params query.Params) (*query.Page[schemas.CustomerContactResponse], error)
 	GetCustomerDetail(id string) (*schemas.CustomerContactResponse, error)
 	CreateCustomer(req schemas.CustomerCreateRequest) (*schemas.CustomerContactResponse, error)
 	UpdateCustomer(id string, req schemas.CustomerUpdateRequest) (*schemas.CustomerContactResponse, error)
@@ -23,7 +24,7 @@ type CRMService interface {
 	GetCustomerHistory(id string) ([]schemas.ActivityResponse, error)
 
 	// 销售线索管理
-	GetLeadList(req map[string]interface{}) ([]schemas.LeadResponse, error)
+	GetLeadList(params query.Params) (*query.Page[schemas.LeadResponse], error)
 	GetLeadDetail(id string) (*schemas.LeadResponse, error)
 	CreateLead(req schemas.LeadCreateRequest) (*schemas.LeadResponse, error)
 	UpdateLead(id string, req schemas.LeadUpdateRequest) (*schemas.LeadResponse, error)
@@ -32,16 +33,16 @@ type CRMService interface {
 	DisqualifyLead(id string) error
 
 	// 销售机会管理
-	GetOpportunityList(req map[string]interface{}) ([]schemas.OpportunityResponse, error)
+	GetOpportunityList(params query.Params) (*query.Page[schemas.OpportunityResponse], error)
 	GetOpportunityDetail(id string) (*schemas.OpportunityResponse, error)
 	CreateOpportunity(req schemas.OpportunityCreateRequest) (*schemas.OpportunityResponse, error)
 	UpdateOpportunity(id string, req schemas.OpportunityUpdateRequest) (*schemas.OpportunityResponse, error)
 	DeleteOpportunity(id string) error
-	CloseOpportunity(id string, req map[string]interface{}) error
+	CloseOpportunity(id string, req schemas.OpportunityCloseRequest) error
 	GetOpportunityActivities(id string) ([]schemas.ActivityResponse, error)
 
 	// 营销活动管理
-	GetCampaignList(req map[string]interface{}) ([]schemas.CampaignResponse, error)
+	GetCampaignList(params query.Params) (*query.Page[schemas.CampaignResponse], error)
 	GetCampaignDetail(id string) (*schemas.CampaignResponse, error)
 	CreateCampaign(req schemas.CampaignCreateRequest) (*schemas.CampaignResponse, error)
 	UpdateCampaign(id string, req schemas.CampaignUpdateRequest) (*schemas.CampaignResponse, error)
@@ -51,7 +52,7 @@ type CRMService interface {
 	GetCampaignResults(id string) (*schemas.CampaignResponse, error)
 
 	// 客户活动管理
-	GetActivityList(req map[string]interface{}) ([]schemas.ActivityResponse, error)
+	GetActivityList(params query.Params) (*query.Page[schemas.ActivityResponse], error)
 	GetActivityDetail(id string) (*schemas.ActivityResponse, error)
 	CreateActivity(req schemas.ActivityCreateRequest) (*schemas.ActivityResponse, error)
 	UpdateActivity(id string, req schemas.ActivityUpdateRequest) (*schemas.ActivityResponse, error)
@@ -60,32 +61,32 @@ type CRMService interface {
 	CancelActivity(id string) error
 
 	// 服务请求管理
-	GetServiceRequestList(req map[string]interface{}) ([]schemas.ServiceRequestResponse, error)
+	GetServiceRequestList(params query.Params) (*query.Page[schemas.ServiceRequestResponse], error)
 	GetServiceRequestDetail(id string) (*schemas.ServiceRequestResponse, error)
 	CreateServiceRequest(req schemas.ServiceRequestCreateRequest) (*schemas.ServiceRequestResponse, error)
 	UpdateServiceRequest(id string, req schemas.ServiceRequestUpdateRequest) (*schemas.ServiceRequestResponse, error)
 	DeleteServiceRequest(id string) error
-	AssignServiceRequest(id string, req map[string]interface{}) error
+	AssignServiceRequest(id string, req schemas.ServiceAssignRequest) error
 	ResolveServiceRequest(id string) error
 	CloseServiceRequest(id string) error
 
 	// 服务工单管理
-	GetServiceTicketList(req map[string]interface{}) ([]schemas.ServiceTicketResponse, error)
+	GetServiceTicketList(params query.Params) (*query.Page[schemas.ServiceTicketResponse], error)
 	GetServiceTicketDetail(id string) (*schemas.ServiceTicketResponse, error)
 	CreateServiceTicket(req schemas.ServiceTicketCreateRequest) (*schemas.ServiceTicketResponse, error)
 	UpdateServiceTicket(id string, req schemas.ServiceTicketUpdateRequest) (*schemas.ServiceTicketResponse, error)
 	DeleteServiceTicket(id string) error
-	AssignServiceTicket(id string, req map[string]interface{}) error
+	AssignServiceTicket(id string, req schemas.ServiceAssignRequest) error
 	ResolveServiceTicket(id string) error
 	CloseServiceTicket(id string) error
 
 	// CRM报表管理
 	GetSalesPipelineReport(req schemas.SalesPipelineReportRequest) (*schemas.SalesPipelineReportResponse, error)
-	GetCustomerAnalysisReport(req schemas.CustomerAnalysisReportRequest) (map[string]interface{}, error)
-	GetActivitySummaryReport(req map[string]interface{}) (map[string]interface{}, error)
-	GetCampaignEffectivenessReport(req map[string]interface{}) (map[string]interface{}, error)
-	GetServicePerformanceReport(req map[string]interface{}) (map[string]interface{}, error)
-	ExportCRMReport(req map[string]interface{}) ([]byte, error)
+	GetCustomerAnalysisReport(req schemas.CustomerAnalysisReportRequest) (*schemas.CRMCustomerAnalysisReportResponse, error)
+	GetActivitySummaryReport(req schemas.CRMReportRequest) (*schemas.ActivitySummaryReportResponse, error)
+	GetCampaignEffectivenessReport(req schemas.CRMReportRequest) (*schemas.CampaignEffectivenessReportResponse, error)
+	GetServicePerformanceReport(req schemas.CRMReportRequest) (*schemas.ServicePerformanceReportResponse, error)
+	ExportCRMReport(req schemas.ExportCRMReportRequest) ([]byte, error)
 }
 
 // crmService CRM服务实现
@@ -100,8 +101,21 @@ func NewCRMService() CRMService {
 	}
 }
 
+// crmAccountListSpec 客户列表查询白名单
+var crmAccountListSpec = query.NewSpec("-created_at",
+	query.Text("account_no"),
+	query.Text("name"),
+	query.Text("type"),
+	query.Text("industry"),
+	query.Text("region"),
+	query.Text("loyalty_level"),
+	query.Text("status"),
+	query.Number("credit_limit"),
+	query.Date("created_at"),
+)
+
 // 客户管理方法
-func (s *crmService) GetCustomerList(req map[string]interface{}) ([]schemas.CustomerContactResponse, error) {
+func (s *crmService) GetCustomerList(params query.Params) (*query.Page[schemas.CustomerContactResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -109,9 +123,9 @@ func (s *crmService) GetCustomerList(req map[string]interface{}) ([]schemas.Cust
 
 	// 从数据库读取客户数据
 	var accounts []models.CRMAccount
-	result := s.db.Find(&accounts)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, crmAccountListSpec, &accounts)
+	if err != nil {
+		return nil, err
 	}
 
 	// 将模型转换为响应格式
@@ -131,7 +145,7 @@ func (s *crmService) GetCustomerList(req map[string]interface{}) ([]schemas.Cust
 		}
 	}
 
-	return customers, nil
+	return query.NewPage(customers, total, params), nil
 }
 
 func (s *crmService) GetCustomerDetail(id string) (*schemas.CustomerContactResponse, error) {
@@ -436,8 +450,18 @@ func (s *crmService) GetCustomerHistory(id string) ([]schemas.ActivityResponse,
 	return historyList, nil
 }
 
+// leadListSpec 销售线索列表查询白名单
+var leadListSpec = query.NewSpec("-created_at",
+	query.Text("name"),
+	query.Text("company"),
+	query.Text("source"),
+	query.Text("status"),
+	query.Number("score"),
+	query.Date("created_at"),
+)
+
 // 销售线索管理方法
-func (s *crmService) GetLeadList(req map[string]interface{}) ([]schemas.LeadResponse, error) {
+func (s *crmService) GetLeadList(params query.Params) (*query.Page[schemas.LeadResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -445,9 +469,9 @@ func (s *crmService) GetLeadList(req map[string]interface{}) ([]schemas.LeadResp
 
 	// 从数据库读取销售线索数据
 	var leads []models.CRMLead
-	result := s.db.Find(&leads)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, leadListSpec, &leads)
+	if err != nil {
+		return nil, err
 	}
 
 	// 将模型转换为响应格式
@@ -470,7 +494,7 @@ func (s *crmService) GetLeadList(req map[string]interface{}) ([]schemas.LeadResp
 		}
 	}
 
-	return leadList, nil
+	return query.NewPage(leadList, total, params), nil
 }
 
 func (s *crmService) GetLeadDetail(id string) (*schemas.LeadResponse, error) {
@@ -694,8 +718,20 @@ func (s *crmService) DisqualifyLead(id string) error {
 	return nil
 }
 
+// opportunityListSpec 销售机会列表查询白名单
+var opportunityListSpec = query.NewSpec("-created_at",
+	query.Text("opportunity_no"),
+	query.Text("account_id"),
+	query.Text("name"),
+	query.Text("stage"),
+	query.Number("probability"),
+	query.Number("estimated_amount"),
+	query.Date("close_date"),
+	query.Date("created_at"),
+)
+
 // 销售机会管理方法
-func (s *crmService) GetOpportunityList(req map[string]interface{}) ([]schemas.OpportunityResponse, error) {
+func (s *crmService) GetOpportunityList(params query.Params) (*query.Page[schemas.OpportunityResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -703,9 +739,9 @@ func (s *crmService) GetOpportunityList(req map[string]interface{}) ([]schemas.O
 
 	// 从数据库读取销售机会数据
 	var opportunities []models.CRMOpportunity
-	result := s.db.Find(&opportunities)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, opportunityListSpec, &opportunities)
+	if err != nil {
+		return nil, err
 	}
 
 	// 将模型转换为响应格式
@@ -729,7 +765,7 @@ func (s *crmService) GetOpportunityList(req map[string]interface{}) ([]schemas.O
 		}
 	}
 
-	return opportunityList, nil
+	return query.NewPage(opportunityList, total, params), nil
 }
 
 func (s *crmService) GetOpportunityDetail(id string) (*schemas.OpportunityResponse, error) {
@@ -907,7 +943,7 @@ func (s *crmService) DeleteOpportunity(id string) error {
 	return nil
 }
 
-func (s *crmService) CloseOpportunity(id string, req map[string]interface{}) error {
+func (s *crmService) CloseOpportunity(id string, req schemas.OpportunityCloseRequest) error {
 	// 检查数据库连接
 	if s.db == nil {
 		return errors.New("database connection is nil")
@@ -920,10 +956,22 @@ func (s *crmService) CloseOpportunity(id string, req map[string]interface{}) err
 		return result.Error
 	}
 
-	// 更新状态为closed
-	opportunity.Stage = "closed"
+	// 按赢单或丢单关闭，未指定关闭日期时取当天
+	opportunity.Stage = req.Stage
+	opportunity.Probability = 0
+	if req.Stage == "closed_won" {
+		opportunity.Probability = 100
+	}
+	opportunity.CloseDate = time.Now()
+	if req.CloseDate != "" {
+		closeDate, err := time.Parse("2006-01-02", req.CloseDate)
+		if err != nil {
+			return err
+		}
+		opportunity.CloseDate = closeDate
+	}
 	opportunity.UpdatedAt = time.Now()
-	opportunity.UpdatedBy = "system"
+	opportunity.UpdatedBy = req.ClosedBy
 
 	// 保存到数据库
 	result = s.db.Save(&opportunity)
@@ -969,8 +1017,20 @@ func (s *crmService) GetOpportunityActivities(id string) ([]schemas.ActivityResp
 	return activityList, nil
 }
 
+// campaignListSpec 营销活动列表查询白名单
+var campaignListSpec = query.NewSpec("-start_date",
+	query.Text("campaign_no"),
+	query.Text("name"),
+	query.Text("type"),
+	query.Text("status"),
+	query.Number("budget"),
+	query.Date("start_date"),
+	query.Date("end_date"),
+	query.Date("created_at"),
+)
+
 // 营销活动管理方法
-func (s *crmService) GetCampaignList(req map[string]interface{}) ([]schemas.CampaignResponse, error) {
+func (s *crmService) GetCampaignList(params query.Params) (*query.Page[schemas.CampaignResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -978,9 +1038,9 @@ func (s *crmService) GetCampaignList(req map[string]interface{}) ([]schemas.Camp
 
 	// 从数据库读取营销活动数据
 	var campaigns []models.CRMCampaign
-	result := s.db.Find(&campaigns)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, campaignListSpec, &campaigns)
+	if err != nil {
+		return nil, err
 	}
 
 	// 将模型转换为响应格式
@@ -1005,7 +1065,7 @@ func (s *crmService) GetCampaignList(req map[string]interface{}) ([]schemas.Camp
 		}
 	}
 
-	return campaignList, nil
+	return query.NewPage(campaignList, total, params), nil
 }
 
 func (s *crmService) GetCampaignDetail(id string) (*schemas.CampaignResponse, error) {
@@ -1250,8 +1310,20 @@ func (s *crmService) GetCampaignResults(id string) (*schemas.CampaignResponse, e
 	return campaignResult, nil
 }
 
+// activityListSpec 客户活动列表查询白名单
+var activityListSpec = query.NewSpec("-start_time",
+	query.Text("account_id"),
+	query.Text("contact_id"),
+	query.Text("type"),
+	query.Text("subject"),
+	query.Text("status"),
+	query.Text("priority"),
+	query.Date("start_time"),
+	query.Date("created_at"),
+)
+
 // 客户活动管理方法
-func (s *crmService) GetActivityList(req map[string]interface{}) ([]schemas.ActivityResponse, error) {
+func (s *crmService) GetActivityList(params query.Params) (*query.Page[schemas.ActivityResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -1259,9 +1331,9 @@ func (s *crmService) GetActivityList(req map[string]interface{}) ([]schemas.Acti
 
 	// 从数据库读取客户活动数据
 	var activities []models.CRMActivity
-	result := s.db.Find(&activities)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, activityListSpec, &activities)
+	if err != nil {
+		return nil, err
 	}
 
 	// 将模型转换为响应格式
@@ -1283,7 +1355,7 @@ func (s *crmService) GetActivityList(req map[string]interface{}) ([]schemas.Acti
 		}
 	}
 
-	return activityList, nil
+	return query.NewPage(activityList, total, params), nil
 }
 
 func (s *crmService) GetActivityDetail(id string) (*schemas.ActivityResponse, error) {
@@ -1500,8 +1572,20 @@ func (s *crmService) CancelActivity(id string) error {
 	return nil
 }
 
+// caseListSpec 服务请求和服务工单列表查询白名单
+var caseListSpec = query.NewSpec("-created_at",
+	query.Text("case_no"),
+	query.Text("account_id"),
+	query.Text("contact_id"),
+	query.Text("subject"),
+	query.Text("type"),
+	query.Text("priority"),
+	query.Text("status"),
+	query.Date("created_at"),
+)
+
 // 服务请求管理方法
-func (s *crmService) GetServiceRequestList(req map[string]interface{}) ([]schemas.ServiceRequestResponse, error) {
+func (s *crmService) GetServiceRequestList(params query.Params) (*query.Page[schemas.ServiceRequestResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -1509,9 +1593,9 @@ func (s *crmService) GetServiceRequestList(req map[string]interface{}) ([]schema
 
 	// 从数据库读取服务请求数据
 	var cases []models.CRMCase
-	result := s.db.Find(&cases)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, caseListSpec, &cases)
+	if err != nil {
+		return nil, err
 	}
 
 	// 将模型转换为响应格式
@@ -1534,7 +1618,7 @@ func (s *crmService) GetServiceRequestList(req map[string]interface{}) ([]schema
 		}
 	}
 
-	return requestList, nil
+	return query.NewPage(requestList, total, params), nil
 }
 
 func (s *crmService) GetServiceRequestDetail(id string) (*schemas.ServiceRequestResponse, error) {
@@ -1695,7 +1779,7 @@ func (s *crmService) DeleteServiceRequest(id string) error {
 	return nil
 }
 
-func (s *crmService) AssignServiceRequest(id string, req map[string]interface{}) error {
+func (s *crmService) AssignServiceRequest(id string, req schemas.ServiceAssignRequest) error {
 	// 检查数据库连接
 	if s.db == nil {
 		return errors.New("database connection is nil")
@@ -1708,10 +1792,14 @@ func (s *crmService) AssignServiceRequest(id string, req map[string]interface{})
 		return result.Error
 	}
 
-	// 更新状态为assigned
+	// 更新状态为assigned，首次分配即视为已响应
 	caseItem.Status = "assigned"
+	if caseItem.ResponseTime == nil {
+		now := time.Now()
+		caseItem.ResponseTime = &now
+	}
 	caseItem.UpdatedAt = time.Now()
-	caseItem.UpdatedBy = "system"
+	caseItem.UpdatedBy = req.AssignedBy
 
 	// 保存到数据库
 	result = s.db.Save(&caseItem)
@@ -1779,7 +1867,7 @@ func (s *crmService) CloseServiceRequest(id string) error {
 }
 
 // 服务工单管理方法
-func (s *crmService) GetServiceTicketList(req map[string]interface{}) ([]schemas.ServiceTicketResponse, error) {
+func (s *crmService) GetServiceTicketList(params query.Params) (*query.Page[schemas.ServiceTicketResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -1787,9 +1875,9 @@ func (s *crmService) GetServiceTicketList(req map[string]interface{}) ([]schemas
 
 	// 从数据库读取服务工单数据
 	var cases []models.CRMCase
-	result := s.db.Find(&cases)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, caseListSpec, &cases)
+	if err != nil {
+		return nil, err
 	}
 
 	// 将模型转换为响应格式
@@ -1816,7 +1904,7 @@ func (s *crmService) GetServiceTicketList(req map[string]interface{}) ([]schemas
 		}
 	}
 
-	return ticketList, nil
+	return query.NewPage(ticketList, total, params), nil
 }
 
 func (s *crmService) GetServiceTicketDetail(id string) (*schemas.ServiceTicketResponse, error) {
@@ -1977,7 +2065,7 @@ func (s *crmService) DeleteServiceTicket(id string) error {
 	return nil
 }
 
-func (s *crmService) AssignServiceTicket(id string, req map[string]interface{}) error {
+func (s *crmService) AssignServiceTicket(id string, req schemas.ServiceAssignRequest) error {
 	// 检查数据库连接
 	if s.db == nil {
 		return errors.New("database connection is nil")
@@ -1990,10 +2078,14 @@ func (s *crmService) AssignServiceTicket(id string, req map[string]interface{})
 		return result.Error
 	}
 
-	// 更新状态为assigned
+	// 更新状态为assigned，首次分配即视为已响应
 	caseItem.Status = "assigned"
+	if caseItem.ResponseTime == nil {
+		now := time.Now()
+		caseItem.ResponseTime = &now
+	}
 	caseItem.UpdatedAt = time.Now()
-	caseItem.UpdatedBy = "system"
+	caseItem.UpdatedBy = req.AssignedBy
 
 	// 保存到数据库
 	result = s.db.Save(&caseItem)
@@ -2078,6 +2170,7 @@ func (s *crmService) GetSalesPipelineReport(req schemas.SalesPipelineReportReque
 
 	// 构建销售漏斗报表数据
 	report := &schemas.SalesPipelineReportResponse{
+		Period:             reportPeriod(req.StartDate, req.EndDate),
 		TotalOpportunities: len(opportunities),
 		TotalValue:         0,
 		Stages:             make([]schemas.SalesPipelineStage, 0),
@@ -2114,7 +2207,7 @@ func (s *crmService) GetSalesPipelineReport(req schemas.SalesPipelineReportReque
 	return report, nil
 }
 
-func (s *crmService) GetCustomerAnalysisReport(req schemas.CustomerAnalysisReportRequest) (map[string]interface{}, error) {
+func (s *crmService) GetCustomerAnalysisReport(req schemas.CustomerAnalysisReportRequest) (*schemas.CRMCustomerAnalysisReportResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -2128,27 +2221,29 @@ func (s *crmService) GetCustomerAnalysisReport(req schemas.CustomerAnalysisRepor
 	}
 
 	// 构建客户分析报表数据
-	report := make(map[string]interface{})
-	report["total_customers"] = len(accounts)
+	report := &schemas.CRMCustomerAnalysisReportResponse{
+		Period:         reportPeriod(req.StartDate, req.EndDate),
+		TotalCustomers: len(accounts),
+	}
 
 	// 按行业分组
 	industryMap := make(map[string]int)
 	for _, account := range accounts {
 		industryMap[account.Industry]++
 	}
-	report["industry_breakdown"] = industryMap
+	report.IndustryBreakdown = industryMap
 
 	// 按状态分组
 	statusMap := make(map[string]int)
 	for _, account := range accounts {
 		statusMap[account.Status]++
 	}
-	report["status_breakdown"] = statusMap
+	report.StatusBreakdown = statusMap
 
 	return report, nil
 }
 
-func (s *crmService) GetActivitySummaryReport(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *crmService) GetActivitySummaryReport(req schemas.CRMReportRequest) (*schemas.ActivitySummaryReportResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -2162,27 +2257,29 @@ func (s *crmService) GetActivitySummaryReport(req map[string]interface{}) (map[s
 	}
 
 	// 构建活动汇总报表数据
-	report := make(map[string]interface{})
-	report["total_activities"] = len(activities)
+	report := &schemas.ActivitySummaryReportResponse{
+		Period:          reportPeriod(req.StartDate, req.EndDate),
+		TotalActivities: len(activities),
+	}
 
 	// 按类型分组
 	typeMap := make(map[string]int)
 	for _, activity := range activities {
 		typeMap[activity.Type]++
 	}
-	report["activity_types"] = typeMap
+	report.ActivityTypes = typeMap
 
 	// 按状态分组
 	statusMap := make(map[string]int)
 	for _, activity := range activities {
 		statusMap[activity.Status]++
 	}
-	report["status_breakdown"] = statusMap
+	report.StatusBreakdown = statusMap
 
 	return report, nil
 }
 
-func (s *crmService) GetCampaignEffectivenessReport(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *crmService) GetCampaignEffectivenessReport(req schemas.CRMReportRequest) (*schemas.CampaignEffectivenessReportResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -2196,8 +2293,10 @@ func (s *crmService) GetCampaignEffectivenessReport(req map[string]interface{})
 	}
 
 	// 构建营销活动效果报表数据
-	report := make(map[string]interface{})
-	report["total_campaigns"] = len(campaigns)
+	report := &schemas.CampaignEffectivenessReportResponse{
+		Period:         reportPeriod(req.StartDate, req.EndDate),
+		TotalCampaigns: len(campaigns),
+	}
 
 	// 计算总预算和实际成本
 	totalBudget := 0.0
@@ -2206,21 +2305,21 @@ func (s *crmService) GetCampaignEffectivenessReport(req map[string]interface{})
 		totalBudget += campaign.Budget
 		totalActualCost += campaign.ActualCost
 	}
-	report["total_budget"] = totalBudget
-	report["total_actual_cost"] = totalActualCost
-	report["cost_efficiency"] = totalBudget / (totalActualCost + 1) // 避免除以零
+	report.TotalBudget = totalBudget
+	report.TotalActualCost = totalActualCost
+	report.CostEfficiency = totalBudget / (totalActualCost + 1) // 避免除以零
 
 	// 按状态分组
 	statusMap := make(map[string]int)
 	for _, campaign := range campaigns {
 		statusMap[campaign.Status]++
 	}
-	report["status_breakdown"] = statusMap
+	report.StatusBreakdown = statusMap
 
 	return report, nil
 }
 
-func (s *crmService) GetServicePerformanceReport(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *crmService) GetServicePerformanceReport(req schemas.CRMReportRequest) (*schemas.ServicePerformanceReportResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -2234,27 +2333,29 @@ func (s *crmService) GetServicePerformanceReport(req map[string]interface{}) (ma
 	}
 
 	// 构建服务绩效报表数据
-	report := make(map[string]interface{})
-	report["total_service_requests"] = len(cases)
+	report := &schemas.ServicePerformanceReportResponse{
+		Period:               reportPeriod(req.StartDate, req.EndDate),
+		TotalServiceRequests: len(cases),
+	}
 
 	// 按状态分组
 	statusMap := make(map[string]int)
 	for _, caseItem := range cases {
 		statusMap[caseItem.Status]++
 	}
-	report["status_breakdown"] = statusMap
+	report.StatusBreakdown = statusMap
 
 	// 按优先级分组
 	priorityMap := make(map[string]int)
 	for _, caseItem := range cases {
 		priorityMap[caseItem.Priority]++
 	}
-	report["priority_breakdown"] = priorityMap
+	report.PriorityBreakdown = priorityMap
 
 	return report, nil
 }
 
-func (s *crmService) ExportCRMReport(req map[string]interface{}) ([]byte, error) {
+func (s *crmService) ExportCRMReport(req schemas.ExportCRMReportRequest) ([]byte, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
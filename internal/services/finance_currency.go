@@ -0,0 +1,756 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/config"
+	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/query"
+	"github.com/wu136995/ginx/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 期末汇兑重估的记账单据类型及金额字段，receivable为应收重估差额（正数为应收增值），
+// payable为应付重估差额（正数为应付增加），记账规则按差额为正的方向配置借贷科目
+const (
+	postingDocumentFXRevaluation = "fx_revaluation"
+	fxRevaluationEvent           = "revalue"
+)
+
+// 汇率来源
+const (
+	exchangeRateSourceManual = "manual"
+	exchangeRateSourceImport = "import"
+)
+
+// ErrExchangeRateNotFound 外币在单据日期及之前没有维护汇率
+var ErrExchangeRateNotFound = errors.New("no exchange rate found for currency")
+
+// baseCurrency 返回配置的本位币代码，未配置时为CNY
+func baseCurrency() string {
+	if code := config.GetAppConfig().Finance.BaseCurrency; code != "" {
+		return strings.ToUpper(code)
+	}
+	return "CNY"
+}
+
+// exchangeRate 取外币在指定日期及之前最近一天的汇率，本位币汇率为1
+func exchangeRate(db *gorm.DB, currency string, date time.Time) (float64, error) {
+	currency = strings.ToUpper(currency)
+	if currency == "" || currency == baseCurrency() {
+		return 1, nil
+	}
+
+	var rates []models.FinanceExchangeRate
+	result := db.Where("currency_code = ? AND rate_date <= ?", currency, date.Format("2006-01-02")).
+		Order("rate_date DESC").
+		Limit(1).
+		Find(&rates)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if len(rates) == 0 {
+		return 0, fmt.Errorf("%w %s on %s", ErrExchangeRateNotFound, currency, date.Format("2006-01-02"))
+	}
+	return rates[0].Rate, nil
+}
+
+// documentCurrency 确定单据币种和汇率，币种为空时取默认币种，再为空时取本位币；
+// 外币需在币种表中启用，指定汇率时使用指定汇率，否则按单据日期取汇率
+func documentCurrency(db *gorm.DB, currency, fallback string, override float64, date time.Time) (string, float64, error) {
+	if currency == "" {
+		currency = fallback
+	}
+	currency = strings.ToUpper(currency)
+	if currency == "" || currency == baseCurrency() {
+		return baseCurrency(), 1, nil
+	}
+
+	var master models.FinanceCurrency
+	result := db.Where("code = ?", currency).Limit(1).Find(&master)
+	if result.Error != nil {
+		return "", 0, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return "", 0, fmt.Errorf("currency %s not found", currency)
+	}
+	if master.Status != "active" {
+		return "", 0, fmt.Errorf("currency %s is inactive", currency)
+	}
+
+	if override > 0 {
+		return currency, override, nil
+	}
+	rate, err := exchangeRate(db, currency, date)
+	if err != nil {
+		return "", 0, err
+	}
+	return currency, rate, nil
+}
+
+// toBaseAmount 按汇率将原币金额折算为本位币，汇率未设置的历史单据按1折算
+func toBaseAmount(amount, rate float64) float64 {
+	if rate <= 0 {
+		rate = 1
+	}
+	return roundAmount(amount * rate)
+}
+
+// settledBaseAmount 按累计核销的原币金额比例分摊单据的本位币金额，返回本次核销amount对应的本位币金额；
+// previous为此前已核销的原币金额。全部核销时取剩余本位币金额，分次核销的本位币金额之和等于单据本位币金额
+func settledBaseAmount(baseTotal, total, previous, amount float64) float64 {
+	share := func(settled float64) float64 {
+		if total <= 0 || roundAmount(settled) >= total {
+			return baseTotal
+		}
+		return roundAmount(baseTotal * settled / total)
+	}
+	return roundAmount(share(previous+amount) - share(previous))
+}
+
+// postRealizedExchange 生成收付款核销的已实现汇兑损益凭证，差额为零时不生成；有差额但未配置记账规则时核销失败，
+// 避免应收应付在本位币上留下余额
+func postRealizedExchange(tx *gorm.DB, document postingDocument) error {
+	if roundAmount(document.Amounts["exchange"]) == 0 {
+		return nil
+	}
+	journal, err := postDocument(tx, document)
+	if err != nil {
+		return err
+	}
+	if journal == nil {
+		return fmt.Errorf("no posting rules configured for %s %s", document.DocumentType, document.Event)
+	}
+	return nil
+}
+
+// currencyListSpec 币种列表查询白名单
+var currencyListSpec = query.NewSpec("code",
+	query.Text("code"),
+	query.Text("name"),
+	query.Text("status"),
+)
+
+// 币种管理方法
+func (s *financeService) GetCurrencyList(params query.Params) (*query.Page[schemas.CurrencyResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取币种
+	var currencies []models.FinanceCurrency
+	total, err := query.Find(s.db, params, currencyListSpec, &currencies)
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	responses := make([]schemas.CurrencyResponse, len(currencies))
+	for i, currency := range currencies {
+		responses[i] = currencyResponse(currency)
+	}
+
+	return query.NewPage(responses, total, params), nil
+}
+
+func (s *financeService) CreateCurrency(req schemas.CurrencyCreateRequest) (*schemas.CurrencyResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 创建币种模型，币种代码统一为大写
+	currency := models.FinanceCurrency{
+		ID:        utils.GenerateID(),
+		Code:      strings.ToUpper(req.Code),
+		Name:      req.Name,
+		Symbol:    req.Symbol,
+		Status:    req.Status,
+		CreatedBy: req.CreatedBy,
+		UpdatedBy: req.CreatedBy,
+	}
+	if currency.Status == "" {
+		currency.Status = "active"
+	}
+
+	// 保存到数据库
+	result := s.db.Create(&currency)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := currencyResponse(currency)
+	return &response, nil
+}
+
+func (s *financeService) UpdateCurrency(id string, req schemas.CurrencyUpdateRequest) (*schemas.CurrencyResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取币种
+	var currency models.FinanceCurrency
+	result := s.db.First(&currency, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 更新字段，币种代码创建后不可修改，本位币不能停用
+	if req.Name != "" {
+		currency.Name = req.Name
+	}
+	if req.Symbol != "" {
+		currency.Symbol = req.Symbol
+	}
+	if req.Status != "" {
+		if req.Status != "active" && currency.Code == baseCurrency() {
+			return nil, errors.New("base currency cannot be deactivated")
+		}
+		currency.Status = req.Status
+	}
+	currency.UpdatedBy = req.UpdatedBy
+
+	// 保存到数据库
+	result = s.db.Save(&currency)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := currencyResponse(currency)
+	return &response, nil
+}
+
+func (s *financeService) DeleteCurrency(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 从数据库读取币种
+	var currency models.FinanceCurrency
+	result := s.db.First(&currency, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// 本位币及已维护汇率的币种不能删除
+	if currency.Code == baseCurrency() {
+		return errors.New("base currency cannot be deleted")
+	}
+	var count int64
+	if err := s.db.Model(&models.FinanceExchangeRate{}).Where("currency_code = ?", currency.Code).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("currency has exchange rates")
+	}
+
+	return s.db.Delete(&currency).Error
+}
+
+// exchangeRateListSpec 汇率列表查询白名单
+var exchangeRateListSpec = query.NewSpec("currency_code,-rate_date",
+	query.Text("currency_code"),
+	query.Date("rate_date"),
+	query.Number("rate"),
+	query.Text("source"),
+)
+
+// 汇率管理方法
+func (s *financeService) GetExchangeRateList(params query.Params) (*query.Page[schemas.ExchangeRateResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取汇率
+	var rates []models.FinanceExchangeRate
+	total, err := query.Find(s.db, params, exchangeRateListSpec, &rates)
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	responses := make([]schemas.ExchangeRateResponse, len(rates))
+	for i, rate := range rates {
+		responses[i] = exchangeRateResponse(rate)
+	}
+
+	return query.NewPage(responses, total, params), nil
+}
+
+func (s *financeService) CreateExchangeRate(req schemas.ExchangeRateCreateRequest) (*schemas.ExchangeRateResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	rate, err := newExchangeRate(req.CurrencyCode, req.RateDate, req.Rate, exchangeRateSourceManual, req.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// 同一币种同一日期的汇率已存在时覆盖
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkForeignCurrencies(tx, []string{rate.CurrencyCode}); err != nil {
+			return err
+		}
+		return saveExchangeRates(tx, []models.FinanceExchangeRate{rate})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var saved models.FinanceExchangeRate
+	result := s.db.Where("currency_code = ? AND rate_date = ?", rate.CurrencyCode, rate.RateDate.Format("2006-01-02")).First(&saved)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := exchangeRateResponse(saved)
+	return &response, nil
+}
+
+func (s *financeService) DeleteExchangeRate(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 从数据库删除汇率，已生成单据的汇率已保存在单据中不受影响
+	result := s.db.Delete(&models.FinanceExchangeRate{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+// ImportExchangeRates 从CSV导入汇率，列依次为币种代码、日期(2006-01-02)、汇率，首行为表头时跳过；
+// 任一行校验失败时整批不导入，已存在的同日汇率被覆盖
+func (s *financeService) ImportExchangeRates(reader io.Reader, createdBy string) (*schemas.ExchangeRateImportResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = 3
+	csvReader.TrimLeadingSpace = true
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) > 0 && strings.EqualFold(strings.TrimSpace(records[0][0]), "currency") {
+		records = records[1:]
+	}
+	if len(records) == 0 {
+		return nil, errors.New("exchange rate file is empty")
+	}
+
+	rates := make([]models.FinanceExchangeRate, 0, len(records))
+	currencies := make([]string, 0)
+	seenCurrencies := make(map[string]bool)
+	seenDates := make(map[string]bool)
+	for i, record := range records {
+		value, err := strconv.ParseFloat(strings.TrimSpace(record[2]), 64)
+		if err != nil || value <= 0 {
+			return nil, fmt.Errorf("line %d: invalid rate %q", i+1, record[2])
+		}
+		rate, err := newExchangeRate(record[0], record[1], value, exchangeRateSourceImport, createdBy)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		key := rate.CurrencyCode + " " + rate.RateDate.Format("2006-01-02")
+		if seenDates[key] {
+			return nil, fmt.Errorf("line %d: duplicate rate for %s", i+1, key)
+		}
+		seenDates[key] = true
+		if !seenCurrencies[rate.CurrencyCode] {
+			seenCurrencies[rate.CurrencyCode] = true
+			currencies = append(currencies, rate.CurrencyCode)
+		}
+		rates = append(rates, rate)
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkForeignCurrencies(tx, currencies); err != nil {
+			return err
+		}
+		return saveExchangeRates(tx, rates)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &schemas.ExchangeRateImportResponse{Imported: len(rates)}, nil
+}
+
+// fxRevaluationListSpec 汇兑重估列表查询白名单
+var fxRevaluationListSpec = query.NewSpec("-revaluation_date",
+	query.Text("revaluation_no"),
+	query.Text("period_id"),
+	query.Date("revaluation_date"),
+)
+
+// 期末汇兑重估方法
+func (s *financeService) GetFXRevaluationList(params query.Params) (*query.Page[schemas.FXRevaluationResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取汇兑重估及明细
+	var revaluations []models.FinanceFXRevaluation
+	total, err := query.Find(s.db, params, fxRevaluationListSpec, &revaluations, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Period").Preload("Items")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	responses := make([]schemas.FXRevaluationResponse, len(revaluations))
+	for i, revaluation := range revaluations {
+		responses[i] = fxRevaluationResponse(revaluation)
+	}
+
+	return query.NewPage(responses, total, params), nil
+}
+
+// RevaluePeriod 按期末汇率重估期末未结清的外币应收应付，重估凭证记在期末日，并于下期首日自动冲回；
+// 冲回后应收应付恢复为按原始汇率入账的账面金额，下期收付款按原始汇率冲减并确认已实现汇兑损益，
+// 重估日及之前的外币收付款不能再核销
+func (s *financeService) RevaluePeriod(periodID string, req schemas.FXRevaluationRequest) (*schemas.FXRevaluationResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	createdBy := req.CreatedBy
+	if createdBy == "" {
+		createdBy = "system"
+	}
+
+	var revaluationID string
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// 锁定会计期间，同一期间只能重估一次
+		var period models.FinancePeriod
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&period, "id = ?", periodID).Error; err != nil {
+			return err
+		}
+		if period.Status == periodStatusClosed {
+			return fmt.Errorf("accounting period %s is closed", period.Name)
+		}
+		var count int64
+		if err := tx.Model(&models.FinanceFXRevaluation{}).Where("period_id = ?", period.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("accounting period %s has already been revalued", period.Name)
+		}
+
+		items, err := fxRevaluationItems(tx, period.EndDate)
+		if err != nil {
+			return err
+		}
+
+		revaluation := models.FinanceFXRevaluation{
+			ID:              utils.GenerateID(),
+			RevaluationNo:   autoJournalNo("FX"),
+			PeriodID:        period.ID,
+			RevaluationDate: period.EndDate,
+			CreatedBy:       createdBy,
+			UpdatedBy:       createdBy,
+		}
+		for i := range items {
+			items[i].ID = utils.GenerateID()
+			items[i].RevaluationID = revaluation.ID
+			items[i].CreatedBy = createdBy
+			items[i].UpdatedBy = createdBy
+			if items[i].DocumentType == postingDocumentSalesInvoice {
+				revaluation.ReceivableAmount += items[i].Difference
+			} else {
+				revaluation.PayableAmount += items[i].Difference
+			}
+		}
+		revaluation.ReceivableAmount = roundAmount(revaluation.ReceivableAmount)
+		revaluation.PayableAmount = roundAmount(revaluation.PayableAmount)
+
+		// 生成期末重估凭证，有差额但未配置记账规则时不允许重估
+		journal, err := postDocument(tx, postingDocument{
+			DocumentType: postingDocumentFXRevaluation,
+			Event:        fxRevaluationEvent,
+			ReferenceID:  revaluation.ID,
+			DocumentNo:   revaluation.RevaluationNo,
+			Date:         period.EndDate,
+			Description:  fmt.Sprintf("%s期末汇兑重估", period.Name),
+			Amounts: map[string]float64{
+				"receivable": revaluation.ReceivableAmount,
+				"payable":    revaluation.PayableAmount,
+			},
+			CreatedBy: createdBy,
+		})
+		if err != nil {
+			return err
+		}
+		if journal == nil && (revaluation.ReceivableAmount != 0 || revaluation.PayableAmount != 0) {
+			return errors.New("no posting rules configured for fx_revaluation")
+		}
+
+		// 重估凭证于下期首日冲回
+		if journal != nil {
+			revaluation.JournalID = journal.ID
+			reversal, err := reverseJournal(tx, *journal, autoJournalNo("RV"), period.EndDate.AddDate(0, 0, 1),
+				postingDocumentFXRevaluation, revaluation.ID, createdBy)
+			if err != nil {
+				return err
+			}
+			revaluation.ReversalJournalID = reversal.ID
+		}
+
+		if err := tx.Omit("Items").Create(&revaluation).Error; err != nil {
+			return err
+		}
+		if len(items) > 0 {
+			if err := tx.Create(&items).Error; err != nil {
+				return err
+			}
+		}
+		revaluationID = revaluation.ID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 读取重估结果及明细
+	var revaluation models.FinanceFXRevaluation
+	result := s.db.Preload("Period").Preload("Items").First(&revaluation, "id = ?", revaluationID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := fxRevaluationResponse(revaluation)
+	return &response, nil
+}
+
+// fxRevaluationItems 计算截至重估日未结清外币发票的汇兑差额，销售发票计入应收，已审核采购发票计入应付
+func fxRevaluationItems(db *gorm.DB, date time.Time) ([]models.FinanceFXRevaluationItem, error) {
+	day := date.Format("2006-01-02")
+	base := baseCurrency()
+	rates := make(map[string]float64)
+	closingRate := func(currency string) (float64, error) {
+		if rate, ok := rates[currency]; ok {
+			return rate, nil
+		}
+		rate, err := exchangeRate(db, currency, date)
+		if err != nil {
+			return 0, err
+		}
+		rates[currency] = rate
+		return rate, nil
+	}
+
+	var items []models.FinanceFXRevaluationItem
+	add := func(documentType, documentID, documentNo, currency string, total, open, originalRate float64) error {
+		if open <= 0 {
+			return nil
+		}
+		rate, err := closingRate(currency)
+		if err != nil {
+			return err
+		}
+		item := fxRevaluationItem(total, open, originalRate, rate)
+		item.DocumentType, item.DocumentID, item.DocumentNo, item.Currency = documentType, documentID, documentNo, currency
+		if item.Difference != 0 {
+			items = append(items, item)
+		}
+		return nil
+	}
+
+	var salesInvoices []models.SalesInvoice
+	result := db.Where("currency <> '' AND currency <> ? AND invoice_date <= ? AND status IN ?", base, day, []string{"unpaid", "partially_paid"}).
+		Order("invoice_date ASC, invoice_no ASC").
+		Find(&salesInvoices)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	for _, invoice := range salesInvoices {
+		if err := add(postingDocumentSalesInvoice, invoice.ID, invoice.InvoiceNo, invoice.Currency,
+			invoice.TotalAmount, salesInvoiceBalance(invoice), invoice.ExchangeRate); err != nil {
+			return nil, err
+		}
+	}
+
+	var purchaseInvoices []models.PurchaseInvoice
+	result = db.Where("currency <> '' AND currency <> ? AND invoice_date <= ? AND status IN ?", base, day, payableInvoiceStatuses).
+		Order("invoice_date ASC, invoice_no ASC").
+		Find(&purchaseInvoices)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	for _, invoice := range purchaseInvoices {
+		if err := add(postingDocumentPurchaseInvoice, invoice.ID, invoice.InvoiceNo, invoice.Currency,
+			invoice.TotalAmount, purchaseInvoiceBalance(invoice), invoice.ExchangeRate); err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
+
+// fxRevaluationItem 计算一张外币发票的重估差额：账面金额为发票入账本位币金额中尚未被收付款冲减的部分，
+// 与收付款核销按比例冲减的口径一致；重估金额为未结清原币金额按期末汇率折算
+func fxRevaluationItem(total, open, originalRate, rate float64) models.FinanceFXRevaluationItem {
+	invoiceBase := toBaseAmount(total, originalRate)
+	item := models.FinanceFXRevaluationItem{
+		OpenAmount:      roundAmount(open),
+		OriginalRate:    originalRate,
+		RevaluationRate: rate,
+		CarryingAmount:  roundAmount(invoiceBase - settledBaseAmount(invoiceBase, total, 0, total-open)),
+		RevaluedAmount:  toBaseAmount(open, rate),
+	}
+	item.Difference = roundAmount(item.RevaluedAmount - item.CarryingAmount)
+	return item
+}
+
+// checkSettlementRevaluation 外币收付款的核销日期不能早于或等于已执行的期末汇兑重估日期：
+// 重估按执行时的未结清金额计算并于下期首日冲回，补录重估期内的核销会使该期末应收应付重复包含汇兑差额
+func checkSettlementRevaluation(db *gorm.DB, currency string, date time.Time) error {
+	if currency == "" || currency == baseCurrency() {
+		return nil
+	}
+	var revaluation models.FinanceFXRevaluation
+	result := db.Where("revaluation_date >= ?", date.Format("2006-01-02")).
+		Order("revaluation_date DESC").
+		Limit(1).
+		Find(&revaluation)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return fmt.Errorf("settlement date %s is not after fx revaluation %s on %s",
+			date.Format("2006-01-02"), revaluation.RevaluationNo, revaluation.RevaluationDate.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// newExchangeRate 校验并构建汇率模型，本位币不需要维护汇率
+func newExchangeRate(currencyCode, rateDate string, value float64, source, operator string) (models.FinanceExchangeRate, error) {
+	code := strings.ToUpper(strings.TrimSpace(currencyCode))
+	if len(code) != 3 {
+		return models.FinanceExchangeRate{}, fmt.Errorf("invalid currency code %q", currencyCode)
+	}
+	if code == baseCurrency() {
+		return models.FinanceExchangeRate{}, fmt.Errorf("base currency %s does not need exchange rates", code)
+	}
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(rateDate))
+	if err != nil {
+		return models.FinanceExchangeRate{}, err
+	}
+	return models.FinanceExchangeRate{
+		ID:           utils.GenerateID(),
+		CurrencyCode: code,
+		RateDate:     date,
+		Rate:         value,
+		Source:       source,
+		CreatedBy:    operator,
+		UpdatedBy:    operator,
+	}, nil
+}
+
+// checkForeignCurrencies 校验币种均已在币种表中维护
+func checkForeignCurrencies(db *gorm.DB, codes []string) error {
+	var currencies []models.FinanceCurrency
+	if err := db.Where("code IN ?", codes).Find(&currencies).Error; err != nil {
+		return err
+	}
+	found := make(map[string]bool, len(currencies))
+	for _, currency := range currencies {
+		found[currency.Code] = true
+	}
+	for _, code := range codes {
+		if !found[code] {
+			return fmt.Errorf("currency %s not found", code)
+		}
+	}
+	return nil
+}
+
+// saveExchangeRates 保存汇率，同一币种同一日期已存在时覆盖汇率和来源
+func saveExchangeRates(tx *gorm.DB, rates []models.FinanceExchangeRate) error {
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "currency_code"}, {Name: "rate_date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "source", "updated_by", "updated_at", "deleted_at"}),
+	}).Create(&rates).Error
+}
+
+// currencyResponse 将币种模型转换为响应格式
+func currencyResponse(currency models.FinanceCurrency) schemas.CurrencyResponse {
+	return schemas.CurrencyResponse{
+		ID:        currency.ID,
+		Code:      currency.Code,
+		Name:      currency.Name,
+		Symbol:    currency.Symbol,
+		IsBase:    currency.Code == baseCurrency(),
+		Status:    currency.Status,
+		CreatedAt: currency.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: currency.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+// exchangeRateResponse 将汇率模型转换为响应格式
+func exchangeRateResponse(rate models.FinanceExchangeRate) schemas.ExchangeRateResponse {
+	return schemas.ExchangeRateResponse{
+		ID:           rate.ID,
+		CurrencyCode: rate.CurrencyCode,
+		RateDate:     rate.RateDate.Format("2006-01-02"),
+		Rate:         rate.Rate,
+		Source:       rate.Source,
+		CreatedAt:    rate.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:    rate.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+// fxRevaluationResponse 将汇兑重估模型转换为响应格式，明细已预加载时一并转换
+func fxRevaluationResponse(revaluation models.FinanceFXRevaluation) schemas.FXRevaluationResponse {
+	response := schemas.FXRevaluationResponse{
+		ID:                revaluation.ID,
+		RevaluationNo:     revaluation.RevaluationNo,
+		PeriodID:          revaluation.PeriodID,
+		PeriodName:        revaluation.Period.Name,
+		RevaluationDate:   revaluation.RevaluationDate.Format("2006-01-02"),
+		ReceivableAmount:  revaluation.ReceivableAmount,
+		PayableAmount:     revaluation.PayableAmount,
+		JournalID:         revaluation.JournalID,
+		ReversalJournalID: revaluation.ReversalJournalID,
+		Items:             make([]schemas.FXRevaluationItemResponse, len(revaluation.Items)),
+		CreatedAt:         revaluation.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	for i, item := range revaluation.Items {
+		response.Items[i] = schemas.FXRevaluationItemResponse{
+			DocumentType:    item.DocumentType,
+			DocumentID:      item.DocumentID,
+			DocumentNo:      item.DocumentNo,
+			Currency:        item.Currency,
+			OpenAmount:      item.OpenAmount,
+			OriginalRate:    item.OriginalRate,
+			RevaluationRate: item.RevaluationRate,
+			CarryingAmount:  item.CarryingAmount,
+			RevaluedAmount:  item.RevaluedAmount,
+			Difference:      item.Difference,
+		}
+	}
+	return response
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/wu136995/ginx/internal/models"
+)
+
+// TestRevaluationThenSettlement 测试期末重估并于下期首日冲回后，下期付款按原始汇率冲减应付，
+// 应付结清且汇兑损益合计等于付款与入账本位币金额之差
+func TestRevaluationThenSettlement(t *testing.T) {
+	// 发票1000美元，入账汇率7.0，应付7000；期末前按7.2付款400美元
+	invoice := models.PurchaseInvoice{InvoiceNo: "PI001", TotalAmount: 1000, Currency: "USD", ExchangeRate: 7.0, Status: "verified"}
+	payable := toBaseAmount(invoice.TotalAmount, invoice.ExchangeRate)
+	var loss float64
+
+	pay := func(payment models.FinancePayment, allocation models.FinancePaymentAllocation) {
+		amount, discount, gain := paymentSettlement(payment, 0, invoice, allocation)
+		payable = roundAmount(payable - amount - discount - gain)
+		loss = roundAmount(loss - gain)
+		invoice.PaidAmount = roundAmount(invoice.PaidAmount + allocation.Amount)
+		invoice.DiscountAmount = roundAmount(invoice.DiscountAmount + allocation.DiscountAmount)
+		settlePurchaseInvoice(&invoice)
+	}
+	pay(models.FinancePayment{Amount: 400, ExchangeRate: 7.2, BaseAmount: 2880}, models.FinancePaymentAllocation{Amount: 400})
+
+	// 期末按7.1重估未结清的600美元，账面金额为剩余入账金额
+	item := fxRevaluationItem(invoice.TotalAmount, purchaseInvoiceBalance(invoice), invoice.ExchangeRate, 7.1)
+	if item.CarryingAmount != payable {
+		t.Fatalf("Expected carrying amount %.2f to equal payable balance, got %.2f", payable, item.CarryingAmount)
+	}
+	if item.Difference != 60 {
+		t.Errorf("Expected revaluation difference 60, got %.2f", item.Difference)
+	}
+	payable = roundAmount(payable + item.Difference)
+	loss = roundAmount(loss + item.Difference)
+
+	// 下期首日冲回重估
+	payable = roundAmount(payable - item.Difference)
+	loss = roundAmount(loss - item.Difference)
+
+	// 下期按7.3付清余额
+	pay(models.FinancePayment{Amount: 600, ExchangeRate: 7.3, BaseAmount: 4380}, models.FinancePaymentAllocation{Amount: 600})
+
+	if invoice.Status != "paid" {
+		t.Errorf("Expected invoice paid, got %s", invoice.Status)
+	}
+	if payable != 0 {
+		t.Errorf("Expected payable balance 0 after revaluation and settlement, got %.2f", payable)
+	}
+	if loss != roundAmount(2880+4380-7000) {
+		t.Errorf("Expected total exchange loss %.2f, got %.2f", roundAmount(2880+4380-7000), loss)
+	}
+}
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/utils"
+	"gorm.io/gorm"
+)
+
+// 凭证状态
+const (
+	journalStatusDraft     = "draft"
+	journalStatusSubmitted = "submitted"
+	journalStatusApproved  = "approved"
+	journalStatusPosted    = "posted"
+	journalStatusRejected  = "rejected"
+)
+
+// accountActivity 科目借贷发生额
+type accountActivity struct {
+	AccountID string
+	Debit     float64
+	Credit    float64
+}
+
+// ledgerEntry 已过账分录明细
+type ledgerEntry struct {
+	AccountID          string
+	JournalID          string
+	JournalNo          string
+	Date               time.Time
+	JournalDescription string
+	Description        string
+	Debit              float64
+	Credit             float64
+}
+
+// validateJournalLines 校验凭证分录，每行只能记借方或贷方，且借贷合计必须相等
+func validateJournalLines(items []models.FinanceJournalItem) (float64, float64, error) {
+	if len(items) < 2 {
+		return 0, 0, errors.New("voucher requires at least two lines")
+	}
+
+	var totalDebit, totalCredit float64
+	for _, item := range items {
+		if item.AccountID == "" {
+			return 0, 0, fmt.Errorf("line %d: account is required", item.LineNo)
+		}
+		if item.Debit < 0 || item.Credit < 0 {
+			return 0, 0, fmt.Errorf("line %d: amounts must not be negative", item.LineNo)
+		}
+		if item.Debit > 0 && item.Credit > 0 {
+			return 0, 0, fmt.Errorf("line %d: debit and credit must not both be set", item.LineNo)
+		}
+		if item.Debit == 0 && item.Credit == 0 {
+			return 0, 0, fmt.Errorf("line %d: debit or credit amount is required", item.LineNo)
+		}
+		totalDebit += item.Debit
+		totalCredit += item.Credit
+	}
+
+	totalDebit = roundAmount(totalDebit)
+	totalCredit = roundAmount(totalCredit)
+	if totalDebit != totalCredit {
+		return 0, 0, fmt.Errorf("voucher is not balanced: debit %.2f, credit %.2f", totalDebit, totalCredit)
+	}
+
+	return totalDebit, totalCredit, nil
+}
+
+// checkJournalAccounts 校验分录引用的会计科目均存在
+func checkJournalAccounts(db *gorm.DB, items []models.FinanceJournalItem) error {
+	accountIDs := make([]string, 0, len(items))
+	seen := make(map[string]bool)
+	for _, item := range items {
+		if !seen[item.AccountID] {
+			seen[item.AccountID] = true
+			accountIDs = append(accountIDs, item.AccountID)
+		}
+	}
+
+	var accounts []models.FinanceAccount
+	if err := db.Where("id IN ?", accountIDs).Find(&accounts).Error; err != nil {
+		return err
+	}
+	found := make(map[string]bool, len(accounts))
+	for _, account := range accounts {
+		found[account.ID] = true
+	}
+	for _, accountID := range accountIDs {
+		if !found[accountID] {
+			return fmt.Errorf("account %s not found", accountID)
+		}
+	}
+
+	return nil
+}
+
+// postedActivity 汇总已过账凭证的科目发生额，日期区间为[from, before)，为nil时不限
+func postedActivity(db *gorm.DB, from, before *time.Time) (map[string]accountActivity, error) {
+	return queryActivity(db, from, before, nil)
+}
+
+// operatingActivity 汇总已过账发生额，排除期末结转凭证，用于计算期间损益
+func operatingActivity(db *gorm.DB, from, before *time.Time) (map[string]accountActivity, error) {
+	return queryActivity(db, from, before, []string{journalReferencePeriodClose, journalReferencePeriodReopen})
+}
+
+func queryActivity(db *gorm.DB, from, before *time.Time, excludeReferenceTypes []string) (map[string]accountActivity, error) {
+	query := db.Table("finance_journal_items AS i").
+		Select("i.account_id AS account_id, SUM(i.debit) AS debit, SUM(i.credit) AS credit").
+		Joins("JOIN finance_journals AS j ON j.id = i.journal_id").
+		Where("j.status = ? AND j.deleted_at IS NULL AND i.deleted_at IS NULL", journalStatusPosted)
+	if from != nil {
+		query = query.Where("j.date >= ?", *from)
+	}
+	if before != nil {
+		query = query.Where("j.date < ?", *before)
+	}
+	if len(excludeReferenceTypes) > 0 {
+		query = query.Where("(j.reference_type IS NULL OR j.reference_type NOT IN ?)", excludeReferenceTypes)
+	}
+
+	var rows []accountActivity
+	if err := query.Group("i.account_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	activity := make(map[string]accountActivity, len(rows))
+	for _, row := range rows {
+		row.Debit = roundAmount(row.Debit)
+		row.Credit = roundAmount(row.Credit)
+		activity[row.AccountID] = row
+	}
+
+	return activity, nil
+}
+
+// createPostedJournal 校验并直接保存已过账的系统凭证，用于期末结转及业务单据自动生成的凭证
+func createPostedJournal(tx *gorm.DB, journal *models.FinanceJournal) error {
+	for i := range journal.Items {
+		journal.Items[i].ID = utils.GenerateID()
+		journal.Items[i].LineNo = i + 1
+		journal.Items[i].Debit = roundAmount(journal.Items[i].Debit)
+		journal.Items[i].Credit = roundAmount(journal.Items[i].Credit)
+		journal.Items[i].CreatedBy = journal.CreatedBy
+		journal.Items[i].UpdatedBy = journal.CreatedBy
+	}
+
+	totalDebit, totalCredit, err := validateJournalLines(journal.Items)
+	if err != nil {
+		return err
+	}
+	if err := checkJournalAccounts(tx, journal.Items); err != nil {
+		return err
+	}
+	if err := checkPeriodOpen(tx, journal.Date, true); err != nil {
+		return err
+	}
+
+	if journal.Currency == "" {
+		journal.Currency = baseCurrency()
+	}
+	if journal.ExchangeRate <= 0 {
+		journal.ExchangeRate = 1
+	}
+
+	now := time.Now()
+	journal.ID = utils.GenerateID()
+	journal.TotalDebit = totalDebit
+	journal.TotalCredit = totalCredit
+	journal.Status = journalStatusPosted
+	journal.PostedBy = journal.CreatedBy
+	journal.PostedAt = &now
+	journal.UpdatedBy = journal.CreatedBy
+
+	return tx.Create(journal).Error
+}
+
+// reverseJournal 生成借贷方向相反的红字冲销凭证，original需已加载分录
+func reverseJournal(tx *gorm.DB, original models.FinanceJournal, journalNo string, date time.Time, referenceType, referenceID, createdBy string) (*models.FinanceJournal, error) {
+	reversal := models.FinanceJournal{
+		JournalNo:     journalNo,
+		Date:          date,
+		Reference:     original.JournalNo,
+		ReferenceType: referenceType,
+		ReferenceID:   referenceID,
+		ReversalOf:    original.ID,
+		Description:   fmt.Sprintf("冲销凭证%s", original.JournalNo),
+		Currency:      original.Currency,
+		ExchangeRate:  original.ExchangeRate,
+		CreatedBy:     createdBy,
+		Items:         make([]models.FinanceJournalItem, len(original.Items)),
+	}
+	for i, item := range original.Items {
+		reversal.Items[i] = models.FinanceJournalItem{
+			AccountID:      item.AccountID,
+			Description:    item.Description,
+			Debit:          item.Credit,
+			Credit:         item.Debit,
+			CurrencyAmount: item.CurrencyAmount,
+		}
+	}
+
+	if err := createPostedJournal(tx, &reversal); err != nil {
+		return nil, err
+	}
+	return &reversal, nil
+}
+
+// postedEntries 读取已过账分录明细，accountID为空时读取全部科目
+func postedEntries(db *gorm.DB, accountID string, from, before *time.Time) ([]ledgerEntry, error) {
+	query := db.Table("finance_journal_items AS i").
+		Select("i.account_id AS account_id, j.id AS journal_id, j.journal_no AS journal_no, j.date AS date, "+
+			"j.description AS journal_description, i.description AS description, i.debit AS debit, i.credit AS credit").
+		Joins("JOIN finance_journals AS j ON j.id = i.journal_id").
+		Where("j.status = ? AND j.deleted_at IS NULL AND i.deleted_at IS NULL", journalStatusPosted)
+	if accountID != "" {
+		query = query.Where("i.account_id = ?", accountID)
+	}
+	if from != nil {
+		query = query.Where("j.date >= ?", *from)
+	}
+	if before != nil {
+		query = query.Where("j.date < ?", *before)
+	}
+
+	var entries []ledgerEntry
+	if err := query.Order("j.date ASC, j.journal_no ASC, i.line_no ASC").Scan(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// parseLedgerPeriod 将起止日期转换为[from, before)区间，结束日期当天包含在内
+func parseLedgerPeriod(startDate, endDate string) (*time.Time, *time.Time, error) {
+	var from, before *time.Time
+	if startDate != "" {
+		date, err := time.Parse("2006-01-02", startDate)
+		if err != nil {
+			return nil, nil, err
+		}
+		from = &date
+	}
+	if endDate != "" {
+		date, err := time.Parse("2006-01-02", endDate)
+		if err != nil {
+			return nil, nil, err
+		}
+		next := date.AddDate(0, 0, 1)
+		before = &next
+	}
+	if from != nil && before != nil && !from.Before(*before) {
+		return nil, nil, errors.New("start date must not be after end date")
+	}
+
+	return from, before, nil
+}
+
+// isDebitNormal 资产和费用类科目余额方向为借方
+func isDebitNormal(accountType string) bool {
+	return accountType == "asset" || accountType == "expense"
+}
+
+// accountBalance 按科目余额方向计算余额
+func accountBalance(accountType string, debit, credit float64) float64 {
+	if isDebitNormal(accountType) {
+		return roundAmount(debit - credit)
+	}
+	return roundAmount(credit - debit)
+}
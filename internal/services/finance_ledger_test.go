@@ -0,0 +1,106 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/wu136995/ginx/internal/models"
+)
+
+// TestValidateJournalLines 测试凭证分录借贷平衡、金额非零且每行只能记借方或贷方
+func TestValidateJournalLines(t *testing.T) {
+	tests := []struct {
+		name       string
+		items      []models.FinanceJournalItem
+		wantDebit  float64
+		wantCredit float64
+		wantErr    bool
+	}{
+		{
+			name: "借贷平衡",
+			items: []models.FinanceJournalItem{
+				{LineNo: 1, AccountID: "1002", Debit: 113},
+				{LineNo: 2, AccountID: "6001", Credit: 100},
+				{LineNo: 3, AccountID: "2221", Credit: 13},
+			},
+			wantDebit:  113,
+			wantCredit: 113,
+		},
+		{
+			name: "合计按金额舍入后平衡",
+			items: []models.FinanceJournalItem{
+				{LineNo: 1, AccountID: "1002", Debit: 0.1},
+				{LineNo: 2, AccountID: "1002", Debit: 0.2},
+				{LineNo: 3, AccountID: "6001", Credit: 0.3},
+			},
+			wantDebit:  0.3,
+			wantCredit: 0.3,
+		},
+		{
+			name: "借贷不平衡",
+			items: []models.FinanceJournalItem{
+				{LineNo: 1, AccountID: "1002", Debit: 100},
+				{LineNo: 2, AccountID: "6001", Credit: 99.99},
+			},
+			wantErr: true,
+		},
+		{
+			name: "只有借方",
+			items: []models.FinanceJournalItem{
+				{LineNo: 1, AccountID: "1002", Debit: 100},
+				{LineNo: 2, AccountID: "6001", Debit: 100},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "只有一行",
+			items:   []models.FinanceJournalItem{{LineNo: 1, AccountID: "1002", Debit: 100}},
+			wantErr: true,
+		},
+		{
+			name: "同一行同时记借方和贷方",
+			items: []models.FinanceJournalItem{
+				{LineNo: 1, AccountID: "1002", Debit: 100, Credit: 100},
+				{LineNo: 2, AccountID: "6001", Debit: 50},
+				{LineNo: 3, AccountID: "6001", Credit: 50},
+			},
+			wantErr: true,
+		},
+		{
+			name: "金额为零",
+			items: []models.FinanceJournalItem{
+				{LineNo: 1, AccountID: "1002", Debit: 100},
+				{LineNo: 2, AccountID: "6001", Credit: 100},
+				{LineNo: 3, AccountID: "6002"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "金额为负数",
+			items: []models.FinanceJournalItem{
+				{LineNo: 1, AccountID: "1002", Debit: -100},
+				{LineNo: 2, AccountID: "6001", Credit: -100},
+			},
+			wantErr: true,
+		},
+		{
+			name: "缺少科目",
+			items: []models.FinanceJournalItem{
+				{LineNo: 1, AccountID: "1002", Debit: 100},
+				{LineNo: 2, Credit: 100},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			debit, credit, err := validateJournalLines(tt.items)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if debit != tt.wantDebit || credit != tt.wantCredit {
+				t.Errorf("Expected debit %.2f credit %.2f, got %.2f %.2f", tt.wantDebit, tt.wantCredit, debit, credit)
+			}
+		})
+	}
+}
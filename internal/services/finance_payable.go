@@ -0,0 +1,712 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/query"
+	"github.com/wu136995/ginx/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 付款单状态，只有已处理的付款冲减发票余额
+const (
+	paymentStatusDraft     = "draft"
+	paymentStatusSubmitted = "submitted"
+	paymentStatusApproved  = "approved"
+	paymentStatusRejected  = "rejected"
+	paymentStatusProcessed = "processed"
+)
+
+// 供应商付款的记账单据类型及事件，pay按付款本位币金额记账（含未核销的预付款），
+// discount按核销时享受的提前付款折扣本位币金额记账，exchange按外币发票核销的已实现汇兑差额记账
+const (
+	postingDocumentPurchasePayment = "purchase_payment"
+	purchasePaymentEventPay        = "pay"
+	purchasePaymentEventDiscount   = "discount"
+	purchasePaymentEventExchange   = "exchange"
+)
+
+// payableInvoiceStatuses 已审核、可以付款的采购发票状态
+var payableInvoiceStatuses = []string{"verified", "partially_paid"}
+
+// paymentDiscountPattern 匹配整个付款条件为“2/10 net 30”“2/10, n/30”形式的提前付款折扣条件，
+// 即自发票日期起10天内付款享受2%折扣、30天到期；“30/60/90”等分期写法不是折扣条件
+var paymentDiscountPattern = regexp.MustCompile(`(?i)^\s*(\d+(?:\.\d+)?)\s*/\s*(\d+)\s*[,，]?\s*(?:net|n\s*/)\s*(\d+)\s*$`)
+
+// paymentDaysPattern 匹配付款条件中的付款期限天数
+var paymentDaysPattern = regexp.MustCompile(`\d+`)
+
+// paymentTerms 解析后的付款条件，天数均自发票日期起算
+type paymentTerms struct {
+	DiscountRate float64 // 提前付款折扣百分比
+	DiscountDays int     // 可享受折扣的天数
+	NetDays      int     // 付款期限天数
+}
+
+// parsePaymentTerms 解析付款条件文本，支持“2/10 net 30”“2/10, n/30”“30天内付款”“月结60天”等写法，
+// 其他写法取第一个数字为付款期限天数；没有天数时返回false，由调用方按默认期限处理
+func parsePaymentTerms(text string) (paymentTerms, bool) {
+	var terms paymentTerms
+	if match := paymentDiscountPattern.FindStringSubmatch(text); match != nil {
+		rate, _ := strconv.ParseFloat(match[1], 64)
+		discountDays, _ := strconv.Atoi(match[2])
+		terms.NetDays, _ = strconv.Atoi(match[3])
+		if rate > 0 && rate < 100 && discountDays <= terms.NetDays {
+			terms.DiscountRate, terms.DiscountDays = rate, discountDays
+		}
+		return terms, true
+	}
+	days := paymentDaysPattern.FindString(text)
+	if days == "" {
+		return terms, false
+	}
+	terms.NetDays, _ = strconv.Atoi(days)
+	return terms, true
+}
+
+// paymentTermsDueDate 按付款条件计算发票到期日，付款条件无法识别时默认一个月
+func paymentTermsDueDate(invoiceDate time.Time, text string) time.Time {
+	if terms, ok := parsePaymentTerms(text); ok {
+		return invoiceDate.AddDate(0, 0, terms.NetDays)
+	}
+	return invoiceDate.AddDate(0, 1, 0)
+}
+
+// invoiceEarlyDiscount 按付款条件计算采购发票在付款日可享受的提前付款折扣及折扣截止日期，折扣按发票余额计算；
+// 付款条件没有折扣时截止日期为nil，已享受过折扣或付款日晚于截止日期时折扣为0
+func invoiceEarlyDiscount(invoice models.PurchaseInvoice, text string, date time.Time) (float64, *time.Time) {
+	terms, _ := parsePaymentTerms(text)
+	if terms.DiscountRate <= 0 {
+		return 0, nil
+	}
+	deadline := invoice.InvoiceDate.AddDate(0, 0, terms.DiscountDays)
+	if invoice.DiscountAmount > 0 || date.Format("2006-01-02") > deadline.Format("2006-01-02") {
+		return 0, &deadline
+	}
+	return roundAmount(purchaseInvoiceBalance(invoice) * terms.DiscountRate / 100), &deadline
+}
+
+// purchaseInvoiceBalance 采购发票未结清金额，已付款和已享受的提前付款折扣均冲减余额
+func purchaseInvoiceBalance(invoice models.PurchaseInvoice) float64 {
+	return roundAmount(invoice.TotalAmount - invoice.PaidAmount - invoice.DiscountAmount)
+}
+
+// paymentSettlement 计算付款核销一张采购发票的本位币金额：amount为按付款单本位币金额分摊的付款金额，
+// discount为按发票入账金额分摊的提前付款折扣，exchange为冲减的应付账面金额与付款本位币金额之差，正数为汇兑收益。
+// 应付按发票入账汇率冲减，三者之和等于本次冲减的应付账面金额；applied为付款单此前已核销的原币金额，invoice为核销前的发票
+func paymentSettlement(payment models.FinancePayment, applied float64, invoice models.PurchaseInvoice, allocation models.FinancePaymentAllocation) (amount, discount, exchange float64) {
+	invoiceBase := toBaseAmount(invoice.TotalAmount, invoice.ExchangeRate)
+	previous := roundAmount(invoice.TotalAmount - purchaseInvoiceBalance(invoice))
+	carrying := settledBaseAmount(invoiceBase, invoice.TotalAmount, previous, allocation.Amount)
+	amount = settledBaseAmount(payment.BaseAmount, payment.Amount, applied, allocation.Amount)
+	discount = settledBaseAmount(invoiceBase, invoice.TotalAmount, previous+allocation.Amount, allocation.DiscountAmount)
+	return amount, discount, roundAmount(carrying - amount)
+}
+
+// settlePurchaseInvoice 按结清金额刷新已审核采购发票的付款状态
+func settlePurchaseInvoice(invoice *models.PurchaseInvoice) {
+	switch {
+	case purchaseInvoiceBalance(*invoice) <= 0:
+		invoice.Status = "paid"
+	case invoice.PaidAmount > 0 || invoice.DiscountAmount > 0:
+		invoice.Status = "partially_paid"
+	default:
+		invoice.Status = "verified"
+	}
+}
+
+// paymentListSpec 付款单列表查询白名单
+var paymentListSpec = query.NewSpec("-date,-code",
+	query.Text("payment_no").As("code"),
+	query.Date("payment_date").As("date"),
+	query.Text("vendor_id").As("supplier_id"),
+	query.Number("amount"),
+	query.Text("currency"),
+	query.Text("payment_method"),
+	query.Text("run_id"),
+	query.Text("status"),
+)
+
+// 付款管理方法
+func (s *financeService) GetPaymentList(params query.Params) (*query.Page[schemas.PaymentResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取付款单及核销明细
+	var payments []models.FinancePayment
+	total, err := query.Find(s.db, params, paymentListSpec, &payments, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Vendor").Preload("Allocations.Invoice")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	responses := make([]schemas.PaymentResponse, len(payments))
+	for i, payment := range payments {
+		responses[i] = paymentResponse(payment)
+	}
+
+	return query.NewPage(responses, total, params), nil
+}
+
+func (s *financeService) GetPaymentDetail(id string) (*schemas.PaymentResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取付款单详情
+	var payment models.FinancePayment
+	result := s.db.Preload("Vendor").Preload("Allocations.Invoice").First(&payment, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := paymentResponse(payment)
+	return &response, nil
+}
+
+func (s *financeService) CreatePayment(req schemas.PaymentCreateRequest) (*schemas.PaymentResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 解析付款日期
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	// 未指定付款单编号时自动生成
+	paymentNo := req.Code
+	if paymentNo == "" {
+		paymentNo = autoJournalNo("PM")
+	}
+
+	payment := models.FinancePayment{
+		ID:            utils.GenerateID(),
+		PaymentNo:     paymentNo,
+		PaymentDate:   date,
+		VendorID:      req.SupplierID,
+		Amount:        roundAmount(req.Amount),
+		PaymentMethod: req.PaymentMethod,
+		BankAccountID: req.BankAccountID,
+		Reference:     req.Reference,
+		Description:   req.Description,
+		Remarks:       req.Remarks,
+		Status:        paymentStatusDraft,
+		CreatedBy:     req.CreatedBy,
+		UpdatedBy:     req.CreatedBy,
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// 币种为空时取供应商默认币种
+		var vendor models.PurchaseVendor
+		if err := tx.First(&vendor, "id = ?", payment.VendorID).Error; err != nil {
+			return err
+		}
+		currency, rate, err := documentCurrency(tx, req.Currency, vendor.Currency, req.ExchangeRate, payment.PaymentDate)
+		if err != nil {
+			return err
+		}
+		payment.Currency, payment.ExchangeRate = currency, rate
+		payment.BaseAmount = toBaseAmount(payment.Amount, rate)
+
+		allocations, err := paymentAllocations(req.Allocations, payment.ID, payment.PaymentDate, req.CreatedBy)
+		if err != nil {
+			return err
+		}
+		if err := checkPaymentAllocations(tx, payment, allocations); err != nil {
+			return err
+		}
+
+		if err := tx.Create(&payment).Error; err != nil {
+			return err
+		}
+		if len(allocations) > 0 {
+			return tx.Omit(clause.Associations).Create(&allocations).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPaymentDetail(payment.ID)
+}
+
+func (s *financeService) UpdatePayment(id string, req schemas.PaymentUpdateRequest) (*schemas.PaymentResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// 从数据库读取付款单
+		var payment models.FinancePayment
+		if err := tx.Preload("Allocations").First(&payment, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		// 只有草稿或已驳回的付款单可以修改
+		if payment.Status != paymentStatusDraft && payment.Status != paymentStatusRejected {
+			return fmt.Errorf("payment in status %s cannot be modified", payment.Status)
+		}
+
+		// 更新付款单字段，日期、供应商、币种或汇率变化时重新确定汇率
+		rerate := false
+		if req.Date != "" {
+			date, err := time.Parse("2006-01-02", req.Date)
+			if err != nil {
+				return err
+			}
+			payment.PaymentDate = date
+			rerate = true
+		}
+		if req.SupplierID != "" && req.SupplierID != payment.VendorID {
+			payment.VendorID = req.SupplierID
+			payment.Currency = ""
+			rerate = true
+		}
+		if req.Amount != nil {
+			payment.Amount = roundAmount(*req.Amount)
+		}
+		if req.Currency != "" {
+			payment.Currency = req.Currency
+			rerate = true
+		}
+		override := 0.0
+		if req.ExchangeRate != nil {
+			override = *req.ExchangeRate
+			rerate = true
+		}
+		if req.PaymentMethod != "" {
+			payment.PaymentMethod = req.PaymentMethod
+		}
+		if req.BankAccountID != "" {
+			payment.BankAccountID = req.BankAccountID
+		}
+		if req.Reference != "" {
+			payment.Reference = req.Reference
+		}
+		if req.Description != "" {
+			payment.Description = req.Description
+		}
+		if req.Remarks != "" {
+			payment.Remarks = req.Remarks
+		}
+		payment.UpdatedBy = req.UpdatedBy
+
+		if rerate {
+			var vendor models.PurchaseVendor
+			if err := tx.First(&vendor, "id = ?", payment.VendorID).Error; err != nil {
+				return err
+			}
+			currency, rate, err := documentCurrency(tx, payment.Currency, vendor.Currency, override, payment.PaymentDate)
+			if err != nil {
+				return err
+			}
+			payment.Currency, payment.ExchangeRate = currency, rate
+		}
+		payment.BaseAmount = toBaseAmount(payment.Amount, payment.ExchangeRate)
+
+		// 传入核销明细时整体替换，否则按新的付款信息重新校验原明细
+		allocations := payment.Allocations
+		if req.Allocations != nil {
+			var err error
+			allocations, err = paymentAllocations(req.Allocations, payment.ID, payment.PaymentDate, req.UpdatedBy)
+			if err != nil {
+				return err
+			}
+		}
+		for i := range allocations {
+			allocations[i].AllocationDate = payment.PaymentDate
+		}
+		if err := checkPaymentAllocations(tx, payment, allocations); err != nil {
+			return err
+		}
+
+		if err := tx.Where("payment_id = ?", payment.ID).Delete(&models.FinancePaymentAllocation{}).Error; err != nil {
+			return err
+		}
+		if len(allocations) > 0 {
+			for i := range allocations {
+				allocations[i].ID = utils.GenerateID()
+			}
+			if err := tx.Omit(clause.Associations).Create(&allocations).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Omit("Allocations").Save(&payment).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPaymentDetail(id)
+}
+
+func (s *financeService) DeletePayment(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		// 从数据库读取付款单
+		var payment models.FinancePayment
+		if err := tx.First(&payment, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		// 只有草稿或已驳回的付款单可以删除
+		if payment.Status != paymentStatusDraft && payment.Status != paymentStatusRejected {
+			return fmt.Errorf("payment in status %s cannot be deleted", payment.Status)
+		}
+
+		if err := tx.Where("payment_id = ?", payment.ID).Delete(&models.FinancePaymentAllocation{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&payment).Error
+	})
+}
+
+func (s *financeService) SubmitPayment(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	return s.transitionPayment(id, []string{paymentStatusDraft, paymentStatusRejected}, func(tx *gorm.DB, payment *models.FinancePayment) error {
+		// 提交时按发票当前余额重新校验核销明细
+		if err := checkPaymentAllocations(tx, *payment, payment.Allocations); err != nil {
+			return err
+		}
+		payment.Status = paymentStatusSubmitted
+		return nil
+	})
+}
+
+func (s *financeService) ApprovePayment(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	return s.transitionPayment(id, []string{paymentStatusSubmitted}, func(tx *gorm.DB, payment *models.FinancePayment) error {
+		payment.Status = paymentStatusApproved
+		return nil
+	})
+}
+
+func (s *financeService) RejectPayment(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	return s.transitionPayment(id, []string{paymentStatusSubmitted, paymentStatusApproved}, func(tx *gorm.DB, payment *models.FinancePayment) error {
+		payment.Status = paymentStatusRejected
+		return nil
+	})
+}
+
+// ProcessPayment 处理已审批的付款：生成付款凭证，并按核销明细冲减采购发票余额
+func (s *financeService) ProcessPayment(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	return s.transitionPayment(id, []string{paymentStatusApproved}, func(tx *gorm.DB, payment *models.FinancePayment) error {
+		return processPayment(tx, payment, payment.Allocations, "system")
+	})
+}
+
+// transitionPayment 在事务中锁定付款单，校验当前状态后执行状态变更
+func (s *financeService) transitionPayment(id string, allowed []string, apply func(tx *gorm.DB, payment *models.FinancePayment) error) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var payment models.FinancePayment
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("Allocations").
+			First(&payment, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if !containsString(allowed, payment.Status) {
+			return fmt.Errorf("payment in status %s cannot be changed", payment.Status)
+		}
+
+		if err := apply(tx, &payment); err != nil {
+			return err
+		}
+
+		return tx.Omit("Allocations").Save(&payment).Error
+	})
+}
+
+// processPayment 生成付款凭证并核销采购发票，付款单状态改为已处理；allocations为付款单的核销明细
+func processPayment(tx *gorm.DB, payment *models.FinancePayment, allocations []models.FinancePaymentAllocation, processedBy string) error {
+	if err := checkPeriodOpen(tx, payment.PaymentDate, false); err != nil {
+		return err
+	}
+
+	if _, err := postDocument(tx, postingDocument{
+		DocumentType:    postingDocumentPurchasePayment,
+		Event:           purchasePaymentEventPay,
+		ReferenceID:     payment.ID,
+		DocumentNo:      payment.PaymentNo,
+		Date:            payment.PaymentDate,
+		Description:     fmt.Sprintf("供应商付款%s", payment.PaymentNo),
+		Currency:        payment.Currency,
+		ExchangeRate:    payment.ExchangeRate,
+		Amounts:         map[string]float64{"amount": payment.BaseAmount},
+		CurrencyAmounts: map[string]float64{"amount": payment.Amount},
+		CreatedBy:       processedBy,
+	}); err != nil {
+		return err
+	}
+
+	payment.AppliedAmount = 0
+	if err := applyPaymentAllocations(tx, payment, allocations, processedBy); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	payment.Status = paymentStatusProcessed
+	payment.ProcessedBy = processedBy
+	payment.ProcessedAt = &now
+	return nil
+}
+
+// applyPaymentAllocations 锁定并核销采购发票，累加付款单已核销金额，有提前付款折扣时生成折扣凭证
+func applyPaymentAllocations(tx *gorm.DB, payment *models.FinancePayment, allocations []models.FinancePaymentAllocation, createdBy string) error {
+	if len(allocations) == 0 {
+		return nil
+	}
+
+	if err := checkSettlementRevaluation(tx, payment.Currency, allocations[0].AllocationDate); err != nil {
+		return err
+	}
+
+	invoiceIDs := make([]string, len(allocations))
+	for i, allocation := range allocations {
+		invoiceIDs[i] = allocation.InvoiceID
+	}
+	var invoices []models.PurchaseInvoice
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", invoiceIDs).Find(&invoices).Error; err != nil {
+		return err
+	}
+	invoiceByID := make(map[string]*models.PurchaseInvoice, len(invoices))
+	for i := range invoices {
+		invoiceByID[invoices[i].ID] = &invoices[i]
+	}
+
+	applied, discount, baseDiscount, exchange := payment.AppliedAmount, 0.0, 0.0, 0.0
+	for _, allocation := range allocations {
+		invoice, ok := invoiceByID[allocation.InvoiceID]
+		if !ok {
+			return fmt.Errorf("purchase invoice %s not found", allocation.InvoiceID)
+		}
+		if err := checkPaymentInvoice(*payment, *invoice); err != nil {
+			return err
+		}
+		settled := roundAmount(allocation.Amount + allocation.DiscountAmount)
+		if balance := purchaseInvoiceBalance(*invoice); settled > balance {
+			return fmt.Errorf("allocation %.2f exceeds balance %.2f of purchase invoice %s", settled, balance, invoice.InvoiceNo)
+		}
+		if roundAmount(applied+allocation.Amount) > payment.Amount {
+			return fmt.Errorf("allocated amount %.2f exceeds payment amount %.2f", roundAmount(applied+allocation.Amount), payment.Amount)
+		}
+
+		_, baseDiscountAmount, exchangeAmount := paymentSettlement(*payment, applied, *invoice, allocation)
+		invoice.PaidAmount = roundAmount(invoice.PaidAmount + allocation.Amount)
+		invoice.DiscountAmount = roundAmount(invoice.DiscountAmount + allocation.DiscountAmount)
+		settlePurchaseInvoice(invoice)
+		invoice.UpdatedBy = createdBy
+		applied = roundAmount(applied + allocation.Amount)
+		discount += allocation.DiscountAmount
+		baseDiscount += baseDiscountAmount
+		exchange += exchangeAmount
+	}
+	payment.AppliedAmount = applied
+	for i := range invoices {
+		if err := tx.Omit(clause.Associations).Save(&invoices[i]).Error; err != nil {
+			return err
+		}
+	}
+
+	// 提前付款折扣按发票原汇率折算，凭证记在核销日期
+	if discount > 0 {
+		if _, err := postDocument(tx, postingDocument{
+			DocumentType:    postingDocumentPurchasePayment,
+			Event:           purchasePaymentEventDiscount,
+			ReferenceID:     payment.ID,
+			DocumentNo:      payment.PaymentNo,
+			Date:            allocations[0].AllocationDate,
+			Description:     fmt.Sprintf("提前付款折扣%s", payment.PaymentNo),
+			Currency:        payment.Currency,
+			ExchangeRate:    payment.ExchangeRate,
+			Amounts:         map[string]float64{"discount": baseDiscount},
+			CurrencyAmounts: map[string]float64{"discount": discount},
+			CreatedBy:       createdBy,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// 外币发票按入账汇率冲减应付，与付款本位币金额的差额确认为已实现汇兑损益
+	return postRealizedExchange(tx, postingDocument{
+		DocumentType: postingDocumentPurchasePayment,
+		Event:        purchasePaymentEventExchange,
+		ReferenceID:  payment.ID,
+		DocumentNo:   payment.PaymentNo,
+		Date:         allocations[0].AllocationDate,
+		Description:  fmt.Sprintf("付款汇兑损益%s", payment.PaymentNo),
+		Currency:     payment.Currency,
+		ExchangeRate: payment.ExchangeRate,
+		Amounts:      map[string]float64{"exchange": roundAmount(exchange)},
+		CreatedBy:    createdBy,
+	})
+}
+
+// paymentAllocations 将核销明细请求转换为模型，同一发票只能出现一次
+func paymentAllocations(items []schemas.PaymentAllocationRequest, paymentID string, date time.Time, createdBy string) ([]models.FinancePaymentAllocation, error) {
+	allocations := make([]models.FinancePaymentAllocation, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		if seen[item.InvoiceID] {
+			return nil, fmt.Errorf("purchase invoice %s is allocated more than once", item.InvoiceID)
+		}
+		seen[item.InvoiceID] = true
+		if item.Amount <= 0 && item.DiscountAmount <= 0 {
+			return nil, fmt.Errorf("allocation to purchase invoice %s has no amount", item.InvoiceID)
+		}
+		allocations = append(allocations, models.FinancePaymentAllocation{
+			ID:             utils.GenerateID(),
+			PaymentID:      paymentID,
+			InvoiceID:      item.InvoiceID,
+			AllocationDate: date,
+			Amount:         roundAmount(item.Amount),
+			DiscountAmount: roundAmount(item.DiscountAmount),
+			CreatedBy:      createdBy,
+			UpdatedBy:      createdBy,
+		})
+	}
+	return allocations, nil
+}
+
+// checkPaymentAllocations 校验未处理付款单的核销明细：发票属于同一供应商和币种且已审核、核销金额不超过发票余额，
+// 核销的付款金额合计不超过付款金额
+func checkPaymentAllocations(db *gorm.DB, payment models.FinancePayment, allocations []models.FinancePaymentAllocation) error {
+	if payment.Amount <= 0 && len(allocations) == 0 {
+		return errors.New("payment amount must be greater than zero")
+	}
+
+	applied := 0.0
+	for _, allocation := range allocations {
+		var invoice models.PurchaseInvoice
+		if err := db.First(&invoice, "id = ?", allocation.InvoiceID).Error; err != nil {
+			return err
+		}
+		if err := checkPaymentInvoice(payment, invoice); err != nil {
+			return err
+		}
+		settled := roundAmount(allocation.Amount + allocation.DiscountAmount)
+		if balance := purchaseInvoiceBalance(invoice); settled > balance {
+			return fmt.Errorf("allocation %.2f exceeds balance %.2f of purchase invoice %s", settled, balance, invoice.InvoiceNo)
+		}
+		applied += allocation.Amount
+	}
+	if roundAmount(applied) > payment.Amount {
+		return fmt.Errorf("allocated amount %.2f exceeds payment amount %.2f", roundAmount(applied), payment.Amount)
+	}
+	return nil
+}
+
+// checkPaymentInvoice 校验采购发票可被付款单核销，未审核或冻结的发票不能付款
+func checkPaymentInvoice(payment models.FinancePayment, invoice models.PurchaseInvoice) error {
+	if invoice.VendorID != payment.VendorID {
+		return fmt.Errorf("purchase invoice %s belongs to another vendor", invoice.InvoiceNo)
+	}
+	if !containsString(payableInvoiceStatuses, invoice.Status) {
+		return fmt.Errorf("purchase invoice %s in status %s cannot be paid", invoice.InvoiceNo, invoice.Status)
+	}
+	currency := invoice.Currency
+	if currency == "" {
+		currency = baseCurrency()
+	}
+	if currency != payment.Currency {
+		return fmt.Errorf("purchase invoice %s currency %s does not match payment currency %s", invoice.InvoiceNo, currency, payment.Currency)
+	}
+	return nil
+}
+
+// paymentResponse 将付款单模型转换为响应格式
+func paymentResponse(payment models.FinancePayment) schemas.PaymentResponse {
+	allocations := make([]schemas.PaymentAllocationResponse, len(payment.Allocations))
+	discount := 0.0
+	for i, allocation := range payment.Allocations {
+		allocations[i] = schemas.PaymentAllocationResponse{
+			ID:             allocation.ID,
+			InvoiceID:      allocation.InvoiceID,
+			InvoiceNo:      allocation.Invoice.InvoiceNo,
+			AllocationDate: allocation.AllocationDate.Format("2006-01-02"),
+			Amount:         allocation.Amount,
+			DiscountAmount: allocation.DiscountAmount,
+		}
+		discount += allocation.DiscountAmount
+	}
+
+	// 未处理的付款单按核销明细预计核销金额
+	applied := payment.AppliedAmount
+	if payment.Status != paymentStatusProcessed {
+		applied = 0
+		for _, allocation := range payment.Allocations {
+			applied += allocation.Amount
+		}
+	}
+
+	processedAt := ""
+	if payment.ProcessedAt != nil {
+		processedAt = payment.ProcessedAt.Format("2006-01-02 15:04:05")
+	}
+
+	return schemas.PaymentResponse{
+		ID:              payment.ID,
+		Code:            payment.PaymentNo,
+		Date:            payment.PaymentDate.Format("2006-01-02"),
+		SupplierID:      payment.VendorID,
+		SupplierName:    payment.Vendor.Name,
+		Amount:          payment.Amount,
+		Currency:        payment.Currency,
+		ExchangeRate:    payment.ExchangeRate,
+		LocalAmount:     payment.BaseAmount,
+		AppliedAmount:   roundAmount(applied),
+		UnappliedAmount: roundAmount(payment.Amount - applied),
+		DiscountAmount:  roundAmount(discount),
+		PaymentMethod:   payment.PaymentMethod,
+		BankAccountID:   payment.BankAccountID,
+		RunID:           payment.RunID,
+		Status:          payment.Status,
+		Reference:       payment.Reference,
+		Description:     payment.Description,
+		Remarks:         payment.Remarks,
+		Allocations:     allocations,
+		ProcessedAt:     processedAt,
+		CreatedAt:       payment.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:       payment.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
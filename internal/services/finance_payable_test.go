@@ -0,0 +1,187 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wu136995/ginx/internal/models"
+)
+
+// TestPaymentSettlement 测试外币采购发票按不同于入账汇率的付款汇率付款后应付在本位币上结清
+func TestPaymentSettlement(t *testing.T) {
+	tests := []struct {
+		name         string
+		payments     []models.FinancePayment
+		allocations  []models.FinancePaymentAllocation
+		wantExchange float64
+	}{
+		{
+			name:         "一次付清并享受折扣",
+			payments:     []models.FinancePayment{{PaymentNo: "PY001", Amount: 980, Currency: "EUR", ExchangeRate: 7.9, BaseAmount: 7742}},
+			allocations:  []models.FinancePaymentAllocation{{Amount: 980, DiscountAmount: 20}},
+			wantExchange: -98,
+		},
+		{
+			name: "分次付款",
+			payments: []models.FinancePayment{
+				{PaymentNo: "PY002", Amount: 400, Currency: "EUR", ExchangeRate: 7.5, BaseAmount: 3000},
+				{PaymentNo: "PY003", Amount: 700, Currency: "EUR", ExchangeRate: 8.1, BaseAmount: 5670},
+			},
+			allocations:  []models.FinancePaymentAllocation{{Amount: 400}, {Amount: 600}},
+			wantExchange: -60,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 发票1000欧元，入账汇率7.8，应付7800
+			invoice := models.PurchaseInvoice{InvoiceNo: "PI001", TotalAmount: 1000, Currency: "EUR", ExchangeRate: 7.8, Status: "verified"}
+			payable := toBaseAmount(invoice.TotalAmount, invoice.ExchangeRate)
+
+			var exchange float64
+			for i, allocation := range tt.allocations {
+				payment := tt.payments[i]
+				amount, discount, gain := paymentSettlement(payment, 0, invoice, allocation)
+				if amount != toBaseAmount(allocation.Amount, payment.ExchangeRate) {
+					t.Errorf("Payment %s: expected amount at payment rate %.2f, got %.2f",
+						payment.PaymentNo, toBaseAmount(allocation.Amount, payment.ExchangeRate), amount)
+				}
+				payable = roundAmount(payable - amount - discount - gain)
+				exchange += gain
+
+				invoice.PaidAmount = roundAmount(invoice.PaidAmount + allocation.Amount)
+				invoice.DiscountAmount = roundAmount(invoice.DiscountAmount + allocation.DiscountAmount)
+				settlePurchaseInvoice(&invoice)
+			}
+
+			if invoice.Status != "paid" {
+				t.Errorf("Expected invoice paid, got %s", invoice.Status)
+			}
+			if payable != 0 {
+				t.Errorf("Expected payable balance 0 in base currency, got %.2f", payable)
+			}
+			if roundAmount(exchange) != tt.wantExchange {
+				t.Errorf("Expected realized exchange %.2f, got %.2f", tt.wantExchange, exchange)
+			}
+		})
+	}
+}
+
+// TestParsePaymentTerms 测试付款条件解析，只有完整的“折扣/天数 net 天数”写法才识别为提前付款折扣
+func TestParsePaymentTerms(t *testing.T) {
+	tests := []struct {
+		text   string
+		want   paymentTerms
+		wantOK bool
+	}{
+		{"2/10 net 30", paymentTerms{DiscountRate: 2, DiscountDays: 10, NetDays: 30}, true},
+		{"2/10, n/30", paymentTerms{DiscountRate: 2, DiscountDays: 10, NetDays: 30}, true},
+		{"1.5/15 NET 45", paymentTerms{DiscountRate: 1.5, DiscountDays: 15, NetDays: 45}, true},
+		{"2/10，n/30", paymentTerms{DiscountRate: 2, DiscountDays: 10, NetDays: 30}, true},
+		{"30/60/90", paymentTerms{NetDays: 30}, true},
+		{"30/60", paymentTerms{NetDays: 30}, true},
+		{"2/40 net 30", paymentTerms{NetDays: 30}, true},
+		{"net 45", paymentTerms{NetDays: 45}, true},
+		{"30天内付款", paymentTerms{NetDays: 30}, true},
+		{"月结60天", paymentTerms{NetDays: 60}, true},
+		{"货到付款", paymentTerms{}, false},
+		{"", paymentTerms{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			terms, ok := parsePaymentTerms(tt.text)
+			if terms != tt.want || ok != tt.wantOK {
+				t.Errorf("Expected %+v %v, got %+v %v", tt.want, tt.wantOK, terms, ok)
+			}
+		})
+	}
+}
+
+// TestPaymentTermsDueDate 测试按付款条件计算到期日，无法识别时默认一个月
+func TestPaymentTermsDueDate(t *testing.T) {
+	invoiceDate := time.Date(2024, 1, 31, 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"2/10 net 30", "2024-03-01"},
+		{"30/60/90", "2024-03-01"},
+		{"月结60天", "2024-03-31"},
+		{"货到付款", "2024-03-02"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := paymentTermsDueDate(invoiceDate, tt.text).Format("2006-01-02"); got != tt.want {
+				t.Errorf("Expected due date %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestInvoiceEarlyDiscount 测试提前付款折扣按发票余额计算，截止日当天仍可享受，已享受过折扣的发票不再享受
+func TestInvoiceEarlyDiscount(t *testing.T) {
+	invoiceDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		name         string
+		invoice      models.PurchaseInvoice
+		text         string
+		date         time.Time
+		wantDiscount float64
+		wantDeadline string
+	}{
+		{
+			name:         "截止日前付款",
+			invoice:      models.PurchaseInvoice{TotalAmount: 1000, InvoiceDate: invoiceDate},
+			text:         "2/10 net 30",
+			date:         invoiceDate.AddDate(0, 0, 5),
+			wantDiscount: 20,
+			wantDeadline: "2024-03-11",
+		},
+		{
+			name:         "截止日当天付款",
+			invoice:      models.PurchaseInvoice{TotalAmount: 1000, PaidAmount: 400, InvoiceDate: invoiceDate},
+			text:         "2/10 net 30",
+			date:         time.Date(2024, 3, 11, 18, 30, 0, 0, time.Local),
+			wantDiscount: 12,
+			wantDeadline: "2024-03-11",
+		},
+		{
+			name:         "截止日后付款",
+			invoice:      models.PurchaseInvoice{TotalAmount: 1000, InvoiceDate: invoiceDate},
+			text:         "2/10 net 30",
+			date:         invoiceDate.AddDate(0, 0, 11),
+			wantDeadline: "2024-03-11",
+		},
+		{
+			name:         "已享受过折扣",
+			invoice:      models.PurchaseInvoice{TotalAmount: 1000, PaidAmount: 490, DiscountAmount: 10, InvoiceDate: invoiceDate},
+			text:         "2/10 net 30",
+			date:         invoiceDate.AddDate(0, 0, 5),
+			wantDeadline: "2024-03-11",
+		},
+		{
+			name:    "分期付款条件没有折扣",
+			invoice: models.PurchaseInvoice{TotalAmount: 1000, InvoiceDate: invoiceDate},
+			text:    "30/60/90",
+			date:    invoiceDate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discount, deadline := invoiceEarlyDiscount(tt.invoice, tt.text, tt.date)
+			if discount != tt.wantDiscount {
+				t.Errorf("Expected discount %.2f, got %.2f", tt.wantDiscount, discount)
+			}
+			var got string
+			if deadline != nil {
+				got = deadline.Format("2006-01-02")
+			}
+			if got != tt.wantDeadline {
+				t.Errorf("Expected discount date %q, got %q", tt.wantDeadline, got)
+			}
+		})
+	}
+}
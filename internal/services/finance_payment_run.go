@@ -0,0 +1,741 @@
+package services
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/config"
+	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/query"
+	"github.com/wu136995/ginx/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 付款运行状态，建议状态可调整明细，审批后生成并处理付款单
+const (
+	paymentRunStatusProposed  = "proposed"
+	paymentRunStatusApproved  = "approved"
+	paymentRunStatusCancelled = "cancelled"
+)
+
+// pain001Namespace ISO 20022 客户贷记转账发起报文命名空间
+const pain001Namespace = "urn:iso:std:iso:20022:tech:xsd:pain.001.001.03"
+
+// ibanPattern IBAN格式：两位国家代码、两位校验位和最多30位账号
+var ibanPattern = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}$`)
+
+// paymentRunListSpec 付款运行列表查询白名单
+var paymentRunListSpec = query.NewSpec("-payment_date,-code",
+	query.Text("run_no").As("code"),
+	query.Date("payment_date"),
+	query.Date("due_date"),
+	query.Text("vendor_id").As("supplier_id"),
+	query.Text("currency"),
+	query.Text("status"),
+)
+
+// 付款运行方法
+func (s *financeService) GetPaymentRunList(params query.Params) (*query.Page[schemas.PaymentRunResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取付款运行，列表不返回明细
+	var runs []models.FinancePaymentRun
+	total, err := query.Find(s.db, params, paymentRunListSpec, &runs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	responses := make([]schemas.PaymentRunResponse, len(runs))
+	for i, run := range runs {
+		responses[i] = paymentRunResponse(run)
+	}
+
+	return query.NewPage(responses, total, params), nil
+}
+
+func (s *financeService) GetPaymentRunDetail(id string) (*schemas.PaymentRunResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取付款运行及明细
+	var run models.FinancePaymentRun
+	result := s.db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("due_date ASC, created_at ASC")
+	}).Preload("Items.Invoice").Preload("Items.Vendor").First(&run, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := paymentRunResponse(run)
+	return &response, nil
+}
+
+// CreatePaymentRun 按到期日、供应商和币种选取已审核的待付采购发票生成付款建议：
+// 到期日不晚于截止日期的发票付清余额，付款日仍在折扣期内的发票即使未到期也纳入并扣除提前付款折扣
+func (s *financeService) CreatePaymentRun(req schemas.PaymentRunCreateRequest) (*schemas.PaymentRunResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 解析付款日期和到期日截止日期
+	paymentDate, err := time.Parse("2006-01-02", req.PaymentDate)
+	if err != nil {
+		return nil, err
+	}
+	dueDate := paymentDate
+	if req.DueDate != "" {
+		dueDate, err = time.Parse("2006-01-02", req.DueDate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 未指定编号时自动生成，付款方式默认银行转账
+	runNo := req.Code
+	if runNo == "" {
+		runNo = autoJournalNo("PY")
+	}
+	paymentMethod := req.PaymentMethod
+	if paymentMethod == "" {
+		paymentMethod = "bank_transfer"
+	}
+
+	run := models.FinancePaymentRun{
+		ID:            utils.GenerateID(),
+		RunNo:         runNo,
+		PaymentDate:   paymentDate,
+		DueDate:       dueDate,
+		VendorID:      req.SupplierID,
+		Currency:      strings.ToUpper(req.Currency),
+		PaymentMethod: paymentMethod,
+		BankAccountID: req.BankAccountID,
+		Status:        paymentRunStatusProposed,
+		Remarks:       req.Remarks,
+		CreatedBy:     req.CreatedBy,
+		UpdatedBy:     req.CreatedBy,
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		invoices, err := paymentRunInvoices(tx, run)
+		if err != nil {
+			return err
+		}
+
+		// 到期或可享受折扣的发票形成付款建议
+		var items []models.FinancePaymentRunItem
+		for _, invoice := range invoices {
+			discount, discountDate := invoiceEarlyDiscount(invoice, invoicePaymentTerms(invoice), run.PaymentDate)
+			if invoice.DueDate.Format("2006-01-02") > run.DueDate.Format("2006-01-02") && discount <= 0 {
+				continue
+			}
+			balance := purchaseInvoiceBalance(invoice)
+			items = append(items, models.FinancePaymentRunItem{
+				ID:             utils.GenerateID(),
+				RunID:          run.ID,
+				InvoiceID:      invoice.ID,
+				VendorID:       invoice.VendorID,
+				Currency:       invoiceCurrency(invoice),
+				DueDate:        invoice.DueDate,
+				DiscountDate:   discountDate,
+				OpenAmount:     balance,
+				DiscountAmount: discount,
+				PaymentAmount:  roundAmount(balance - discount),
+				CreatedBy:      req.CreatedBy,
+				UpdatedBy:      req.CreatedBy,
+			})
+		}
+		if len(items) == 0 {
+			return errors.New("no purchase invoices are due for payment")
+		}
+
+		if err := paymentRunTotals(tx, &run, items); err != nil {
+			return err
+		}
+		if err := tx.Omit("Items").Create(&run).Error; err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Create(&items).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPaymentRunDetail(run.ID)
+}
+
+// UpdatePaymentRun 调整付款建议，传入的明细整体替换原明细，按发票当前余额和付款日可享受的折扣重新校验
+func (s *financeService) UpdatePaymentRun(id string, req schemas.PaymentRunUpdateRequest) (*schemas.PaymentRunResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// 锁定付款运行，只有建议状态可以调整
+		var run models.FinancePaymentRun
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&run, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if run.Status != paymentRunStatusProposed {
+			return fmt.Errorf("payment run in status %s cannot be modified", run.Status)
+		}
+
+		if req.Remarks != "" {
+			run.Remarks = req.Remarks
+		}
+		run.UpdatedBy = req.UpdatedBy
+		if req.Items == nil {
+			return tx.Omit("Items").Save(&run).Error
+		}
+		if len(req.Items) == 0 {
+			return errors.New("payment run must have at least one item")
+		}
+
+		// 明细发票必须仍在付款运行的选取范围内
+		invoices, err := paymentRunInvoices(tx, run)
+		if err != nil {
+			return err
+		}
+		invoiceByID := make(map[string]models.PurchaseInvoice, len(invoices))
+		for _, invoice := range invoices {
+			invoiceByID[invoice.ID] = invoice
+		}
+
+		items := make([]models.FinancePaymentRunItem, 0, len(req.Items))
+		seen := make(map[string]bool, len(req.Items))
+		for _, itemReq := range req.Items {
+			if seen[itemReq.InvoiceID] {
+				return fmt.Errorf("purchase invoice %s is proposed more than once", itemReq.InvoiceID)
+			}
+			seen[itemReq.InvoiceID] = true
+			invoice, ok := invoiceByID[itemReq.InvoiceID]
+			if !ok {
+				return fmt.Errorf("purchase invoice %s is not payable in this payment run", itemReq.InvoiceID)
+			}
+			item := models.FinancePaymentRunItem{
+				ID:             utils.GenerateID(),
+				RunID:          run.ID,
+				InvoiceID:      invoice.ID,
+				VendorID:       invoice.VendorID,
+				Currency:       invoiceCurrency(invoice),
+				DueDate:        invoice.DueDate,
+				OpenAmount:     purchaseInvoiceBalance(invoice),
+				DiscountAmount: roundAmount(itemReq.DiscountAmount),
+				PaymentAmount:  roundAmount(itemReq.PaymentAmount),
+				CreatedBy:      req.UpdatedBy,
+				UpdatedBy:      req.UpdatedBy,
+			}
+			if err := checkPaymentRunItem(run, &item, invoice); err != nil {
+				return err
+			}
+			items = append(items, item)
+		}
+
+		if err := paymentRunTotals(tx, &run, items); err != nil {
+			return err
+		}
+		if err := tx.Where("run_id = ?", run.ID).Delete(&models.FinancePaymentRunItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Create(&items).Error; err != nil {
+			return err
+		}
+		return tx.Omit("Items").Save(&run).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPaymentRunDetail(id)
+}
+
+// ApprovePaymentRun 审批付款建议：按供应商和币种汇总生成付款单并立即处理，核销明细冲减发票余额，
+// 享受的提前付款折扣随核销生成折扣凭证
+func (s *financeService) ApprovePaymentRun(id string, req schemas.PaymentRunApproveRequest) (*schemas.PaymentRunResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// 锁定付款运行，只有建议状态可以审批
+		var run models.FinancePaymentRun
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("Items", func(db *gorm.DB) *gorm.DB {
+				return db.Order("due_date ASC, created_at ASC")
+			}).
+			Preload("Items.Invoice.Vendor").
+			First(&run, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if run.Status != paymentRunStatusProposed {
+			return fmt.Errorf("payment run in status %s cannot be approved", run.Status)
+		}
+		if len(run.Items) == 0 {
+			return errors.New("payment run has no items")
+		}
+
+		// 建议生成后发票可能已被其他付款核销，按当前余额重新校验
+		for i := range run.Items {
+			if err := checkPaymentRunItem(run, &run.Items[i], run.Items[i].Invoice); err != nil {
+				return err
+			}
+		}
+
+		// 按供应商和币种分组，保持明细顺序
+		type paymentGroup struct {
+			vendorID string
+			currency string
+			items    []*models.FinancePaymentRunItem
+		}
+		var groups []*paymentGroup
+		groupByKey := make(map[string]*paymentGroup)
+		for i := range run.Items {
+			item := &run.Items[i]
+			key := item.VendorID + "|" + item.Currency
+			group, ok := groupByKey[key]
+			if !ok {
+				group = &paymentGroup{vendorID: item.VendorID, currency: item.Currency}
+				groupByKey[key] = group
+				groups = append(groups, group)
+			}
+			group.items = append(group.items, item)
+		}
+
+		for _, group := range groups {
+			currency, rate, err := documentCurrency(tx, group.currency, "", 0, run.PaymentDate)
+			if err != nil {
+				return err
+			}
+			payment := models.FinancePayment{
+				ID:            utils.GenerateID(),
+				PaymentNo:     autoJournalNo("PM"),
+				PaymentDate:   run.PaymentDate,
+				VendorID:      group.vendorID,
+				Currency:      currency,
+				ExchangeRate:  rate,
+				PaymentMethod: run.PaymentMethod,
+				BankAccountID: run.BankAccountID,
+				Reference:     run.RunNo,
+				Description:   fmt.Sprintf("付款运行%s", run.RunNo),
+				RunID:         run.ID,
+				Status:        paymentStatusApproved,
+				CreatedBy:     req.ApprovedBy,
+				UpdatedBy:     req.ApprovedBy,
+			}
+			allocations := make([]models.FinancePaymentAllocation, len(group.items))
+			for i, item := range group.items {
+				payment.Amount += item.PaymentAmount
+				allocations[i] = models.FinancePaymentAllocation{
+					ID:             utils.GenerateID(),
+					PaymentID:      payment.ID,
+					InvoiceID:      item.InvoiceID,
+					AllocationDate: run.PaymentDate,
+					Amount:         item.PaymentAmount,
+					DiscountAmount: item.DiscountAmount,
+					CreatedBy:      req.ApprovedBy,
+					UpdatedBy:      req.ApprovedBy,
+				}
+				item.PaymentID = payment.ID
+				item.UpdatedBy = req.ApprovedBy
+			}
+			payment.Amount = roundAmount(payment.Amount)
+			payment.BaseAmount = toBaseAmount(payment.Amount, rate)
+
+			if err := processPayment(tx, &payment, allocations, req.ApprovedBy); err != nil {
+				return err
+			}
+			if err := tx.Omit(clause.Associations).Create(&payment).Error; err != nil {
+				return err
+			}
+			if err := tx.Omit(clause.Associations).Create(&allocations).Error; err != nil {
+				return err
+			}
+		}
+
+		for i := range run.Items {
+			if err := tx.Omit(clause.Associations).Save(&run.Items[i]).Error; err != nil {
+				return err
+			}
+		}
+		if err := paymentRunTotals(tx, &run, run.Items); err != nil {
+			return err
+		}
+		now := time.Now()
+		run.Status = paymentRunStatusApproved
+		run.ApprovedBy = req.ApprovedBy
+		run.ApprovedAt = &now
+		run.UpdatedBy = req.ApprovedBy
+		return tx.Omit("Items").Save(&run).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPaymentRunDetail(id)
+}
+
+// CancelPaymentRun 取消付款建议，取消后其中的发票可以进入新的付款运行
+func (s *financeService) CancelPaymentRun(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var run models.FinancePaymentRun
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&run, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if run.Status != paymentRunStatusProposed {
+			return fmt.Errorf("payment run in status %s cannot be cancelled", run.Status)
+		}
+		run.Status = paymentRunStatusCancelled
+		return tx.Omit("Items").Save(&run).Error
+	})
+}
+
+// ExportPaymentRun 将已审批付款运行生成的付款单导出为ISO 20022 pain.001.001.03银行付款文件，
+// 每张付款单一笔贷记转账，附言为核销的发票编号
+func (s *financeService) ExportPaymentRun(id string) ([]byte, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	var run models.FinancePaymentRun
+	if err := s.db.First(&run, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	if run.Status != paymentRunStatusApproved {
+		return nil, fmt.Errorf("payment run in status %s cannot be exported", run.Status)
+	}
+
+	// 付款方账户取自配置
+	bank := config.GetAppConfig().Finance.PaymentBank
+	if bank.Name == "" || bank.Account == "" {
+		return nil, errors.New("payment bank account is not configured")
+	}
+
+	var payments []models.FinancePayment
+	result := s.db.Preload("Vendor").Preload("Allocations.Invoice").
+		Where("run_id = ?", run.ID).
+		Order("payment_no ASC").
+		Find(&payments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(payments) == 0 {
+		return nil, errors.New("payment run has no payments")
+	}
+
+	return pain001PaymentFile(run, bank, payments, time.Now())
+}
+
+// pain001PaymentFile 生成付款运行的pain.001.001.03报文，每张付款单一笔贷记转账，收款方必须维护银行账号
+func pain001PaymentFile(run models.FinancePaymentRun, bank config.PaymentBankConfig, payments []models.FinancePayment, createdAt time.Time) ([]byte, error) {
+	transfers := make([]pain001Transfer, 0, len(payments))
+	total := 0.0
+	for _, payment := range payments {
+		if payment.Vendor.BankAccount == "" {
+			return nil, fmt.Errorf("vendor %s has no bank account", payment.Vendor.Name)
+		}
+		invoiceNos := make([]string, len(payment.Allocations))
+		for i, allocation := range payment.Allocations {
+			invoiceNos[i] = allocation.Invoice.InvoiceNo
+		}
+		transfer := pain001Transfer{
+			EndToEndID: payment.PaymentNo,
+			Amount:     pain001Amount{Currency: payment.Currency, Value: pain001Decimal(payment.Amount)},
+			Creditor:   pain001Party{Name: payment.Vendor.Name},
+			Account:    pain001AccountOf(payment.Vendor.BankAccount),
+			Remittance: pain001Remittance(strings.Join(invoiceNos, ",")),
+		}
+		if payment.Vendor.BankName != "" {
+			transfer.Agent = &pain001Agent{Institution: pain001Institution{Name: payment.Vendor.BankName}}
+		}
+		transfers = append(transfers, transfer)
+		total = roundAmount(total + payment.Amount)
+	}
+
+	debtorAgent := pain001Institution{BIC: bank.BIC}
+	if bank.BIC == "" {
+		debtorAgent = pain001Institution{Other: &pain001OtherID{ID: "NOTPROVIDED"}}
+	}
+	count := strconv.Itoa(len(transfers))
+	document := pain001Document{
+		Namespace: pain001Namespace,
+		Initiation: pain001Initiation{
+			Header: pain001Header{
+				MessageID:   run.RunNo,
+				CreatedAt:   createdAt.Format("2006-01-02T15:04:05"),
+				Count:       count,
+				ControlSum:  pain001Decimal(total),
+				InitiatedBy: pain001Party{Name: bank.Name},
+			},
+			Payment: pain001PaymentInfo{
+				ID:            run.RunNo,
+				Method:        "TRF",
+				Count:         count,
+				ControlSum:    pain001Decimal(total),
+				ExecutionDate: run.PaymentDate.Format("2006-01-02"),
+				Debtor:        pain001Party{Name: bank.Name},
+				DebtorAccount: pain001AccountOf(bank.Account),
+				DebtorAgent:   pain001Agent{Institution: debtorAgent},
+				Transfers:     transfers,
+			},
+		},
+	}
+
+	data, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// paymentRunInvoices 读取付款运行选取范围内的待付采购发票：已审核或部分付款、余额大于0、
+// 符合供应商和币种条件且不在其他未审批的付款运行中
+func paymentRunInvoices(db *gorm.DB, run models.FinancePaymentRun) ([]models.PurchaseInvoice, error) {
+	proposed := db.Model(&models.FinancePaymentRunItem{}).
+		Select("finance_payment_run_items.invoice_id").
+		Joins("JOIN finance_payment_runs ON finance_payment_runs.id = finance_payment_run_items.run_id AND finance_payment_runs.deleted_at IS NULL").
+		Where("finance_payment_runs.status = ? AND finance_payment_runs.id <> ?", paymentRunStatusProposed, run.ID)
+
+	invoiceQuery := db.Preload("Vendor").
+		Where("status IN ? AND total_amount - paid_amount - discount_amount > 0", payableInvoiceStatuses).
+		Where("id NOT IN (?)", proposed)
+	if run.VendorID != "" {
+		invoiceQuery = invoiceQuery.Where("vendor_id = ?", run.VendorID)
+	}
+	if run.Currency != "" {
+		if run.Currency == baseCurrency() {
+			invoiceQuery = invoiceQuery.Where("(currency = ? OR currency = '' OR currency IS NULL)", run.Currency)
+		} else {
+			invoiceQuery = invoiceQuery.Where("currency = ?", run.Currency)
+		}
+	}
+
+	var invoices []models.PurchaseInvoice
+	if err := invoiceQuery.Order("due_date ASC, invoice_no ASC").Find(&invoices).Error; err != nil {
+		return nil, err
+	}
+	return invoices, nil
+}
+
+// checkPaymentRunItem 按发票当前余额校验付款建议明细，并刷新发票余额和折扣截止日期：
+// 折扣不能超过付款日可享受的提前付款折扣，享受折扣时须付清余额；发票需预加载供应商以取得付款条件
+func checkPaymentRunItem(run models.FinancePaymentRun, item *models.FinancePaymentRunItem, invoice models.PurchaseInvoice) error {
+	if !containsString(payableInvoiceStatuses, invoice.Status) {
+		return fmt.Errorf("purchase invoice %s in status %s cannot be paid", invoice.InvoiceNo, invoice.Status)
+	}
+	if item.PaymentAmount <= 0 {
+		return fmt.Errorf("payment amount for purchase invoice %s must be greater than zero", invoice.InvoiceNo)
+	}
+
+	balance := purchaseInvoiceBalance(invoice)
+	available, discountDate := invoiceEarlyDiscount(invoice, invoicePaymentTerms(invoice), run.PaymentDate)
+	if item.DiscountAmount > available {
+		return fmt.Errorf("discount %.2f exceeds available discount %.2f of purchase invoice %s", item.DiscountAmount, available, invoice.InvoiceNo)
+	}
+	settled := roundAmount(item.PaymentAmount + item.DiscountAmount)
+	if settled > balance {
+		return fmt.Errorf("payment %.2f exceeds balance %.2f of purchase invoice %s", settled, balance, invoice.InvoiceNo)
+	}
+	if item.DiscountAmount > 0 && settled < balance {
+		return fmt.Errorf("discount on purchase invoice %s requires paying the full balance %.2f", invoice.InvoiceNo, balance)
+	}
+	item.OpenAmount = balance
+	item.DiscountDate = discountDate
+	return nil
+}
+
+// paymentRunTotals 按付款日汇率将付款建议金额和折扣折算为本位币合计
+func paymentRunTotals(db *gorm.DB, run *models.FinancePaymentRun, items []models.FinancePaymentRunItem) error {
+	total, discount := 0.0, 0.0
+	for _, item := range items {
+		rate, err := exchangeRate(db, item.Currency, run.PaymentDate)
+		if err != nil {
+			return err
+		}
+		total += toBaseAmount(item.PaymentAmount, rate)
+		discount += toBaseAmount(item.DiscountAmount, rate)
+	}
+	run.TotalAmount = roundAmount(total)
+	run.DiscountAmount = roundAmount(discount)
+	return nil
+}
+
+// invoicePaymentTerms 采购发票适用的付款条件，发票未指定时取供应商付款条件
+func invoicePaymentTerms(invoice models.PurchaseInvoice) string {
+	if invoice.PaymentTerms != "" {
+		return invoice.PaymentTerms
+	}
+	return invoice.Vendor.PaymentTerms
+}
+
+// invoiceCurrency 采购发票币种，为空时为本位币
+func invoiceCurrency(invoice models.PurchaseInvoice) string {
+	if invoice.Currency == "" {
+		return baseCurrency()
+	}
+	return strings.ToUpper(invoice.Currency)
+}
+
+// paymentRunResponse 将付款运行模型转换为响应格式
+func paymentRunResponse(run models.FinancePaymentRun) schemas.PaymentRunResponse {
+	items := make([]schemas.PaymentRunItemResponse, len(run.Items))
+	for i, item := range run.Items {
+		discountDate := ""
+		if item.DiscountDate != nil {
+			discountDate = item.DiscountDate.Format("2006-01-02")
+		}
+		items[i] = schemas.PaymentRunItemResponse{
+			ID:             item.ID,
+			InvoiceID:      item.InvoiceID,
+			InvoiceNo:      item.Invoice.InvoiceNo,
+			SupplierID:     item.VendorID,
+			SupplierName:   item.Vendor.Name,
+			Currency:       item.Currency,
+			DueDate:        item.DueDate.Format("2006-01-02"),
+			DiscountDate:   discountDate,
+			OpenAmount:     item.OpenAmount,
+			DiscountAmount: item.DiscountAmount,
+			PaymentAmount:  item.PaymentAmount,
+			PaymentID:      item.PaymentID,
+		}
+	}
+
+	approvedAt := ""
+	if run.ApprovedAt != nil {
+		approvedAt = run.ApprovedAt.Format("2006-01-02 15:04:05")
+	}
+
+	return schemas.PaymentRunResponse{
+		ID:             run.ID,
+		Code:           run.RunNo,
+		PaymentDate:    run.PaymentDate.Format("2006-01-02"),
+		DueDate:        run.DueDate.Format("2006-01-02"),
+		SupplierID:     run.VendorID,
+		Currency:       run.Currency,
+		PaymentMethod:  run.PaymentMethod,
+		BankAccountID:  run.BankAccountID,
+		TotalAmount:    run.TotalAmount,
+		DiscountAmount: run.DiscountAmount,
+		Status:         run.Status,
+		ApprovedBy:     run.ApprovedBy,
+		ApprovedAt:     approvedAt,
+		Remarks:        run.Remarks,
+		Items:          items,
+		CreatedBy:      run.CreatedBy,
+		CreatedAt:      run.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:      run.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+// pain.001.001.03 报文结构，只包含贷记转账必需的元素
+
+type pain001Document struct {
+	XMLName    xml.Name          `xml:"Document"`
+	Namespace  string            `xml:"xmlns,attr"`
+	Initiation pain001Initiation `xml:"CstmrCdtTrfInitn"`
+}
+
+type pain001Initiation struct {
+	Header  pain001Header      `xml:"GrpHdr"`
+	Payment pain001PaymentInfo `xml:"PmtInf"`
+}
+
+type pain001Header struct {
+	MessageID   string       `xml:"MsgId"`
+	CreatedAt   string       `xml:"CreDtTm"`
+	Count       string       `xml:"NbOfTxs"`
+	ControlSum  string       `xml:"CtrlSum"`
+	InitiatedBy pain001Party `xml:"InitgPty"`
+}
+
+type pain001PaymentInfo struct {
+	ID            string            `xml:"PmtInfId"`
+	Method        string            `xml:"PmtMtd"`
+	Count         string            `xml:"NbOfTxs"`
+	ControlSum    string            `xml:"CtrlSum"`
+	ExecutionDate string            `xml:"ReqdExctnDt"`
+	Debtor        pain001Party      `xml:"Dbtr"`
+	DebtorAccount pain001Account    `xml:"DbtrAcct"`
+	DebtorAgent   pain001Agent      `xml:"DbtrAgt"`
+	Transfers     []pain001Transfer `xml:"CdtTrfTxInf"`
+}
+
+type pain001Transfer struct {
+	EndToEndID string         `xml:"PmtId>EndToEndId"`
+	Amount     pain001Amount  `xml:"Amt>InstdAmt"`
+	Agent      *pain001Agent  `xml:"CdtrAgt,omitempty"`
+	Creditor   pain001Party   `xml:"Cdtr"`
+	Account    pain001Account `xml:"CdtrAcct"`
+	Remittance string         `xml:"RmtInf>Ustrd,omitempty"`
+}
+
+type pain001Party struct {
+	Name string `xml:"Nm"`
+}
+
+type pain001Amount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type pain001Account struct {
+	IBAN  string          `xml:"Id>IBAN,omitempty"`
+	Other *pain001OtherID `xml:"Id>Othr,omitempty"`
+}
+
+type pain001Agent struct {
+	Institution pain001Institution `xml:"FinInstnId"`
+}
+
+type pain001Institution struct {
+	BIC   string          `xml:"BIC,omitempty"`
+	Name  string          `xml:"Nm,omitempty"`
+	Other *pain001OtherID `xml:"Othr,omitempty"`
+}
+
+type pain001OtherID struct {
+	ID string `xml:"Id"`
+}
+
+// pain001AccountOf 账号去除空格后符合IBAN格式的按IBAN导出，否则作为其他账号导出
+func pain001AccountOf(account string) pain001Account {
+	compact := strings.ToUpper(strings.ReplaceAll(account, " ", ""))
+	if ibanPattern.MatchString(compact) {
+		return pain001Account{IBAN: compact}
+	}
+	return pain001Account{Other: &pain001OtherID{ID: strings.ReplaceAll(account, " ", "")}}
+}
+
+// pain001Remittance 非结构化附言最长140个字符，超出部分截断
+func pain001Remittance(text string) string {
+	if runes := []rune(text); len(runes) > 140 {
+		return string(runes[:140])
+	}
+	return text
+}
+
+// pain001Decimal 金额保留两位小数
+func pain001Decimal(amount float64) string {
+	return strconv.FormatFloat(roundAmount(amount), 'f', 2, 64)
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wu136995/ginx/internal/config"
+	"github.com/wu136995/ginx/internal/models"
+)
+
+// TestPain001PaymentFile 测试付款运行导出的pain.001报文：汇总笔数和金额、IBAN与其他账号、附言及收款方开户行
+func TestPain001PaymentFile(t *testing.T) {
+	run := models.FinancePaymentRun{RunNo: "PR202403001", PaymentDate: time.Date(2024, 3, 15, 0, 0, 0, 0, time.Local)}
+	bank := config.PaymentBankConfig{Name: "示例贸易有限公司", Account: "DE89 3704 0044 0532 0130 00", BIC: "COBADEFFXXX"}
+	payments := []models.FinancePayment{
+		{
+			PaymentNo: "PY001", Amount: 980.1, Currency: "EUR",
+			Vendor: models.PurchaseVendor{Name: "Vendor A", BankName: "Bank A", BankAccount: "FR14 2004 1010 0505 0001 3M02 606"},
+			Allocations: []models.FinancePaymentAllocation{
+				{Invoice: models.PurchaseInvoice{InvoiceNo: "PI001"}},
+				{Invoice: models.PurchaseInvoice{InvoiceNo: "PI002"}},
+			},
+		},
+		{
+			PaymentNo: "PY002", Amount: 19.9, Currency: "EUR",
+			Vendor:      models.PurchaseVendor{Name: "Vendor B", BankAccount: "6222 0200 1234 5678"},
+			Allocations: []models.FinancePaymentAllocation{{Invoice: models.PurchaseInvoice{InvoiceNo: "PI003"}}},
+		},
+	}
+
+	data, err := pain001PaymentFile(run, bank, payments, time.Date(2024, 3, 14, 9, 30, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("Failed to build payment file: %v", err)
+	}
+	file := string(data)
+	for _, want := range []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		`<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03">`,
+		`<MsgId>PR202403001</MsgId>`,
+		`<CreDtTm>2024-03-14T09:30:00</CreDtTm>`,
+		`<NbOfTxs>2</NbOfTxs>`,
+		`<CtrlSum>1000.00</CtrlSum>`,
+		`<ReqdExctnDt>2024-03-15</ReqdExctnDt>`,
+		`<IBAN>DE89370400440532013000</IBAN>`,
+		`<BIC>COBADEFFXXX</BIC>`,
+		`<EndToEndId>PY001</EndToEndId>`,
+		`<InstdAmt Ccy="EUR">980.10</InstdAmt>`,
+		`<Nm>Bank A</Nm>`,
+		`<IBAN>FR1420041010050500013M02606</IBAN>`,
+		`<Ustrd>PI001,PI002</Ustrd>`,
+		`<InstdAmt Ccy="EUR">19.90</InstdAmt>`,
+		`<Id>6222020012345678</Id>`,
+		`<Ustrd>PI003</Ustrd>`,
+	} {
+		if !strings.Contains(file, want) {
+			t.Errorf("Expected payment file to contain %s", want)
+		}
+	}
+	if strings.Count(file, "<CtrlSum>1000.00</CtrlSum>") != 2 {
+		t.Errorf("Expected control sum in group header and payment information")
+	}
+	if strings.Count(file, "<CdtrAgt>") != 1 {
+		t.Errorf("Expected creditor agent only for vendor with bank name")
+	}
+
+	// 没有BIC时付款方开户行导出为NOTPROVIDED
+	bank.BIC = ""
+	data, err = pain001PaymentFile(run, bank, payments, time.Now())
+	if err != nil {
+		t.Fatalf("Failed to build payment file: %v", err)
+	}
+	if !strings.Contains(string(data), "<DbtrAgt>\n        <FinInstnId>\n          <Othr>\n            <Id>NOTPROVIDED</Id>") {
+		t.Errorf("Expected debtor agent NOTPROVIDED without BIC")
+	}
+
+	// 收款方没有银行账号时不能导出
+	payments[1].Vendor.BankAccount = ""
+	if _, err := pain001PaymentFile(run, bank, payments, time.Now()); err == nil {
+		t.Errorf("Expected error for vendor without bank account")
+	}
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wu136995/ginx/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 会计期间状态
+const (
+	periodStatusOpen       = "open"
+	periodStatusSoftClosed = "soft_closed"
+	periodStatusClosed     = "closed"
+)
+
+// 期末结转及反结转凭证的来源类型，不计入利润表和现金流量表的损益发生额
+const (
+	journalReferencePeriodClose  = "period_close"
+	journalReferencePeriodReopen = "period_reopen"
+)
+
+// checkPeriodOpen 校验业务日期所在会计期间允许记账，未设置会计期间的日期不受限制；
+// 软关账期间只允许财务凭证记账，业务单据需传入allowSoftClosed为false。
+// 在事务中调用时对期间加共享锁直至事务结束，与关账、反结账的排他锁互斥，记账不会提交到正在关闭的期间
+func checkPeriodOpen(db *gorm.DB, date time.Time, allowSoftClosed bool) error {
+	day := date.Format("2006-01-02")
+
+	var period models.FinancePeriod
+	result := db.Where("start_date <= ? AND end_date >= ?", day, day).Limit(1).Find(&period)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil
+	}
+
+	// 按主键加锁后重新读取状态，只锁定该期间
+	if err := db.Clauses(clause.Locking{Strength: "SHARE"}).First(&period, "id = ?", period.ID).Error; err != nil {
+		return err
+	}
+
+	switch period.Status {
+	case periodStatusClosed:
+		return fmt.Errorf("accounting period %s is closed, date %s is not allowed", period.Name, day)
+	case periodStatusSoftClosed:
+		if !allowSoftClosed {
+			return fmt.Errorf("accounting period %s is soft-closed, date %s is not allowed", period.Name, day)
+		}
+	}
+
+	return nil
+}
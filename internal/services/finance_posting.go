@@ -0,0 +1,268 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/utils"
+	"gorm.io/gorm"
+)
+
+// 自动记账单据类型
+const (
+	postingDocumentSalesInvoice    = "sales_invoice"
+	postingDocumentPurchaseInvoice = "purchase_invoice"
+	postingDocumentInventory       = "inventory_transaction"
+)
+
+// postingRuleStatusActive 启用状态的记账规则参与自动记账
+const postingRuleStatusActive = "active"
+
+// postingAmountFields 各单据类型可供记账规则引用的金额字段
+var postingAmountFields = map[string][]string{
+	postingDocumentSalesInvoice:    {"total", "net", "tax"},
+	postingDocumentPurchaseInvoice: {"total", "net", "tax"},
+	postingDocumentInventory:       {"cost", "variance"},
+	postingDocumentFXRevaluation:   {"receivable", "payable"},
+	postingDocumentSalesReceipt:    {"amount", "write_off", "exchange"},
+	postingDocumentSalesReturn:     {"total", "net", "tax"},
+	postingDocumentPurchasePayment: {"amount", "discount", "exchange"},
+}
+
+// postingDocument 待生成凭证的业务单据，Amounts为本位币金额，金额为负时对应规则借贷方向互换；
+// 外币单据的CurrencyAmounts记录同一字段的原币金额
+type postingDocument struct {
+	DocumentType    string
+	Event           string
+	ReferenceID     string
+	DocumentNo      string
+	Date            time.Time
+	Description     string
+	Currency        string
+	ExchangeRate    float64
+	Amounts         map[string]float64
+	CurrencyAmounts map[string]float64
+	CreatedBy       string
+}
+
+// postDocument 按单据类型和事件匹配启用的记账规则生成已过账凭证，未配置规则或金额均为零时不生成凭证
+func postDocument(tx *gorm.DB, document postingDocument) (*models.FinanceJournal, error) {
+	var rules []models.FinancePostingRule
+	result := tx.Where("document_type = ? AND event = ? AND status = ?", document.DocumentType, document.Event, postingRuleStatusActive).
+		Order("sequence ASC, created_at ASC").
+		Find(&rules)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	accounts, err := postingAccounts(tx, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	createdBy := document.CreatedBy
+	if createdBy == "" {
+		createdBy = "system"
+	}
+	journal := models.FinanceJournal{
+		JournalNo:     autoJournalNo("AJ"),
+		Date:          document.Date,
+		Reference:     document.DocumentNo,
+		ReferenceType: document.DocumentType,
+		ReferenceID:   document.ReferenceID,
+		Description:   document.Description,
+		Currency:      document.Currency,
+		ExchangeRate:  document.ExchangeRate,
+		CreatedBy:     createdBy,
+	}
+	for _, rule := range rules {
+		amount := roundAmount(document.Amounts[rule.AmountField])
+		if amount == 0 {
+			continue
+		}
+		currencyAmount := math.Abs(roundAmount(document.CurrencyAmounts[rule.AmountField]))
+		debitAccountID, creditAccountID := accounts[rule.DebitAccountCode], accounts[rule.CreditAccountCode]
+		if amount < 0 {
+			debitAccountID, creditAccountID, amount = creditAccountID, debitAccountID, -amount
+		}
+		journal.Items = append(journal.Items,
+			models.FinanceJournalItem{AccountID: debitAccountID, Description: rule.Description, Debit: amount, CurrencyAmount: currencyAmount},
+			models.FinanceJournalItem{AccountID: creditAccountID, Description: rule.Description, Credit: amount, CurrencyAmount: currencyAmount},
+		)
+	}
+	if len(journal.Items) == 0 {
+		return nil, nil
+	}
+
+	if err := createPostedJournal(tx, &journal); err != nil {
+		return nil, fmt.Errorf("failed to post %s %s: %w", document.DocumentType, document.DocumentNo, err)
+	}
+	return &journal, nil
+}
+
+// reverseDocumentJournals 冲销单据尚未冲销的自动凭证，用于单据作废或删除
+func reverseDocumentJournals(tx *gorm.DB, documentType, referenceID string, date time.Time, createdBy string) error {
+	reversed := tx.Model(&models.FinanceJournal{}).
+		Select("reversal_of").
+		Where("reference_type = ? AND reference_id = ? AND reversal_of <> ''", documentType, referenceID)
+
+	var journals []models.FinanceJournal
+	result := tx.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("line_no ASC")
+	}).
+		Where("reference_type = ? AND reference_id = ? AND status = ?", documentType, referenceID, journalStatusPosted).
+		Where("(reversal_of IS NULL OR reversal_of = '')").
+		Where("id NOT IN (?)", reversed).
+		Order("journal_no ASC").
+		Find(&journals)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if createdBy == "" {
+		createdBy = "system"
+	}
+	for _, journal := range journals {
+		if _, err := reverseJournal(tx, journal, autoJournalNo("RV"), date, documentType, referenceID, createdBy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// postingAccounts 将规则引用的科目编码解析为科目ID
+func postingAccounts(db *gorm.DB, rules []models.FinancePostingRule) (map[string]string, error) {
+	codes := make([]string, 0, len(rules)*2)
+	for _, rule := range rules {
+		codes = append(codes, rule.DebitAccountCode, rule.CreditAccountCode)
+	}
+
+	var accounts []models.FinanceAccount
+	if err := db.Where("code IN ?", codes).Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+	ids := make(map[string]string, len(accounts))
+	for _, account := range accounts {
+		ids[account.Code] = account.ID
+	}
+	for _, code := range codes {
+		if _, ok := ids[code]; !ok {
+			return nil, fmt.Errorf("posting rule account %s not found", code)
+		}
+	}
+
+	return ids, nil
+}
+
+// validatePostingRule 校验记账规则的金额字段和借贷科目
+func validatePostingRule(db *gorm.DB, rule models.FinancePostingRule) error {
+	valid := false
+	for _, field := range postingAmountFields[rule.DocumentType] {
+		if field == rule.AmountField {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("amount field %s is not available for %s, expected one of: %s",
+			rule.AmountField, rule.DocumentType, strings.Join(postingAmountFields[rule.DocumentType], ", "))
+	}
+	if rule.DebitAccountCode == rule.CreditAccountCode {
+		return errors.New("debit and credit account must differ")
+	}
+
+	_, err := postingAccounts(db, []models.FinancePostingRule{rule})
+	return err
+}
+
+// autoJournalNo 生成系统凭证编号，同一秒内批量生成时以随机后缀区分
+func autoJournalNo(prefix string) string {
+	return prefix + time.Now().Format("060102150405") + strings.ToUpper(utils.GenerateID()[:6])
+}
+
+// inventoryPostingDocument 库存交易按交易类型匹配规则，盘点调整和成本重估按金额方向区分盈亏事件
+func inventoryPostingDocument(transaction models.InventoryTransaction) postingDocument {
+	event := transaction.Type
+	switch transaction.Type {
+	case "adjustment", "revaluation":
+		if transaction.TotalCost >= 0 {
+			event += "_gain"
+		} else {
+			event += "_loss"
+		}
+	}
+
+	return postingDocument{
+		DocumentType: postingDocumentInventory,
+		Event:        event,
+		ReferenceID:  transaction.ID,
+		DocumentNo:   transaction.TransactionNo,
+		Date:         transaction.TransactionDate,
+		Description:  fmt.Sprintf("库存交易%s", transaction.TransactionNo),
+		Amounts: map[string]float64{
+			"cost":     math.Abs(transaction.TotalCost),
+			"variance": transaction.CostVariance,
+		},
+		CreatedBy: transaction.CreatedBy,
+	}
+}
+
+// purchaseInvoicePostingDocument 采购发票审核时按本位币金额生成凭证，net为不含税金额
+func purchaseInvoicePostingDocument(invoice models.PurchaseInvoice) postingDocument {
+	baseTotal, baseTax := toBaseAmount(invoice.TotalAmount, invoice.ExchangeRate), toBaseAmount(invoice.TaxAmount, invoice.ExchangeRate)
+	return postingDocument{
+		DocumentType: postingDocumentPurchaseInvoice,
+		Event:        "verify",
+		ReferenceID:  invoice.ID,
+		DocumentNo:   invoice.InvoiceNo,
+		Date:         invoice.InvoiceDate,
+		Description:  fmt.Sprintf("采购发票%s", invoice.InvoiceNo),
+		Currency:     invoice.Currency,
+		ExchangeRate: invoice.ExchangeRate,
+		Amounts: map[string]float64{
+			"total": baseTotal,
+			"tax":   baseTax,
+			"net":   roundAmount(baseTotal - baseTax),
+		},
+		CurrencyAmounts: map[string]float64{
+			"total": invoice.TotalAmount,
+			"tax":   invoice.TaxAmount,
+			"net":   roundAmount(invoice.TotalAmount - invoice.TaxAmount),
+		},
+		CreatedBy: invoice.UpdatedBy,
+	}
+}
+
+// salesInvoicePostingDocument 销售发票开具时按本位币金额生成凭证，tax为销项税额，net为不含税金额
+func salesInvoicePostingDocument(invoice models.SalesInvoice) postingDocument {
+	baseTotal, baseTax := toBaseAmount(invoice.TotalAmount, invoice.ExchangeRate), toBaseAmount(invoice.TaxAmount, invoice.ExchangeRate)
+	return postingDocument{
+		DocumentType: postingDocumentSalesInvoice,
+		Event:        "issue",
+		ReferenceID:  invoice.ID,
+		DocumentNo:   invoice.InvoiceNo,
+		Date:         invoice.InvoiceDate,
+		Description:  fmt.Sprintf("销售发票%s", invoice.InvoiceNo),
+		Currency:     invoice.Currency,
+		ExchangeRate: invoice.ExchangeRate,
+		Amounts: map[string]float64{
+			"total": baseTotal,
+			"tax":   baseTax,
+			"net":   roundAmount(baseTotal - baseTax),
+		},
+		CurrencyAmounts: map[string]float64{
+			"total": invoice.TotalAmount,
+			"tax":   invoice.TaxAmount,
+			"net":   roundAmount(invoice.TotalAmount - invoice.TaxAmount),
+		},
+		CreatedBy: invoice.UpdatedBy,
+	}
+}
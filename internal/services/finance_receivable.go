@@ -0,0 +1,938 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/config"
+	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/query"
+	"github.com/wu136995/ginx/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 收款单状态，只有已处理的收款冲减发票余额
+const (
+	receiptStatusDraft     = "draft"
+	receiptStatusSubmitted = "submitted"
+	receiptStatusApproved  = "approved"
+	receiptStatusRejected  = "rejected"
+	receiptStatusProcessed = "processed"
+)
+
+// 客户收款的记账单据类型及事件，receive按收款本位币金额记账（含未核销的预收款），
+// write_off按核销的坏账或折让本位币金额记账，exchange按外币发票核销的已实现汇兑差额记账
+const (
+	postingDocumentSalesReceipt = "sales_receipt"
+	salesReceiptEventReceive    = "receive"
+	salesReceiptEventWriteOff   = "write_off"
+	salesReceiptEventExchange   = "exchange"
+)
+
+// 账龄计算基准
+const (
+	agingBasisDueDate     = "due_date"
+	agingBasisInvoiceDate = "invoice_date"
+)
+
+// salesInvoiceBalance 销售发票未结清金额，已收款、已核销坏账和退货贷项均冲减余额
+func salesInvoiceBalance(invoice models.SalesInvoice) float64 {
+	return roundAmount(invoice.TotalAmount - invoice.PaidAmount - invoice.WriteOffAmount - invoice.CreditedAmount)
+}
+
+// receiptSettlement 计算收款核销一张销售发票的本位币金额：amount为按收款单本位币金额分摊的收款金额，
+// writeOff为按发票入账金额分摊的坏账核销金额，exchange为冲减的应收账面金额与收款本位币金额之差，正数为汇兑损失。
+// 应收按发票入账汇率冲减，三者之和等于本次冲减的应收账面金额；applied为收款单此前已核销的原币金额，invoice为核销前的发票
+func receiptSettlement(receipt models.FinanceReceipt, applied float64, invoice models.SalesInvoice, allocation models.FinanceReceiptAllocation) (amount, writeOff, exchange float64) {
+	invoiceBase := toBaseAmount(invoice.TotalAmount, invoice.ExchangeRate)
+	previous := roundAmount(invoice.TotalAmount - salesInvoiceBalance(invoice))
+	carrying := settledBaseAmount(invoiceBase, invoice.TotalAmount, previous, allocation.Amount)
+	amount = settledBaseAmount(receipt.BaseAmount, receipt.Amount, applied, allocation.Amount)
+	writeOff = settledBaseAmount(invoiceBase, invoice.TotalAmount, previous+allocation.Amount, allocation.WriteOffAmount)
+	return amount, writeOff, roundAmount(carrying - amount)
+}
+
+// settleSalesInvoice 按结清金额刷新销售发票收款状态
+func settleSalesInvoice(invoice *models.SalesInvoice) {
+	switch {
+	case salesInvoiceBalance(*invoice) <= 0:
+		invoice.Status = "paid"
+	case invoice.PaidAmount > 0 || invoice.WriteOffAmount > 0 || invoice.CreditedAmount > 0:
+		invoice.Status = "partially_paid"
+	default:
+		invoice.Status = "unpaid"
+	}
+}
+
+// receiptListSpec 收款单列表查询白名单
+var receiptListSpec = query.NewSpec("-date,-code",
+	query.Text("receipt_no").As("code"),
+	query.Date("receipt_date").As("date"),
+	query.Text("customer_id"),
+	query.Number("amount"),
+	query.Text("currency"),
+	query.Text("payment_method"),
+	query.Text("status"),
+)
+
+// 收款管理方法
+func (s *financeService) GetReceiptList(params query.Params) (*query.Page[schemas.CollectionResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取收款单及核销明细
+	var receipts []models.FinanceReceipt
+	total, err := query.Find(s.db, params, receiptListSpec, &receipts, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Customer").Preload("Allocations.Invoice")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	responses := make([]schemas.CollectionResponse, len(receipts))
+	for i, receipt := range receipts {
+		responses[i] = collectionResponse(receipt)
+	}
+
+	return query.NewPage(responses, total, params), nil
+}
+
+func (s *financeService) GetReceiptDetail(id string) (*schemas.CollectionResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取收款单详情
+	var receipt models.FinanceReceipt
+	result := s.db.Preload("Customer").Preload("Allocations.Invoice").First(&receipt, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := collectionResponse(receipt)
+	return &response, nil
+}
+
+func (s *financeService) CreateReceipt(req schemas.CollectionCreateRequest) (*schemas.CollectionResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 解析收款日期
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	// 未指定收款单编号时自动生成
+	receiptNo := req.Code
+	if receiptNo == "" {
+		receiptNo = autoJournalNo("RC")
+	}
+
+	receipt := models.FinanceReceipt{
+		ID:            utils.GenerateID(),
+		ReceiptNo:     receiptNo,
+		ReceiptDate:   date,
+		CustomerID:    req.CustomerID,
+		Amount:        roundAmount(req.Amount),
+		PaymentMethod: req.PaymentMethod,
+		BankAccountID: req.BankAccountID,
+		Reference:     req.Reference,
+		Description:   req.Description,
+		Remarks:       req.Remarks,
+		Status:        receiptStatusDraft,
+		CreatedBy:     req.CreatedBy,
+		UpdatedBy:     req.CreatedBy,
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// 币种为空时取客户默认币种
+		var customer models.SalesCustomer
+		if err := tx.First(&customer, "id = ?", receipt.CustomerID).Error; err != nil {
+			return err
+		}
+		currency, rate, err := documentCurrency(tx, req.Currency, customer.Currency, req.ExchangeRate, receipt.ReceiptDate)
+		if err != nil {
+			return err
+		}
+		receipt.Currency, receipt.ExchangeRate = currency, rate
+		receipt.BaseAmount = toBaseAmount(receipt.Amount, rate)
+
+		allocations, err := receiptAllocations(req.Allocations, receipt.ID, receipt.ReceiptDate, req.CreatedBy)
+		if err != nil {
+			return err
+		}
+		if err := checkReceiptAllocations(tx, receipt, allocations); err != nil {
+			return err
+		}
+
+		if err := tx.Create(&receipt).Error; err != nil {
+			return err
+		}
+		if len(allocations) > 0 {
+			return tx.Omit(clause.Associations).Create(&allocations).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetReceiptDetail(receipt.ID)
+}
+
+func (s *financeService) UpdateReceipt(id string, req schemas.CollectionUpdateRequest) (*schemas.CollectionResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// 从数据库读取收款单
+		var receipt models.FinanceReceipt
+		if err := tx.Preload("Allocations").First(&receipt, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		// 只有草稿或已驳回的收款单可以修改
+		if receipt.Status != receiptStatusDraft && receipt.Status != receiptStatusRejected {
+			return fmt.Errorf("receipt in status %s cannot be modified", receipt.Status)
+		}
+
+		// 更新收款单字段，日期、客户、币种或汇率变化时重新确定汇率
+		rerate := false
+		if req.Date != "" {
+			date, err := time.Parse("2006-01-02", req.Date)
+			if err != nil {
+				return err
+			}
+			receipt.ReceiptDate = date
+			rerate = true
+		}
+		if req.CustomerID != "" && req.CustomerID != receipt.CustomerID {
+			receipt.CustomerID = req.CustomerID
+			receipt.Currency = ""
+			rerate = true
+		}
+		if req.Amount != nil {
+			receipt.Amount = roundAmount(*req.Amount)
+		}
+		if req.Currency != "" {
+			receipt.Currency = req.Currency
+			rerate = true
+		}
+		override := 0.0
+		if req.ExchangeRate != nil {
+			override = *req.ExchangeRate
+			rerate = true
+		}
+		if req.PaymentMethod != "" {
+			receipt.PaymentMethod = req.PaymentMethod
+		}
+		if req.BankAccountID != "" {
+			receipt.BankAccountID = req.BankAccountID
+		}
+		if req.Reference != "" {
+			receipt.Reference = req.Reference
+		}
+		if req.Description != "" {
+			receipt.Description = req.Description
+		}
+		if req.Remarks != "" {
+			receipt.Remarks = req.Remarks
+		}
+		receipt.UpdatedBy = req.UpdatedBy
+
+		if rerate {
+			var customer models.SalesCustomer
+			if err := tx.First(&customer, "id = ?", receipt.CustomerID).Error; err != nil {
+				return err
+			}
+			currency, rate, err := documentCurrency(tx, receipt.Currency, customer.Currency, override, receipt.ReceiptDate)
+			if err != nil {
+				return err
+			}
+			receipt.Currency, receipt.ExchangeRate = currency, rate
+		}
+		receipt.BaseAmount = toBaseAmount(receipt.Amount, receipt.ExchangeRate)
+
+		// 传入核销明细时整体替换，否则按新的收款信息重新校验原明细
+		allocations := receipt.Allocations
+		if req.Allocations != nil {
+			var err error
+			allocations, err = receiptAllocations(req.Allocations, receipt.ID, receipt.ReceiptDate, req.UpdatedBy)
+			if err != nil {
+				return err
+			}
+		}
+		for i := range allocations {
+			allocations[i].AllocationDate = receipt.ReceiptDate
+		}
+		if err := checkReceiptAllocations(tx, receipt, allocations); err != nil {
+			return err
+		}
+
+		if err := tx.Where("receipt_id = ?", receipt.ID).Delete(&models.FinanceReceiptAllocation{}).Error; err != nil {
+			return err
+		}
+		if len(allocations) > 0 {
+			for i := range allocations {
+				allocations[i].ID = utils.GenerateID()
+			}
+			if err := tx.Omit(clause.Associations).Create(&allocations).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Omit("Allocations").Save(&receipt).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetReceiptDetail(id)
+}
+
+func (s *financeService) DeleteReceipt(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		// 从数据库读取收款单
+		var receipt models.FinanceReceipt
+		if err := tx.First(&receipt, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		// 只有草稿或已驳回的收款单可以删除
+		if receipt.Status != receiptStatusDraft && receipt.Status != receiptStatusRejected {
+			return fmt.Errorf("receipt in status %s cannot be deleted", receipt.Status)
+		}
+
+		if err := tx.Where("receipt_id = ?", receipt.ID).Delete(&models.FinanceReceiptAllocation{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&receipt).Error
+	})
+}
+
+func (s *financeService) SubmitReceipt(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	return s.transitionReceipt(id, []string{receiptStatusDraft, receiptStatusRejected}, func(tx *gorm.DB, receipt *models.FinanceReceipt) error {
+		// 提交时按发票当前余额重新校验核销明细
+		if err := checkReceiptAllocations(tx, *receipt, receipt.Allocations); err != nil {
+			return err
+		}
+		receipt.Status = receiptStatusSubmitted
+		return nil
+	})
+}
+
+func (s *financeService) ApproveReceipt(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	return s.transitionReceipt(id, []string{receiptStatusSubmitted}, func(tx *gorm.DB, receipt *models.FinanceReceipt) error {
+		receipt.Status = receiptStatusApproved
+		return nil
+	})
+}
+
+func (s *financeService) RejectReceipt(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	return s.transitionReceipt(id, []string{receiptStatusSubmitted, receiptStatusApproved}, func(tx *gorm.DB, receipt *models.FinanceReceipt) error {
+		receipt.Status = receiptStatusRejected
+		return nil
+	})
+}
+
+// ProcessReceipt 处理已审批的收款：生成收款凭证，并按核销明细冲减发票余额
+func (s *financeService) ProcessReceipt(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	return s.transitionReceipt(id, []string{receiptStatusApproved}, func(tx *gorm.DB, receipt *models.FinanceReceipt) error {
+		return processReceipt(tx, receipt, receipt.Allocations, "system")
+	})
+}
+
+// ApplyReceipt 将已处理收款的未核销金额核销到发票，或对发票余额核销坏账
+func (s *financeService) ApplyReceipt(id string, req schemas.CollectionApplyRequest) (*schemas.CollectionResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	date := time.Now()
+	if req.Date != "" {
+		var err error
+		date, err = time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			return nil, err
+		}
+	}
+	createdBy := req.CreatedBy
+	if createdBy == "" {
+		createdBy = "system"
+	}
+
+	err := s.transitionReceipt(id, []string{receiptStatusProcessed}, func(tx *gorm.DB, receipt *models.FinanceReceipt) error {
+		if date.Before(receipt.ReceiptDate) {
+			return errors.New("allocation date cannot be earlier than receipt date")
+		}
+		if err := checkPeriodOpen(tx, date, false); err != nil {
+			return err
+		}
+		allocations, err := receiptAllocations(req.Allocations, receipt.ID, date, createdBy)
+		if err != nil {
+			return err
+		}
+		if err := applyReceiptAllocations(tx, receipt, allocations, createdBy); err != nil {
+			return err
+		}
+		receipt.UpdatedBy = createdBy
+		return tx.Omit(clause.Associations).Create(&allocations).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetReceiptDetail(id)
+}
+
+// transitionReceipt 在事务中锁定收款单，校验当前状态后执行状态变更
+func (s *financeService) transitionReceipt(id string, allowed []string, apply func(tx *gorm.DB, receipt *models.FinanceReceipt) error) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var receipt models.FinanceReceipt
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("Allocations").
+			First(&receipt, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		permitted := false
+		for _, status := range allowed {
+			if receipt.Status == status {
+				permitted = true
+				break
+			}
+		}
+		if !permitted {
+			return fmt.Errorf("receipt in status %s cannot be changed", receipt.Status)
+		}
+
+		if err := apply(tx, &receipt); err != nil {
+			return err
+		}
+
+		return tx.Omit("Allocations").Save(&receipt).Error
+	})
+}
+
+// processReceipt 生成收款凭证并核销发票，收款单状态改为已处理；allocations为收款单已保存的核销明细
+func processReceipt(tx *gorm.DB, receipt *models.FinanceReceipt, allocations []models.FinanceReceiptAllocation, processedBy string) error {
+	if err := checkPeriodOpen(tx, receipt.ReceiptDate, false); err != nil {
+		return err
+	}
+
+	if _, err := postDocument(tx, postingDocument{
+		DocumentType:    postingDocumentSalesReceipt,
+		Event:           salesReceiptEventReceive,
+		ReferenceID:     receipt.ID,
+		DocumentNo:      receipt.ReceiptNo,
+		Date:            receipt.ReceiptDate,
+		Description:     fmt.Sprintf("客户收款%s", receipt.ReceiptNo),
+		Currency:        receipt.Currency,
+		ExchangeRate:    receipt.ExchangeRate,
+		Amounts:         map[string]float64{"amount": receipt.BaseAmount},
+		CurrencyAmounts: map[string]float64{"amount": receipt.Amount},
+		CreatedBy:       processedBy,
+	}); err != nil {
+		return err
+	}
+
+	receipt.AppliedAmount = 0
+	if err := applyReceiptAllocations(tx, receipt, allocations, processedBy); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	receipt.Status = receiptStatusProcessed
+	receipt.ProcessedBy = processedBy
+	receipt.ProcessedAt = &now
+	return nil
+}
+
+// applyReceiptAllocations 锁定并核销发票，累加收款单已核销金额，有坏账核销时生成核销凭证
+func applyReceiptAllocations(tx *gorm.DB, receipt *models.FinanceReceipt, allocations []models.FinanceReceiptAllocation, createdBy string) error {
+	if len(allocations) == 0 {
+		return nil
+	}
+
+	if err := checkSettlementRevaluation(tx, receipt.Currency, allocations[0].AllocationDate); err != nil {
+		return err
+	}
+
+	invoiceIDs := make([]string, len(allocations))
+	for i, allocation := range allocations {
+		invoiceIDs[i] = allocation.InvoiceID
+	}
+	var invoices []models.SalesInvoice
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", invoiceIDs).Find(&invoices).Error; err != nil {
+		return err
+	}
+	invoiceByID := make(map[string]*models.SalesInvoice, len(invoices))
+	for i := range invoices {
+		invoiceByID[invoices[i].ID] = &invoices[i]
+	}
+
+	applied, writeOff, baseWriteOff, exchange := receipt.AppliedAmount, 0.0, 0.0, 0.0
+	for _, allocation := range allocations {
+		invoice, ok := invoiceByID[allocation.InvoiceID]
+		if !ok {
+			return fmt.Errorf("sales invoice %s not found", allocation.InvoiceID)
+		}
+		if err := checkReceiptInvoice(*receipt, *invoice); err != nil {
+			return err
+		}
+		settled := roundAmount(allocation.Amount + allocation.WriteOffAmount)
+		if balance := salesInvoiceBalance(*invoice); settled > balance {
+			return fmt.Errorf("allocation %.2f exceeds balance %.2f of sales invoice %s", settled, balance, invoice.InvoiceNo)
+		}
+		if roundAmount(applied+allocation.Amount) > receipt.Amount {
+			return fmt.Errorf("allocated amount %.2f exceeds receipt amount %.2f", roundAmount(applied+allocation.Amount), receipt.Amount)
+		}
+
+		_, baseWriteOffAmount, exchangeAmount := receiptSettlement(*receipt, applied, *invoice, allocation)
+		invoice.PaidAmount = roundAmount(invoice.PaidAmount + allocation.Amount)
+		invoice.WriteOffAmount = roundAmount(invoice.WriteOffAmount + allocation.WriteOffAmount)
+		settleSalesInvoice(invoice)
+		invoice.UpdatedBy = createdBy
+		applied = roundAmount(applied + allocation.Amount)
+		writeOff += allocation.WriteOffAmount
+		baseWriteOff += baseWriteOffAmount
+		exchange += exchangeAmount
+	}
+	receipt.AppliedAmount = applied
+	for i := range invoices {
+		if err := tx.Omit("Items").Save(&invoices[i]).Error; err != nil {
+			return err
+		}
+	}
+
+	// 坏账核销按发票原汇率折算，凭证记在核销日期
+	if writeOff > 0 {
+		if _, err := postDocument(tx, postingDocument{
+			DocumentType:    postingDocumentSalesReceipt,
+			Event:           salesReceiptEventWriteOff,
+			ReferenceID:     receipt.ID,
+			DocumentNo:      receipt.ReceiptNo,
+			Date:            allocations[0].AllocationDate,
+			Description:     fmt.Sprintf("应收核销%s", receipt.ReceiptNo),
+			Currency:        receipt.Currency,
+			ExchangeRate:    receipt.ExchangeRate,
+			Amounts:         map[string]float64{"write_off": baseWriteOff},
+			CurrencyAmounts: map[string]float64{"write_off": writeOff},
+			CreatedBy:       createdBy,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// 外币发票按入账汇率冲减应收，与收款本位币金额的差额确认为已实现汇兑损益
+	return postRealizedExchange(tx, postingDocument{
+		DocumentType: postingDocumentSalesReceipt,
+		Event:        salesReceiptEventExchange,
+		ReferenceID:  receipt.ID,
+		DocumentNo:   receipt.ReceiptNo,
+		Date:         allocations[0].AllocationDate,
+		Description:  fmt.Sprintf("收款汇兑损益%s", receipt.ReceiptNo),
+		Currency:     receipt.Currency,
+		ExchangeRate: receipt.ExchangeRate,
+		Amounts:      map[string]float64{"exchange": roundAmount(exchange)},
+		CreatedBy:    createdBy,
+	})
+}
+
+// receiptAllocations 将核销明细请求转换为模型，同一发票只能出现一次
+func receiptAllocations(items []schemas.CollectionAllocationRequest, receiptID string, date time.Time, createdBy string) ([]models.FinanceReceiptAllocation, error) {
+	allocations := make([]models.FinanceReceiptAllocation, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		if seen[item.InvoiceID] {
+			return nil, fmt.Errorf("sales invoice %s is allocated more than once", item.InvoiceID)
+		}
+		seen[item.InvoiceID] = true
+		if item.Amount <= 0 && item.WriteOffAmount <= 0 {
+			return nil, fmt.Errorf("allocation to sales invoice %s has no amount", item.InvoiceID)
+		}
+		allocations = append(allocations, models.FinanceReceiptAllocation{
+			ID:             utils.GenerateID(),
+			ReceiptID:      receiptID,
+			InvoiceID:      item.InvoiceID,
+			AllocationDate: date,
+			Amount:         roundAmount(item.Amount),
+			WriteOffAmount: roundAmount(item.WriteOffAmount),
+			CreatedBy:      createdBy,
+			UpdatedBy:      createdBy,
+		})
+	}
+	return allocations, nil
+}
+
+// checkReceiptAllocations 校验未处理收款单的核销明细：发票属于同一客户和币种、核销金额不超过发票余额，
+// 核销的收款金额合计不超过收款金额；没有收款金额时只能核销坏账
+func checkReceiptAllocations(db *gorm.DB, receipt models.FinanceReceipt, allocations []models.FinanceReceiptAllocation) error {
+	if receipt.Amount <= 0 && len(allocations) == 0 {
+		return errors.New("receipt amount must be greater than zero")
+	}
+
+	applied := 0.0
+	for _, allocation := range allocations {
+		var invoice models.SalesInvoice
+		if err := db.First(&invoice, "id = ?", allocation.InvoiceID).Error; err != nil {
+			return err
+		}
+		if err := checkReceiptInvoice(receipt, invoice); err != nil {
+			return err
+		}
+		settled := roundAmount(allocation.Amount + allocation.WriteOffAmount)
+		if balance := salesInvoiceBalance(invoice); settled > balance {
+			return fmt.Errorf("allocation %.2f exceeds balance %.2f of sales invoice %s", settled, balance, invoice.InvoiceNo)
+		}
+		applied += allocation.Amount
+	}
+	if roundAmount(applied) > receipt.Amount {
+		return fmt.Errorf("allocated amount %.2f exceeds receipt amount %.2f", roundAmount(applied), receipt.Amount)
+	}
+	return nil
+}
+
+// checkReceiptInvoice 校验发票可被收款单核销
+func checkReceiptInvoice(receipt models.FinanceReceipt, invoice models.SalesInvoice) error {
+	if invoice.CustomerID != receipt.CustomerID {
+		return fmt.Errorf("sales invoice %s belongs to another customer", invoice.InvoiceNo)
+	}
+	if invoice.Status == "cancelled" {
+		return fmt.Errorf("sales invoice %s is cancelled", invoice.InvoiceNo)
+	}
+	currency := invoice.Currency
+	if currency == "" {
+		currency = baseCurrency()
+	}
+	if currency != receipt.Currency {
+		return fmt.Errorf("sales invoice %s currency %s does not match receipt currency %s", invoice.InvoiceNo, currency, receipt.Currency)
+	}
+	return nil
+}
+
+// collectionResponse 将收款单模型转换为响应格式
+func collectionResponse(receipt models.FinanceReceipt) schemas.CollectionResponse {
+	allocations := make([]schemas.CollectionAllocationResponse, len(receipt.Allocations))
+	writeOff := 0.0
+	for i, allocation := range receipt.Allocations {
+		allocations[i] = schemas.CollectionAllocationResponse{
+			ID:             allocation.ID,
+			InvoiceID:      allocation.InvoiceID,
+			InvoiceNo:      allocation.Invoice.InvoiceNo,
+			AllocationDate: allocation.AllocationDate.Format("2006-01-02"),
+			Amount:         allocation.Amount,
+			WriteOffAmount: allocation.WriteOffAmount,
+		}
+		writeOff += allocation.WriteOffAmount
+	}
+
+	// 未处理的收款单按核销明细预计核销金额
+	applied := receipt.AppliedAmount
+	if receipt.Status != receiptStatusProcessed {
+		applied = 0
+		for _, allocation := range receipt.Allocations {
+			applied += allocation.Amount
+		}
+	}
+
+	processedAt := ""
+	if receipt.ProcessedAt != nil {
+		processedAt = receipt.ProcessedAt.Format("2006-01-02 15:04:05")
+	}
+
+	return schemas.CollectionResponse{
+		ID:              receipt.ID,
+		Code:            receipt.ReceiptNo,
+		Date:            receipt.ReceiptDate.Format("2006-01-02"),
+		CustomerID:      receipt.CustomerID,
+		CustomerName:    receipt.Customer.Name,
+		Amount:          receipt.Amount,
+		Currency:        receipt.Currency,
+		ExchangeRate:    receipt.ExchangeRate,
+		LocalAmount:     receipt.BaseAmount,
+		AppliedAmount:   roundAmount(applied),
+		UnappliedAmount: roundAmount(receipt.Amount - applied),
+		WriteOffAmount:  roundAmount(writeOff),
+		PaymentMethod:   receipt.PaymentMethod,
+		BankAccountID:   receipt.BankAccountID,
+		Status:          receipt.Status,
+		Reference:       receipt.Reference,
+		Description:     receipt.Description,
+		Remarks:         receipt.Remarks,
+		Allocations:     allocations,
+		ProcessedAt:     processedAt,
+		CreatedAt:       receipt.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:       receipt.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+// GetAccountsReceivableReport 按客户统计截至指定日期的应收账龄，只计入当日及之前已处理的收款核销和贷项冲减，
+// 发票、预收款和贷项余额均按原汇率折算为本位币
+func (s *financeService) GetAccountsReceivableReport(req schemas.AgingReportRequest) (*schemas.AgingReportResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 解析报表日期、账龄基准和分段
+	date := time.Now()
+	if req.Date != "" {
+		var err error
+		date, err = time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			return nil, err
+		}
+	}
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	day := date.Format("2006-01-02")
+	basis := req.Basis
+	if basis == "" {
+		basis = agingBasisDueDate
+	}
+	bounds, err := agingBounds(req.Buckets)
+	if err != nil {
+		return nil, err
+	}
+
+	// 读取截至报表日已开具的发票
+	invoiceQuery := s.db.Preload("Customer").Where("invoice_date <= ? AND status <> ?", day, "cancelled")
+	if req.PartnerID != "" {
+		invoiceQuery = invoiceQuery.Where("customer_id = ?", req.PartnerID)
+	}
+	var invoices []models.SalesInvoice
+	if err := invoiceQuery.Order("invoice_date ASC").Find(&invoices).Error; err != nil {
+		return nil, err
+	}
+
+	// 汇总已处理收款的核销金额，区分报表日及之前和全部核销
+	type settlement struct {
+		InvoiceID string
+		AsOf      float64
+		Total     float64
+	}
+	var settlements []settlement
+	result := s.db.Model(&models.FinanceReceiptAllocation{}).
+		Select("finance_receipt_allocations.invoice_id AS invoice_id, "+
+			"SUM(CASE WHEN finance_receipt_allocations.allocation_date <= ? THEN finance_receipt_allocations.amount + finance_receipt_allocations.write_off_amount ELSE 0 END) AS as_of, "+
+			"SUM(finance_receipt_allocations.amount + finance_receipt_allocations.write_off_amount) AS total", day).
+		Joins("JOIN finance_receipts ON finance_receipts.id = finance_receipt_allocations.receipt_id AND finance_receipts.deleted_at IS NULL").
+		Where("finance_receipts.status = ?", receiptStatusProcessed).
+		Group("finance_receipt_allocations.invoice_id").
+		Scan(&settlements)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	// 贷项通知单在开具当日冲减原发票
+	var credits []settlement
+	result = s.db.Model(&models.SalesCreditNote{}).
+		Select("invoice_id, "+
+			"SUM(CASE WHEN credit_date <= ? THEN applied_amount ELSE 0 END) AS as_of, "+
+			"SUM(applied_amount) AS total", day).
+		Where("invoice_id <> ? AND applied_amount > 0", "").
+		Group("invoice_id").
+		Scan(&credits)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	settled := make(map[string]settlement, len(settlements))
+	for _, item := range append(settlements, credits...) {
+		existing := settled[item.InvoiceID]
+		existing.InvoiceID = item.InvoiceID
+		existing.AsOf += item.AsOf
+		existing.Total += item.Total
+		settled[item.InvoiceID] = existing
+	}
+
+	items := make(map[string]*schemas.AgingReportItem)
+	item := func(customer models.SalesCustomer, customerID string) *schemas.AgingReportItem {
+		if existing, ok := items[customerID]; ok {
+			return existing
+		}
+		created := &schemas.AgingReportItem{
+			PartnerID:   customerID,
+			PartnerName: customer.Name,
+			Buckets:     make([]float64, len(bounds)+1),
+		}
+		items[customerID] = created
+		return created
+	}
+
+	for _, invoice := range invoices {
+		// 没有核销记录的历史收款视为开票当日已收
+		applied := settled[invoice.ID]
+		legacy := math.Max(invoice.PaidAmount+invoice.WriteOffAmount+invoice.CreditedAmount-applied.Total, 0)
+		open := roundAmount(invoice.TotalAmount - legacy - applied.AsOf)
+		if open <= 0 {
+			continue
+		}
+		amount := toBaseAmount(open, invoice.ExchangeRate)
+
+		row := item(invoice.Customer, invoice.CustomerID)
+		start := invoice.DueDate
+		if basis == agingBasisInvoiceDate {
+			start = invoice.InvoiceDate
+		}
+		days := int(date.Sub(time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)).Hours() / 24)
+		if basis == agingBasisDueDate && days <= 0 {
+			row.Current += amount
+		} else {
+			row.Buckets[agingBucket(bounds, days)] += amount
+		}
+		row.Total += amount
+	}
+
+	// 已处理收款在报表日尚未核销的部分作为预收款
+	var receipts []models.FinanceReceipt
+	receiptQuery := s.db.Preload("Customer").
+		Preload("Allocations", "allocation_date <= ?", day).
+		Where("status = ? AND receipt_date <= ?", receiptStatusProcessed, day)
+	if req.PartnerID != "" {
+		receiptQuery = receiptQuery.Where("customer_id = ?", req.PartnerID)
+	}
+	if err := receiptQuery.Find(&receipts).Error; err != nil {
+		return nil, err
+	}
+	for _, receipt := range receipts {
+		unapplied := receipt.Amount
+		for _, allocation := range receipt.Allocations {
+			unapplied -= allocation.Amount
+		}
+		if roundAmount(unapplied) <= 0 {
+			continue
+		}
+		item(receipt.Customer, receipt.CustomerID).Unapplied += toBaseAmount(unapplied, receipt.ExchangeRate)
+	}
+
+	// 报表日及之前开具的贷项通知单未冲减发票的部分作为客户贷项余额
+	var notes []models.SalesCreditNote
+	noteQuery := s.db.Preload("Customer").Where("credit_date <= ? AND total_amount > applied_amount", day)
+	if req.PartnerID != "" {
+		noteQuery = noteQuery.Where("customer_id = ?", req.PartnerID)
+	}
+	if err := noteQuery.Find(&notes).Error; err != nil {
+		return nil, err
+	}
+	for _, note := range notes {
+		item(note.Customer, note.CustomerID).Unapplied += toBaseAmount(note.TotalAmount-note.AppliedAmount, note.ExchangeRate)
+	}
+
+	// 舍入并按客户名称排序
+	response := &schemas.AgingReportResponse{
+		Date:    day,
+		Basis:   basis,
+		Buckets: agingLabels(bounds, basis),
+		Items:   make([]schemas.AgingReportItem, 0, len(items)),
+	}
+	for _, row := range items {
+		row.Current = roundAmount(row.Current)
+		for i := range row.Buckets {
+			row.Buckets[i] = roundAmount(row.Buckets[i])
+		}
+		row.Total = roundAmount(row.Total)
+		row.Unapplied = roundAmount(row.Unapplied)
+		row.Balance = roundAmount(row.Total - row.Unapplied)
+		response.Total += row.Total
+		response.Items = append(response.Items, *row)
+	}
+	response.Total = roundAmount(response.Total)
+	sort.Slice(response.Items, func(i, j int) bool {
+		if response.Items[i].PartnerName != response.Items[j].PartnerName {
+			return response.Items[i].PartnerName < response.Items[j].PartnerName
+		}
+		return response.Items[i].PartnerID < response.Items[j].PartnerID
+	})
+
+	return response, nil
+}
+
+// agingBounds 解析逗号分隔的账龄分段天数上限，为空时取配置，分段必须为递增的正整数
+func agingBounds(buckets string) ([]int, error) {
+	var bounds []int
+	if buckets == "" {
+		bounds = append(bounds, config.GetAppConfig().Finance.AgingBuckets...)
+		if len(bounds) == 0 {
+			bounds = []int{30, 60, 90}
+		}
+	} else {
+		for _, part := range strings.Split(buckets, ",") {
+			bound, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return nil, fmt.Errorf("invalid aging bucket %q", part)
+			}
+			bounds = append(bounds, bound)
+		}
+	}
+	for i, bound := range bounds {
+		if bound <= 0 || (i > 0 && bound <= bounds[i-1]) {
+			return nil, errors.New("aging buckets must be increasing positive days")
+		}
+	}
+	return bounds, nil
+}
+
+// agingBucket 返回账龄天数所在分段的下标，超过最后一个上限时为最后的超期段
+func agingBucket(bounds []int, days int) int {
+	for i, bound := range bounds {
+		if days <= bound {
+			return i
+		}
+	}
+	return len(bounds)
+}
+
+// agingLabels 生成账龄分段名称，如0-30、31-60、90+；按到期日计算时首段从1天开始
+func agingLabels(bounds []int, basis string) []string {
+	labels := make([]string, 0, len(bounds)+1)
+	from := 0
+	if basis == agingBasisDueDate {
+		from = 1
+	}
+	for _, bound := range bounds {
+		labels = append(labels, fmt.Sprintf("%d-%d", from, bound))
+		from = bound + 1
+	}
+	return append(labels, fmt.Sprintf("%d+", bounds[len(bounds)-1]))
+}
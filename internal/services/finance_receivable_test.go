@@ -0,0 +1,79 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/wu136995/ginx/internal/models"
+)
+
+// TestReceiptSettlement 测试外币发票按不同汇率分次收款后应收在本位币上结清，差额确认为汇兑损益
+func TestReceiptSettlement(t *testing.T) {
+	// 发票1000美元，入账汇率7.0，应收7000
+	invoice := models.SalesInvoice{InvoiceNo: "SI001", TotalAmount: 1000, Currency: "USD", ExchangeRate: 7.0}
+	receivable := toBaseAmount(invoice.TotalAmount, invoice.ExchangeRate)
+
+	// 第一笔收款600美元（汇率7.2）全部核销；第二笔收款500美元（汇率6.9）核销333.33美元并核销坏账66.67美元，其余为预收款
+	first := models.FinanceReceipt{ReceiptNo: "RC001", Amount: 600, Currency: "USD", ExchangeRate: 7.2, BaseAmount: 4320}
+	second := models.FinanceReceipt{ReceiptNo: "RC002", Amount: 500, Currency: "USD", ExchangeRate: 6.9, BaseAmount: 3450}
+	steps := []struct {
+		receipt    *models.FinanceReceipt
+		allocation models.FinanceReceiptAllocation
+		wantAmount float64
+		wantLoss   float64
+	}{
+		{&first, models.FinanceReceiptAllocation{Amount: 600}, 4320, -120},
+		{&second, models.FinanceReceiptAllocation{Amount: 333.33, WriteOffAmount: 66.67}, 2299.98, 33.33},
+	}
+
+	var cash, exchange float64
+	for _, step := range steps {
+		amount, writeOff, loss := receiptSettlement(*step.receipt, step.receipt.AppliedAmount, invoice, step.allocation)
+		if amount != step.wantAmount || loss != step.wantLoss {
+			t.Errorf("Receipt %s: expected amount %.2f exchange %.2f, got %.2f %.2f",
+				step.receipt.ReceiptNo, step.wantAmount, step.wantLoss, amount, loss)
+		}
+		receivable = roundAmount(receivable - amount - writeOff - loss)
+		cash += amount
+		exchange += loss
+
+		step.receipt.AppliedAmount = roundAmount(step.receipt.AppliedAmount + step.allocation.Amount)
+		invoice.PaidAmount = roundAmount(invoice.PaidAmount + step.allocation.Amount)
+		invoice.WriteOffAmount = roundAmount(invoice.WriteOffAmount + step.allocation.WriteOffAmount)
+	}
+
+	if salesInvoiceBalance(invoice) != 0 {
+		t.Fatalf("Expected invoice settled, balance %.2f", salesInvoiceBalance(invoice))
+	}
+	if receivable != 0 {
+		t.Errorf("Expected receivable balance 0 in base currency, got %.2f", receivable)
+	}
+	if roundAmount(exchange) != -86.67 {
+		t.Errorf("Expected realized exchange gain 86.67, got %.2f", -exchange)
+	}
+
+	// 预收款166.67美元核销到另一张发票后，第二笔收款的本位币金额全部分摊
+	other := models.SalesInvoice{InvoiceNo: "SI002", TotalAmount: 166.67, Currency: "USD", ExchangeRate: 7.0}
+	amount, _, _ := receiptSettlement(second, second.AppliedAmount, other, models.FinanceReceiptAllocation{Amount: 166.67})
+	if roundAmount(amount+2299.98) != second.BaseAmount {
+		t.Errorf("Expected receipt base amount %.2f fully allocated, got %.2f", second.BaseAmount, roundAmount(amount+2299.98))
+	}
+}
+
+// TestSettledBaseAmount 测试按累计比例分摊本位币金额，分次核销不留尾差
+func TestSettledBaseAmount(t *testing.T) {
+	baseTotal, total := 100.0, 3.0
+	var sum, previous float64
+	for i := 0; i < 3; i++ {
+		sum += settledBaseAmount(baseTotal, total, previous, 1)
+		previous++
+	}
+	if roundAmount(sum) != baseTotal {
+		t.Errorf("Expected shares to sum to %.2f, got %.2f", baseTotal, sum)
+	}
+	if got := settledBaseAmount(baseTotal, total, 0, 1); got != 33.33 {
+		t.Errorf("Expected first share 33.33, got %.2f", got)
+	}
+	if got := settledBaseAmount(baseTotal, total, 1, 1); got != 33.34 {
+		t.Errorf("Expected second share 33.34, got %.2f", got)
+	}
+}
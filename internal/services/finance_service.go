@@ -2,85 +2,143 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
 
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/config"
 	"github.com/wu136995/ginx/internal/database"
 	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/query"
+	"github.com/wu136995/ginx/internal/utils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // FinanceService 财务服务接口
 type FinanceService interface {
 	// 账户管理
-	GetAccountList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetAccountDetail(id string) (map[string]interface{}, error)
-	CreateAccount(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateAccount(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetAccountList(params query.Params) (*query.Page[schemas.AccountResponse], error)
+	GetAccountDetail(id string) (*schemas.AccountResponse, error)
+	CreateAccount(req schemas.AccountCreateRequest) (*schemas.AccountResponse, error)
+	UpdateAccount(id string, req schemas.AccountUpdateRequest) (*schemas.AccountResponse, error)
 	DeleteAccount(id string) error
-	GetAccountTransactions(id string, req map[string]interface{}) ([]map[string]interface{}, error)
+	GetAccountTransactions(id string, req schemas.AccountTransactionsRequest) (*schemas.AccountLedgerResponse, error)
 
 	// 凭证管理
-	GetVoucherList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetVoucherDetail(id string) (map[string]interface{}, error)
-	CreateVoucher(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateVoucher(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetVoucherList(req schemas.VoucherListRequest, params query.Params) (*query.Page[schemas.VoucherResponse], error)
+	GetVoucherDetail(id string) (*schemas.VoucherResponse, error)
+	CreateVoucher(req schemas.VoucherCreateRequest) (*schemas.VoucherResponse, error)
+	UpdateVoucher(id string, req schemas.VoucherUpdateRequest) (*schemas.VoucherResponse, error)
 	DeleteVoucher(id string) error
 	SubmitVoucher(id string) error
 	ApproveVoucher(id string) error
 	RejectVoucher(id string) error
+	PostVoucher(id string) error
+
+	// 会计期间管理
+	GetFiscalYearList(params query.Params) (*query.Page[schemas.FiscalYearResponse], error)
+	CreateFiscalYear(req schemas.FiscalYearCreateRequest) (*schemas.FiscalYearResponse, error)
+	GetPeriodList(params query.Params) (*query.Page[schemas.PeriodResponse], error)
+	SoftClosePeriod(id string) error
+	ClosePeriod(id string, req schemas.PeriodCloseRequest) (*schemas.PeriodResponse, error)
+	ReopenPeriod(id string) error
+
+	// 自动记账规则管理
+	GetPostingRuleList(params query.Params) (*query.Page[schemas.PostingRuleResponse], error)
+	CreatePostingRule(req schemas.PostingRuleCreateRequest) (*schemas.PostingRuleResponse, error)
+	UpdatePostingRule(id string, req schemas.PostingRuleUpdateRequest) (*schemas.PostingRuleResponse, error)
+	DeletePostingRule(id string) error
+
+	// 税务管理
+	GetTaxCodeList(params query.Params) (*query.Page[schemas.TaxCodeResponse], error)
+	GetTaxCodeDetail(id string) (*schemas.TaxCodeResponse, error)
+	CreateTaxCode(req schemas.TaxCodeCreateRequest) (*schemas.TaxCodeResponse, error)
+	UpdateTaxCode(id string, req schemas.TaxCodeUpdateRequest) (*schemas.TaxCodeResponse, error)
+	DeleteTaxCode(id string) error
+	GetTaxRuleList(params query.Params) (*query.Page[schemas.TaxRuleResponse], error)
+	CreateTaxRule(req schemas.TaxRuleCreateRequest) (*schemas.TaxRuleResponse, error)
+	UpdateTaxRule(id string, req schemas.TaxRuleUpdateRequest) (*schemas.TaxRuleResponse, error)
+	DeleteTaxRule(id string) error
+
+	// 外币管理
+	GetCurrencyList(params query.Params) (*query.Page[schemas.CurrencyResponse], error)
+	CreateCurrency(req schemas.CurrencyCreateRequest) (*schemas.CurrencyResponse, error)
+	UpdateCurrency(id string, req schemas.CurrencyUpdateRequest) (*schemas.CurrencyResponse, error)
+	DeleteCurrency(id string) error
+	GetExchangeRateList(params query.Params) (*query.Page[schemas.ExchangeRateResponse], error)
+	CreateExchangeRate(req schemas.ExchangeRateCreateRequest) (*schemas.ExchangeRateResponse, error)
+	DeleteExchangeRate(id string) error
+	ImportExchangeRates(reader io.Reader, createdBy string) (*schemas.ExchangeRateImportResponse, error)
+	GetFXRevaluationList(params query.Params) (*query.Page[schemas.FXRevaluationResponse], error)
+	RevaluePeriod(periodID string, req schemas.FXRevaluationRequest) (*schemas.FXRevaluationResponse, error)
 
 	// 付款管理
-	GetPaymentList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetPaymentDetail(id string) (map[string]interface{}, error)
-	CreatePayment(req map[string]interface{}) (map[string]interface{}, error)
-	UpdatePayment(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetPaymentList(params query.Params) (*query.Page[schemas.PaymentResponse], error)
+	GetPaymentDetail(id string) (*schemas.PaymentResponse, error)
+	CreatePayment(req schemas.PaymentCreateRequest) (*schemas.PaymentResponse, error)
+	UpdatePayment(id string, req schemas.PaymentUpdateRequest) (*schemas.PaymentResponse, error)
 	DeletePayment(id string) error
 	SubmitPayment(id string) error
 	ApprovePayment(id string) error
 	RejectPayment(id string) error
 	ProcessPayment(id string) error
 
+	// 付款运行
+	GetPaymentRunList(params query.Params) (*query.Page[schemas.PaymentRunResponse], error)
+	GetPaymentRunDetail(id string) (*schemas.PaymentRunResponse, error)
+	CreatePaymentRun(req schemas.PaymentRunCreateRequest) (*schemas.PaymentRunResponse, error)
+	UpdatePaymentRun(id string, req schemas.PaymentRunUpdateRequest) (*schemas.PaymentRunResponse, error)
+	ApprovePaymentRun(id string, req schemas.PaymentRunApproveRequest) (*schemas.PaymentRunResponse, error)
+	CancelPaymentRun(id string) error
+	ExportPaymentRun(id string) ([]byte, error)
+
 	// 收款管理
-	GetReceiptList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetReceiptDetail(id string) (map[string]interface{}, error)
-	CreateReceipt(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateReceipt(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetReceiptList(params query.Params) (*query.Page[schemas.CollectionResponse], error)
+	GetReceiptDetail(id string) (*schemas.CollectionResponse, error)
+	CreateReceipt(req schemas.CollectionCreateRequest) (*schemas.CollectionResponse, error)
+	UpdateReceipt(id string, req schemas.CollectionUpdateRequest) (*schemas.CollectionResponse, error)
 	DeleteReceipt(id string) error
 	SubmitReceipt(id string) error
 	ApproveReceipt(id string) error
 	RejectReceipt(id string) error
 	ProcessReceipt(id string) error
+	ApplyReceipt(id string, req schemas.CollectionApplyRequest) (*schemas.CollectionResponse, error)
 
 	// 固定资产管理
-	GetAssetList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetAssetDetail(id string) (map[string]interface{}, error)
-	CreateAsset(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateAsset(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetAssetList(params query.Params) (*query.Page[schemas.AssetResponse], error)
+	GetAssetDetail(id string) (*schemas.AssetResponse, error)
+	CreateAsset(req schemas.AssetCreateRequest) (*schemas.AssetResponse, error)
+	UpdateAsset(id string, req schemas.AssetUpdateRequest) (*schemas.AssetResponse, error)
 	DeleteAsset(id string) error
-	TransferAsset(id string, req map[string]interface{}) error
-	DisposeAsset(id string, req map[string]interface{}) error
-	CalculateDepreciation(req map[string]interface{}) (map[string]interface{}, error)
+	TransferAsset(id string, req schemas.AssetTransferRequest) error
+	DisposeAsset(id string, req schemas.AssetDisposeRequest) error
+	CalculateDepreciation(req schemas.DepreciationRequest) (*schemas.DepreciationResponse, error)
 
 	// 预算管理
-	GetBudgetList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetBudgetDetail(id string) (map[string]interface{}, error)
-	CreateBudget(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateBudget(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetBudgetList(params query.Params) (*query.Page[schemas.BudgetResponse], error)
+	GetBudgetDetail(id string) (*schemas.BudgetResponse, error)
+	CreateBudget(req schemas.BudgetCreateRequest) (*schemas.BudgetResponse, error)
+	UpdateBudget(id string, req schemas.BudgetUpdateRequest) (*schemas.BudgetResponse, error)
 	DeleteBudget(id string) error
 	SubmitBudget(id string) error
 	ApproveBudget(id string) error
 	RejectBudget(id string) error
-	AdjustBudget(id string, req map[string]interface{}) (map[string]interface{}, error)
-	GetBudgetExecution(id string) (map[string]interface{}, error)
+	AdjustBudget(id string, req schemas.BudgetAdjustRequest) (*schemas.BudgetResponse, error)
+	GetBudgetExecution(id string) (*schemas.BudgetExecutionResponse, error)
 
 	// 财务报表管理
-	GetBalanceSheet(req map[string]interface{}) (map[string]interface{}, error)
-	GetIncomeStatement(req map[string]interface{}) (map[string]interface{}, error)
-	GetCashFlowStatement(req map[string]interface{}) (map[string]interface{}, error)
-	GetTrialBalance(req map[string]interface{}) (map[string]interface{}, error)
-	GetGeneralLedger(req map[string]interface{}) (map[string]interface{}, error)
-	GetAccountsReceivableReport(req map[string]interface{}) (map[string]interface{}, error)
-	GetAccountsPayableReport(req map[string]interface{}) (map[string]interface{}, error)
-	ExportFinanceReport(req map[string]interface{}) ([]byte, error)
+	GetBalanceSheet(req schemas.BalanceSheetRequest) (*schemas.BalanceSheetResponse, error)
+	GetIncomeStatement(req schemas.IncomeStatementRequest) (*schemas.IncomeStatementResponse, error)
+	GetCashFlowStatement(req schemas.CashFlowStatementRequest) (*schemas.CashFlowStatementResponse, error)
+	GetTrialBalance(req schemas.TrialBalanceRequest) (*schemas.TrialBalanceResponse, error)
+	GetGeneralLedger(req schemas.GeneralLedgerRequest) (*schemas.GeneralLedgerResponse, error)
+	GetAccountsReceivableReport(req schemas.AgingReportRequest) (*schemas.AgingReportResponse, error)
+	GetAccountsPayableReport(req schemas.AgingReportRequest) (*schemas.AgingReportResponse, error)
+	GetVATSummaryReport(req schemas.VATReportRequest) (*schemas.VATReportResponse, error)
+	ExportFinanceReport(req schemas.ExportFinanceReportRequest) ([]byte, error)
 }
 
 // financeService 财务服务实现
@@ -95,8 +153,18 @@ func NewFinanceService() FinanceService {
 	}
 }
 
+// accountListSpec 会计科目列表查询白名单
+var accountListSpec = query.NewSpec("code",
+	query.Text("code"),
+	query.Text("name"),
+	query.Text("type"),
+	query.Number("level"),
+	query.Text("parent_id"),
+	query.Text("cash_flow_category"),
+)
+
 // 账户管理方法
-func (s *financeService) GetAccountList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *financeService) GetAccountList(params query.Params) (*query.Page[schemas.AccountResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -104,30 +172,21 @@ func (s *financeService) GetAccountList(req map[string]interface{}) ([]map[strin
 
 	// 从数据库读取会计科目数据
 	var accounts []models.FinanceAccount
-	result := s.db.Find(&accounts)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, accountListSpec, &accounts)
+	if err != nil {
+		return nil, err
 	}
 
-	// 将模型转换为map
-	accountList := make([]map[string]interface{}, len(accounts))
+	// 转换为响应结构
+	accountList := make([]schemas.AccountResponse, len(accounts))
 	for i, account := range accounts {
-		accountList[i] = map[string]interface{}{
-			"id":         account.ID,
-			"code":       account.Code,
-			"name":       account.Name,
-			"type":       account.Type,
-			"level":      account.Level,
-			"parent_id":  account.ParentID,
-			"created_at": account.CreatedAt,
-			"updated_at": account.UpdatedAt,
-		}
+		accountList[i] = accountResponse(account)
 	}
 
-	return accountList, nil
+	return query.NewPage(accountList, total, params), nil
 }
 
-func (s *financeService) GetAccountDetail(id string) (map[string]interface{}, error) {
+func (s *financeService) GetAccountDetail(id string) (*schemas.AccountResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -140,22 +199,11 @@ func (s *financeService) GetAccountDetail(id string) (map[string]interface{}, er
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	accountDetail := map[string]interface{}{
-		"id":         account.ID,
-		"code":       account.Code,
-		"name":       account.Name,
-		"type":       account.Type,
-		"level":      account.Level,
-		"parent_id":  account.ParentID,
-		"created_at": account.CreatedAt,
-		"updated_at": account.UpdatedAt,
-	}
-
-	return accountDetail, nil
+	response := accountResponse(account)
+	return &response, nil
 }
 
-func (s *financeService) CreateAccount(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *financeService) CreateAccount(req schemas.AccountCreateRequest) (*schemas.AccountResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -163,14 +211,15 @@ func (s *financeService) CreateAccount(req map[string]interface{}) (map[string]i
 
 	// 创建会计科目模型
 	account := models.FinanceAccount{
-		ID:        req["id"].(string),
-		Code:      req["code"].(string),
-		Name:      req["name"].(string),
-		Type:      req["type"].(string),
-		Level:     req["level"].(int),
-		ParentID:  req["parent_id"].(string),
-		CreatedBy: req["created_by"].(string),
-		UpdatedBy: req["created_by"].(string),
+		ID:               utils.GenerateID(),
+		Code:             req.Code,
+		Name:             req.Name,
+		Type:             req.Type,
+		Level:            req.Level,
+		ParentID:         req.ParentID,
+		CashFlowCategory: req.CashFlowCategory,
+		CreatedBy:        req.CreatedBy,
+		UpdatedBy:        req.CreatedBy,
 	}
 
 	// 保存到数据库
@@ -179,22 +228,11 @@ func (s *financeService) CreateAccount(req map[string]interface{}) (map[string]i
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	accountMap := map[string]interface{}{
-		"id":         account.ID,
-		"code":       account.Code,
-		"name":       account.Name,
-		"type":       account.Type,
-		"level":      account.Level,
-		"parent_id":  account.ParentID,
-		"created_at": account.CreatedAt,
-		"updated_at": account.UpdatedAt,
-	}
-
-	return accountMap, nil
+	response := accountResponse(account)
+	return &response, nil
 }
 
-func (s *financeService) UpdateAccount(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *financeService) UpdateAccount(id string, req schemas.AccountUpdateRequest) (*schemas.AccountResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -208,24 +246,25 @@ func (s *financeService) UpdateAccount(id string, req map[string]interface{}) (m
 	}
 
 	// 更新字段
-	if code, ok := req["code"].(string); ok {
-		account.Code = code
+	if req.Code != "" {
+		account.Code = req.Code
 	}
-	if name, ok := req["name"].(string); ok {
-		account.Name = name
+	if req.Name != "" {
+		account.Name = req.Name
 	}
-	if accountType, ok := req["type"].(string); ok {
-		account.Type = accountType
+	if req.Type != "" {
+		account.Type = req.Type
 	}
-	if level, ok := req["level"].(int); ok {
-		account.Level = level
+	if req.Level != nil {
+		account.Level = *req.Level
 	}
-	if parentID, ok := req["parent_id"].(string); ok {
-		account.ParentID = parentID
+	if req.ParentID != "" {
+		account.ParentID = req.ParentID
 	}
-	if updatedBy, ok := req["updated_by"].(string); ok {
-		account.UpdatedBy = updatedBy
+	if req.CashFlowCategory != "" {
+		account.CashFlowCategory = req.CashFlowCategory
 	}
+	account.UpdatedBy = req.UpdatedBy
 
 	// 保存到数据库
 	result = s.db.Save(&account)
@@ -233,19 +272,8 @@ func (s *financeService) UpdateAccount(id string, req map[string]interface{}) (m
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	accountMap := map[string]interface{}{
-		"id":         account.ID,
-		"code":       account.Code,
-		"name":       account.Name,
-		"type":       account.Type,
-		"level":      account.Level,
-		"parent_id":  account.ParentID,
-		"created_at": account.CreatedAt,
-		"updated_at": account.UpdatedAt,
-	}
-
-	return accountMap, nil
+	response := accountResponse(account)
+	return &response, nil
 }
 
 func (s *financeService) DeleteAccount(id string) error {
@@ -263,55 +291,271 @@ func (s *financeService) DeleteAccount(id string) error {
 	return nil
 }
 
-func (s *financeService) GetAccountTransactions(id string, req map[string]interface{}) ([]map[string]interface{}, error) {
+// accountResponse 将会计科目模型转换为响应结构
+func accountResponse(account models.FinanceAccount) schemas.AccountResponse {
+	return schemas.AccountResponse{
+		ID:               account.ID,
+		Code:             account.Code,
+		Name:             account.Name,
+		Type:             account.Type,
+		Level:            account.Level,
+		ParentID:         account.ParentID,
+		CashFlowCategory: account.CashFlowCategory,
+		CreatedBy:        account.CreatedBy,
+		CreatedAt:        account.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedBy:        account.UpdatedBy,
+		UpdatedAt:        account.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func (s *financeService) GetAccountTransactions(id string, req schemas.AccountTransactionsRequest) (*schemas.AccountLedgerResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空数组，实际实现中应该从数据库读取交易数据
-	return []map[string]interface{}{}, nil
+	// 从数据库读取会计科目
+	var account models.FinanceAccount
+	result := s.db.First(&account, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 解析日期区间
+	from, before, err := parseLedgerPeriod(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 期初余额为开始日期前已过账发生额
+	var opening accountActivity
+	if from != nil {
+		activity, err := postedActivity(s.db, nil, from)
+		if err != nil {
+			return nil, err
+		}
+		opening = activity[account.ID]
+	}
+
+	// 读取期间内已过账分录
+	entries, err := postedEntries(s.db, account.ID, from, before)
+	if err != nil {
+		return nil, err
+	}
+
+	ledger := buildAccountLedger(account, accountBalance(account.Type, opening.Debit, opening.Credit), entries)
+	return &ledger, nil
 }
 
+// voucherListSpec 凭证列表查询白名单，编号、状态、日期区间和来源单据另由请求参数过滤
+var voucherListSpec = query.NewSpec("-date,-journal_no",
+	query.Text("journal_no"),
+	query.Date("date"),
+	query.Number("total_debit"),
+	query.Text("created_by"),
+	query.Date("created_at"),
+)
+
 // 凭证管理方法
-func (s *financeService) GetVoucherList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *financeService) GetVoucherList(req schemas.VoucherListRequest, params query.Params) (*query.Page[schemas.VoucherResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空数组，实际实现中应该从数据库读取凭证数据
-	return []map[string]interface{}{}, nil
+	// 构建查询
+	db := s.db
+	if req.Code != "" {
+		db = db.Where("journal_no LIKE ?", "%"+req.Code+"%")
+	}
+	if req.Status != "" {
+		db = db.Where("status = ?", req.Status)
+	}
+	if req.StartDate != "" {
+		db = db.Where("date >= ?", req.StartDate)
+	}
+	if req.EndDate != "" {
+		db = db.Where("date <= ?", req.EndDate)
+	}
+	if req.ReferenceType != "" {
+		db = db.Where("reference_type = ?", req.ReferenceType)
+	}
+	if req.ReferenceID != "" {
+		db = db.Where("reference_id = ?", req.ReferenceID)
+	}
+
+	// 从数据库读取凭证
+	var journals []models.FinanceJournal
+	total, err := query.Find(db, params, voucherListSpec, &journals, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Items.Account")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	responses := make([]schemas.VoucherResponse, len(journals))
+	for i, journal := range journals {
+		responses[i] = voucherResponse(journal)
+	}
+
+	return query.NewPage(responses, total, params), nil
 }
 
-func (s *financeService) GetVoucherDetail(id string) (map[string]interface{}, error) {
+func (s *financeService) GetVoucherDetail(id string) (*schemas.VoucherResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该从数据库读取凭证详情
-	return map[string]interface{}{}, nil
+	// 从数据库读取凭证详情
+	var journal models.FinanceJournal
+	result := s.db.Preload("Items.Account").First(&journal, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := voucherResponse(journal)
+	return &response, nil
 }
 
-func (s *financeService) CreateVoucher(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *financeService) CreateVoucher(req schemas.VoucherCreateRequest) (*schemas.VoucherResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该创建凭证并保存到数据库
-	return req, nil
+	// 解析凭证日期
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	// 未指定凭证编号时自动生成
+	journalNo := req.Code
+	if journalNo == "" {
+		journalNo = fmt.Sprintf("JV%s", time.Now().Format("20060102030405"))
+	}
+
+	// 创建凭证模型，草稿状态允许借贷暂不平衡
+	journal := models.FinanceJournal{
+		ID:          utils.GenerateID(),
+		JournalNo:   journalNo,
+		Date:        date,
+		Reference:   req.Reference,
+		Description: req.Description,
+		Status:      journalStatusDraft,
+		Remarks:     req.Remarks,
+		Items:       journalItems(req.Items),
+	}
+	journal.TotalDebit, journal.TotalCredit = journalTotals(journal.Items)
+
+	// 保存到数据库
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkPeriodOpen(tx, journal.Date, true); err != nil {
+			return err
+		}
+		currency, rate, err := documentCurrency(tx, req.Currency, "", req.ExchangeRate, journal.Date)
+		if err != nil {
+			return err
+		}
+		journal.Currency, journal.ExchangeRate = currency, rate
+		if err := checkJournalAccounts(tx, journal.Items); err != nil {
+			return err
+		}
+		return tx.Create(&journal).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetVoucherDetail(journal.ID)
 }
 
-func (s *financeService) UpdateVoucher(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *financeService) UpdateVoucher(id string, req schemas.VoucherUpdateRequest) (*schemas.VoucherResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该更新凭证并保存到数据库
-	return req, nil
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// 从数据库读取凭证
+		var journal models.FinanceJournal
+		if err := tx.First(&journal, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		// 只有草稿或已驳回的凭证可以修改
+		if journal.Status != journalStatusDraft && journal.Status != journalStatusRejected {
+			return fmt.Errorf("voucher in status %s cannot be modified", journal.Status)
+		}
+
+		// 更新字段
+		if req.Date != "" {
+			date, err := time.Parse("2006-01-02", req.Date)
+			if err != nil {
+				return err
+			}
+			journal.Date = date
+		}
+		if req.Description != "" {
+			journal.Description = req.Description
+		}
+		if req.Reference != "" {
+			journal.Reference = req.Reference
+		}
+		if req.Remarks != "" {
+			journal.Remarks = req.Remarks
+		}
+
+		// 币种或日期变更时重新确定汇率，未指定汇率时按凭证日期取汇率
+		if req.Currency != "" || req.ExchangeRate > 0 || req.Date != "" {
+			currency := req.Currency
+			if currency == "" {
+				currency = journal.Currency
+			}
+			override := req.ExchangeRate
+			if override == 0 && req.Currency == "" && req.Date == "" {
+				override = journal.ExchangeRate
+			}
+			currency, rate, err := documentCurrency(tx, currency, "", override, journal.Date)
+			if err != nil {
+				return err
+			}
+			journal.Currency, journal.ExchangeRate = currency, rate
+		}
+
+		// 提供分录时整体替换
+		if len(req.Items) > 0 {
+			items := journalItems(req.Items)
+			if err := checkJournalAccounts(tx, items); err != nil {
+				return err
+			}
+			if err := tx.Where("journal_id = ?", journal.ID).Delete(&models.FinanceJournalItem{}).Error; err != nil {
+				return err
+			}
+			for i := range items {
+				items[i].JournalID = journal.ID
+			}
+			if err := tx.Create(&items).Error; err != nil {
+				return err
+			}
+			journal.TotalDebit, journal.TotalCredit = journalTotals(items)
+		}
+
+		// 凭证日期所在期间必须允许记账
+		if err := checkPeriodOpen(tx, journal.Date, true); err != nil {
+			return err
+		}
+
+		// 保存到数据库
+		return tx.Omit("Items").Save(&journal).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetVoucherDetail(id)
 }
 
 func (s *financeService) DeleteVoucher(id string) error {
@@ -320,8 +564,23 @@ func (s *financeService) DeleteVoucher(id string) error {
 		return errors.New("database connection is nil")
 	}
 
-	// 这里简单返回nil，实际实现中应该从数据库删除凭证
-	return nil
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var journal models.FinanceJournal
+		if err := tx.First(&journal, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		// 已提交的凭证不能删除
+		if journal.Status != journalStatusDraft && journal.Status != journalStatusRejected {
+			return fmt.Errorf("voucher in status %s cannot be deleted", journal.Status)
+		}
+
+		// 从数据库删除凭证及分录
+		if err := tx.Where("journal_id = ?", journal.ID).Delete(&models.FinanceJournalItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&journal).Error
+	})
 }
 
 func (s *financeService) SubmitVoucher(id string) error {
@@ -330,8 +589,20 @@ func (s *financeService) SubmitVoucher(id string) error {
 		return errors.New("database connection is nil")
 	}
 
-	// 这里简单返回nil，实际实现中应该更新凭证状态为已提交
-	return nil
+	return s.transitionVoucher(id, []string{journalStatusDraft, journalStatusRejected}, func(tx *gorm.DB, journal *models.FinanceJournal) error {
+		// 提交时校验借贷平衡
+		totalDebit, totalCredit, err := validateJournalLines(journal.Items)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		journal.TotalDebit = totalDebit
+		journal.TotalCredit = totalCredit
+		journal.Status = journalStatusSubmitted
+		journal.SubmittedAt = &now
+		return nil
+	})
 }
 
 func (s *financeService) ApproveVoucher(id string) error {
@@ -340,8 +611,12 @@ func (s *financeService) ApproveVoucher(id string) error {
 		return errors.New("database connection is nil")
 	}
 
-	// 这里简单返回nil，实际实现中应该更新凭证状态为已批准
-	return nil
+	return s.transitionVoucher(id, []string{journalStatusSubmitted}, func(tx *gorm.DB, journal *models.FinanceJournal) error {
+		now := time.Now()
+		journal.Status = journalStatusApproved
+		journal.ApprovedAt = &now
+		return nil
+	})
 }
 
 func (s *financeService) RejectVoucher(id string) error {
@@ -350,231 +625,742 @@ func (s *financeService) RejectVoucher(id string) error {
 		return errors.New("database connection is nil")
 	}
 
-	// 这里简单返回nil，实际实现中应该更新凭证状态为已拒绝
-	return nil
+	return s.transitionVoucher(id, []string{journalStatusSubmitted, journalStatusApproved}, func(tx *gorm.DB, journal *models.FinanceJournal) error {
+		journal.Status = journalStatusRejected
+		journal.SubmittedAt = nil
+		journal.ApprovedAt = nil
+		return nil
+	})
 }
 
-// 付款管理方法
-func (s *financeService) GetPaymentList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *financeService) PostVoucher(id string) error {
 	// 检查数据库连接
 	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+		return errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空数组，实际实现中应该从数据库读取付款数据
-	return []map[string]interface{}{}, nil
+	return s.transitionVoucher(id, []string{journalStatusApproved}, func(tx *gorm.DB, journal *models.FinanceJournal) error {
+		// 过账前再次校验，防止审核后分录被改动
+		if _, _, err := validateJournalLines(journal.Items); err != nil {
+			return err
+		}
+		if err := checkPeriodOpen(tx, journal.Date, true); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		journal.Status = journalStatusPosted
+		journal.PostedAt = &now
+		return nil
+	})
 }
 
-func (s *financeService) GetPaymentDetail(id string) (map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+// transitionVoucher 锁定凭证并在允许的状态下执行状态变更
+func (s *financeService) transitionVoucher(id string, allowed []string, apply func(tx *gorm.DB, journal *models.FinanceJournal) error) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var journal models.FinanceJournal
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("Items", func(db *gorm.DB) *gorm.DB {
+				return db.Order("line_no ASC")
+			}).
+			First(&journal, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		permitted := false
+		for _, status := range allowed {
+			if journal.Status == status {
+				permitted = true
+				break
+			}
+		}
+		if !permitted {
+			return fmt.Errorf("voucher in status %s cannot be changed", journal.Status)
+		}
+
+		if err := apply(tx, &journal); err != nil {
+			return err
+		}
+
+		return tx.Omit("Items").Save(&journal).Error
+	})
+}
+
+// journalItems 将凭证分录请求转换为模型，行号从1开始
+func journalItems(items []schemas.VoucherItemRequest) []models.FinanceJournalItem {
+	journalItems := make([]models.FinanceJournalItem, len(items))
+	for i, item := range items {
+		journalItems[i] = models.FinanceJournalItem{
+			ID:             utils.GenerateID(),
+			LineNo:         i + 1,
+			AccountID:      item.AccountID,
+			Description:    item.Description,
+			Debit:          roundAmount(item.Debit),
+			Credit:         roundAmount(item.Credit),
+			CurrencyAmount: roundAmount(item.CurrencyAmount),
+		}
 	}
+	return journalItems
+}
 
-	// 这里简单返回空map，实际实现中应该从数据库读取付款详情
-	return map[string]interface{}{}, nil
+// journalTotals 计算分录借贷合计
+func journalTotals(items []models.FinanceJournalItem) (float64, float64) {
+	var totalDebit, totalCredit float64
+	for _, item := range items {
+		totalDebit += item.Debit
+		totalCredit += item.Credit
+	}
+	return roundAmount(totalDebit), roundAmount(totalCredit)
 }
 
-func (s *financeService) CreatePayment(req map[string]interface{}) (map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+// voucherResponse 将凭证模型转换为响应格式
+func voucherResponse(journal models.FinanceJournal) schemas.VoucherResponse {
+	response := schemas.VoucherResponse{
+		ID:            journal.ID,
+		Code:          journal.JournalNo,
+		Date:          journal.Date.Format("2006-01-02"),
+		Description:   journal.Description,
+		Status:        journal.Status,
+		TotalDebit:    journal.TotalDebit,
+		TotalCredit:   journal.TotalCredit,
+		Currency:      journal.Currency,
+		ExchangeRate:  journal.ExchangeRate,
+		Reference:     journal.Reference,
+		ReferenceType: journal.ReferenceType,
+		ReferenceID:   journal.ReferenceID,
+		ReversalOf:    journal.ReversalOf,
+		Items:         make([]schemas.VoucherItemResponse, len(journal.Items)),
+		Remarks:       journal.Remarks,
+		SubmittedAt:   formatOptionalTime(journal.SubmittedAt),
+		ApprovedAt:    formatOptionalTime(journal.ApprovedAt),
+		PostedAt:      formatOptionalTime(journal.PostedAt),
+		CreatedAt:     journal.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:     journal.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	sort.Slice(journal.Items, func(i, j int) bool {
+		return journal.Items[i].LineNo < journal.Items[j].LineNo
+	})
+	for i, item := range journal.Items {
+		response.Items[i] = schemas.VoucherItemResponse{
+			ID:             item.ID,
+			VoucherID:      item.JournalID,
+			LineNo:         item.LineNo,
+			AccountID:      item.AccountID,
+			AccountCode:    item.Account.Code,
+			AccountName:    item.Account.Name,
+			Debit:          item.Debit,
+			Credit:         item.Credit,
+			CurrencyAmount: item.CurrencyAmount,
+			Description:    item.Description,
+		}
 	}
 
-	// 这里简单返回请求数据，实际实现中应该创建付款并保存到数据库
-	return req, nil
+	return response
 }
 
-func (s *financeService) UpdatePayment(id string, req map[string]interface{}) (map[string]interface{}, error) {
+// formatOptionalTime 格式化可空时间
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+// fiscalYearListSpec 会计年度列表查询白名单
+var fiscalYearListSpec = query.NewSpec("-year",
+	query.Number("year"),
+	query.Text("status"),
+)
+
+// periodListSpec 会计期间列表查询白名单
+var periodListSpec = query.NewSpec("start_date",
+	query.Text("fiscal_year_id"),
+	query.Number("period_no"),
+	query.Text("status"),
+	query.Date("start_date"),
+	query.Date("end_date"),
+)
+
+// 会计期间管理方法
+func (s *financeService) GetFiscalYearList(params query.Params) (*query.Page[schemas.FiscalYearResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该更新付款并保存到数据库
-	return req, nil
+	// 从数据库读取会计年度及期间
+	var years []models.FinanceFiscalYear
+	total, err := query.Find(s.db, params, fiscalYearListSpec, &years, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Periods", func(db *gorm.DB) *gorm.DB {
+			return db.Order("period_no ASC")
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	responses := make([]schemas.FiscalYearResponse, len(years))
+	for i, year := range years {
+		responses[i] = fiscalYearResponse(year)
+	}
+
+	return query.NewPage(responses, total, params), nil
 }
 
-func (s *financeService) DeletePayment(id string) error {
+func (s *financeService) CreateFiscalYear(req schemas.FiscalYearCreateRequest) (*schemas.FiscalYearResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
-		return errors.New("database connection is nil")
+		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回nil，实际实现中应该从数据库删除付款
-	return nil
+	// 未指定起始月份时从1月开始
+	startMonth := req.StartMonth
+	if startMonth == 0 {
+		startMonth = 1
+	}
+	startDate := time.Date(req.Year, time.Month(startMonth), 1, 0, 0, 0, 0, time.Local)
+	endDate := startDate.AddDate(1, 0, -1)
+
+	// 创建会计年度及12个自然月期间
+	year := models.FinanceFiscalYear{
+		ID:        utils.GenerateID(),
+		Year:      req.Year,
+		Name:      fmt.Sprintf("%d会计年度", req.Year),
+		StartDate: startDate,
+		EndDate:   endDate,
+		Status:    periodStatusOpen,
+		CreatedBy: req.CreatedBy,
+		UpdatedBy: req.CreatedBy,
+		Periods:   make([]models.FinancePeriod, 12),
+	}
+	for i := range year.Periods {
+		periodStart := startDate.AddDate(0, i, 0)
+		year.Periods[i] = models.FinancePeriod{
+			ID:        utils.GenerateID(),
+			PeriodNo:  i + 1,
+			Name:      periodStart.Format("2006-01"),
+			StartDate: periodStart,
+			EndDate:   periodStart.AddDate(0, 1, -1),
+			Status:    periodStatusOpen,
+			CreatedBy: req.CreatedBy,
+			UpdatedBy: req.CreatedBy,
+		}
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// 会计期间不能与已有期间重叠
+		var overlapping int64
+		if err := tx.Model(&models.FinancePeriod{}).
+			Where("start_date <= ? AND end_date >= ?", endDate.Format("2006-01-02"), startDate.Format("2006-01-02")).
+			Count(&overlapping).Error; err != nil {
+			return err
+		}
+		if overlapping > 0 {
+			return fmt.Errorf("fiscal year %d overlaps existing accounting periods", req.Year)
+		}
+
+		// 保存到数据库
+		return tx.Create(&year).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := fiscalYearResponse(year)
+	return &response, nil
 }
 
-func (s *financeService) SubmitPayment(id string) error {
+func (s *financeService) GetPeriodList(params query.Params) (*query.Page[schemas.PeriodResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
-		return errors.New("database connection is nil")
+		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回nil，实际实现中应该更新付款状态为已提交
-	return nil
+	// 从数据库读取会计期间
+	var periods []models.FinancePeriod
+	total, err := query.Find(s.db, params, periodListSpec, &periods)
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	responses := make([]schemas.PeriodResponse, len(periods))
+	for i, period := range periods {
+		responses[i] = periodResponse(period)
+	}
+
+	return query.NewPage(responses, total, params), nil
 }
 
-func (s *financeService) ApprovePayment(id string) error {
+func (s *financeService) SoftClosePeriod(id string) error {
 	// 检查数据库连接
 	if s.db == nil {
 		return errors.New("database connection is nil")
 	}
 
-	// 这里简单返回nil，实际实现中应该更新付款状态为已批准
-	return nil
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var period models.FinancePeriod
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&period, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		// 只有打开的期间可以软关账
+		if period.Status != periodStatusOpen {
+			return fmt.Errorf("period %s in status %s cannot be soft-closed", period.Name, period.Status)
+		}
+
+		period.Status = periodStatusSoftClosed
+		return tx.Save(&period).Error
+	})
 }
 
-func (s *financeService) RejectPayment(id string) error {
+func (s *financeService) ClosePeriod(id string, req schemas.PeriodCloseRequest) (*schemas.PeriodResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
-		return errors.New("database connection is nil")
+		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回nil，实际实现中应该更新付款状态为已拒绝
-	return nil
+	var period models.FinancePeriod
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&period, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if period.Status == periodStatusClosed {
+			return fmt.Errorf("period %s is already closed", period.Name)
+		}
+
+		// 按顺序结账，之前的期间必须已关闭
+		var unclosed int64
+		if err := tx.Model(&models.FinancePeriod{}).
+			Where("end_date < ? AND status <> ?", period.StartDate.Format("2006-01-02"), periodStatusClosed).
+			Count(&unclosed).Error; err != nil {
+			return err
+		}
+		if unclosed > 0 {
+			return fmt.Errorf("previous periods must be closed before period %s", period.Name)
+		}
+
+		// 期间内不能存在未过账凭证
+		var unposted int64
+		if err := tx.Model(&models.FinanceJournal{}).
+			Where("date >= ? AND date <= ? AND status IN ?", period.StartDate.Format("2006-01-02"), period.EndDate.Format("2006-01-02"),
+				[]string{journalStatusDraft, journalStatusSubmitted, journalStatusApproved}).
+			Count(&unposted).Error; err != nil {
+			return err
+		}
+		if unposted > 0 {
+			return fmt.Errorf("period %s has %d unposted vouchers", period.Name, unposted)
+		}
+
+		// 结转损益
+		closedBy := req.ClosedBy
+		if closedBy == "" {
+			closedBy = "system"
+		}
+		journal, err := s.closeProfitAndLoss(tx, period, req.RetainedEarningsAccountID, closedBy)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		period.Status = periodStatusClosed
+		period.ClosedBy = closedBy
+		period.ClosedAt = &now
+		period.ClosingJournalID = ""
+		if journal != nil {
+			period.ClosingJournalID = journal.ID
+		}
+		period.UpdatedBy = closedBy
+		if err := tx.Save(&period).Error; err != nil {
+			return err
+		}
+
+		// 年度内期间全部关闭时关闭会计年度
+		var openPeriods int64
+		if err := tx.Model(&models.FinancePeriod{}).
+			Where("fiscal_year_id = ? AND status <> ?", period.FiscalYearID, periodStatusClosed).
+			Count(&openPeriods).Error; err != nil {
+			return err
+		}
+		if openPeriods == 0 {
+			return tx.Model(&models.FinanceFiscalYear{}).Where("id = ?", period.FiscalYearID).
+				Updates(map[string]interface{}{"status": periodStatusClosed, "updated_by": closedBy}).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := periodResponse(period)
+	return &response, nil
 }
 
-func (s *financeService) ProcessPayment(id string) error {
+func (s *financeService) ReopenPeriod(id string) error {
 	// 检查数据库连接
 	if s.db == nil {
 		return errors.New("database connection is nil")
 	}
 
-	// 这里简单返回nil，实际实现中应该处理付款
-	return nil
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var period models.FinancePeriod
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&period, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if period.Status == periodStatusOpen {
+			return fmt.Errorf("period %s is already open", period.Name)
+		}
+
+		if period.Status == periodStatusClosed {
+			// 只能从最后一个已关闭期间开始反结账
+			var laterClosed int64
+			if err := tx.Model(&models.FinancePeriod{}).
+				Where("start_date > ? AND status = ?", period.EndDate.Format("2006-01-02"), periodStatusClosed).
+				Count(&laterClosed).Error; err != nil {
+				return err
+			}
+			if laterClosed > 0 {
+				return fmt.Errorf("later periods must be reopened before period %s", period.Name)
+			}
+
+			// 冲销期末结转凭证
+			if period.ClosingJournalID != "" {
+				var closing models.FinanceJournal
+				if err := tx.Preload("Items").First(&closing, "id = ?", period.ClosingJournalID).Error; err != nil {
+					return err
+				}
+				journalNo := fmt.Sprintf("RVS%s", time.Now().Format("20060102030405"))
+				if _, err := reverseJournal(tx, closing, journalNo, period.EndDate, journalReferencePeriodReopen, period.ID, "system"); err != nil {
+					return err
+				}
+			}
+
+			if err := tx.Model(&models.FinanceFiscalYear{}).Where("id = ?", period.FiscalYearID).
+				Update("status", periodStatusOpen).Error; err != nil {
+				return err
+			}
+		}
+
+		period.Status = periodStatusOpen
+		period.ClosingJournalID = ""
+		period.ClosedBy = ""
+		period.ClosedAt = nil
+		return tx.Save(&period).Error
+	})
 }
 
-// 收款管理方法
-func (s *financeService) GetReceiptList(req map[string]interface{}) ([]map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+// closeProfitAndLoss 将期间内收入、费用科目发生额结转到留存收益科目，无损益发生额时不生成凭证
+func (s *financeService) closeProfitAndLoss(tx *gorm.DB, period models.FinancePeriod, retainedEarningsAccountID, closedBy string) (*models.FinanceJournal, error) {
+	// 确定留存收益科目
+	var retainedEarnings models.FinanceAccount
+	query := tx.Where("code = ?", config.GetAppConfig().Finance.RetainedEarningsAccount)
+	if retainedEarningsAccountID != "" {
+		query = tx.Where("id = ?", retainedEarningsAccountID)
+	}
+	if err := query.First(&retainedEarnings).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("retained earnings account not found")
+		}
+		return nil, err
+	}
+	if retainedEarnings.Type != "equity" {
+		return nil, fmt.Errorf("retained earnings account %s must be an equity account", retainedEarnings.Code)
 	}
 
-	// 这里简单返回空数组，实际实现中应该从数据库读取收款数据
-	return []map[string]interface{}{}, nil
-}
+	// 汇总期间内全部已过账发生额（含之前结账后的反结转）
+	from := period.StartDate
+	before := period.EndDate.AddDate(0, 0, 1)
+	activity, err := postedActivity(tx, &from, &before)
+	if err != nil {
+		return nil, err
+	}
 
-func (s *financeService) GetReceiptDetail(id string) (map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+	var accounts []models.FinanceAccount
+	if err := tx.Where("type IN ?", []string{"revenue", "expense"}).Order("code ASC").Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+
+	// 逐个科目冲平余额，差额计入留存收益
+	journal := models.FinanceJournal{
+		JournalNo:     fmt.Sprintf("CLS%s", time.Now().Format("20060102030405")),
+		Date:          period.EndDate,
+		Reference:     period.Name,
+		ReferenceType: journalReferencePeriodClose,
+		ReferenceID:   period.ID,
+		Description:   fmt.Sprintf("%s期末结转损益", period.Name),
+		CreatedBy:     closedBy,
+	}
+	var profit float64
+	for _, account := range accounts {
+		row := activity[account.ID]
+		net := roundAmount(row.Debit - row.Credit)
+		if net == 0 {
+			continue
+		}
+		item := models.FinanceJournalItem{AccountID: account.ID, Description: journal.Description}
+		if net > 0 {
+			item.Credit = net
+		} else {
+			item.Debit = -net
+		}
+		journal.Items = append(journal.Items, item)
+		profit -= net
+	}
+	if len(journal.Items) == 0 {
+		return nil, nil
+	}
+
+	profit = roundAmount(profit)
+	if profit != 0 {
+		item := models.FinanceJournalItem{AccountID: retainedEarnings.ID, Description: journal.Description}
+		if profit > 0 {
+			item.Credit = profit
+		} else {
+			item.Debit = -profit
+		}
+		journal.Items = append(journal.Items, item)
 	}
 
-	// 这里简单返回空map，实际实现中应该从数据库读取收款详情
-	return map[string]interface{}{}, nil
+	// 收入与费用相抵为零时分录已平衡，无需留存收益行
+	if err := createPostedJournal(tx, &journal); err != nil {
+		return nil, err
+	}
+	return &journal, nil
+}
+
+// fiscalYearResponse 将会计年度模型转换为响应格式
+func fiscalYearResponse(year models.FinanceFiscalYear) schemas.FiscalYearResponse {
+	response := schemas.FiscalYearResponse{
+		ID:        year.ID,
+		Year:      year.Year,
+		Name:      year.Name,
+		StartDate: year.StartDate.Format("2006-01-02"),
+		EndDate:   year.EndDate.Format("2006-01-02"),
+		Status:    year.Status,
+		Periods:   make([]schemas.PeriodResponse, len(year.Periods)),
+		CreatedAt: year.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: year.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+	for i, period := range year.Periods {
+		response.Periods[i] = periodResponse(period)
+	}
+	return response
+}
+
+// periodResponse 将会计期间模型转换为响应格式
+func periodResponse(period models.FinancePeriod) schemas.PeriodResponse {
+	return schemas.PeriodResponse{
+		ID:               period.ID,
+		FiscalYearID:     period.FiscalYearID,
+		PeriodNo:         period.PeriodNo,
+		Name:             period.Name,
+		StartDate:        period.StartDate.Format("2006-01-02"),
+		EndDate:          period.EndDate.Format("2006-01-02"),
+		Status:           period.Status,
+		ClosingVoucherID: period.ClosingJournalID,
+		ClosedAt:         formatOptionalTime(period.ClosedAt),
+	}
 }
 
-func (s *financeService) CreateReceipt(req map[string]interface{}) (map[string]interface{}, error) {
+// postingRuleListSpec 记账规则列表查询白名单
+var postingRuleListSpec = query.NewSpec("document_type,event,sequence",
+	query.Text("document_type"),
+	query.Text("event"),
+	query.Number("sequence"),
+	query.Text("amount_field"),
+	query.Text("debit_account_code"),
+	query.Text("credit_account_code"),
+	query.Text("status"),
+)
+
+// 自动记账规则管理方法
+func (s *financeService) GetPostingRuleList(params query.Params) (*query.Page[schemas.PostingRuleResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该创建收款并保存到数据库
-	return req, nil
+	// 从数据库读取记账规则
+	var rules []models.FinancePostingRule
+	total, err := query.Find(s.db, params, postingRuleListSpec, &rules)
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	responses := make([]schemas.PostingRuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = postingRuleResponse(rule)
+	}
+
+	return query.NewPage(responses, total, params), nil
 }
 
-func (s *financeService) UpdateReceipt(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *financeService) CreatePostingRule(req schemas.PostingRuleCreateRequest) (*schemas.PostingRuleResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该更新收款并保存到数据库
-	return req, nil
-}
+	// 创建记账规则模型
+	rule := models.FinancePostingRule{
+		ID:                utils.GenerateID(),
+		DocumentType:      req.DocumentType,
+		Event:             req.Event,
+		Sequence:          req.Sequence,
+		AmountField:       req.AmountField,
+		DebitAccountCode:  req.DebitAccountCode,
+		CreditAccountCode: req.CreditAccountCode,
+		Description:       req.Description,
+		Status:            req.Status,
+		CreatedBy:         req.CreatedBy,
+		UpdatedBy:         req.CreatedBy,
+	}
+	if rule.Status == "" {
+		rule.Status = postingRuleStatusActive
+	}
 
-func (s *financeService) DeleteReceipt(id string) error {
-	// 检查数据库连接
-	if s.db == nil {
-		return errors.New("database connection is nil")
+	// 校验金额字段和借贷科目
+	if err := validatePostingRule(s.db, rule); err != nil {
+		return nil, err
 	}
 
-	// 这里简单返回nil，实际实现中应该从数据库删除收款
-	return nil
+	// 保存到数据库
+	result := s.db.Create(&rule)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := postingRuleResponse(rule)
+	return &response, nil
 }
 
-func (s *financeService) SubmitReceipt(id string) error {
+func (s *financeService) UpdatePostingRule(id string, req schemas.PostingRuleUpdateRequest) (*schemas.PostingRuleResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
-		return errors.New("database connection is nil")
+		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回nil，实际实现中应该更新收款状态为已提交
-	return nil
-}
+	// 从数据库读取记账规则
+	var rule models.FinancePostingRule
+	result := s.db.First(&rule, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
 
-func (s *financeService) ApproveReceipt(id string) error {
-	// 检查数据库连接
-	if s.db == nil {
-		return errors.New("database connection is nil")
+	// 更新字段，单据类型创建后不可修改
+	if req.Event != "" {
+		rule.Event = req.Event
+	}
+	if req.Sequence != nil {
+		rule.Sequence = *req.Sequence
+	}
+	if req.AmountField != "" {
+		rule.AmountField = req.AmountField
+	}
+	if req.DebitAccountCode != "" {
+		rule.DebitAccountCode = req.DebitAccountCode
+	}
+	if req.CreditAccountCode != "" {
+		rule.CreditAccountCode = req.CreditAccountCode
+	}
+	if req.Description != "" {
+		rule.Description = req.Description
 	}
+	if req.Status != "" {
+		rule.Status = req.Status
+	}
+	rule.UpdatedBy = req.UpdatedBy
 
-	// 这里简单返回nil，实际实现中应该更新收款状态为已批准
-	return nil
-}
+	// 校验金额字段和借贷科目
+	if err := validatePostingRule(s.db, rule); err != nil {
+		return nil, err
+	}
 
-func (s *financeService) RejectReceipt(id string) error {
-	// 检查数据库连接
-	if s.db == nil {
-		return errors.New("database connection is nil")
+	// 保存到数据库
+	result = s.db.Save(&rule)
+	if result.Error != nil {
+		return nil, result.Error
 	}
 
-	// 这里简单返回nil，实际实现中应该更新收款状态为已拒绝
-	return nil
+	response := postingRuleResponse(rule)
+	return &response, nil
 }
 
-func (s *financeService) ProcessReceipt(id string) error {
+func (s *financeService) DeletePostingRule(id string) error {
 	// 检查数据库连接
 	if s.db == nil {
 		return errors.New("database connection is nil")
 	}
 
-	// 这里简单返回nil，实际实现中应该处理收款
+	// 从数据库删除记账规则，已生成的凭证不受影响
+	result := s.db.Delete(&models.FinancePostingRule{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+
 	return nil
 }
 
+// postingRuleResponse 将记账规则模型转换为响应格式
+func postingRuleResponse(rule models.FinancePostingRule) schemas.PostingRuleResponse {
+	return schemas.PostingRuleResponse{
+		ID:                rule.ID,
+		DocumentType:      rule.DocumentType,
+		Event:             rule.Event,
+		Sequence:          rule.Sequence,
+		AmountField:       rule.AmountField,
+		DebitAccountCode:  rule.DebitAccountCode,
+		CreditAccountCode: rule.CreditAccountCode,
+		Description:       rule.Description,
+		Status:            rule.Status,
+		CreatedAt:         rule.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:         rule.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
 // 固定资产管理方法
-func (s *financeService) GetAssetList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *financeService) GetAssetList(params query.Params) (*query.Page[schemas.AssetResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
 	// 这里简单返回空数组，实际实现中应该从数据库读取固定资产数据
-	return []map[string]interface{}{}, nil
+	return query.NewPage([]schemas.AssetResponse{}, 0, params), nil
 }
 
-func (s *financeService) GetAssetDetail(id string) (map[string]interface{}, error) {
+func (s *financeService) GetAssetDetail(id string) (*schemas.AssetResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该从数据库读取固定资产详情
-	return map[string]interface{}{}, nil
+	// 这里简单返回空结果，实际实现中应该从数据库读取固定资产详情
+	return &schemas.AssetResponse{}, nil
 }
 
-func (s *financeService) CreateAsset(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *financeService) CreateAsset(req schemas.AssetCreateRequest) (*schemas.AssetResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该创建固定资产并保存到数据库
-	return req, nil
+	// 这里简单返回空结果，实际实现中应该创建固定资产并保存到数据库
+	return &schemas.AssetResponse{}, nil
 }
 
-func (s *financeService) UpdateAsset(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *financeService) UpdateAsset(id string, req schemas.AssetUpdateRequest) (*schemas.AssetResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该更新固定资产并保存到数据库
-	return req, nil
+	// 这里简单返回空结果，实际实现中应该更新固定资产并保存到数据库
+	return &schemas.AssetResponse{}, nil
 }
 
 func (s *financeService) DeleteAsset(id string) error {
@@ -587,7 +1373,7 @@ func (s *financeService) DeleteAsset(id string) error {
 	return nil
 }
 
-func (s *financeService) TransferAsset(id string, req map[string]interface{}) error {
+func (s *financeService) TransferAsset(id string, req schemas.AssetTransferRequest) error {
 	// 检查数据库连接
 	if s.db == nil {
 		return errors.New("database connection is nil")
@@ -597,7 +1383,7 @@ func (s *financeService) TransferAsset(id string, req map[string]interface{}) er
 	return nil
 }
 
-func (s *financeService) DisposeAsset(id string, req map[string]interface{}) error {
+func (s *financeService) DisposeAsset(id string, req schemas.AssetDisposeRequest) error {
 	// 检查数据库连接
 	if s.db == nil {
 		return errors.New("database connection is nil")
@@ -607,55 +1393,55 @@ func (s *financeService) DisposeAsset(id string, req map[string]interface{}) err
 	return nil
 }
 
-func (s *financeService) CalculateDepreciation(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *financeService) CalculateDepreciation(req schemas.DepreciationRequest) (*schemas.DepreciationResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该计算折旧
-	return map[string]interface{}{}, nil
+	// 这里简单返回空结果，实际实现中应该计算折旧
+	return &schemas.DepreciationResponse{Period: req.Period, Items: []schemas.DepreciationItem{}}, nil
 }
 
 // 预算管理方法
-func (s *financeService) GetBudgetList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *financeService) GetBudgetList(params query.Params) (*query.Page[schemas.BudgetResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
 	// 这里简单返回空数组，实际实现中应该从数据库读取预算数据
-	return []map[string]interface{}{}, nil
+	return query.NewPage([]schemas.BudgetResponse{}, 0, params), nil
 }
 
-func (s *financeService) GetBudgetDetail(id string) (map[string]interface{}, error) {
+func (s *financeService) GetBudgetDetail(id string) (*schemas.BudgetResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该从数据库读取预算详情
-	return map[string]interface{}{}, nil
+	// 这里简单返回空结果，实际实现中应该从数据库读取预算详情
+	return &schemas.BudgetResponse{}, nil
 }
 
-func (s *financeService) CreateBudget(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *financeService) CreateBudget(req schemas.BudgetCreateRequest) (*schemas.BudgetResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该创建预算并保存到数据库
-	return req, nil
+	// 这里简单返回空结果，实际实现中应该创建预算并保存到数据库
+	return &schemas.BudgetResponse{}, nil
 }
 
-func (s *financeService) UpdateBudget(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *financeService) UpdateBudget(id string, req schemas.BudgetUpdateRequest) (*schemas.BudgetResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该更新预算并保存到数据库
-	return req, nil
+	// 这里简单返回空结果，实际实现中应该更新预算并保存到数据库
+	return &schemas.BudgetResponse{}, nil
 }
 
 func (s *financeService) DeleteBudget(id string) error {
@@ -698,98 +1484,584 @@ func (s *financeService) RejectBudget(id string) error {
 	return nil
 }
 
-func (s *financeService) AdjustBudget(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *financeService) AdjustBudget(id string, req schemas.BudgetAdjustRequest) (*schemas.BudgetResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该调整预算并保存到数据库
-	return req, nil
+	// 这里简单返回空结果，实际实现中应该调整预算并保存到数据库
+	return &schemas.BudgetResponse{}, nil
 }
 
-func (s *financeService) GetBudgetExecution(id string) (map[string]interface{}, error) {
+func (s *financeService) GetBudgetExecution(id string) (*schemas.BudgetExecutionResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该从数据库读取预算执行情况
-	return map[string]interface{}{}, nil
+	// 这里简单返回空结果，实际实现中应该从数据库读取预算执行情况
+	return &schemas.BudgetExecutionResponse{BudgetID: id, Items: []schemas.BudgetItemResponse{}}, nil
 }
 
 // 财务报表管理方法
-func (s *financeService) GetBalanceSheet(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *financeService) GetBalanceSheet(req schemas.BalanceSheetRequest) (*schemas.BalanceSheetResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该生成资产负债表
-	return map[string]interface{}{}, nil
+	// 解析报表日期，默认与上年末比较
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, err
+	}
+	comparisonDate := time.Date(date.Year()-1, 12, 31, 0, 0, 0, 0, date.Location())
+	if req.ComparisonDate != "" {
+		comparisonDate, err = time.Parse("2006-01-02", req.ComparisonDate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 读取科目树
+	tree, err := s.loadAccountTree()
+	if err != nil {
+		return nil, err
+	}
+
+	// 汇总截至报表日和比较日的已过账发生额
+	currentBefore := date.AddDate(0, 0, 1)
+	currentActivity, err := postedActivity(s.db, nil, &currentBefore)
+	if err != nil {
+		return nil, err
+	}
+	comparisonBefore := comparisonDate.AddDate(0, 0, 1)
+	comparisonActivity, err := postedActivity(s.db, nil, &comparisonBefore)
+	if err != nil {
+		return nil, err
+	}
+	current := tree.rollUp(currentActivity)
+	comparison := tree.rollUp(comparisonActivity)
+
+	assets, totalAssets, comparisonAssets := tree.lines("asset", current, comparison)
+	liabilities, totalLiabilities, comparisonLiabilities := tree.lines("liability", current, comparison)
+	equity, totalEquity, comparisonEquity := tree.lines("equity", current, comparison)
+
+	// 尚未结转的损益列入所有者权益
+	profit := tree.netIncome(currentActivity)
+	comparisonProfit := tree.netIncome(comparisonActivity)
+	if profit != 0 || comparisonProfit != 0 {
+		equity = append(equity, statementLine{
+			Account:          models.FinanceAccount{Name: "本年利润（未结转）"},
+			Level:            1,
+			Amount:           profit,
+			ComparisonAmount: comparisonProfit,
+		})
+		totalEquity = roundAmount(totalEquity + profit)
+		comparisonEquity = roundAmount(comparisonEquity + comparisonProfit)
+	}
+
+	response := &schemas.BalanceSheetResponse{
+		Date:                      req.Date,
+		Assets:                    balanceSheetItems(assets, totalAssets),
+		TotalAssets:               totalAssets,
+		Liabilities:               balanceSheetItems(liabilities, totalLiabilities),
+		TotalLiabilities:          totalLiabilities,
+		Equity:                    balanceSheetItems(equity, totalEquity),
+		TotalEquity:               totalEquity,
+		TotalLiabilitiesAndEquity: roundAmount(totalLiabilities + totalEquity),
+		Comparison: &schemas.BalanceSheetComparison{
+			Date:             comparisonDate.Format("2006-01-02"),
+			TotalAssets:      comparisonAssets,
+			TotalLiabilities: comparisonLiabilities,
+			TotalEquity:      comparisonEquity,
+			AssetChange:      roundAmount(totalAssets - comparisonAssets),
+			LiabilityChange:  roundAmount(totalLiabilities - comparisonLiabilities),
+			EquityChange:     roundAmount(totalEquity - comparisonEquity),
+		},
+	}
+	response.Balanced = response.TotalAssets == response.TotalLiabilitiesAndEquity
+
+	return response, nil
 }
 
-func (s *financeService) GetIncomeStatement(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *financeService) GetIncomeStatement(req schemas.IncomeStatementRequest) (*schemas.IncomeStatementResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该生成利润表
-	return map[string]interface{}{}, nil
+	// 解析本期及比较期间
+	start, end, comparisonStart, comparisonEnd, err := statementPeriods(req.StartDate, req.EndDate, req.ComparisonStartDate, req.ComparisonEndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 读取科目树
+	tree, err := s.loadAccountTree()
+	if err != nil {
+		return nil, err
+	}
+
+	// 汇总两期已过账发生额，期末结转凭证不计入损益
+	currentBefore := end.AddDate(0, 0, 1)
+	currentActivity, err := operatingActivity(s.db, &start, &currentBefore)
+	if err != nil {
+		return nil, err
+	}
+	comparisonBefore := comparisonEnd.AddDate(0, 0, 1)
+	comparisonActivity, err := operatingActivity(s.db, &comparisonStart, &comparisonBefore)
+	if err != nil {
+		return nil, err
+	}
+	current := tree.rollUp(currentActivity)
+	comparison := tree.rollUp(comparisonActivity)
+
+	revenues, totalRevenues, comparisonRevenues := tree.lines("revenue", current, comparison)
+	expenses, totalExpenses, comparisonExpenses := tree.lines("expense", current, comparison)
+	netIncome := roundAmount(totalRevenues - totalExpenses)
+	comparisonIncome := roundAmount(comparisonRevenues - comparisonExpenses)
+
+	response := &schemas.IncomeStatementResponse{
+		Period:        formatPeriod(start, end),
+		Revenues:      incomeStatementItems(revenues, totalRevenues),
+		TotalRevenues: totalRevenues,
+		Expenses:      incomeStatementItems(expenses, totalRevenues),
+		TotalExpenses: totalExpenses,
+		NetIncome:     netIncome,
+		Comparison: &schemas.IncomeStatementComparison{
+			Period:        formatPeriod(comparisonStart, comparisonEnd),
+			TotalRevenues: comparisonRevenues,
+			TotalExpenses: comparisonExpenses,
+			NetIncome:     comparisonIncome,
+			RevenueChange: roundAmount(totalRevenues - comparisonRevenues),
+			ExpenseChange: roundAmount(totalExpenses - comparisonExpenses),
+			IncomeChange:  roundAmount(netIncome - comparisonIncome),
+		},
+	}
+
+	return response, nil
 }
 
-func (s *financeService) GetCashFlowStatement(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *financeService) GetCashFlowStatement(req schemas.CashFlowStatementRequest) (*schemas.CashFlowStatementResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该生成现金流量表
-	return map[string]interface{}{}, nil
+	// 解析本期及比较期间
+	start, end, comparisonStart, comparisonEnd, err := statementPeriods(req.StartDate, req.EndDate, req.ComparisonStartDate, req.ComparisonEndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 读取科目树
+	tree, err := s.loadAccountTree()
+	if err != nil {
+		return nil, err
+	}
+
+	// 按间接法分别计算两期现金流量
+	current, err := s.cashFlow(tree, start, end)
+	if err != nil {
+		return nil, err
+	}
+	comparison, err := s.cashFlow(tree, comparisonStart, comparisonEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &schemas.CashFlowStatementResponse{
+		Period:        formatPeriod(start, end),
+		BeginningCash: current.BeginningCash,
+		EndingCash:    current.EndingCash,
+		Comparison: &schemas.CashFlowComparison{
+			Period:        formatPeriod(comparisonStart, comparisonEnd),
+			BeginningCash: comparison.BeginningCash,
+			EndingCash:    comparison.EndingCash,
+		},
+	}
+
+	// 经营活动从净利润开始调整
+	response.OperatingActivities = append([]schemas.CashFlowItem{{
+		Description:      "净利润",
+		Amount:           current.NetIncome,
+		ComparisonAmount: comparison.NetIncome,
+	}}, cashFlowItems(tree, current.Sections[cashFlowOperating], comparison.Sections[cashFlowOperating])...)
+	response.InvestingActivities = cashFlowItems(tree, current.Sections[cashFlowInvesting], comparison.Sections[cashFlowInvesting])
+	response.FinancingActivities = cashFlowItems(tree, current.Sections[cashFlowFinancing], comparison.Sections[cashFlowFinancing])
+
+	response.TotalOperating, response.Comparison.TotalOperating = cashFlowTotals(response.OperatingActivities)
+	response.TotalInvesting, response.Comparison.TotalInvesting = cashFlowTotals(response.InvestingActivities)
+	response.TotalFinancing, response.Comparison.TotalFinancing = cashFlowTotals(response.FinancingActivities)
+	response.NetChangeCash = roundAmount(response.TotalOperating + response.TotalInvesting + response.TotalFinancing)
+	response.Comparison.NetChangeCash = roundAmount(response.Comparison.TotalOperating + response.Comparison.TotalInvesting + response.Comparison.TotalFinancing)
+
+	return response, nil
 }
 
-func (s *financeService) GetTrialBalance(req map[string]interface{}) (map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+// loadAccountTree 读取全部会计科目并构建科目树
+func (s *financeService) loadAccountTree() (*accountTree, error) {
+	var accounts []models.FinanceAccount
+	result := s.db.Find(&accounts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return newAccountTree(accounts), nil
+}
+
+// cashFlowResult 间接法现金流量计算结果，Sections按分类记录各科目对现金的影响
+type cashFlowResult struct {
+	NetIncome     float64
+	Sections      map[string]map[string]float64
+	BeginningCash float64
+	EndingCash    float64
+}
+
+// cashFlow 按间接法计算期间现金流量：非现金资产负债类科目的余额变动即为对现金的调整
+func (s *financeService) cashFlow(tree *accountTree, start, end time.Time) (*cashFlowResult, error) {
+	before := end.AddDate(0, 0, 1)
+	opening, err := postedActivity(s.db, nil, &start)
+	if err != nil {
+		return nil, err
+	}
+	period, err := operatingActivity(s.db, &start, &before)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &cashFlowResult{
+		NetIncome: tree.netIncome(period),
+		Sections: map[string]map[string]float64{
+			cashFlowOperating: {},
+			cashFlowInvesting: {},
+			cashFlowFinancing: {},
+		},
+	}
+
+	// 期初现金为现金类科目期初余额
+	for id, row := range opening {
+		if account, ok := tree.byID[id]; ok && cashFlowCategory(account) == cashFlowCash {
+			result.BeginningCash += row.Debit - row.Credit
+		}
+	}
+
+	var cashChange float64
+	for id, row := range period {
+		account, ok := tree.byID[id]
+		if !ok || account.Type == "revenue" || account.Type == "expense" {
+			continue
+		}
+
+		net := row.Debit - row.Credit
+		category := cashFlowCategory(account)
+		if category == cashFlowCash {
+			cashChange += net
+			continue
+		}
+		if section, ok := result.Sections[category]; ok {
+			section[id] = roundAmount(-net)
+		}
+	}
+
+	result.BeginningCash = roundAmount(result.BeginningCash)
+	result.EndingCash = roundAmount(result.BeginningCash + cashChange)
+	return result, nil
+}
+
+// balanceSheetItems 将报表行转换为资产负债表项目
+func balanceSheetItems(lines []statementLine, total float64) []schemas.BalanceSheetItem {
+	items := make([]schemas.BalanceSheetItem, len(lines))
+	for i, line := range lines {
+		items[i] = schemas.BalanceSheetItem{
+			AccountID:        line.Account.ID,
+			AccountCode:      line.Account.Code,
+			AccountName:      line.Account.Name,
+			Level:            line.Level,
+			Amount:           line.Amount,
+			ComparisonAmount: line.ComparisonAmount,
+			Percentage:       percentage(line.Amount, total),
+		}
+	}
+	return items
+}
+
+// incomeStatementItems 将报表行转换为利润表项目，占比以营业收入为基数
+func incomeStatementItems(lines []statementLine, totalRevenues float64) []schemas.IncomeStatementItem {
+	items := make([]schemas.IncomeStatementItem, len(lines))
+	for i, line := range lines {
+		items[i] = schemas.IncomeStatementItem{
+			AccountID:        line.Account.ID,
+			AccountCode:      line.Account.Code,
+			AccountName:      line.Account.Name,
+			Level:            line.Level,
+			Amount:           line.Amount,
+			ComparisonAmount: line.ComparisonAmount,
+			Percentage:       percentage(line.Amount, totalRevenues),
+		}
+	}
+	return items
+}
+
+// cashFlowItems 按科目顺序合并两期现金流量调整项
+func cashFlowItems(tree *accountTree, current, comparison map[string]float64) []schemas.CashFlowItem {
+	items := make([]schemas.CashFlowItem, 0)
+	for _, account := range tree.accounts {
+		amount, inCurrent := current[account.ID]
+		comparisonAmount, inComparison := comparison[account.ID]
+		if (!inCurrent && !inComparison) || (amount == 0 && comparisonAmount == 0) {
+			continue
+		}
+		items = append(items, schemas.CashFlowItem{
+			AccountID:        account.ID,
+			AccountCode:      account.Code,
+			Description:      account.Name,
+			Amount:           amount,
+			ComparisonAmount: comparisonAmount,
+		})
+	}
+	return items
+}
+
+// cashFlowTotals 计算现金流量项目两期合计
+func cashFlowTotals(items []schemas.CashFlowItem) (float64, float64) {
+	var total, comparisonTotal float64
+	for _, item := range items {
+		total += item.Amount
+		comparisonTotal += item.ComparisonAmount
+	}
+	return roundAmount(total), roundAmount(comparisonTotal)
+}
+
+// statementPeriods 解析报表期间，未指定比较期间时取上一等长期间
+func statementPeriods(startDate, endDate, comparisonStartDate, comparisonEndDate string) (time.Time, time.Time, time.Time, time.Time, error) {
+	var zero time.Time
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return zero, zero, zero, zero, err
+	}
+	end, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		return zero, zero, zero, zero, err
+	}
+	if end.Before(start) {
+		return zero, zero, zero, zero, errors.New("start date must not be after end date")
+	}
+
+	if comparisonStartDate == "" || comparisonEndDate == "" {
+		comparisonStart, comparisonEnd := priorPeriod(start, end)
+		return start, end, comparisonStart, comparisonEnd, nil
+	}
+
+	comparisonStart, err := time.Parse("2006-01-02", comparisonStartDate)
+	if err != nil {
+		return zero, zero, zero, zero, err
+	}
+	comparisonEnd, err := time.Parse("2006-01-02", comparisonEndDate)
+	if err != nil {
+		return zero, zero, zero, zero, err
+	}
+	if comparisonEnd.Before(comparisonStart) {
+		return zero, zero, zero, zero, errors.New("comparison start date must not be after comparison end date")
 	}
 
-	// 这里简单返回空map，实际实现中应该生成试算平衡表
-	return map[string]interface{}{}, nil
+	return start, end, comparisonStart, comparisonEnd, nil
 }
 
-func (s *financeService) GetGeneralLedger(req map[string]interface{}) (map[string]interface{}, error) {
+// formatPeriod 格式化报表期间
+func formatPeriod(start, end time.Time) string {
+	return fmt.Sprintf("%s ~ %s", start.Format("2006-01-02"), end.Format("2006-01-02"))
+}
+
+func (s *financeService) GetTrialBalance(req schemas.TrialBalanceRequest) (*schemas.TrialBalanceResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该生成总账
-	return map[string]interface{}{}, nil
+	// 解析日期区间
+	from, before, err := parseLedgerPeriod(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 从数据库读取会计科目
+	var accounts []models.FinanceAccount
+	result := s.db.Order("code ASC").Find(&accounts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 汇总期初及本期已过账发生额
+	opening, err := postedActivity(s.db, nil, from)
+	if err != nil {
+		return nil, err
+	}
+	period, err := postedActivity(s.db, from, before)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &schemas.TrialBalanceResponse{
+		Period: fmt.Sprintf("%s ~ %s", req.StartDate, req.EndDate),
+		Items:  make([]schemas.TrialBalanceItem, 0),
+	}
+	for _, account := range accounts {
+		openingActivity, hasOpening := opening[account.ID]
+		periodActivity, hasPeriod := period[account.ID]
+		if !hasOpening && !hasPeriod {
+			continue
+		}
+
+		// 余额按借贷差额列示在对应方向
+		item := schemas.TrialBalanceItem{
+			AccountID:    account.ID,
+			AccountCode:  account.Code,
+			AccountName:  account.Name,
+			AccountType:  account.Type,
+			PeriodDebit:  periodActivity.Debit,
+			PeriodCredit: periodActivity.Credit,
+		}
+		openingNet := roundAmount(openingActivity.Debit - openingActivity.Credit)
+		closingNet := roundAmount(openingNet + periodActivity.Debit - periodActivity.Credit)
+		item.OpeningDebit, item.OpeningCredit = splitBalance(openingNet)
+		item.ClosingDebit, item.ClosingCredit = splitBalance(closingNet)
+		response.Items = append(response.Items, item)
+
+		response.TotalOpeningDebit += item.OpeningDebit
+		response.TotalOpeningCredit += item.OpeningCredit
+		response.TotalPeriodDebit += item.PeriodDebit
+		response.TotalPeriodCredit += item.PeriodCredit
+		response.TotalClosingDebit += item.ClosingDebit
+		response.TotalClosingCredit += item.ClosingCredit
+	}
+
+	response.TotalOpeningDebit = roundAmount(response.TotalOpeningDebit)
+	response.TotalOpeningCredit = roundAmount(response.TotalOpeningCredit)
+	response.TotalPeriodDebit = roundAmount(response.TotalPeriodDebit)
+	response.TotalPeriodCredit = roundAmount(response.TotalPeriodCredit)
+	response.TotalClosingDebit = roundAmount(response.TotalClosingDebit)
+	response.TotalClosingCredit = roundAmount(response.TotalClosingCredit)
+	response.Balanced = response.TotalOpeningDebit == response.TotalOpeningCredit &&
+		response.TotalPeriodDebit == response.TotalPeriodCredit &&
+		response.TotalClosingDebit == response.TotalClosingCredit
+
+	return response, nil
 }
 
-func (s *financeService) GetAccountsReceivableReport(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *financeService) GetGeneralLedger(req schemas.GeneralLedgerRequest) (*schemas.GeneralLedgerResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该生成应收账款报表
-	return map[string]interface{}{}, nil
+	// 解析日期区间
+	from, before, err := parseLedgerPeriod(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 从数据库读取会计科目
+	query := s.db.Order("code ASC")
+	if req.AccountID != "" {
+		query = query.Where("id = ?", req.AccountID)
+	}
+	var accounts []models.FinanceAccount
+	result := query.Find(&accounts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 汇总期初发生额并读取本期分录
+	opening, err := postedActivity(s.db, nil, from)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := postedEntries(s.db, req.AccountID, from, before)
+	if err != nil {
+		return nil, err
+	}
+	entriesByAccount := make(map[string][]ledgerEntry)
+	for _, entry := range entries {
+		entriesByAccount[entry.AccountID] = append(entriesByAccount[entry.AccountID], entry)
+	}
+
+	response := &schemas.GeneralLedgerResponse{
+		Period:   fmt.Sprintf("%s ~ %s", req.StartDate, req.EndDate),
+		Accounts: make([]schemas.AccountLedgerResponse, 0),
+	}
+	for _, account := range accounts {
+		openingActivity, hasOpening := opening[account.ID]
+		accountEntries := entriesByAccount[account.ID]
+		if !hasOpening && len(accountEntries) == 0 {
+			continue
+		}
+
+		openingBalance := accountBalance(account.Type, openingActivity.Debit, openingActivity.Credit)
+		response.Accounts = append(response.Accounts, buildAccountLedger(account, openingBalance, accountEntries))
+	}
+
+	return response, nil
+}
+
+// buildAccountLedger 根据期初余额和分录生成科目明细账，余额按科目方向逐笔累计
+func buildAccountLedger(account models.FinanceAccount, openingBalance float64, entries []ledgerEntry) schemas.AccountLedgerResponse {
+	ledger := schemas.AccountLedgerResponse{
+		AccountID:      account.ID,
+		AccountCode:    account.Code,
+		AccountName:    account.Name,
+		AccountType:    account.Type,
+		OpeningBalance: openingBalance,
+		Entries:        make([]schemas.AccountLedgerEntry, len(entries)),
+	}
+
+	balance := openingBalance
+	for i, entry := range entries {
+		balance = roundAmount(balance + accountBalance(account.Type, entry.Debit, entry.Credit))
+		ledger.TotalDebit += entry.Debit
+		ledger.TotalCredit += entry.Credit
+
+		description := entry.Description
+		if description == "" {
+			description = entry.JournalDescription
+		}
+		ledger.Entries[i] = schemas.AccountLedgerEntry{
+			VoucherID:   entry.JournalID,
+			VoucherCode: entry.JournalNo,
+			Date:        entry.Date.Format("2006-01-02"),
+			Description: description,
+			Debit:       entry.Debit,
+			Credit:      entry.Credit,
+			Balance:     balance,
+		}
+	}
+	ledger.TotalDebit = roundAmount(ledger.TotalDebit)
+	ledger.TotalCredit = roundAmount(ledger.TotalCredit)
+	ledger.ClosingBalance = balance
+
+	return ledger
+}
+
+// splitBalance 将借贷差额拆分为借方余额和贷方余额
+func splitBalance(net float64) (float64, float64) {
+	if net >= 0 {
+		return net, 0
+	}
+	return 0, -net
 }
 
-func (s *financeService) GetAccountsPayableReport(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *financeService) GetAccountsPayableReport(req schemas.AgingReportRequest) (*schemas.AgingReportResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该生成应付账款报表
-	return map[string]interface{}{}, nil
+	// 这里简单返回空结果，实际实现中应该生成应付账款报表
+	return &schemas.AgingReportResponse{Date: req.Date, Items: []schemas.AgingReportItem{}}, nil
 }
 
-func (s *financeService) ExportFinanceReport(req map[string]interface{}) ([]byte, error) {
+func (s *financeService) ExportFinanceReport(req schemas.ExportFinanceReportRequest) ([]byte, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"github.com/wu136995/ginx/internal/models"
+)
+
+// 现金流量分类
+const (
+	cashFlowCash      = "cash"
+	cashFlowOperating = "operating"
+	cashFlowInvesting = "investing"
+	cashFlowFinancing = "financing"
+)
+
+// accountTree 会计科目树，按科目编码先序排列
+type accountTree struct {
+	accounts []models.FinanceAccount
+	byID     map[string]models.FinanceAccount
+	children map[string][]string
+	roots    []string
+}
+
+// newAccountTree 根据科目的ParentID构建科目树
+func newAccountTree(accounts []models.FinanceAccount) *accountTree {
+	tree := &accountTree{
+		byID:     make(map[string]models.FinanceAccount, len(accounts)),
+		children: make(map[string][]string),
+	}
+
+	sorted := make([]models.FinanceAccount, len(accounts))
+	copy(sorted, accounts)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Code < sorted[j].Code
+	})
+	for _, account := range sorted {
+		tree.byID[account.ID] = account
+	}
+	for _, account := range sorted {
+		if _, ok := tree.byID[account.ParentID]; ok && account.ParentID != account.ID {
+			tree.children[account.ParentID] = append(tree.children[account.ParentID], account.ID)
+		} else {
+			tree.roots = append(tree.roots, account.ID)
+		}
+	}
+
+	// 先序遍历，父科目排在子科目之前
+	var visit func(id string)
+	visit = func(id string) {
+		tree.accounts = append(tree.accounts, tree.byID[id])
+		for _, childID := range tree.children[id] {
+			visit(childID)
+		}
+	}
+	for _, id := range tree.roots {
+		visit(id)
+	}
+
+	return tree
+}
+
+// rollUp 计算各科目余额并汇总到所有上级科目，余额方向按各自科目类型确定
+func (t *accountTree) rollUp(activity map[string]accountActivity) map[string]float64 {
+	totals := make(map[string]float64, len(t.byID))
+	for id, row := range activity {
+		account, ok := t.byID[id]
+		if !ok {
+			continue
+		}
+		amount := accountBalance(account.Type, row.Debit, row.Credit)
+		for current, depth := account, 0; depth <= len(t.byID); depth++ {
+			totals[current.ID] += amount
+			parent, ok := t.byID[current.ParentID]
+			if !ok || parent.ID == current.ID {
+				break
+			}
+			current = parent
+		}
+	}
+
+	for id, amount := range totals {
+		totals[id] = roundAmount(amount)
+	}
+	return totals
+}
+
+// statementLine 报表行
+type statementLine struct {
+	Account          models.FinanceAccount
+	Level            int
+	Amount           float64
+	ComparisonAmount float64
+}
+
+// lines 生成指定类型科目的报表行并返回顶级科目合计，两期均为零的科目不列示
+func (t *accountTree) lines(accountType string, current, comparison map[string]float64) ([]statementLine, float64, float64) {
+	var lines []statementLine
+	var total, comparisonTotal float64
+	for _, account := range t.accounts {
+		if account.Type != accountType {
+			continue
+		}
+
+		// 上级科目类型不同时视为该类顶级科目
+		parent, hasParent := t.byID[account.ParentID]
+		if !hasParent || parent.Type != accountType {
+			total += current[account.ID]
+			comparisonTotal += comparison[account.ID]
+		}
+
+		if current[account.ID] == 0 && comparison[account.ID] == 0 {
+			continue
+		}
+		lines = append(lines, statementLine{
+			Account:          account,
+			Level:            t.depth(account),
+			Amount:           current[account.ID],
+			ComparisonAmount: comparison[account.ID],
+		})
+	}
+
+	return lines, roundAmount(total), roundAmount(comparisonTotal)
+}
+
+// depth 科目在树中的层级，顶级为1
+func (t *accountTree) depth(account models.FinanceAccount) int {
+	level := 1
+	for current := account; level <= len(t.byID); level++ {
+		parent, ok := t.byID[current.ParentID]
+		if !ok || parent.ID == current.ID {
+			break
+		}
+		current = parent
+	}
+	return level
+}
+
+// netIncome 根据收入和费用科目发生额计算净利润
+func (t *accountTree) netIncome(activity map[string]accountActivity) float64 {
+	var income float64
+	for id, row := range activity {
+		account, ok := t.byID[id]
+		if !ok {
+			continue
+		}
+		switch account.Type {
+		case "revenue":
+			income += accountBalance(account.Type, row.Debit, row.Credit)
+		case "expense":
+			income -= accountBalance(account.Type, row.Debit, row.Credit)
+		}
+	}
+	return roundAmount(income)
+}
+
+// cashFlowCategory 科目现金流量分类，未设置时资产负债类归为经营活动，权益类归为筹资活动
+func cashFlowCategory(account models.FinanceAccount) string {
+	if account.CashFlowCategory != "" {
+		return account.CashFlowCategory
+	}
+	if account.Type == "equity" {
+		return cashFlowFinancing
+	}
+	return cashFlowOperating
+}
+
+// percentage 计算占比，保留两位小数
+func percentage(amount, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return roundAmount(amount / total * 100)
+}
+
+// priorPeriod 计算上一等长期间，整月期间按月份平移
+func priorPeriod(start, end time.Time) (time.Time, time.Time) {
+	if start.Day() == 1 && end.AddDate(0, 0, 1).Day() == 1 {
+		months := (end.Year()-start.Year())*12 + int(end.Month()-start.Month()) + 1
+		return start.AddDate(0, -months, 0), start.AddDate(0, 0, -1)
+	}
+
+	days := int(end.Sub(start).Hours()/24) + 1
+	return start.AddDate(0, 0, -days), start.AddDate(0, 0, -1)
+}
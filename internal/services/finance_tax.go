@@ -0,0 +1,828 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/config"
+	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/query"
+	"github.com/wu136995/ginx/internal/utils"
+	"gorm.io/gorm"
+)
+
+// 税码确定规则的业务方向
+const (
+	taxDirectionSales    = "sales"
+	taxDirectionPurchase = "purchase"
+)
+
+// taxRoundingDocument 按单据同一税码税率汇总后舍入，尾差调整到金额最大的行
+const taxRoundingDocument = "document"
+
+// taxLine 待计税的单据行，Amount按单据是否含税解释为含税或不含税金额，TaxCodeID和TaxRate为按税收分类确定的税码税率
+type taxLine struct {
+	Amount    float64
+	Category  string
+	TaxCodeID string
+	TaxRate   float64
+}
+
+// taxedLine 单据行计税结果，未匹配到税码规则时税额为零
+type taxedLine struct {
+	TaxCodeID string
+	TaxRate   float64
+	NetAmount float64
+	TaxAmount float64
+}
+
+// taxCodeRate 税码及税率，单据级舍入按税码税率分组
+type taxCodeRate struct {
+	TaxCodeID string
+	TaxRate   float64
+}
+
+// documentTax 单据计税结果，Lines与计税行一一对应
+type documentTax struct {
+	Lines           []taxedLine
+	TaxAmount       float64
+	TotalAmount     float64
+	BaseTotalAmount float64
+	BaseTaxAmount   float64
+}
+
+// determineTaxRate 按业务方向、往来单位纳税状态和物料税收分类匹配税码，取单据日期生效的税率。
+// 同时指定纳税状态和税收分类的规则优先，其次按优先级；未匹配到规则时返回空税码
+func determineTaxRate(db *gorm.DB, direction, partyStatus, category string, date time.Time) (string, float64, error) {
+	var rules []models.FinanceTaxRule
+	result := db.Where("direction = ? AND status = ?", direction, "active").
+		Where("party_tax_status IN ? AND item_tax_category IN ?", []string{"", partyStatus}, []string{"", category}).
+		Order("priority DESC, created_at ASC").
+		Find(&rules)
+	if result.Error != nil {
+		return "", 0, result.Error
+	}
+	if len(rules) == 0 {
+		return "", 0, nil
+	}
+
+	specificity := func(rule models.FinanceTaxRule) int {
+		score := 0
+		if rule.PartyTaxStatus != "" {
+			score += 2
+		}
+		if rule.ItemTaxCategory != "" {
+			score++
+		}
+		return score
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		return specificity(rules[i]) > specificity(rules[j])
+	})
+	rule := rules[0]
+
+	var code models.FinanceTaxCode
+	if err := db.First(&code, "id = ?", rule.TaxCodeID).Error; err != nil {
+		return "", 0, err
+	}
+	if code.Status != "active" {
+		return "", 0, fmt.Errorf("tax code %s is inactive", code.Code)
+	}
+
+	var rate models.FinanceTaxRate
+	result = db.Where("tax_code_id = ? AND valid_from <= ? AND (valid_to IS NULL OR valid_to >= ?)", code.ID, date, date).
+		Order("valid_from DESC").
+		First(&rate)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return "", 0, fmt.Errorf("tax code %s has no rate effective on %s", code.Code, date.Format("2006-01-02"))
+	}
+	if result.Error != nil {
+		return "", 0, result.Error
+	}
+	return code.ID, rate.Rate, nil
+}
+
+// resolveTaxRates 按业务方向和往来单位纳税状态逐行确定税码税率，同一税收分类只匹配一次
+func resolveTaxRates(db *gorm.DB, direction, partyStatus string, date time.Time, lines []taxLine) error {
+	rates := make(map[string]taxCodeRate)
+	for i := range lines {
+		rate, ok := rates[lines[i].Category]
+		if !ok {
+			codeID, taxRate, err := determineTaxRate(db, direction, partyStatus, lines[i].Category, date)
+			if err != nil {
+				return err
+			}
+			rate = taxCodeRate{TaxCodeID: codeID, TaxRate: taxRate}
+			rates[lines[i].Category] = rate
+		}
+		lines[i].TaxCodeID, lines[i].TaxRate = rate.TaxCodeID, rate.TaxRate
+	}
+	return nil
+}
+
+// calculateTax 按各行税率计算税额，返回各行结果和税额合计。
+// 含税价按 税额=金额×税率/(100+税率) 价内分离，不含税价按 税额=金额×税率/100 计算；
+// rounding为document时同一税码税率的税额合计舍入一次，与逐行舍入的差额计入金额最大的行
+func calculateTax(lines []taxLine, inclusive bool, rounding string) ([]taxedLine, float64) {
+	taxed := make([]taxedLine, len(lines))
+	raw := make([]float64, len(lines))
+	for i, line := range lines {
+		if inclusive {
+			raw[i] = line.Amount * line.TaxRate / (100 + line.TaxRate)
+		} else {
+			raw[i] = line.Amount * line.TaxRate / 100
+		}
+		taxed[i] = taxedLine{TaxCodeID: line.TaxCodeID, TaxRate: line.TaxRate, TaxAmount: roundAmount(raw[i])}
+	}
+
+	if rounding == taxRoundingDocument {
+		groups := make(map[taxCodeRate][]int)
+		for i, line := range taxed {
+			key := taxCodeRate{TaxCodeID: line.TaxCodeID, TaxRate: line.TaxRate}
+			groups[key] = append(groups[key], i)
+		}
+		for _, indexes := range groups {
+			var total, rounded float64
+			largest := indexes[0]
+			for _, i := range indexes {
+				total += raw[i]
+				rounded += taxed[i].TaxAmount
+				if math.Abs(lines[i].Amount) > math.Abs(lines[largest].Amount) {
+					largest = i
+				}
+			}
+			if diff := roundAmount(roundAmount(total) - rounded); diff != 0 {
+				taxed[largest].TaxAmount = roundAmount(taxed[largest].TaxAmount + diff)
+			}
+		}
+	}
+
+	var totalTax float64
+	for i := range taxed {
+		taxed[i].NetAmount = roundAmount(lines[i].Amount)
+		if inclusive {
+			taxed[i].NetAmount = roundAmount(lines[i].Amount - taxed[i].TaxAmount)
+		}
+		totalTax += taxed[i].TaxAmount
+	}
+	return taxed, roundAmount(totalTax)
+}
+
+// documentTaxTotals 汇总单据税额、价税合计及本位币金额，含税价的价税合计为行金额之和，不含税价另加税额
+func documentTaxTotals(lines []taxLine, taxed []taxedLine, tax float64, inclusive bool, exchangeRate float64) documentTax {
+	var amount float64
+	for _, line := range lines {
+		amount += line.Amount
+	}
+	result := documentTax{Lines: taxed, TaxAmount: tax, TotalAmount: roundAmount(amount)}
+	if !inclusive {
+		result.TotalAmount = roundAmount(amount + tax)
+	}
+	result.BaseTotalAmount = toBaseAmount(result.TotalAmount, exchangeRate)
+	result.BaseTaxAmount = toBaseAmount(result.TaxAmount, exchangeRate)
+	return result
+}
+
+// applyDocumentTax 确定单据各行税码税率并按配置的舍入方式计税，销售发票、贷项通知单和采购发票共用
+func applyDocumentTax(db *gorm.DB, direction, partyStatus string, date time.Time, inclusive bool, exchangeRate float64, lines []taxLine) (documentTax, error) {
+	if err := resolveTaxRates(db, direction, partyStatus, date, lines); err != nil {
+		return documentTax{}, err
+	}
+	taxed, tax := calculateTax(lines, inclusive, config.GetAppConfig().Finance.TaxRounding)
+	return documentTaxTotals(lines, taxed, tax, inclusive, exchangeRate), nil
+}
+
+// productTaxCategories 读取销售产品的税收分类
+func productTaxCategories(db *gorm.DB, productIDs []string) (map[string]string, error) {
+	var products []models.SalesProduct
+	if err := db.Where("id IN ?", productIDs).Find(&products).Error; err != nil {
+		return nil, err
+	}
+	categories := make(map[string]string, len(products))
+	for _, product := range products {
+		categories[product.ID] = product.TaxCategory
+	}
+	return categories, nil
+}
+
+// applySalesInvoiceTax 按客户纳税状态和产品税收分类计算销售发票各行税额，回写发票税额、价税合计及本位币金额
+func applySalesInvoiceTax(db *gorm.DB, invoice *models.SalesInvoice) error {
+	var customer models.SalesCustomer
+	if err := db.First(&customer, "id = ?", invoice.CustomerID).Error; err != nil {
+		return err
+	}
+
+	productIDs := make([]string, len(invoice.Items))
+	for i, item := range invoice.Items {
+		productIDs[i] = item.ProductID
+	}
+	categories, err := productTaxCategories(db, productIDs)
+	if err != nil {
+		return err
+	}
+	lines := make([]taxLine, len(invoice.Items))
+	for i, item := range invoice.Items {
+		lines[i] = taxLine{Amount: item.Amount, Category: categories[item.ProductID]}
+	}
+
+	tax, err := applyDocumentTax(db, taxDirectionSales, customer.TaxStatus, invoice.InvoiceDate, invoice.PriceIncludesTax, invoice.ExchangeRate, lines)
+	if err != nil {
+		return err
+	}
+	for i, line := range tax.Lines {
+		item := &invoice.Items[i]
+		item.TaxCodeID, item.TaxRate, item.NetAmount, item.TaxAmount = line.TaxCodeID, line.TaxRate, line.NetAmount, line.TaxAmount
+	}
+	invoice.TaxAmount, invoice.TotalAmount = tax.TaxAmount, tax.TotalAmount
+	invoice.BaseTotalAmount, invoice.BaseTaxAmount = tax.BaseTotalAmount, tax.BaseTaxAmount
+	return nil
+}
+
+// applySalesCreditNoteTax 按原发票的计税日期重新确定贷项通知单各行税码税率，回写税额、价税合计及本位币金额
+func applySalesCreditNoteTax(db *gorm.DB, note *models.SalesCreditNote, date time.Time) error {
+	var customer models.SalesCustomer
+	if err := db.First(&customer, "id = ?", note.CustomerID).Error; err != nil {
+		return err
+	}
+
+	productIDs := make([]string, len(note.Items))
+	for i, item := range note.Items {
+		productIDs[i] = item.ProductID
+	}
+	categories, err := productTaxCategories(db, productIDs)
+	if err != nil {
+		return err
+	}
+	lines := make([]taxLine, len(note.Items))
+	for i, item := range note.Items {
+		lines[i] = taxLine{Amount: item.Amount, Category: categories[item.ProductID]}
+	}
+
+	tax, err := applyDocumentTax(db, taxDirectionSales, customer.TaxStatus, date, note.PriceIncludesTax, note.ExchangeRate, lines)
+	if err != nil {
+		return err
+	}
+	setSalesCreditNoteTax(note, tax)
+	return nil
+}
+
+// setSalesCreditNoteTax 回写贷项通知单各行税码税率、不含税金额和税额，以及单据税额、价税合计和本位币金额
+func setSalesCreditNoteTax(note *models.SalesCreditNote, tax documentTax) {
+	for i, line := range tax.Lines {
+		item := &note.Items[i]
+		item.TaxCodeID, item.TaxRate, item.NetAmount, item.TaxAmount = line.TaxCodeID, line.TaxRate, line.NetAmount, line.TaxAmount
+	}
+	note.TaxAmount, note.TotalAmount = tax.TaxAmount, tax.TotalAmount
+	note.BaseTotalAmount, note.BaseTaxAmount = tax.BaseTotalAmount, tax.BaseTaxAmount
+}
+
+// applyPurchaseInvoiceTax 按供应商纳税状态和物料税收分类计算采购发票各行税额，回写发票税额、价税合计及本位币金额
+func applyPurchaseInvoiceTax(db *gorm.DB, invoice *models.PurchaseInvoice) error {
+	var vendor models.PurchaseVendor
+	if err := db.First(&vendor, "id = ?", invoice.VendorID).Error; err != nil {
+		return err
+	}
+
+	itemIDs := make([]string, len(invoice.Items))
+	for i, item := range invoice.Items {
+		itemIDs[i] = item.ItemID
+	}
+	var items []models.InventoryItem
+	if err := db.Where("id IN ?", itemIDs).Find(&items).Error; err != nil {
+		return err
+	}
+	categories := make(map[string]string, len(items))
+	for _, item := range items {
+		categories[item.ID] = item.TaxCategory
+	}
+	lines := make([]taxLine, len(invoice.Items))
+	for i, item := range invoice.Items {
+		lines[i] = taxLine{Amount: item.Amount, Category: categories[item.ItemID]}
+	}
+
+	tax, err := applyDocumentTax(db, taxDirectionPurchase, vendor.TaxStatus, invoice.InvoiceDate, invoice.PriceIncludesTax, invoice.ExchangeRate, lines)
+	if err != nil {
+		return err
+	}
+	for i, line := range tax.Lines {
+		item := &invoice.Items[i]
+		item.TaxCodeID, item.TaxRate, item.NetAmount, item.TaxAmount = line.TaxCodeID, line.TaxRate, line.NetAmount, line.TaxAmount
+	}
+	invoice.TaxAmount, invoice.TotalAmount = tax.TaxAmount, tax.TotalAmount
+	invoice.BaseTotalAmount, invoice.BaseTaxAmount = tax.BaseTotalAmount, tax.BaseTaxAmount
+	return nil
+}
+
+// pricesIncludeTax 请求未指定时按配置确定单据价格是否含税
+func pricesIncludeTax(value *bool) bool {
+	if value != nil {
+		return *value
+	}
+	return config.GetAppConfig().Finance.PricesIncludeTax
+}
+
+// taxCodeListSpec 税码列表查询白名单
+var taxCodeListSpec = query.NewSpec("code",
+	query.Text("code"),
+	query.Text("name"),
+	query.Text("status"),
+)
+
+// 税码管理方法
+func (s *financeService) GetTaxCodeList(params query.Params) (*query.Page[schemas.TaxCodeResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取税码及税率
+	var codes []models.FinanceTaxCode
+	total, err := query.Find(s.db, params, taxCodeListSpec, &codes, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Rates", func(db *gorm.DB) *gorm.DB {
+			return db.Order("valid_from ASC")
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	responses := make([]schemas.TaxCodeResponse, len(codes))
+	for i, code := range codes {
+		responses[i] = taxCodeResponse(code)
+	}
+
+	return query.NewPage(responses, total, params), nil
+}
+
+func (s *financeService) GetTaxCodeDetail(id string) (*schemas.TaxCodeResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取税码及税率
+	var code models.FinanceTaxCode
+	result := s.db.Preload("Rates", func(db *gorm.DB) *gorm.DB {
+		return db.Order("valid_from ASC")
+	}).First(&code, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := taxCodeResponse(code)
+	return &response, nil
+}
+
+func (s *financeService) CreateTaxCode(req schemas.TaxCodeCreateRequest) (*schemas.TaxCodeResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 创建税码模型，税率有效期不能重叠
+	code := models.FinanceTaxCode{
+		ID:          utils.GenerateID(),
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+		Status:      req.Status,
+		CreatedBy:   req.CreatedBy,
+		UpdatedBy:   req.CreatedBy,
+	}
+	if code.Status == "" {
+		code.Status = "active"
+	}
+	rates, err := taxCodeRates(code.ID, req.Rates, req.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+	code.Rates = rates
+
+	// 保存到数据库
+	result := s.db.Create(&code)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return s.GetTaxCodeDetail(code.ID)
+}
+
+func (s *financeService) UpdateTaxCode(id string, req schemas.TaxCodeUpdateRequest) (*schemas.TaxCodeResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取税码
+	var code models.FinanceTaxCode
+	result := s.db.First(&code, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 更新字段，税码编码创建后不可修改
+	if req.Name != "" {
+		code.Name = req.Name
+	}
+	if req.Description != "" {
+		code.Description = req.Description
+	}
+	if req.Status != "" {
+		code.Status = req.Status
+	}
+	code.UpdatedBy = req.UpdatedBy
+
+	// 未传税率时只更新税码
+	if len(req.Rates) == 0 {
+		if err := s.db.Save(&code).Error; err != nil {
+			return nil, err
+		}
+		return s.GetTaxCodeDetail(code.ID)
+	}
+
+	// 在同一事务中替换税率，已开具发票的税率已保存在发票明细中不受影响
+	rates, err := taxCodeRates(code.ID, req.Rates, req.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tax_code_id = ?", code.ID).Delete(&models.FinanceTaxRate{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&rates).Error; err != nil {
+			return err
+		}
+		return tx.Omit("Rates").Save(&code).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetTaxCodeDetail(code.ID)
+}
+
+func (s *financeService) DeleteTaxCode(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 被税码规则引用的税码不能删除
+	var count int64
+	if err := s.db.Model(&models.FinanceTaxRule{}).Where("tax_code_id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("tax code is referenced by tax rules")
+	}
+
+	// 在同一事务中删除税码及税率
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tax_code_id = ?", id).Delete(&models.FinanceTaxRate{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.FinanceTaxCode{}, "id = ?", id).Error
+	})
+}
+
+// taxRuleListSpec 税码确定规则列表查询白名单
+var taxRuleListSpec = query.NewSpec("direction,-priority",
+	query.Text("direction"),
+	query.Text("party_tax_status"),
+	query.Text("item_tax_category"),
+	query.Text("tax_code_id"),
+	query.Number("priority"),
+	query.Text("status"),
+)
+
+// 税码确定规则管理方法
+func (s *financeService) GetTaxRuleList(params query.Params) (*query.Page[schemas.TaxRuleResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取税码确定规则
+	var rules []models.FinanceTaxRule
+	total, err := query.Find(s.db, params, taxRuleListSpec, &rules, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("TaxCode")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	responses := make([]schemas.TaxRuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = taxRuleResponse(rule)
+	}
+
+	return query.NewPage(responses, total, params), nil
+}
+
+func (s *financeService) CreateTaxRule(req schemas.TaxRuleCreateRequest) (*schemas.TaxRuleResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 校验税码存在
+	var code models.FinanceTaxCode
+	if err := s.db.First(&code, "id = ?", req.TaxCodeID).Error; err != nil {
+		return nil, err
+	}
+
+	// 创建税码确定规则模型
+	rule := models.FinanceTaxRule{
+		ID:              utils.GenerateID(),
+		Direction:       req.Direction,
+		PartyTaxStatus:  req.PartyTaxStatus,
+		ItemTaxCategory: req.ItemTaxCategory,
+		TaxCodeID:       code.ID,
+		Priority:        req.Priority,
+		Status:          req.Status,
+		CreatedBy:       req.CreatedBy,
+		UpdatedBy:       req.CreatedBy,
+	}
+	if rule.Status == "" {
+		rule.Status = "active"
+	}
+
+	// 保存到数据库
+	result := s.db.Create(&rule)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	rule.TaxCode = code
+	response := taxRuleResponse(rule)
+	return &response, nil
+}
+
+func (s *financeService) UpdateTaxRule(id string, req schemas.TaxRuleUpdateRequest) (*schemas.TaxRuleResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取税码确定规则
+	var rule models.FinanceTaxRule
+	result := s.db.First(&rule, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 更新字段，业务方向创建后不可修改
+	if req.PartyTaxStatus != nil {
+		rule.PartyTaxStatus = *req.PartyTaxStatus
+	}
+	if req.ItemTaxCategory != nil {
+		rule.ItemTaxCategory = *req.ItemTaxCategory
+	}
+	if req.TaxCodeID != "" {
+		rule.TaxCodeID = req.TaxCodeID
+	}
+	if req.Priority != nil {
+		rule.Priority = *req.Priority
+	}
+	if req.Status != "" {
+		rule.Status = req.Status
+	}
+	rule.UpdatedBy = req.UpdatedBy
+
+	// 校验税码存在
+	if err := s.db.First(&rule.TaxCode, "id = ?", rule.TaxCodeID).Error; err != nil {
+		return nil, err
+	}
+
+	// 保存到数据库
+	result = s.db.Omit("TaxCode").Save(&rule)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := taxRuleResponse(rule)
+	return &response, nil
+}
+
+func (s *financeService) DeleteTaxRule(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 从数据库删除税码确定规则，已开具发票的税额不受影响
+	result := s.db.Delete(&models.FinanceTaxRule{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+// vatReportRow 增值税汇总报表查询行
+type vatReportRow struct {
+	TaxCode      string
+	TaxName      string
+	TaxRate      float64
+	NetAmount    float64
+	TaxAmount    float64
+	InvoiceCount int
+}
+
+// GetVATSummaryReport 按税码税率汇总期间内销售发票销项税额（扣减贷项通知单）和已审核采购发票进项税额
+func (s *financeService) GetVATSummaryReport(req schemas.VATReportRequest) (*schemas.VATReportResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	from, before, err := parseLedgerPeriod(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 销项税额取自销售发票明细
+	var output []vatReportRow
+	result := s.db.Table("sales_invoice_items AS i").
+		Select("COALESCE(c.code, '') AS tax_code, COALESCE(c.name, '') AS tax_name, i.tax_rate AS tax_rate, "+
+			"SUM(i.net_amount) AS net_amount, SUM(i.tax_amount) AS tax_amount, COUNT(DISTINCT v.id) AS invoice_count").
+		Joins("JOIN sales_invoices AS v ON v.id = i.invoice_id").
+		Joins("LEFT JOIN finance_tax_codes AS c ON c.id = i.tax_code_id").
+		Where("v.invoice_date >= ? AND v.invoice_date < ? AND v.deleted_at IS NULL AND i.deleted_at IS NULL", *from, *before).
+		Group("c.code, c.name, i.tax_rate").
+		Order("tax_code ASC, tax_rate ASC").
+		Scan(&output)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 销售贷项通知单按税码税率冲减销项税额
+	var credits []vatReportRow
+	result = s.db.Table("sales_credit_note_items AS i").
+		Select("COALESCE(c.code, '') AS tax_code, COALESCE(c.name, '') AS tax_name, i.tax_rate AS tax_rate, "+
+			"SUM(i.net_amount) AS net_amount, SUM(i.tax_amount) AS tax_amount, COUNT(DISTINCT n.id) AS invoice_count").
+		Joins("JOIN sales_credit_notes AS n ON n.id = i.credit_note_id").
+		Joins("LEFT JOIN finance_tax_codes AS c ON c.id = i.tax_code_id").
+		Where("n.credit_date >= ? AND n.credit_date < ? AND n.deleted_at IS NULL AND i.deleted_at IS NULL", *from, *before).
+		Group("c.code, c.name, i.tax_rate").
+		Scan(&credits)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	for _, credit := range credits {
+		matched := false
+		for i := range output {
+			if output[i].TaxCode == credit.TaxCode && output[i].TaxRate == credit.TaxRate {
+				output[i].NetAmount -= credit.NetAmount
+				output[i].TaxAmount -= credit.TaxAmount
+				output[i].InvoiceCount += credit.InvoiceCount
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			credit.NetAmount, credit.TaxAmount = -credit.NetAmount, -credit.TaxAmount
+			output = append(output, credit)
+		}
+	}
+
+	// 进项税额取自已审核采购发票明细
+	verified := invoicedQuantityStatuses
+	var input []vatReportRow
+	result = s.db.Table("purchase_invoice_items AS i").
+		Select("COALESCE(c.code, '') AS tax_code, COALESCE(c.name, '') AS tax_name, i.tax_rate AS tax_rate, "+
+			"SUM(i.net_amount) AS net_amount, SUM(i.tax_amount) AS tax_amount, COUNT(DISTINCT v.id) AS invoice_count").
+		Joins("JOIN purchase_invoices AS v ON v.id = i.invoice_id").
+		Joins("LEFT JOIN finance_tax_codes AS c ON c.id = i.tax_code_id").
+		Where("v.invoice_date >= ? AND v.invoice_date < ? AND v.status IN ? AND v.deleted_at IS NULL AND i.deleted_at IS NULL", *from, *before, verified).
+		Group("c.code, c.name, i.tax_rate").
+		Order("tax_code ASC, tax_rate ASC").
+		Scan(&input)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 未分明细的采购发票按发票头税额单独汇总
+	var headerOnly vatReportRow
+	result = s.db.Model(&models.PurchaseInvoice{}).
+		Select("COALESCE(SUM(total_amount - tax_amount), 0) AS net_amount, COALESCE(SUM(tax_amount), 0) AS tax_amount, COUNT(*) AS invoice_count").
+		Where("invoice_date >= ? AND invoice_date < ? AND status IN ?", *from, *before, verified).
+		Where("NOT EXISTS (SELECT 1 FROM purchase_invoice_items AS i WHERE i.invoice_id = purchase_invoices.id AND i.deleted_at IS NULL)").
+		Scan(&headerOnly)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if headerOnly.InvoiceCount > 0 {
+		input = append(input, headerOnly)
+	}
+
+	response := &schemas.VATReportResponse{
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		OutputTax: make([]schemas.VATReportItem, len(output)),
+		InputTax:  make([]schemas.VATReportItem, len(input)),
+	}
+	for i, row := range output {
+		response.OutputTax[i] = vatReportItem(row)
+		response.OutputTaxTotal += row.TaxAmount
+	}
+	for i, row := range input {
+		response.InputTax[i] = vatReportItem(row)
+		response.InputTaxTotal += row.TaxAmount
+	}
+	response.OutputTaxTotal = roundAmount(response.OutputTaxTotal)
+	response.InputTaxTotal = roundAmount(response.InputTaxTotal)
+	response.TaxPayable = roundAmount(response.OutputTaxTotal - response.InputTaxTotal)
+
+	return response, nil
+}
+
+// taxCodeRates 根据请求构建税率，按生效日期排序并校验有效期不重叠
+func taxCodeRates(codeID string, reqs []schemas.TaxRateRequest, operator string) ([]models.FinanceTaxRate, error) {
+	rates := make([]models.FinanceTaxRate, len(reqs))
+	for i, req := range reqs {
+		validFrom, validTo, err := parseValidity(req.ValidFrom, req.ValidTo)
+		if err != nil {
+			return nil, err
+		}
+		rates[i] = models.FinanceTaxRate{
+			ID:        utils.GenerateID(),
+			TaxCodeID: codeID,
+			Rate:      req.Rate,
+			ValidFrom: validFrom,
+			ValidTo:   validTo,
+			CreatedBy: operator,
+			UpdatedBy: operator,
+		}
+	}
+
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].ValidFrom.Before(rates[j].ValidFrom)
+	})
+	for i := 1; i < len(rates); i++ {
+		previous := rates[i-1]
+		if previous.ValidTo == nil || !previous.ValidTo.Before(rates[i].ValidFrom) {
+			return nil, fmt.Errorf("tax rate valid from %s overlaps the previous rate", rates[i].ValidFrom.Format("2006-01-02"))
+		}
+	}
+	return rates, nil
+}
+
+// taxCodeResponse 将税码模型转换为响应格式，税率已预加载时一并转换
+func taxCodeResponse(code models.FinanceTaxCode) schemas.TaxCodeResponse {
+	response := schemas.TaxCodeResponse{
+		ID:          code.ID,
+		Code:        code.Code,
+		Name:        code.Name,
+		Description: code.Description,
+		Status:      code.Status,
+		Rates:       make([]schemas.TaxRateResponse, len(code.Rates)),
+		CreatedAt:   code.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:   code.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+	for i, rate := range code.Rates {
+		response.Rates[i] = schemas.TaxRateResponse{
+			ID:        rate.ID,
+			Rate:      rate.Rate,
+			ValidFrom: rate.ValidFrom.Format("2006-01-02"),
+		}
+		if rate.ValidTo != nil {
+			response.Rates[i].ValidTo = rate.ValidTo.Format("2006-01-02")
+		}
+	}
+	return response
+}
+
+// taxRuleResponse 将税码确定规则模型转换为响应格式
+func taxRuleResponse(rule models.FinanceTaxRule) schemas.TaxRuleResponse {
+	return schemas.TaxRuleResponse{
+		ID:              rule.ID,
+		Direction:       rule.Direction,
+		PartyTaxStatus:  rule.PartyTaxStatus,
+		ItemTaxCategory: rule.ItemTaxCategory,
+		TaxCodeID:       rule.TaxCodeID,
+		TaxCode:         rule.TaxCode.Code,
+		Priority:        rule.Priority,
+		Status:          rule.Status,
+		CreatedAt:       rule.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:       rule.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+// vatReportItem 将增值税汇总查询行转换为报表明细
+func vatReportItem(row vatReportRow) schemas.VATReportItem {
+	return schemas.VATReportItem{
+		TaxCode:      row.TaxCode,
+		TaxName:      row.TaxName,
+		TaxRate:      row.TaxRate,
+		NetAmount:    roundAmount(row.NetAmount),
+		TaxAmount:    roundAmount(row.TaxAmount),
+		InvoiceCount: row.InvoiceCount,
+	}
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"testing"
+)
+
+// TestCalculateTax 测试含税价、不含税价计税及逐行、单据级舍入
+func TestCalculateTax(t *testing.T) {
+	tests := []struct {
+		name      string
+		lines     []taxLine
+		inclusive bool
+		rounding  string
+		wantNet   []float64
+		wantTax   []float64
+		wantTotal float64
+	}{
+		{
+			name:      "不含税价",
+			lines:     []taxLine{{Amount: 100, TaxCodeID: "VAT13", TaxRate: 13}, {Amount: 50, TaxCodeID: "VAT6", TaxRate: 6}},
+			rounding:  "line",
+			wantNet:   []float64{100, 50},
+			wantTax:   []float64{13, 3},
+			wantTotal: 16,
+		},
+		{
+			name:      "含税价价内分离",
+			lines:     []taxLine{{Amount: 113, TaxCodeID: "VAT13", TaxRate: 13}, {Amount: 50, TaxCodeID: "VAT6", TaxRate: 6}},
+			inclusive: true,
+			rounding:  "line",
+			wantNet:   []float64{100, 47.17},
+			wantTax:   []float64{13, 2.83},
+			wantTotal: 15.83,
+		},
+		{
+			name:      "未匹配税码",
+			lines:     []taxLine{{Amount: 80}},
+			inclusive: true,
+			rounding:  "line",
+			wantNet:   []float64{80},
+			wantTax:   []float64{0},
+			wantTotal: 0,
+		},
+		{
+			name:      "逐行舍入",
+			lines:     []taxLine{{Amount: 0.05, TaxCodeID: "VAT13", TaxRate: 13}, {Amount: 0.05, TaxCodeID: "VAT13", TaxRate: 13}, {Amount: 0.06, TaxCodeID: "VAT13", TaxRate: 13}},
+			rounding:  "line",
+			wantNet:   []float64{0.05, 0.05, 0.06},
+			wantTax:   []float64{0.01, 0.01, 0.01},
+			wantTotal: 0.03,
+		},
+		{
+			name:      "单据级舍入尾差计入金额最大的行",
+			lines:     []taxLine{{Amount: 0.05, TaxCodeID: "VAT13", TaxRate: 13}, {Amount: 0.05, TaxCodeID: "VAT13", TaxRate: 13}, {Amount: 0.06, TaxCodeID: "VAT13", TaxRate: 13}},
+			rounding:  taxRoundingDocument,
+			wantNet:   []float64{0.05, 0.05, 0.06},
+			wantTax:   []float64{0.01, 0.01, 0},
+			wantTotal: 0.02,
+		},
+		{
+			name: "单据级舍入按税码分组",
+			lines: []taxLine{
+				{Amount: 33.33, TaxCodeID: "VAT13", TaxRate: 13},
+				{Amount: 33.33, TaxCodeID: "VAT13", TaxRate: 13},
+				{Amount: 33.34, TaxCodeID: "VAT13", TaxRate: 13},
+				{Amount: 50, TaxCodeID: "VAT6", TaxRate: 6},
+			},
+			inclusive: true,
+			rounding:  taxRoundingDocument,
+			wantNet:   []float64{29.5, 29.5, 29.5, 47.17},
+			wantTax:   []float64{3.83, 3.83, 3.84, 2.83},
+			wantTotal: 14.33,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taxed, total := calculateTax(tt.lines, tt.inclusive, tt.rounding)
+			if total != tt.wantTotal {
+				t.Errorf("Expected total tax %.2f, got %.2f", tt.wantTotal, total)
+			}
+			for i, line := range taxed {
+				if line.NetAmount != tt.wantNet[i] || line.TaxAmount != tt.wantTax[i] {
+					t.Errorf("Line %d: expected net %.2f tax %.2f, got net %.2f tax %.2f",
+						i, tt.wantNet[i], tt.wantTax[i], line.NetAmount, line.TaxAmount)
+				}
+				if line.TaxCodeID != tt.lines[i].TaxCodeID || line.TaxRate != tt.lines[i].TaxRate {
+					t.Errorf("Line %d: expected tax code %s rate %.2f, got %s %.2f",
+						i, tt.lines[i].TaxCodeID, tt.lines[i].TaxRate, line.TaxCodeID, line.TaxRate)
+				}
+			}
+		})
+	}
+}
+
+// TestDocumentTaxTotals 测试价税合计及本位币金额
+func TestDocumentTaxTotals(t *testing.T) {
+	lines := []taxLine{{Amount: 100, TaxCodeID: "VAT13", TaxRate: 13}, {Amount: 200, TaxCodeID: "VAT13", TaxRate: 13}}
+
+	taxed, tax := calculateTax(lines, false, "line")
+	exclusive := documentTaxTotals(lines, taxed, tax, false, 7.1)
+	if exclusive.TaxAmount != 39 || exclusive.TotalAmount != 339 {
+		t.Errorf("Expected tax 39 total 339, got tax %.2f total %.2f", exclusive.TaxAmount, exclusive.TotalAmount)
+	}
+	if exclusive.BaseTotalAmount != 2406.9 || exclusive.BaseTaxAmount != 276.9 {
+		t.Errorf("Expected base total 2406.90 tax 276.90, got %.2f %.2f", exclusive.BaseTotalAmount, exclusive.BaseTaxAmount)
+	}
+
+	taxed, tax = calculateTax(lines, true, "line")
+	inclusive := documentTaxTotals(lines, taxed, tax, true, 0)
+	if inclusive.TaxAmount != 34.51 || inclusive.TotalAmount != 300 {
+		t.Errorf("Expected tax 34.51 total 300, got tax %.2f total %.2f", inclusive.TaxAmount, inclusive.TotalAmount)
+	}
+	if inclusive.BaseTotalAmount != 300 || inclusive.BaseTaxAmount != 34.51 {
+		t.Errorf("Expected base amounts equal to document amounts without exchange rate, got %.2f %.2f", inclusive.BaseTotalAmount, inclusive.BaseTaxAmount)
+	}
+}
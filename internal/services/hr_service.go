@@ -3,81 +3,84 @@ package services
 import (
 	"errors"
 
+	"github.com/wu136995/ginx/internal/api/schemas"
 	"github.com/wu136995/ginx/internal/database"
 	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/query"
+	"github.com/wu136995/ginx/internal/utils"
 	"gorm.io/gorm"
 )
 
 // HRService 人力资源服务接口
 type HRService interface {
 	// 员工管理
-	GetEmployeeList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetEmployeeDetail(id string) (map[string]interface{}, error)
-	CreateEmployee(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateEmployee(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetEmployeeList(params query.Params) (*query.Page[schemas.EmployeeResponse], error)
+	GetEmployeeDetail(id string) (*schemas.EmployeeResponse, error)
+	CreateEmployee(req schemas.EmployeeCreateRequest) (*schemas.EmployeeResponse, error)
+	UpdateEmployee(id string, req schemas.EmployeeUpdateRequest) (*schemas.EmployeeResponse, error)
 	DeleteEmployee(id string) error
 	ActivateEmployee(id string) error
 	DeactivateEmployee(id string) error
 
 	// 部门管理
-	GetDepartmentList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetDepartmentDetail(id string) (map[string]interface{}, error)
-	CreateDepartment(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateDepartment(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetDepartmentList(params query.Params) (*query.Page[schemas.DepartmentResponse], error)
+	GetDepartmentDetail(id string) (*schemas.DepartmentResponse, error)
+	CreateDepartment(req schemas.DepartmentCreateRequest) (*schemas.DepartmentResponse, error)
+	UpdateDepartment(id string, req schemas.DepartmentUpdateRequest) (*schemas.DepartmentResponse, error)
 	DeleteDepartment(id string) error
-	GetDepartmentEmployees(id string) ([]map[string]interface{}, error)
+	GetDepartmentEmployees(id string) ([]schemas.EmployeeResponse, error)
 
 	// 职位管理
-	GetPositionList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetPositionDetail(id string) (map[string]interface{}, error)
-	CreatePosition(req map[string]interface{}) (map[string]interface{}, error)
-	UpdatePosition(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetPositionList(params query.Params) (*query.Page[schemas.PositionResponse], error)
+	GetPositionDetail(id string) (*schemas.PositionResponse, error)
+	CreatePosition(req schemas.PositionCreateRequest) (*schemas.PositionResponse, error)
+	UpdatePosition(id string, req schemas.PositionUpdateRequest) (*schemas.PositionResponse, error)
 	DeletePosition(id string) error
 
 	// 考勤管理
-	GetAttendanceList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetAttendanceDetail(id string) (map[string]interface{}, error)
-	CreateAttendance(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateAttendance(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetAttendanceList(params query.Params) (*query.Page[schemas.AttendanceResponse], error)
+	GetAttendanceDetail(id string) (*schemas.AttendanceResponse, error)
+	CreateAttendance(req schemas.AttendanceCreateRequest) (*schemas.AttendanceResponse, error)
+	UpdateAttendance(id string, req schemas.AttendanceUpdateRequest) (*schemas.AttendanceResponse, error)
 	DeleteAttendance(id string) error
-	ImportAttendance(req map[string]interface{}) error
-	GetAttendanceReport(req map[string]interface{}) (map[string]interface{}, error)
+	ImportAttendance(req schemas.AttendanceImportRequest) error
+	GetAttendanceReport(req schemas.AttendanceReportRequest) (*schemas.AttendanceReportResponse, error)
 
 	// 薪资管理
-	GetSalaryList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetSalaryDetail(id string) (map[string]interface{}, error)
-	CreateSalary(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateSalary(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetSalaryList(params query.Params) (*query.Page[schemas.SalaryResponse], error)
+	GetSalaryDetail(id string) (*schemas.SalaryResponse, error)
+	CreateSalary(req schemas.SalaryCreateRequest) (*schemas.SalaryResponse, error)
+	UpdateSalary(id string, req schemas.SalaryUpdateRequest) (*schemas.SalaryResponse, error)
 	DeleteSalary(id string) error
-	CalculateSalary(req map[string]interface{}) (map[string]interface{}, error)
-	GetSalaryReport(req map[string]interface{}) (map[string]interface{}, error)
+	CalculateSalary(req schemas.SalaryCalculateRequest) ([]schemas.SalaryResponse, error)
+	GetSalaryReport(req schemas.SalaryReportRequest) (*schemas.SalaryReportResponse, error)
 
 	// 培训管理
-	GetTrainingList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetTrainingDetail(id string) (map[string]interface{}, error)
-	CreateTraining(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateTraining(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetTrainingList(params query.Params) (*query.Page[schemas.TrainingResponse], error)
+	GetTrainingDetail(id string) (*schemas.TrainingResponse, error)
+	CreateTraining(req schemas.TrainingCreateRequest) (*schemas.TrainingResponse, error)
+	UpdateTraining(id string, req schemas.TrainingUpdateRequest) (*schemas.TrainingResponse, error)
 	DeleteTraining(id string) error
-	EnrollEmployee(id string, req map[string]interface{}) error
-	CompleteTraining(id string, req map[string]interface{}) error
+	EnrollEmployee(id string, req schemas.EnrollEmployeeRequest) error
+	CompleteTraining(id string, req schemas.TrainingCompleteRequest) error
 
 	// 招聘管理
-	GetRecruitmentList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetRecruitmentDetail(id string) (map[string]interface{}, error)
-	CreateRecruitment(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateRecruitment(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetRecruitmentList(params query.Params) (*query.Page[schemas.RecruitmentResponse], error)
+	GetRecruitmentDetail(id string) (*schemas.RecruitmentResponse, error)
+	CreateRecruitment(req schemas.RecruitmentCreateRequest) (*schemas.RecruitmentResponse, error)
+	UpdateRecruitment(id string, req schemas.RecruitmentUpdateRequest) (*schemas.RecruitmentResponse, error)
 	DeleteRecruitment(id string) error
-	AddApplicant(id string, req map[string]interface{}) (map[string]interface{}, error)
-	GetRecruitmentApplicants(id string) ([]map[string]interface{}, error)
+	AddApplicant(id string, req schemas.ApplicantCreateRequest) (*schemas.ApplicantResponse, error)
+	GetRecruitmentApplicants(id string) ([]schemas.ApplicantResponse, error)
 
 	// 绩效评估管理
-	GetPerformanceList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetPerformanceDetail(id string) (map[string]interface{}, error)
-	CreatePerformance(req map[string]interface{}) (map[string]interface{}, error)
-	UpdatePerformance(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetPerformanceList(params query.Params) (*query.Page[schemas.PerformanceResponse], error)
+	GetPerformanceDetail(id string) (*schemas.PerformanceResponse, error)
+	CreatePerformance(req schemas.PerformanceCreateRequest) (*schemas.PerformanceResponse, error)
+	UpdatePerformance(id string, req schemas.PerformanceUpdateRequest) (*schemas.PerformanceResponse, error)
 	DeletePerformance(id string) error
-	EvaluatePerformance(id string, req map[string]interface{}) (map[string]interface{}, error)
-	GetPerformanceReport(req map[string]interface{}) (map[string]interface{}, error)
+	EvaluatePerformance(id string, req schemas.PerformanceEvaluateRequest) (*schemas.PerformanceResponse, error)
+	GetPerformanceReport(req schemas.PerformanceReportRequest) (*schemas.PerformanceReportResponse, error)
 }
 
 // hrService 人力资源服务实现
@@ -92,8 +95,19 @@ func NewHRService() HRService {
 	}
 }
 
+// employeeListSpec 员工列表查询白名单
+var employeeListSpec = query.NewSpec("-created_at",
+	query.Number("id"),
+	query.Text("username"),
+	query.Text("email"),
+	query.Text("nickname"),
+	query.Text("role"),
+	query.Text("status"),
+	query.Date("created_at"),
+)
+
 // 员工管理方法
-func (s *hrService) GetEmployeeList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *hrService) GetEmployeeList(params query.Params) (*query.Page[schemas.EmployeeResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -101,30 +115,21 @@ func (s *hrService) GetEmployeeList(req map[string]interface{}) ([]map[string]in
 
 	// 从数据库读取用户数据作为员工数据
 	var users []models.User
-	result := s.db.Find(&users)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, employeeListSpec, &users)
+	if err != nil {
+		return nil, err
 	}
 
-	// 将模型转换为map
-	employeeList := make([]map[string]interface{}, len(users))
+	// 将模型转换为响应格式
+	employeeList := make([]schemas.EmployeeResponse, len(users))
 	for i, user := range users {
-		employeeList[i] = map[string]interface{}{
-			"id":       user.ID,
-			"username": user.Username,
-			"email":    user.Email,
-			"nickname": user.Nickname,
-			"avatar":   user.Avatar,
-			"role":     user.Role,
-			"created_at": user.CreatedAt,
-			"updated_at": user.UpdatedAt,
-		}
+		employeeList[i] = employeeResponse(user)
 	}
 
-	return employeeList, nil
+	return query.NewPage(employeeList, total, params), nil
 }
 
-func (s *hrService) GetEmployeeDetail(id string) (map[string]interface{}, error) {
+func (s *hrService) GetEmployeeDetail(id string) (*schemas.EmployeeResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -137,35 +142,30 @@ func (s *hrService) GetEmployeeDetail(id string) (map[string]interface{}, error)
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	employeeDetail := map[string]interface{}{
-		"id":       user.ID,
-		"username": user.Username,
-		"email":    user.Email,
-		"nickname": user.Nickname,
-		"avatar":   user.Avatar,
-		"role":     user.Role,
-		"created_at": user.CreatedAt,
-		"updated_at": user.UpdatedAt,
-	}
-
-	return employeeDetail, nil
+	response := employeeResponse(user)
+	return &response, nil
 }
 
-func (s *hrService) CreateEmployee(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) CreateEmployee(req schemas.EmployeeCreateRequest) (*schemas.EmployeeResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
+	// 密码加密后存储
+	hash, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
+
 	// 创建用户模型作为员工模型
 	user := models.User{
-		Username: req["username"].(string),
-		Email:    req["email"].(string),
-		Password: req["password"].(string),
-		Nickname: req["nickname"].(string),
-		Avatar:   req["avatar"].(string),
-		Role:     req["role"].(string),
+		Username: req.Username,
+		Email:    req.Email,
+		Password: hash,
+		Nickname: req.Nickname,
+		Avatar:   req.Avatar,
+		Role:     req.Role,
 	}
 
 	// 保存到数据库
@@ -174,22 +174,11 @@ func (s *hrService) CreateEmployee(req map[string]interface{}) (map[string]inter
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	employeeMap := map[string]interface{}{
-		"id":       user.ID,
-		"username": user.Username,
-		"email":    user.Email,
-		"nickname": user.Nickname,
-		"avatar":   user.Avatar,
-		"role":     user.Role,
-		"created_at": user.CreatedAt,
-		"updated_at": user.UpdatedAt,
-	}
-
-	return employeeMap, nil
+	response := employeeResponse(user)
+	return &response, nil
 }
 
-func (s *hrService) UpdateEmployee(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) UpdateEmployee(id string, req schemas.EmployeeUpdateRequest) (*schemas.EmployeeResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -203,23 +192,27 @@ func (s *hrService) UpdateEmployee(id string, req map[string]interface{}) (map[s
 	}
 
 	// 更新字段
-	if username, ok := req["username"].(string); ok {
-		user.Username = username
+	if req.Username != "" {
+		user.Username = req.Username
 	}
-	if email, ok := req["email"].(string); ok {
-		user.Email = email
+	if req.Email != "" {
+		user.Email = req.Email
 	}
-	if password, ok := req["password"].(string); ok {
-		user.Password = password
+	if req.Password != "" {
+		hash, err := utils.HashPassword(req.Password)
+		if err != nil {
+			return nil, err
+		}
+		user.Password = hash
 	}
-	if nickname, ok := req["nickname"].(string); ok {
-		user.Nickname = nickname
+	if req.Nickname != "" {
+		user.Nickname = req.Nickname
 	}
-	if avatar, ok := req["avatar"].(string); ok {
-		user.Avatar = avatar
+	if req.Avatar != "" {
+		user.Avatar = req.Avatar
 	}
-	if role, ok := req["role"].(string); ok {
-		user.Role = role
+	if req.Role != "" {
+		user.Role = req.Role
 	}
 
 	// 保存到数据库
@@ -228,19 +221,8 @@ func (s *hrService) UpdateEmployee(id string, req map[string]interface{}) (map[s
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	employeeMap := map[string]interface{}{
-		"id":       user.ID,
-		"username": user.Username,
-		"email":    user.Email,
-		"nickname": user.Nickname,
-		"avatar":   user.Avatar,
-		"role":     user.Role,
-		"created_at": user.CreatedAt,
-		"updated_at": user.UpdatedAt,
-	}
-
-	return employeeMap, nil
+	response := employeeResponse(user)
+	return &response, nil
 }
 
 func (s *hrService) DeleteEmployee(id string) error {
@@ -279,44 +261,44 @@ func (s *hrService) DeactivateEmployee(id string) error {
 }
 
 // 部门管理方法
-func (s *hrService) GetDepartmentList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *hrService) GetDepartmentList(params query.Params) (*query.Page[schemas.DepartmentResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
 	// 这里简单返回空数组，实际实现中应该从数据库读取部门数据
-	return []map[string]interface{}{}, nil
+	return query.NewPage([]schemas.DepartmentResponse{}, 0, params), nil
 }
 
-func (s *hrService) GetDepartmentDetail(id string) (map[string]interface{}, error) {
+func (s *hrService) GetDepartmentDetail(id string) (*schemas.DepartmentResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该从数据库读取部门详情
-	return map[string]interface{}{}, nil
+	// 这里简单返回空结果，实际实现中应该从数据库读取部门详情
+	return &schemas.DepartmentResponse{}, nil
 }
 
-func (s *hrService) CreateDepartment(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) CreateDepartment(req schemas.DepartmentCreateRequest) (*schemas.DepartmentResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该创建部门并保存到数据库
-	return req, nil
+	// 这里简单返回空结果，实际实现中应该创建部门并保存到数据库
+	return &schemas.DepartmentResponse{}, nil
 }
 
-func (s *hrService) UpdateDepartment(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) UpdateDepartment(id string, req schemas.DepartmentUpdateRequest) (*schemas.DepartmentResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该更新部门并保存到数据库
-	return req, nil
+	// 这里简单返回空结果，实际实现中应该更新部门并保存到数据库
+	return &schemas.DepartmentResponse{}, nil
 }
 
 func (s *hrService) DeleteDepartment(id string) error {
@@ -329,55 +311,55 @@ func (s *hrService) DeleteDepartment(id string) error {
 	return nil
 }
 
-func (s *hrService) GetDepartmentEmployees(id string) ([]map[string]interface{}, error) {
+func (s *hrService) GetDepartmentEmployees(id string) ([]schemas.EmployeeResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
 	// 这里简单返回空数组，实际实现中应该从数据库读取部门员工数据
-	return []map[string]interface{}{}, nil
+	return []schemas.EmployeeResponse{}, nil
 }
 
 // 职位管理方法
-func (s *hrService) GetPositionList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *hrService) GetPositionList(params query.Params) (*query.Page[schemas.PositionResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
 	// 这里简单返回空数组，实际实现中应该从数据库读取职位数据
-	return []map[string]interface{}{}, nil
+	return query.NewPage([]schemas.PositionResponse{}, 0, params), nil
 }
 
-func (s *hrService) GetPositionDetail(id string) (map[string]interface{}, error) {
+func (s *hrService) GetPositionDetail(id string) (*schemas.PositionResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该从数据库读取职位详情
-	return map[string]interface{}{}, nil
+	// 这里简单返回空结果，实际实现中应该从数据库读取职位详情
+	return &schemas.PositionResponse{}, nil
 }
 
-func (s *hrService) CreatePosition(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) CreatePosition(req schemas.PositionCreateRequest) (*schemas.PositionResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该创建职位并保存到数据库
-	return req, nil
+	// 这里简单返回空结果，实际实现中应该创建职位并保存到数据库
+	return &schemas.PositionResponse{}, nil
 }
 
-func (s *hrService) UpdatePosition(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) UpdatePosition(id string, req schemas.PositionUpdateRequest) (*schemas.PositionResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该更新职位并保存到数据库
-	return req, nil
+	// 这里简单返回空结果，实际实现中应该更新职位并保存到数据库
+	return &schemas.PositionResponse{}, nil
 }
 
 func (s *hrService) DeletePosition(id string) error {
@@ -391,44 +373,44 @@ func (s *hrService) DeletePosition(id string) error {
 }
 
 // 考勤管理方法
-func (s *hrService) GetAttendanceList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *hrService) GetAttendanceList(params query.Params) (*query.Page[schemas.AttendanceResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
 	// 这里简单返回空数组，实际实现中应该从数据库读取考勤数据
-	return []map[string]interface{}{}, nil
+	return query.NewPage([]schemas.AttendanceResponse{}, 0, params), nil
 }
 
-func (s *hrService) GetAttendanceDetail(id string) (map[string]interface{}, error) {
+func (s *hrService) GetAttendanceDetail(id string) (*schemas.AttendanceResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该从数据库读取考勤详情
-	return map[string]interface{}{}, nil
+	// 这里简单返回空结果，实际实现中应该从数据库读取考勤详情
+	return &schemas.AttendanceResponse{}, nil
 }
 
-func (s *hrService) CreateAttendance(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) CreateAttendance(req schemas.AttendanceCreateRequest) (*schemas.AttendanceResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该创建考勤并保存到数据库
-	return req, nil
+	// 这里简单返回空结果，实际实现中应该创建考勤并保存到数据库
+	return &schemas.AttendanceResponse{}, nil
 }
 
-func (s *hrService) UpdateAttendance(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) UpdateAttendance(id string, req schemas.AttendanceUpdateRequest) (*schemas.AttendanceResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该更新考勤并保存到数据库
-	return req, nil
+	// 这里简单返回空结果，实际实现中应该更新考勤并保存到数据库
+	return &schemas.AttendanceResponse{}, nil
 }
 
 func (s *hrService) DeleteAttendance(id string) error {
@@ -441,7 +423,7 @@ func (s *hrService) DeleteAttendance(id string) error {
 	return nil
 }
 
-func (s *hrService) ImportAttendance(req map[string]interface{}) error {
+func (s *hrService) ImportAttendance(req schemas.AttendanceImportRequest) error {
 	// 检查数据库连接
 	if s.db == nil {
 		return errors.New("database connection is nil")
@@ -451,55 +433,58 @@ func (s *hrService) ImportAttendance(req map[string]interface{}) error {
 	return nil
 }
 
-func (s *hrService) GetAttendanceReport(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) GetAttendanceReport(req schemas.AttendanceReportRequest) (*schemas.AttendanceReportResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该生成考勤报表
-	return map[string]interface{}{}, nil
+	// 这里简单返回空结果，实际实现中应该生成考勤报表
+	return &schemas.AttendanceReportResponse{
+		Period:    req.StartDate + " ~ " + req.EndDate,
+		Employees: []schemas.EmployeeAttendanceReport{},
+	}, nil
 }
 
 // 薪资管理方法
-func (s *hrService) GetSalaryList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *hrService) GetSalaryList(params query.Params) (*query.Page[schemas.SalaryResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
 	// 这里简单返回空数组，实际实现中应该从数据库读取薪资数据
-	return []map[string]interface{}{}, nil
+	return query.NewPage([]schemas.SalaryResponse{}, 0, params), nil
 }
 
-func (s *hrService) GetSalaryDetail(id string) (map[string]interface{}, error) {
+func (s *hrService) GetSalaryDetail(id string) (*schemas.SalaryResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该从数据库读取薪资详情
-	return map[string]interface{}{}, nil
+	// 这里简单返回空结果，实际实现中应该从数据库读取薪资详情
+	return &schemas.SalaryResponse{}, nil
 }
 
-func (s *hrService) CreateSalary(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) CreateSalary(req schemas.SalaryCreateRequest) (*schemas.SalaryResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该创建薪资并保存到数据库
-	return req, nil
+	// 这里简单返回空结果，实际实现中应该创建薪资并保存到数据库
+	return &schemas.SalaryResponse{}, nil
 }
 
-func (s *hrService) UpdateSalary(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) UpdateSalary(id string, req schemas.SalaryUpdateRequest) (*schemas.SalaryResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该更新薪资并保存到数据库
-	return req, nil
+	// 这里简单返回空结果，实际实现中应该更新薪资并保存到数据库
+	return &schemas.SalaryResponse{}, nil
 }
 
 func (s *hrService) DeleteSalary(id string) error {
@@ -512,65 +497,68 @@ func (s *hrService) DeleteSalary(id string) error {
 	return nil
 }
 
-func (s *hrService) CalculateSalary(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) CalculateSalary(req schemas.SalaryCalculateRequest) ([]schemas.SalaryResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该计算薪资
-	return map[string]interface{}{}, nil
+	// 这里简单返回空结果，实际实现中应该计算薪资
+	return []schemas.SalaryResponse{}, nil
 }
 
-func (s *hrService) GetSalaryReport(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) GetSalaryReport(req schemas.SalaryReportRequest) (*schemas.SalaryReportResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该生成薪资报表
-	return map[string]interface{}{}, nil
+	// 这里简单返回空结果，实际实现中应该生成薪资报表
+	return &schemas.SalaryReportResponse{
+		PayPeriod: req.PayPeriod,
+		Salaries:  []schemas.SalaryResponse{},
+	}, nil
 }
 
 // 培训管理方法
-func (s *hrService) GetTrainingList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *hrService) GetTrainingList(params query.Params) (*query.Page[schemas.TrainingResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
 	// 这里简单返回空数组，实际实现中应该从数据库读取培训数据
-	return []map[string]interface{}{}, nil
+	return query.NewPage([]schemas.TrainingResponse{}, 0, params), nil
 }
 
-func (s *hrService) GetTrainingDetail(id string) (map[string]interface{}, error) {
+func (s *hrService) GetTrainingDetail(id string) (*schemas.TrainingResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该从数据库读取培训详情
-	return map[string]interface{}{}, nil
+	// 这里简单返回空结果，实际实现中应该从数据库读取培训详情
+	return &schemas.TrainingResponse{}, nil
 }
 
-func (s *hrService) CreateTraining(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) CreateTraining(req schemas.TrainingCreateRequest) (*schemas.TrainingResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该创建培训并保存到数据库
-	return req, nil
+	// 这里简单返回空结果，实际实现中应该创建培训并保存到数据库
+	return &schemas.TrainingResponse{}, nil
 }
 
-func (s *hrService) UpdateTraining(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) UpdateTraining(id string, req schemas.TrainingUpdateRequest) (*schemas.TrainingResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该更新培训并保存到数据库
-	return req, nil
+	// 这里简单返回空结果，实际实现中应该更新培训并保存到数据库
+	return &schemas.TrainingResponse{}, nil
 }
 
 func (s *hrService) DeleteTraining(id string) error {
@@ -583,7 +571,7 @@ func (s *hrService) DeleteTraining(id string) error {
 	return nil
 }
 
-func (s *hrService) EnrollEmployee(id string, req map[string]interface{}) error {
+func (s *hrService) EnrollEmployee(id string, req schemas.EnrollEmployeeRequest) error {
 	// 检查数据库连接
 	if s.db == nil {
 		return errors.New("database connection is nil")
@@ -593,7 +581,7 @@ func (s *hrService) EnrollEmployee(id string, req map[string]interface{}) error
 	return nil
 }
 
-func (s *hrService) CompleteTraining(id string, req map[string]interface{}) error {
+func (s *hrService) CompleteTraining(id string, req schemas.TrainingCompleteRequest) error {
 	// 检查数据库连接
 	if s.db == nil {
 		return errors.New("database connection is nil")
@@ -604,44 +592,44 @@ func (s *hrService) CompleteTraining(id string, req map[string]interface{}) erro
 }
 
 // 招聘管理方法
-func (s *hrService) GetRecruitmentList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *hrService) GetRecruitmentList(params query.Params) (*query.Page[schemas.RecruitmentResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
 	// 这里简单返回空数组，实际实现中应该从数据库读取招聘数据
-	return []map[string]interface{}{}, nil
+	return query.NewPage([]schemas.RecruitmentResponse{}, 0, params), nil
 }
 
-func (s *hrService) GetRecruitmentDetail(id string) (map[string]interface{}, error) {
+func (s *hrService) GetRecruitmentDetail(id string) (*schemas.RecruitmentResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该从数据库读取招聘详情
-	return map[string]interface{}{}, nil
+	// 这里简单返回空结果，实际实现中应该从数据库读取招聘详情
+	return &schemas.RecruitmentResponse{}, nil
 }
 
-func (s *hrService) CreateRecruitment(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) CreateRecruitment(req schemas.RecruitmentCreateRequest) (*schemas.RecruitmentResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该创建招聘并保存到数据库
-	return req, nil
+	// 这里简单返回空结果，实际实现中应该创建招聘并保存到数据库
+	return &schemas.RecruitmentResponse{}, nil
 }
 
-func (s *hrService) UpdateRecruitment(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) UpdateRecruitment(id string, req schemas.RecruitmentUpdateRequest) (*schemas.RecruitmentResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该更新招聘并保存到数据库
-	return req, nil
+	// 这里简单返回空结果，实际实现中应该更新招聘并保存到数据库
+	return &schemas.RecruitmentResponse{}, nil
 }
 
 func (s *hrService) DeleteRecruitment(id string) error {
@@ -654,65 +642,65 @@ func (s *hrService) DeleteRecruitment(id string) error {
 	return nil
 }
 
-func (s *hrService) AddApplicant(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) AddApplicant(id string, req schemas.ApplicantCreateRequest) (*schemas.ApplicantResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该添加应聘者到招聘
-	return req, nil
+	// 这里简单返回空结果，实际实现中应该添加应聘者到招聘
+	return &schemas.ApplicantResponse{}, nil
 }
 
-func (s *hrService) GetRecruitmentApplicants(id string) ([]map[string]interface{}, error) {
+func (s *hrService) GetRecruitmentApplicants(id string) ([]schemas.ApplicantResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
 	// 这里简单返回空数组，实际实现中应该从数据库读取招聘应聘者数据
-	return []map[string]interface{}{}, nil
+	return []schemas.ApplicantResponse{}, nil
 }
 
 // 绩效评估管理方法
-func (s *hrService) GetPerformanceList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *hrService) GetPerformanceList(params query.Params) (*query.Page[schemas.PerformanceResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
 	// 这里简单返回空数组，实际实现中应该从数据库读取绩效评估数据
-	return []map[string]interface{}{}, nil
+	return query.NewPage([]schemas.PerformanceResponse{}, 0, params), nil
 }
 
-func (s *hrService) GetPerformanceDetail(id string) (map[string]interface{}, error) {
+func (s *hrService) GetPerformanceDetail(id string) (*schemas.PerformanceResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该从数据库读取绩效评估详情
-	return map[string]interface{}{}, nil
+	// 这里简单返回空结果，实际实现中应该从数据库读取绩效评估详情
+	return &schemas.PerformanceResponse{}, nil
 }
 
-func (s *hrService) CreatePerformance(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) CreatePerformance(req schemas.PerformanceCreateRequest) (*schemas.PerformanceResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该创建绩效评估并保存到数据库
-	return req, nil
+	// 这里简单返回空结果，实际实现中应该创建绩效评估并保存到数据库
+	return &schemas.PerformanceResponse{}, nil
 }
 
-func (s *hrService) UpdatePerformance(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) UpdatePerformance(id string, req schemas.PerformanceUpdateRequest) (*schemas.PerformanceResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该更新绩效评估并保存到数据库
-	return req, nil
+	// 这里简单返回空结果，实际实现中应该更新绩效评估并保存到数据库
+	return &schemas.PerformanceResponse{}, nil
 }
 
 func (s *hrService) DeletePerformance(id string) error {
@@ -725,22 +713,40 @@ func (s *hrService) DeletePerformance(id string) error {
 	return nil
 }
 
-func (s *hrService) EvaluatePerformance(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) EvaluatePerformance(id string, req schemas.PerformanceEvaluateRequest) (*schemas.PerformanceResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回请求数据，实际实现中应该评估绩效
-	return req, nil
+	// 这里简单返回空结果，实际实现中应该评估绩效
+	return &schemas.PerformanceResponse{}, nil
 }
 
-func (s *hrService) GetPerformanceReport(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *hrService) GetPerformanceReport(req schemas.PerformanceReportRequest) (*schemas.PerformanceReportResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空map，实际实现中应该生成绩效评估报表
-	return map[string]interface{}{}, nil
+	// 这里简单返回空结果，实际实现中应该生成绩效评估报表
+	return &schemas.PerformanceReportResponse{
+		EvaluationPeriod: req.EvaluationPeriod,
+		Performances:     []schemas.PerformanceResponse{},
+	}, nil
+}
+
+// employeeResponse 将用户模型转换为员工响应格式
+func employeeResponse(user models.User) schemas.EmployeeResponse {
+	return schemas.EmployeeResponse{
+		ID:        user.ID,
+		Username:  user.Username,
+		Email:     user.Email,
+		Nickname:  user.Nickname,
+		Avatar:    user.Avatar,
+		Role:      user.Role,
+		Status:    user.Status,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
 }
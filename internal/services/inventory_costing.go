@@ -0,0 +1,245 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/utils"
+	"gorm.io/gorm/clause"
+)
+
+// 物料计价方法
+const (
+	costMethodMovingAverage = "moving_average"
+	costMethodFIFO          = "fifo"
+	costMethodStandard      = "standard"
+)
+
+// movementCost 库存变动的计价结果
+type movementCost struct {
+	UnitCost  float64
+	TotalCost float64
+	Variance  float64
+}
+
+// inboundCost 计算入库成本，标准成本法按标准成本入账并记录采购价差
+func (p *inventoryPoster) inboundCost(item models.InventoryItem, onHand models.InventoryOnHand, movement inventoryMovement) movementCost {
+	if item.CostMethod == costMethodStandard {
+		cost := movementCost{
+			UnitCost:  item.StandardCost,
+			TotalCost: roundAmount(movement.Quantity * item.StandardCost),
+		}
+		if movement.TotalCost != 0 {
+			cost.Variance = roundAmount(movement.TotalCost - cost.TotalCost)
+		} else if movement.UnitCost > 0 {
+			cost.Variance = roundAmount(movement.Quantity*movement.UnitCost) - cost.TotalCost
+		}
+		return cost
+	}
+
+	// 指定入库金额时单位成本由金额倒算
+	if movement.TotalCost != 0 {
+		return movementCost{
+			UnitCost:  roundUnitCost(movement.TotalCost / movement.Quantity),
+			TotalCost: roundAmount(movement.TotalCost),
+		}
+	}
+
+	// 未提供入库成本时沿用当前成本
+	unitCost := movement.UnitCost
+	if unitCost == 0 {
+		unitCost = currentUnitCost(item, onHand)
+	}
+
+	return movementCost{
+		UnitCost:  unitCost,
+		TotalCost: roundAmount(movement.Quantity * unitCost),
+	}
+}
+
+// outboundCost 计算出库成本，成本由系统按物料计价方法确定
+func (p *inventoryPoster) outboundCost(item models.InventoryItem, onHand models.InventoryOnHand, movement inventoryMovement) (movementCost, error) {
+	quantity := -movement.Quantity
+
+	switch item.CostMethod {
+	case costMethodStandard:
+		return movementCost{
+			UnitCost:  item.StandardCost,
+			TotalCost: -roundAmount(quantity * item.StandardCost),
+		}, nil
+	case costMethodFIFO:
+		totalCost, err := p.consumeLayers(item, onHand, movement, quantity)
+		if err != nil {
+			return movementCost{}, err
+		}
+		return movementCost{
+			UnitCost:  roundUnitCost(totalCost / quantity),
+			TotalCost: -totalCost,
+		}, nil
+	default:
+		unitCost := currentUnitCost(item, onHand)
+		totalCost := roundAmount(quantity * unitCost)
+
+		// 全部出清时按结存成本出库，避免尾差
+		if onHand.Quantity == quantity {
+			totalCost = onHand.TotalCost
+		}
+		return movementCost{
+			UnitCost:  unitCost,
+			TotalCost: -totalCost,
+		}, nil
+	}
+}
+
+// consumeLayers 按先进先出顺序消耗成本层，返回出库总成本
+func (p *inventoryPoster) consumeLayers(item models.InventoryItem, onHand models.InventoryOnHand, movement inventoryMovement, quantity float64) (float64, error) {
+	var layers []models.InventoryCostLayer
+	result := p.tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("item_id = ? AND warehouse_id = ? AND location_id = ? AND remaining_quantity > 0", movement.ItemID, movement.WarehouseID, movement.LocationID).
+		Order("layer_date ASC, created_at ASC").
+		Find(&layers)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	totalCost, consumed := allocateCostLayers(layers, quantity, currentUnitCost(item, onHand))
+	for i := range layers[:consumed] {
+		layer := &layers[i]
+		layer.UpdatedBy = movement.CreatedBy
+		layer.UpdatedAt = time.Now()
+		if err := p.tx.Save(layer).Error; err != nil {
+			return 0, err
+		}
+	}
+
+	return totalCost, nil
+}
+
+// allocateCostLayers 按成本层顺序扣减剩余数量，返回出库总成本和被消耗的成本层个数；
+// 成本层不足（允许负库存）时超出部分按最后消耗的成本层单位成本计价，没有成本层时按当前成本计价
+func allocateCostLayers(layers []models.InventoryCostLayer, quantity, currentCost float64) (float64, int) {
+	var totalCost float64
+	consumedLayers := 0
+	remaining := quantity
+	lastUnitCost := currentCost
+	for i := range layers {
+		if remaining <= 0 {
+			break
+		}
+		layer := &layers[i]
+		consumed := math.Min(layer.RemainingQuantity, remaining)
+		totalCost += consumed * layer.UnitCost
+		remaining -= consumed
+		lastUnitCost = layer.UnitCost
+		layer.RemainingQuantity -= consumed
+		consumedLayers++
+	}
+
+	if remaining > 0 {
+		totalCost += remaining * lastUnitCost
+	}
+
+	return roundAmount(totalCost), consumedLayers
+}
+
+// addLayer 为先进先出物料的入库交易建立成本层，先冲抵负库存部分
+func (p *inventoryPoster) addLayer(onHand models.InventoryOnHand, transaction models.InventoryTransaction) error {
+	quantity := transaction.Quantity
+	if onHand.Quantity < 0 {
+		quantity += onHand.Quantity
+	}
+	if quantity <= 0 {
+		return nil
+	}
+
+	layer := models.InventoryCostLayer{
+		ID:                utils.GenerateID(),
+		ItemID:            transaction.ItemID,
+		WarehouseID:       transaction.WarehouseID,
+		LocationID:        transaction.LocationID,
+		TransactionID:     transaction.ID,
+		LayerDate:         transaction.TransactionDate,
+		OriginalQuantity:  quantity,
+		RemainingQuantity: quantity,
+		UnitCost:          transaction.UnitCost,
+		CreatedBy:         transaction.CreatedBy,
+		CreatedAt:         time.Now(),
+		UpdatedBy:         transaction.CreatedBy,
+		UpdatedAt:         time.Now(),
+	}
+
+	return p.tx.Create(&layer).Error
+}
+
+// revalue 按新单位成本重估现存量，差额记为一笔数量为零的重估交易
+func (p *inventoryPoster) revalue(transactionNo string, item models.InventoryItem, onHand models.InventoryOnHand, newUnitCost float64, transactionDate time.Time, remarks, createdBy string) (*models.InventoryTransaction, error) {
+	if err := checkPeriodOpen(p.tx, transactionDate, false); err != nil {
+		return nil, err
+	}
+
+	newTotalCost := roundAmount(onHand.Quantity * newUnitCost)
+	difference := roundAmount(newTotalCost - onHand.TotalCost)
+
+	// 更新现存量成本
+	onHand.UnitCost = newUnitCost
+	onHand.TotalCost = newTotalCost
+	onHand.UpdatedBy = createdBy
+	onHand.UpdatedAt = time.Now()
+	if err := p.tx.Save(&onHand).Error; err != nil {
+		return nil, err
+	}
+
+	// 先进先出物料同步调整剩余成本层
+	if item.CostMethod == costMethodFIFO {
+		result := p.tx.Model(&models.InventoryCostLayer{}).
+			Where("item_id = ? AND warehouse_id = ? AND location_id = ? AND remaining_quantity > 0", onHand.ItemID, onHand.WarehouseID, onHand.LocationID).
+			Updates(map[string]interface{}{
+				"unit_cost":  newUnitCost,
+				"updated_by": createdBy,
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+	}
+
+	// 写入重估交易
+	transaction := models.InventoryTransaction{
+		ID:              utils.GenerateID(),
+		TransactionNo:   transactionNo,
+		ItemID:          onHand.ItemID,
+		WarehouseID:     onHand.WarehouseID,
+		LocationID:      onHand.LocationID,
+		Type:            "revaluation",
+		Quantity:        0,
+		UnitCost:        newUnitCost,
+		TotalCost:       difference,
+		BalanceQuantity: onHand.Quantity,
+		BalanceCost:     newTotalCost,
+		ReferenceType:   "inventory_revaluation",
+		ReferenceID:     item.ID,
+		TransactionDate: transactionDate,
+		Remarks:         remarks,
+		CreatedBy:       createdBy,
+		CreatedAt:       time.Now(),
+	}
+	if err := p.tx.Create(&transaction).Error; err != nil {
+		return nil, err
+	}
+
+	// 按记账规则生成重估凭证
+	if _, err := postDocument(p.tx, inventoryPostingDocument(transaction)); err != nil {
+		return nil, err
+	}
+
+	return &transaction, nil
+}
+
+// currentUnitCost 取当前单位成本，无结存成本时取标准成本
+func currentUnitCost(item models.InventoryItem, onHand models.InventoryOnHand) float64 {
+	if onHand.UnitCost > 0 {
+		return onHand.UnitCost
+	}
+	return item.StandardCost
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/wu136995/ginx/internal/models"
+)
+
+// TestAllocateCostLayers 测试先进先出按成本层顺序出库，部分消耗成本层，成本层不足或没有成本层（负库存）时的计价
+func TestAllocateCostLayers(t *testing.T) {
+	newLayers := func() []models.InventoryCostLayer {
+		return []models.InventoryCostLayer{
+			{ID: "layer-1", RemainingQuantity: 10, UnitCost: 5},
+			{ID: "layer-2", RemainingQuantity: 5, UnitCost: 6},
+			{ID: "layer-3", RemainingQuantity: 8, UnitCost: 7},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		layers        []models.InventoryCostLayer
+		quantity      float64
+		wantCost      float64
+		wantConsumed  int
+		wantRemaining []float64
+	}{
+		{name: "恰好消耗第一层", layers: newLayers(), quantity: 10, wantCost: 50, wantConsumed: 1, wantRemaining: []float64{0, 5, 8}},
+		{name: "部分消耗第二层", layers: newLayers(), quantity: 12, wantCost: 62, wantConsumed: 2, wantRemaining: []float64{0, 3, 8}},
+		{name: "成本层不足按最后一层成本计价", layers: newLayers(), quantity: 30, wantCost: 185, wantConsumed: 3, wantRemaining: []float64{0, 0, 0}},
+		{name: "没有成本层按当前成本计价", quantity: 4, wantCost: 32},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cost, consumed := allocateCostLayers(tt.layers, tt.quantity, 8)
+			if cost != tt.wantCost || consumed != tt.wantConsumed {
+				t.Errorf("Expected cost %.2f from %d layers, got %.2f from %d", tt.wantCost, tt.wantConsumed, cost, consumed)
+			}
+			for i, layer := range tt.layers {
+				if layer.RemainingQuantity != tt.wantRemaining[i] {
+					t.Errorf("Expected %s remaining %.4f, got %.4f", layer.ID, tt.wantRemaining[i], layer.RemainingQuantity)
+				}
+			}
+		})
+	}
+}
+
+// TestMovingAverageCost 测试移动平均物料出库按当前成本计价、入库后重新计算平均成本，全部出清时按结存金额出库
+func TestMovingAverageCost(t *testing.T) {
+	poster := &inventoryPoster{}
+	item := models.InventoryItem{CostMethod: costMethodMovingAverage, StandardCost: 9}
+	onHand := models.InventoryOnHand{Quantity: 10, TotalCost: 100, UnitCost: 10}
+
+	steps := []struct {
+		name          string
+		movement      inventoryMovement
+		wantUnitCost  float64
+		wantTotalCost float64
+		wantBalance   float64
+		wantAverage   float64
+	}{
+		{name: "出库按平均成本", movement: inventoryMovement{Quantity: -4}, wantUnitCost: 10, wantTotalCost: -40, wantBalance: 60, wantAverage: 10},
+		{name: "入库后重新计算平均成本", movement: inventoryMovement{Quantity: 4, UnitCost: 12.5}, wantUnitCost: 12.5, wantTotalCost: 50, wantBalance: 110, wantAverage: 11},
+		{name: "按金额入库倒算单位成本", movement: inventoryMovement{Quantity: 5, TotalCost: 40}, wantUnitCost: 8, wantTotalCost: 40, wantBalance: 150, wantAverage: 10},
+		{name: "未提供成本时沿用平均成本", movement: inventoryMovement{Quantity: 1}, wantUnitCost: 10, wantTotalCost: 10, wantBalance: 160, wantAverage: 10},
+		{name: "出库后平均成本不变", movement: inventoryMovement{Quantity: -13}, wantUnitCost: 10, wantTotalCost: -130, wantBalance: 30, wantAverage: 10},
+		{name: "全部出清按结存金额出库", movement: inventoryMovement{Quantity: -3}, wantUnitCost: 10, wantTotalCost: -30, wantBalance: 0, wantAverage: 10},
+	}
+
+	for _, step := range steps {
+		var cost movementCost
+		if step.movement.Quantity > 0 {
+			cost = poster.inboundCost(item, onHand, step.movement)
+		} else {
+			var err error
+			cost, err = poster.outboundCost(item, onHand, step.movement)
+			if err != nil {
+				t.Fatalf("%s: unexpected error %v", step.name, err)
+			}
+		}
+		if cost.UnitCost != step.wantUnitCost || cost.TotalCost != step.wantTotalCost {
+			t.Errorf("%s: expected unit cost %.6f total %.2f, got %.6f %.2f", step.name, step.wantUnitCost, step.wantTotalCost, cost.UnitCost, cost.TotalCost)
+		}
+		applyMovementCost(&onHand, step.movement.Quantity, cost)
+		if onHand.TotalCost != step.wantBalance || onHand.UnitCost != step.wantAverage {
+			t.Errorf("%s: expected balance %.2f average %.6f, got %.2f %.6f", step.name, step.wantBalance, step.wantAverage, onHand.TotalCost, onHand.UnitCost)
+		}
+	}
+}
+
+// TestMovingAverageClearsRounding 测试平均成本有尾差时全部出清按结存金额出库，结存金额归零
+func TestMovingAverageClearsRounding(t *testing.T) {
+	poster := &inventoryPoster{}
+	item := models.InventoryItem{CostMethod: costMethodMovingAverage}
+	onHand := models.InventoryOnHand{}
+
+	receipt := poster.inboundCost(item, onHand, inventoryMovement{Quantity: 3, TotalCost: 10})
+	applyMovementCost(&onHand, 3, receipt)
+	if onHand.UnitCost != 3.333333 || onHand.TotalCost != 10 {
+		t.Fatalf("Expected average 3.333333 balance 10, got %.6f %.2f", onHand.UnitCost, onHand.TotalCost)
+	}
+
+	issue, err := poster.outboundCost(item, onHand, inventoryMovement{Quantity: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	applyMovementCost(&onHand, -1, issue)
+	if issue.TotalCost != -3.33 || onHand.TotalCost != 6.67 || onHand.UnitCost != 3.335 {
+		t.Fatalf("Expected issue -3.33 balance 6.67 average 3.335, got %.2f %.2f %.6f", issue.TotalCost, onHand.TotalCost, onHand.UnitCost)
+	}
+
+	issue, err = poster.outboundCost(item, onHand, inventoryMovement{Quantity: -2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	applyMovementCost(&onHand, -2, issue)
+	if issue.TotalCost != -6.67 || onHand.Quantity != 0 || onHand.TotalCost != 0 {
+		t.Errorf("Expected clearing issue -6.67 and zero balance, got %.2f %.4f %.2f", issue.TotalCost, onHand.Quantity, onHand.TotalCost)
+	}
+}
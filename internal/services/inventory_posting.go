@@ -0,0 +1,219 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// inventoryMovement 库存变动，Quantity为正表示入库，为负表示出库；出库成本由计价方法确定，UnitCost仅用于入库；
+// TotalCost不为零时按该金额入库，用于调拨转入等需要按转出实际成本入账的变动
+type inventoryMovement struct {
+	ItemID          string
+	WarehouseID     string
+	LocationID      string
+	Type            string
+	Quantity        float64
+	UnitCost        float64
+	TotalCost       float64
+	ReferenceType   string
+	ReferenceID     string
+	TransactionDate time.Time
+	Remarks         string
+	CreatedBy       string
+}
+
+// inventoryPoster 库存过账引擎，负责写入库存交易并同步现存量
+type inventoryPoster struct {
+	tx *gorm.DB
+}
+
+// newInventoryPoster 创建库存过账引擎，tx应为已开启的数据库事务
+func newInventoryPoster(tx *gorm.DB) *inventoryPoster {
+	return &inventoryPoster{
+		tx: tx,
+	}
+}
+
+// Post 按顺序过账一组库存变动，交易编号为单据编号加行号
+func (p *inventoryPoster) Post(documentNo string, movements []inventoryMovement) ([]models.InventoryTransaction, error) {
+	transactions := make([]models.InventoryTransaction, 0, len(movements))
+	for i, movement := range movements {
+		transaction, err := p.postMovement(fmt.Sprintf("%s%03d", documentNo, i+1), movement)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, *transaction)
+	}
+
+	return transactions, nil
+}
+
+func (p *inventoryPoster) postMovement(transactionNo string, movement inventoryMovement) (*models.InventoryTransaction, error) {
+	if movement.Quantity == 0 {
+		return nil, errors.New("movement quantity must not be zero")
+	}
+
+	// 交易日期所在会计期间必须允许记账
+	if err := checkPeriodOpen(p.tx, movement.TransactionDate, false); err != nil {
+		return nil, err
+	}
+
+	// 读取仓库配置
+	var warehouse models.InventoryWarehouse
+	if err := p.tx.First(&warehouse, "id = ?", movement.WarehouseID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("warehouse %s not found", movement.WarehouseID)
+		}
+		return nil, err
+	}
+
+	// 读取物料计价方法
+	var item models.InventoryItem
+	if err := p.tx.First(&item, "id = ?", movement.ItemID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("item %s not found", movement.ItemID)
+		}
+		return nil, err
+	}
+
+	// 锁定现存量记录，不存在则新建
+	onHand, err := lockOnHand(p.tx, movement)
+	if err != nil {
+		return nil, err
+	}
+	previous := onHand
+
+	balanceQuantity := onHand.Quantity + movement.Quantity
+	if balanceQuantity < 0 && !warehouse.AllowNegativeStock {
+		return nil, fmt.Errorf("insufficient stock for item %s in warehouse %s: on hand %.4f, required %.4f",
+			item.ItemNo, warehouse.Code, onHand.Quantity, -movement.Quantity)
+	}
+
+	// 按物料计价方法计算出入库成本
+	var cost movementCost
+	if movement.Quantity > 0 {
+		cost = p.inboundCost(item, onHand, movement)
+	} else {
+		var err error
+		cost, err = p.outboundCost(item, onHand, movement)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	applyMovementCost(&onHand, movement.Quantity, cost)
+	onHand.UpdatedBy = movement.CreatedBy
+	onHand.UpdatedAt = time.Now()
+
+	// 保存现存量
+	if err := p.tx.Save(&onHand).Error; err != nil {
+		return nil, err
+	}
+
+	// 写入库存交易
+	transaction := models.InventoryTransaction{
+		ID:              utils.GenerateID(),
+		TransactionNo:   transactionNo,
+		ItemID:          movement.ItemID,
+		WarehouseID:     movement.WarehouseID,
+		LocationID:      movement.LocationID,
+		Type:            movement.Type,
+		Quantity:        movement.Quantity,
+		UnitCost:        cost.UnitCost,
+		TotalCost:       cost.TotalCost,
+		BalanceQuantity: onHand.Quantity,
+		BalanceCost:     onHand.TotalCost,
+		CostVariance:    cost.Variance,
+		ReferenceType:   movement.ReferenceType,
+		ReferenceID:     movement.ReferenceID,
+		TransactionDate: movement.TransactionDate,
+		Remarks:         movement.Remarks,
+		CreatedBy:       movement.CreatedBy,
+		CreatedAt:       time.Now(),
+	}
+	if err := p.tx.Create(&transaction).Error; err != nil {
+		return nil, err
+	}
+
+	// 按记账规则生成库存凭证
+	if _, err := postDocument(p.tx, inventoryPostingDocument(transaction)); err != nil {
+		return nil, err
+	}
+
+	// 先进先出物料入库时建立成本层
+	if movement.Quantity > 0 && item.CostMethod == costMethodFIFO {
+		if err := p.addLayer(previous, transaction); err != nil {
+			return nil, err
+		}
+	}
+
+	return &transaction, nil
+}
+
+// roundAmount 金额保留两位小数
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// lockOnHand 锁定物料在仓库库位的现存量记录。记录不存在时先插入零数量记录，并发首次入库由唯一键
+// (item_id, warehouse_id, location_id) 保证只有一方插入成功，另一方忽略冲突后重新读取并锁定已插入的记录
+func lockOnHand(tx *gorm.DB, movement inventoryMovement) (models.InventoryOnHand, error) {
+	var onHand models.InventoryOnHand
+	scope := func() *gorm.DB {
+		return tx.Where("item_id = ? AND warehouse_id = ? AND location_id = ?", movement.ItemID, movement.WarehouseID, movement.LocationID)
+	}
+
+	// 先以非锁定读判断记录是否存在，避免不存在时锁定读加间隙锁导致并发插入死锁
+	var count int64
+	if err := scope().Model(&models.InventoryOnHand{}).Count(&count).Error; err != nil {
+		return onHand, err
+	}
+	if count == 0 {
+		row := models.InventoryOnHand{
+			ID:          utils.GenerateID(),
+			ItemID:      movement.ItemID,
+			WarehouseID: movement.WarehouseID,
+			LocationID:  movement.LocationID,
+			CreatedBy:   movement.CreatedBy,
+			CreatedAt:   time.Now(),
+			UpdatedBy:   movement.CreatedBy,
+			UpdatedAt:   time.Now(),
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&row).Error; err != nil {
+			return onHand, err
+		}
+	}
+
+	err := scope().Clauses(clause.Locking{Strength: "UPDATE"}).First(&onHand).Error
+	return onHand, err
+}
+
+// applyMovementCost 按变动数量和成本更新现存量结存：结存数量为正时单位成本为结存金额除以结存数量（移动平均），
+// 出清时结存金额归零，负库存时沿用本次变动的单位成本
+func applyMovementCost(onHand *models.InventoryOnHand, quantity float64, cost movementCost) {
+	balanceQuantity := onHand.Quantity + quantity
+	balanceCost := roundAmount(onHand.TotalCost + cost.TotalCost)
+	if balanceQuantity == 0 {
+		balanceCost = 0
+	}
+
+	onHand.Quantity = balanceQuantity
+	onHand.TotalCost = balanceCost
+	if balanceQuantity > 0 {
+		onHand.UnitCost = roundUnitCost(balanceCost / balanceQuantity)
+	} else {
+		onHand.UnitCost = cost.UnitCost
+	}
+}
+
+// roundUnitCost 单位成本保留六位小数，避免移动平均成本多次入库后与结存金额产生累计尾差
+func roundUnitCost(unitCost float64) float64 {
+	return math.Round(unitCost*1e6) / 1e6
+}
@@ -0,0 +1,1022 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/database"
+	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/query"
+	"github.com/wu136995/ginx/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 补货方法，再订货点法低于再订货点时按固定批量补货，最小最大法低于最小库存时补足到最大库存
+const (
+	replenishmentMethodReorderPoint = "reorder_point"
+	replenishmentMethodMinMax       = "min_max"
+)
+
+// 补货运行触发方式
+const (
+	replenishmentTriggerManual    = "manual"
+	replenishmentTriggerScheduled = "scheduled"
+)
+
+// 补货运行明细处理结果
+const (
+	replenishmentActionNone             = "none"
+	replenishmentActionTransfer         = "transfer"
+	replenishmentActionPurchase         = "purchase"
+	replenishmentActionTransferPurchase = "transfer_purchase"
+	replenishmentActionSkipped          = "skipped"
+)
+
+// 调拨申请状态
+const (
+	transferRequestStatusPending   = "pending"
+	transferRequestStatusCompleted = "completed"
+	transferRequestStatusCancelled = "cancelled"
+)
+
+// transferRequestSourceReplenishment 补货运行生成的调拨申请来源
+const transferRequestSourceReplenishment = "replenishment"
+
+// openRequisitionStatuses 未转完采购订单、剩余数量计入预计可用量的采购申请状态
+var openRequisitionStatuses = []string{requisitionStatusPending, requisitionStatusSubmitted, requisitionStatusApproved, requisitionStatusPartiallyOrdered}
+
+// openPurchaseOrderStatuses 未收数量计入预计可用量的采购订单状态，未审批订单同样计入以免重复请购
+var openPurchaseOrderStatuses = []string{"pending", "submitted", "approved"}
+
+// replenishmentRuleListSpec 补货参数列表查询白名单
+var replenishmentRuleListSpec = query.NewSpec("itemId",
+	query.Text("item_id").As("itemId"),
+	query.Text("warehouse_id").As("warehouseId"),
+	query.Text("method"),
+	query.Text("preferred_vendor_id").As("preferredVendorId"),
+	query.Text("source_warehouse_id").As("sourceWarehouseId"),
+	query.Text("status"),
+	query.Date("created_at").As("createdAt"),
+)
+
+// replenishmentRunListSpec 补货运行列表查询白名单
+var replenishmentRunListSpec = query.NewSpec("-startedAt",
+	query.Text("run_no").As("runNo"),
+	query.Text("trigger_type").As("trigger"),
+	query.Number("purchase_line_count").As("purchaseLineCount"),
+	query.Number("transfer_count").As("transferCount"),
+	query.Date("started_at").As("startedAt"),
+)
+
+// transferRequestListSpec 调拨申请列表查询白名单
+var transferRequestListSpec = query.NewSpec("-createdAt",
+	query.Text("request_no").As("requestNo"),
+	query.Text("item_id").As("itemId"),
+	query.Text("from_warehouse_id").As("fromWarehouseId"),
+	query.Text("to_warehouse_id").As("toWarehouseId"),
+	query.Text("status"),
+	query.Text("source_id").As("sourceId"),
+	query.Date("required_date").As("requiredDate"),
+	query.Date("created_at").As("createdAt"),
+)
+
+// 自动补货方法
+func (s *inventoryService) GetReplenishmentRuleList(params query.Params) (*query.Page[schemas.ReplenishmentRuleResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取补货参数
+	var rules []models.InventoryReplenishmentRule
+	total, err := query.Find(s.db, params, replenishmentRuleListSpec, &rules, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Item").Preload("Warehouse")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	response := make([]schemas.ReplenishmentRuleResponse, len(rules))
+	for i, rule := range rules {
+		response[i] = replenishmentRuleResponse(rule)
+	}
+
+	return query.NewPage(response, total, params), nil
+}
+
+func (s *inventoryService) CreateReplenishmentRule(req schemas.CreateReplenishmentRuleRequest) (*schemas.ReplenishmentRuleResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 同一物料和仓库只能有一条补货参数
+	var count int64
+	if err := s.db.Model(&models.InventoryReplenishmentRule{}).
+		Where("item_id = ? AND warehouse_id = ?", req.ItemId, req.WarehouseId).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, fmt.Errorf("replenishment rule for item %s in warehouse %s already exists", req.ItemId, req.WarehouseId)
+	}
+
+	status := req.Status
+	if status == "" {
+		status = "active"
+	}
+	rule := models.InventoryReplenishmentRule{
+		ID:                utils.GenerateID(),
+		ItemID:            req.ItemId,
+		WarehouseID:       req.WarehouseId,
+		Method:            req.Method,
+		ReorderPoint:      req.ReorderPoint,
+		SafetyStock:       req.SafetyStock,
+		MinQuantity:       req.MinQuantity,
+		MaxQuantity:       req.MaxQuantity,
+		ReorderQuantity:   req.ReorderQuantity,
+		MultipleQuantity:  req.MultipleQuantity,
+		PreferredVendorID: req.PreferredVendorId,
+		SourceWarehouseID: req.SourceWarehouseId,
+		LeadTimeDays:      req.LeadTimeDays,
+		Status:            status,
+		CreatedBy:         req.CreatedBy,
+		CreatedAt:         time.Now(),
+		UpdatedBy:         req.CreatedBy,
+		UpdatedAt:         time.Now(),
+	}
+	if err := validateReplenishmentRule(s.db, &rule); err != nil {
+		return nil, err
+	}
+
+	// 保存补货参数到数据库
+	if err := s.db.Create(&rule).Error; err != nil {
+		return nil, err
+	}
+
+	response := replenishmentRuleResponse(rule)
+	return &response, nil
+}
+
+func (s *inventoryService) UpdateReplenishmentRule(id string, req schemas.UpdateReplenishmentRuleRequest) (*schemas.ReplenishmentRuleResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	var rule models.InventoryReplenishmentRule
+	if err := s.db.First(&rule, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	rule.Method = req.Method
+	rule.ReorderPoint = req.ReorderPoint
+	rule.SafetyStock = req.SafetyStock
+	rule.MinQuantity = req.MinQuantity
+	rule.MaxQuantity = req.MaxQuantity
+	rule.ReorderQuantity = req.ReorderQuantity
+	rule.MultipleQuantity = req.MultipleQuantity
+	rule.PreferredVendorID = req.PreferredVendorId
+	rule.SourceWarehouseID = req.SourceWarehouseId
+	rule.LeadTimeDays = req.LeadTimeDays
+	if req.Status != "" {
+		rule.Status = req.Status
+	}
+	rule.UpdatedBy = req.UpdatedBy
+	rule.UpdatedAt = time.Now()
+	if err := validateReplenishmentRule(s.db, &rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Save(&rule).Error; err != nil {
+		return nil, err
+	}
+
+	response := replenishmentRuleResponse(rule)
+	return &response, nil
+}
+
+func (s *inventoryService) DeleteReplenishmentRule(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	result := s.db.Delete(&models.InventoryReplenishmentRule{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (s *inventoryService) RunReplenishment(req schemas.RunReplenishmentRequest) (*schemas.ReplenishmentRunResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	return s.runReplenishment(req, replenishmentTriggerManual)
+}
+
+func (s *inventoryService) GetReplenishmentRunList(params query.Params) (*query.Page[schemas.ReplenishmentRunResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取补货运行记录，列表不含明细
+	var runs []models.InventoryReplenishmentRun
+	total, err := query.Find(s.db, params, replenishmentRunListSpec, &runs)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]schemas.ReplenishmentRunResponse, len(runs))
+	for i, run := range runs {
+		response[i] = replenishmentRunResponse(run, "")
+	}
+
+	return query.NewPage(response, total, params), nil
+}
+
+func (s *inventoryService) GetReplenishmentRunDetail(id string) (*schemas.ReplenishmentRunResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取补货运行及明细
+	var run models.InventoryReplenishmentRun
+	result := s.db.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at, item_id, warehouse_id")
+	}).Preload("Lines.Item").First(&run, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var requisitionNo string
+	if run.RequisitionID != "" {
+		var requisition models.PurchaseRequisition
+		if err := s.db.Unscoped().Select("requisition_no").First(&requisition, "id = ?", run.RequisitionID).Error; err == nil {
+			requisitionNo = requisition.RequisitionNo
+		}
+	}
+
+	response := replenishmentRunResponse(run, requisitionNo)
+	return &response, nil
+}
+
+func (s *inventoryService) GetTransferRequestList(params query.Params) (*query.Page[schemas.TransferRequestResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取调拨申请
+	var requests []models.InventoryTransferRequest
+	total, err := query.Find(s.db, params, transferRequestListSpec, &requests, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Item")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]schemas.TransferRequestResponse, len(requests))
+	for i, request := range requests {
+		response[i] = transferRequestResponse(request)
+	}
+
+	return query.NewPage(response, total, params), nil
+}
+
+func (s *inventoryService) CompleteTransferRequest(id string, req schemas.CompleteTransferRequestRequest) (*schemas.TransferRequestResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	var request models.InventoryTransferRequest
+	if err := s.db.First(&request, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	if request.Status != transferRequestStatusPending {
+		return nil, fmt.Errorf("transfer request in status %s cannot be completed", request.Status)
+	}
+
+	// 在同一事务中生成调拨交易并更新申请状态
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		transfer, err := (&inventoryService{db: tx}).CreateWarehouseTransfer(schemas.CreateWarehouseTransferRequest{
+			FromWarehouseId: request.FromWarehouseID,
+			ToWarehouseId:   request.ToWarehouseID,
+			TransactionDate: req.TransactionDate,
+			Remarks:         "Transfer request " + request.RequestNo,
+			Items: []schemas.TransactionItem{{
+				ItemId:         request.ItemID,
+				Quantity:       request.Quantity,
+				FromLocationId: req.FromLocationId,
+				ToLocationId:   req.ToLocationId,
+			}},
+		})
+		if err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.InventoryTransferRequest{}).
+			Where("id = ? AND status = ?", request.ID, transferRequestStatusPending).
+			Updates(map[string]interface{}{
+				"status":         transferRequestStatusCompleted,
+				"transaction_no": transfer.TransactionNo,
+				"updated_by":     req.UpdatedBy,
+				"updated_at":     time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("transfer request has already been processed")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Preload("Item").First(&request, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	response := transferRequestResponse(request)
+	return &response, nil
+}
+
+func (s *inventoryService) CancelTransferRequest(id string, req schemas.CancelTransferRequestRequest) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	var request models.InventoryTransferRequest
+	if err := s.db.First(&request, "id = ?", id).Error; err != nil {
+		return err
+	}
+	if request.Status != transferRequestStatusPending {
+		return fmt.Errorf("transfer request in status %s cannot be cancelled", request.Status)
+	}
+
+	remarks := request.Remarks
+	if req.Reason != "" {
+		remarks = strings.TrimSpace(remarks + "\nCancelled: " + req.Reason)
+	}
+	return s.db.Model(&request).Updates(map[string]interface{}{
+		"status":     transferRequestStatusCancelled,
+		"remarks":    remarks,
+		"updated_by": req.UpdatedBy,
+		"updated_at": time.Now(),
+	}).Error
+}
+
+// StartReplenishmentScheduler 按间隔定时执行补货运行，ctx取消后停止。
+// 部署多个实例时只应在一个实例上启用（其他实例将 inventory.replenishmentInterval 设为0）；
+// 补货运行通过锁定补货参数串行执行，多个实例同时运行不会重复补货，但会互相等待并产生多余的运行记录
+func StartReplenishmentScheduler(ctx context.Context, interval time.Duration) {
+	service := &inventoryService{db: database.GetDB()}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				log.Println("定时补货已停止")
+				return
+			case <-time.After(interval):
+				if service.db == nil {
+					continue
+				}
+				run, err := service.runReplenishment(schemas.RunReplenishmentRequest{CreatedBy: "system"}, replenishmentTriggerScheduled)
+				if err != nil {
+					log.Printf("定时补货失败: %v", err)
+					continue
+				}
+				log.Printf("定时补货完成: %s，请购%d行，调拨%d笔", run.RunNo, run.PurchaseLineCount, run.TransferCount)
+			}
+		}
+	}()
+
+	log.Println("定时补货已启动")
+}
+
+// replenishmentStock 物料各仓库的现存量和预留量，同一次运行内按物料缓存
+type replenishmentStock struct {
+	onHand   map[string]float64
+	reserved map[string]float64
+}
+
+// runReplenishment 在一个事务中执行补货运行。正式运行先锁定全部有效补货参数，定时、手动和多个实例的补货运行依次执行，
+// 后一次运行读取前一次运行生成的采购申请和调拨申请，同一缺口不会重复补货
+func (s *inventoryService) runReplenishment(req schemas.RunReplenishmentRequest, trigger string) (*schemas.ReplenishmentRunResponse, error) {
+	var response *schemas.ReplenishmentRunResponse
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		response, err = replenish(tx, req, trigger)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// replenish 逐条有效补货参数计算预计可用量，低于触发水位时先从供货仓库调拨，不足部分生成待提交的采购申请；
+// 每条补货参数都记录运行明细和计算说明，试运行只返回明细不保存
+func replenish(tx *gorm.DB, req schemas.RunReplenishmentRequest, trigger string) (*schemas.ReplenishmentRunResponse, error) {
+	startedAt := time.Now()
+	today := localDate(startedAt)
+
+	// 供货仓库的触发水位作为可调出量的下限；正式运行按ID顺序锁定全部有效补货参数，串行化补货运行
+	sourceQuery := tx.Where("status = ?", "active").Order("id")
+	if !req.DryRun {
+		sourceQuery = sourceQuery.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	var sourceRules []models.InventoryReplenishmentRule
+	if err := sourceQuery.Find(&sourceRules).Error; err != nil {
+		return nil, err
+	}
+	sourceFloors := replenishmentSourceFloors(sourceRules)
+
+	var rules []models.InventoryReplenishmentRule
+	ruleQuery := tx.Preload("Item").Where("status = ?", "active")
+	if req.ItemId != "" {
+		ruleQuery = ruleQuery.Where("item_id = ?", req.ItemId)
+	}
+	if req.WarehouseId != "" {
+		ruleQuery = ruleQuery.Where("warehouse_id = ?", req.WarehouseId)
+	}
+	if err := ruleQuery.Order("item_id, warehouse_id").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	stocks := make(map[string]*replenishmentStock)
+	itemStock := func(itemID string) (*replenishmentStock, error) {
+		if stock, ok := stocks[itemID]; ok {
+			return stock, nil
+		}
+		onHand, err := inventoryAvailableStock(tx, itemID)
+		if err != nil {
+			return nil, err
+		}
+		reservedByType, err := inventoryReservedQuantities(tx, itemID)
+		if err != nil {
+			return nil, err
+		}
+		reserved := make(map[string]float64)
+		for _, byWarehouse := range reservedByType {
+			for warehouseID, quantity := range byWarehouse {
+				reserved[warehouseID] = roundQuantity(reserved[warehouseID] + quantity)
+			}
+		}
+		stocks[itemID] = &replenishmentStock{onHand: onHand, reserved: reserved}
+		return stocks[itemID], nil
+	}
+
+	// 本次运行新增的调拨数量，计入后续明细的调入调出
+	plannedTransfers := make(map[string]float64)
+	transferKey := func(direction, itemID, warehouseID string) string {
+		return direction + "/" + itemID + "/" + warehouseID
+	}
+
+	var lines []models.InventoryReplenishmentRunLine
+	var requisitionItems []models.PurchaseRequisitionItem
+	var transferRequests []models.InventoryTransferRequest
+	for _, rule := range rules {
+		line := models.InventoryReplenishmentRunLine{
+			ID:                utils.GenerateID(),
+			RuleID:            rule.ID,
+			ItemID:            rule.ItemID,
+			WarehouseID:       rule.WarehouseID,
+			Method:            rule.Method,
+			Action:            replenishmentActionNone,
+			SourceWarehouseID: rule.SourceWarehouseID,
+			CreatedAt:         time.Now(),
+			Item:              rule.Item,
+		}
+		if rule.Item.Status != "" && rule.Item.Status != "active" {
+			line.Action = replenishmentActionSkipped
+			line.Explanation = fmt.Sprintf("item %s is %s, rule skipped", rule.Item.ItemNo, rule.Item.Status)
+			lines = append(lines, line)
+			continue
+		}
+
+		// 预计可用量 = 现存量 - 预留量 + 计划入库 + 未转订单请购 + 调入在途 - 调出待发
+		stock, err := itemStock(rule.ItemID)
+		if err != nil {
+			return nil, err
+		}
+		scheduled, err := replenishmentScheduledReceipts(tx, rule.ItemID, rule.WarehouseID)
+		if err != nil {
+			return nil, err
+		}
+		openRequisitions, err := replenishmentOpenRequisitions(tx, rule.ItemID, rule.WarehouseID)
+		if err != nil {
+			return nil, err
+		}
+		inbound, outbound, err := pendingTransferQuantities(tx, rule.ItemID, rule.WarehouseID)
+		if err != nil {
+			return nil, err
+		}
+		line.OnHandQuantity = stock.onHand[rule.WarehouseID]
+		line.ReservedQuantity = stock.reserved[rule.WarehouseID]
+		line.ScheduledReceipts = scheduled
+		line.OpenRequisitions = openRequisitions
+		line.InboundTransfers = roundQuantity(inbound + plannedTransfers[transferKey("in", rule.ItemID, rule.WarehouseID)])
+		line.OutboundTransfers = roundQuantity(outbound + plannedTransfers[transferKey("out", rule.ItemID, rule.WarehouseID)])
+		line.ProjectedQuantity = roundQuantity(line.OnHandQuantity - line.ReservedQuantity + line.ScheduledReceipts +
+			line.OpenRequisitions + line.InboundTransfers - line.OutboundTransfers)
+		line.TriggerLevel, line.TargetLevel = replenishmentLevels(rule)
+
+		explanation := []string{fmt.Sprintf("projected %s = on hand %s - reserved %s + scheduled receipts %s + open requisitions %s + inbound transfers %s - outbound transfers %s",
+			formatQuantity(line.ProjectedQuantity), formatQuantity(line.OnHandQuantity), formatQuantity(line.ReservedQuantity),
+			formatQuantity(line.ScheduledReceipts), formatQuantity(line.OpenRequisitions), formatQuantity(line.InboundTransfers), formatQuantity(line.OutboundTransfers))}
+
+		need := replenishmentQuantity(rule, line.ProjectedQuantity, line.TriggerLevel, line.TargetLevel)
+		if need <= 0 {
+			explanation = append(explanation, fmt.Sprintf("above %s, no replenishment needed", replenishmentTriggerText(rule, line.TriggerLevel)))
+			line.Explanation = strings.Join(explanation, "; ")
+			lines = append(lines, line)
+			continue
+		}
+		explanation = append(explanation, fmt.Sprintf("at or below %s, %s", replenishmentTriggerText(rule, line.TriggerLevel), replenishmentQuantityText(rule, line.ProjectedQuantity, line.TargetLevel, need)))
+
+		// 先从供货仓库调拨，可调出量为供货仓库现存量减预留量和待发调拨，并保留供货仓库自身的触发水位
+		remaining := need
+		if rule.SourceWarehouseID != "" {
+			sourceStock := stock.onHand[rule.SourceWarehouseID] - stock.reserved[rule.SourceWarehouseID]
+			_, sourceOutbound, err := pendingTransferQuantities(tx, rule.ItemID, rule.SourceWarehouseID)
+			if err != nil {
+				return nil, err
+			}
+			sourceOutbound += plannedTransfers[transferKey("out", rule.ItemID, rule.SourceWarehouseID)]
+			floor := sourceFloors[rule.ItemID+"/"+rule.SourceWarehouseID]
+			free := sourceFreeQuantity(sourceStock, sourceOutbound, floor)
+			line.TransferQuantity = roundQuantity(math.Min(free, remaining))
+			explanation = append(explanation, fmt.Sprintf("source warehouse %s free %s (available %s - pending outbound %s - floor %s)",
+				rule.SourceWarehouseID, formatQuantity(free), formatQuantity(sourceStock), formatQuantity(sourceOutbound), formatQuantity(floor)))
+			if line.TransferQuantity > 0 {
+				transferRequests = append(transferRequests, models.InventoryTransferRequest{
+					ID:              utils.GenerateID(),
+					RequestNo:       autoJournalNo("TR"),
+					ItemID:          rule.ItemID,
+					FromWarehouseID: rule.SourceWarehouseID,
+					ToWarehouseID:   rule.WarehouseID,
+					Quantity:        line.TransferQuantity,
+					RequiredDate:    today,
+					Status:          transferRequestStatusPending,
+					SourceType:      transferRequestSourceReplenishment,
+					CreatedBy:       req.CreatedBy,
+					CreatedAt:       time.Now(),
+					UpdatedBy:       req.CreatedBy,
+					UpdatedAt:       time.Now(),
+				})
+				line.TransferRequestID = transferRequests[len(transferRequests)-1].ID
+				plannedTransfers[transferKey("out", rule.ItemID, rule.SourceWarehouseID)] += line.TransferQuantity
+				plannedTransfers[transferKey("in", rule.ItemID, rule.WarehouseID)] += line.TransferQuantity
+				remaining = roundQuantity(remaining - line.TransferQuantity)
+				explanation = append(explanation, fmt.Sprintf("transfer %s from warehouse %s", formatQuantity(line.TransferQuantity), rule.SourceWarehouseID))
+			}
+		}
+
+		// 不足部分向首选供应商请购，未设置首选供应商时取最近采购的供应商
+		if remaining > 0 {
+			line.PurchaseQuantity = remaining
+			vendorID := rule.PreferredVendorID
+			lastVendorID, lastPrice, err := lastPurchase(tx, rule.ItemID, vendorID)
+			if err != nil {
+				return nil, err
+			}
+			if vendorID == "" {
+				vendorID = lastVendorID
+			}
+			price := rule.Item.StandardCost
+			if lastVendorID != "" && lastVendorID == vendorID {
+				price = lastPrice
+			}
+			leadTime := rule.LeadTimeDays
+			if leadTime == 0 && vendorID != "" {
+				var vendor models.PurchaseVendor
+				if err := tx.Select("lead_time").First(&vendor, "id = ?", vendorID).Error; err == nil {
+					leadTime = vendor.LeadTime
+				}
+			}
+			requiredDate := today.AddDate(0, 0, leadTime)
+			requisitionItems = append(requisitionItems, models.PurchaseRequisitionItem{
+				ID:              utils.GenerateID(),
+				ItemID:          rule.ItemID,
+				VendorID:        vendorID,
+				WarehouseID:     rule.WarehouseID,
+				Quantity:        remaining,
+				EstimatedPrice:  price,
+				EstimatedAmount: roundAmount(remaining * price),
+				RequiredDate:    requiredDate,
+				Purpose:         "Automatic replenishment",
+				CreatedBy:       req.CreatedBy,
+				CreatedAt:       time.Now(),
+				UpdatedBy:       req.CreatedBy,
+				UpdatedAt:       time.Now(),
+			})
+			line.VendorID = vendorID
+			line.RequisitionItemID = requisitionItems[len(requisitionItems)-1].ID
+			if vendorID == "" {
+				explanation = append(explanation, fmt.Sprintf("purchase %s without vendor (no preferred or previous vendor), required by %s",
+					formatQuantity(remaining), requiredDate.Format("2006-01-02")))
+			} else {
+				explanation = append(explanation, fmt.Sprintf("purchase %s from vendor %s at %.2f, required by %s (lead time %d days)",
+					formatQuantity(remaining), vendorID, price, requiredDate.Format("2006-01-02"), leadTime))
+			}
+		}
+
+		switch {
+		case line.TransferQuantity > 0 && line.PurchaseQuantity > 0:
+			line.Action = replenishmentActionTransferPurchase
+		case line.TransferQuantity > 0:
+			line.Action = replenishmentActionTransfer
+		default:
+			line.Action = replenishmentActionPurchase
+		}
+		line.Explanation = strings.Join(explanation, "; ")
+		lines = append(lines, line)
+	}
+
+	run := models.InventoryReplenishmentRun{
+		ID:                utils.GenerateID(),
+		RunNo:             autoJournalNo("RP"),
+		TriggerType:       trigger,
+		RuleCount:         len(rules),
+		PurchaseLineCount: len(requisitionItems),
+		TransferCount:     len(transferRequests),
+		StartedAt:         startedAt,
+		FinishedAt:        time.Now(),
+		CreatedBy:         req.CreatedBy,
+		CreatedAt:         startedAt,
+		Lines:             lines,
+	}
+	if req.DryRun {
+		response := replenishmentRunResponse(run, "")
+		response.ID, response.RunNo, response.DryRun = "", "", true
+		for i := range response.Lines {
+			response.Lines[i].ID, response.Lines[i].TransferRequestId, response.Lines[i].RequisitionItemId = "", "", ""
+		}
+		return &response, nil
+	}
+
+	// 保存运行记录、采购申请和调拨申请
+	requisitionNo, err := saveReplenishmentRun(tx, req, &run, lines, requisitionItems, transferRequests, today)
+	if err != nil {
+		return nil, err
+	}
+
+	run.Lines = lines
+	response := replenishmentRunResponse(run, requisitionNo)
+	return &response, nil
+}
+
+// saveReplenishmentRun 保存补货运行记录及明细，补货数量合并为一张待提交的采购申请并生成调拨申请，返回采购申请编号
+func saveReplenishmentRun(tx *gorm.DB, req schemas.RunReplenishmentRequest, run *models.InventoryReplenishmentRun, lines []models.InventoryReplenishmentRunLine,
+	requisitionItems []models.PurchaseRequisitionItem, transferRequests []models.InventoryTransferRequest, today time.Time) (string, error) {
+	var requisitionNo string
+	if len(requisitionItems) > 0 {
+		requisition := models.PurchaseRequisition{
+			ID:              utils.GenerateID(),
+			RequisitionNo:   autoJournalNo("PR"),
+			DepartmentID:    req.DepartmentId,
+			ApplicantID:     req.CreatedBy,
+			ApplicationDate: today,
+			Status:          requisitionStatusPending,
+			Reason:          "Automatic replenishment run " + run.RunNo,
+			CreatedBy:       req.CreatedBy,
+			CreatedAt:       time.Now(),
+			UpdatedBy:       req.CreatedBy,
+			UpdatedAt:       time.Now(),
+		}
+		for i := range requisitionItems {
+			requisitionItems[i].RequisitionID = requisition.ID
+			requisition.EstimatedAmount += requisitionItems[i].EstimatedAmount
+		}
+		requisition.EstimatedAmount = roundAmount(requisition.EstimatedAmount)
+		requisition.Items = requisitionItems
+		if err := tx.Create(&requisition).Error; err != nil {
+			return "", err
+		}
+		run.RequisitionID = requisition.ID
+		requisitionNo = requisition.RequisitionNo
+	}
+	for i := range transferRequests {
+		transferRequests[i].SourceID = run.ID
+		transferRequests[i].Remarks = "Automatic replenishment run " + run.RunNo
+	}
+	if len(transferRequests) > 0 {
+		if err := tx.Create(&transferRequests).Error; err != nil {
+			return "", err
+		}
+	}
+	run.Lines = nil
+	if err := tx.Create(run).Error; err != nil {
+		return "", err
+	}
+	for i := range lines {
+		lines[i].RunID = run.ID
+	}
+	if len(lines) > 0 {
+		if err := tx.Omit("Item").Create(&lines).Error; err != nil {
+			return "", err
+		}
+	}
+	return requisitionNo, nil
+}
+
+// replenishmentLevels 计算补货触发水位和目标水位，触发水位不低于安全库存：
+// 再订货点法目标为触发水位加固定批量，最小最大法目标为最大库存
+func replenishmentLevels(rule models.InventoryReplenishmentRule) (float64, float64) {
+	if rule.Method == replenishmentMethodMinMax {
+		trigger := math.Max(rule.MinQuantity, rule.SafetyStock)
+		return trigger, math.Max(rule.MaxQuantity, trigger)
+	}
+	trigger := math.Max(rule.ReorderPoint, rule.SafetyStock)
+	return trigger, roundQuantity(trigger + rule.ReorderQuantity)
+}
+
+// replenishmentQuantity 预计可用量不高于触发水位时计算补货数量：再订货点法按固定批量的整数倍补货，直至高于触发水位；
+// 最小最大法补足到最大库存。结果按批量倍数向上取整，未触发时返回0
+func replenishmentQuantity(rule models.InventoryReplenishmentRule, projected, trigger, target float64) float64 {
+	if projected > trigger {
+		return 0
+	}
+	var quantity float64
+	if rule.Method == replenishmentMethodMinMax {
+		quantity = target - projected
+	} else {
+		quantity = (math.Floor((trigger-projected)/rule.ReorderQuantity) + 1) * rule.ReorderQuantity
+	}
+	if rule.MultipleQuantity > 0 {
+		quantity = math.Ceil(roundQuantity(quantity/rule.MultipleQuantity)) * rule.MultipleQuantity
+	}
+	return roundQuantity(math.Max(quantity, 0))
+}
+
+// replenishmentSourceFloors 按物料和仓库汇总供货仓库自身补货规则的触发水位，调出后不低于该水位
+func replenishmentSourceFloors(rules []models.InventoryReplenishmentRule) map[string]float64 {
+	floors := make(map[string]float64)
+	for _, rule := range rules {
+		trigger, _ := replenishmentLevels(rule)
+		floors[rule.ItemID+"/"+rule.WarehouseID] = trigger
+	}
+	return floors
+}
+
+// sourceFreeQuantity 计算供货仓库可调出量：可用量减待发调拨和保留水位，不足时为0
+func sourceFreeQuantity(available, outbound, floor float64) float64 {
+	return roundQuantity(math.Max(available-outbound-floor, 0))
+}
+
+// replenishmentTriggerText 说明触发水位的来源
+func replenishmentTriggerText(rule models.InventoryReplenishmentRule, trigger float64) string {
+	if rule.Method == replenishmentMethodMinMax {
+		return fmt.Sprintf("min %s (min quantity %s, safety stock %s)", formatQuantity(trigger), formatQuantity(rule.MinQuantity), formatQuantity(rule.SafetyStock))
+	}
+	return fmt.Sprintf("reorder point %s (reorder point %s, safety stock %s)", formatQuantity(trigger), formatQuantity(rule.ReorderPoint), formatQuantity(rule.SafetyStock))
+}
+
+// replenishmentQuantityText 说明补货数量的计算
+func replenishmentQuantityText(rule models.InventoryReplenishmentRule, projected, target, quantity float64) string {
+	text := fmt.Sprintf("reorder %s lots of %s = %s", formatQuantity(math.Round(quantity/math.Max(rule.ReorderQuantity, 1))), formatQuantity(rule.ReorderQuantity), formatQuantity(quantity))
+	if rule.Method == replenishmentMethodMinMax {
+		text = fmt.Sprintf("order up to max %s: %s - %s = %s", formatQuantity(target), formatQuantity(target), formatQuantity(projected), formatQuantity(roundQuantity(target-projected)))
+	}
+	if rule.MultipleQuantity > 0 {
+		text += fmt.Sprintf(", rounded up to multiple of %s = %s", formatQuantity(rule.MultipleQuantity), formatQuantity(quantity))
+	}
+	return text
+}
+
+// formatQuantity 去掉数量末尾多余的零用于补货说明
+func formatQuantity(quantity float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.4f", quantity), "0"), ".")
+}
+
+// validateReplenishmentRule 校验补货参数的水位设置和关联的物料、仓库、供应商
+func validateReplenishmentRule(db *gorm.DB, rule *models.InventoryReplenishmentRule) error {
+	switch rule.Method {
+	case replenishmentMethodReorderPoint:
+		if rule.ReorderQuantity <= 0 {
+			return errors.New("reorder point method requires a positive reorder quantity")
+		}
+	case replenishmentMethodMinMax:
+		if rule.MaxQuantity <= 0 || rule.MaxQuantity < rule.MinQuantity {
+			return errors.New("min/max method requires a max quantity not less than min quantity")
+		}
+	default:
+		return fmt.Errorf("unsupported replenishment method %s", rule.Method)
+	}
+	if rule.SourceWarehouseID != "" && rule.SourceWarehouseID == rule.WarehouseID {
+		return errors.New("source warehouse must differ from the replenished warehouse")
+	}
+
+	var item models.InventoryItem
+	if err := db.First(&item, "id = ?", rule.ItemID).Error; err != nil {
+		return fmt.Errorf("item %s: %w", rule.ItemID, err)
+	}
+	warehouseIDs := []string{rule.WarehouseID}
+	if rule.SourceWarehouseID != "" {
+		warehouseIDs = append(warehouseIDs, rule.SourceWarehouseID)
+	}
+	var count int64
+	if err := db.Model(&models.InventoryWarehouse{}).Where("id IN ?", warehouseIDs).Count(&count).Error; err != nil {
+		return err
+	}
+	if int(count) != len(warehouseIDs) {
+		return errors.New("warehouse or source warehouse does not exist")
+	}
+	if rule.PreferredVendorID != "" {
+		var vendor models.PurchaseVendor
+		if err := db.First(&vendor, "id = ?", rule.PreferredVendorID).Error; err != nil {
+			return fmt.Errorf("vendor %s: %w", rule.PreferredVendorID, err)
+		}
+	}
+	return nil
+}
+
+// replenishmentScheduledReceipts 汇总物料在仓库的计划入库：未关闭采购订单的未收数量和已审批、生产中生产订单的计划产量
+func replenishmentScheduledReceipts(db *gorm.DB, itemID, warehouseID string) (float64, error) {
+	var purchased float64
+	result := db.Table("purchase_order_items AS i").
+		Select("COALESCE(SUM(i.quantity - i.received_quantity), 0)").
+		Joins("JOIN purchase_orders AS o ON o.id = i.order_id").
+		Where("i.item_id = ? AND i.warehouse_id = ? AND i.quantity > i.received_quantity AND o.status IN ? AND i.deleted_at IS NULL AND o.deleted_at IS NULL",
+			itemID, warehouseID, openPurchaseOrderStatuses).
+		Scan(&purchased)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	var produced float64
+	result = db.Model(&models.ProductionOrder{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("item_id = ? AND warehouse_id = ? AND status IN ?", itemID, warehouseID, []string{"approved", "in_progress"}).
+		Scan(&produced)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return roundQuantity(purchased + produced), nil
+}
+
+// replenishmentOpenRequisitions 汇总物料在仓库未驳回采购申请中尚未转采购订单的数量
+func replenishmentOpenRequisitions(db *gorm.DB, itemID, warehouseID string) (float64, error) {
+	var quantity float64
+	result := db.Table("purchase_requisition_items AS i").
+		Select("COALESCE(SUM(i.quantity - i.ordered_quantity), 0)").
+		Joins("JOIN purchase_requisitions AS r ON r.id = i.requisition_id").
+		Where("i.item_id = ? AND i.warehouse_id = ? AND i.quantity > i.ordered_quantity AND r.status IN ? AND i.deleted_at IS NULL AND r.deleted_at IS NULL",
+			itemID, warehouseID, openRequisitionStatuses).
+		Scan(&quantity)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return roundQuantity(quantity), nil
+}
+
+// pendingTransferQuantities 汇总物料在仓库待执行的调入和调出调拨申请数量
+func pendingTransferQuantities(db *gorm.DB, itemID, warehouseID string) (float64, float64, error) {
+	var rows []struct {
+		FromWarehouseID string
+		ToWarehouseID   string
+		Quantity        float64
+	}
+	result := db.Model(&models.InventoryTransferRequest{}).
+		Select("from_warehouse_id, to_warehouse_id, SUM(quantity) AS quantity").
+		Where("item_id = ? AND status = ? AND (from_warehouse_id = ? OR to_warehouse_id = ?)", itemID, transferRequestStatusPending, warehouseID, warehouseID).
+		Group("from_warehouse_id, to_warehouse_id").
+		Scan(&rows)
+	if result.Error != nil {
+		return 0, 0, result.Error
+	}
+
+	var inbound, outbound float64
+	for _, row := range rows {
+		if row.ToWarehouseID == warehouseID {
+			inbound += row.Quantity
+		}
+		if row.FromWarehouseID == warehouseID {
+			outbound += row.Quantity
+		}
+	}
+	return roundQuantity(inbound), roundQuantity(outbound), nil
+}
+
+// replenishmentRuleResponse 将补货参数模型转换为响应格式
+func replenishmentRuleResponse(rule models.InventoryReplenishmentRule) schemas.ReplenishmentRuleResponse {
+	return schemas.ReplenishmentRuleResponse{
+		ID:                rule.ID,
+		ItemId:            rule.ItemID,
+		ItemCode:          rule.Item.ItemNo,
+		ItemName:          rule.Item.Name,
+		WarehouseId:       rule.WarehouseID,
+		WarehouseName:     rule.Warehouse.Name,
+		Method:            rule.Method,
+		ReorderPoint:      rule.ReorderPoint,
+		SafetyStock:       rule.SafetyStock,
+		MinQuantity:       rule.MinQuantity,
+		MaxQuantity:       rule.MaxQuantity,
+		ReorderQuantity:   rule.ReorderQuantity,
+		MultipleQuantity:  rule.MultipleQuantity,
+		PreferredVendorId: rule.PreferredVendorID,
+		SourceWarehouseId: rule.SourceWarehouseID,
+		LeadTimeDays:      rule.LeadTimeDays,
+		Status:            rule.Status,
+		CreatedBy:         rule.CreatedBy,
+		CreatedAt:         rule.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedBy:         rule.UpdatedBy,
+		UpdatedAt:         rule.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+// replenishmentRunResponse 将补货运行模型转换为响应格式，明细按物料和仓库排序
+func replenishmentRunResponse(run models.InventoryReplenishmentRun, requisitionNo string) schemas.ReplenishmentRunResponse {
+	response := schemas.ReplenishmentRunResponse{
+		ID:                run.ID,
+		RunNo:             run.RunNo,
+		Trigger:           run.TriggerType,
+		RuleCount:         run.RuleCount,
+		PurchaseLineCount: run.PurchaseLineCount,
+		TransferCount:     run.TransferCount,
+		RequisitionId:     run.RequisitionID,
+		RequisitionNo:     requisitionNo,
+		StartedAt:         run.StartedAt.Format("2006-01-02 15:04:05"),
+		FinishedAt:        run.FinishedAt.Format("2006-01-02 15:04:05"),
+		CreatedBy:         run.CreatedBy,
+	}
+	for _, line := range run.Lines {
+		response.Lines = append(response.Lines, schemas.ReplenishmentRunLineResponse{
+			ID:                line.ID,
+			RuleId:            line.RuleID,
+			ItemId:            line.ItemID,
+			ItemCode:          line.Item.ItemNo,
+			ItemName:          line.Item.Name,
+			WarehouseId:       line.WarehouseID,
+			Method:            line.Method,
+			OnHandQuantity:    line.OnHandQuantity,
+			ReservedQuantity:  line.ReservedQuantity,
+			ScheduledReceipts: line.ScheduledReceipts,
+			OpenRequisitions:  line.OpenRequisitions,
+			InboundTransfers:  line.InboundTransfers,
+			OutboundTransfers: line.OutboundTransfers,
+			ProjectedQuantity: line.ProjectedQuantity,
+			TriggerLevel:      line.TriggerLevel,
+			TargetLevel:       line.TargetLevel,
+			Action:            line.Action,
+			TransferQuantity:  line.TransferQuantity,
+			PurchaseQuantity:  line.PurchaseQuantity,
+			SourceWarehouseId: line.SourceWarehouseID,
+			VendorId:          line.VendorID,
+			TransferRequestId: line.TransferRequestID,
+			RequisitionItemId: line.RequisitionItemID,
+			Explanation:       line.Explanation,
+		})
+	}
+	sort.SliceStable(response.Lines, func(i, j int) bool {
+		if response.Lines[i].ItemId != response.Lines[j].ItemId {
+			return response.Lines[i].ItemId < response.Lines[j].ItemId
+		}
+		return response.Lines[i].WarehouseId < response.Lines[j].WarehouseId
+	})
+	return response
+}
+
+// transferRequestResponse 将调拨申请模型转换为响应格式
+func transferRequestResponse(request models.InventoryTransferRequest) schemas.TransferRequestResponse {
+	return schemas.TransferRequestResponse{
+		ID:              request.ID,
+		RequestNo:       request.RequestNo,
+		ItemId:          request.ItemID,
+		ItemCode:        request.Item.ItemNo,
+		ItemName:        request.Item.Name,
+		FromWarehouseId: request.FromWarehouseID,
+		ToWarehouseId:   request.ToWarehouseID,
+		Quantity:        request.Quantity,
+		RequiredDate:    request.RequiredDate.Format("2006-01-02"),
+		Status:          request.Status,
+		SourceType:      request.SourceType,
+		SourceId:        request.SourceID,
+		TransactionNo:   request.TransactionNo,
+		Remarks:         request.Remarks,
+		CreatedBy:       request.CreatedBy,
+		CreatedAt:       request.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedBy:       request.UpdatedBy,
+		UpdatedAt:       request.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/wu136995/ginx/internal/models"
+)
+
+// TestReplenishmentQuantity 测试再订货点法和最小最大法的触发水位、目标水位及补货数量，安全库存高于触发水位时以安全库存触发
+func TestReplenishmentQuantity(t *testing.T) {
+	reorderPoint := models.InventoryReplenishmentRule{Method: replenishmentMethodReorderPoint, ReorderPoint: 20, SafetyStock: 10, ReorderQuantity: 50}
+	minMax := models.InventoryReplenishmentRule{Method: replenishmentMethodMinMax, MinQuantity: 10, MaxQuantity: 100}
+
+	tests := []struct {
+		name        string
+		rule        models.InventoryReplenishmentRule
+		projected   float64
+		wantTrigger float64
+		wantTarget  float64
+		want        float64
+	}{
+		{name: "再订货点法高于再订货点", rule: reorderPoint, projected: 21, wantTrigger: 20, wantTarget: 70, want: 0},
+		{name: "再订货点法等于再订货点", rule: reorderPoint, projected: 20, wantTrigger: 20, wantTarget: 70, want: 50},
+		{name: "再订货点法补多个批量", rule: reorderPoint, projected: -40, wantTrigger: 20, wantTarget: 70, want: 100},
+		{
+			name:        "再订货点法安全库存高于再订货点",
+			rule:        models.InventoryReplenishmentRule{Method: replenishmentMethodReorderPoint, ReorderPoint: 20, SafetyStock: 30, ReorderQuantity: 50},
+			projected:   25,
+			wantTrigger: 30,
+			wantTarget:  80,
+			want:        50,
+		},
+		{
+			name:        "再订货点法按批量倍数向上取整",
+			rule:        models.InventoryReplenishmentRule{Method: replenishmentMethodReorderPoint, ReorderPoint: 20, ReorderQuantity: 50, MultipleQuantity: 12},
+			projected:   15,
+			wantTrigger: 20,
+			wantTarget:  70,
+			want:        60,
+		},
+		{name: "最小最大法高于最小库存", rule: minMax, projected: 11, wantTrigger: 10, wantTarget: 100, want: 0},
+		{name: "最小最大法补足到最大库存", rule: minMax, projected: 4, wantTrigger: 10, wantTarget: 100, want: 96},
+		{
+			name:        "最小最大法按批量倍数向上取整",
+			rule:        models.InventoryReplenishmentRule{Method: replenishmentMethodMinMax, MinQuantity: 10, MaxQuantity: 100, MultipleQuantity: 25},
+			projected:   4,
+			wantTrigger: 10,
+			wantTarget:  100,
+			want:        100,
+		},
+		{
+			name:        "最小最大法安全库存高于最大库存",
+			rule:        models.InventoryReplenishmentRule{Method: replenishmentMethodMinMax, MinQuantity: 10, MaxQuantity: 30, SafetyStock: 40},
+			projected:   35,
+			wantTrigger: 40,
+			wantTarget:  40,
+			want:        5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trigger, target := replenishmentLevels(tt.rule)
+			if trigger != tt.wantTrigger || target != tt.wantTarget {
+				t.Fatalf("Expected trigger %.4f target %.4f, got %.4f %.4f", tt.wantTrigger, tt.wantTarget, trigger, target)
+			}
+			if got := replenishmentQuantity(tt.rule, tt.projected, trigger, target); got != tt.want {
+				t.Errorf("Expected quantity %.4f, got %.4f", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestSourceFreeQuantity 测试供货仓库可调出量扣除待发调拨后不低于其自身补货规则的触发水位
+func TestSourceFreeQuantity(t *testing.T) {
+	floors := replenishmentSourceFloors([]models.InventoryReplenishmentRule{
+		{ItemID: "item-a", WarehouseID: "wh-main", Method: replenishmentMethodReorderPoint, ReorderPoint: 30, SafetyStock: 10, ReorderQuantity: 100},
+		{ItemID: "item-a", WarehouseID: "wh-b", Method: replenishmentMethodMinMax, MinQuantity: 5, MaxQuantity: 50, SafetyStock: 8},
+	})
+
+	tests := []struct {
+		name      string
+		key       string
+		available float64
+		outbound  float64
+		wantFloor float64
+		want      float64
+	}{
+		{name: "保留再订货点", key: "item-a/wh-main", available: 100, outbound: 20, wantFloor: 30, want: 50},
+		{name: "可调出量受触发水位限制", key: "item-a/wh-main", available: 40, outbound: 5, wantFloor: 30, want: 5},
+		{name: "低于触发水位不可调出", key: "item-a/wh-main", available: 20, wantFloor: 30, want: 0},
+		{name: "安全库存高于最小库存", key: "item-a/wh-b", available: 10, wantFloor: 8, want: 2},
+		{name: "供货仓库没有补货规则", key: "item-b/wh-main", available: 10, outbound: 4, want: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			floor := floors[tt.key]
+			if floor != tt.wantFloor {
+				t.Fatalf("Expected floor %.4f, got %.4f", tt.wantFloor, floor)
+			}
+			if got := sourceFreeQuantity(tt.available, tt.outbound, floor); got != tt.want {
+				t.Errorf("Expected free quantity %.4f, got %.4f", tt.want, got)
+			}
+		})
+	}
+}
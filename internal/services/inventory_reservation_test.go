@@ -0,0 +1,140 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wu136995/ginx/internal/models"
+)
+
+// TestReservationWarehouses 测试指定仓库时只在该仓库预留，否则按未预留量从多到少、仓库ID升序选择
+func TestReservationWarehouses(t *testing.T) {
+	stock := map[string]float64{"wh-b": 5, "wh-a": 5, "wh-c": 20, "wh-d": 0}
+
+	tests := []struct {
+		name      string
+		preferred string
+		want      []string
+	}{
+		{name: "指定仓库", preferred: "wh-b", want: []string{"wh-b"}},
+		{name: "指定仓库无库存", preferred: "wh-x", want: []string{"wh-x"}},
+		{name: "未指定仓库", want: []string{"wh-c", "wh-a", "wh-b", "wh-d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := reservationWarehouses(stock, tt.preferred)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected warehouses %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestAllocateReservations 测试可用量内为硬预留，不足部分为软预留，同一物料的多个明细共用可用量
+func TestAllocateReservations(t *testing.T) {
+	type allocation struct {
+		SourceItemID string
+		WarehouseID  string
+		Type         string
+		Quantity     float64
+	}
+
+	order := models.SalesOrder{
+		ID:      "order-001",
+		OrderNo: "SO001",
+		Items: []models.SalesOrderItem{
+			{ID: "line-1", Product: models.SalesProduct{ItemID: "item-a"}, WarehouseID: "wh-a", Quantity: 8, ShippedQuantity: 2},
+			{ID: "line-2", Product: models.SalesProduct{ItemID: "item-a"}, WarehouseID: "wh-a", Quantity: 5},
+			{ID: "line-3", Product: models.SalesProduct{ItemID: "item-b"}, Quantity: 12},
+			{ID: "line-4", Product: models.SalesProduct{ItemID: "item-b"}, Quantity: 3, ShippedQuantity: 3},
+			{ID: "line-5", Quantity: 4},
+		},
+	}
+	free := map[string]map[string]float64{
+		"item-a": {"wh-a": 10, "wh-b": 50},
+		"item-b": {"wh-a": 4, "wh-b": 6},
+	}
+
+	want := []allocation{
+		{SourceItemID: "line-1", WarehouseID: "wh-a", Type: reservationTypeHard, Quantity: 6},
+		{SourceItemID: "line-2", WarehouseID: "wh-a", Type: reservationTypeHard, Quantity: 4},
+		{SourceItemID: "line-2", WarehouseID: "wh-a", Type: reservationTypeSoft, Quantity: 1},
+		{SourceItemID: "line-3", WarehouseID: "wh-b", Type: reservationTypeHard, Quantity: 6},
+		{SourceItemID: "line-3", WarehouseID: "wh-a", Type: reservationTypeHard, Quantity: 4},
+		{SourceItemID: "line-3", WarehouseID: "", Type: reservationTypeSoft, Quantity: 2},
+	}
+
+	reservations := allocateReservations(order, free, "user-001")
+	var got []allocation
+	for _, reservation := range reservations {
+		got = append(got, allocation{reservation.SourceItemID, reservation.WarehouseID, reservation.Type, reservation.Quantity})
+		if reservation.SourceID != "order-001" || reservation.CreatedBy != "user-001" || reservation.Status != reservationStatusActive {
+			t.Errorf("Unexpected reservation header %+v", reservation)
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected reservations %+v, got %+v", want, got)
+	}
+	if free["item-a"]["wh-a"] != 0 || free["item-a"]["wh-b"] != 50 || free["item-b"]["wh-a"] != 0 || free["item-b"]["wh-b"] != 0 {
+		t.Errorf("Expected allocated stock deducted, got %v", free)
+	}
+}
+
+// TestReservationConsumptions 测试发货先核销发货仓库的硬预留，再核销软预留，最后核销其他仓库的硬预留
+func TestReservationConsumptions(t *testing.T) {
+	reservations := []models.InventoryReservation{
+		{ID: "hard-other", WarehouseID: "wh-b", Type: reservationTypeHard, Quantity: 5},
+		{ID: "soft", WarehouseID: "wh-a", Type: reservationTypeSoft, Quantity: 4, ConsumedQuantity: 1},
+		{ID: "hard-ship-1", WarehouseID: "wh-a", Type: reservationTypeHard, Quantity: 2},
+		{ID: "hard-ship-2", WarehouseID: "wh-a", Type: reservationTypeHard, Quantity: 3, ConsumedQuantity: 3},
+		{ID: "hard-ship-3", WarehouseID: "wh-a", Type: reservationTypeHard, Quantity: 4},
+	}
+
+	tests := []struct {
+		name     string
+		quantity float64
+		want     []reservationConsumption
+	}{
+		{
+			name:     "发货仓库的硬预留足够",
+			quantity: 5,
+			want: []reservationConsumption{
+				{ReservationID: "hard-ship-1", Quantity: 2, Consumed: true},
+				{ReservationID: "hard-ship-3", Quantity: 3},
+			},
+		},
+		{
+			name:     "依次核销软预留和其他仓库的硬预留",
+			quantity: 10,
+			want: []reservationConsumption{
+				{ReservationID: "hard-ship-1", Quantity: 2, Consumed: true},
+				{ReservationID: "hard-ship-3", Quantity: 4, Consumed: true},
+				{ReservationID: "soft", Quantity: 3, Consumed: true},
+				{ReservationID: "hard-other", Quantity: 1},
+			},
+		},
+		{
+			name:     "发货数量超出全部预留",
+			quantity: 20,
+			want: []reservationConsumption{
+				{ReservationID: "hard-ship-1", Quantity: 2, Consumed: true},
+				{ReservationID: "hard-ship-3", Quantity: 4, Consumed: true},
+				{ReservationID: "soft", Quantity: 3, Consumed: true},
+				{ReservationID: "hard-other", Quantity: 5, Consumed: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := reservationConsumptions(reservations, "wh-a", tt.quantity)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected consumptions %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+	if reservations[0].ID != "hard-other" {
+		t.Errorf("Expected input reservations keep their order, got %s first", reservations[0].ID)
+	}
+}
@@ -3,18 +3,22 @@ package services
 import (
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/wu136995/ginx/internal/api/schemas"
 	"github.com/wu136995/ginx/internal/database"
 	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/query"
+	"github.com/wu136995/ginx/internal/utils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // InventoryService 库存服务接口
 type InventoryService interface {
 	// 仓库管理
-	GetWarehouseList(req schemas.GetWarehouseListRequest) ([]schemas.WarehouseResponse, error)
+	GetWarehouseList(params query.Params) (*query.Page[schemas.WarehouseResponse], error)
 	GetWarehouseDetail(id string) (*schemas.WarehouseResponse, error)
 	CreateWarehouse(req schemas.CreateWarehouseRequest) (*schemas.WarehouseResponse, error)
 	UpdateWarehouse(id string, req schemas.UpdateWarehouseRequest) (*schemas.WarehouseResponse, error)
@@ -22,28 +26,46 @@ type InventoryService interface {
 	AddWarehouseLocation(id string, req schemas.AddWarehouseLocationRequest) (*schemas.LocationResponse, error)
 
 	// 物料管理
-	GetItemList(req schemas.GetItemListRequest) ([]schemas.ItemResponse, error)
+	GetItemList(params query.Params) (*query.Page[schemas.ItemResponse], error)
 	GetItemDetail(id string) (*schemas.ItemResponse, error)
 	CreateItem(req schemas.CreateItemRequest) (*schemas.ItemResponse, error)
 	UpdateItem(id string, req schemas.UpdateItemRequest) (*schemas.ItemResponse, error)
 	DeleteItem(id string) error
 	GetItemStock(id string) (*schemas.ItemStockResponse, error)
+	GetItemCostLayers(id string, req schemas.GetCostLayerListRequest) (*schemas.ItemCostLayersResponse, error)
+	RevalueItem(id string, req schemas.RevalueItemRequest) (*schemas.TransactionResponse, error)
 
 	// 库存交易管理
-	GetTransactionList(req schemas.GetTransactionListRequest) ([]schemas.TransactionResponse, error)
+	GetTransactionList(params query.Params) (*query.Page[schemas.TransactionResponse], error)
 	GetTransactionDetail(id string) (*schemas.TransactionResponse, error)
 	CreateTransaction(req schemas.CreateTransactionRequest) (*schemas.TransactionResponse, error)
 	CreateInventoryAdjustment(req schemas.CreateInventoryAdjustmentRequest) (*schemas.TransactionResponse, error)
 	CreateWarehouseTransfer(req schemas.CreateWarehouseTransferRequest) (*schemas.TransactionResponse, error)
 
 	// 库存盘点管理
-	GetCountList(req schemas.GetCountListRequest) ([]schemas.CountResponse, error)
+	GetCountList(params query.Params) (*query.Page[schemas.CountResponse], error)
 	GetCountDetail(id string) (*schemas.CountResponse, error)
 	CreateCount(req schemas.CreateCountRequest) (*schemas.CountResponse, error)
 	UpdateCount(id string, req schemas.UpdateCountRequest) (*schemas.CountResponse, error)
 	CompleteCount(id string, req schemas.CompleteCountRequest) error
 	CancelCount(id string, req schemas.CancelCountRequest) error
 
+	// 库存预留及可承诺量
+	GetReservationList(params query.Params) (*query.Page[schemas.ReservationResponse], error)
+	GetAvailableToPromise(req schemas.GetAvailableToPromiseRequest) (*schemas.AvailableToPromiseResponse, error)
+
+	// 自动补货
+	GetReplenishmentRuleList(params query.Params) (*query.Page[schemas.ReplenishmentRuleResponse], error)
+	CreateReplenishmentRule(req schemas.CreateReplenishmentRuleRequest) (*schemas.ReplenishmentRuleResponse, error)
+	UpdateReplenishmentRule(id string, req schemas.UpdateReplenishmentRuleRequest) (*schemas.ReplenishmentRuleResponse, error)
+	DeleteReplenishmentRule(id string) error
+	RunReplenishment(req schemas.RunReplenishmentRequest) (*schemas.ReplenishmentRunResponse, error)
+	GetReplenishmentRunList(params query.Params) (*query.Page[schemas.ReplenishmentRunResponse], error)
+	GetReplenishmentRunDetail(id string) (*schemas.ReplenishmentRunResponse, error)
+	GetTransferRequestList(params query.Params) (*query.Page[schemas.TransferRequestResponse], error)
+	CompleteTransferRequest(id string, req schemas.CompleteTransferRequestRequest) (*schemas.TransferRequestResponse, error)
+	CancelTransferRequest(id string, req schemas.CancelTransferRequestRequest) error
+
 	// 库存报表管理
 	GetInventoryBalanceReport(req schemas.GetInventoryBalanceReportRequest) (*schemas.InventoryBalanceReportResponse, error)
 	GetInventoryMovementReport(req schemas.GetInventoryMovementReportRequest) (*schemas.InventoryMovementReportResponse, error)
@@ -64,8 +86,19 @@ func NewInventoryService() InventoryService {
 	}
 }
 
+// warehouseListSpec 仓库列表查询白名单
+var warehouseListSpec = query.NewSpec("warehouseNo",
+	query.Text("code").As("warehouseNo"),
+	query.Text("name"),
+	query.Text("type"),
+	query.Text("region"),
+	query.Text("status"),
+	query.Number("capacity"),
+	query.Date("created_at").As("createdAt"),
+)
+
 // 仓库管理方法
-func (s *inventoryService) GetWarehouseList(req schemas.GetWarehouseListRequest) ([]schemas.WarehouseResponse, error) {
+func (s *inventoryService) GetWarehouseList(params query.Params) (*query.Page[schemas.WarehouseResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -73,35 +106,36 @@ func (s *inventoryService) GetWarehouseList(req schemas.GetWarehouseListRequest)
 
 	// 从数据库读取仓库数据
 	var warehouses []models.InventoryWarehouse
-	result := s.db.Find(&warehouses)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, warehouseListSpec, &warehouses)
+	if err != nil {
+		return nil, err
 	}
 
 	// 将模型转换为响应格式
 	response := make([]schemas.WarehouseResponse, len(warehouses))
 	for i, warehouse := range warehouses {
 		response[i] = schemas.WarehouseResponse{
-			ID:           warehouse.ID,
-			Code:         warehouse.Code,
-			Name:         warehouse.Name,
-			Type:         warehouse.Type,
-			Address:      warehouse.Address,
-			Region:       warehouse.Region,
-			Contact:      warehouse.Contact,
-			Phone:        warehouse.Phone,
-			Description:  warehouse.Description,
-			Capacity:     warehouse.Capacity,
-			UsedCapacity: warehouse.UsedCapacity,
-			Status:       warehouse.Status,
-			CreatedBy:    warehouse.CreatedBy,
-			CreatedAt:    warehouse.CreatedAt,
-			UpdatedBy:    warehouse.UpdatedBy,
-			UpdatedAt:    warehouse.UpdatedAt,
+			ID:                 warehouse.ID,
+			Code:               warehouse.Code,
+			Name:               warehouse.Name,
+			Type:               warehouse.Type,
+			Address:            warehouse.Address,
+			Region:             warehouse.Region,
+			Contact:            warehouse.Contact,
+			Phone:              warehouse.Phone,
+			Description:        warehouse.Description,
+			Capacity:           warehouse.Capacity,
+			UsedCapacity:       warehouse.UsedCapacity,
+			AllowNegativeStock: warehouse.AllowNegativeStock,
+			Status:             warehouse.Status,
+			CreatedBy:          warehouse.CreatedBy,
+			CreatedAt:          warehouse.CreatedAt,
+			UpdatedBy:          warehouse.UpdatedBy,
+			UpdatedAt:          warehouse.UpdatedAt,
 		}
 	}
 
-	return response, nil
+	return query.NewPage(response, total, params), nil
 }
 
 func (s *inventoryService) GetWarehouseDetail(id string) (*schemas.WarehouseResponse, error) {
@@ -119,22 +153,23 @@ func (s *inventoryService) GetWarehouseDetail(id string) (*schemas.WarehouseResp
 
 	// 将模型转换为响应格式
 	response := &schemas.WarehouseResponse{
-		ID:           warehouse.ID,
-		Code:         warehouse.Code,
-		Name:         warehouse.Name,
-		Type:         warehouse.Type,
-		Address:      warehouse.Address,
-		Region:       warehouse.Region,
-		Contact:      warehouse.Contact,
-		Phone:        warehouse.Phone,
-		Description:  warehouse.Description,
-		Capacity:     warehouse.Capacity,
-		UsedCapacity: warehouse.UsedCapacity,
-		Status:       warehouse.Status,
-		CreatedBy:    warehouse.CreatedBy,
-		CreatedAt:    warehouse.CreatedAt,
-		UpdatedBy:    warehouse.UpdatedBy,
-		UpdatedAt:    warehouse.UpdatedAt,
+		ID:                 warehouse.ID,
+		Code:               warehouse.Code,
+		Name:               warehouse.Name,
+		Type:               warehouse.Type,
+		Address:            warehouse.Address,
+		Region:             warehouse.Region,
+		Contact:            warehouse.Contact,
+		Phone:              warehouse.Phone,
+		Description:        warehouse.Description,
+		Capacity:           warehouse.Capacity,
+		UsedCapacity:       warehouse.UsedCapacity,
+		AllowNegativeStock: warehouse.AllowNegativeStock,
+		Status:             warehouse.Status,
+		CreatedBy:          warehouse.CreatedBy,
+		CreatedAt:          warehouse.CreatedAt,
+		UpdatedBy:          warehouse.UpdatedBy,
+		UpdatedAt:          warehouse.UpdatedAt,
 	}
 
 	return response, nil
@@ -148,21 +183,22 @@ func (s *inventoryService) CreateWarehouse(req schemas.CreateWarehouseRequest) (
 
 	// 创建仓库模型
 	warehouse := models.InventoryWarehouse{
-		ID:          req.ID,
-		Code:        req.Code,
-		Name:        req.Name,
-		Type:        req.Type,
-		Address:     req.Address,
-		Region:      req.Region,
-		Contact:     req.Contact,
-		Phone:       req.Phone,
-		Description: req.Description,
-		Capacity:    req.Capacity,
-		Status:      req.Status,
-		CreatedBy:   req.CreatedBy,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
-		UpdatedBy:   req.CreatedBy,
+		ID:                 req.ID,
+		Code:               req.Code,
+		Name:               req.Name,
+		Type:               req.Type,
+		Address:            req.Address,
+		Region:             req.Region,
+		Contact:            req.Contact,
+		Phone:              req.Phone,
+		Description:        req.Description,
+		Capacity:           req.Capacity,
+		AllowNegativeStock: req.AllowNegativeStock,
+		Status:             req.Status,
+		CreatedBy:          req.CreatedBy,
+		CreatedAt:          time.Now(),
+		UpdatedAt:          time.Now(),
+		UpdatedBy:          req.CreatedBy,
 	}
 
 	// 保存到数据库
@@ -173,22 +209,23 @@ func (s *inventoryService) CreateWarehouse(req schemas.CreateWarehouseRequest) (
 
 	// 将模型转换为响应格式
 	response := &schemas.WarehouseResponse{
-		ID:           warehouse.ID,
-		Code:         warehouse.Code,
-		Name:         warehouse.Name,
-		Type:         warehouse.Type,
-		Address:      warehouse.Address,
-		Region:       warehouse.Region,
-		Contact:      warehouse.Contact,
-		Phone:        warehouse.Phone,
-		Description:  warehouse.Description,
-		Capacity:     warehouse.Capacity,
-		UsedCapacity: warehouse.UsedCapacity,
-		Status:       warehouse.Status,
-		CreatedBy:    warehouse.CreatedBy,
-		CreatedAt:    warehouse.CreatedAt,
-		UpdatedBy:    warehouse.UpdatedBy,
-		UpdatedAt:    warehouse.UpdatedAt,
+		ID:                 warehouse.ID,
+		Code:               warehouse.Code,
+		Name:               warehouse.Name,
+		Type:               warehouse.Type,
+		Address:            warehouse.Address,
+		Region:             warehouse.Region,
+		Contact:            warehouse.Contact,
+		Phone:              warehouse.Phone,
+		Description:        warehouse.Description,
+		Capacity:           warehouse.Capacity,
+		UsedCapacity:       warehouse.UsedCapacity,
+		AllowNegativeStock: warehouse.AllowNegativeStock,
+		Status:             warehouse.Status,
+		CreatedBy:          warehouse.CreatedBy,
+		CreatedAt:          warehouse.CreatedAt,
+		UpdatedBy:          warehouse.UpdatedBy,
+		UpdatedAt:          warehouse.UpdatedAt,
 	}
 
 	return response, nil
@@ -232,6 +269,9 @@ func (s *inventoryService) UpdateWarehouse(id string, req schemas.UpdateWarehous
 	if req.Capacity > 0 {
 		warehouse.Capacity = req.Capacity
 	}
+	if req.AllowNegativeStock != nil {
+		warehouse.AllowNegativeStock = *req.AllowNegativeStock
+	}
 	if req.Status != "" {
 		warehouse.Status = req.Status
 	}
@@ -244,20 +284,21 @@ func (s *inventoryService) UpdateWarehouse(id string, req schemas.UpdateWarehous
 
 	// 将模型转换为响应格式
 	response := &schemas.WarehouseResponse{
-		ID:           warehouse.ID,
-		Code:         warehouse.Code,
-		Name:         warehouse.Name,
-		Type:         warehouse.Type,
-		Address:      warehouse.Address,
-		Region:       warehouse.Region,
-		Contact:      warehouse.Contact,
-		Phone:        warehouse.Phone,
-		Description:  warehouse.Description,
-		Capacity:     warehouse.Capacity,
-		UsedCapacity: warehouse.UsedCapacity,
-		Status:       warehouse.Status,
-		CreatedAt:    warehouse.CreatedAt,
-		UpdatedAt:    warehouse.UpdatedAt,
+		ID:                 warehouse.ID,
+		Code:               warehouse.Code,
+		Name:               warehouse.Name,
+		Type:               warehouse.Type,
+		Address:            warehouse.Address,
+		Region:             warehouse.Region,
+		Contact:            warehouse.Contact,
+		Phone:              warehouse.Phone,
+		Description:        warehouse.Description,
+		Capacity:           warehouse.Capacity,
+		UsedCapacity:       warehouse.UsedCapacity,
+		AllowNegativeStock: warehouse.AllowNegativeStock,
+		Status:             warehouse.Status,
+		CreatedAt:          warehouse.CreatedAt,
+		UpdatedAt:          warehouse.UpdatedAt,
 	}
 
 	return response, nil
@@ -323,8 +364,21 @@ func (s *inventoryService) AddWarehouseLocation(id string, req schemas.AddWareho
 	return response, nil
 }
 
+// itemListSpec 物料列表查询白名单
+var itemListSpec = query.NewSpec("itemNo",
+	query.Text("item_no").As("itemNo"),
+	query.Text("name"),
+	query.Text("category_id").As("category"),
+	query.Text("unit"),
+	query.Text("type"),
+	query.Text("cost_method").As("costMethod"),
+	query.Text("status"),
+	query.Number("standard_cost").As("standardCost"),
+	query.Date("created_at").As("createdAt"),
+)
+
 // 物料管理方法
-func (s *inventoryService) GetItemList(req schemas.GetItemListRequest) ([]schemas.ItemResponse, error) {
+func (s *inventoryService) GetItemList(params query.Params) (*query.Page[schemas.ItemResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -332,29 +386,32 @@ func (s *inventoryService) GetItemList(req schemas.GetItemListRequest) ([]schema
 
 	// 从数据库读取物料数据
 	var items []models.InventoryItem
-	result := s.db.Find(&items)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, itemListSpec, &items)
+	if err != nil {
+		return nil, err
 	}
 
 	// 将模型转换为响应格式
 	response := make([]schemas.ItemResponse, len(items))
 	for i, item := range items {
 		response[i] = schemas.ItemResponse{
-			ID:          item.ID,
-			ItemNo:      item.ItemNo,
-			Name:        item.Name,
-			Description: item.Description,
-			CategoryID:  item.CategoryID,
-			Unit:        item.Unit,
-			Type:        item.Type,
-			Status:      item.Status,
-			CreatedAt:   item.CreatedAt,
-			UpdatedAt:   item.UpdatedAt,
+			ID:           item.ID,
+			ItemNo:       item.ItemNo,
+			Name:         item.Name,
+			Description:  item.Description,
+			CategoryID:   item.CategoryID,
+			Unit:         item.Unit,
+			Type:         item.Type,
+			CostMethod:   item.CostMethod,
+			StandardCost: item.StandardCost,
+			TaxCategory:  item.TaxCategory,
+			Status:       item.Status,
+			CreatedAt:    item.CreatedAt,
+			UpdatedAt:    item.UpdatedAt,
 		}
 	}
 
-	return response, nil
+	return query.NewPage(response, total, params), nil
 }
 
 func (s *inventoryService) GetItemDetail(id string) (*schemas.ItemResponse, error) {
@@ -372,18 +429,21 @@ func (s *inventoryService) GetItemDetail(id string) (*schemas.ItemResponse, erro
 
 	// 将模型转换为响应格式
 	response := &schemas.ItemResponse{
-		ID:          item.ID,
-		ItemNo:      item.ItemNo,
-		Name:        item.Name,
-		Description: item.Description,
-		CategoryID:  item.CategoryID,
-		Unit:        item.Unit,
-		Type:        item.Type,
-		Status:      item.Status,
-		CreatedBy:   item.CreatedBy,
-		CreatedAt:   item.CreatedAt,
-		UpdatedBy:   item.UpdatedBy,
-		UpdatedAt:   item.UpdatedAt,
+		ID:           item.ID,
+		ItemNo:       item.ItemNo,
+		Name:         item.Name,
+		Description:  item.Description,
+		CategoryID:   item.CategoryID,
+		Unit:         item.Unit,
+		Type:         item.Type,
+		CostMethod:   item.CostMethod,
+		StandardCost: item.StandardCost,
+		TaxCategory:  item.TaxCategory,
+		Status:       item.Status,
+		CreatedBy:    item.CreatedBy,
+		CreatedAt:    item.CreatedAt,
+		UpdatedBy:    item.UpdatedBy,
+		UpdatedAt:    item.UpdatedAt,
 	}
 
 	return response, nil
@@ -395,17 +455,33 @@ func (s *inventoryService) CreateItem(req schemas.CreateItemRequest) (*schemas.I
 		return nil, errors.New("database connection is nil")
 	}
 
+	// 未指定计价方法时默认移动加权平均
+	costMethod := req.CostMethod
+	if costMethod == "" {
+		costMethod = costMethodMovingAverage
+	}
+
+	// 未指定税收分类时默认标准税率
+	taxCategory := req.TaxCategory
+	if taxCategory == "" {
+		taxCategory = "standard"
+	}
+
 	// 创建物料模型
 	item := models.InventoryItem{
-		ItemNo:      req.ItemNo,
-		Name:        req.Name,
-		Description: req.Description,
-		CategoryID:  req.CategoryID,
-		Unit:        req.Unit,
-		Type:        req.Type,
-		Status:      req.Status,
-		CreatedBy:   req.CreatedBy,
-		UpdatedBy:   req.CreatedBy,
+		ID:           utils.GenerateID(),
+		ItemNo:       req.ItemNo,
+		Name:         req.Name,
+		Description:  req.Description,
+		CategoryID:   req.CategoryID,
+		Unit:         req.Unit,
+		Type:         req.Type,
+		CostMethod:   costMethod,
+		StandardCost: req.StandardCost,
+		TaxCategory:  taxCategory,
+		Status:       req.Status,
+		CreatedBy:    req.CreatedBy,
+		UpdatedBy:    req.CreatedBy,
 	}
 
 	// 保存到数据库
@@ -416,18 +492,21 @@ func (s *inventoryService) CreateItem(req schemas.CreateItemRequest) (*schemas.I
 
 	// 将模型转换为响应格式
 	response := &schemas.ItemResponse{
-		ID:          item.ID,
-		ItemNo:      item.ItemNo,
-		Name:        item.Name,
-		Description: item.Description,
-		CategoryID:  item.CategoryID,
-		Unit:        item.Unit,
-		Type:        item.Type,
-		Status:      item.Status,
-		CreatedBy:   item.CreatedBy,
-		CreatedAt:   item.CreatedAt,
-		UpdatedBy:   item.UpdatedBy,
-		UpdatedAt:   item.UpdatedAt,
+		ID:           item.ID,
+		ItemNo:       item.ItemNo,
+		Name:         item.Name,
+		Description:  item.Description,
+		CategoryID:   item.CategoryID,
+		Unit:         item.Unit,
+		Type:         item.Type,
+		CostMethod:   item.CostMethod,
+		StandardCost: item.StandardCost,
+		TaxCategory:  item.TaxCategory,
+		Status:       item.Status,
+		CreatedBy:    item.CreatedBy,
+		CreatedAt:    item.CreatedAt,
+		UpdatedBy:    item.UpdatedBy,
+		UpdatedAt:    item.UpdatedAt,
 	}
 
 	return response, nil
@@ -465,6 +544,21 @@ func (s *inventoryService) UpdateItem(id string, req schemas.UpdateItemRequest)
 	if req.Type != "" {
 		item.Type = req.Type
 	}
+	if req.CostMethod != "" && req.CostMethod != item.CostMethod {
+		// 有结存时切换计价方法会造成成本断层
+		var count int64
+		result = s.db.Model(&models.InventoryOnHand{}).Where("item_id = ? AND quantity <> 0", id).Count(&count)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if count > 0 {
+			return nil, errors.New("cannot change cost method while item has stock on hand")
+		}
+		item.CostMethod = req.CostMethod
+	}
+	if req.TaxCategory != "" {
+		item.TaxCategory = req.TaxCategory
+	}
 	if req.Status != "" {
 		item.Status = req.Status
 	}
@@ -478,18 +572,21 @@ func (s *inventoryService) UpdateItem(id string, req schemas.UpdateItemRequest)
 
 	// 将模型转换为响应格式
 	response := &schemas.ItemResponse{
-		ID:          item.ID,
-		ItemNo:      item.ItemNo,
-		Name:        item.Name,
-		Description: item.Description,
-		CategoryID:  item.CategoryID,
-		Unit:        item.Unit,
-		Type:        item.Type,
-		Status:      item.Status,
-		CreatedBy:   item.CreatedBy,
-		CreatedAt:   item.CreatedAt,
-		UpdatedBy:   item.UpdatedBy,
-		UpdatedAt:   item.UpdatedAt,
+		ID:           item.ID,
+		ItemNo:       item.ItemNo,
+		Name:         item.Name,
+		Description:  item.Description,
+		CategoryID:   item.CategoryID,
+		Unit:         item.Unit,
+		Type:         item.Type,
+		CostMethod:   item.CostMethod,
+		StandardCost: item.StandardCost,
+		TaxCategory:  item.TaxCategory,
+		Status:       item.Status,
+		CreatedBy:    item.CreatedBy,
+		CreatedAt:    item.CreatedAt,
+		UpdatedBy:    item.UpdatedBy,
+		UpdatedAt:    item.UpdatedAt,
 	}
 
 	return response, nil
@@ -577,8 +674,201 @@ func (s *inventoryService) GetItemStock(id string) (*schemas.ItemStockResponse,
 	return response, nil
 }
 
+func (s *inventoryService) GetItemCostLayers(id string, req schemas.GetCostLayerListRequest) (*schemas.ItemCostLayersResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取物料
+	var item models.InventoryItem
+	result := s.db.First(&item, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := &schemas.ItemCostLayersResponse{
+		ItemId:       item.ID,
+		ItemNo:       item.ItemNo,
+		CostMethod:   item.CostMethod,
+		StandardCost: item.StandardCost,
+		Layers:       make([]schemas.CostLayerResponse, 0),
+	}
+
+	// 先进先出物料返回成本层明细
+	if item.CostMethod == costMethodFIFO {
+		query := s.db.Where("item_id = ?", id)
+		if req.WarehouseId != "" {
+			query = query.Where("warehouse_id = ?", req.WarehouseId)
+		}
+		if req.LocationId != "" {
+			query = query.Where("location_id = ?", req.LocationId)
+		}
+		if !req.IncludeConsumed {
+			query = query.Where("remaining_quantity > 0")
+		}
+
+		var layers []models.InventoryCostLayer
+		result = query.Order("layer_date ASC, created_at ASC").Find(&layers)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+
+		for _, layer := range layers {
+			remainingCost := roundAmount(layer.RemainingQuantity * layer.UnitCost)
+			response.TotalQuantity += layer.RemainingQuantity
+			response.TotalCost += remainingCost
+			response.Layers = append(response.Layers, schemas.CostLayerResponse{
+				ID:                layer.ID,
+				WarehouseId:       layer.WarehouseID,
+				LocationId:        layer.LocationID,
+				TransactionId:     layer.TransactionID,
+				LayerDate:         layer.LayerDate.Format("2006-01-02"),
+				OriginalQuantity:  layer.OriginalQuantity,
+				RemainingQuantity: layer.RemainingQuantity,
+				UnitCost:          layer.UnitCost,
+				RemainingCost:     remainingCost,
+			})
+		}
+		response.TotalCost = roundAmount(response.TotalCost)
+
+		return response, nil
+	}
+
+	// 其他计价方法按现存量返回单一成本层
+	query := s.db.Where("item_id = ?", id)
+	if req.WarehouseId != "" {
+		query = query.Where("warehouse_id = ?", req.WarehouseId)
+	}
+	if req.LocationId != "" {
+		query = query.Where("location_id = ?", req.LocationId)
+	}
+	var onHandItems []models.InventoryOnHand
+	result = query.Find(&onHandItems)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, onHand := range onHandItems {
+		if onHand.Quantity == 0 && !req.IncludeConsumed {
+			continue
+		}
+		response.TotalQuantity += onHand.Quantity
+		response.TotalCost += onHand.TotalCost
+		response.Layers = append(response.Layers, schemas.CostLayerResponse{
+			WarehouseId:       onHand.WarehouseID,
+			LocationId:        onHand.LocationID,
+			OriginalQuantity:  onHand.Quantity,
+			RemainingQuantity: onHand.Quantity,
+			UnitCost:          onHand.UnitCost,
+			RemainingCost:     onHand.TotalCost,
+		})
+	}
+	response.TotalCost = roundAmount(response.TotalCost)
+
+	return response, nil
+}
+
+func (s *inventoryService) RevalueItem(id string, req schemas.RevalueItemRequest) (*schemas.TransactionResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 解析交易日期
+	transactionDate, err := time.Parse("2006-01-02", req.TransactionDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 生成交易编号
+	transactionNo := fmt.Sprintf("REV%s", time.Now().Format("20060102030405"))
+
+	// 在同一事务中重估现存量，标准成本物料同时更新标准成本
+	var transactions []models.InventoryTransaction
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var item models.InventoryItem
+		if err := tx.First(&item, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if item.CostMethod == costMethodStandard && req.WarehouseId != "" {
+			return errors.New("standard cost revaluation must cover all warehouses")
+		}
+
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("item_id = ? AND quantity <> 0", id)
+		if req.WarehouseId != "" {
+			query = query.Where("warehouse_id = ?", req.WarehouseId)
+		}
+		var onHandItems []models.InventoryOnHand
+		if err := query.Find(&onHandItems).Error; err != nil {
+			return err
+		}
+
+		poster := newInventoryPoster(tx)
+		for i, onHand := range onHandItems {
+			transaction, err := poster.revalue(fmt.Sprintf("%s%03d", transactionNo, i+1), item, onHand, req.NewUnitCost, transactionDate, req.Remarks, "")
+			if err != nil {
+				return err
+			}
+			transactions = append(transactions, *transaction)
+		}
+
+		if item.CostMethod == costMethodStandard {
+			item.StandardCost = req.NewUnitCost
+			item.UpdatedAt = time.Now()
+			return tx.Save(&item).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 构建交易明细响应
+	transactionItems := make([]schemas.TransactionItemResponse, len(transactions))
+	for i, transaction := range transactions {
+		transactionItems[i] = schemas.TransactionItemResponse{
+			ID:         transaction.ID,
+			ItemId:     transaction.ItemID,
+			Quantity:   transaction.BalanceQuantity,
+			UnitCost:   transaction.UnitCost,
+			TotalCost:  transaction.TotalCost,
+			LocationId: transaction.LocationID,
+		}
+	}
+
+	// 将模型转换为响应格式
+	response := &schemas.TransactionResponse{
+		ID:              transactionNo, // 使用交易编号作为响应ID
+		TransactionNo:   transactionNo,
+		Type:            "revaluation",
+		WarehouseId:     req.WarehouseId,
+		TransactionDate: req.TransactionDate,
+		Remarks:         req.Remarks,
+		Items:           transactionItems,
+		CreatedBy:       "", // 可以根据实际情况设置
+		CreatedAt:       time.Now(),
+	}
+
+	return response, nil
+}
+
+// transactionListSpec 库存交易列表查询白名单
+var transactionListSpec = query.NewSpec("-transactionDate",
+	query.Text("transaction_no").As("transactionNo"),
+	query.Text("type"),
+	query.Text("item_id").As("itemId"),
+	query.Text("warehouse_id").As("warehouseId"),
+	query.Text("location_id").As("locationId"),
+	query.Text("reference_type").As("referenceType"),
+	query.Text("reference_id").As("referenceId"),
+	query.Number("quantity"),
+	query.Number("total_cost").As("totalCost"),
+	query.Date("transaction_date").As("transactionDate"),
+)
+
 // 库存交易管理方法
-func (s *inventoryService) GetTransactionList(req schemas.GetTransactionListRequest) ([]schemas.TransactionResponse, error) {
+func (s *inventoryService) GetTransactionList(params query.Params) (*query.Page[schemas.TransactionResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -586,9 +876,9 @@ func (s *inventoryService) GetTransactionList(req schemas.GetTransactionListRequ
 
 	// 从数据库读取交易数据
 	var transactions []models.InventoryTransaction
-	result := s.db.Find(&transactions)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, transactionListSpec, &transactions)
+	if err != nil {
+		return nil, err
 	}
 
 	// 将模型转换为响应格式
@@ -618,7 +908,7 @@ func (s *inventoryService) GetTransactionList(req schemas.GetTransactionListRequ
 		}
 	}
 
-	return response, nil
+	return query.NewPage(response, total, params), nil
 }
 
 func (s *inventoryService) GetTransactionDetail(id string) (*schemas.TransactionResponse, error) {
@@ -667,6 +957,20 @@ func (s *inventoryService) CreateTransaction(req schemas.CreateTransactionReques
 		return nil, errors.New("database connection is nil")
 	}
 
+	// 调拨交易按仓库间转移处理
+	if req.Type == "transfer" {
+		return s.CreateWarehouseTransfer(schemas.CreateWarehouseTransferRequest{
+			FromWarehouseId: req.FromWarehouseId,
+			ToWarehouseId:   req.ToWarehouseId,
+			TransactionDate: req.TransactionDate,
+			Remarks:         req.Remarks,
+			Items:           req.Items,
+		})
+	}
+	if req.WarehouseId == "" {
+		return nil, errors.New("warehouseId is required")
+	}
+
 	// 解析交易日期
 	transactionDate, err := time.Parse("2006-01-02", req.TransactionDate)
 	if err != nil {
@@ -676,45 +980,56 @@ func (s *inventoryService) CreateTransaction(req schemas.CreateTransactionReques
 	// 生成交易编号
 	transactionNo := fmt.Sprintf("TRX%s", time.Now().Format("20060102030405"))
 
-	// 构建交易明细响应
-	transactionItems := make([]schemas.TransactionItemResponse, len(req.Items))
-	var totalQuantity float64
-
-	// 为每个项目创建交易记录
+	// 构建库存变动，销售为出库，采购和生产为入库，调整按数量正负处理
+	movements := make([]inventoryMovement, len(req.Items))
 	for i, item := range req.Items {
-		// 计算总成本
-		totalCost := item.Quantity * item.UnitCost
-		totalQuantity += item.Quantity
+		quantity := item.Quantity
+		switch req.Type {
+		case "sales":
+			quantity = -math.Abs(quantity)
+		case "purchase", "production":
+			quantity = math.Abs(quantity)
+		}
 
-		// 创建交易模型
-		transaction := models.InventoryTransaction{
-			TransactionNo:   transactionNo,
+		movements[i] = inventoryMovement{
 			ItemID:          item.ItemId,
 			WarehouseID:     req.WarehouseId,
 			LocationID:      item.LocationId,
 			Type:            req.Type,
-			Quantity:        item.Quantity,
+			Quantity:        quantity,
 			UnitCost:        item.UnitCost,
-			TotalCost:       totalCost,
 			ReferenceType:   "", // 可以根据实际情况设置
-			ReferenceID:     "", // 可以根据实际情况设置
+			ReferenceID:     req.ReferenceNo,
 			TransactionDate: transactionDate,
 			Remarks:         req.Remarks,
 			CreatedBy:       "", // 可以根据实际情况设置
 		}
+	}
 
-		// 保存到数据库
-		result := s.db.Create(&transaction)
-		if result.Error != nil {
-			return nil, result.Error
-		}
+	// 过账库存变动
+	var transactions []models.InventoryTransaction
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		transactions, err = newInventoryPoster(tx).Post(transactionNo, movements)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		// 构建交易明细响应
+	// 构建交易明细响应
+	transactionItems := make([]schemas.TransactionItemResponse, len(transactions))
+	var totalQuantity float64
+	for i, transaction := range transactions {
+		totalQuantity += transaction.Quantity
 		transactionItems[i] = schemas.TransactionItemResponse{
-			ItemId:    item.ItemId,
-			Quantity:  item.Quantity,
-			UnitCost:  item.UnitCost,
-			TotalCost: totalCost,
+			ID:           transaction.ID,
+			ItemId:       transaction.ItemID,
+			Quantity:     transaction.Quantity,
+			UnitCost:     transaction.UnitCost,
+			TotalCost:    transaction.TotalCost,
+			CostVariance: transaction.CostVariance,
+			LocationId:   transaction.LocationID,
 		}
 	}
 
@@ -723,6 +1038,7 @@ func (s *inventoryService) CreateTransaction(req schemas.CreateTransactionReques
 		ID:              transactionNo, // 使用交易编号作为响应ID
 		TransactionNo:   transactionNo,
 		Type:            req.Type,
+		ReferenceNo:     req.ReferenceNo,
 		WarehouseId:     req.WarehouseId,
 		TransactionDate: req.TransactionDate,
 		Remarks:         req.Remarks,
@@ -750,45 +1066,50 @@ func (s *inventoryService) CreateInventoryAdjustment(req schemas.CreateInventory
 	// 生成交易编号
 	transactionNo := fmt.Sprintf("ADJ%s", time.Now().Format("20060102030405"))
 
-	// 构建交易明细响应
-	transactionItems := make([]schemas.TransactionItemResponse, len(req.Items))
-	var totalQuantity float64
-
-	// 为每个项目创建调整交易记录
+	// 构建调整变动，数量为正表示盘盈，为负表示盘亏
+	movements := make([]inventoryMovement, len(req.Items))
 	for i, item := range req.Items {
-		// 计算总成本
-		totalCost := item.Quantity * item.UnitCost
-		totalQuantity += item.Quantity
-
-		// 创建调整交易模型
-		transaction := models.InventoryTransaction{
-			TransactionNo:   transactionNo,
+		movements[i] = inventoryMovement{
 			ItemID:          item.ItemId,
 			WarehouseID:     req.WarehouseId,
 			LocationID:      item.LocationId,
 			Type:            "adjustment",
 			Quantity:        item.Quantity,
 			UnitCost:        item.UnitCost,
-			TotalCost:       totalCost,
 			ReferenceType:   "inventory_adjustment",
 			ReferenceID:     "", // 可以根据实际情况设置
 			TransactionDate: transactionDate,
-			Remarks:         req.Remarks,
+			Remarks:         req.Reason,
 			CreatedBy:       "", // 可以根据实际情况设置
 		}
-
-		// 保存到数据库
-		result := s.db.Create(&transaction)
-		if result.Error != nil {
-			return nil, result.Error
+		if req.Remarks != "" {
+			movements[i].Remarks = req.Reason + ": " + req.Remarks
 		}
+	}
 
-		// 构建交易明细响应
+	// 过账库存变动
+	var transactions []models.InventoryTransaction
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		transactions, err = newInventoryPoster(tx).Post(transactionNo, movements)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 构建交易明细响应
+	transactionItems := make([]schemas.TransactionItemResponse, len(transactions))
+	var totalQuantity float64
+	for i, transaction := range transactions {
+		totalQuantity += transaction.Quantity
 		transactionItems[i] = schemas.TransactionItemResponse{
-			ItemId:    item.ItemId,
-			Quantity:  item.Quantity,
-			UnitCost:  item.UnitCost,
-			TotalCost: totalCost,
+			ID:         transaction.ID,
+			ItemId:     transaction.ItemID,
+			Quantity:   transaction.Quantity,
+			UnitCost:   transaction.UnitCost,
+			TotalCost:  transaction.TotalCost,
+			LocationId: transaction.LocationID,
 		}
 	}
 
@@ -814,6 +1135,9 @@ func (s *inventoryService) CreateWarehouseTransfer(req schemas.CreateWarehouseTr
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
+	if req.FromWarehouseId == "" || req.ToWarehouseId == "" {
+		return nil, errors.New("fromWarehouseId and toWarehouseId are required")
+	}
 
 	// 解析交易日期
 	transactionDate, err := time.Parse("2006-01-02", req.TransactionDate)
@@ -828,65 +1152,63 @@ func (s *inventoryService) CreateWarehouseTransfer(req schemas.CreateWarehouseTr
 	transactionItems := make([]schemas.TransactionItemResponse, len(req.Items))
 	var totalQuantity float64
 
-	// 为每个项目创建转出和转入交易记录
-	for i, item := range req.Items {
-		// 计算总成本
-		totalCost := item.Quantity * item.UnitCost
-		totalQuantity += item.Quantity
-
-		// 创建转出交易
-		outTransaction := models.InventoryTransaction{
-			TransactionNo:   transactionNo,
-			ItemID:          item.ItemId,
-			WarehouseID:     req.FromWarehouseId,
-			LocationID:      item.FromLocationId,
-			Type:            "transfer_out",
-			Quantity:        -item.Quantity,
-			UnitCost:        item.UnitCost,
-			TotalCost:       -totalCost,
-			ReferenceType:   "warehouse_transfer",
-			ReferenceID:     "", // 可以根据实际情况设置
-			TransactionDate: transactionDate,
-			Remarks:         req.Remarks,
-			CreatedBy:       "", // 可以根据实际情况设置
-		}
-
-		// 保存转出交易
-		result := s.db.Create(&outTransaction)
-		if result.Error != nil {
-			return nil, result.Error
-		}
-
-		// 创建转入交易
-		inTransaction := models.InventoryTransaction{
-			TransactionNo:   transactionNo,
-			ItemID:          item.ItemId,
-			WarehouseID:     req.ToWarehouseId,
-			LocationID:      item.ToLocationId,
-			Type:            "transfer_in",
-			Quantity:        item.Quantity,
-			UnitCost:        item.UnitCost,
-			TotalCost:       totalCost,
-			ReferenceType:   "warehouse_transfer",
-			ReferenceID:     "", // 可以根据实际情况设置
-			TransactionDate: transactionDate,
-			Remarks:         req.Remarks,
-			CreatedBy:       "", // 可以根据实际情况设置
-		}
+	// 在同一事务中先转出再转入，转入金额取转出实际冲减的成本金额
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		poster := newInventoryPoster(tx)
+		for i, item := range req.Items {
+			quantity := math.Abs(item.Quantity)
+
+			// 过账转出交易
+			outTransaction, err := poster.postMovement(fmt.Sprintf("%s%03d", transactionNo, 2*i+1), inventoryMovement{
+				ItemID:          item.ItemId,
+				WarehouseID:     req.FromWarehouseId,
+				LocationID:      item.FromLocationId,
+				Type:            "transfer_out",
+				Quantity:        -quantity,
+				UnitCost:        item.UnitCost,
+				ReferenceType:   "warehouse_transfer",
+				ReferenceID:     "", // 可以根据实际情况设置
+				TransactionDate: transactionDate,
+				Remarks:         req.Remarks,
+				CreatedBy:       "", // 可以根据实际情况设置
+			})
+			if err != nil {
+				return err
+			}
 
-		// 保存转入交易
-		result = s.db.Create(&inTransaction)
-		if result.Error != nil {
-			return nil, result.Error
-		}
+			// 过账转入交易
+			inTransaction, err := poster.postMovement(fmt.Sprintf("%s%03d", transactionNo, 2*i+2), inventoryMovement{
+				ItemID:          item.ItemId,
+				WarehouseID:     req.ToWarehouseId,
+				LocationID:      item.ToLocationId,
+				Type:            "transfer_in",
+				Quantity:        quantity,
+				UnitCost:        outTransaction.UnitCost,
+				TotalCost:       -outTransaction.TotalCost,
+				ReferenceType:   "warehouse_transfer",
+				ReferenceID:     "", // 可以根据实际情况设置
+				TransactionDate: transactionDate,
+				Remarks:         req.Remarks,
+				CreatedBy:       "", // 可以根据实际情况设置
+			})
+			if err != nil {
+				return err
+			}
 
-		// 构建交易明细响应
-		transactionItems[i] = schemas.TransactionItemResponse{
-			ItemId:    item.ItemId,
-			Quantity:  item.Quantity,
-			UnitCost:  item.UnitCost,
-			TotalCost: totalCost,
+			totalQuantity += quantity
+			transactionItems[i] = schemas.TransactionItemResponse{
+				ItemId:         item.ItemId,
+				Quantity:       quantity,
+				UnitCost:       inTransaction.UnitCost,
+				TotalCost:      inTransaction.TotalCost,
+				FromLocationId: item.FromLocationId,
+				ToLocationId:   item.ToLocationId,
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// 将模型转换为响应格式
@@ -907,8 +1229,17 @@ func (s *inventoryService) CreateWarehouseTransfer(req schemas.CreateWarehouseTr
 	return response, nil
 }
 
+// countListSpec 盘点单列表查询白名单
+var countListSpec = query.NewSpec("-countDate",
+	query.Text("count_no").As("countNo"),
+	query.Text("warehouse_id").As("warehouseId"),
+	query.Text("status"),
+	query.Number("total_variance").As("totalVariance"),
+	query.Date("count_date").As("countDate"),
+)
+
 // 库存盘点管理方法
-func (s *inventoryService) GetCountList(req schemas.GetCountListRequest) ([]schemas.CountResponse, error) {
+func (s *inventoryService) GetCountList(params query.Params) (*query.Page[schemas.CountResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -916,9 +1247,9 @@ func (s *inventoryService) GetCountList(req schemas.GetCountListRequest) ([]sche
 
 	// 从数据库读取盘点数据
 	var counts []models.InventoryCount
-	result := s.db.Find(&counts)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, countListSpec, &counts)
+	if err != nil {
+		return nil, err
 	}
 
 	// 将模型转换为响应格式
@@ -939,7 +1270,7 @@ func (s *inventoryService) GetCountList(req schemas.GetCountListRequest) ([]sche
 		}
 	}
 
-	return response, nil
+	return query.NewPage(response, total, params), nil
 }
 
 func (s *inventoryService) GetCountDetail(id string) (*schemas.CountResponse, error) {
@@ -1126,8 +1457,87 @@ func (s *inventoryService) GetInventoryBalanceReport(req schemas.GetInventoryBal
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 这里简单返回空响应，实际实现中应该生成库存余额报表
-	return &schemas.InventoryBalanceReportResponse{}, nil
+	// 按物料和仓库汇总，指定截止日期时从库存交易汇总，否则取当前现存量
+	type balanceRow struct {
+		ItemID      string
+		WarehouseID string
+		Quantity    float64
+		TotalCost   float64
+	}
+	var rows []balanceRow
+	var query *gorm.DB
+	asOfDate := req.AsOfDate
+	if asOfDate != "" {
+		date, err := time.Parse("2006-01-02", asOfDate)
+		if err != nil {
+			return nil, err
+		}
+		query = s.db.Model(&models.InventoryTransaction{}).
+			Where("transaction_date < ?", date.AddDate(0, 0, 1))
+	} else {
+		asOfDate = time.Now().Format("2006-01-02")
+		query = s.db.Model(&models.InventoryOnHand{})
+	}
+	if req.WarehouseId != "" {
+		query = query.Where("warehouse_id = ?", req.WarehouseId)
+	}
+	if req.ItemId != "" {
+		query = query.Where("item_id = ?", req.ItemId)
+	}
+	if req.Category != "" {
+		query = query.Where("item_id IN (?)", s.db.Model(&models.InventoryItem{}).Select("id").Where("category_id = ?", req.Category))
+	}
+	result := query.Select("item_id, warehouse_id, SUM(quantity) AS quantity, SUM(total_cost) AS total_cost").
+		Group("item_id, warehouse_id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 读取物料和仓库名称
+	items := make(map[string]models.InventoryItem)
+	warehouses := make(map[string]models.InventoryWarehouse)
+	response := &schemas.InventoryBalanceReportResponse{
+		AsOfDate: asOfDate,
+		Items:    make([]schemas.BalanceReportItem, 0, len(rows)),
+	}
+	for _, row := range rows {
+		if row.Quantity == 0 && row.TotalCost == 0 {
+			continue
+		}
+
+		item, ok := items[row.ItemID]
+		if !ok {
+			s.db.First(&item, "id = ?", row.ItemID)
+			items[row.ItemID] = item
+		}
+		warehouse, ok := warehouses[row.WarehouseID]
+		if !ok {
+			s.db.First(&warehouse, "id = ?", row.WarehouseID)
+			warehouses[row.WarehouseID] = warehouse
+		}
+
+		var unitCost float64
+		if row.Quantity != 0 {
+			unitCost = roundUnitCost(row.TotalCost / row.Quantity)
+		}
+
+		response.Items = append(response.Items, schemas.BalanceReportItem{
+			ItemId:        row.ItemID,
+			ItemCode:      item.ItemNo,
+			ItemName:      item.Name,
+			WarehouseId:   row.WarehouseID,
+			WarehouseName: warehouse.Name,
+			Quantity:      row.Quantity,
+			UnitCost:      unitCost,
+			TotalValue:    roundAmount(row.TotalCost),
+		})
+		response.TotalValue += row.TotalCost
+	}
+	response.TotalItems = len(response.Items)
+	response.TotalValue = roundAmount(response.TotalValue)
+
+	return response, nil
 }
 
 func (s *inventoryService) GetInventoryMovementReport(req schemas.GetInventoryMovementReportRequest) (*schemas.InventoryMovementReportResponse, error) {
@@ -4,18 +4,21 @@ import (
 	"errors"
 	"time"
 
+	"github.com/wu136995/ginx/internal/api/schemas"
 	"github.com/wu136995/ginx/internal/database"
 	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/query"
+	"github.com/wu136995/ginx/internal/utils"
 	"gorm.io/gorm"
 )
 
 // ProductionService 生产服务接口
 type ProductionService interface {
 	// 生产订单管理
-	GetProductionOrderList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetProductionOrderDetail(id string) (map[string]interface{}, error)
-	CreateProductionOrder(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateProductionOrder(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetProductionOrderList(params query.Params) (*query.Page[schemas.ProductionOrderResponse], error)
+	GetProductionOrderDetail(id string) (*schemas.ProductionOrderResponse, error)
+	CreateProductionOrder(req schemas.CreateProductionOrderRequest) (*schemas.ProductionOrderResponse, error)
+	UpdateProductionOrder(id string, req schemas.UpdateProductionOrderRequest) (*schemas.ProductionOrderResponse, error)
 	DeleteProductionOrder(id string) error
 	SubmitProductionOrder(id string) error
 	ApproveProductionOrder(id string) error
@@ -25,43 +28,43 @@ type ProductionService interface {
 	CancelProductionOrder(id string) error
 
 	// 生产工单管理
-	GetProductionTicketList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetProductionTicketDetail(id string) (map[string]interface{}, error)
-	CreateProductionTicket(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateProductionTicket(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetProductionTicketList(params query.Params) (*query.Page[schemas.ProductionTicketResponse], error)
+	GetProductionTicketDetail(id string) (*schemas.ProductionTicketResponse, error)
+	CreateProductionTicket(req schemas.CreateProductionTicketRequest) (*schemas.ProductionTicketResponse, error)
+	UpdateProductionTicket(id string, req schemas.UpdateProductionTicketRequest) (*schemas.ProductionTicketResponse, error)
 	DeleteProductionTicket(id string) error
 	StartProductionTicket(id string) error
 	CompleteProductionTicket(id string) error
 	CancelProductionTicket(id string) error
 
 	// 工艺路线管理
-	GetRoutingList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetRoutingDetail(id string) (map[string]interface{}, error)
-	CreateRouting(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateRouting(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetRoutingList(params query.Params) (*query.Page[schemas.RoutingResponse], error)
+	GetRoutingDetail(id string) (*schemas.RoutingResponse, error)
+	CreateRouting(req schemas.CreateRoutingRequest) (*schemas.RoutingResponse, error)
+	UpdateRouting(id string, req schemas.UpdateRoutingRequest) (*schemas.RoutingResponse, error)
 	DeleteRouting(id string) error
 
 	// 工作中心管理
-	GetWorkCenterList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetWorkCenterDetail(id string) (map[string]interface{}, error)
-	CreateWorkCenter(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateWorkCenter(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetWorkCenterList(params query.Params) (*query.Page[schemas.WorkCenterResponse], error)
+	GetWorkCenterDetail(id string) (*schemas.WorkCenterResponse, error)
+	CreateWorkCenter(req schemas.CreateWorkCenterRequest) (*schemas.WorkCenterResponse, error)
+	UpdateWorkCenter(id string, req schemas.UpdateWorkCenterRequest) (*schemas.WorkCenterResponse, error)
 	DeleteWorkCenter(id string) error
-	GetWorkCenterCapacity(id string) (map[string]interface{}, error)
-	GetWorkCenterSchedule(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetWorkCenterCapacity(id string) (*schemas.WorkCenterCapacityResponse, error)
+	GetWorkCenterSchedule(id string, req schemas.WorkCenterScheduleRequest) (*schemas.WorkCenterScheduleResponse, error)
 
 	// 物料需求计划管理
-	RunMRP(req map[string]interface{}) (map[string]interface{}, error)
-	GetMRPResults(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetMRPSuggestions(req map[string]interface{}) ([]map[string]interface{}, error)
+	RunMRP(req schemas.MRPRunRequest) (*schemas.MRPRunResponse, error)
+	GetMRPResults(req schemas.MRPResultRequest) ([]schemas.MRPResultResponse, error)
+	GetMRPSuggestions(runID string) ([]schemas.MRPSuggestionResponse, error)
 
 	// 生产报表管理
-	GetProductionPlanReport(req map[string]interface{}) (map[string]interface{}, error)
-	GetProductionExecutionReport(req map[string]interface{}) (map[string]interface{}, error)
-	GetWorkCenterLoadReport(req map[string]interface{}) (map[string]interface{}, error)
-	GetMaterialConsumptionReport(req map[string]interface{}) (map[string]interface{}, error)
-	GetProductionCostReport(req map[string]interface{}) (map[string]interface{}, error)
-	ExportProductionReport(req map[string]interface{}) ([]byte, error)
+	GetProductionPlanReport(req schemas.ProductionReportRequest) (*schemas.ProductionPlanReportResponse, error)
+	GetProductionExecutionReport(req schemas.ProductionReportRequest) (*schemas.ProductionExecutionReportResponse, error)
+	GetWorkCenterLoadReport(req schemas.ProductionReportRequest) (*schemas.WorkCenterLoadReportResponse, error)
+	GetMaterialConsumptionReport(req schemas.ProductionReportRequest) (*schemas.MaterialConsumptionReportResponse, error)
+	GetProductionCostReport(req schemas.ProductionReportRequest) (*schemas.ProductionCostReportResponse, error)
+	ExportProductionReport(req schemas.ExportProductionReportRequest) ([]byte, error)
 }
 
 // productionService 生产服务实现
@@ -76,8 +79,20 @@ func NewProductionService() ProductionService {
 	}
 }
 
+// productionOrderListSpec 生产订单列表查询白名单
+var productionOrderListSpec = query.NewSpec("-created_at",
+	query.Text("order_no"),
+	query.Text("product_name"),
+	query.Text("status"),
+	query.Text("priority"),
+	query.Number("quantity"),
+	query.Date("start_date"),
+	query.Date("end_date"),
+	query.Date("created_at"),
+)
+
 // 生产订单管理方法
-func (s *productionService) GetProductionOrderList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *productionService) GetProductionOrderList(params query.Params) (*query.Page[schemas.ProductionOrderResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -86,34 +101,21 @@ func (s *productionService) GetProductionOrderList(req map[string]interface{}) (
 
 	// 从数据库读取生产订单数据
 	var productionOrders []models.ProductionOrder
-	result := s.db.Find(&productionOrders)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, productionOrderListSpec, &productionOrders)
+	if err != nil {
+		return nil, err
 	}
 
-	// 将模型转换为map
-	orderList := make([]map[string]interface{}, len(productionOrders))
+	// 将模型转换为响应格式
+	orderList := make([]schemas.ProductionOrderResponse, len(productionOrders))
 	for i, order := range productionOrders {
-		orderList[i] = map[string]interface{}{
-			"id":           order.ID,
-			"order_no":     order.OrderNo,
-			"product_name": order.ProductName,
-			"quantity":     order.Quantity,
-			"status":       order.Status,
-			"priority":     order.Priority,
-			"start_date":   order.StartDate,
-			"end_date":     order.EndDate,
-			"created_at":   order.CreatedAt,
-			"created_by":   order.CreatedBy,
-			"updated_at":   order.UpdatedAt,
-			"updated_by":   order.UpdatedBy,
-		}
+		orderList[i] = productionOrderResponse(order)
 	}
 
-	return orderList, nil
+	return query.NewPage(orderList, total, params), nil
 }
 
-func (s *productionService) GetProductionOrderDetail(id string) (map[string]interface{}, error) {
+func (s *productionService) GetProductionOrderDetail(id string) (*schemas.ProductionOrderResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -127,46 +129,41 @@ func (s *productionService) GetProductionOrderDetail(id string) (map[string]inte
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	orderDetail := map[string]interface{}{
-		"id":           productionOrder.ID,
-		"order_no":     productionOrder.OrderNo,
-		"product_name": productionOrder.ProductName,
-		"quantity":     productionOrder.Quantity,
-		"status":       productionOrder.Status,
-		"priority":     productionOrder.Priority,
-		"start_date":   productionOrder.StartDate,
-		"end_date":     productionOrder.EndDate,
-		"created_at":   productionOrder.CreatedAt,
-		"created_by":   productionOrder.CreatedBy,
-		"updated_at":   productionOrder.UpdatedAt,
-		"updated_by":   productionOrder.UpdatedBy,
-	}
-
-	return orderDetail, nil
+	response := productionOrderResponse(productionOrder)
+	return &response, nil
 }
 
-func (s *productionService) CreateProductionOrder(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *productionService) CreateProductionOrder(req schemas.CreateProductionOrderRequest) (*schemas.ProductionOrderResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
 		return nil, errors.New("database connection is nil")
 	}
 
+	// 解析计划日期，日期格式已由请求校验保证
+	startDate, _ := time.Parse("2006-01-02", req.StartDate)
+	endDate, _ := time.Parse("2006-01-02", req.EndDate)
+	if endDate.Before(startDate) {
+		return nil, errors.New("end date is before start date")
+	}
+
 	// 从请求中获取数据
+	now := time.Now()
 	productionOrder := models.ProductionOrder{
-		ID:          req["id"].(string),
-		OrderNo:     req["order_no"].(string),
-		ProductName: req["product_name"].(string),
-		Quantity:    req["quantity"].(int),
-		Status:      req["status"].(string),
-		Priority:    req["priority"].(string),
-		StartDate:   time.Now(),
-		EndDate:     time.Now().Add(7 * 24 * time.Hour),
-		CreatedAt:   time.Now(),
-		CreatedBy:   req["created_by"].(string),
-		UpdatedAt:   time.Now(),
-		UpdatedBy:   req["created_by"].(string),
+		ID:          utils.GenerateID(),
+		OrderNo:     req.OrderNo,
+		ProductName: req.ProductName,
+		ItemID:      req.ItemID,
+		WarehouseID: req.WarehouseID,
+		Quantity:    req.Quantity,
+		Status:      "pending",
+		Priority:    req.Priority,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		CreatedAt:   now,
+		CreatedBy:   req.CreatedBy,
+		UpdatedAt:   now,
+		UpdatedBy:   req.CreatedBy,
 	}
 
 	// 保存到数据库
@@ -175,26 +172,11 @@ func (s *productionService) CreateProductionOrder(req map[string]interface{}) (m
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	createdOrder := map[string]interface{}{
-		"id":           productionOrder.ID,
-		"order_no":     productionOrder.OrderNo,
-		"product_name": productionOrder.ProductName,
-		"quantity":     productionOrder.Quantity,
-		"status":       productionOrder.Status,
-		"priority":     productionOrder.Priority,
-		"start_date":   productionOrder.StartDate,
-		"end_date":     productionOrder.EndDate,
-		"created_at":   productionOrder.CreatedAt,
-		"created_by":   productionOrder.CreatedBy,
-		"updated_at":   productionOrder.UpdatedAt,
-		"updated_by":   productionOrder.UpdatedBy,
-	}
-
-	return createdOrder, nil
+	response := productionOrderResponse(productionOrder)
+	return &response, nil
 }
 
-func (s *productionService) UpdateProductionOrder(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *productionService) UpdateProductionOrder(id string, req schemas.UpdateProductionOrderRequest) (*schemas.ProductionOrderResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -209,14 +191,32 @@ func (s *productionService) UpdateProductionOrder(id string, req map[string]inte
 	}
 
 	// 更新字段
-	productionOrder.ProductName = req["product_name"].(string)
-	productionOrder.Quantity = req["quantity"].(int)
-	productionOrder.Status = req["status"].(string)
-	productionOrder.Priority = req["priority"].(string)
-	productionOrder.StartDate = time.Now()
-	productionOrder.EndDate = time.Now().Add(7 * 24 * time.Hour)
+	if req.ProductName != "" {
+		productionOrder.ProductName = req.ProductName
+	}
+	if req.ItemID != "" {
+		productionOrder.ItemID = req.ItemID
+	}
+	if req.WarehouseID != "" {
+		productionOrder.WarehouseID = req.WarehouseID
+	}
+	if req.Quantity != nil {
+		productionOrder.Quantity = *req.Quantity
+	}
+	if req.Priority != "" {
+		productionOrder.Priority = req.Priority
+	}
+	if req.StartDate != "" {
+		productionOrder.StartDate, _ = time.Parse("2006-01-02", req.StartDate)
+	}
+	if req.EndDate != "" {
+		productionOrder.EndDate, _ = time.Parse("2006-01-02", req.EndDate)
+	}
+	if productionOrder.EndDate.Before(productionOrder.StartDate) {
+		return nil, errors.New("end date is before start date")
+	}
 	productionOrder.UpdatedAt = time.Now()
-	productionOrder.UpdatedBy = req["updated_by"].(string)
+	productionOrder.UpdatedBy = req.UpdatedBy
 
 	// 保存到数据库
 	result = s.db.Save(&productionOrder)
@@ -224,23 +224,8 @@ func (s *productionService) UpdateProductionOrder(id string, req map[string]inte
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	updatedOrder := map[string]interface{}{
-		"id":           productionOrder.ID,
-		"order_no":     productionOrder.OrderNo,
-		"product_name": productionOrder.ProductName,
-		"quantity":     productionOrder.Quantity,
-		"status":       productionOrder.Status,
-		"priority":     productionOrder.Priority,
-		"start_date":   productionOrder.StartDate,
-		"end_date":     productionOrder.EndDate,
-		"created_at":   productionOrder.CreatedAt,
-		"created_by":   productionOrder.CreatedBy,
-		"updated_at":   productionOrder.UpdatedAt,
-		"updated_by":   productionOrder.UpdatedBy,
-	}
-
-	return updatedOrder, nil
+	response := productionOrderResponse(productionOrder)
+	return &response, nil
 }
 
 func (s *productionService) DeleteProductionOrder(id string) error {
@@ -427,8 +412,20 @@ func (s *productionService) CancelProductionOrder(id string) error {
 	return nil
 }
 
+// productionTicketListSpec 生产工单列表查询白名单
+var productionTicketListSpec = query.NewSpec("-created_at",
+	query.Text("ticket_no"),
+	query.Text("production_order_id"),
+	query.Text("product_name"),
+	query.Text("work_center_id"),
+	query.Text("status"),
+	query.Number("quantity"),
+	query.Date("start_time"),
+	query.Date("created_at"),
+)
+
 // 生产工单管理方法
-func (s *productionService) GetProductionTicketList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *productionService) GetProductionTicketList(params query.Params) (*query.Page[schemas.ProductionTicketResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -437,35 +434,21 @@ func (s *productionService) GetProductionTicketList(req map[string]interface{})
 
 	// 从数据库读取生产工单数据
 	var productionTickets []models.ProductionTicket
-	result := s.db.Find(&productionTickets)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, productionTicketListSpec, &productionTickets)
+	if err != nil {
+		return nil, err
 	}
 
-	// 将模型转换为map
-	ticketList := make([]map[string]interface{}, len(productionTickets))
+	// 将模型转换为响应格式
+	ticketList := make([]schemas.ProductionTicketResponse, len(productionTickets))
 	for i, ticket := range productionTickets {
-		ticketList[i] = map[string]interface{}{
-			"id":                  ticket.ID,
-			"ticket_no":           ticket.TicketNo,
-			"production_order_id": ticket.ProductionOrderID,
-			"product_name":        ticket.ProductName,
-			"quantity":            ticket.Quantity,
-			"status":              ticket.Status,
-			"work_center_id":      ticket.WorkCenterID,
-			"start_time":          ticket.StartTime,
-			"end_time":            ticket.EndTime,
-			"created_at":          ticket.CreatedAt,
-			"created_by":          ticket.CreatedBy,
-			"updated_at":          ticket.UpdatedAt,
-			"updated_by":          ticket.UpdatedBy,
-		}
+		ticketList[i] = productionTicketResponse(ticket)
 	}
 
-	return ticketList, nil
+	return query.NewPage(ticketList, total, params), nil
 }
 
-func (s *productionService) GetProductionTicketDetail(id string) (map[string]interface{}, error) {
+func (s *productionService) GetProductionTicketDetail(id string) (*schemas.ProductionTicketResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -479,48 +462,39 @@ func (s *productionService) GetProductionTicketDetail(id string) (map[string]int
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	ticketDetail := map[string]interface{}{
-		"id":                  productionTicket.ID,
-		"ticket_no":           productionTicket.TicketNo,
-		"production_order_id": productionTicket.ProductionOrderID,
-		"product_name":        productionTicket.ProductName,
-		"quantity":            productionTicket.Quantity,
-		"status":              productionTicket.Status,
-		"work_center_id":      productionTicket.WorkCenterID,
-		"start_time":          productionTicket.StartTime,
-		"end_time":            productionTicket.EndTime,
-		"created_at":          productionTicket.CreatedAt,
-		"created_by":          productionTicket.CreatedBy,
-		"updated_at":          productionTicket.UpdatedAt,
-		"updated_by":          productionTicket.UpdatedBy,
-	}
-
-	return ticketDetail, nil
+	response := productionTicketResponse(productionTicket)
+	return &response, nil
 }
 
-func (s *productionService) CreateProductionTicket(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *productionService) CreateProductionTicket(req schemas.CreateProductionTicketRequest) (*schemas.ProductionTicketResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
 		return nil, errors.New("database connection is nil")
 	}
 
+	// 工单必须挂在已存在的生产订单下
+	var productionOrder models.ProductionOrder
+	if err := s.db.First(&productionOrder, "id = ?", req.ProductionOrderID).Error; err != nil {
+		return nil, err
+	}
+
 	// 从请求中获取数据
+	now := time.Now()
 	productionTicket := models.ProductionTicket{
-		ID:                req["id"].(string),
-		TicketNo:          req["ticket_no"].(string),
-		ProductionOrderID: req["production_order_id"].(string),
-		ProductName:       req["product_name"].(string),
-		Quantity:          req["quantity"].(int),
-		Status:            req["status"].(string),
-		WorkCenterID:      req["work_center_id"].(string),
-		StartTime:         time.Now(),
-		EndTime:           time.Now().Add(24 * time.Hour),
-		CreatedAt:         time.Now(),
-		CreatedBy:         req["created_by"].(string),
-		UpdatedAt:         time.Now(),
-		UpdatedBy:         req["created_by"].(string),
+		ID:                utils.GenerateID(),
+		TicketNo:          req.TicketNo,
+		ProductionOrderID: req.ProductionOrderID,
+		ProductName:       req.ProductName,
+		Quantity:          req.Quantity,
+		Status:            "pending",
+		WorkCenterID:      req.WorkCenterID,
+		StartTime:         req.StartTime,
+		EndTime:           req.EndTime,
+		CreatedAt:         now,
+		CreatedBy:         req.CreatedBy,
+		UpdatedAt:         now,
+		UpdatedBy:         req.CreatedBy,
 	}
 
 	// 保存到数据库
@@ -529,27 +503,11 @@ func (s *productionService) CreateProductionTicket(req map[string]interface{}) (
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	createdTicket := map[string]interface{}{
-		"id":                  productionTicket.ID,
-		"ticket_no":           productionTicket.TicketNo,
-		"production_order_id": productionTicket.ProductionOrderID,
-		"product_name":        productionTicket.ProductName,
-		"quantity":            productionTicket.Quantity,
-		"status":              productionTicket.Status,
-		"work_center_id":      productionTicket.WorkCenterID,
-		"start_time":          productionTicket.StartTime,
-		"end_time":            productionTicket.EndTime,
-		"created_at":          productionTicket.CreatedAt,
-		"created_by":          productionTicket.CreatedBy,
-		"updated_at":          productionTicket.UpdatedAt,
-		"updated_by":          productionTicket.UpdatedBy,
-	}
-
-	return createdTicket, nil
+	response := productionTicketResponse(productionTicket)
+	return &response, nil
 }
 
-func (s *productionService) UpdateProductionTicket(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *productionService) UpdateProductionTicket(id string, req schemas.UpdateProductionTicketRequest) (*schemas.ProductionTicketResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -564,14 +522,26 @@ func (s *productionService) UpdateProductionTicket(id string, req map[string]int
 	}
 
 	// 更新字段
-	productionTicket.ProductName = req["product_name"].(string)
-	productionTicket.Quantity = req["quantity"].(int)
-	productionTicket.Status = req["status"].(string)
-	productionTicket.WorkCenterID = req["work_center_id"].(string)
-	productionTicket.StartTime = time.Now()
-	productionTicket.EndTime = time.Now().Add(24 * time.Hour)
+	if req.ProductName != "" {
+		productionTicket.ProductName = req.ProductName
+	}
+	if req.Quantity != nil {
+		productionTicket.Quantity = *req.Quantity
+	}
+	if req.WorkCenterID != "" {
+		productionTicket.WorkCenterID = req.WorkCenterID
+	}
+	if req.StartTime != nil {
+		productionTicket.StartTime = *req.StartTime
+	}
+	if req.EndTime != nil {
+		productionTicket.EndTime = *req.EndTime
+	}
+	if !productionTicket.EndTime.After(productionTicket.StartTime) {
+		return nil, errors.New("end time must be after start time")
+	}
 	productionTicket.UpdatedAt = time.Now()
-	productionTicket.UpdatedBy = req["updated_by"].(string)
+	productionTicket.UpdatedBy = req.UpdatedBy
 
 	// 保存到数据库
 	result = s.db.Save(&productionTicket)
@@ -579,24 +549,8 @@ func (s *productionService) UpdateProductionTicket(id string, req map[string]int
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	updatedTicket := map[string]interface{}{
-		"id":                  productionTicket.ID,
-		"ticket_no":           productionTicket.TicketNo,
-		"production_order_id": productionTicket.ProductionOrderID,
-		"product_name":        productionTicket.ProductName,
-		"quantity":            productionTicket.Quantity,
-		"status":              productionTicket.Status,
-		"work_center_id":      productionTicket.WorkCenterID,
-		"start_time":          productionTicket.StartTime,
-		"end_time":            productionTicket.EndTime,
-		"created_at":          productionTicket.CreatedAt,
-		"created_by":          productionTicket.CreatedBy,
-		"updated_at":          productionTicket.UpdatedAt,
-		"updated_by":          productionTicket.UpdatedBy,
-	}
-
-	return updatedTicket, nil
+	response := productionTicketResponse(productionTicket)
+	return &response, nil
 }
 
 func (s *productionService) DeleteProductionTicket(id string) error {
@@ -699,8 +653,15 @@ func (s *productionService) CancelProductionTicket(id string) error {
 	return nil
 }
 
+// routingListSpec 工艺路线列表查询白名单
+var routingListSpec = query.NewSpec("-created_at",
+	query.Text("routing_no"),
+	query.Text("product_name"),
+	query.Date("created_at"),
+)
+
 // 工艺路线管理方法
-func (s *productionService) GetRoutingList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *productionService) GetRoutingList(params query.Params) (*query.Page[schemas.RoutingResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -709,30 +670,21 @@ func (s *productionService) GetRoutingList(req map[string]interface{}) ([]map[st
 
 	// 从数据库读取工艺路线数据
 	var routings []models.Routing
-	result := s.db.Find(&routings)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, routingListSpec, &routings)
+	if err != nil {
+		return nil, err
 	}
 
-	// 将模型转换为map
-	routingList := make([]map[string]interface{}, len(routings))
+	// 将模型转换为响应格式
+	routingList := make([]schemas.RoutingResponse, len(routings))
 	for i, routing := range routings {
-		routingList[i] = map[string]interface{}{
-			"id":           routing.ID,
-			"routing_no":   routing.RoutingNo,
-			"product_name": routing.ProductName,
-			"description":  routing.Description,
-			"created_at":   routing.CreatedAt,
-			"created_by":   routing.CreatedBy,
-			"updated_at":   routing.UpdatedAt,
-			"updated_by":   routing.UpdatedBy,
-		}
+		routingList[i] = routingResponse(routing)
 	}
 
-	return routingList, nil
+	return query.NewPage(routingList, total, params), nil
 }
 
-func (s *productionService) GetRoutingDetail(id string) (map[string]interface{}, error) {
+func (s *productionService) GetRoutingDetail(id string) (*schemas.RoutingResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -746,22 +698,11 @@ func (s *productionService) GetRoutingDetail(id string) (map[string]interface{},
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	routingDetail := map[string]interface{}{
-		"id":           routing.ID,
-		"routing_no":   routing.RoutingNo,
-		"product_name": routing.ProductName,
-		"description":  routing.Description,
-		"created_at":   routing.CreatedAt,
-		"created_by":   routing.CreatedBy,
-		"updated_at":   routing.UpdatedAt,
-		"updated_by":   routing.UpdatedBy,
-	}
-
-	return routingDetail, nil
+	response := routingResponse(routing)
+	return &response, nil
 }
 
-func (s *productionService) CreateRouting(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *productionService) CreateRouting(req schemas.CreateRoutingRequest) (*schemas.RoutingResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -769,15 +710,16 @@ func (s *productionService) CreateRouting(req map[string]interface{}) (map[strin
 	}
 
 	// 从请求中获取数据
+	now := time.Now()
 	routing := models.Routing{
-		ID:          req["id"].(string),
-		RoutingNo:   req["routing_no"].(string),
-		ProductName: req["product_name"].(string),
-		Description: req["description"].(string),
-		CreatedAt:   time.Now(),
-		CreatedBy:   req["created_by"].(string),
-		UpdatedAt:   time.Now(),
-		UpdatedBy:   req["created_by"].(string),
+		ID:          utils.GenerateID(),
+		RoutingNo:   req.RoutingNo,
+		ProductName: req.ProductName,
+		Description: req.Description,
+		CreatedAt:   now,
+		CreatedBy:   req.CreatedBy,
+		UpdatedAt:   now,
+		UpdatedBy:   req.CreatedBy,
 	}
 
 	// 保存到数据库
@@ -786,22 +728,11 @@ func (s *productionService) CreateRouting(req map[string]interface{}) (map[strin
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	createdRouting := map[string]interface{}{
-		"id":           routing.ID,
-		"routing_no":   routing.RoutingNo,
-		"product_name": routing.ProductName,
-		"description":  routing.Description,
-		"created_at":   routing.CreatedAt,
-		"created_by":   routing.CreatedBy,
-		"updated_at":   routing.UpdatedAt,
-		"updated_by":   routing.UpdatedBy,
-	}
-
-	return createdRouting, nil
+	response := routingResponse(routing)
+	return &response, nil
 }
 
-func (s *productionService) UpdateRouting(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *productionService) UpdateRouting(id string, req schemas.UpdateRoutingRequest) (*schemas.RoutingResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -816,10 +747,14 @@ func (s *productionService) UpdateRouting(id string, req map[string]interface{})
 	}
 
 	// 更新字段
-	routing.ProductName = req["product_name"].(string)
-	routing.Description = req["description"].(string)
+	if req.ProductName != "" {
+		routing.ProductName = req.ProductName
+	}
+	if req.Description != "" {
+		routing.Description = req.Description
+	}
 	routing.UpdatedAt = time.Now()
-	routing.UpdatedBy = req["updated_by"].(string)
+	routing.UpdatedBy = req.UpdatedBy
 
 	// 保存到数据库
 	result = s.db.Save(&routing)
@@ -827,19 +762,8 @@ func (s *productionService) UpdateRouting(id string, req map[string]interface{})
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	updatedRouting := map[string]interface{}{
-		"id":           routing.ID,
-		"routing_no":   routing.RoutingNo,
-		"product_name": routing.ProductName,
-		"description":  routing.Description,
-		"created_at":   routing.CreatedAt,
-		"created_by":   routing.CreatedBy,
-		"updated_at":   routing.UpdatedAt,
-		"updated_by":   routing.UpdatedBy,
-	}
-
-	return updatedRouting, nil
+	response := routingResponse(routing)
+	return &response, nil
 }
 
 func (s *productionService) DeleteRouting(id string) error {
@@ -858,8 +782,16 @@ func (s *productionService) DeleteRouting(id string) error {
 	return nil
 }
 
+// workCenterListSpec 工作中心列表查询白名单
+var workCenterListSpec = query.NewSpec("-created_at",
+	query.Text("work_center_no"),
+	query.Text("name"),
+	query.Number("capacity"),
+	query.Date("created_at"),
+)
+
 // 工作中心管理方法
-func (s *productionService) GetWorkCenterList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *productionService) GetWorkCenterList(params query.Params) (*query.Page[schemas.WorkCenterResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -868,31 +800,21 @@ func (s *productionService) GetWorkCenterList(req map[string]interface{}) ([]map
 
 	// 从数据库读取工作中心数据
 	var workCenters []models.WorkCenter
-	result := s.db.Find(&workCenters)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, workCenterListSpec, &workCenters)
+	if err != nil {
+		return nil, err
 	}
 
-	// 将模型转换为map
-	workCenterList := make([]map[string]interface{}, len(workCenters))
+	// 将模型转换为响应格式
+	workCenterList := make([]schemas.WorkCenterResponse, len(workCenters))
 	for i, workCenter := range workCenters {
-		workCenterList[i] = map[string]interface{}{
-			"id":             workCenter.ID,
-			"work_center_no": workCenter.WorkCenterNo,
-			"name":           workCenter.Name,
-			"description":    workCenter.Description,
-			"capacity":       workCenter.Capacity,
-			"created_at":     workCenter.CreatedAt,
-			"created_by":     workCenter.CreatedBy,
-			"updated_at":     workCenter.UpdatedAt,
-			"updated_by":     workCenter.UpdatedBy,
-		}
+		workCenterList[i] = workCenterResponse(workCenter)
 	}
 
-	return workCenterList, nil
+	return query.NewPage(workCenterList, total, params), nil
 }
 
-func (s *productionService) GetWorkCenterDetail(id string) (map[string]interface{}, error) {
+func (s *productionService) GetWorkCenterDetail(id string) (*schemas.WorkCenterResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -906,23 +828,11 @@ func (s *productionService) GetWorkCenterDetail(id string) (map[string]interface
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	workCenterDetail := map[string]interface{}{
-		"id":             workCenter.ID,
-		"work_center_no": workCenter.WorkCenterNo,
-		"name":           workCenter.Name,
-		"description":    workCenter.Description,
-		"capacity":       workCenter.Capacity,
-		"created_at":     workCenter.CreatedAt,
-		"created_by":     workCenter.CreatedBy,
-		"updated_at":     workCenter.UpdatedAt,
-		"updated_by":     workCenter.UpdatedBy,
-	}
-
-	return workCenterDetail, nil
+	response := workCenterResponse(workCenter)
+	return &response, nil
 }
 
-func (s *productionService) CreateWorkCenter(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *productionService) CreateWorkCenter(req schemas.CreateWorkCenterRequest) (*schemas.WorkCenterResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -930,16 +840,17 @@ func (s *productionService) CreateWorkCenter(req map[string]interface{}) (map[st
 	}
 
 	// 从请求中获取数据
+	now := time.Now()
 	workCenter := models.WorkCenter{
-		ID:           req["id"].(string),
-		WorkCenterNo: req["work_center_no"].(string),
-		Name:         req["name"].(string),
-		Description:  req["description"].(string),
-		Capacity:     req["capacity"].(int),
-		CreatedAt:    time.Now(),
-		CreatedBy:    req["created_by"].(string),
-		UpdatedAt:    time.Now(),
-		UpdatedBy:    req["created_by"].(string),
+		ID:           utils.GenerateID(),
+		WorkCenterNo: req.WorkCenterNo,
+		Name:         req.Name,
+		Description:  req.Description,
+		Capacity:     req.Capacity,
+		CreatedAt:    now,
+		CreatedBy:    req.CreatedBy,
+		UpdatedAt:    now,
+		UpdatedBy:    req.CreatedBy,
 	}
 
 	// 保存到数据库
@@ -948,23 +859,11 @@ func (s *productionService) CreateWorkCenter(req map[string]interface{}) (map[st
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	createdWorkCenter := map[string]interface{}{
-		"id":             workCenter.ID,
-		"work_center_no": workCenter.WorkCenterNo,
-		"name":           workCenter.Name,
-		"description":    workCenter.Description,
-		"capacity":       workCenter.Capacity,
-		"created_at":     workCenter.CreatedAt,
-		"created_by":     workCenter.CreatedBy,
-		"updated_at":     workCenter.UpdatedAt,
-		"updated_by":     workCenter.UpdatedBy,
-	}
-
-	return createdWorkCenter, nil
+	response := workCenterResponse(workCenter)
+	return &response, nil
 }
 
-func (s *productionService) UpdateWorkCenter(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *productionService) UpdateWorkCenter(id string, req schemas.UpdateWorkCenterRequest) (*schemas.WorkCenterResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -979,11 +878,17 @@ func (s *productionService) UpdateWorkCenter(id string, req map[string]interface
 	}
 
 	// 更新字段
-	workCenter.Name = req["name"].(string)
-	workCenter.Description = req["description"].(string)
-	workCenter.Capacity = req["capacity"].(int)
+	if req.Name != "" {
+		workCenter.Name = req.Name
+	}
+	if req.Description != "" {
+		workCenter.Description = req.Description
+	}
+	if req.Capacity != nil {
+		workCenter.Capacity = *req.Capacity
+	}
 	workCenter.UpdatedAt = time.Now()
-	workCenter.UpdatedBy = req["updated_by"].(string)
+	workCenter.UpdatedBy = req.UpdatedBy
 
 	// 保存到数据库
 	result = s.db.Save(&workCenter)
@@ -991,20 +896,8 @@ func (s *productionService) UpdateWorkCenter(id string, req map[string]interface
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	updatedWorkCenter := map[string]interface{}{
-		"id":             workCenter.ID,
-		"work_center_no": workCenter.WorkCenterNo,
-		"name":           workCenter.Name,
-		"description":    workCenter.Description,
-		"capacity":       workCenter.Capacity,
-		"created_at":     workCenter.CreatedAt,
-		"created_by":     workCenter.CreatedBy,
-		"updated_at":     workCenter.UpdatedAt,
-		"updated_by":     workCenter.UpdatedBy,
-	}
-
-	return updatedWorkCenter, nil
+	response := workCenterResponse(workCenter)
+	return &response, nil
 }
 
 func (s *productionService) DeleteWorkCenter(id string) error {
@@ -1023,7 +916,7 @@ func (s *productionService) DeleteWorkCenter(id string) error {
 	return nil
 }
 
-func (s *productionService) GetWorkCenterCapacity(id string) (map[string]interface{}, error) {
+func (s *productionService) GetWorkCenterCapacity(id string) (*schemas.WorkCenterCapacityResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -1038,19 +931,19 @@ func (s *productionService) GetWorkCenterCapacity(id string) (map[string]interfa
 	}
 
 	// 计算工作中心容量
-	capacityDetail := map[string]interface{}{
-		"id":                 workCenter.ID,
-		"work_center_no":     workCenter.WorkCenterNo,
-		"name":               workCenter.Name,
-		"total_capacity":     workCenter.Capacity,
-		"used_capacity":      0, // 实际应用中需要计算已使用容量
-		"available_capacity": workCenter.Capacity,
+	capacity := schemas.WorkCenterCapacityResponse{
+		ID:                workCenter.ID,
+		WorkCenterNo:      workCenter.WorkCenterNo,
+		Name:              workCenter.Name,
+		TotalCapacity:     workCenter.Capacity,
+		UsedCapacity:      0, // 实际应用中需要计算已使用容量
+		AvailableCapacity: workCenter.Capacity,
 	}
 
-	return capacityDetail, nil
+	return &capacity, nil
 }
 
-func (s *productionService) GetWorkCenterSchedule(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *productionService) GetWorkCenterSchedule(id string, req schemas.WorkCenterScheduleRequest) (*schemas.WorkCenterScheduleResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -1064,19 +957,36 @@ func (s *productionService) GetWorkCenterSchedule(id string, req map[string]inte
 		return nil, result.Error
 	}
 
+	// 读取排在该工作中心上的未取消工单
+	db := s.db.Where("work_center_id = ? AND status <> ?", workCenter.ID, "cancelled")
+	if req.StartDate != "" {
+		db = db.Where("end_time >= ?", req.StartDate)
+	}
+	if req.EndDate != "" {
+		endDate, _ := time.Parse("2006-01-02", req.EndDate)
+		db = db.Where("start_time < ?", endDate.AddDate(0, 0, 1))
+	}
+	var tickets []models.ProductionTicket
+	if err := db.Order("start_time").Find(&tickets).Error; err != nil {
+		return nil, err
+	}
+
 	// 生成工作中心调度
-	scheduleDetail := map[string]interface{}{
-		"id":             workCenter.ID,
-		"work_center_no": workCenter.WorkCenterNo,
-		"name":           workCenter.Name,
-		"schedule":       []map[string]interface{}{},
+	schedule := schemas.WorkCenterScheduleResponse{
+		ID:           workCenter.ID,
+		WorkCenterNo: workCenter.WorkCenterNo,
+		Name:         workCenter.Name,
+		Schedule:     make([]schemas.ProductionTicketResponse, len(tickets)),
+	}
+	for i, ticket := range tickets {
+		schedule.Schedule[i] = productionTicketResponse(ticket)
 	}
 
-	return scheduleDetail, nil
+	return &schedule, nil
 }
 
 // 物料需求计划管理方法
-func (s *productionService) RunMRP(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *productionService) RunMRP(req schemas.MRPRunRequest) (*schemas.MRPRunResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -1091,19 +1001,19 @@ func (s *productionService) RunMRP(req map[string]interface{}) (map[string]inter
 	// 5. 生成MRP结果和建议
 
 	// 模拟MRP运行结果
-	mrpResult := map[string]interface{}{
-		"run_id":      "1",
-		"run_date":    time.Now(),
-		"status":      "completed",
-		"total_items": 10,
-		"suggestions": 5,
-		"errors":      0,
+	mrpResult := schemas.MRPRunResponse{
+		RunID:       "1",
+		RunDate:     time.Now(),
+		Status:      "completed",
+		TotalItems:  10,
+		Suggestions: 5,
+		Errors:      0,
 	}
 
-	return mrpResult, nil
+	return &mrpResult, nil
 }
 
-func (s *productionService) GetMRPResults(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *productionService) GetMRPResults(req schemas.MRPResultRequest) ([]schemas.MRPResultResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -1114,35 +1024,47 @@ func (s *productionService) GetMRPResults(req map[string]interface{}) ([]map[str
 	// 实际应用中，这里需要从数据库读取MRP结果
 
 	// 模拟MRP结果数据
-	mrpResults := []map[string]interface{}{
+	suggestedDate := time.Now().Add(7 * 24 * time.Hour)
+	mrpResults := []schemas.MRPResultResponse{
 		{
-			"id":              "1",
-			"item_code":       "ITEM-001",
-			"item_name":       "智能手机",
-			"current_stock":   100,
-			"demand":          500,
-			"supply":          0,
-			"net_requirement": 400,
-			"suggested_qty":   400,
-			"suggested_date":  time.Now().Add(7 * 24 * time.Hour),
+			ID:             "1",
+			ItemCode:       "ITEM-001",
+			ItemName:       "智能手机",
+			CurrentStock:   100,
+			Demand:         500,
+			Supply:         0,
+			NetRequirement: 400,
+			SuggestedQty:   400,
+			SuggestedDate:  suggestedDate,
 		},
 		{
-			"id":              "2",
-			"item_code":       "ITEM-002",
-			"item_name":       "平板电脑",
-			"current_stock":   50,
-			"demand":          200,
-			"supply":          0,
-			"net_requirement": 150,
-			"suggested_qty":   150,
-			"suggested_date":  time.Now().Add(7 * 24 * time.Hour),
+			ID:             "2",
+			ItemCode:       "ITEM-002",
+			ItemName:       "平板电脑",
+			CurrentStock:   50,
+			Demand:         200,
+			Supply:         0,
+			NetRequirement: 150,
+			SuggestedQty:   150,
+			SuggestedDate:  suggestedDate,
 		},
 	}
 
+	// 按物料编码过滤
+	if req.ItemCode != "" {
+		filtered := make([]schemas.MRPResultResponse, 0, len(mrpResults))
+		for _, mrpResult := range mrpResults {
+			if mrpResult.ItemCode == req.ItemCode {
+				filtered = append(filtered, mrpResult)
+			}
+		}
+		mrpResults = filtered
+	}
+
 	return mrpResults, nil
 }
 
-func (s *productionService) GetMRPSuggestions(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *productionService) GetMRPSuggestions(runID string) ([]schemas.MRPSuggestionResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -1150,27 +1072,28 @@ func (s *productionService) GetMRPSuggestions(req map[string]interface{}) ([]map
 	}
 
 	// 从数据库读取MRP建议数据
-	// 实际应用中，这里需要从数据库读取MRP建议
+	// 实际应用中，这里需要按运行ID从数据库读取MRP建议
 
 	// 模拟MRP建议数据
-	mrpSuggestions := []map[string]interface{}{
+	suggestedDate := time.Now().Add(7 * 24 * time.Hour)
+	mrpSuggestions := []schemas.MRPSuggestionResponse{
 		{
-			"id":              "1",
-			"item_code":       "ITEM-001",
-			"item_name":       "智能手机",
-			"suggestion_type": "purchase",
-			"suggested_qty":   400,
-			"suggested_date":  time.Now().Add(7 * 24 * time.Hour),
-			"supplier":        "供应商A",
+			ID:             "1",
+			ItemCode:       "ITEM-001",
+			ItemName:       "智能手机",
+			SuggestionType: "purchase",
+			SuggestedQty:   400,
+			SuggestedDate:  suggestedDate,
+			Supplier:       "供应商A",
 		},
 		{
-			"id":              "2",
-			"item_code":       "ITEM-002",
-			"item_name":       "平板电脑",
-			"suggestion_type": "production",
-			"suggested_qty":   150,
-			"suggested_date":  time.Now().Add(7 * 24 * time.Hour),
-			"work_center":     "装配车间",
+			ID:             "2",
+			ItemCode:       "ITEM-002",
+			ItemName:       "平板电脑",
+			SuggestionType: "production",
+			SuggestedQty:   150,
+			SuggestedDate:  suggestedDate,
+			WorkCenter:     "装配车间",
 		},
 	}
 
@@ -1178,7 +1101,7 @@ func (s *productionService) GetMRPSuggestions(req map[string]interface{}) ([]map
 }
 
 // 生产报表管理方法
-func (s *productionService) GetProductionPlanReport(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *productionService) GetProductionPlanReport(req schemas.ProductionReportRequest) (*schemas.ProductionPlanReportResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -1189,36 +1112,37 @@ func (s *productionService) GetProductionPlanReport(req map[string]interface{})
 	// 实际应用中，这里需要从数据库读取生产计划数据
 
 	// 模拟生产计划报表数据
-	report := map[string]interface{}{
-		"report_type":    "production_plan",
-		"report_date":    time.Now(),
-		"period":         "month",
-		"total_orders":   10,
-		"total_quantity": 5000,
-		"orders": []map[string]interface{}{
+	now := time.Now()
+	report := schemas.ProductionPlanReportResponse{
+		ReportType:    "production_plan",
+		ReportDate:    now,
+		Period:        reportPeriod(req.StartDate, req.EndDate),
+		TotalOrders:   10,
+		TotalQuantity: 5000,
+		Orders: []schemas.ProductionPlanReportItem{
 			{
-				"order_no":     "PO-2026-0001",
-				"product_name": "智能手机",
-				"quantity":     1000,
-				"start_date":   time.Now(),
-				"end_date":     time.Now().Add(7 * 24 * time.Hour),
-				"status":       "approved",
+				OrderNo:     "PO-2026-0001",
+				ProductName: "智能手机",
+				Quantity:    1000,
+				StartDate:   now,
+				EndDate:     now.Add(7 * 24 * time.Hour),
+				Status:      "approved",
 			},
 			{
-				"order_no":     "PO-2026-0002",
-				"product_name": "平板电脑",
-				"quantity":     500,
-				"start_date":   time.Now().Add(2 * 24 * time.Hour),
-				"end_date":     time.Now().Add(9 * 24 * time.Hour),
-				"status":       "approved",
+				OrderNo:     "PO-2026-0002",
+				ProductName: "平板电脑",
+				Quantity:    500,
+				StartDate:   now.Add(2 * 24 * time.Hour),
+				EndDate:     now.Add(9 * 24 * time.Hour),
+				Status:      "approved",
 			},
 		},
 	}
 
-	return report, nil
+	return &report, nil
 }
 
-func (s *productionService) GetProductionExecutionReport(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *productionService) GetProductionExecutionReport(req schemas.ProductionReportRequest) (*schemas.ProductionExecutionReportResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -1229,37 +1153,38 @@ func (s *productionService) GetProductionExecutionReport(req map[string]interfac
 	// 实际应用中，这里需要从数据库读取生产执行数据
 
 	// 模拟生产执行报表数据
-	report := map[string]interface{}{
-		"report_type":       "production_execution",
-		"report_date":       time.Now(),
-		"period":            "month",
-		"total_tickets":     20,
-		"completed_tickets": 15,
-		"completion_rate":   75.0,
-		"tickets": []map[string]interface{}{
+	now := time.Now()
+	report := schemas.ProductionExecutionReportResponse{
+		ReportType:       "production_execution",
+		ReportDate:       now,
+		Period:           reportPeriod(req.StartDate, req.EndDate),
+		TotalTickets:     20,
+		CompletedTickets: 15,
+		CompletionRate:   75.0,
+		Tickets: []schemas.ProductionExecutionReportItem{
 			{
-				"ticket_no":    "PT-2026-0001",
-				"product_name": "智能手机",
-				"quantity":     100,
-				"start_time":   time.Now().Add(-24 * time.Hour),
-				"end_time":     time.Now().Add(-16 * time.Hour),
-				"status":       "completed",
+				TicketNo:    "PT-2026-0001",
+				ProductName: "智能手机",
+				Quantity:    100,
+				StartTime:   now.Add(-24 * time.Hour),
+				EndTime:     now.Add(-16 * time.Hour),
+				Status:      "completed",
 			},
 			{
-				"ticket_no":    "PT-2026-0002",
-				"product_name": "平板电脑",
-				"quantity":     50,
-				"start_time":   time.Now().Add(-12 * time.Hour),
-				"end_time":     time.Now().Add(-4 * time.Hour),
-				"status":       "completed",
+				TicketNo:    "PT-2026-0002",
+				ProductName: "平板电脑",
+				Quantity:    50,
+				StartTime:   now.Add(-12 * time.Hour),
+				EndTime:     now.Add(-4 * time.Hour),
+				Status:      "completed",
 			},
 		},
 	}
 
-	return report, nil
+	return &report, nil
 }
 
-func (s *productionService) GetWorkCenterLoadReport(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *productionService) GetWorkCenterLoadReport(req schemas.ProductionReportRequest) (*schemas.WorkCenterLoadReportResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -1270,33 +1195,33 @@ func (s *productionService) GetWorkCenterLoadReport(req map[string]interface{})
 	// 实际应用中，这里需要从数据库读取工作中心负载数据
 
 	// 模拟工作中心负载报表数据
-	report := map[string]interface{}{
-		"report_type":        "work_center_load",
-		"report_date":        time.Now(),
-		"period":             "month",
-		"total_work_centers": 2,
-		"work_centers": []map[string]interface{}{
+	report := schemas.WorkCenterLoadReportResponse{
+		ReportType:       "work_center_load",
+		ReportDate:       time.Now(),
+		Period:           reportPeriod(req.StartDate, req.EndDate),
+		TotalWorkCenters: 2,
+		WorkCenters: []schemas.WorkCenterLoadReportItem{
 			{
-				"work_center_no": "WC-2026-0001",
-				"name":           "装配车间",
-				"total_capacity": 1000,
-				"used_capacity":  750,
-				"load_rate":      75.0,
+				WorkCenterNo:  "WC-2026-0001",
+				Name:          "装配车间",
+				TotalCapacity: 1000,
+				UsedCapacity:  750,
+				LoadRate:      75.0,
 			},
 			{
-				"work_center_no": "WC-2026-0002",
-				"name":           "测试车间",
-				"total_capacity": 500,
-				"used_capacity":  300,
-				"load_rate":      60.0,
+				WorkCenterNo:  "WC-2026-0002",
+				Name:          "测试车间",
+				TotalCapacity: 500,
+				UsedCapacity:  300,
+				LoadRate:      60.0,
 			},
 		},
 	}
 
-	return report, nil
+	return &report, nil
 }
 
-func (s *productionService) GetMaterialConsumptionReport(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *productionService) GetMaterialConsumptionReport(req schemas.ProductionReportRequest) (*schemas.MaterialConsumptionReportResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -1307,33 +1232,33 @@ func (s *productionService) GetMaterialConsumptionReport(req map[string]interfac
 	// 实际应用中，这里需要从数据库读取物料消耗数据
 
 	// 模拟物料消耗报表数据
-	report := map[string]interface{}{
-		"report_type":     "material_consumption",
-		"report_date":     time.Now(),
-		"period":          "month",
-		"total_materials": 5,
-		"materials": []map[string]interface{}{
+	report := schemas.MaterialConsumptionReportResponse{
+		ReportType:     "material_consumption",
+		ReportDate:     time.Now(),
+		Period:         reportPeriod(req.StartDate, req.EndDate),
+		TotalMaterials: 5,
+		Materials: []schemas.MaterialConsumptionReportItem{
 			{
-				"material_code": "MAT-001",
-				"material_name": "屏幕",
-				"consumption":   1000,
-				"unit":          "个",
-				"cost":          100000.0,
+				MaterialCode: "MAT-001",
+				MaterialName: "屏幕",
+				Consumption:  1000,
+				Unit:         "个",
+				Cost:         100000.0,
 			},
 			{
-				"material_code": "MAT-002",
-				"material_name": "电池",
-				"consumption":   1000,
-				"unit":          "个",
-				"cost":          50000.0,
+				MaterialCode: "MAT-002",
+				MaterialName: "电池",
+				Consumption:  1000,
+				Unit:         "个",
+				Cost:         50000.0,
 			},
 		},
 	}
 
-	return report, nil
+	return &report, nil
 }
 
-func (s *productionService) GetProductionCostReport(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *productionService) GetProductionCostReport(req schemas.ProductionReportRequest) (*schemas.ProductionCostReportResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -1344,34 +1269,22 @@ func (s *productionService) GetProductionCostReport(req map[string]interface{})
 	// 实际应用中，这里需要从数据库读取生产成本数据
 
 	// 模拟生产成本报表数据
-	report := map[string]interface{}{
-		"report_type": "production_cost",
-		"report_date": time.Now(),
-		"period":      "month",
-		"total_cost":  200000.0,
-		"cost_breakdown": []map[string]interface{}{
-			{
-				"cost_type":  "material",
-				"amount":     150000.0,
-				"percentage": 75.0,
-			},
-			{
-				"cost_type":  "labor",
-				"amount":     30000.0,
-				"percentage": 15.0,
-			},
-			{
-				"cost_type":  "overhead",
-				"amount":     20000.0,
-				"percentage": 10.0,
-			},
+	report := schemas.ProductionCostReportResponse{
+		ReportType: "production_cost",
+		ReportDate: time.Now(),
+		Period:     reportPeriod(req.StartDate, req.EndDate),
+		TotalCost:  200000.0,
+		CostBreakdown: []schemas.ProductionCostReportItem{
+			{CostType: "material", Amount: 150000.0, Percentage: 75.0},
+			{CostType: "labor", Amount: 30000.0, Percentage: 15.0},
+			{CostType: "overhead", Amount: 20000.0, Percentage: 10.0},
 		},
 	}
 
-	return report, nil
+	return &report, nil
 }
 
-func (s *productionService) ExportProductionReport(req map[string]interface{}) ([]byte, error) {
+func (s *productionService) ExportProductionReport(req schemas.ExportProductionReportRequest) ([]byte, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		// 数据库连接失败，返回错误
@@ -1379,7 +1292,7 @@ func (s *productionService) ExportProductionReport(req map[string]interface{}) (
 	}
 
 	// 实际应用中，这里需要生成并导出报表
-	// 1. 读取报表数据
+	// 1. 按报表类型读取报表数据
 	// 2. 生成报表文件（PDF, Excel等）
 	// 3. 返回报表文件字节流
 
@@ -1389,513 +1302,78 @@ func (s *productionService) ExportProductionReport(req map[string]interface{}) (
 	return reportData, nil
 }
 
-// getMockCreateProductionOrder 获取模拟创建生产订单数据
-func (s *productionService) getMockCreateProductionOrder(req map[string]interface{}) map[string]interface{} {
-	return map[string]interface{}{
-		"id":           req["id"],
-		"order_no":     req["order_no"],
-		"product_name": req["product_name"],
-		"quantity":     req["quantity"],
-		"status":       req["status"],
-		"priority":     req["priority"],
-		"start_date":   time.Now(),
-		"end_date":     time.Now().Add(7 * 24 * time.Hour),
-		"created_at":   time.Now(),
-		"created_by":   req["created_by"],
-		"updated_at":   time.Now(),
-		"updated_by":   req["created_by"],
-	}
-}
-
-// getMockUpdateProductionOrder 获取模拟更新生产订单数据
-func (s *productionService) getMockUpdateProductionOrder(id string, req map[string]interface{}) map[string]interface{} {
-	return map[string]interface{}{
-		"id":           id,
-		"order_no":     "PO-2026-0001",
-		"product_name": req["product_name"],
-		"quantity":     req["quantity"],
-		"status":       req["status"],
-		"priority":     req["priority"],
-		"start_date":   time.Now(),
-		"end_date":     time.Now().Add(7 * 24 * time.Hour),
-		"created_at":   time.Now().Add(-24 * time.Hour),
-		"created_by":   "admin",
-		"updated_at":   time.Now(),
-		"updated_by":   req["updated_by"],
-	}
-}
-
-// getMockProductionTicketList 获取模拟生产工单列表数据
-func (s *productionService) getMockProductionTicketList() []map[string]interface{} {
-	return []map[string]interface{}{
-		{
-			"id":                  "1",
-			"ticket_no":           "PT-2026-0001",
-			"production_order_id": "1",
-			"product_name":        "智能手机",
-			"quantity":            100,
-			"status":              "pending",
-			"work_center_id":      "1",
-			"start_time":          time.Now(),
-			"end_time":            time.Now().Add(24 * time.Hour),
-			"created_at":          time.Now(),
-			"created_by":          "admin",
-			"updated_at":          time.Now(),
-			"updated_by":          "admin",
-		},
-		{
-			"id":                  "2",
-			"ticket_no":           "PT-2026-0002",
-			"production_order_id": "1",
-			"product_name":        "智能手机",
-			"quantity":            100,
-			"status":              "in_progress",
-			"work_center_id":      "2",
-			"start_time":          time.Now(),
-			"end_time":            time.Now().Add(24 * time.Hour),
-			"created_at":          time.Now(),
-			"created_by":          "admin",
-			"updated_at":          time.Now(),
-			"updated_by":          "admin",
-		},
-	}
-}
-
-// getMockProductionTicketDetail 获取模拟生产工单详情数据
-func (s *productionService) getMockProductionTicketDetail(id string) map[string]interface{} {
-	return map[string]interface{}{
-		"id":                  id,
-		"ticket_no":           "PT-2026-0001",
-		"production_order_id": "1",
-		"product_name":        "智能手机",
-		"quantity":            100,
-		"status":              "pending",
-		"work_center_id":      "1",
-		"start_time":          time.Now(),
-		"end_time":            time.Now().Add(24 * time.Hour),
-		"created_at":          time.Now(),
-		"created_by":          "admin",
-		"updated_at":          time.Now(),
-		"updated_by":          "admin",
-	}
-}
-
-// getMockCreateProductionTicket 获取模拟创建生产工单数据
-func (s *productionService) getMockCreateProductionTicket(req map[string]interface{}) map[string]interface{} {
-	return map[string]interface{}{
-		"id":                  req["id"],
-		"ticket_no":           req["ticket_no"],
-		"production_order_id": req["production_order_id"],
-		"product_name":        req["product_name"],
-		"quantity":            req["quantity"],
-		"status":              req["status"],
-		"work_center_id":      req["work_center_id"],
-		"start_time":          time.Now(),
-		"end_time":            time.Now().Add(24 * time.Hour),
-		"created_at":          time.Now(),
-		"created_by":          req["created_by"],
-		"updated_at":          time.Now(),
-		"updated_by":          req["created_by"],
-	}
-}
-
-// getMockUpdateProductionTicket 获取模拟更新生产工单数据
-func (s *productionService) getMockUpdateProductionTicket(id string, req map[string]interface{}) map[string]interface{} {
-	return map[string]interface{}{
-		"id":                  id,
-		"ticket_no":           "PT-2026-0001",
-		"production_order_id": "1",
-		"product_name":        req["product_name"],
-		"quantity":            req["quantity"],
-		"status":              req["status"],
-		"work_center_id":      req["work_center_id"],
-		"start_time":          time.Now(),
-		"end_time":            time.Now().Add(24 * time.Hour),
-		"created_at":          time.Now().Add(-24 * time.Hour),
-		"created_by":          "admin",
-		"updated_at":          time.Now(),
-		"updated_by":          req["updated_by"],
-	}
-}
-
-// getMockRoutingList 获取模拟工艺路线列表数据
-func (s *productionService) getMockRoutingList() []map[string]interface{} {
-	return []map[string]interface{}{
-		{
-			"id":           "1",
-			"routing_no":   "RT-2026-0001",
-			"product_name": "智能手机",
-			"description":  "智能手机生产工艺路线",
-			"created_at":   time.Now(),
-			"created_by":   "admin",
-			"updated_at":   time.Now(),
-			"updated_by":   "admin",
-		},
-		{
-			"id":           "2",
-			"routing_no":   "RT-2026-0002",
-			"product_name": "平板电脑",
-			"description":  "平板电脑生产工艺路线",
-			"created_at":   time.Now(),
-			"created_by":   "admin",
-			"updated_at":   time.Now(),
-			"updated_by":   "admin",
-		},
-	}
-}
-
-// getMockRoutingDetail 获取模拟工艺路线详情数据
-func (s *productionService) getMockRoutingDetail(id string) map[string]interface{} {
-	return map[string]interface{}{
-		"id":           id,
-		"routing_no":   "RT-2026-0001",
-		"product_name": "智能手机",
-		"description":  "智能手机生产工艺路线",
-		"created_at":   time.Now(),
-		"created_by":   "admin",
-		"updated_at":   time.Now(),
-		"updated_by":   "admin",
-	}
-}
-
-// getMockCreateRouting 获取模拟创建工艺路线数据
-func (s *productionService) getMockCreateRouting(req map[string]interface{}) map[string]interface{} {
-	return map[string]interface{}{
-		"id":           req["id"],
-		"routing_no":   req["routing_no"],
-		"product_name": req["product_name"],
-		"description":  req["description"],
-		"created_at":   time.Now(),
-		"created_by":   req["created_by"],
-		"updated_at":   time.Now(),
-		"updated_by":   req["created_by"],
-	}
-}
-
-// getMockUpdateRouting 获取模拟更新工艺路线数据
-func (s *productionService) getMockUpdateRouting(id string, req map[string]interface{}) map[string]interface{} {
-	return map[string]interface{}{
-		"id":           id,
-		"routing_no":   "RT-2026-0001",
-		"product_name": req["product_name"],
-		"description":  req["description"],
-		"created_at":   time.Now().Add(-24 * time.Hour),
-		"created_by":   "admin",
-		"updated_at":   time.Now(),
-		"updated_by":   req["updated_by"],
-	}
-}
-
-// getMockWorkCenterList 获取模拟工作中心列表数据
-func (s *productionService) getMockWorkCenterList() []map[string]interface{} {
-	return []map[string]interface{}{
-		{
-			"id":             "1",
-			"work_center_no": "WC-2026-0001",
-			"name":           "装配车间",
-			"description":    "智能手机装配车间",
-			"capacity":       1000,
-			"created_at":     time.Now(),
-			"created_by":     "admin",
-			"updated_at":     time.Now(),
-			"updated_by":     "admin",
-		},
-		{
-			"id":             "2",
-			"work_center_no": "WC-2026-0002",
-			"name":           "测试车间",
-			"description":    "智能手机测试车间",
-			"capacity":       500,
-			"created_at":     time.Now(),
-			"created_by":     "admin",
-			"updated_at":     time.Now(),
-			"updated_by":     "admin",
-		},
-	}
-}
-
-// getMockWorkCenterDetail 获取模拟工作中心详情数据
-func (s *productionService) getMockWorkCenterDetail(id string) map[string]interface{} {
-	return map[string]interface{}{
-		"id":             id,
-		"work_center_no": "WC-2026-0001",
-		"name":           "装配车间",
-		"description":    "智能手机装配车间",
-		"capacity":       1000,
-		"created_at":     time.Now(),
-		"created_by":     "admin",
-		"updated_at":     time.Now(),
-		"updated_by":     "admin",
-	}
-}
-
-// getMockCreateWorkCenter 获取模拟创建工作中心数据
-func (s *productionService) getMockCreateWorkCenter(req map[string]interface{}) map[string]interface{} {
-	return map[string]interface{}{
-		"id":             req["id"],
-		"work_center_no": req["work_center_no"],
-		"name":           req["name"],
-		"description":    req["description"],
-		"capacity":       req["capacity"],
-		"created_at":     time.Now(),
-		"created_by":     req["created_by"],
-		"updated_at":     time.Now(),
-		"updated_by":     req["created_by"],
-	}
-}
-
-// getMockUpdateWorkCenter 获取模拟更新工作中心数据
-func (s *productionService) getMockUpdateWorkCenter(id string, req map[string]interface{}) map[string]interface{} {
-	return map[string]interface{}{
-		"id":             id,
-		"work_center_no": "WC-2026-0001",
-		"name":           req["name"],
-		"description":    req["description"],
-		"capacity":       req["capacity"],
-		"created_at":     time.Now().Add(-24 * time.Hour),
-		"created_by":     "admin",
-		"updated_at":     time.Now(),
-		"updated_by":     req["updated_by"],
-	}
-}
-
-// getMockWorkCenterCapacity 获取模拟工作中心容量数据
-func (s *productionService) getMockWorkCenterCapacity(id string) map[string]interface{} {
-	return map[string]interface{}{
-		"id":                 id,
-		"work_center_no":     "WC-2026-0001",
-		"name":               "装配车间",
-		"total_capacity":     1000,
-		"used_capacity":      500,
-		"available_capacity": 500,
-	}
-}
-
-// getMockWorkCenterSchedule 获取模拟工作中心调度数据
-func (s *productionService) getMockWorkCenterSchedule(id string) map[string]interface{} {
-	return map[string]interface{}{
-		"id":             id,
-		"work_center_no": "WC-2026-0001",
-		"name":           "装配车间",
-		"schedule": []map[string]interface{}{
-			{
-				"order_id":     "1",
-				"order_no":     "PO-2026-0001",
-				"product_name": "智能手机",
-				"quantity":     100,
-				"start_time":   time.Now(),
-				"end_time":     time.Now().Add(8 * time.Hour),
-			},
-			{
-				"order_id":     "2",
-				"order_no":     "PO-2026-0002",
-				"product_name": "平板电脑",
-				"quantity":     50,
-				"start_time":   time.Now().Add(8 * time.Hour),
-				"end_time":     time.Now().Add(16 * time.Hour),
-			},
-		},
-	}
-}
-
-// getMockRunMRP 获取模拟MRP运行结果
-func (s *productionService) getMockRunMRP() map[string]interface{} {
-	return map[string]interface{}{
-		"run_id":      "1",
-		"run_date":    time.Now(),
-		"status":      "completed",
-		"total_items": 10,
-		"suggestions": 5,
-		"errors":      0,
-	}
-}
-
-// getMockMRPResults 获取模拟MRP结果数据
-func (s *productionService) getMockMRPResults() []map[string]interface{} {
-	return []map[string]interface{}{
-		{
-			"id":              "1",
-			"item_code":       "ITEM-001",
-			"item_name":       "智能手机",
-			"current_stock":   100,
-			"demand":          500,
-			"supply":          0,
-			"net_requirement": 400,
-			"suggested_qty":   400,
-			"suggested_date":  time.Now().Add(7 * 24 * time.Hour),
-		},
-		{
-			"id":              "2",
-			"item_code":       "ITEM-002",
-			"item_name":       "平板电脑",
-			"current_stock":   50,
-			"demand":          200,
-			"supply":          0,
-			"net_requirement": 150,
-			"suggested_qty":   150,
-			"suggested_date":  time.Now().Add(7 * 24 * time.Hour),
-		},
-	}
-}
-
-// getMockMRPSuggestions 获取模拟MRP建议数据
-func (s *productionService) getMockMRPSuggestions() []map[string]interface{} {
-	return []map[string]interface{}{
-		{
-			"id":              "1",
-			"item_code":       "ITEM-001",
-			"item_name":       "智能手机",
-			"suggestion_type": "purchase",
-			"suggested_qty":   400,
-			"suggested_date":  time.Now().Add(7 * 24 * time.Hour),
-			"supplier":        "供应商A",
-		},
-		{
-			"id":              "2",
-			"item_code":       "ITEM-002",
-			"item_name":       "平板电脑",
-			"suggestion_type": "production",
-			"suggested_qty":   150,
-			"suggested_date":  time.Now().Add(7 * 24 * time.Hour),
-			"work_center":     "装配车间",
-		},
-	}
-}
-
-// getMockProductionPlanReport 获取模拟生产计划报表数据
-func (s *productionService) getMockProductionPlanReport() map[string]interface{} {
-	return map[string]interface{}{
-		"report_type":    "production_plan",
-		"report_date":    time.Now(),
-		"period":         "month",
-		"total_orders":   10,
-		"total_quantity": 5000,
-		"orders": []map[string]interface{}{
-			{
-				"order_no":     "PO-2026-0001",
-				"product_name": "智能手机",
-				"quantity":     1000,
-				"start_date":   time.Now(),
-				"end_date":     time.Now().Add(7 * 24 * time.Hour),
-				"status":       "approved",
-			},
-			{
-				"order_no":     "PO-2026-0002",
-				"product_name": "平板电脑",
-				"quantity":     500,
-				"start_date":   time.Now().Add(2 * 24 * time.Hour),
-				"end_date":     time.Now().Add(9 * 24 * time.Hour),
-				"status":       "approved",
-			},
-		},
-	}
-}
-
-// getMockProductionExecutionReport 获取模拟生产执行报表数据
-func (s *productionService) getMockProductionExecutionReport() map[string]interface{} {
-	return map[string]interface{}{
-		"report_type":       "production_execution",
-		"report_date":       time.Now(),
-		"period":            "month",
-		"total_tickets":     20,
-		"completed_tickets": 15,
-		"completion_rate":   75.0,
-		"tickets": []map[string]interface{}{
-			{
-				"ticket_no":    "PT-2026-0001",
-				"product_name": "智能手机",
-				"quantity":     100,
-				"start_time":   time.Now().Add(-24 * time.Hour),
-				"end_time":     time.Now().Add(-16 * time.Hour),
-				"status":       "completed",
-			},
-			{
-				"ticket_no":    "PT-2026-0002",
-				"product_name": "平板电脑",
-				"quantity":     50,
-				"start_time":   time.Now().Add(-12 * time.Hour),
-				"end_time":     time.Now().Add(-4 * time.Hour),
-				"status":       "completed",
-			},
-		},
-	}
-}
-
-// getMockWorkCenterLoadReport 获取模拟工作中心负载报表数据
-func (s *productionService) getMockWorkCenterLoadReport() map[string]interface{} {
-	return map[string]interface{}{
-		"report_type":        "work_center_load",
-		"report_date":        time.Now(),
-		"period":             "month",
-		"total_work_centers": 2,
-		"work_centers": []map[string]interface{}{
-			{
-				"work_center_no": "WC-2026-0001",
-				"name":           "装配车间",
-				"total_capacity": 1000,
-				"used_capacity":  750,
-				"load_rate":      75.0,
-			},
-			{
-				"work_center_no": "WC-2026-0002",
-				"name":           "测试车间",
-				"total_capacity": 500,
-				"used_capacity":  300,
-				"load_rate":      60.0,
-			},
-		},
-	}
-}
-
-// getMockMaterialConsumptionReport 获取模拟物料消耗报表数据
-func (s *productionService) getMockMaterialConsumptionReport() map[string]interface{} {
-	return map[string]interface{}{
-		"report_type":     "material_consumption",
-		"report_date":     time.Now(),
-		"period":          "month",
-		"total_materials": 5,
-		"materials": []map[string]interface{}{
-			{
-				"material_code": "MAT-001",
-				"material_name": "屏幕",
-				"consumption":   1000,
-				"unit":          "个",
-				"cost":          100000.0,
-			},
-			{
-				"material_code": "MAT-002",
-				"material_name": "电池",
-				"consumption":   1000,
-				"unit":          "个",
-				"cost":          50000.0,
-			},
-		},
-	}
-}
-
-// getMockProductionCostReport 获取模拟生产成本报表数据
-func (s *productionService) getMockProductionCostReport() map[string]interface{} {
-	return map[string]interface{}{
-		"report_type": "production_cost",
-		"report_date": time.Now(),
-		"period":      "month",
-		"total_cost":  200000.0,
-		"cost_breakdown": []map[string]interface{}{
-			{
-				"cost_type":  "material",
-				"amount":     150000.0,
-				"percentage": 75.0,
-			},
-			{
-				"cost_type":  "labor",
-				"amount":     30000.0,
-				"percentage": 15.0,
-			},
-			{
-				"cost_type":  "overhead",
-				"amount":     20000.0,
-				"percentage": 10.0,
-			},
-		},
+// reportPeriod 报表期间，未指定日期范围时按月
+func reportPeriod(startDate, endDate string) string {
+	if startDate == "" || endDate == "" {
+		return "month"
+	}
+	return startDate + " ~ " + endDate
+}
+
+// productionOrderResponse 将生产订单模型转换为响应格式
+func productionOrderResponse(order models.ProductionOrder) schemas.ProductionOrderResponse {
+	return schemas.ProductionOrderResponse{
+		ID:          order.ID,
+		OrderNo:     order.OrderNo,
+		ProductName: order.ProductName,
+		ItemID:      order.ItemID,
+		WarehouseID: order.WarehouseID,
+		Quantity:    order.Quantity,
+		Status:      order.Status,
+		Priority:    order.Priority,
+		StartDate:   order.StartDate,
+		EndDate:     order.EndDate,
+		CreatedAt:   order.CreatedAt,
+		CreatedBy:   order.CreatedBy,
+		UpdatedAt:   order.UpdatedAt,
+		UpdatedBy:   order.UpdatedBy,
+	}
+}
+
+// productionTicketResponse 将生产工单模型转换为响应格式
+func productionTicketResponse(ticket models.ProductionTicket) schemas.ProductionTicketResponse {
+	return schemas.ProductionTicketResponse{
+		ID:                ticket.ID,
+		TicketNo:          ticket.TicketNo,
+		ProductionOrderID: ticket.ProductionOrderID,
+		ProductName:       ticket.ProductName,
+		Quantity:          ticket.Quantity,
+		Status:            ticket.Status,
+		WorkCenterID:      ticket.WorkCenterID,
+		StartTime:         ticket.StartTime,
+		EndTime:           ticket.EndTime,
+		CreatedAt:         ticket.CreatedAt,
+		CreatedBy:         ticket.CreatedBy,
+		UpdatedAt:         ticket.UpdatedAt,
+		UpdatedBy:         ticket.UpdatedBy,
+	}
+}
+
+// routingResponse 将工艺路线模型转换为响应格式
+func routingResponse(routing models.Routing) schemas.RoutingResponse {
+	return schemas.RoutingResponse{
+		ID:          routing.ID,
+		RoutingNo:   routing.RoutingNo,
+		ProductName: routing.ProductName,
+		Description: routing.Description,
+		CreatedAt:   routing.CreatedAt,
+		CreatedBy:   routing.CreatedBy,
+		UpdatedAt:   routing.UpdatedAt,
+		UpdatedBy:   routing.UpdatedBy,
+	}
+}
+
+// workCenterResponse 将工作中心模型转换为响应格式
+func workCenterResponse(workCenter models.WorkCenter) schemas.WorkCenterResponse {
+	return schemas.WorkCenterResponse{
+		ID:           workCenter.ID,
+		WorkCenterNo: workCenter.WorkCenterNo,
+		Name:         workCenter.Name,
+		Description:  workCenter.Description,
+		Capacity:     workCenter.Capacity,
+		CreatedAt:    workCenter.CreatedAt,
+		CreatedBy:    workCenter.CreatedBy,
+		UpdatedAt:    workCenter.UpdatedAt,
+		UpdatedBy:    workCenter.UpdatedBy,
 	}
 }
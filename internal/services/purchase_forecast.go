@@ -0,0 +1,461 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/models"
+	"gorm.io/gorm"
+)
+
+// 采购预测方法
+const (
+	forecastMethodMovingAverage        = "moving_average"        // 移动平均
+	forecastMethodExponentialSmoothing = "exponential_smoothing" // 指数平滑
+	forecastMethodSeasonal             = "seasonal"              // 上年同期乘以近期同比增长
+)
+
+// 采购预测分期
+const (
+	forecastBucketWeek  = "week"
+	forecastBucketMonth = "month"
+)
+
+// 采购预测参数默认值
+const (
+	forecastDefaultPeriods          = 3
+	forecastDefaultAlpha            = 0.3
+	forecastDefaultAveragePeriods   = 3  // 移动平均及季节法计算同比增长的历史期数
+	forecastDefaultSmoothingPeriods = 12 // 指数平滑的历史期数
+)
+
+// forecastIssueTypes 计入历史消耗的出库交易类型，销售退货冲减当期消耗
+var forecastIssueTypes = []string{"sales", "sales_out"}
+
+// forecastSalesOrderStatuses 计入未交付销售需求的销售订单状态
+var forecastSalesOrderStatuses = []string{"approved", "credit_hold", "partially_shipped"}
+
+// forecastDatedQuantity 带日期的物料数量，用于汇总销售需求和采购在途
+type forecastDatedQuantity struct {
+	ItemID       string
+	OrderDate    time.Time
+	DeliveryDate *time.Time
+	Quantity     float64
+}
+
+func (s *purchaseService) GetPurchaseForecastReport(req schemas.PurchaseForecastRequest) (*schemas.PurchaseForecastReportResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	return purchaseForecast(s.db, req)
+}
+
+func (s *purchaseService) CreatePurchasePlanFromForecast(req schemas.PurchaseForecastPlanRequest) (*schemas.PurchasePlanResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	report, err := purchaseForecast(s.db, req.PurchaseForecastRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	// 每个物料每期的建议采购量生成一条计划明细，需求日期为该期开始日期
+	var items []schemas.PurchasePlanItemRequest
+	for _, item := range report.Items {
+		for _, bucket := range item.Buckets {
+			if bucket.SuggestedQuantity <= 0 {
+				continue
+			}
+			items = append(items, schemas.PurchasePlanItemRequest{
+				ItemID:         item.ItemID,
+				VendorID:       item.VendorID,
+				Quantity:       bucket.SuggestedQuantity,
+				EstimatedPrice: item.EstimatedPrice,
+				NeedDate:       bucket.StartDate,
+			})
+		}
+	}
+	if len(items) == 0 {
+		return nil, errors.New("forecast suggests no purchases for the period")
+	}
+
+	planNo := req.PlanNo
+	if planNo == "" {
+		planNo = autoJournalNo("PP")
+	}
+	buckets := report.Items[0].Buckets
+	return s.CreatePurchasePlan(schemas.PurchasePlanCreateRequest{
+		PlanNo:      planNo,
+		Name:        req.Name,
+		Description: req.Description,
+		StartDate:   buckets[0].StartDate,
+		EndDate:     buckets[len(buckets)-1].EndDate,
+		Items:       items,
+		CreatedBy:   req.CreatedBy,
+	})
+}
+
+// purchaseForecast 由历史消耗和未交付销售需求预测各期需求，扣减可用库存和采购在途后给出各期建议采购量
+func purchaseForecast(db *gorm.DB, req schemas.PurchaseForecastRequest) (*schemas.PurchaseForecastReportResponse, error) {
+	options, start, err := forecastOptions(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 历史期在前、预测期在后，bounds[k]到bounds[k+1]前一天为第k期
+	historyCount := options.HistoryPeriods
+	if options.Method == forecastMethodSeasonal {
+		historyCount += forecastSeasonLength(options.Bucket)
+	}
+	bounds := make([]time.Time, historyCount+options.Periods+1)
+	for k := range bounds {
+		bounds[k] = forecastBucketStart(start, options.Bucket, k-historyCount)
+	}
+	horizonEnd := bounds[len(bounds)-1]
+
+	// 历史消耗：销售出库为负数量，销售退货为正数量
+	var transactions []struct {
+		ItemID          string
+		TransactionDate time.Time
+		Quantity        float64
+	}
+	transactionQuery := db.Model(&models.InventoryTransaction{}).
+		Select("item_id, transaction_date, quantity").
+		Where("transaction_date >= ? AND transaction_date < ?", bounds[0], start).
+		Where("((type IN ? AND quantity < 0) OR type = ?)", forecastIssueTypes, inventoryTypeSalesReturn)
+	if options.ItemID != "" {
+		transactionQuery = transactionQuery.Where("item_id = ?", options.ItemID)
+	}
+	if err := transactionQuery.Scan(&transactions).Error; err != nil {
+		return nil, err
+	}
+
+	// 未交付销售需求，销售产品通过关联物料折算
+	var salesDemand []forecastDatedQuantity
+	salesQuery := db.Table("sales_order_items AS i").
+		Select("p.item_id AS item_id, o.order_date AS order_date, o.delivery_date AS delivery_date, SUM(i.quantity - i.shipped_quantity) AS quantity").
+		Joins("JOIN sales_orders AS o ON o.id = i.order_id").
+		Joins("JOIN sales_products AS p ON p.id = i.product_id").
+		Where("i.quantity > i.shipped_quantity AND o.status IN ? AND COALESCE(p.item_id, '') <> '' AND i.deleted_at IS NULL AND o.deleted_at IS NULL",
+			forecastSalesOrderStatuses)
+	if options.ItemID != "" {
+		salesQuery = salesQuery.Where("p.item_id = ?", options.ItemID)
+	}
+	if err := salesQuery.Group("p.item_id, o.id, o.order_date, o.delivery_date").Scan(&salesDemand).Error; err != nil {
+		return nil, err
+	}
+
+	// 预测范围：有历史消耗或未交付销售需求的物料，指定物料时只预测该物料
+	var itemIDs []string
+	seen := make(map[string]bool)
+	addItem := func(itemID string) {
+		if !seen[itemID] {
+			seen[itemID] = true
+			itemIDs = append(itemIDs, itemID)
+		}
+	}
+	if options.ItemID != "" {
+		addItem(options.ItemID)
+	}
+	consumption := make(map[string][]float64)
+	for _, transaction := range transactions {
+		addItem(transaction.ItemID)
+		if consumption[transaction.ItemID] == nil {
+			consumption[transaction.ItemID] = make([]float64, historyCount)
+		}
+		if k := forecastBucketIndex(bounds, transaction.TransactionDate); k >= 0 && k < historyCount {
+			consumption[transaction.ItemID][k] -= transaction.Quantity
+		}
+	}
+	for _, demand := range salesDemand {
+		addItem(demand.ItemID)
+	}
+
+	response := &schemas.PurchaseForecastReportResponse{
+		Period:         reportPeriod(start.Format("2006-01-02"), horizonEnd.AddDate(0, 0, -1).Format("2006-01-02")),
+		HistoryPeriod:  reportPeriod(bounds[0].Format("2006-01-02"), start.AddDate(0, 0, -1).Format("2006-01-02")),
+		Method:         options.Method,
+		Bucket:         options.Bucket,
+		HistoryPeriods: options.HistoryPeriods,
+		Items:          []schemas.PurchaseForecastItem{},
+	}
+	if options.Method == forecastMethodExponentialSmoothing {
+		response.Alpha = options.Alpha
+	}
+	if len(itemIDs) == 0 {
+		return response, nil
+	}
+
+	var items []models.InventoryItem
+	if err := db.Where("id IN ?", itemIDs).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	itemMap := make(map[string]models.InventoryItem, len(items))
+	for _, item := range items {
+		itemMap[item.ID] = item
+	}
+
+	// 未关闭采购订单的未收数量作为计划入库
+	var receipts []forecastDatedQuantity
+	result := db.Table("purchase_order_items AS i").
+		Select("i.item_id AS item_id, o.order_date AS order_date, o.delivery_date AS delivery_date, SUM(i.quantity - i.received_quantity) AS quantity").
+		Joins("JOIN purchase_orders AS o ON o.id = i.order_id").
+		Where("i.item_id IN ? AND i.quantity > i.received_quantity AND o.status IN ? AND i.deleted_at IS NULL AND o.deleted_at IS NULL",
+			itemIDs, openPurchaseOrderStatuses).
+		Group("i.item_id, o.id, o.order_date, o.delivery_date").
+		Scan(&receipts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, itemID := range itemIDs {
+		item, ok := itemMap[itemID]
+		if !ok {
+			continue
+		}
+
+		// 建议供应商取最近一次采购的供应商，指定供应商时只预测向其采购过的物料
+		vendorID, price, err := lastPurchase(db, itemID, options.VendorID)
+		if err != nil {
+			return nil, err
+		}
+		if options.VendorID != "" && vendorID == "" {
+			continue
+		}
+		if vendorID == "" {
+			price = item.StandardCost
+		}
+		leadTime := 0
+		if vendorID != "" {
+			var vendor models.PurchaseVendor
+			if err := db.Select("lead_time").First(&vendor, "id = ?", vendorID).Error; err == nil {
+				leadTime = vendor.LeadTime
+			}
+		}
+
+		stock, err := inventoryAvailableStock(db, itemID)
+		if err != nil {
+			return nil, err
+		}
+		var onHand float64
+		for _, quantity := range stock {
+			onHand += quantity
+		}
+
+		history := consumption[itemID]
+		if history == nil {
+			history = make([]float64, historyCount)
+		}
+		for k := range history {
+			history[k] = roundQuantity(math.Max(history[k], 0))
+		}
+		forecast := forecastDemand(options, history)
+		sales := forecastFutureQuantities(bounds, historyCount, itemID, salesDemand)
+		scheduled := forecastFutureQuantities(bounds, historyCount, itemID, receipts)
+
+		line := schemas.PurchaseForecastItem{
+			ItemID:         item.ID,
+			ItemCode:       item.ItemNo,
+			ItemName:       item.Name,
+			Unit:           item.Unit,
+			OnHandQuantity: roundQuantity(onHand),
+			VendorID:       vendorID,
+			LeadTimeDays:   leadTime,
+			EstimatedPrice: price,
+			History:        make([]schemas.PurchaseForecastHistory, historyCount),
+			Buckets:        make([]schemas.PurchaseForecastBucket, options.Periods),
+		}
+		for k, quantity := range history {
+			line.History[k] = schemas.PurchaseForecastHistory{
+				StartDate: bounds[k].Format("2006-01-02"),
+				EndDate:   bounds[k+1].AddDate(0, 0, -1).Format("2006-01-02"),
+				Quantity:  quantity,
+			}
+		}
+
+		// 逐期推算可用量：每期需求取预测量和已接销售需求的较大值，可用量不足时建议采购补足到零
+		available := onHand
+		for k := range line.Buckets {
+			needDate := bounds[historyCount+k]
+			demand := math.Max(forecast[k], sales[k])
+			available = roundQuantity(available + scheduled[k] - demand)
+			bucket := schemas.PurchaseForecastBucket{
+				StartDate:         needDate.Format("2006-01-02"),
+				EndDate:           bounds[historyCount+k+1].AddDate(0, 0, -1).Format("2006-01-02"),
+				ForecastQuantity:  forecast[k],
+				SalesDemand:       sales[k],
+				DemandQuantity:    roundQuantity(demand),
+				ScheduledReceipts: scheduled[k],
+			}
+			if available < 0 {
+				bucket.SuggestedQuantity = -available
+				available = 0
+
+				// 按供应商前置时间倒推下单日期，已来不及的从预测起始日期下单
+				orderDate := needDate.AddDate(0, 0, -leadTime)
+				if orderDate.Before(start) {
+					orderDate = start
+				}
+				bucket.OrderDate = orderDate.Format("2006-01-02")
+				if line.SuggestedDate == "" {
+					line.SuggestedDate = bucket.OrderDate
+				}
+			}
+			bucket.ProjectedAvailable = available
+			line.Buckets[k] = bucket
+			line.ForecastQuantity += bucket.DemandQuantity
+			line.SuggestedQuantity += bucket.SuggestedQuantity
+		}
+		line.ForecastQuantity = roundQuantity(line.ForecastQuantity)
+		line.SuggestedQuantity = roundQuantity(line.SuggestedQuantity)
+		response.Items = append(response.Items, line)
+	}
+
+	// 需要采购的物料按下单日期在前，其余按物料编码排列
+	sort.SliceStable(response.Items, func(i, j int) bool {
+		a, b := response.Items[i], response.Items[j]
+		if (a.SuggestedDate == "") != (b.SuggestedDate == "") {
+			return a.SuggestedDate != ""
+		}
+		if a.SuggestedDate != b.SuggestedDate {
+			return a.SuggestedDate < b.SuggestedDate
+		}
+		return a.ItemCode < b.ItemCode
+	})
+	return response, nil
+}
+
+// forecastOptions 补齐采购预测参数的默认值，返回参数和预测起始日期；起始日期为本地时区零点，
+// 与交易和订单日期的时区一致，历史和预测区间按本地日期划分
+func forecastOptions(req schemas.PurchaseForecastRequest) (schemas.PurchaseForecastRequest, time.Time, error) {
+	start := localDate(time.Now())
+	if req.StartDate != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", req.StartDate, time.Local)
+		if err != nil {
+			return req, time.Time{}, err
+		}
+		start = parsed
+	}
+	if req.Method == "" {
+		req.Method = forecastMethodMovingAverage
+	}
+	if req.Bucket == "" {
+		req.Bucket = forecastBucketMonth
+	}
+	if req.Periods == 0 {
+		req.Periods = forecastDefaultPeriods
+	}
+	if req.HistoryPeriods == 0 {
+		req.HistoryPeriods = forecastDefaultAveragePeriods
+		if req.Method == forecastMethodExponentialSmoothing {
+			req.HistoryPeriods = forecastDefaultSmoothingPeriods
+		}
+	}
+	if req.Alpha == 0 {
+		req.Alpha = forecastDefaultAlpha
+	}
+	return req, start, nil
+}
+
+// forecastSeasonLength 一个季节周期包含的期数
+func forecastSeasonLength(bucket string) int {
+	if bucket == forecastBucketWeek {
+		return 52
+	}
+	return 12
+}
+
+// forecastBucketStart 第offset期的开始日期，负数为预测起始日期之前的历史期
+func forecastBucketStart(start time.Time, bucket string, offset int) time.Time {
+	if bucket == forecastBucketWeek {
+		return start.AddDate(0, 0, 7*offset)
+	}
+	return start.AddDate(0, offset, 0)
+}
+
+// forecastBucketIndex 日期所在的期序号，早于第一期时返回-1，晚于最后一期时返回期数
+func forecastBucketIndex(bounds []time.Time, date time.Time) int {
+	return sort.Search(len(bounds), func(k int) bool {
+		return bounds[k].After(date)
+	}) - 1
+}
+
+// forecastFutureQuantities 按交货日期把物料数量汇总到各预测期，已逾期的计入第一期，超出预测期的不计
+func forecastFutureQuantities(bounds []time.Time, historyCount int, itemID string, quantities []forecastDatedQuantity) []float64 {
+	periods := len(bounds) - 1 - historyCount
+	totals := make([]float64, periods)
+	for _, quantity := range quantities {
+		if quantity.ItemID != itemID {
+			continue
+		}
+		// 未约定交货日期的按订单日期计
+		date := quantity.OrderDate
+		if quantity.DeliveryDate != nil {
+			date = *quantity.DeliveryDate
+		}
+		k := forecastBucketIndex(bounds, date) - historyCount
+		if k >= periods {
+			continue
+		}
+		if k < 0 {
+			k = 0
+		}
+		totals[k] += quantity.Quantity
+	}
+	for k := range totals {
+		totals[k] = roundQuantity(totals[k])
+	}
+	return totals
+}
+
+// forecastDemand 按预测方法由历史各期消耗量推算各预测期的需求量
+func forecastDemand(options schemas.PurchaseForecastRequest, history []float64) []float64 {
+	forecast := make([]float64, options.Periods)
+	window := history[len(history)-options.HistoryPeriods:]
+	var recent float64
+	for _, quantity := range window {
+		recent += quantity
+	}
+	average := recent / float64(len(window))
+
+	switch options.Method {
+	case forecastMethodExponentialSmoothing:
+		level := window[0]
+		for _, quantity := range window[1:] {
+			level = options.Alpha*quantity + (1-options.Alpha)*level
+		}
+		for k := range forecast {
+			forecast[k] = level
+		}
+	case forecastMethodSeasonal:
+		// 上年同期消耗乘以最近几期相对上年同期的增长比例，上年同期无消耗时按近期平均
+		season := forecastSeasonLength(options.Bucket)
+		var lastYear float64
+		for k := len(history) - len(window); k < len(history); k++ {
+			lastYear += history[k-season]
+		}
+		for k := range forecast {
+			if lastYear > 0 {
+				forecast[k] = history[len(history)-season+k%season] * recent / lastYear
+			} else {
+				forecast[k] = average
+			}
+		}
+	default:
+		for k := range forecast {
+			forecast[k] = average
+		}
+	}
+
+	for k := range forecast {
+		forecast[k] = roundQuantity(forecast[k])
+	}
+	return forecast
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/wu136995/ginx/internal/config"
+	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/utils"
+	"gorm.io/gorm"
+)
+
+// ErrInvoiceBlocked 采购发票与订单、收货三单匹配超出容差，发票审核被冻结
+var ErrInvoiceBlocked = errors.New("purchase invoice is blocked by three-way match")
+
+// 三单匹配差异类型
+const (
+	discrepancyPrice     = "price"     // 发票单价与订单单价差异超出容差
+	discrepancyQuantity  = "quantity"  // 开票数量超过待开票收货数量且超出容差
+	discrepancyUnmatched = "unmatched" // 发票明细无对应的采购订单明细
+	discrepancyNoItems   = "no_items"  // 发票无明细，无法逐行匹配
+)
+
+// invoicedQuantityStatuses 已审核、开票数量计入已开票数量的发票状态
+var invoicedQuantityStatuses = []string{"verified", "partially_paid", "paid"}
+
+// matchPurchaseInvoice 将发票明细与采购订单明细单价、已完成收货数量逐行匹配，返回超出容差的差异。
+// 单价按不含税的折扣后单价比较，偏差绝对值超过单价容差即为差异；数量以收货数量扣除其他已审核发票的开票数量为待开票数量，
+// 发票指定收货单时只计该收货单的数量，开票数量超过待开票数量的部分超过数量容差即为差异，少开票视为分批开票不作差异
+func matchPurchaseInvoice(db *gorm.DB, invoice models.PurchaseInvoice) ([]models.PurchaseInvoiceDiscrepancy, error) {
+	cfg := config.GetAppConfig().Purchase
+	now := time.Now()
+	newDiscrepancy := func(item models.PurchaseInvoiceItem, kind string, reason string) models.PurchaseInvoiceDiscrepancy {
+		return models.PurchaseInvoiceDiscrepancy{
+			ID:            utils.GenerateID(),
+			InvoiceID:     invoice.ID,
+			InvoiceItemID: item.ID,
+			OrderItemID:   item.OrderItemID,
+			Type:          kind,
+			Reason:        reason,
+			CreatedBy:     "system",
+			CreatedAt:     now,
+			UpdatedBy:     "system",
+			UpdatedAt:     now,
+		}
+	}
+
+	if len(invoice.Items) == 0 {
+		return []models.PurchaseInvoiceDiscrepancy{
+			newDiscrepancy(models.PurchaseInvoiceItem{}, discrepancyNoItems, "invoice has no items to match against order and receipts"),
+		}, nil
+	}
+
+	// 采购订单明细
+	var orderItems []models.PurchaseOrderItem
+	if err := db.Where("order_id = ?", invoice.OrderID).Find(&orderItems).Error; err != nil {
+		return nil, err
+	}
+	orderItemMap := make(map[string]models.PurchaseOrderItem, len(orderItems))
+	for _, orderItem := range orderItems {
+		orderItemMap[orderItem.ID] = orderItem
+	}
+
+	// 已完成收货数量
+	type quantityRow struct {
+		OrderItemID string
+		Quantity    float64
+	}
+	var receivedRows []quantityRow
+	receiptQuery := db.Model(&models.PurchaseReceiptItem{}).
+		Select("purchase_receipt_items.order_item_id, SUM(purchase_receipt_items.quantity) AS quantity").
+		Joins("JOIN purchase_receipts ON purchase_receipts.id = purchase_receipt_items.receipt_id AND purchase_receipts.deleted_at IS NULL").
+		Where("purchase_receipts.order_id = ? AND purchase_receipts.status = ?", invoice.OrderID, "completed")
+	if invoice.ReceiptID != "" {
+		receiptQuery = receiptQuery.Where("purchase_receipts.id = ?", invoice.ReceiptID)
+	}
+	if err := receiptQuery.Group("purchase_receipt_items.order_item_id").Scan(&receivedRows).Error; err != nil {
+		return nil, err
+	}
+	openQuantities := make(map[string]float64, len(receivedRows))
+	for _, row := range receivedRows {
+		openQuantities[row.OrderItemID] = row.Quantity
+	}
+
+	// 扣除其他已审核发票的开票数量
+	var invoicedRows []quantityRow
+	invoicedQuery := db.Model(&models.PurchaseInvoiceItem{}).
+		Select("purchase_invoice_items.order_item_id, SUM(purchase_invoice_items.quantity) AS quantity").
+		Joins("JOIN purchase_invoices ON purchase_invoices.id = purchase_invoice_items.invoice_id AND purchase_invoices.deleted_at IS NULL").
+		Where("purchase_invoices.order_id = ? AND purchase_invoices.id <> ? AND purchase_invoices.status IN ?", invoice.OrderID, invoice.ID, invoicedQuantityStatuses)
+	if invoice.ReceiptID != "" {
+		invoicedQuery = invoicedQuery.Where("purchase_invoices.receipt_id = ?", invoice.ReceiptID)
+	}
+	if err := invoicedQuery.Group("purchase_invoice_items.order_item_id").Scan(&invoicedRows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range invoicedRows {
+		openQuantities[row.OrderItemID] -= row.Quantity
+	}
+
+	var discrepancies []models.PurchaseInvoiceDiscrepancy
+	for _, item := range invoice.Items {
+		orderItem, ok := orderItemMap[item.OrderItemID]
+		if !ok {
+			discrepancies = append(discrepancies, newDiscrepancy(item, discrepancyUnmatched,
+				fmt.Sprintf("item %s has no matching purchase order item", item.ItemID)))
+			continue
+		}
+
+		expectedPrice, actualPrice := matchUnitPrices(orderItem, item)
+		if variance, percent := matchVariance(expectedPrice, actualPrice); math.Abs(percent) > cfg.PriceTolerance {
+			discrepancy := newDiscrepancy(item, discrepancyPrice,
+				fmt.Sprintf("item %s unit price %.2f differs from order price %.2f by %.2f%%, tolerance %.2f%%",
+					item.ItemID, actualPrice, expectedPrice, percent, cfg.PriceTolerance))
+			discrepancy.Expected, discrepancy.Actual = expectedPrice, actualPrice
+			discrepancy.Variance, discrepancy.VariancePercent, discrepancy.Tolerance = variance, percent, cfg.PriceTolerance
+			discrepancies = append(discrepancies, discrepancy)
+		}
+
+		// 同一订单明细的多行发票依次占用待开票数量
+		expectedQuantity := roundQuantity(math.Max(openQuantities[item.OrderItemID], 0))
+		openQuantities[item.OrderItemID] -= item.Quantity
+		if variance, percent := matchVariance(expectedQuantity, item.Quantity); variance > 0 && percent > cfg.QuantityTolerance {
+			discrepancy := newDiscrepancy(item, discrepancyQuantity,
+				fmt.Sprintf("item %s invoiced quantity %.4f exceeds received and uninvoiced quantity %.4f, tolerance %.2f%%",
+					item.ItemID, item.Quantity, expectedQuantity, cfg.QuantityTolerance))
+			discrepancy.Expected, discrepancy.Actual = expectedQuantity, item.Quantity
+			discrepancy.Variance, discrepancy.VariancePercent, discrepancy.Tolerance = variance, percent, cfg.QuantityTolerance
+			discrepancies = append(discrepancies, discrepancy)
+		}
+	}
+	return discrepancies, nil
+}
+
+// matchUnitPrices 返回三单匹配比较的订单单价和发票单价，均为不含税的折扣后单价：
+// 订单取明细金额除以数量，发票取明细不含税金额除以数量，含税价发票扣除价内税额后比较
+func matchUnitPrices(orderItem models.PurchaseOrderItem, item models.PurchaseInvoiceItem) (float64, float64) {
+	expected := orderItem.UnitPrice
+	if orderItem.Quantity > 0 {
+		expected = roundAmount(orderItem.Amount / orderItem.Quantity)
+	}
+	net := purchaseInvoiceItemNetAmount(item)
+	actual := item.UnitPrice
+	if item.Quantity != 0 {
+		actual = roundAmount(net / item.Quantity)
+	}
+	return expected, actual
+}
+
+// matchVariance 计算实际值相对预期值的偏差和偏差百分比，预期值为零时有偏差即按100%计
+func matchVariance(expected, actual float64) (float64, float64) {
+	variance := roundQuantity(actual - expected)
+	if expected == 0 {
+		if variance == 0 {
+			return 0, 0
+		}
+		return variance, math.Copysign(100, variance)
+	}
+	return variance, math.Round(variance/expected*1000000) / 10000
+}
+
+// purchaseInvoiceBlockReason 汇总差异原因作为发票冻结原因
+func purchaseInvoiceBlockReason(discrepancies []models.PurchaseInvoiceDiscrepancy) string {
+	reasons := make([]string, len(discrepancies))
+	for i, discrepancy := range discrepancies {
+		reasons[i] = discrepancy.Reason
+	}
+	reason := strings.Join(reasons, "; ")
+	if len(reason) > 500 {
+		reason = reason[:497] + "..."
+	}
+	return reason
+}
+
+// resolveInvoiceOrderItems 为未指定订单明细的发票明细按物料匹配采购订单明细，同一物料有多行时取第一行
+func resolveInvoiceOrderItems(db *gorm.DB, invoice *models.PurchaseInvoice) error {
+	var orderItems []models.PurchaseOrderItem
+	if err := db.Where("order_id = ?", invoice.OrderID).Order("created_at").Find(&orderItems).Error; err != nil {
+		return err
+	}
+	byID := make(map[string]bool, len(orderItems))
+	byItem := make(map[string]string, len(orderItems))
+	for _, orderItem := range orderItems {
+		byID[orderItem.ID] = true
+		if _, ok := byItem[orderItem.ItemID]; !ok {
+			byItem[orderItem.ItemID] = orderItem.ID
+		}
+	}
+	for i, item := range invoice.Items {
+		if item.OrderItemID != "" {
+			if !byID[item.OrderItemID] {
+				return fmt.Errorf("order item %s does not belong to purchase order %s", item.OrderItemID, invoice.OrderID)
+			}
+			continue
+		}
+		invoice.Items[i].OrderItemID = byItem[item.ItemID]
+	}
+	return nil
+}
+
+// purchaseInvoiceItemNetAmount 返回发票明细的不含税金额，未计税的历史发票取明细金额扣除税额
+func purchaseInvoiceItemNetAmount(item models.PurchaseInvoiceItem) float64 {
+	if item.NetAmount != 0 {
+		return item.NetAmount
+	}
+	return roundAmount(item.Amount - item.TaxAmount)
+}
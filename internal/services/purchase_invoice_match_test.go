@@ -0,0 +1,59 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/wu136995/ginx/internal/models"
+)
+
+// TestMatchUnitPrices 测试三单匹配按不含税单价比较，含税发票在容差内不产生价格差异
+func TestMatchUnitPrices(t *testing.T) {
+	const tolerance = 2.0
+	orderItem := models.PurchaseOrderItem{Quantity: 10, UnitPrice: 10.2, Amount: 102}
+
+	tests := []struct {
+		name        string
+		item        models.PurchaseInvoiceItem
+		wantActual  float64
+		wantBlocked bool
+	}{
+		{
+			name:       "含税价在容差内",
+			item:       models.PurchaseInvoiceItem{Quantity: 10, UnitPrice: 11.3, Amount: 113, NetAmount: 100, TaxAmount: 13},
+			wantActual: 10,
+		},
+		{
+			name:       "不含税价在容差内",
+			item:       models.PurchaseInvoiceItem{Quantity: 10, UnitPrice: 10, Amount: 100, NetAmount: 100, TaxAmount: 13},
+			wantActual: 10,
+		},
+		{
+			name:        "含税价超出容差",
+			item:        models.PurchaseInvoiceItem{Quantity: 10, UnitPrice: 12.43, Amount: 124.3, NetAmount: 110, TaxAmount: 14.3},
+			wantActual:  11,
+			wantBlocked: true,
+		},
+		{
+			name:       "未计税的历史发票",
+			item:       models.PurchaseInvoiceItem{Quantity: 5, UnitPrice: 10.1, Amount: 50.5},
+			wantActual: 10.1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected, actual := matchUnitPrices(orderItem, tt.item)
+			if expected != 10.2 {
+				t.Errorf("Expected order price 10.20, got %.2f", expected)
+			}
+			if actual != tt.wantActual {
+				t.Errorf("Expected invoice net price %.2f, got %.2f", tt.wantActual, actual)
+			}
+			_, percent := matchVariance(expected, actual)
+			if blocked := math.Abs(percent) > tolerance; blocked != tt.wantBlocked {
+				t.Errorf("Expected blocked %v, got %v (variance %.2f%%)", tt.wantBlocked, blocked, percent)
+			}
+		})
+	}
+}
@@ -0,0 +1,476 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/query"
+	"github.com/wu136995/ginx/internal/utils"
+	"gorm.io/gorm"
+)
+
+// priceAnalysisDefaultMonths 未指定期间时价格分析默认统计截至当天的最近月数
+const priceAnalysisDefaultMonths = 12
+
+// 采购价格历史来源
+const (
+	priceSourceOrder   = "order"   // 采购订单审批
+	priceSourceInvoice = "invoice" // 采购发票审核
+)
+
+// priceHistoryListSpec 采购价格历史查询白名单
+var priceHistoryListSpec = query.NewSpec("-price_date",
+	query.Text("vendor_id"),
+	query.Text("item_id"),
+	query.Text("source_type"),
+	query.Text("source_id"),
+	query.Text("source_no"),
+	query.Text("currency"),
+	query.Number("base_unit_price"),
+	query.Date("price_date"),
+)
+
+// targetPriceListSpec 采购目标价查询白名单
+var targetPriceListSpec = query.NewSpec("item_id",
+	query.Text("item_id"),
+	query.Number("target_price"),
+	query.Date("updated_at"),
+)
+
+// 采购价格分析方法
+func (s *purchaseService) GetPriceAnalysisReport(req schemas.PurchaseReportRequest) (*schemas.PriceAnalysisReportResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	start, end, err := reportWindow(req.StartDate, req.EndDate, priceAnalysisDefaultMonths)
+	if err != nil {
+		return nil, err
+	}
+
+	// 读取期间内的价格历史，按价格日期排列以确定首次和最后价格
+	historyQuery := s.db.Where("price_date BETWEEN ? AND ?", start, end)
+	if req.VendorID != "" {
+		historyQuery = historyQuery.Where("vendor_id = ?", req.VendorID)
+	}
+	if req.ItemID != "" {
+		historyQuery = historyQuery.Where("item_id = ?", req.ItemID)
+	}
+	if req.SourceType != "" {
+		historyQuery = historyQuery.Where("source_type = ?", req.SourceType)
+	}
+	var histories []models.PurchasePriceHistory
+	if err := historyQuery.Order("price_date, created_at").Find(&histories).Error; err != nil {
+		return nil, err
+	}
+
+	// 物料、供应商和目标价
+	var itemIDs, vendorIDs []string
+	seenItems, seenVendors := make(map[string]bool), make(map[string]bool)
+	for _, history := range histories {
+		if !seenItems[history.ItemID] {
+			seenItems[history.ItemID] = true
+			itemIDs = append(itemIDs, history.ItemID)
+		}
+		if !seenVendors[history.VendorID] {
+			seenVendors[history.VendorID] = true
+			vendorIDs = append(vendorIDs, history.VendorID)
+		}
+	}
+	items := make(map[string]models.InventoryItem, len(itemIDs))
+	vendorNames := make(map[string]string, len(vendorIDs))
+	targets := make(map[string]float64, len(itemIDs))
+	if len(histories) > 0 {
+		var itemRows []models.InventoryItem
+		if err := s.db.Unscoped().Where("id IN ?", itemIDs).Find(&itemRows).Error; err != nil {
+			return nil, err
+		}
+		for _, item := range itemRows {
+			items[item.ID] = item
+		}
+		var vendors []models.PurchaseVendor
+		if err := s.db.Unscoped().Select("id, name").Where("id IN ?", vendorIDs).Find(&vendors).Error; err != nil {
+			return nil, err
+		}
+		for _, vendor := range vendors {
+			vendorNames[vendor.ID] = vendor.Name
+		}
+		var targetRows []models.PurchaseTargetPrice
+		if err := s.db.Where("item_id IN ?", itemIDs).Find(&targetRows).Error; err != nil {
+			return nil, err
+		}
+		for _, target := range targetRows {
+			targets[target.ItemID] = target.TargetPrice
+		}
+	}
+
+	byItem := make(map[string][]models.PurchasePriceHistory, len(itemIDs))
+	for _, history := range histories {
+		byItem[history.ItemID] = append(byItem[history.ItemID], history)
+	}
+	response := &schemas.PriceAnalysisReportResponse{
+		Period:   reportPeriod(start.Format("2006-01-02"), end.Format("2006-01-02")),
+		Currency: baseCurrency(),
+		Items:    make([]schemas.PriceAnalysisItem, 0, len(itemIDs)),
+	}
+	for _, itemID := range itemIDs {
+		target, hasTarget := targets[itemID]
+		response.Items = append(response.Items, priceAnalysisItem(items[itemID], byItem[itemID], vendorNames, target, hasTarget))
+	}
+
+	// 采购金额大的物料排在前面，便于优先准备谈判
+	sort.SliceStable(response.Items, func(i, j int) bool {
+		return response.Items[i].TotalAmount > response.Items[j].TotalAmount
+	})
+	return response, nil
+}
+
+func (s *purchaseService) GetPriceHistoryList(params query.Params) (*query.Page[schemas.PriceHistoryResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取采购价格历史
+	var histories []models.PurchasePriceHistory
+	total, err := query.Find(s.db, params, priceHistoryListSpec, &histories, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Vendor").Preload("Item")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]schemas.PriceHistoryResponse, len(histories))
+	for i, history := range histories {
+		response[i] = schemas.PriceHistoryResponse{
+			ID:            history.ID,
+			VendorID:      history.VendorID,
+			VendorName:    history.Vendor.Name,
+			ItemID:        history.ItemID,
+			ItemName:      history.Item.Name,
+			SourceType:    history.SourceType,
+			SourceID:      history.SourceID,
+			SourceItemID:  history.SourceItemID,
+			SourceNo:      history.SourceNo,
+			Quantity:      history.Quantity,
+			UnitPrice:     history.UnitPrice,
+			Currency:      history.Currency,
+			ExchangeRate:  history.ExchangeRate,
+			BaseUnitPrice: history.BaseUnitPrice,
+			PriceDate:     history.PriceDate.Format("2006-01-02"),
+			CreatedAt:     history.CreatedAt.Format("2006-01-02 15:04:05"),
+		}
+	}
+
+	return query.NewPage(response, total, params), nil
+}
+
+func (s *purchaseService) GetTargetPriceList(params query.Params) (*query.Page[schemas.TargetPriceResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取采购目标价
+	var targets []models.PurchaseTargetPrice
+	total, err := query.Find(s.db, params, targetPriceListSpec, &targets, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Item")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]schemas.TargetPriceResponse, len(targets))
+	for i, target := range targets {
+		response[i] = targetPriceResponse(target)
+	}
+
+	return query.NewPage(response, total, params), nil
+}
+
+func (s *purchaseService) SetTargetPrice(itemID string, req schemas.TargetPriceRequest) (*schemas.TargetPriceResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	var item models.InventoryItem
+	if err := s.db.First(&item, "id = ?", itemID).Error; err != nil {
+		return nil, err
+	}
+
+	// 每个物料一条目标价，已存在时更新
+	var target models.PurchaseTargetPrice
+	result := s.db.Where("item_id = ?", itemID).Limit(1).Find(&target)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	now := time.Now()
+	if result.RowsAffected == 0 {
+		target = models.PurchaseTargetPrice{
+			ID:        utils.GenerateID(),
+			ItemID:    itemID,
+			CreatedBy: req.UpdatedBy,
+			CreatedAt: now,
+		}
+	}
+	target.TargetPrice = roundAmount(req.TargetPrice)
+	target.Remarks = req.Remarks
+	target.UpdatedBy = req.UpdatedBy
+	target.UpdatedAt = now
+	if err := s.db.Save(&target).Error; err != nil {
+		return nil, err
+	}
+
+	target.Item = item
+	response := targetPriceResponse(target)
+	return &response, nil
+}
+
+func (s *purchaseService) DeleteTargetPrice(itemID string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	result := s.db.Where("item_id = ?", itemID).Delete(&models.PurchaseTargetPrice{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// recordOrderPriceHistory 采购订单审批时按明细记录价格历史，价格日期为订单日期
+func recordOrderPriceHistory(tx *gorm.DB, order models.PurchaseOrder) error {
+	histories := make([]models.PurchasePriceHistory, 0, len(order.Items))
+	for _, item := range order.Items {
+		histories = append(histories, priceHistory(order.VendorID, item.ItemID, priceSourceOrder, order.ID, item.ID, order.OrderNo,
+			item.Quantity, item.Amount, order.Currency, order.ExchangeRate, order.OrderDate))
+	}
+	return savePriceHistory(tx, priceSourceOrder, order.ID, histories)
+}
+
+// recordInvoicePriceHistory 采购发票审核时按明细的不含税金额记录价格历史，价格日期为发票日期
+func recordInvoicePriceHistory(tx *gorm.DB, invoice models.PurchaseInvoice) error {
+	histories := make([]models.PurchasePriceHistory, 0, len(invoice.Items))
+	for _, item := range invoice.Items {
+		histories = append(histories, priceHistory(invoice.VendorID, item.ItemID, priceSourceInvoice, invoice.ID, item.ID, invoice.InvoiceNo,
+			item.Quantity, purchaseInvoiceItemNetAmount(item), invoice.Currency, invoice.ExchangeRate, invoice.InvoiceDate))
+	}
+	return savePriceHistory(tx, priceSourceInvoice, invoice.ID, histories)
+}
+
+// deletePriceHistory 删除来源单据的价格历史，单据删除时调用
+func deletePriceHistory(tx *gorm.DB, sourceType, sourceID string) error {
+	return tx.Where("source_type = ? AND source_id = ?", sourceType, sourceID).Delete(&models.PurchasePriceHistory{}).Error
+}
+
+// savePriceHistory 替换来源单据的价格历史，数量为零的明细不记录，重复审核时不会重复计入
+func savePriceHistory(tx *gorm.DB, sourceType, sourceID string, histories []models.PurchasePriceHistory) error {
+	if err := deletePriceHistory(tx, sourceType, sourceID); err != nil {
+		return err
+	}
+	rows := histories[:0]
+	for _, history := range histories {
+		if history.Quantity > 0 {
+			rows = append(rows, history)
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.Create(&rows).Error
+}
+
+// priceHistory 构建一行价格历史，单价取不含税的明细金额除以数量即折扣后单价，并按单据汇率折合本位币
+func priceHistory(vendorID, itemID, sourceType, sourceID, sourceItemID, sourceNo string, quantity, amount float64, currency string, rate float64, date time.Time) models.PurchasePriceHistory {
+	if currency == "" {
+		currency = baseCurrency()
+	}
+	if rate <= 0 {
+		rate = 1
+	}
+	var unitPrice float64
+	if quantity > 0 {
+		unitPrice = roundAmount(amount / quantity)
+	}
+	return models.PurchasePriceHistory{
+		ID:            utils.GenerateID(),
+		VendorID:      vendorID,
+		ItemID:        itemID,
+		SourceType:    sourceType,
+		SourceID:      sourceID,
+		SourceItemID:  sourceItemID,
+		SourceNo:      sourceNo,
+		Quantity:      quantity,
+		UnitPrice:     unitPrice,
+		Currency:      currency,
+		ExchangeRate:  rate,
+		BaseUnitPrice: toBaseAmount(unitPrice, rate),
+		PriceDate:     date,
+		CreatedBy:     "system",
+		CreatedAt:     time.Now(),
+	}
+}
+
+// priceStats 一组价格历史的数量、金额和价格统计
+type priceStats struct {
+	count        int
+	quantity     float64
+	amount       float64
+	min          float64
+	max          float64
+	first        float64
+	last         float64
+	lastDate     time.Time
+	lastVendorID string
+}
+
+// add 计入一行价格历史，调用方按价格日期顺序传入
+func (p *priceStats) add(history models.PurchasePriceHistory) {
+	price := history.BaseUnitPrice
+	if p.count == 0 {
+		p.min, p.max, p.first = price, price, price
+	}
+	p.count++
+	p.quantity += history.Quantity
+	p.amount += price * history.Quantity
+	p.min = math.Min(p.min, price)
+	p.max = math.Max(p.max, price)
+	p.last, p.lastDate, p.lastVendorID = price, history.PriceDate, history.VendorID
+}
+
+// avg 按数量加权的平均单价
+func (p *priceStats) avg() float64 {
+	if p.quantity == 0 {
+		return 0
+	}
+	return roundAmount(p.amount / p.quantity)
+}
+
+// savings 按目标价计算的节约金额，负数表示超支
+func (p *priceStats) savings(target float64) float64 {
+	return roundAmount(target*p.quantity - p.amount)
+}
+
+// priceAnalysisItem 汇总一个物料的价格统计、月度走势和供应商比较
+func priceAnalysisItem(item models.InventoryItem, histories []models.PurchasePriceHistory, vendorNames map[string]string, target float64, hasTarget bool) schemas.PriceAnalysisItem {
+	var total priceStats
+	months := make(map[string]*priceStats)
+	var monthKeys []string
+	vendors := make(map[string]*priceStats)
+	var vendorKeys []string
+	for _, history := range histories {
+		total.add(history)
+		month := history.PriceDate.Format("2006-01")
+		if months[month] == nil {
+			months[month] = &priceStats{}
+			monthKeys = append(monthKeys, month)
+		}
+		months[month].add(history)
+		if vendors[history.VendorID] == nil {
+			vendors[history.VendorID] = &priceStats{}
+			vendorKeys = append(vendorKeys, history.VendorID)
+		}
+		vendors[history.VendorID].add(history)
+	}
+
+	analysis := schemas.PriceAnalysisItem{
+		ItemID:        item.ID,
+		ItemCode:      item.ItemNo,
+		ItemName:      item.Name,
+		RecordCount:   total.count,
+		TotalQuantity: roundQuantity(total.quantity),
+		TotalAmount:   roundAmount(total.amount),
+		MinPrice:      total.min,
+		MaxPrice:      total.max,
+		AvgPrice:      total.avg(),
+		FirstPrice:    total.first,
+		LastPrice:     total.last,
+		LastPriceDate: total.lastDate.Format("2006-01-02"),
+		LastVendorID:  total.lastVendorID,
+		Trend:         make([]schemas.PriceTrendPoint, 0, len(monthKeys)),
+		Vendors:       make([]schemas.VendorPriceComparison, 0, len(vendorKeys)),
+	}
+	if total.first > 0 {
+		analysis.PriceChange = math.Round((total.last-total.first)/total.first*10000) / 100
+	}
+	if hasTarget {
+		savings := total.savings(target)
+		analysis.TargetPrice, analysis.SavingsAmount = &target, &savings
+		if targetAmount := target * total.quantity; targetAmount > 0 {
+			rate := math.Round(savings/targetAmount*10000) / 100
+			analysis.SavingsRate = &rate
+		}
+	}
+
+	sort.Strings(monthKeys)
+	for _, month := range monthKeys {
+		stats := months[month]
+		analysis.Trend = append(analysis.Trend, schemas.PriceTrendPoint{
+			Period:      month,
+			RecordCount: stats.count,
+			Quantity:    roundQuantity(stats.quantity),
+			MinPrice:    stats.min,
+			MaxPrice:    stats.max,
+			AvgPrice:    stats.avg(),
+		})
+	}
+
+	// 供应商按平均价从低到高排列，溢价相对最低平均价计算
+	for _, vendorID := range vendorKeys {
+		stats := vendors[vendorID]
+		comparison := schemas.VendorPriceComparison{
+			VendorID:      vendorID,
+			VendorName:    vendorNames[vendorID],
+			RecordCount:   stats.count,
+			Quantity:      roundQuantity(stats.quantity),
+			MinPrice:      stats.min,
+			MaxPrice:      stats.max,
+			AvgPrice:      stats.avg(),
+			LastPrice:     stats.last,
+			LastPriceDate: stats.lastDate.Format("2006-01-02"),
+		}
+		if hasTarget {
+			savings := stats.savings(target)
+			comparison.SavingsAmount = &savings
+		}
+		analysis.Vendors = append(analysis.Vendors, comparison)
+	}
+	sort.SliceStable(analysis.Vendors, func(i, j int) bool {
+		return analysis.Vendors[i].AvgPrice < analysis.Vendors[j].AvgPrice
+	})
+	if len(analysis.Vendors) > 0 {
+		best := analysis.Vendors[0].AvgPrice
+		for i := range analysis.Vendors {
+			if best > 0 {
+				analysis.Vendors[i].PremiumToBest = math.Round((analysis.Vendors[i].AvgPrice-best)/best*10000) / 100
+			}
+		}
+	}
+	return analysis
+}
+
+// targetPriceResponse 将采购目标价模型转换为响应格式
+func targetPriceResponse(target models.PurchaseTargetPrice) schemas.TargetPriceResponse {
+	return schemas.TargetPriceResponse{
+		ID:          target.ID,
+		ItemID:      target.ItemID,
+		ItemCode:    target.Item.ItemNo,
+		ItemName:    target.Item.Name,
+		TargetPrice: target.TargetPrice,
+		Remarks:     target.Remarks,
+		CreatedBy:   target.CreatedBy,
+		CreatedAt:   target.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedBy:   target.UpdatedBy,
+		UpdatedAt:   target.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,1020 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/query"
+	"github.com/wu136995/ginx/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 采购申请状态，审批后可转采购订单，按已转订单数量更新为partially_ordered或ordered
+const (
+	requisitionStatusPending          = "pending"
+	requisitionStatusSubmitted        = "submitted"
+	requisitionStatusApproved         = "approved"
+	requisitionStatusRejected         = "rejected"
+	requisitionStatusPartiallyOrdered = "partially_ordered"
+	requisitionStatusOrdered          = "ordered"
+)
+
+// 采购计划状态，审批后可转采购订单，转单状态与采购申请一致
+const (
+	planStatusDraft    = "draft"
+	planStatusApproved = "approved"
+)
+
+// convertibleSourceStatuses 可转采购订单的申请和计划状态
+var convertibleSourceStatuses = []string{requisitionStatusApproved, requisitionStatusPartiallyOrdered}
+
+// lastPurchaseStatuses 取最近采购供应商和价格时计入的采购订单状态
+var lastPurchaseStatuses = []string{"approved", "closed"}
+
+// purchasePlanListSpec 采购计划列表查询白名单
+var purchasePlanListSpec = query.NewSpec("-start_date",
+	query.Text("plan_no"),
+	query.Text("name"),
+	query.Text("status"),
+	query.Number("total_amount"),
+	query.Date("start_date"),
+	query.Date("end_date"),
+	query.Date("created_at"),
+)
+
+// requisitionListSpec 采购申请列表查询白名单
+var requisitionListSpec = query.NewSpec("-application_date",
+	query.Text("requisition_no"),
+	query.Text("department_id"),
+	query.Text("applicant_id"),
+	query.Text("status"),
+	query.Number("estimated_amount"),
+	query.Date("application_date"),
+	query.Date("created_at"),
+)
+
+// 采购计划管理方法
+func (s *purchaseService) GetPurchasePlanList(params query.Params) (*query.Page[schemas.PurchasePlanResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取采购计划数据
+	var plans []models.PurchasePlan
+	total, err := query.Find(s.db, params, purchasePlanListSpec, &plans)
+	if err != nil {
+		return nil, err
+	}
+
+	// 转换为响应结构
+	planList := make([]schemas.PurchasePlanResponse, len(plans))
+	for i, plan := range plans {
+		planList[i] = purchasePlanResponse(plan)
+	}
+
+	return query.NewPage(planList, total, params), nil
+}
+
+func (s *purchaseService) GetPurchasePlanDetail(id string) (*schemas.PurchasePlanResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取采购计划详情及明细
+	var plan models.PurchasePlan
+	result := s.db.Preload("Items").First(&plan, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := purchasePlanResponse(plan)
+	return &response, nil
+}
+
+func (s *purchaseService) CreatePurchasePlan(req schemas.PurchasePlanCreateRequest) (*schemas.PurchasePlanResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 解析日期
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	if endDate.Before(startDate) {
+		return nil, errors.New("end date must not be before start date")
+	}
+
+	plan := models.PurchasePlan{
+		ID:          utils.GenerateID(),
+		PlanNo:      req.PlanNo,
+		Name:        req.Name,
+		Description: req.Description,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Status:      planStatusDraft,
+		CreatedBy:   req.CreatedBy,
+		CreatedAt:   time.Now(),
+		UpdatedBy:   req.CreatedBy,
+		UpdatedAt:   time.Now(),
+	}
+	plan.Items, plan.TotalAmount, err = purchasePlanItems(plan.ID, req.Items, req.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// 保存计划及明细到数据库
+	result := s.db.Create(&plan)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := purchasePlanResponse(plan)
+	return &response, nil
+}
+
+func (s *purchaseService) UpdatePurchasePlan(id string, req schemas.PurchasePlanUpdateRequest) (*schemas.PurchasePlanResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取采购计划
+	var plan models.PurchasePlan
+	result := s.db.Preload("Items").First(&plan, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 审批后的计划不允许修改
+	if plan.Status != planStatusDraft {
+		return nil, fmt.Errorf("purchase plan in status %s cannot be modified", plan.Status)
+	}
+
+	// 更新字段
+	if req.Name != "" {
+		plan.Name = req.Name
+	}
+	if req.Description != "" {
+		plan.Description = req.Description
+	}
+	if req.StartDate != "" {
+		startDate, err := time.Parse("2006-01-02", req.StartDate)
+		if err != nil {
+			return nil, err
+		}
+		plan.StartDate = startDate
+	}
+	if req.EndDate != "" {
+		endDate, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		plan.EndDate = endDate
+	}
+	if plan.EndDate.Before(plan.StartDate) {
+		return nil, errors.New("end date must not be before start date")
+	}
+	plan.UpdatedAt = time.Now()
+	plan.UpdatedBy = req.UpdatedBy
+
+	// 未传明细时只更新计划头
+	if len(req.Items) == 0 {
+		if err := s.db.Omit("Items").Save(&plan).Error; err != nil {
+			return nil, err
+		}
+		response := purchasePlanResponse(plan)
+		return &response, nil
+	}
+
+	// 在同一事务中替换明细并保存计划
+	var err error
+	plan.Items, plan.TotalAmount, err = purchasePlanItems(plan.ID, req.Items, req.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("plan_id = ?", plan.ID).Delete(&models.PurchasePlanItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&plan.Items).Error; err != nil {
+			return err
+		}
+		return tx.Omit("Items").Save(&plan).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := purchasePlanResponse(plan)
+	return &response, nil
+}
+
+func (s *purchaseService) DeletePurchasePlan(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 从数据库读取采购计划
+	var plan models.PurchasePlan
+	result := s.db.First(&plan, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// 已审批的计划可能已转采购订单，不允许删除
+	if plan.Status != planStatusDraft {
+		return fmt.Errorf("purchase plan in status %s cannot be deleted", plan.Status)
+	}
+
+	// 在同一事务中删除计划及明细
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("plan_id = ?", plan.ID).Delete(&models.PurchasePlanItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&plan).Error
+	})
+}
+
+func (s *purchaseService) ApprovePurchasePlan(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 从数据库读取采购计划
+	var plan models.PurchasePlan
+	result := s.db.First(&plan, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if plan.Status != planStatusDraft {
+		return fmt.Errorf("purchase plan in status %s cannot be approved", plan.Status)
+	}
+
+	// 更新状态为approved
+	return s.db.Model(&plan).Updates(map[string]interface{}{
+		"status":     planStatusApproved,
+		"updated_by": "system",
+		"updated_at": time.Now(),
+	}).Error
+}
+
+// 采购申请管理方法
+func (s *purchaseService) GetRequisitionList(params query.Params) (*query.Page[schemas.RequisitionResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取采购申请数据
+	var requisitions []models.PurchaseRequisition
+	total, err := query.Find(s.db, params, requisitionListSpec, &requisitions)
+	if err != nil {
+		return nil, err
+	}
+
+	// 转换为响应结构
+	requisitionList := make([]schemas.RequisitionResponse, len(requisitions))
+	for i, requisition := range requisitions {
+		requisitionList[i] = requisitionResponse(requisition)
+	}
+
+	return query.NewPage(requisitionList, total, params), nil
+}
+
+func (s *purchaseService) GetRequisitionDetail(id string) (*schemas.RequisitionResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取采购申请详情及明细
+	var requisition models.PurchaseRequisition
+	result := s.db.Preload("Items").First(&requisition, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := requisitionResponse(requisition)
+	return &response, nil
+}
+
+func (s *purchaseService) CreateRequisition(req schemas.RequisitionCreateRequest) (*schemas.RequisitionResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 解析日期
+	applicationDate, err := time.Parse("2006-01-02", req.ApplicationDate)
+	if err != nil {
+		return nil, err
+	}
+
+	requisition := models.PurchaseRequisition{
+		ID:              utils.GenerateID(),
+		RequisitionNo:   req.RequisitionNo,
+		DepartmentID:    req.DepartmentID,
+		ApplicantID:     req.ApplicantID,
+		ApplicationDate: applicationDate,
+		Status:          requisitionStatusPending,
+		Reason:          req.Reason,
+		Remarks:         req.Remarks,
+		CreatedBy:       req.CreatedBy,
+		CreatedAt:       time.Now(),
+		UpdatedBy:       req.CreatedBy,
+		UpdatedAt:       time.Now(),
+	}
+	requisition.Items, requisition.EstimatedAmount, err = requisitionItems(requisition.ID, req.Items, req.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// 保存申请及明细到数据库
+	result := s.db.Create(&requisition)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := requisitionResponse(requisition)
+	return &response, nil
+}
+
+func (s *purchaseService) UpdateRequisition(id string, req schemas.RequisitionUpdateRequest) (*schemas.RequisitionResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取采购申请
+	var requisition models.PurchaseRequisition
+	result := s.db.Preload("Items").First(&requisition, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 只有待提交和被驳回的申请可以修改
+	if requisition.Status != requisitionStatusPending && requisition.Status != requisitionStatusRejected {
+		return nil, fmt.Errorf("requisition in status %s cannot be modified", requisition.Status)
+	}
+
+	// 更新字段
+	if req.DepartmentID != "" {
+		requisition.DepartmentID = req.DepartmentID
+	}
+	if req.ApplicantID != "" {
+		requisition.ApplicantID = req.ApplicantID
+	}
+	if req.ApplicationDate != "" {
+		applicationDate, err := time.Parse("2006-01-02", req.ApplicationDate)
+		if err != nil {
+			return nil, err
+		}
+		requisition.ApplicationDate = applicationDate
+	}
+	if req.Reason != "" {
+		requisition.Reason = req.Reason
+	}
+	if req.Remarks != "" {
+		requisition.Remarks = req.Remarks
+	}
+	requisition.UpdatedAt = time.Now()
+	requisition.UpdatedBy = req.UpdatedBy
+
+	// 未传明细时只更新申请头
+	if len(req.Items) == 0 {
+		if err := s.db.Omit("Items").Save(&requisition).Error; err != nil {
+			return nil, err
+		}
+		response := requisitionResponse(requisition)
+		return &response, nil
+	}
+
+	// 在同一事务中替换明细并保存申请
+	var err error
+	requisition.Items, requisition.EstimatedAmount, err = requisitionItems(requisition.ID, req.Items, req.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("requisition_id = ?", requisition.ID).Delete(&models.PurchaseRequisitionItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&requisition.Items).Error; err != nil {
+			return err
+		}
+		return tx.Omit("Items").Save(&requisition).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := requisitionResponse(requisition)
+	return &response, nil
+}
+
+func (s *purchaseService) DeleteRequisition(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 从数据库读取采购申请
+	var requisition models.PurchaseRequisition
+	result := s.db.First(&requisition, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// 已审批的申请可能已转采购订单，不允许删除
+	if requisition.Status != requisitionStatusPending && requisition.Status != requisitionStatusRejected {
+		return fmt.Errorf("requisition in status %s cannot be deleted", requisition.Status)
+	}
+
+	// 在同一事务中删除申请及明细
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("requisition_id = ?", requisition.ID).Delete(&models.PurchaseRequisitionItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&requisition).Error
+	})
+}
+
+func (s *purchaseService) SubmitRequisition(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	return s.changeRequisitionStatus(id, requisitionStatusSubmitted, requisitionStatusPending, requisitionStatusRejected)
+}
+
+func (s *purchaseService) ApproveRequisition(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	return s.changeRequisitionStatus(id, requisitionStatusApproved, requisitionStatusSubmitted)
+}
+
+func (s *purchaseService) RejectRequisition(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	return s.changeRequisitionStatus(id, requisitionStatusRejected, requisitionStatusSubmitted)
+}
+
+// changeRequisitionStatus 校验当前状态后更新采购申请状态
+func (s *purchaseService) changeRequisitionStatus(id string, status string, from ...string) error {
+	var requisition models.PurchaseRequisition
+	result := s.db.First(&requisition, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	allowed := false
+	for _, current := range from {
+		allowed = allowed || requisition.Status == current
+	}
+	if !allowed {
+		return fmt.Errorf("requisition in status %s cannot be changed to %s", requisition.Status, status)
+	}
+
+	return s.db.Model(&requisition).Updates(map[string]interface{}{
+		"status":     status,
+		"updated_by": "system",
+		"updated_at": time.Now(),
+	}).Error
+}
+
+// conversionLine 待转采购订单的申请或计划明细
+type conversionLine struct {
+	RequisitionItemID string
+	PlanItemID        string
+	SourceNo          string
+	ItemID            string
+	VendorID          string
+	WarehouseID       string
+	Quantity          float64
+	UnitPrice         float64
+}
+
+func (s *purchaseService) ConvertToPurchaseOrders(req schemas.PurchaseOrderConversionRequest) ([]schemas.PurchaseOrderResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	if len(req.RequisitionIDs) == 0 && len(req.PlanIDs) == 0 {
+		return nil, errors.New("at least one requisition or purchase plan is required")
+	}
+
+	orderDate := localDate(time.Now())
+	if req.OrderDate != "" {
+		var err error
+		orderDate, err = time.Parse("2006-01-02", req.OrderDate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 收集已审批申请和计划中未转订单的明细
+	var lines []conversionLine
+	if len(req.RequisitionIDs) > 0 {
+		var requisitions []models.PurchaseRequisition
+		if err := s.db.Preload("Items").Where("id IN ?", req.RequisitionIDs).Find(&requisitions).Error; err != nil {
+			return nil, err
+		}
+		if len(requisitions) != len(req.RequisitionIDs) {
+			return nil, errors.New("some requisitions do not exist")
+		}
+		for _, requisition := range requisitions {
+			if !isConvertibleSource(requisition.Status) {
+				return nil, fmt.Errorf("requisition %s in status %s cannot be converted", requisition.RequisitionNo, requisition.Status)
+			}
+			for _, item := range requisition.Items {
+				if remaining := roundQuantity(item.Quantity - item.OrderedQuantity); remaining > 0 {
+					lines = append(lines, conversionLine{
+						RequisitionItemID: item.ID,
+						SourceNo:          requisition.RequisitionNo,
+						ItemID:            item.ItemID,
+						VendorID:          item.VendorID,
+						WarehouseID:       item.WarehouseID,
+						Quantity:          remaining,
+						UnitPrice:         item.EstimatedPrice,
+					})
+				}
+			}
+		}
+	}
+	if len(req.PlanIDs) > 0 {
+		var plans []models.PurchasePlan
+		if err := s.db.Preload("Items").Where("id IN ?", req.PlanIDs).Find(&plans).Error; err != nil {
+			return nil, err
+		}
+		if len(plans) != len(req.PlanIDs) {
+			return nil, errors.New("some purchase plans do not exist")
+		}
+		for _, plan := range plans {
+			if !isConvertibleSource(plan.Status) {
+				return nil, fmt.Errorf("purchase plan %s in status %s cannot be converted", plan.PlanNo, plan.Status)
+			}
+			for _, item := range plan.Items {
+				if remaining := roundQuantity(item.Quantity - item.OrderedQuantity); remaining > 0 {
+					lines = append(lines, conversionLine{
+						PlanItemID: item.ID,
+						SourceNo:   plan.PlanNo,
+						ItemID:     item.ItemID,
+						VendorID:   item.VendorID,
+						Quantity:   remaining,
+						UnitPrice:  item.EstimatedPrice,
+					})
+				}
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("no remaining quantity to convert")
+	}
+
+	// 按建议供应商、请求指定的供应商、最近采购供应商的顺序确定供应商，并按供应商分组；明细未指定收货仓库时取请求的仓库
+	var vendorIDs []string
+	groups := make(map[string][]conversionLine)
+	for _, line := range lines {
+		if line.WarehouseID == "" {
+			line.WarehouseID = req.WarehouseID
+		}
+		if line.VendorID == "" {
+			line.VendorID = req.VendorID
+		}
+		lastVendorID, lastPrice, err := lastPurchase(s.db, line.ItemID, line.VendorID)
+		if err != nil {
+			return nil, err
+		}
+		if line.VendorID == "" {
+			line.VendorID = lastVendorID
+		}
+		if line.VendorID == "" {
+			return nil, fmt.Errorf("no vendor for item %s of %s", line.ItemID, line.SourceNo)
+		}
+		if line.UnitPrice == 0 && lastVendorID == line.VendorID {
+			line.UnitPrice = lastPrice
+		}
+		if _, ok := groups[line.VendorID]; !ok {
+			vendorIDs = append(vendorIDs, line.VendorID)
+		}
+		groups[line.VendorID] = append(groups[line.VendorID], line)
+	}
+
+	var orders []models.PurchaseOrder
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		requisitionIDs := make(map[string]bool)
+		planIDs := make(map[string]bool)
+		for _, vendorID := range vendorIDs {
+			var vendor models.PurchaseVendor
+			if err := tx.First(&vendor, "id = ?", vendorID).Error; err != nil {
+				return err
+			}
+			if vendor.Status != "active" {
+				return fmt.Errorf("vendor %s in status %s cannot receive purchase orders", vendor.Name, vendor.Status)
+			}
+
+			// 按供应商前置时间确定交货日期，付款条件取供应商默认值
+			deliveryDate := orderDate.AddDate(0, 0, vendor.LeadTime)
+			order := models.PurchaseOrder{
+				ID:           utils.GenerateID(),
+				OrderNo:      autoJournalNo("PO"),
+				VendorID:     vendor.ID,
+				OrderDate:    orderDate,
+				DeliveryDate: &deliveryDate,
+				Status:       "pending",
+				PaymentTerms: vendor.PaymentTerms,
+				CreatedBy:    req.CreatedBy,
+				CreatedAt:    time.Now(),
+				UpdatedBy:    req.CreatedBy,
+				UpdatedAt:    time.Now(),
+			}
+			var sourceNos []string
+			for _, line := range groups[vendorID] {
+				amount := roundAmount(line.Quantity * line.UnitPrice)
+				order.Items = append(order.Items, models.PurchaseOrderItem{
+					ID:                utils.GenerateID(),
+					OrderID:           order.ID,
+					ItemID:            line.ItemID,
+					Quantity:          line.Quantity,
+					UnitPrice:         line.UnitPrice,
+					Amount:            amount,
+					WarehouseID:       line.WarehouseID,
+					RequisitionItemID: line.RequisitionItemID,
+					PlanItemID:        line.PlanItemID,
+					CreatedBy:         req.CreatedBy,
+					CreatedAt:         time.Now(),
+					UpdatedBy:         req.CreatedBy,
+					UpdatedAt:         time.Now(),
+				})
+				order.TotalAmount += amount
+				if !containsString(sourceNos, line.SourceNo) {
+					sourceNos = append(sourceNos, line.SourceNo)
+				}
+			}
+			order.Remarks = "Generated from " + strings.Join(sourceNos, ", ")
+
+			var err error
+			order.Currency, order.ExchangeRate, err = documentCurrency(tx, "", vendor.Currency, 0, orderDate)
+			if err != nil {
+				return err
+			}
+			order.BaseTotalAmount = toBaseAmount(order.TotalAmount, order.ExchangeRate)
+			if err := tx.Create(&order).Error; err != nil {
+				return err
+			}
+
+			// 记录来源明细的已转订单数量
+			for _, item := range order.Items {
+				if err := adjustSourceOrderedQuantity(tx, item, item.Quantity); err != nil {
+					return err
+				}
+				if item.RequisitionItemID != "" {
+					requisitionIDs[item.RequisitionItemID] = true
+				}
+				if item.PlanItemID != "" {
+					planIDs[item.PlanItemID] = true
+				}
+			}
+			orders = append(orders, order)
+		}
+		return refreshConversionSources(tx, mapKeys(requisitionIDs), mapKeys(planIDs))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]schemas.PurchaseOrderResponse, len(orders))
+	for i, order := range orders {
+		response[i] = purchaseOrderResponse(order)
+	}
+	return response, nil
+}
+
+// releasePurchaseOrderSources 删除采购订单或替换明细时退回来源申请和计划明细的已转订单数量
+func releasePurchaseOrderSources(tx *gorm.DB, items []models.PurchaseOrderItem) error {
+	var requisitionItemIDs, planItemIDs []string
+	for _, item := range items {
+		if item.RequisitionItemID == "" && item.PlanItemID == "" {
+			continue
+		}
+		if err := adjustSourceOrderedQuantity(tx, item, -item.Quantity); err != nil {
+			return err
+		}
+		if item.RequisitionItemID != "" {
+			requisitionItemIDs = append(requisitionItemIDs, item.RequisitionItemID)
+		}
+		if item.PlanItemID != "" {
+			planItemIDs = append(planItemIDs, item.PlanItemID)
+		}
+	}
+	if len(requisitionItemIDs) == 0 && len(planItemIDs) == 0 {
+		return nil
+	}
+	return refreshConversionSources(tx, requisitionItemIDs, planItemIDs)
+}
+
+// adjustSourceOrderedQuantity 锁定来源申请或计划明细后调整已转订单数量，转单后的已转订单数量不能超过明细数量，
+// 防止并发或重复转单超量下单
+func adjustSourceOrderedQuantity(tx *gorm.DB, item models.PurchaseOrderItem, quantity float64) error {
+	if item.RequisitionItemID != "" {
+		var source models.PurchaseRequisitionItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&source, "id = ?", item.RequisitionItemID).Error; err != nil {
+			return err
+		}
+		ordered := roundQuantity(source.OrderedQuantity + quantity)
+		if quantity > 0 && ordered > source.Quantity {
+			return fmt.Errorf("requisition item %s ordered quantity %.4f would exceed requested quantity %.4f",
+				source.ID, ordered, source.Quantity)
+		}
+		if err := tx.Model(&source).Update("ordered_quantity", ordered).Error; err != nil {
+			return err
+		}
+	}
+	if item.PlanItemID != "" {
+		var source models.PurchasePlanItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&source, "id = ?", item.PlanItemID).Error; err != nil {
+			return err
+		}
+		ordered := roundQuantity(source.OrderedQuantity + quantity)
+		if quantity > 0 && ordered > source.Quantity {
+			return fmt.Errorf("purchase plan item %s ordered quantity %.4f would exceed planned quantity %.4f",
+				source.ID, ordered, source.Quantity)
+		}
+		if err := tx.Model(&source).Update("ordered_quantity", ordered).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refreshConversionSources 按明细已转订单数量重新确定来源申请和计划的状态
+func refreshConversionSources(tx *gorm.DB, requisitionItemIDs, planItemIDs []string) error {
+	if len(requisitionItemIDs) > 0 {
+		var requisitions []models.PurchaseRequisition
+		err := tx.Preload("Items").
+			Where("id IN (?)", tx.Model(&models.PurchaseRequisitionItem{}).Select("requisition_id").Where("id IN ?", requisitionItemIDs)).
+			Find(&requisitions).Error
+		if err != nil {
+			return err
+		}
+		for _, requisition := range requisitions {
+			quantities := make([][2]float64, len(requisition.Items))
+			for i, item := range requisition.Items {
+				quantities[i] = [2]float64{item.Quantity, item.OrderedQuantity}
+			}
+			status := conversionStatus(quantities)
+			if status != requisition.Status {
+				if err := tx.Model(&requisition).Update("status", status).Error; err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if len(planItemIDs) > 0 {
+		var plans []models.PurchasePlan
+		err := tx.Preload("Items").
+			Where("id IN (?)", tx.Model(&models.PurchasePlanItem{}).Select("plan_id").Where("id IN ?", planItemIDs)).
+			Find(&plans).Error
+		if err != nil {
+			return err
+		}
+		for _, plan := range plans {
+			quantities := make([][2]float64, len(plan.Items))
+			for i, item := range plan.Items {
+				quantities[i] = [2]float64{item.Quantity, item.OrderedQuantity}
+			}
+			status := conversionStatus(quantities)
+			if status != plan.Status {
+				if err := tx.Model(&plan).Update("status", status).Error; err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// conversionStatus 按明细数量和已转订单数量确定转单状态：全部转完为ordered，部分转单为partially_ordered，否则为approved
+func conversionStatus(quantities [][2]float64) string {
+	ordered, complete := false, true
+	for _, quantity := range quantities {
+		if quantity[1] > 0 {
+			ordered = true
+		}
+		if roundQuantity(quantity[0]-quantity[1]) > 0 {
+			complete = false
+		}
+	}
+	switch {
+	case ordered && complete:
+		return requisitionStatusOrdered
+	case ordered:
+		return requisitionStatusPartiallyOrdered
+	default:
+		return requisitionStatusApproved
+	}
+}
+
+// isConvertibleSource 判断申请或计划是否可转采购订单
+func isConvertibleSource(status string) bool {
+	return containsString(convertibleSourceStatuses, status)
+}
+
+// lastPurchase 取物料最近一次采购的供应商，以及指定供应商（未指定时为最近供应商）最近一次的采购单价
+func lastPurchase(db *gorm.DB, itemID, vendorID string) (string, float64, error) {
+	var row struct {
+		VendorID  string
+		UnitPrice float64
+	}
+	lastQuery := db.Model(&models.PurchaseOrderItem{}).
+		Select("purchase_orders.vendor_id, purchase_order_items.unit_price").
+		Joins("JOIN purchase_orders ON purchase_orders.id = purchase_order_items.order_id AND purchase_orders.deleted_at IS NULL").
+		Where("purchase_order_items.item_id = ? AND purchase_orders.status IN ?", itemID, lastPurchaseStatuses)
+	if vendorID != "" {
+		lastQuery = lastQuery.Where("purchase_orders.vendor_id = ?", vendorID)
+	}
+	if err := lastQuery.Order("purchase_orders.order_date DESC").Limit(1).Scan(&row).Error; err != nil {
+		return "", 0, err
+	}
+	return row.VendorID, row.UnitPrice, nil
+}
+
+// containsString 判断字符串切片是否包含指定值
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// localDate 返回时间所在本地日期的零点；time.Truncate按UTC截断，非UTC时区下会得到前一天或当天的非零点时间
+func localDate(t time.Time) time.Time {
+	year, month, day := t.In(time.Local).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+// mapKeys 返回集合中的键
+func mapKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// purchasePlanItems 由请求构造采购计划明细，返回明细及计划总金额
+func purchasePlanItems(planID string, items []schemas.PurchasePlanItemRequest, operator string) ([]models.PurchasePlanItem, float64, error) {
+	planItems := make([]models.PurchasePlanItem, len(items))
+	var total float64
+	for i, item := range items {
+		needDate, err := time.Parse("2006-01-02", item.NeedDate)
+		if err != nil {
+			return nil, 0, err
+		}
+		amount := roundAmount(item.Quantity * item.EstimatedPrice)
+		planItems[i] = models.PurchasePlanItem{
+			ID:              utils.GenerateID(),
+			PlanID:          planID,
+			ItemID:          item.ItemID,
+			VendorID:        item.VendorID,
+			Quantity:        item.Quantity,
+			EstimatedPrice:  item.EstimatedPrice,
+			EstimatedAmount: amount,
+			NeedDate:        needDate,
+			CreatedBy:       operator,
+			CreatedAt:       time.Now(),
+			UpdatedBy:       operator,
+			UpdatedAt:       time.Now(),
+		}
+		total += amount
+	}
+	return planItems, roundAmount(total), nil
+}
+
+// requisitionItems 由请求构造采购申请明细，返回明细及申请预估总金额
+func requisitionItems(requisitionID string, items []schemas.RequisitionItemRequest, operator string) ([]models.PurchaseRequisitionItem, float64, error) {
+	requisitionItems := make([]models.PurchaseRequisitionItem, len(items))
+	var total float64
+	for i, item := range items {
+		requiredDate, err := time.Parse("2006-01-02", item.RequiredDate)
+		if err != nil {
+			return nil, 0, err
+		}
+		amount := roundAmount(item.Quantity * item.EstimatedPrice)
+		requisitionItems[i] = models.PurchaseRequisitionItem{
+			ID:              utils.GenerateID(),
+			RequisitionID:   requisitionID,
+			ItemID:          item.ItemID,
+			VendorID:        item.VendorID,
+			WarehouseID:     item.WarehouseID,
+			Quantity:        item.Quantity,
+			EstimatedPrice:  item.EstimatedPrice,
+			EstimatedAmount: amount,
+			RequiredDate:    requiredDate,
+			Purpose:         item.Purpose,
+			CreatedBy:       operator,
+			CreatedAt:       time.Now(),
+			UpdatedBy:       operator,
+			UpdatedAt:       time.Now(),
+		}
+		total += amount
+	}
+	return requisitionItems, roundAmount(total), nil
+}
+
+// purchasePlanResponse 将采购计划模型转换为响应结构
+func purchasePlanResponse(plan models.PurchasePlan) schemas.PurchasePlanResponse {
+	response := schemas.PurchasePlanResponse{
+		ID:          plan.ID,
+		PlanNo:      plan.PlanNo,
+		Name:        plan.Name,
+		Description: plan.Description,
+		StartDate:   plan.StartDate.Format("2006-01-02"),
+		EndDate:     plan.EndDate.Format("2006-01-02"),
+		TotalAmount: plan.TotalAmount,
+		Status:      plan.Status,
+		Items:       make([]schemas.PurchasePlanItemResponse, len(plan.Items)),
+		CreatedBy:   plan.CreatedBy,
+		CreatedAt:   plan.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedBy:   plan.UpdatedBy,
+		UpdatedAt:   plan.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+	for i, item := range plan.Items {
+		response.Items[i] = schemas.PurchasePlanItemResponse{
+			ID:                item.ID,
+			PlanID:            item.PlanID,
+			ItemID:            item.ItemID,
+			VendorID:          item.VendorID,
+			Quantity:          item.Quantity,
+			OrderedQuantity:   item.OrderedQuantity,
+			RemainingQuantity: roundQuantity(item.Quantity - item.OrderedQuantity),
+			EstimatedPrice:    item.EstimatedPrice,
+			EstimatedAmount:   item.EstimatedAmount,
+			NeedDate:          item.NeedDate.Format("2006-01-02"),
+		}
+	}
+	return response
+}
+
+// requisitionResponse 将采购申请模型转换为响应结构
+func requisitionResponse(requisition models.PurchaseRequisition) schemas.RequisitionResponse {
+	response := schemas.RequisitionResponse{
+		ID:              requisition.ID,
+		RequisitionNo:   requisition.RequisitionNo,
+		DepartmentID:    requisition.DepartmentID,
+		ApplicantID:     requisition.ApplicantID,
+		ApplicationDate: requisition.ApplicationDate.Format("2006-01-02"),
+		EstimatedAmount: requisition.EstimatedAmount,
+		Status:          requisition.Status,
+		Reason:          requisition.Reason,
+		Items:           make([]schemas.RequisitionItemResponse, len(requisition.Items)),
+		Remarks:         requisition.Remarks,
+		CreatedBy:       requisition.CreatedBy,
+		CreatedAt:       requisition.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedBy:       requisition.UpdatedBy,
+		UpdatedAt:       requisition.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+	for i, item := range requisition.Items {
+		response.Items[i] = schemas.RequisitionItemResponse{
+			ID:                item.ID,
+			RequisitionID:     item.RequisitionID,
+			ItemID:            item.ItemID,
+			VendorID:          item.VendorID,
+			WarehouseID:       item.WarehouseID,
+			Quantity:          item.Quantity,
+			OrderedQuantity:   item.OrderedQuantity,
+			RemainingQuantity: roundQuantity(item.Quantity - item.OrderedQuantity),
+			EstimatedPrice:    item.EstimatedPrice,
+			EstimatedAmount:   item.EstimatedAmount,
+			RequiredDate:      item.RequiredDate.Format("2006-01-02"),
+			Purpose:           item.Purpose,
+		}
+	}
+	return response
+}
@@ -0,0 +1,401 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/config"
+	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/query"
+	"github.com/wu136995/ginx/internal/utils"
+	"gorm.io/gorm"
+)
+
+// scorecardDefaultMonths 未指定评估期间时默认评估截至当天的最近月数
+const scorecardDefaultMonths = 3
+
+// supplierScorecardListSpec 供应商历史评分查询白名单
+var supplierScorecardListSpec = query.NewSpec("-period_end",
+	query.Date("period_start"),
+	query.Date("period_end"),
+	query.Number("on_time_rate"),
+	query.Number("quality_reject_rate"),
+	query.Number("price_variance"),
+	query.Number("lead_time_accuracy"),
+	query.Number("overall_rating"),
+	query.Date("created_at"),
+)
+
+// 供应商评分卡方法
+func (s *purchaseService) GetSupplierAnalysisReport(req schemas.PurchaseReportRequest) (*schemas.SupplierAnalysisReportResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	start, end, err := reportWindow(req.StartDate, req.EndDate, scorecardDefaultMonths)
+	if err != nil {
+		return nil, err
+	}
+
+	// 按期间实时计算评分，不保存
+	cards, vendorNames, err := evaluateSupplierScorecards(s.db, start, end, req.VendorID)
+	if err != nil {
+		return nil, err
+	}
+
+	suppliers, err := rankedScorecardResponses(s.db, cards, vendorNames)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schemas.SupplierAnalysisReportResponse{
+		Period:    reportPeriod(start.Format("2006-01-02"), end.Format("2006-01-02")),
+		Weights:   scorecardWeights(),
+		Suppliers: suppliers,
+	}, nil
+}
+
+func (s *purchaseService) EvaluateSupplierScorecards(req schemas.SupplierScorecardEvaluateRequest) ([]schemas.SupplierScorecardResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	start, end, err := reportWindow(req.StartDate, req.EndDate, scorecardDefaultMonths)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, vendorNames, err := evaluateSupplierScorecards(s.db, start, end, req.VendorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 同一供应商同一期间重复评估时覆盖已保存的评分
+	now := time.Now()
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		for i := range cards {
+			var existing models.PurchaseVendorScorecard
+			err := tx.Where("vendor_id = ? AND period_start = ? AND period_end = ?", cards[i].VendorID, start, end).First(&existing).Error
+			switch {
+			case err == nil:
+				cards[i].ID = existing.ID
+				cards[i].CreatedBy = existing.CreatedBy
+				cards[i].CreatedAt = existing.CreatedAt
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				cards[i].ID = utils.GenerateID()
+				cards[i].CreatedBy = req.CreatedBy
+				cards[i].CreatedAt = now
+			default:
+				return err
+			}
+			cards[i].UpdatedBy = req.CreatedBy
+			cards[i].UpdatedAt = now
+			if err := tx.Save(&cards[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rankedScorecardResponses(s.db, cards, vendorNames)
+}
+
+func (s *purchaseService) GetSupplierScorecardHistory(vendorID string, params query.Params) (*query.Page[schemas.SupplierScorecardResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	var vendor models.PurchaseVendor
+	if err := s.db.First(&vendor, "id = ?", vendorID).Error; err != nil {
+		return nil, err
+	}
+
+	// 从数据库读取该供应商的历史评分
+	var cards []models.PurchaseVendorScorecard
+	total, err := query.Find(s.db, params, supplierScorecardListSpec, &cards, func(db *gorm.DB) *gorm.DB {
+		return db.Where("vendor_id = ?", vendorID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]schemas.SupplierScorecardResponse, len(cards))
+	for i, card := range cards {
+		responses[i] = supplierScorecardResponse(card, vendor.Name)
+	}
+
+	return query.NewPage(responses, total, params), nil
+}
+
+// reportWindow 按本地时区解析报表期间，与单据日期的时区一致；未指定截止日期时取当天，未指定开始日期时取截止日期前months个月
+func reportWindow(startDate, endDate string, months int) (time.Time, time.Time, error) {
+	end := localDate(time.Now())
+	if endDate != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", endDate, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		end = parsed
+	}
+	start := end.AddDate(0, -months, 0).AddDate(0, 0, 1)
+	if startDate != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", startDate, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		start = parsed
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, errors.New("end date must not be before start date")
+	}
+	return start, end, nil
+}
+
+// evaluateSupplierScorecards 按期间计算供应商评分卡，返回评分和供应商名称。
+// 准时率按期间内已完成收货单的收货日期与订单交货日期（未填写时为下单日期加供应商交货周期）加宽限天数比较；
+// 质量退货率为期间内已完成退货数量占收货数量的比例；价格偏差为期间内已审核发票按订单折扣后单价计算的超付金额占比；
+// 交期准确率为100减去实际交货周期与承诺交货周期平均相对偏差的百分比。未指定供应商时只返回期间内有收货、退货或发票的供应商
+func evaluateSupplierScorecards(db *gorm.DB, start, end time.Time, vendorID string) ([]models.PurchaseVendorScorecard, map[string]string, error) {
+	cfg := config.GetAppConfig().Purchase
+
+	var vendors []models.PurchaseVendor
+	vendorQuery := db.Order("vendor_no")
+	if vendorID != "" {
+		vendorQuery = vendorQuery.Where("id = ?", vendorID)
+	}
+	if err := vendorQuery.Find(&vendors).Error; err != nil {
+		return nil, nil, err
+	}
+	if vendorID != "" && len(vendors) == 0 {
+		return nil, nil, gorm.ErrRecordNotFound
+	}
+
+	cards := make(map[string]*models.PurchaseVendorScorecard, len(vendors))
+	vendorNames := make(map[string]string, len(vendors))
+	leadTimeDeviation := make(map[string]float64, len(vendors))
+	for _, vendor := range vendors {
+		cards[vendor.ID] = &models.PurchaseVendorScorecard{VendorID: vendor.ID, PeriodStart: start, PeriodEnd: end}
+		vendorNames[vendor.ID] = vendor.Name
+	}
+	scoped := func(tx *gorm.DB, column string) *gorm.DB {
+		if vendorID != "" {
+			return tx.Where(column+" = ?", vendorID)
+		}
+		return tx
+	}
+
+	// 准时交货和交货周期
+	var receipts []struct {
+		VendorID      string
+		ReceiptDate   time.Time
+		TotalQuantity float64
+		OrderDate     time.Time
+		DeliveryDate  *time.Time
+		LeadTime      int
+	}
+	receiptQuery := db.Model(&models.PurchaseReceipt{}).
+		Select("purchase_receipts.vendor_id, purchase_receipts.receipt_date, purchase_receipts.total_quantity, purchase_orders.order_date, purchase_orders.delivery_date, purchase_vendors.lead_time").
+		Joins("JOIN purchase_orders ON purchase_orders.id = purchase_receipts.order_id AND purchase_orders.deleted_at IS NULL").
+		Joins("JOIN purchase_vendors ON purchase_vendors.id = purchase_receipts.vendor_id").
+		Where("purchase_receipts.status = ? AND purchase_receipts.receipt_date BETWEEN ? AND ?", "completed", start, end)
+	if err := scoped(receiptQuery, "purchase_receipts.vendor_id").Scan(&receipts).Error; err != nil {
+		return nil, nil, err
+	}
+	for _, receipt := range receipts {
+		card, ok := cards[receipt.VendorID]
+		if !ok {
+			continue
+		}
+		promised := receipt.OrderDate.AddDate(0, 0, receipt.LeadTime)
+		if receipt.DeliveryDate != nil {
+			promised = *receipt.DeliveryDate
+		}
+		if !receipt.ReceiptDate.After(promised.AddDate(0, 0, cfg.OnTimeGraceDays)) {
+			card.OnTimeCount++
+		}
+		actualDays := receipt.ReceiptDate.Sub(receipt.OrderDate).Hours() / 24
+		promisedDays := promised.Sub(receipt.OrderDate).Hours() / 24
+		card.ReceiptCount++
+		card.ReceivedQuantity += receipt.TotalQuantity
+		card.AvgLeadTimeDays += actualDays
+		card.PromisedLeadTimeDays += promisedDays
+		leadTimeDeviation[receipt.VendorID] += math.Abs(actualDays-promisedDays) / math.Max(promisedDays, 1)
+	}
+
+	// 质量退货
+	var returns []struct {
+		VendorID string
+		Quantity float64
+	}
+	returnQuery := db.Model(&models.PurchaseReturn{}).
+		Select("vendor_id, SUM(total_quantity) AS quantity").
+		Where("status = ? AND return_date BETWEEN ? AND ?", "completed", start, end)
+	if err := scoped(returnQuery, "vendor_id").Group("vendor_id").Scan(&returns).Error; err != nil {
+		return nil, nil, err
+	}
+	for _, row := range returns {
+		if card, ok := cards[row.VendorID]; ok {
+			card.ReturnedQuantity += row.Quantity
+		}
+	}
+
+	// 发票价格偏差，按订单折扣后单价计算应付金额，与发票不含税金额比较
+	var invoiceItems []struct {
+		VendorID      string
+		Quantity      float64
+		Amount        float64
+		NetAmount     float64
+		TaxAmount     float64
+		UnitPrice     float64
+		OrderQuantity float64
+		OrderAmount   float64
+	}
+	invoiceQuery := db.Model(&models.PurchaseInvoiceItem{}).
+		Select("purchase_invoices.vendor_id, purchase_invoice_items.quantity, purchase_invoice_items.amount, purchase_invoice_items.net_amount, purchase_invoice_items.tax_amount, purchase_order_items.unit_price, purchase_order_items.quantity AS order_quantity, purchase_order_items.amount AS order_amount").
+		Joins("JOIN purchase_invoices ON purchase_invoices.id = purchase_invoice_items.invoice_id AND purchase_invoices.deleted_at IS NULL").
+		Joins("JOIN purchase_order_items ON purchase_order_items.id = purchase_invoice_items.order_item_id").
+		Where("purchase_invoices.status IN ? AND purchase_invoices.invoice_date BETWEEN ? AND ?", invoicedQuantityStatuses, start, end)
+	if err := scoped(invoiceQuery, "purchase_invoices.vendor_id").Scan(&invoiceItems).Error; err != nil {
+		return nil, nil, err
+	}
+	for _, item := range invoiceItems {
+		card, ok := cards[item.VendorID]
+		if !ok {
+			continue
+		}
+		expectedPrice := item.UnitPrice
+		if item.OrderQuantity > 0 {
+			expectedPrice = item.OrderAmount / item.OrderQuantity
+		}
+		expected := expectedPrice * item.Quantity
+		card.InvoicedAmount += expected
+		net := purchaseInvoiceItemNetAmount(models.PurchaseInvoiceItem{Amount: item.Amount, NetAmount: item.NetAmount, TaxAmount: item.TaxAmount})
+		card.PriceVarianceAmount += net - expected
+	}
+
+	result := make([]models.PurchaseVendorScorecard, 0, len(cards))
+	for _, vendor := range vendors {
+		card := cards[vendor.ID]
+		if vendorID == "" && card.ReceiptCount == 0 && card.ReturnedQuantity == 0 && card.InvoicedAmount == 0 {
+			continue
+		}
+		if card.ReceiptCount > 0 {
+			count := float64(card.ReceiptCount)
+			card.OnTimeRate = roundAmount(float64(card.OnTimeCount) / count * 100)
+			card.AvgLeadTimeDays = roundAmount(card.AvgLeadTimeDays / count)
+			card.PromisedLeadTimeDays = roundAmount(card.PromisedLeadTimeDays / count)
+			card.LeadTimeAccuracy = roundAmount(math.Max(100-leadTimeDeviation[vendor.ID]/count*100, 0))
+		}
+		if card.ReceivedQuantity > 0 {
+			card.QualityRejectRate = roundAmount(card.ReturnedQuantity / card.ReceivedQuantity * 100)
+		}
+		if card.InvoicedAmount > 0 {
+			card.PriceVariance = roundAmount(card.PriceVarianceAmount / card.InvoicedAmount * 100)
+		}
+		card.ReceivedQuantity = roundQuantity(card.ReceivedQuantity)
+		card.ReturnedQuantity = roundQuantity(card.ReturnedQuantity)
+		card.InvoicedAmount = roundAmount(card.InvoicedAmount)
+		card.PriceVarianceAmount = roundAmount(card.PriceVarianceAmount)
+		card.OverallRating = scorecardRating(*card, cfg.ScorecardWeights)
+		result = append(result, *card)
+	}
+	return result, vendorNames, nil
+}
+
+// scorecardRating 按配置权重计算综合评分，只对期间内有数据的指标加权：
+// 准时得分为准时率，质量得分为100减退货率，价格得分为100减超付百分比（低于订单价不加分），交期得分为交期准确率
+func scorecardRating(card models.PurchaseVendorScorecard, weights config.ScorecardWeights) float64 {
+	var score, total float64
+	if card.ReceiptCount > 0 {
+		score += card.OnTimeRate*weights.OnTime + card.LeadTimeAccuracy*weights.LeadTime
+		total += weights.OnTime + weights.LeadTime
+	}
+	if card.ReceivedQuantity > 0 {
+		score += math.Max(100-card.QualityRejectRate, 0) * weights.Quality
+		total += weights.Quality
+	}
+	if card.InvoicedAmount > 0 {
+		score += math.Min(math.Max(100-card.PriceVariance, 0), 100) * weights.Price
+		total += weights.Price
+	}
+	if total <= 0 {
+		return 0
+	}
+	return roundAmount(score / total)
+}
+
+// scorecardWeights 返回当前配置的评分权重
+func scorecardWeights() schemas.SupplierScorecardWeights {
+	weights := config.GetAppConfig().Purchase.ScorecardWeights
+	return schemas.SupplierScorecardWeights{
+		OnTime:   weights.OnTime,
+		Quality:  weights.Quality,
+		Price:    weights.Price,
+		LeadTime: weights.LeadTime,
+	}
+}
+
+// rankedScorecardResponses 按综合评分从高到低排名，并附上各供应商本期间之前最近一次保存的评分用于趋势比较
+func rankedScorecardResponses(db *gorm.DB, cards []models.PurchaseVendorScorecard, vendorNames map[string]string) ([]schemas.SupplierScorecardResponse, error) {
+	sort.SliceStable(cards, func(i, j int) bool {
+		return cards[i].OverallRating > cards[j].OverallRating
+	})
+
+	responses := make([]schemas.SupplierScorecardResponse, len(cards))
+	for i, card := range cards {
+		response := supplierScorecardResponse(card, vendorNames[card.VendorID])
+		response.Rank = i + 1
+
+		var previous models.PurchaseVendorScorecard
+		err := db.Where("vendor_id = ? AND period_end < ?", card.VendorID, card.PeriodEnd).
+			Order("period_end DESC, updated_at DESC").First(&previous).Error
+		switch {
+		case err == nil:
+			rating := previous.OverallRating
+			change := roundAmount(card.OverallRating - rating)
+			response.PreviousRating, response.RatingChange = &rating, &change
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, err
+		}
+		responses[i] = response
+	}
+	return responses, nil
+}
+
+// supplierScorecardResponse 将供应商评分卡模型转换为响应格式
+func supplierScorecardResponse(card models.PurchaseVendorScorecard, vendorName string) schemas.SupplierScorecardResponse {
+	response := schemas.SupplierScorecardResponse{
+		ID:                   card.ID,
+		VendorID:             card.VendorID,
+		VendorName:           vendorName,
+		PeriodStart:          card.PeriodStart.Format("2006-01-02"),
+		PeriodEnd:            card.PeriodEnd.Format("2006-01-02"),
+		ReceiptCount:         card.ReceiptCount,
+		OnTimeCount:          card.OnTimeCount,
+		OnTimeRate:           card.OnTimeRate,
+		ReceivedQuantity:     card.ReceivedQuantity,
+		ReturnedQuantity:     card.ReturnedQuantity,
+		QualityRejectRate:    card.QualityRejectRate,
+		InvoicedAmount:       card.InvoicedAmount,
+		PriceVarianceAmount:  card.PriceVarianceAmount,
+		PriceVariance:        card.PriceVariance,
+		AvgLeadTimeDays:      card.AvgLeadTimeDays,
+		PromisedLeadTimeDays: card.PromisedLeadTimeDays,
+		LeadTimeAccuracy:     card.LeadTimeAccuracy,
+		OverallRating:        card.OverallRating,
+		CreatedBy:            card.CreatedBy,
+	}
+	if !card.CreatedAt.IsZero() {
+		response.CreatedAt = card.CreatedAt.Format("2006-01-02 15:04:05")
+	}
+	return response
+}
@@ -2,77 +2,100 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/wu136995/ginx/internal/api/schemas"
 	"github.com/wu136995/ginx/internal/database"
 	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/query"
+	"github.com/wu136995/ginx/internal/utils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // PurchaseService 采购服务接口
 type PurchaseService interface {
 	// 供应商管理
-	GetSupplierList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetSupplierDetail(id string) (map[string]interface{}, error)
-	CreateSupplier(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateSupplier(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetSupplierList(params query.Params) (*query.Page[schemas.SupplierResponse], error)
+	GetSupplierDetail(id string) (*schemas.SupplierResponse, error)
+	CreateSupplier(req schemas.SupplierCreateRequest) (*schemas.SupplierResponse, error)
+	UpdateSupplier(id string, req schemas.SupplierUpdateRequest) (*schemas.SupplierResponse, error)
 	DeleteSupplier(id string) error
-	GetSupplierContacts(id string) ([]map[string]interface{}, error)
-	AddSupplierContact(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetSupplierContacts(id string) ([]schemas.SupplierContactResponse, error)
+	AddSupplierContact(id string, req schemas.SupplierContactCreateRequest) (*schemas.SupplierContactResponse, error)
+
+	// 采购计划管理
+	GetPurchasePlanList(params query.Params) (*query.Page[schemas.PurchasePlanResponse], error)
+	GetPurchasePlanDetail(id string) (*schemas.PurchasePlanResponse, error)
+	CreatePurchasePlan(req schemas.PurchasePlanCreateRequest) (*schemas.PurchasePlanResponse, error)
+	UpdatePurchasePlan(id string, req schemas.PurchasePlanUpdateRequest) (*schemas.PurchasePlanResponse, error)
+	DeletePurchasePlan(id string) error
+	ApprovePurchasePlan(id string) error
+	CreatePurchasePlanFromForecast(req schemas.PurchaseForecastPlanRequest) (*schemas.PurchasePlanResponse, error)
 
 	// 采购申请管理
-	GetRequisitionList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetRequisitionDetail(id string) (map[string]interface{}, error)
-	CreateRequisition(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateRequisition(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetRequisitionList(params query.Params) (*query.Page[schemas.RequisitionResponse], error)
+	GetRequisitionDetail(id string) (*schemas.RequisitionResponse, error)
+	CreateRequisition(req schemas.RequisitionCreateRequest) (*schemas.RequisitionResponse, error)
+	UpdateRequisition(id string, req schemas.RequisitionUpdateRequest) (*schemas.RequisitionResponse, error)
 	DeleteRequisition(id string) error
 	SubmitRequisition(id string) error
 	ApproveRequisition(id string) error
 	RejectRequisition(id string) error
 
 	// 采购订单管理
-	GetPurchaseOrderList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetPurchaseOrderDetail(id string) (map[string]interface{}, error)
-	CreatePurchaseOrder(req map[string]interface{}) (map[string]interface{}, error)
-	UpdatePurchaseOrder(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetPurchaseOrderList(params query.Params) (*query.Page[schemas.PurchaseOrderResponse], error)
+	GetPurchaseOrderDetail(id string) (*schemas.PurchaseOrderResponse, error)
+	CreatePurchaseOrder(req schemas.PurchaseOrderCreateRequest) (*schemas.PurchaseOrderResponse, error)
+	UpdatePurchaseOrder(id string, req schemas.PurchaseOrderUpdateRequest) (*schemas.PurchaseOrderResponse, error)
 	DeletePurchaseOrder(id string) error
 	SubmitPurchaseOrder(id string) error
 	ApprovePurchaseOrder(id string) error
 	RejectPurchaseOrder(id string) error
 	ClosePurchaseOrder(id string) error
+	ConvertToPurchaseOrders(req schemas.PurchaseOrderConversionRequest) ([]schemas.PurchaseOrderResponse, error)
 
 	// 采购收货管理
-	GetPurchaseReceiptList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetPurchaseReceiptDetail(id string) (map[string]interface{}, error)
-	CreatePurchaseReceipt(req map[string]interface{}) (map[string]interface{}, error)
-	UpdatePurchaseReceipt(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetPurchaseReceiptList(params query.Params) (*query.Page[schemas.ReceiptResponse], error)
+	GetPurchaseReceiptDetail(id string) (*schemas.ReceiptResponse, error)
+	CreatePurchaseReceipt(req schemas.ReceiptCreateRequest) (*schemas.ReceiptResponse, error)
+	UpdatePurchaseReceipt(id string, req schemas.ReceiptUpdateRequest) (*schemas.ReceiptResponse, error)
 	DeletePurchaseReceipt(id string) error
 	CompletePurchaseReceipt(id string) error
 
 	// 采购发票管理
-	GetPurchaseInvoiceList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetPurchaseInvoiceDetail(id string) (map[string]interface{}, error)
-	CreatePurchaseInvoice(req map[string]interface{}) (map[string]interface{}, error)
-	UpdatePurchaseInvoice(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetPurchaseInvoiceList(params query.Params) (*query.Page[schemas.PurchaseInvoiceResponse], error)
+	GetPurchaseInvoiceDetail(id string) (*schemas.PurchaseInvoiceResponse, error)
+	CreatePurchaseInvoice(req schemas.PurchaseInvoiceCreateRequest) (*schemas.PurchaseInvoiceResponse, error)
+	UpdatePurchaseInvoice(id string, req schemas.PurchaseInvoiceUpdateRequest) (*schemas.PurchaseInvoiceResponse, error)
 	DeletePurchaseInvoice(id string) error
 	VerifyPurchaseInvoice(id string) error
-	PayPurchaseInvoice(id string) error
+	ReleaseInvoiceBlock(id, operator string, req schemas.ReleaseInvoiceBlockRequest) error
+	PayPurchaseInvoice(id string, req schemas.PayPurchaseInvoiceRequest) error
 
 	// 采购退货管理
-	GetPurchaseReturnList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetPurchaseReturnDetail(id string) (map[string]interface{}, error)
-	CreatePurchaseReturn(req map[string]interface{}) (map[string]interface{}, error)
-	UpdatePurchaseReturn(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetPurchaseReturnList(params query.Params) (*query.Page[schemas.PurchaseReturnResponse], error)
+	GetPurchaseReturnDetail(id string) (*schemas.PurchaseReturnResponse, error)
+	CreatePurchaseReturn(req schemas.PurchaseReturnCreateRequest) (*schemas.PurchaseReturnResponse, error)
+	UpdatePurchaseReturn(id string, req schemas.PurchaseReturnUpdateRequest) (*schemas.PurchaseReturnResponse, error)
 	DeletePurchaseReturn(id string) error
 	CompletePurchaseReturn(id string) error
 
 	// 采购报表管理
-	GetPurchaseSummaryReport(req map[string]interface{}) (map[string]interface{}, error)
-	GetPurchaseDetailReport(req map[string]interface{}) (map[string]interface{}, error)
-	GetSupplierAnalysisReport(req map[string]interface{}) (map[string]interface{}, error)
-	GetPriceAnalysisReport(req map[string]interface{}) (map[string]interface{}, error)
-	GetPurchaseForecastReport(req map[string]interface{}) (map[string]interface{}, error)
-	ExportPurchaseReport(req map[string]interface{}) ([]byte, error)
+	GetPurchaseSummaryReport(req schemas.PurchaseReportRequest) (*schemas.PurchaseSummaryReportResponse, error)
+	GetPurchaseDetailReport(req schemas.PurchaseReportRequest) (*schemas.PurchaseDetailReportResponse, error)
+	GetSupplierAnalysisReport(req schemas.PurchaseReportRequest) (*schemas.SupplierAnalysisReportResponse, error)
+	EvaluateSupplierScorecards(req schemas.SupplierScorecardEvaluateRequest) ([]schemas.SupplierScorecardResponse, error)
+	GetSupplierScorecardHistory(vendorID string, params query.Params) (*query.Page[schemas.SupplierScorecardResponse], error)
+	GetPriceAnalysisReport(req schemas.PurchaseReportRequest) (*schemas.PriceAnalysisReportResponse, error)
+	GetPriceHistoryList(params query.Params) (*query.Page[schemas.PriceHistoryResponse], error)
+	GetTargetPriceList(params query.Params) (*query.Page[schemas.TargetPriceResponse], error)
+	SetTargetPrice(itemID string, req schemas.TargetPriceRequest) (*schemas.TargetPriceResponse, error)
+	DeleteTargetPrice(itemID string) error
+	GetPurchaseForecastReport(req schemas.PurchaseForecastRequest) (*schemas.PurchaseForecastReportResponse, error)
+	ExportPurchaseReport(req schemas.ExportPurchaseReportRequest) ([]byte, error)
 }
 
 // purchaseService 采购服务实现
@@ -87,8 +110,21 @@ func NewPurchaseService() PurchaseService {
 	}
 }
 
+// supplierListSpec 供应商列表查询白名单
+var supplierListSpec = query.NewSpec("-created_at",
+	query.Text("vendor_no"),
+	query.Text("name"),
+	query.Text("contact_person"),
+	query.Text("phone"),
+	query.Text("category"),
+	query.Text("status"),
+	query.Number("credit_limit"),
+	query.Number("lead_time"),
+	query.Date("created_at"),
+)
+
 // 供应商管理方法
-func (s *purchaseService) GetSupplierList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *purchaseService) GetSupplierList(params query.Params) (*query.Page[schemas.SupplierResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -96,42 +132,21 @@ func (s *purchaseService) GetSupplierList(req map[string]interface{}) ([]map[str
 
 	// 从数据库读取供应商数据
 	var vendors []models.PurchaseVendor
-	result := s.db.Find(&vendors)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, supplierListSpec, &vendors)
+	if err != nil {
+		return nil, err
 	}
 
-	// 将模型转换为map
-	supplierList := make([]map[string]interface{}, len(vendors))
+	// 转换为响应结构
+	supplierList := make([]schemas.SupplierResponse, len(vendors))
 	for i, vendor := range vendors {
-		supplierList[i] = map[string]interface{}{
-			"id":             vendor.ID,
-			"vendor_no":      vendor.VendorNo,
-			"name":           vendor.Name,
-			"contact_person": vendor.ContactPerson,
-			"phone":          vendor.Phone,
-			"email":          vendor.Email,
-			"address":        vendor.Address,
-			"tax_no":         vendor.TaxNo,
-			"bank_name":      vendor.BankName,
-			"bank_account":   vendor.BankAccount,
-			"category":       vendor.Category,
-			"credit_limit":   vendor.CreditLimit,
-			"lead_time":      vendor.LeadTime,
-			"payment_terms":  vendor.PaymentTerms,
-			"remarks":        vendor.Remarks,
-			"status":         vendor.Status,
-			"created_at":     vendor.CreatedAt,
-			"created_by":     vendor.CreatedBy,
-			"updated_at":     vendor.UpdatedAt,
-			"updated_by":     vendor.UpdatedBy,
-		}
+		supplierList[i] = supplierResponse(vendor)
 	}
 
-	return supplierList, nil
+	return query.NewPage(supplierList, total, params), nil
 }
 
-func (s *purchaseService) GetSupplierDetail(id string) (map[string]interface{}, error) {
+func (s *purchaseService) GetSupplierDetail(id string) (*schemas.SupplierResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -144,34 +159,11 @@ func (s *purchaseService) GetSupplierDetail(id string) (map[string]interface{},
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	supplierDetail := map[string]interface{}{
-		"id":             vendor.ID,
-		"vendor_no":      vendor.VendorNo,
-		"name":           vendor.Name,
-		"contact_person": vendor.ContactPerson,
-		"phone":          vendor.Phone,
-		"email":          vendor.Email,
-		"address":        vendor.Address,
-		"tax_no":         vendor.TaxNo,
-		"bank_name":      vendor.BankName,
-		"bank_account":   vendor.BankAccount,
-		"category":       vendor.Category,
-		"credit_limit":   vendor.CreditLimit,
-		"lead_time":      vendor.LeadTime,
-		"payment_terms":  vendor.PaymentTerms,
-		"remarks":        vendor.Remarks,
-		"status":         vendor.Status,
-		"created_at":     vendor.CreatedAt,
-		"created_by":     vendor.CreatedBy,
-		"updated_at":     vendor.UpdatedAt,
-		"updated_by":     vendor.UpdatedBy,
-	}
-
-	return supplierDetail, nil
+	response := supplierResponse(vendor)
+	return &response, nil
 }
 
-func (s *purchaseService) CreateSupplier(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *purchaseService) CreateSupplier(req schemas.SupplierCreateRequest) (*schemas.SupplierResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -179,26 +171,34 @@ func (s *purchaseService) CreateSupplier(req map[string]interface{}) (map[string
 
 	// 从请求中获取数据
 	vendor := models.PurchaseVendor{
-		ID:            req["id"].(string),
-		VendorNo:      req["vendor_no"].(string),
-		Name:          req["name"].(string),
-		ContactPerson: req["contact_person"].(string),
-		Phone:         req["phone"].(string),
-		Email:         req["email"].(string),
-		Address:       req["address"].(string),
-		TaxNo:         req["tax_no"].(string),
-		BankName:      req["bank_name"].(string),
-		BankAccount:   req["bank_account"].(string),
-		Category:      req["category"].(string),
-		CreditLimit:   req["credit_limit"].(float64),
-		LeadTime:      req["lead_time"].(int),
-		PaymentTerms:  req["payment_terms"].(string),
-		Remarks:       req["remarks"].(string),
-		Status:        req["status"].(string),
+		ID:            utils.GenerateID(),
+		VendorNo:      req.VendorNo,
+		Name:          req.Name,
+		ContactPerson: req.ContactPerson,
+		Phone:         req.Phone,
+		Email:         req.Email,
+		Address:       req.Address,
+		TaxNo:         req.TaxNo,
+		BankName:      req.BankName,
+		BankAccount:   req.BankAccount,
+		Category:      req.Category,
+		CreditLimit:   req.CreditLimit,
+		LeadTime:      req.LeadTime,
+		TaxStatus:     req.TaxStatus,
+		Currency:      strings.ToUpper(req.Currency),
+		PaymentTerms:  req.PaymentTerms,
+		Remarks:       req.Remarks,
+		Status:        req.Status,
 		CreatedAt:     time.Now(),
-		CreatedBy:     req["created_by"].(string),
+		CreatedBy:     req.CreatedBy,
 		UpdatedAt:     time.Now(),
-		UpdatedBy:     req["created_by"].(string),
+		UpdatedBy:     req.CreatedBy,
+	}
+	if vendor.Status == "" {
+		vendor.Status = "active"
+	}
+	if vendor.TaxStatus == "" {
+		vendor.TaxStatus = "taxable"
 	}
 
 	// 保存到数据库
@@ -207,34 +207,11 @@ func (s *purchaseService) CreateSupplier(req map[string]interface{}) (map[string
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	createdSupplier := map[string]interface{}{
-		"id":             vendor.ID,
-		"vendor_no":      vendor.VendorNo,
-		"name":           vendor.Name,
-		"contact_person": vendor.ContactPerson,
-		"phone":          vendor.Phone,
-		"email":          vendor.Email,
-		"address":        vendor.Address,
-		"tax_no":         vendor.TaxNo,
-		"bank_name":      vendor.BankName,
-		"bank_account":   vendor.BankAccount,
-		"category":       vendor.Category,
-		"credit_limit":   vendor.CreditLimit,
-		"lead_time":      vendor.LeadTime,
-		"payment_terms":  vendor.PaymentTerms,
-		"remarks":        vendor.Remarks,
-		"status":         vendor.Status,
-		"created_at":     vendor.CreatedAt,
-		"created_by":     vendor.CreatedBy,
-		"updated_at":     vendor.UpdatedAt,
-		"updated_by":     vendor.UpdatedBy,
-	}
-
-	return createdSupplier, nil
+	response := supplierResponse(vendor)
+	return &response, nil
 }
 
-func (s *purchaseService) UpdateSupplier(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *purchaseService) UpdateSupplier(id string, req schemas.SupplierUpdateRequest) (*schemas.SupplierResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -248,22 +225,56 @@ func (s *purchaseService) UpdateSupplier(id string, req map[string]interface{})
 	}
 
 	// 更新字段
-	vendor.Name = req["name"].(string)
-	vendor.ContactPerson = req["contact_person"].(string)
-	vendor.Phone = req["phone"].(string)
-	vendor.Email = req["email"].(string)
-	vendor.Address = req["address"].(string)
-	vendor.TaxNo = req["tax_no"].(string)
-	vendor.BankName = req["bank_name"].(string)
-	vendor.BankAccount = req["bank_account"].(string)
-	vendor.Category = req["category"].(string)
-	vendor.CreditLimit = req["credit_limit"].(float64)
-	vendor.LeadTime = req["lead_time"].(int)
-	vendor.PaymentTerms = req["payment_terms"].(string)
-	vendor.Remarks = req["remarks"].(string)
-	vendor.Status = req["status"].(string)
+	if req.Name != "" {
+		vendor.Name = req.Name
+	}
+	if req.ContactPerson != "" {
+		vendor.ContactPerson = req.ContactPerson
+	}
+	if req.Phone != "" {
+		vendor.Phone = req.Phone
+	}
+	if req.Email != "" {
+		vendor.Email = req.Email
+	}
+	if req.Address != "" {
+		vendor.Address = req.Address
+	}
+	if req.TaxNo != "" {
+		vendor.TaxNo = req.TaxNo
+	}
+	if req.BankName != "" {
+		vendor.BankName = req.BankName
+	}
+	if req.BankAccount != "" {
+		vendor.BankAccount = req.BankAccount
+	}
+	if req.Category != "" {
+		vendor.Category = req.Category
+	}
+	if req.CreditLimit != nil {
+		vendor.CreditLimit = *req.CreditLimit
+	}
+	if req.LeadTime != nil {
+		vendor.LeadTime = *req.LeadTime
+	}
+	if req.TaxStatus != "" {
+		vendor.TaxStatus = req.TaxStatus
+	}
+	if req.Currency != "" {
+		vendor.Currency = strings.ToUpper(req.Currency)
+	}
+	if req.PaymentTerms != "" {
+		vendor.PaymentTerms = req.PaymentTerms
+	}
+	if req.Remarks != "" {
+		vendor.Remarks = req.Remarks
+	}
+	if req.Status != "" {
+		vendor.Status = req.Status
+	}
 	vendor.UpdatedAt = time.Now()
-	vendor.UpdatedBy = req["updated_by"].(string)
+	vendor.UpdatedBy = req.UpdatedBy
 
 	// 保存到数据库
 	result = s.db.Save(&vendor)
@@ -271,31 +282,8 @@ func (s *purchaseService) UpdateSupplier(id string, req map[string]interface{})
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	updatedSupplier := map[string]interface{}{
-		"id":             vendor.ID,
-		"vendor_no":      vendor.VendorNo,
-		"name":           vendor.Name,
-		"contact_person": vendor.ContactPerson,
-		"phone":          vendor.Phone,
-		"email":          vendor.Email,
-		"address":        vendor.Address,
-		"tax_no":         vendor.TaxNo,
-		"bank_name":      vendor.BankName,
-		"bank_account":   vendor.BankAccount,
-		"category":       vendor.Category,
-		"credit_limit":   vendor.CreditLimit,
-		"lead_time":      vendor.LeadTime,
-		"payment_terms":  vendor.PaymentTerms,
-		"remarks":        vendor.Remarks,
-		"status":         vendor.Status,
-		"created_at":     vendor.CreatedAt,
-		"created_by":     vendor.CreatedBy,
-		"updated_at":     vendor.UpdatedAt,
-		"updated_by":     vendor.UpdatedBy,
-	}
-
-	return updatedSupplier, nil
+	response := supplierResponse(vendor)
+	return &response, nil
 }
 
 func (s *purchaseService) DeleteSupplier(id string) error {
@@ -313,7 +301,7 @@ func (s *purchaseService) DeleteSupplier(id string) error {
 	return nil
 }
 
-func (s *purchaseService) GetSupplierContacts(id string) ([]map[string]interface{}, error) {
+func (s *purchaseService) GetSupplierContacts(id string) ([]schemas.SupplierContactResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -321,111 +309,33 @@ func (s *purchaseService) GetSupplierContacts(id string) ([]map[string]interface
 
 	// 实际应用中，这里需要从数据库读取供应商联系人数据
 	// 由于模型中没有定义联系人表，暂时返回空列表
-	return []map[string]interface{}{}, nil
+	return []schemas.SupplierContactResponse{}, nil
 }
 
-func (s *purchaseService) AddSupplierContact(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *purchaseService) AddSupplierContact(id string, req schemas.SupplierContactCreateRequest) (*schemas.SupplierContactResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
 	// 实际应用中，这里需要创建供应商联系人
-	// 由于模型中没有定义联系人表，暂时返回空map
-	return map[string]interface{}{}, nil
+	// 由于模型中没有定义联系人表，暂时返回空结果
+	return &schemas.SupplierContactResponse{}, nil
 }
 
-// 采购申请管理方法
-func (s *purchaseService) GetRequisitionList(req map[string]interface{}) ([]map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
-	}
-
-	// 实际应用中，这里需要从数据库读取采购申请数据
-	// 由于模型中没有定义采购申请表，暂时返回空列表
-	return []map[string]interface{}{}, nil
-}
-
-func (s *purchaseService) GetRequisitionDetail(id string) (map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
-	}
-
-	// 实际应用中，这里需要从数据库读取采购申请详情
-	// 由于模型中没有定义采购申请表，暂时返回空map
-	return map[string]interface{}{}, nil
-}
-
-func (s *purchaseService) CreateRequisition(req map[string]interface{}) (map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
-	}
-
-	// 实际应用中，这里需要创建采购申请
-	// 由于模型中没有定义采购申请表，暂时返回空map
-	return map[string]interface{}{}, nil
-}
-
-func (s *purchaseService) UpdateRequisition(id string, req map[string]interface{}) (map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
-	}
-
-	// 实际应用中，这里需要更新采购申请
-	// 由于模型中没有定义采购申请表，暂时返回空map
-	return map[string]interface{}{}, nil
-}
-
-func (s *purchaseService) DeleteRequisition(id string) error {
-	// 检查数据库连接
-	if s.db == nil {
-		return errors.New("database connection is nil")
-	}
-
-	// 实际应用中，这里需要删除采购申请
-	// 由于模型中没有定义采购申请表，暂时返回nil
-	return nil
-}
-
-func (s *purchaseService) SubmitRequisition(id string) error {
-	// 检查数据库连接
-	if s.db == nil {
-		return errors.New("database connection is nil")
-	}
-
-	// 实际应用中，这里需要提交采购申请
-	// 由于模型中没有定义采购申请表，暂时返回nil
-	return nil
-}
-
-func (s *purchaseService) ApproveRequisition(id string) error {
-	// 检查数据库连接
-	if s.db == nil {
-		return errors.New("database connection is nil")
-	}
-
-	// 实际应用中，这里需要批准采购申请
-	// 由于模型中没有定义采购申请表，暂时返回nil
-	return nil
-}
-
-func (s *purchaseService) RejectRequisition(id string) error {
-	// 检查数据库连接
-	if s.db == nil {
-		return errors.New("database connection is nil")
-	}
-
-	// 实际应用中，这里需要拒绝采购申请
-	// 由于模型中没有定义采购申请表，暂时返回nil
-	return nil
-}
+// purchaseOrderListSpec 采购订单列表查询白名单
+var purchaseOrderListSpec = query.NewSpec("-order_date",
+	query.Text("order_no"),
+	query.Text("vendor_id"),
+	query.Text("status"),
+	query.Number("total_amount"),
+	query.Date("order_date"),
+	query.Date("delivery_date"),
+	query.Date("created_at"),
+)
 
 // 采购订单管理方法
-func (s *purchaseService) GetPurchaseOrderList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *purchaseService) GetPurchaseOrderList(params query.Params) (*query.Page[schemas.PurchaseOrderResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -433,121 +343,88 @@ func (s *purchaseService) GetPurchaseOrderList(req map[string]interface{}) ([]ma
 
 	// 从数据库读取采购订单数据
 	var orders []models.PurchaseOrder
-	result := s.db.Find(&orders)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, purchaseOrderListSpec, &orders)
+	if err != nil {
+		return nil, err
 	}
 
-	// 将模型转换为map
-	orderList := make([]map[string]interface{}, len(orders))
+	// 转换为响应结构
+	orderList := make([]schemas.PurchaseOrderResponse, len(orders))
 	for i, order := range orders {
-		orderList[i] = map[string]interface{}{
-			"id":            order.ID,
-			"order_no":      order.OrderNo,
-			"vendor_id":     order.VendorID,
-			"order_date":    order.OrderDate,
-			"delivery_date": order.DeliveryDate,
-			"total_amount":  order.TotalAmount,
-			"status":        order.Status,
-			"payment_terms": order.PaymentTerms,
-			"remarks":       order.Remarks,
-			"created_at":    order.CreatedAt,
-			"created_by":    order.CreatedBy,
-			"updated_at":    order.UpdatedAt,
-			"updated_by":    order.UpdatedBy,
-		}
+		orderList[i] = purchaseOrderResponse(order)
 	}
 
-	return orderList, nil
+	return query.NewPage(orderList, total, params), nil
 }
 
-func (s *purchaseService) GetPurchaseOrderDetail(id string) (map[string]interface{}, error) {
+func (s *purchaseService) GetPurchaseOrderDetail(id string) (*schemas.PurchaseOrderResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 从数据库读取采购订单详情
+	// 从数据库读取采购订单详情及明细
 	var order models.PurchaseOrder
-	result := s.db.First(&order, "id = ?", id)
+	result := s.db.Preload("Items").First(&order, "id = ?", id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	orderDetail := map[string]interface{}{
-		"id":            order.ID,
-		"order_no":      order.OrderNo,
-		"vendor_id":     order.VendorID,
-		"order_date":    order.OrderDate,
-		"delivery_date": order.DeliveryDate,
-		"total_amount":  order.TotalAmount,
-		"status":        order.Status,
-		"payment_terms": order.PaymentTerms,
-		"remarks":       order.Remarks,
-		"created_at":    order.CreatedAt,
-		"created_by":    order.CreatedBy,
-		"updated_at":    order.UpdatedAt,
-		"updated_by":    order.UpdatedBy,
-	}
-
-	return orderDetail, nil
+	response := purchaseOrderResponse(order)
+	return &response, nil
 }
 
-func (s *purchaseService) CreatePurchaseOrder(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *purchaseService) CreatePurchaseOrder(req schemas.PurchaseOrderCreateRequest) (*schemas.PurchaseOrderResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
+	// 解析日期
+	orderDate, err := time.Parse("2006-01-02", req.OrderDate)
+	if err != nil {
+		return nil, err
+	}
+
 	// 从请求中获取数据
 	order := models.PurchaseOrder{
-		ID:           req["id"].(string),
-		OrderNo:      req["order_no"].(string),
-		VendorID:     req["vendor_id"].(string),
-		OrderDate:    time.Now(),
-		TotalAmount:  req["total_amount"].(float64),
-		Status:       req["status"].(string),
-		PaymentTerms: req["payment_terms"].(string),
-		Remarks:      req["remarks"].(string),
+		ID:           utils.GenerateID(),
+		OrderNo:      req.OrderNo,
+		VendorID:     req.VendorID,
+		OrderDate:    orderDate,
+		Status:       "pending",
+		PaymentTerms: req.PaymentTerms,
+		Remarks:      req.Remarks,
 		CreatedAt:    time.Now(),
-		CreatedBy:    req["created_by"].(string),
+		CreatedBy:    req.CreatedBy,
 		UpdatedAt:    time.Now(),
-		UpdatedBy:    req["created_by"].(string),
+		UpdatedBy:    req.CreatedBy,
 	}
-
-	// 处理可选的delivery_date
-	if deliveryDate, ok := req["delivery_date"].(time.Time); ok {
+	if req.DeliveryDate != "" {
+		deliveryDate, err := time.Parse("2006-01-02", req.DeliveryDate)
+		if err != nil {
+			return nil, err
+		}
 		order.DeliveryDate = &deliveryDate
 	}
+	order.Items, order.TotalAmount = purchaseOrderItems(order.ID, req.Items, req.CreatedBy)
+	order.Currency, order.ExchangeRate, err = purchaseDocumentCurrency(s.db, order.VendorID, req.Currency, req.ExchangeRate, order.OrderDate)
+	if err != nil {
+		return nil, err
+	}
+	order.BaseTotalAmount = toBaseAmount(order.TotalAmount, order.ExchangeRate)
 
-	// 保存到数据库
+	// 保存订单及明细到数据库
 	result := s.db.Create(&order)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	createdOrder := map[string]interface{}{
-		"id":            order.ID,
-		"order_no":      order.OrderNo,
-		"vendor_id":     order.VendorID,
-		"order_date":    order.OrderDate,
-		"delivery_date": order.DeliveryDate,
-		"total_amount":  order.TotalAmount,
-		"status":        order.Status,
-		"payment_terms": order.PaymentTerms,
-		"remarks":       order.Remarks,
-		"created_at":    order.CreatedAt,
-		"created_by":    order.CreatedBy,
-		"updated_at":    order.UpdatedAt,
-		"updated_by":    order.UpdatedBy,
-	}
-
-	return createdOrder, nil
+	response := purchaseOrderResponse(order)
+	return &response, nil
 }
 
-func (s *purchaseService) UpdatePurchaseOrder(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *purchaseService) UpdatePurchaseOrder(id string, req schemas.PurchaseOrderUpdateRequest) (*schemas.PurchaseOrderResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -555,50 +432,93 @@ func (s *purchaseService) UpdatePurchaseOrder(id string, req map[string]interfac
 
 	// 从数据库读取采购订单
 	var order models.PurchaseOrder
-	result := s.db.First(&order, "id = ?", id)
+	result := s.db.Preload("Items").First(&order, "id = ?", id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
 	// 更新字段
-	order.VendorID = req["vendor_id"].(string)
-	order.OrderDate = time.Now()
-	order.TotalAmount = req["total_amount"].(float64)
-	order.Status = req["status"].(string)
-	order.PaymentTerms = req["payment_terms"].(string)
-	order.Remarks = req["remarks"].(string)
+	if req.VendorID != "" {
+		order.VendorID = req.VendorID
+	}
+	if req.OrderDate != "" {
+		orderDate, err := time.Parse("2006-01-02", req.OrderDate)
+		if err != nil {
+			return nil, err
+		}
+		order.OrderDate = orderDate
+	}
+	if req.DeliveryDate != "" {
+		deliveryDate, err := time.Parse("2006-01-02", req.DeliveryDate)
+		if err != nil {
+			return nil, err
+		}
+		order.DeliveryDate = &deliveryDate
+	}
+	if req.PaymentTerms != "" {
+		order.PaymentTerms = req.PaymentTerms
+	}
+	if req.Remarks != "" {
+		order.Remarks = req.Remarks
+	}
 	order.UpdatedAt = time.Now()
-	order.UpdatedBy = req["updated_by"].(string)
+	order.UpdatedBy = req.UpdatedBy
 
-	// 处理可选的delivery_date
-	if deliveryDate, ok := req["delivery_date"].(time.Time); ok {
-		order.DeliveryDate = &deliveryDate
+	// 审批后的订单不允许修改币种和汇率
+	if (req.Currency != "" || req.ExchangeRate > 0) && order.Status != "pending" && order.Status != "rejected" {
+		return nil, fmt.Errorf("purchase order in status %s cannot change currency", order.Status)
 	}
 
-	// 保存到数据库
-	result = s.db.Save(&order)
-	if result.Error != nil {
-		return nil, result.Error
+	// 币种或审批前修改订单日期时重新取汇率
+	if req.Currency != "" || req.ExchangeRate > 0 || (req.OrderDate != "" && (order.Status == "pending" || order.Status == "rejected")) {
+		currency := req.Currency
+		if currency == "" {
+			currency = order.Currency
+		}
+		var err error
+		order.Currency, order.ExchangeRate, err = purchaseDocumentCurrency(s.db, order.VendorID, currency, req.ExchangeRate, order.OrderDate)
+		if err != nil {
+			return nil, err
+		}
+		order.BaseTotalAmount = toBaseAmount(order.TotalAmount, order.ExchangeRate)
+	}
+
+	// 未传明细时只更新订单头
+	if len(req.Items) == 0 {
+		if err := s.db.Omit("Items").Save(&order).Error; err != nil {
+			return nil, err
+		}
+		response := purchaseOrderResponse(order)
+		return &response, nil
 	}
 
-	// 将模型转换为map
-	updatedOrder := map[string]interface{}{
-		"id":            order.ID,
-		"order_no":      order.OrderNo,
-		"vendor_id":     order.VendorID,
-		"order_date":    order.OrderDate,
-		"delivery_date": order.DeliveryDate,
-		"total_amount":  order.TotalAmount,
-		"status":        order.Status,
-		"payment_terms": order.PaymentTerms,
-		"remarks":       order.Remarks,
-		"created_at":    order.CreatedAt,
-		"created_by":    order.CreatedBy,
-		"updated_at":    order.UpdatedAt,
-		"updated_by":    order.UpdatedBy,
-	}
-
-	return updatedOrder, nil
+	// 审批后的订单不允许替换明细
+	if order.Status != "pending" && order.Status != "rejected" {
+		return nil, fmt.Errorf("purchase order in status %s cannot change items", order.Status)
+	}
+
+	// 在同一事务中替换明细并保存订单，原明细来自申请或计划时退回已转订单数量
+	previousItems := order.Items
+	order.Items, order.TotalAmount = purchaseOrderItems(order.ID, req.Items, req.UpdatedBy)
+	order.BaseTotalAmount = toBaseAmount(order.TotalAmount, order.ExchangeRate)
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := releasePurchaseOrderSources(tx, previousItems); err != nil {
+			return err
+		}
+		if err := tx.Where("order_id = ?", order.ID).Delete(&models.PurchaseOrderItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&order.Items).Error; err != nil {
+			return err
+		}
+		return tx.Omit("Items").Save(&order).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := purchaseOrderResponse(order)
+	return &response, nil
 }
 
 func (s *purchaseService) DeletePurchaseOrder(id string) error {
@@ -607,13 +527,23 @@ func (s *purchaseService) DeletePurchaseOrder(id string) error {
 		return errors.New("database connection is nil")
 	}
 
-	// 从数据库删除采购订单
-	result := s.db.Delete(&models.PurchaseOrder{}, "id = ?", id)
+	// 从数据库读取采购订单及明细
+	var order models.PurchaseOrder
+	result := s.db.Preload("Items").First(&order, "id = ?", id)
 	if result.Error != nil {
 		return result.Error
 	}
 
-	return nil
+	// 在同一事务中退回来源申请和计划的已转订单数量，删除价格历史和订单
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := releasePurchaseOrderSources(tx, order.Items); err != nil {
+			return err
+		}
+		if err := deletePriceHistory(tx, priceSourceOrder, order.ID); err != nil {
+			return err
+		}
+		return tx.Delete(&order).Error
+	})
 }
 
 func (s *purchaseService) SubmitPurchaseOrder(id string) error {
@@ -649,9 +579,9 @@ func (s *purchaseService) ApprovePurchaseOrder(id string) error {
 		return errors.New("database connection is nil")
 	}
 
-	// 从数据库读取采购订单
+	// 从数据库读取采购订单及明细
 	var order models.PurchaseOrder
-	result := s.db.First(&order, "id = ?", id)
+	result := s.db.Preload("Items").First(&order, "id = ?", id)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -661,13 +591,13 @@ func (s *purchaseService) ApprovePurchaseOrder(id string) error {
 	order.UpdatedAt = time.Now()
 	order.UpdatedBy = "system"
 
-	// 保存到数据库
-	result = s.db.Save(&order)
-	if result.Error != nil {
-		return result.Error
-	}
-
-	return nil
+	// 在同一事务中保存订单并记录明细的采购价格历史
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Items").Save(&order).Error; err != nil {
+			return err
+		}
+		return recordOrderPriceHistory(tx, order)
+	})
 }
 
 func (s *purchaseService) RejectPurchaseOrder(id string) error {
@@ -724,8 +654,19 @@ func (s *purchaseService) ClosePurchaseOrder(id string) error {
 	return nil
 }
 
+// purchaseReceiptListSpec 采购入库单列表查询白名单
+var purchaseReceiptListSpec = query.NewSpec("-receipt_date",
+	query.Text("receipt_no"),
+	query.Text("order_id"),
+	query.Text("vendor_id"),
+	query.Text("status"),
+	query.Number("total_amount"),
+	query.Date("receipt_date"),
+	query.Date("created_at"),
+)
+
 // 采购收货管理方法
-func (s *purchaseService) GetPurchaseReceiptList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *purchaseService) GetPurchaseReceiptList(params query.Params) (*query.Page[schemas.ReceiptResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -733,117 +674,111 @@ func (s *purchaseService) GetPurchaseReceiptList(req map[string]interface{}) ([]
 
 	// 从数据库读取采购收货单数据
 	var receipts []models.PurchaseReceipt
-	result := s.db.Find(&receipts)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, purchaseReceiptListSpec, &receipts)
+	if err != nil {
+		return nil, err
 	}
 
-	// 将模型转换为map
-	receiptList := make([]map[string]interface{}, len(receipts))
+	// 转换为响应结构
+	receiptList := make([]schemas.ReceiptResponse, len(receipts))
 	for i, receipt := range receipts {
-		receiptList[i] = map[string]interface{}{
-			"id":             receipt.ID,
-			"receipt_no":     receipt.ReceiptNo,
-			"order_id":       receipt.OrderID,
-			"vendor_id":      receipt.VendorID,
-			"receipt_date":   receipt.ReceiptDate,
-			"total_quantity": receipt.TotalQuantity,
-			"total_amount":   receipt.TotalAmount,
-			"status":         receipt.Status,
-			"remarks":        receipt.Remarks,
-			"created_at":     receipt.CreatedAt,
-			"created_by":     receipt.CreatedBy,
-			"updated_at":     receipt.UpdatedAt,
-			"updated_by":     receipt.UpdatedBy,
-		}
+		receiptList[i] = purchaseReceiptResponse(receipt)
 	}
 
-	return receiptList, nil
+	return query.NewPage(receiptList, total, params), nil
 }
 
-func (s *purchaseService) GetPurchaseReceiptDetail(id string) (map[string]interface{}, error) {
+func (s *purchaseService) GetPurchaseReceiptDetail(id string) (*schemas.ReceiptResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 从数据库读取采购收货单详情
+	// 从数据库读取采购收货单详情及明细
 	var receipt models.PurchaseReceipt
-	result := s.db.First(&receipt, "id = ?", id)
+	result := s.db.Preload("Items").First(&receipt, "id = ?", id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	receiptDetail := map[string]interface{}{
-		"id":             receipt.ID,
-		"receipt_no":     receipt.ReceiptNo,
-		"order_id":       receipt.OrderID,
-		"vendor_id":      receipt.VendorID,
-		"receipt_date":   receipt.ReceiptDate,
-		"total_quantity": receipt.TotalQuantity,
-		"total_amount":   receipt.TotalAmount,
-		"status":         receipt.Status,
-		"remarks":        receipt.Remarks,
-		"created_at":     receipt.CreatedAt,
-		"created_by":     receipt.CreatedBy,
-		"updated_at":     receipt.UpdatedAt,
-		"updated_by":     receipt.UpdatedBy,
-	}
-
-	return receiptDetail, nil
+	response := purchaseReceiptResponse(receipt)
+	return &response, nil
 }
 
-func (s *purchaseService) CreatePurchaseReceipt(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *purchaseService) CreatePurchaseReceipt(req schemas.ReceiptCreateRequest) (*schemas.ReceiptResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 从请求中获取数据
+	// 解析收货日期
+	receiptDate, err := time.Parse("2006-01-02", req.ReceiptDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 读取采购订单及明细，收货物料和单价以订单为准
+	var order models.PurchaseOrder
+	if err := s.db.Preload("Items").First(&order, "id = ?", req.OrderID).Error; err != nil {
+		return nil, err
+	}
+	orderItems := make(map[string]models.PurchaseOrderItem, len(order.Items))
+	for _, item := range order.Items {
+		orderItems[item.ID] = item
+	}
+
 	receipt := models.PurchaseReceipt{
-		ID:            req["id"].(string),
-		ReceiptNo:     req["receipt_no"].(string),
-		OrderID:       req["order_id"].(string),
-		VendorID:      req["vendor_id"].(string),
-		ReceiptDate:   time.Now(),
-		TotalQuantity: req["total_quantity"].(float64),
-		TotalAmount:   req["total_amount"].(float64),
-		Status:        req["status"].(string),
-		Remarks:       req["remarks"].(string),
-		CreatedAt:     time.Now(),
-		CreatedBy:     req["created_by"].(string),
-		UpdatedAt:     time.Now(),
-		UpdatedBy:     req["created_by"].(string),
+		ID:          utils.GenerateID(),
+		ReceiptNo:   req.ReceiptNo,
+		OrderID:     order.ID,
+		VendorID:    order.VendorID,
+		ReceiptDate: receiptDate,
+		Status:      "pending",
+		Remarks:     req.Remarks,
+		CreatedAt:   time.Now(),
+		CreatedBy:   req.CreatedBy,
+		UpdatedAt:   time.Now(),
+		UpdatedBy:   req.CreatedBy,
+	}
+
+	// 构建收货明细并汇总数量金额
+	receipt.Items = make([]models.PurchaseReceiptItem, len(req.Items))
+	for i, item := range req.Items {
+		orderItem, ok := orderItems[item.OrderItemID]
+		if !ok {
+			return nil, fmt.Errorf("order item %s does not belong to purchase order %s", item.OrderItemID, order.OrderNo)
+		}
+		amount := item.Quantity * orderItem.UnitPrice
+		receipt.Items[i] = models.PurchaseReceiptItem{
+			ID:          utils.GenerateID(),
+			ReceiptID:   receipt.ID,
+			OrderItemID: orderItem.ID,
+			ItemID:      orderItem.ItemID,
+			Quantity:    item.Quantity,
+			UnitPrice:   orderItem.UnitPrice,
+			Amount:      amount,
+			WarehouseID: item.WarehouseID,
+			LocationID:  item.LocationID,
+			CreatedBy:   req.CreatedBy,
+			CreatedAt:   time.Now(),
+			UpdatedBy:   req.CreatedBy,
+			UpdatedAt:   time.Now(),
+		}
+		receipt.TotalQuantity += item.Quantity
+		receipt.TotalAmount += amount
 	}
 
-	// 保存到数据库
+	// 保存收货单及明细到数据库
 	result := s.db.Create(&receipt)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	createdReceipt := map[string]interface{}{
-		"id":             receipt.ID,
-		"receipt_no":     receipt.ReceiptNo,
-		"order_id":       receipt.OrderID,
-		"vendor_id":      receipt.VendorID,
-		"receipt_date":   receipt.ReceiptDate,
-		"total_quantity": receipt.TotalQuantity,
-		"total_amount":   receipt.TotalAmount,
-		"status":         receipt.Status,
-		"remarks":        receipt.Remarks,
-		"created_at":     receipt.CreatedAt,
-		"created_by":     receipt.CreatedBy,
-		"updated_at":     receipt.UpdatedAt,
-		"updated_by":     receipt.UpdatedBy,
-	}
-
-	return createdReceipt, nil
+	response := purchaseReceiptResponse(receipt)
+	return &response, nil
 }
 
-func (s *purchaseService) UpdatePurchaseReceipt(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *purchaseService) UpdatePurchaseReceipt(id string, req schemas.ReceiptUpdateRequest) (*schemas.ReceiptResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -851,46 +786,38 @@ func (s *purchaseService) UpdatePurchaseReceipt(id string, req map[string]interf
 
 	// 从数据库读取采购收货单
 	var receipt models.PurchaseReceipt
-	result := s.db.First(&receipt, "id = ?", id)
+	result := s.db.Preload("Items").First(&receipt, "id = ?", id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
+	// 已完成的收货单已过账入库，不允许修改
+	if receipt.Status == "completed" {
+		return nil, errors.New("completed purchase receipt cannot be modified")
+	}
+
 	// 更新字段
-	receipt.OrderID = req["order_id"].(string)
-	receipt.VendorID = req["vendor_id"].(string)
-	receipt.ReceiptDate = time.Now()
-	receipt.TotalQuantity = req["total_quantity"].(float64)
-	receipt.TotalAmount = req["total_amount"].(float64)
-	receipt.Status = req["status"].(string)
-	receipt.Remarks = req["remarks"].(string)
+	if req.ReceiptDate != "" {
+		receiptDate, err := time.Parse("2006-01-02", req.ReceiptDate)
+		if err != nil {
+			return nil, err
+		}
+		receipt.ReceiptDate = receiptDate
+	}
+	if req.Remarks != "" {
+		receipt.Remarks = req.Remarks
+	}
 	receipt.UpdatedAt = time.Now()
-	receipt.UpdatedBy = req["updated_by"].(string)
+	receipt.UpdatedBy = req.UpdatedBy
 
 	// 保存到数据库
-	result = s.db.Save(&receipt)
+	result = s.db.Omit("Items").Save(&receipt)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	updatedReceipt := map[string]interface{}{
-		"id":             receipt.ID,
-		"receipt_no":     receipt.ReceiptNo,
-		"order_id":       receipt.OrderID,
-		"vendor_id":      receipt.VendorID,
-		"receipt_date":   receipt.ReceiptDate,
-		"total_quantity": receipt.TotalQuantity,
-		"total_amount":   receipt.TotalAmount,
-		"status":         receipt.Status,
-		"remarks":        receipt.Remarks,
-		"created_at":     receipt.CreatedAt,
-		"created_by":     receipt.CreatedBy,
-		"updated_at":     receipt.UpdatedAt,
-		"updated_by":     receipt.UpdatedBy,
-	}
-
-	return updatedReceipt, nil
+	response := purchaseReceiptResponse(receipt)
+	return &response, nil
 }
 
 func (s *purchaseService) DeletePurchaseReceipt(id string) error {
@@ -914,29 +841,97 @@ func (s *purchaseService) CompletePurchaseReceipt(id string) error {
 		return errors.New("database connection is nil")
 	}
 
-	// 从数据库读取采购收货单
+	// 从数据库读取采购收货单及明细
 	var receipt models.PurchaseReceipt
-	result := s.db.First(&receipt, "id = ?", id)
+	result := s.db.Preload("Items").First(&receipt, "id = ?", id)
 	if result.Error != nil {
 		return result.Error
 	}
+	if receipt.Status == "completed" {
+		return errors.New("purchase receipt is already completed")
+	}
+
+	// 构建入库变动
+	movements := make([]inventoryMovement, len(receipt.Items))
+	for i, item := range receipt.Items {
+		movements[i] = inventoryMovement{
+			ItemID:          item.ItemID,
+			WarehouseID:     item.WarehouseID,
+			LocationID:      item.LocationID,
+			Type:            "purchase_in",
+			Quantity:        item.Quantity,
+			UnitCost:        item.UnitPrice,
+			ReferenceType:   "purchase_receipt",
+			ReferenceID:     receipt.ID,
+			TransactionDate: receipt.ReceiptDate,
+			Remarks:         receipt.ReceiptNo,
+			CreatedBy:       "system",
+		}
+	}
 
-	// 更新状态为completed
-	receipt.Status = "completed"
-	receipt.UpdatedAt = time.Now()
-	receipt.UpdatedBy = "system"
+	// 在同一事务中先按未完成状态条件更新收货单状态，防止并发重复完成，再过账入库并回写订单已收数量
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.PurchaseReceipt{}).
+			Where("id = ? AND status <> ?", receipt.ID, "completed").
+			Updates(map[string]interface{}{
+				"status":     "completed",
+				"updated_by": "system",
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("purchase receipt is already completed")
+		}
 
-	// 保存到数据库
-	result = s.db.Save(&receipt)
-	if result.Error != nil {
-		return result.Error
-	}
+		transactionNo := fmt.Sprintf("PRC%s", time.Now().Format("20060102030405"))
+		if _, err := newInventoryPoster(tx).Post(transactionNo, movements); err != nil {
+			return err
+		}
 
-	return nil
+		// 已收数量不能超过订单数量
+		for _, item := range receipt.Items {
+			if item.OrderItemID == "" {
+				continue
+			}
+			var orderItem models.PurchaseOrderItem
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&orderItem, "id = ?", item.OrderItemID).Error; err != nil {
+				return err
+			}
+			received := roundQuantity(orderItem.ReceivedQuantity + item.Quantity)
+			if received > orderItem.Quantity {
+				return fmt.Errorf("order item %s received quantity %.4f would exceed ordered quantity %.4f",
+					orderItem.ID, received, orderItem.Quantity)
+			}
+			result := tx.Model(&orderItem).Updates(map[string]interface{}{
+				"received_quantity": received,
+				"updated_at":        time.Now(),
+			})
+			if result.Error != nil {
+				return result.Error
+			}
+		}
+		return nil
+	})
 }
 
+// purchaseInvoiceListSpec 采购发票列表查询白名单
+var purchaseInvoiceListSpec = query.NewSpec("-invoice_date",
+	query.Text("invoice_no"),
+	query.Text("order_id"),
+	query.Text("receipt_id"),
+	query.Text("vendor_id"),
+	query.Text("status"),
+	query.Number("total_amount"),
+	query.Number("paid_amount"),
+	query.Date("invoice_date"),
+	query.Date("due_date"),
+	query.Date("created_at"),
+)
+
 // 采购发票管理方法
-func (s *purchaseService) GetPurchaseInvoiceList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *purchaseService) GetPurchaseInvoiceList(params query.Params) (*query.Page[schemas.PurchaseInvoiceResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -944,97 +939,137 @@ func (s *purchaseService) GetPurchaseInvoiceList(req map[string]interface{}) ([]
 
 	// 从数据库读取采购发票数据
 	var invoices []models.PurchaseInvoice
-	result := s.db.Find(&invoices)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, purchaseInvoiceListSpec, &invoices)
+	if err != nil {
+		return nil, err
 	}
 
-	// 将模型转换为map
-	invoiceList := make([]map[string]interface{}, len(invoices))
+	// 转换为响应结构
+	invoiceList := make([]schemas.PurchaseInvoiceResponse, len(invoices))
 	for i, invoice := range invoices {
-		invoiceList[i] = map[string]interface{}{
-			"id":            invoice.ID,
-			"invoice_no":    invoice.InvoiceNo,
-			"order_id":      invoice.OrderID,
-			"receipt_id":    invoice.ReceiptID,
-			"vendor_id":     invoice.VendorID,
-			"invoice_date":  invoice.InvoiceDate,
-			"due_date":      invoice.DueDate,
-			"total_amount":  invoice.TotalAmount,
-			"tax_amount":    invoice.TaxAmount,
-			"status":        invoice.Status,
-			"payment_terms": invoice.PaymentTerms,
-			"remarks":       invoice.Remarks,
-			"created_at":    invoice.CreatedAt,
-			"created_by":    invoice.CreatedBy,
-			"updated_at":    invoice.UpdatedAt,
-			"updated_by":    invoice.UpdatedBy,
-		}
+		invoiceList[i] = purchaseInvoiceResponse(invoice)
 	}
 
-	return invoiceList, nil
+	return query.NewPage(invoiceList, total, params), nil
 }
 
-func (s *purchaseService) GetPurchaseInvoiceDetail(id string) (map[string]interface{}, error) {
+func (s *purchaseService) GetPurchaseInvoiceDetail(id string) (*schemas.PurchaseInvoiceResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 从数据库读取采购发票详情
+	// 从数据库读取采购发票详情及明细
 	var invoice models.PurchaseInvoice
-	result := s.db.First(&invoice, "id = ?", id)
+	result := s.db.Preload("Items").Preload("Discrepancies").First(&invoice, "id = ?", id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	invoiceDetail := map[string]interface{}{
-		"id":            invoice.ID,
-		"invoice_no":    invoice.InvoiceNo,
-		"order_id":      invoice.OrderID,
-		"receipt_id":    invoice.ReceiptID,
-		"vendor_id":     invoice.VendorID,
-		"invoice_date":  invoice.InvoiceDate,
-		"due_date":      invoice.DueDate,
-		"total_amount":  invoice.TotalAmount,
-		"tax_amount":    invoice.TaxAmount,
-		"status":        invoice.Status,
-		"payment_terms": invoice.PaymentTerms,
-		"remarks":       invoice.Remarks,
-		"created_at":    invoice.CreatedAt,
-		"created_by":    invoice.CreatedBy,
-		"updated_at":    invoice.UpdatedAt,
-		"updated_by":    invoice.UpdatedBy,
-	}
-
-	return invoiceDetail, nil
+	response := purchaseInvoiceResponse(invoice)
+	return &response, nil
 }
 
-func (s *purchaseService) CreatePurchaseInvoice(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *purchaseService) CreatePurchaseInvoice(req schemas.PurchaseInvoiceCreateRequest) (*schemas.PurchaseInvoiceResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 从请求中获取数据
+	// 解析日期，未指定到期日时按付款条件计算
+	invoiceDate, err := time.Parse("2006-01-02", req.InvoiceDate)
+	if err != nil {
+		return nil, err
+	}
+	var dueDate time.Time
+	if req.DueDate != "" {
+		dueDate, err = time.Parse("2006-01-02", req.DueDate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 供应商和币种以采购订单为准
+	var order models.PurchaseOrder
+	if err := s.db.First(&order, "id = ?", req.OrderID).Error; err != nil {
+		return nil, err
+	}
+	currency, rate, err := documentCurrency(s.db, order.Currency, "", req.ExchangeRate, invoiceDate)
+	if err != nil {
+		return nil, err
+	}
+
 	invoice := models.PurchaseInvoice{
-		ID:           req["id"].(string),
-		InvoiceNo:    req["invoice_no"].(string),
-		OrderID:      req["order_id"].(string),
-		ReceiptID:    req["receipt_id"].(string),
-		VendorID:     req["vendor_id"].(string),
-		InvoiceDate:  time.Now(),
-		DueDate:      time.Now().AddDate(0, 1, 0), // 默认30天
-		TotalAmount:  req["total_amount"].(float64),
-		TaxAmount:    req["tax_amount"].(float64),
-		Status:       req["status"].(string),
-		PaymentTerms: req["payment_terms"].(string),
-		Remarks:      req["remarks"].(string),
-		CreatedAt:    time.Now(),
-		CreatedBy:    req["created_by"].(string),
-		UpdatedAt:    time.Now(),
-		UpdatedBy:    req["created_by"].(string),
+		ID:               utils.GenerateID(),
+		InvoiceNo:        req.InvoiceNo,
+		OrderID:          order.ID,
+		ReceiptID:        req.ReceiptID,
+		VendorID:         order.VendorID,
+		InvoiceDate:      invoiceDate,
+		DueDate:          dueDate,
+		TotalAmount:      req.TotalAmount,
+		TaxAmount:        req.TaxAmount,
+		PriceIncludesTax: pricesIncludeTax(req.PriceIncludesTax),
+		Currency:         currency,
+		ExchangeRate:     rate,
+		Status:           "unpaid",
+		PaymentTerms:     req.PaymentTerms,
+		Remarks:          req.Remarks,
+		CreatedAt:        time.Now(),
+		CreatedBy:        req.CreatedBy,
+		UpdatedAt:        time.Now(),
+		UpdatedBy:        req.CreatedBy,
+	}
+	if invoice.PaymentTerms == "" {
+		invoice.PaymentTerms = order.PaymentTerms
+	}
+	if invoice.PaymentTerms == "" {
+		var vendor models.PurchaseVendor
+		if err := s.db.First(&vendor, "id = ?", order.VendorID).Error; err != nil {
+			return nil, err
+		}
+		invoice.PaymentTerms = vendor.PaymentTerms
+	}
+	if invoice.DueDate.IsZero() {
+		invoice.DueDate = paymentTermsDueDate(invoice.InvoiceDate, invoice.PaymentTerms)
+	}
+	for _, item := range req.Items {
+		invoice.Items = append(invoice.Items, models.PurchaseInvoiceItem{
+			ID:          utils.GenerateID(),
+			InvoiceID:   invoice.ID,
+			OrderItemID: item.OrderItemID,
+			ItemID:      item.ItemID,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Discount:    item.Discount,
+			Amount:      item.Quantity*item.UnitPrice - item.Discount,
+			CreatedBy:   req.CreatedBy,
+			CreatedAt:   time.Now(),
+			UpdatedBy:   req.CreatedBy,
+			UpdatedAt:   time.Now(),
+		})
+	}
+
+	// 发票明细关联采购订单明细，供审核时三单匹配
+	if err := resolveInvoiceOrderItems(s.db, &invoice); err != nil {
+		return nil, err
+	}
+
+	// 有明细时按明细计税得出总金额和税额，否则以请求的总金额为准
+	if len(invoice.Items) > 0 {
+		if err := applyPurchaseInvoiceTax(s.db, &invoice); err != nil {
+			return nil, err
+		}
+	} else if invoice.TotalAmount <= 0 {
+		return nil, errors.New("total amount is required for purchase invoice without items")
+	} else {
+		invoice.BaseTotalAmount = toBaseAmount(invoice.TotalAmount, invoice.ExchangeRate)
+		invoice.BaseTaxAmount = toBaseAmount(invoice.TaxAmount, invoice.ExchangeRate)
+	}
+
+	// 发票日期所在会计期间必须允许记账
+	if err := checkPeriodOpen(s.db, invoice.InvoiceDate, false); err != nil {
+		return nil, err
 	}
 
 	// 保存到数据库
@@ -1043,30 +1078,11 @@ func (s *purchaseService) CreatePurchaseInvoice(req map[string]interface{}) (map
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	createdInvoice := map[string]interface{}{
-		"id":            invoice.ID,
-		"invoice_no":    invoice.InvoiceNo,
-		"order_id":      invoice.OrderID,
-		"receipt_id":    invoice.ReceiptID,
-		"vendor_id":     invoice.VendorID,
-		"invoice_date":  invoice.InvoiceDate,
-		"due_date":      invoice.DueDate,
-		"total_amount":  invoice.TotalAmount,
-		"tax_amount":    invoice.TaxAmount,
-		"status":        invoice.Status,
-		"payment_terms": invoice.PaymentTerms,
-		"remarks":       invoice.Remarks,
-		"created_at":    invoice.CreatedAt,
-		"created_by":    invoice.CreatedBy,
-		"updated_at":    invoice.UpdatedAt,
-		"updated_by":    invoice.UpdatedBy,
-	}
-
-	return createdInvoice, nil
+	response := purchaseInvoiceResponse(invoice)
+	return &response, nil
 }
 
-func (s *purchaseService) UpdatePurchaseInvoice(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *purchaseService) UpdatePurchaseInvoice(id string, req schemas.PurchaseInvoiceUpdateRequest) (*schemas.PurchaseInvoiceResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -1074,52 +1090,102 @@ func (s *purchaseService) UpdatePurchaseInvoice(id string, req map[string]interf
 
 	// 从数据库读取采购发票
 	var invoice models.PurchaseInvoice
-	result := s.db.First(&invoice, "id = ?", id)
+	result := s.db.Preload("Items").First(&invoice, "id = ?", id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
+	// 已审核的发票已生成凭证，不允许修改
+	if invoice.Status == "verified" || invoice.Status == "partially_paid" || invoice.Status == "paid" {
+		return nil, errors.New("verified purchase invoice cannot be modified")
+	}
+
+	// 发票日期所在会计期间必须允许记账
+	if err := checkPeriodOpen(s.db, invoice.InvoiceDate, false); err != nil {
+		return nil, err
+	}
+
 	// 更新字段
-	invoice.OrderID = req["order_id"].(string)
-	invoice.ReceiptID = req["receipt_id"].(string)
-	invoice.VendorID = req["vendor_id"].(string)
-	invoice.InvoiceDate = time.Now()
-	invoice.DueDate = time.Now().AddDate(0, 1, 0) // 默认30天
-	invoice.TotalAmount = req["total_amount"].(float64)
-	invoice.TaxAmount = req["tax_amount"].(float64)
-	invoice.Status = req["status"].(string)
-	invoice.PaymentTerms = req["payment_terms"].(string)
-	invoice.Remarks = req["remarks"].(string)
+	if req.ReceiptID != "" {
+		invoice.ReceiptID = req.ReceiptID
+	}
+	if req.InvoiceDate != "" {
+		invoiceDate, err := time.Parse("2006-01-02", req.InvoiceDate)
+		if err != nil {
+			return nil, err
+		}
+		invoice.InvoiceDate = invoiceDate
+	}
+	if req.DueDate != "" {
+		dueDate, err := time.Parse("2006-01-02", req.DueDate)
+		if err != nil {
+			return nil, err
+		}
+		invoice.DueDate = dueDate
+	}
+	if len(invoice.Items) > 0 && (req.TotalAmount != nil || req.TaxAmount != nil) {
+		return nil, errors.New("amounts of itemized purchase invoice are calculated from items")
+	}
+	if req.TotalAmount != nil {
+		invoice.TotalAmount = *req.TotalAmount
+	}
+	if req.TaxAmount != nil {
+		invoice.TaxAmount = *req.TaxAmount
+	}
+	if req.PaymentTerms != "" {
+		invoice.PaymentTerms = req.PaymentTerms
+	}
+	// 发票日期或付款条件变化且未指定到期日时按付款条件重新计算
+	if req.DueDate == "" && (req.InvoiceDate != "" || req.PaymentTerms != "") {
+		invoice.DueDate = paymentTermsDueDate(invoice.InvoiceDate, invoice.PaymentTerms)
+	}
+	if req.Remarks != "" {
+		invoice.Remarks = req.Remarks
+	}
 	invoice.UpdatedAt = time.Now()
-	invoice.UpdatedBy = req["updated_by"].(string)
+	invoice.UpdatedBy = req.UpdatedBy
 
-	// 保存到数据库
-	result = s.db.Save(&invoice)
-	if result.Error != nil {
-		return nil, result.Error
+	// 修改后的发票日期同样需要校验
+	if err := checkPeriodOpen(s.db, invoice.InvoiceDate, false); err != nil {
+		return nil, err
 	}
 
-	// 将模型转换为map
-	updatedInvoice := map[string]interface{}{
-		"id":            invoice.ID,
-		"invoice_no":    invoice.InvoiceNo,
-		"order_id":      invoice.OrderID,
-		"receipt_id":    invoice.ReceiptID,
-		"vendor_id":     invoice.VendorID,
-		"invoice_date":  invoice.InvoiceDate,
-		"due_date":      invoice.DueDate,
-		"total_amount":  invoice.TotalAmount,
-		"tax_amount":    invoice.TaxAmount,
-		"status":        invoice.Status,
-		"payment_terms": invoice.PaymentTerms,
-		"remarks":       invoice.Remarks,
-		"created_at":    invoice.CreatedAt,
-		"created_by":    invoice.CreatedBy,
-		"updated_at":    invoice.UpdatedAt,
-		"updated_by":    invoice.UpdatedBy,
-	}
-
-	return updatedInvoice, nil
+	// 发票日期变化或指定汇率时重新确定汇率
+	if req.InvoiceDate != "" || req.ExchangeRate > 0 {
+		var err error
+		invoice.Currency, invoice.ExchangeRate, err = documentCurrency(s.db, invoice.Currency, "", req.ExchangeRate, invoice.InvoiceDate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 有明细的发票按新的发票日期重新确定税率
+	if len(invoice.Items) == 0 {
+		invoice.BaseTotalAmount = toBaseAmount(invoice.TotalAmount, invoice.ExchangeRate)
+		invoice.BaseTaxAmount = toBaseAmount(invoice.TaxAmount, invoice.ExchangeRate)
+		if err := s.db.Omit("Items").Save(&invoice).Error; err != nil {
+			return nil, err
+		}
+		response := purchaseInvoiceResponse(invoice)
+		return &response, nil
+	}
+	if err := applyPurchaseInvoiceTax(s.db, &invoice); err != nil {
+		return nil, err
+	}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		for _, item := range invoice.Items {
+			if err := tx.Save(&item).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Omit("Items").Save(&invoice).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := purchaseInvoiceResponse(invoice)
+	return &response, nil
 }
 
 func (s *purchaseService) DeletePurchaseInvoice(id string) error {
@@ -1128,13 +1194,36 @@ func (s *purchaseService) DeletePurchaseInvoice(id string) error {
 		return errors.New("database connection is nil")
 	}
 
-	// 从数据库删除采购发票
-	result := s.db.Delete(&models.PurchaseInvoice{}, "id = ?", id)
+	// 从数据库读取采购发票
+	var invoice models.PurchaseInvoice
+	result := s.db.First(&invoice, "id = ?", id)
 	if result.Error != nil {
 		return result.Error
 	}
 
-	return nil
+	// 已付款或已享受折扣的发票不能删除
+	if invoice.PaidAmount > 0 || invoice.DiscountAmount > 0 {
+		return errors.New("paid purchase invoice cannot be deleted")
+	}
+
+	// 发票日期所在会计期间必须允许记账
+	if err := checkPeriodOpen(s.db, invoice.InvoiceDate, false); err != nil {
+		return err
+	}
+
+	// 在同一事务中冲销审核生成的凭证，删除价格历史和采购发票
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := reverseDocumentJournals(tx, postingDocumentPurchaseInvoice, invoice.ID, time.Now(), "system"); err != nil {
+			return err
+		}
+		if err := tx.Where("invoice_id = ?", invoice.ID).Delete(&models.PurchaseInvoiceDiscrepancy{}).Error; err != nil {
+			return err
+		}
+		if err := deletePriceHistory(tx, priceSourceInvoice, invoice.ID); err != nil {
+			return err
+		}
+		return tx.Delete(&invoice).Error
+	})
 }
 
 func (s *purchaseService) VerifyPurchaseInvoice(id string) error {
@@ -1143,56 +1232,249 @@ func (s *purchaseService) VerifyPurchaseInvoice(id string) error {
 		return errors.New("database connection is nil")
 	}
 
-	// 从数据库读取采购发票
+	// 从数据库读取采购发票及明细
 	var invoice models.PurchaseInvoice
-	result := s.db.First(&invoice, "id = ?", id)
+	result := s.db.Preload("Items").First(&invoice, "id = ?", id)
 	if result.Error != nil {
 		return result.Error
 	}
 
-	// 更新状态为verified
+	// 冻结的发票可在修正订单、收货后重新审核
+	if invoice.Status != "unpaid" && invoice.Status != "blocked" {
+		return fmt.Errorf("purchase invoice in status %s cannot be verified", invoice.Status)
+	}
+
+	// 发票日期所在会计期间必须允许记账
+	if err := checkPeriodOpen(s.db, invoice.InvoiceDate, false); err != nil {
+		return err
+	}
+
+	// 与采购订单单价和收货数量三单匹配，超出容差时记录差异并冻结发票，需授权人员放行
+	discrepancies, err := matchPurchaseInvoice(s.db, invoice)
+	if err != nil {
+		return err
+	}
+	if len(discrepancies) > 0 {
+		blockReason := purchaseInvoiceBlockReason(discrepancies)
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("invoice_id = ?", invoice.ID).Delete(&models.PurchaseInvoiceDiscrepancy{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Create(&discrepancies).Error; err != nil {
+				return err
+			}
+			return updatePurchaseInvoiceStatus(tx, invoice.ID, invoice.Status, map[string]interface{}{
+				"status":       "blocked",
+				"block_reason": blockReason,
+				"updated_by":   "system",
+				"updated_at":   time.Now(),
+			})
+		})
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: %s", ErrInvoiceBlocked, blockReason)
+	}
+
+	// 匹配通过后清除此前的差异，在同一事务中按读取时的状态条件更新状态为verified，防止并发重复审核，
+	// 再记录采购价格历史并按记账规则生成凭证
+	status := invoice.Status
 	invoice.Status = "verified"
+	invoice.BlockReason = ""
 	invoice.UpdatedAt = time.Now()
 	invoice.UpdatedBy = "system"
-
-	// 保存到数据库
-	result = s.db.Save(&invoice)
-	if result.Error != nil {
-		return result.Error
-	}
-
-	return nil
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		err := updatePurchaseInvoiceStatus(tx, invoice.ID, status, map[string]interface{}{
+			"status":       invoice.Status,
+			"block_reason": invoice.BlockReason,
+			"updated_by":   invoice.UpdatedBy,
+			"updated_at":   invoice.UpdatedAt,
+		})
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("invoice_id = ?", invoice.ID).Delete(&models.PurchaseInvoiceDiscrepancy{}).Error; err != nil {
+			return err
+		}
+		if err := recordInvoicePriceHistory(tx, invoice); err != nil {
+			return err
+		}
+		_, err = postDocument(tx, purchaseInvoicePostingDocument(invoice))
+		return err
+	})
 }
 
-func (s *purchaseService) PayPurchaseInvoice(id string) error {
+// ReleaseInvoiceBlock 放行匹配冻结的采购发票，operator为放行人，取自当前登录用户
+func (s *purchaseService) ReleaseInvoiceBlock(id, operator string, req schemas.ReleaseInvoiceBlockRequest) error {
 	// 检查数据库连接
 	if s.db == nil {
 		return errors.New("database connection is nil")
 	}
 
-	// 从数据库读取采购发票
+	// 从数据库读取采购发票及明细
 	var invoice models.PurchaseInvoice
-	result := s.db.First(&invoice, "id = ?", id)
+	result := s.db.Preload("Items").First(&invoice, "id = ?", id)
 	if result.Error != nil {
 		return result.Error
 	}
 
-	// 更新状态为paid
-	invoice.Status = "paid"
-	invoice.UpdatedAt = time.Now()
-	invoice.UpdatedBy = "system"
+	if invoice.Status != "blocked" {
+		return fmt.Errorf("purchase invoice in status %s is not blocked", invoice.Status)
+	}
 
-	// 保存到数据库
-	result = s.db.Save(&invoice)
+	// 发票日期所在会计期间必须允许记账
+	if err := checkPeriodOpen(s.db, invoice.InvoiceDate, false); err != nil {
+		return err
+	}
+
+	// 放行后发票直接审核、记录采购价格历史并生成凭证，保留冻结原因和差异记录，并记录放行人和原因；
+	// 按冻结状态条件更新，防止并发重复放行
+	now := time.Now()
+	invoice.Status = "verified"
+	invoice.BlockReleasedBy = operator
+	invoice.BlockReleasedAt = &now
+	invoice.BlockReleaseReason = req.Reason
+	invoice.UpdatedAt = now
+	invoice.UpdatedBy = operator
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		err := updatePurchaseInvoiceStatus(tx, invoice.ID, "blocked", map[string]interface{}{
+			"status":               invoice.Status,
+			"block_released_by":    invoice.BlockReleasedBy,
+			"block_released_at":    invoice.BlockReleasedAt,
+			"block_release_reason": invoice.BlockReleaseReason,
+			"updated_by":           invoice.UpdatedBy,
+			"updated_at":           invoice.UpdatedAt,
+		})
+		if err != nil {
+			return err
+		}
+		if err := recordInvoicePriceHistory(tx, invoice); err != nil {
+			return err
+		}
+		_, err = postDocument(tx, purchaseInvoicePostingDocument(invoice))
+		return err
+	})
+}
+
+// updatePurchaseInvoiceStatus 按当前状态条件更新采购发票，发票已被并发处理、状态不符时返回错误
+func updatePurchaseInvoiceStatus(tx *gorm.DB, id, status string, values map[string]interface{}) error {
+	result := tx.Model(&models.PurchaseInvoice{}).Where("id = ? AND status = ?", id, status).Updates(values)
 	if result.Error != nil {
 		return result.Error
 	}
-
+	if result.RowsAffected == 0 {
+		return errors.New("purchase invoice has already been processed")
+	}
 	return nil
 }
 
+// PayPurchaseInvoice 直接为已审核的采购发票付款：生成并处理一张核销该发票的付款单。
+// 未指定金额时付清余额，付款日仍在折扣期内的同时享受提前付款折扣
+func (s *purchaseService) PayPurchaseInvoice(id string, req schemas.PayPurchaseInvoiceRequest) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 解析付款日期，默认当天
+	date := time.Now()
+	if req.PaymentDate != "" {
+		var err error
+		date, err = time.Parse("2006-01-02", req.PaymentDate)
+		if err != nil {
+			return err
+		}
+	}
+	createdBy := req.CreatedBy
+	if createdBy == "" {
+		createdBy = "system"
+	}
+	paymentMethod := req.PaymentMethod
+	if paymentMethod == "" {
+		paymentMethod = "bank_transfer"
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		// 从数据库读取采购发票，未审核或匹配冻结的发票不能付款
+		var invoice models.PurchaseInvoice
+		if err := tx.Preload("Vendor").First(&invoice, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if !containsString(payableInvoiceStatuses, invoice.Status) {
+			return fmt.Errorf("purchase invoice in status %s cannot be paid", invoice.Status)
+		}
+		balance := purchaseInvoiceBalance(invoice)
+		if balance <= 0 {
+			return errors.New("purchase invoice is already settled")
+		}
+
+		// 付清余额时扣除可享受的提前付款折扣
+		amount, discount := roundAmount(req.Amount), 0.0
+		if amount <= 0 {
+			discount, _ = invoiceEarlyDiscount(invoice, invoicePaymentTerms(invoice), date)
+			amount = roundAmount(balance - discount)
+		}
+		if amount > balance {
+			return fmt.Errorf("payment amount %.2f exceeds invoice balance %.2f", amount, balance)
+		}
+
+		currency, rate, err := documentCurrency(tx, invoice.Currency, "", 0, date)
+		if err != nil {
+			return err
+		}
+		payment := models.FinancePayment{
+			ID:            utils.GenerateID(),
+			PaymentNo:     autoJournalNo("PM"),
+			PaymentDate:   date,
+			VendorID:      invoice.VendorID,
+			Amount:        amount,
+			Currency:      currency,
+			ExchangeRate:  rate,
+			BaseAmount:    toBaseAmount(amount, rate),
+			PaymentMethod: paymentMethod,
+			BankAccountID: req.BankAccountID,
+			Reference:     invoice.InvoiceNo,
+			Description:   fmt.Sprintf("采购发票%s付款", invoice.InvoiceNo),
+			Remarks:       req.Remarks,
+			Status:        paymentStatusApproved,
+			CreatedBy:     createdBy,
+			UpdatedBy:     createdBy,
+		}
+		allocations := []models.FinancePaymentAllocation{{
+			ID:             utils.GenerateID(),
+			PaymentID:      payment.ID,
+			InvoiceID:      invoice.ID,
+			AllocationDate: date,
+			Amount:         amount,
+			DiscountAmount: discount,
+			CreatedBy:      createdBy,
+			UpdatedBy:      createdBy,
+		}}
+
+		if err := processPayment(tx, &payment, allocations, createdBy); err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Create(&payment).Error; err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Create(&allocations).Error
+	})
+}
+
+// purchaseReturnListSpec 采购退货单列表查询白名单
+var purchaseReturnListSpec = query.NewSpec("-return_date",
+	query.Text("return_no"),
+	query.Text("order_id"),
+	query.Text("receipt_id"),
+	query.Text("vendor_id"),
+	query.Text("status"),
+	query.Number("total_amount"),
+	query.Date("return_date"),
+	query.Date("created_at"),
+)
+
 // 采购退货管理方法
-func (s *purchaseService) GetPurchaseReturnList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *purchaseService) GetPurchaseReturnList(params query.Params) (*query.Page[schemas.PurchaseReturnResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -1200,125 +1482,103 @@ func (s *purchaseService) GetPurchaseReturnList(req map[string]interface{}) ([]m
 
 	// 从数据库读取采购退货单数据
 	var returns []models.PurchaseReturn
-	result := s.db.Find(&returns)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, purchaseReturnListSpec, &returns)
+	if err != nil {
+		return nil, err
 	}
 
-	// 将模型转换为map
-	returnList := make([]map[string]interface{}, len(returns))
+	// 转换为响应结构
+	returnList := make([]schemas.PurchaseReturnResponse, len(returns))
 	for i, returnOrder := range returns {
-		returnList[i] = map[string]interface{}{
-			"id":             returnOrder.ID,
-			"return_no":      returnOrder.ReturnNo,
-			"order_id":       returnOrder.OrderID,
-			"receipt_id":     returnOrder.ReceiptID,
-			"vendor_id":      returnOrder.VendorID,
-			"return_date":    returnOrder.ReturnDate,
-			"total_quantity": returnOrder.TotalQuantity,
-			"total_amount":   returnOrder.TotalAmount,
-			"status":         returnOrder.Status,
-			"reason":         returnOrder.Reason,
-			"remarks":        returnOrder.Remarks,
-			"created_at":     returnOrder.CreatedAt,
-			"created_by":     returnOrder.CreatedBy,
-			"updated_at":     returnOrder.UpdatedAt,
-			"updated_by":     returnOrder.UpdatedBy,
-		}
+		returnList[i] = purchaseReturnResponse(returnOrder)
 	}
 
-	return returnList, nil
+	return query.NewPage(returnList, total, params), nil
 }
 
-func (s *purchaseService) GetPurchaseReturnDetail(id string) (map[string]interface{}, error) {
+func (s *purchaseService) GetPurchaseReturnDetail(id string) (*schemas.PurchaseReturnResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 从数据库读取采购退货单详情
+	// 从数据库读取采购退货单详情及明细
 	var returnOrder models.PurchaseReturn
-	result := s.db.First(&returnOrder, "id = ?", id)
+	result := s.db.Preload("Items").First(&returnOrder, "id = ?", id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	returnDetail := map[string]interface{}{
-		"id":             returnOrder.ID,
-		"return_no":      returnOrder.ReturnNo,
-		"order_id":       returnOrder.OrderID,
-		"receipt_id":     returnOrder.ReceiptID,
-		"vendor_id":      returnOrder.VendorID,
-		"return_date":    returnOrder.ReturnDate,
-		"total_quantity": returnOrder.TotalQuantity,
-		"total_amount":   returnOrder.TotalAmount,
-		"status":         returnOrder.Status,
-		"reason":         returnOrder.Reason,
-		"remarks":        returnOrder.Remarks,
-		"created_at":     returnOrder.CreatedAt,
-		"created_by":     returnOrder.CreatedBy,
-		"updated_at":     returnOrder.UpdatedAt,
-		"updated_by":     returnOrder.UpdatedBy,
-	}
-
-	return returnDetail, nil
+	response := purchaseReturnResponse(returnOrder)
+	return &response, nil
 }
 
-func (s *purchaseService) CreatePurchaseReturn(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *purchaseService) CreatePurchaseReturn(req schemas.PurchaseReturnCreateRequest) (*schemas.PurchaseReturnResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 从请求中获取数据
+	// 解析退货日期
+	returnDate, err := time.Parse("2006-01-02", req.ReturnDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 供应商以采购订单为准
+	var order models.PurchaseOrder
+	if err := s.db.First(&order, "id = ?", req.OrderID).Error; err != nil {
+		return nil, err
+	}
+
 	returnOrder := models.PurchaseReturn{
-		ID:            req["id"].(string),
-		ReturnNo:      req["return_no"].(string),
-		OrderID:       req["order_id"].(string),
-		ReceiptID:     req["receipt_id"].(string),
-		VendorID:      req["vendor_id"].(string),
-		ReturnDate:    time.Now(),
-		TotalQuantity: req["total_quantity"].(float64),
-		TotalAmount:   req["total_amount"].(float64),
-		Status:        req["status"].(string),
-		Reason:        req["reason"].(string),
-		Remarks:       req["remarks"].(string),
-		CreatedAt:     time.Now(),
-		CreatedBy:     req["created_by"].(string),
-		UpdatedAt:     time.Now(),
-		UpdatedBy:     req["created_by"].(string),
+		ID:         utils.GenerateID(),
+		ReturnNo:   req.ReturnNo,
+		OrderID:    order.ID,
+		ReceiptID:  req.ReceiptID,
+		VendorID:   order.VendorID,
+		ReturnDate: returnDate,
+		Status:     "pending",
+		Reason:     req.Reason,
+		Remarks:    req.Remarks,
+		CreatedAt:  time.Now(),
+		CreatedBy:  req.CreatedBy,
+		UpdatedAt:  time.Now(),
+		UpdatedBy:  req.CreatedBy,
+	}
+
+	// 构建退货明细并汇总数量金额
+	returnOrder.Items = make([]models.PurchaseReturnItem, len(req.Items))
+	for i, item := range req.Items {
+		amount := item.Quantity * item.UnitPrice
+		returnOrder.Items[i] = models.PurchaseReturnItem{
+			ID:          utils.GenerateID(),
+			ReturnID:    returnOrder.ID,
+			ItemID:      item.ItemID,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Amount:      amount,
+			WarehouseID: item.WarehouseID,
+			CreatedBy:   req.CreatedBy,
+			CreatedAt:   time.Now(),
+			UpdatedBy:   req.CreatedBy,
+			UpdatedAt:   time.Now(),
+		}
+		returnOrder.TotalQuantity += item.Quantity
+		returnOrder.TotalAmount += amount
 	}
 
-	// 保存到数据库
+	// 保存退货单及明细到数据库
 	result := s.db.Create(&returnOrder)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	createdReturn := map[string]interface{}{
-		"id":             returnOrder.ID,
-		"return_no":      returnOrder.ReturnNo,
-		"order_id":       returnOrder.OrderID,
-		"receipt_id":     returnOrder.ReceiptID,
-		"vendor_id":      returnOrder.VendorID,
-		"return_date":    returnOrder.ReturnDate,
-		"total_quantity": returnOrder.TotalQuantity,
-		"total_amount":   returnOrder.TotalAmount,
-		"status":         returnOrder.Status,
-		"reason":         returnOrder.Reason,
-		"remarks":        returnOrder.Remarks,
-		"created_at":     returnOrder.CreatedAt,
-		"created_by":     returnOrder.CreatedBy,
-		"updated_at":     returnOrder.UpdatedAt,
-		"updated_by":     returnOrder.UpdatedBy,
-	}
-
-	return createdReturn, nil
+	response := purchaseReturnResponse(returnOrder)
+	return &response, nil
 }
 
-func (s *purchaseService) UpdatePurchaseReturn(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *purchaseService) UpdatePurchaseReturn(id string, req schemas.PurchaseReturnUpdateRequest) (*schemas.PurchaseReturnResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -1326,50 +1586,41 @@ func (s *purchaseService) UpdatePurchaseReturn(id string, req map[string]interfa
 
 	// 从数据库读取采购退货单
 	var returnOrder models.PurchaseReturn
-	result := s.db.First(&returnOrder, "id = ?", id)
+	result := s.db.Preload("Items").First(&returnOrder, "id = ?", id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
+	// 已完成的退货单不允许修改
+	if returnOrder.Status == "completed" {
+		return nil, errors.New("completed purchase return cannot be modified")
+	}
+
 	// 更新字段
-	returnOrder.OrderID = req["order_id"].(string)
-	returnOrder.ReceiptID = req["receipt_id"].(string)
-	returnOrder.VendorID = req["vendor_id"].(string)
-	returnOrder.ReturnDate = time.Now()
-	returnOrder.TotalQuantity = req["total_quantity"].(float64)
-	returnOrder.TotalAmount = req["total_amount"].(float64)
-	returnOrder.Status = req["status"].(string)
-	returnOrder.Reason = req["reason"].(string)
-	returnOrder.Remarks = req["remarks"].(string)
+	if req.ReturnDate != "" {
+		returnDate, err := time.Parse("2006-01-02", req.ReturnDate)
+		if err != nil {
+			return nil, err
+		}
+		returnOrder.ReturnDate = returnDate
+	}
+	if req.Reason != "" {
+		returnOrder.Reason = req.Reason
+	}
+	if req.Remarks != "" {
+		returnOrder.Remarks = req.Remarks
+	}
 	returnOrder.UpdatedAt = time.Now()
-	returnOrder.UpdatedBy = req["updated_by"].(string)
+	returnOrder.UpdatedBy = req.UpdatedBy
 
 	// 保存到数据库
-	result = s.db.Save(&returnOrder)
+	result = s.db.Omit("Items").Save(&returnOrder)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	updatedReturn := map[string]interface{}{
-		"id":             returnOrder.ID,
-		"return_no":      returnOrder.ReturnNo,
-		"order_id":       returnOrder.OrderID,
-		"receipt_id":     returnOrder.ReceiptID,
-		"vendor_id":      returnOrder.VendorID,
-		"return_date":    returnOrder.ReturnDate,
-		"total_quantity": returnOrder.TotalQuantity,
-		"total_amount":   returnOrder.TotalAmount,
-		"status":         returnOrder.Status,
-		"reason":         returnOrder.Reason,
-		"remarks":        returnOrder.Remarks,
-		"created_at":     returnOrder.CreatedAt,
-		"created_by":     returnOrder.CreatedBy,
-		"updated_at":     returnOrder.UpdatedAt,
-		"updated_by":     returnOrder.UpdatedBy,
-	}
-
-	return updatedReturn, nil
+	response := purchaseReturnResponse(returnOrder)
+	return &response, nil
 }
 
 func (s *purchaseService) DeletePurchaseReturn(id string) error {
@@ -1415,68 +1666,280 @@ func (s *purchaseService) CompletePurchaseReturn(id string) error {
 }
 
 // 采购报表管理方法
-func (s *purchaseService) GetPurchaseSummaryReport(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *purchaseService) GetPurchaseSummaryReport(req schemas.PurchaseReportRequest) (*schemas.PurchaseSummaryReportResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
 	// 实际应用中，这里需要基于数据库数据生成采购汇总报表
-	// 暂时返回空map
-	return map[string]interface{}{}, nil
+	// 暂时返回空结果
+	return &schemas.PurchaseSummaryReportResponse{
+		Period:        reportPeriod(req.StartDate, req.EndDate),
+		SupplierStats: []schemas.SupplierStatResponse{},
+	}, nil
 }
 
-func (s *purchaseService) GetPurchaseDetailReport(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *purchaseService) GetPurchaseDetailReport(req schemas.PurchaseReportRequest) (*schemas.PurchaseDetailReportResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
 	// 实际应用中，这里需要基于数据库数据生成采购明细报表
-	// 暂时返回空map
-	return map[string]interface{}{}, nil
+	// 暂时返回空结果
+	return &schemas.PurchaseDetailReportResponse{
+		Period: reportPeriod(req.StartDate, req.EndDate),
+		Items:  []schemas.PurchaseDetailReportItem{},
+	}, nil
 }
 
-func (s *purchaseService) GetSupplierAnalysisReport(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *purchaseService) ExportPurchaseReport(req schemas.ExportPurchaseReportRequest) ([]byte, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 实际应用中，这里需要基于数据库数据生成供应商分析报表
-	// 暂时返回空map
-	return map[string]interface{}{}, nil
+	// 实际应用中，这里需要基于数据库数据导出采购报表
+	// 暂时返回空字节数组
+	return []byte{}, nil
 }
 
-func (s *purchaseService) GetPriceAnalysisReport(req map[string]interface{}) (map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+// purchaseOrderItems 根据请求构建采购订单明细并汇总订单金额
+func purchaseOrderItems(orderID string, items []schemas.PurchaseOrderItemRequest, operator string) ([]models.PurchaseOrderItem, float64) {
+	orderItems := make([]models.PurchaseOrderItem, len(items))
+	var total float64
+	for i, item := range items {
+		amount := item.Quantity*item.UnitPrice - item.Discount
+		orderItems[i] = models.PurchaseOrderItem{
+			ID:          utils.GenerateID(),
+			OrderID:     orderID,
+			ItemID:      item.ItemID,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Discount:    item.Discount,
+			Amount:      amount,
+			WarehouseID: item.WarehouseID,
+			CreatedBy:   operator,
+			CreatedAt:   time.Now(),
+			UpdatedBy:   operator,
+			UpdatedAt:   time.Now(),
+		}
+		total += amount
 	}
+	return orderItems, total
+}
 
-	// 实际应用中，这里需要基于数据库数据生成价格分析报表
-	// 暂时返回空map
-	return map[string]interface{}{}, nil
+// purchaseDocumentCurrency 确定采购订单的币种及汇率，未指定币种时取供应商默认币种
+func purchaseDocumentCurrency(db *gorm.DB, vendorID, currency string, override float64, date time.Time) (string, float64, error) {
+	var vendor models.PurchaseVendor
+	if err := db.First(&vendor, "id = ?", vendorID).Error; err != nil {
+		return "", 0, err
+	}
+	return documentCurrency(db, currency, vendor.Currency, override, date)
 }
 
-func (s *purchaseService) GetPurchaseForecastReport(req map[string]interface{}) (map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+// supplierResponse 将供应商模型转换为响应结构
+func supplierResponse(vendor models.PurchaseVendor) schemas.SupplierResponse {
+	return schemas.SupplierResponse{
+		ID:            vendor.ID,
+		VendorNo:      vendor.VendorNo,
+		Name:          vendor.Name,
+		ContactPerson: vendor.ContactPerson,
+		Phone:         vendor.Phone,
+		Email:         vendor.Email,
+		Address:       vendor.Address,
+		TaxNo:         vendor.TaxNo,
+		BankName:      vendor.BankName,
+		BankAccount:   vendor.BankAccount,
+		Category:      vendor.Category,
+		CreditLimit:   vendor.CreditLimit,
+		LeadTime:      vendor.LeadTime,
+		TaxStatus:     vendor.TaxStatus,
+		Currency:      vendor.Currency,
+		PaymentTerms:  vendor.PaymentTerms,
+		Remarks:       vendor.Remarks,
+		Status:        vendor.Status,
+		CreatedBy:     vendor.CreatedBy,
+		CreatedAt:     vendor.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedBy:     vendor.UpdatedBy,
+		UpdatedAt:     vendor.UpdatedAt.Format("2006-01-02 15:04:05"),
 	}
+}
 
-	// 实际应用中，这里需要基于数据库数据生成采购预测报表
-	// 暂时返回空map
-	return map[string]interface{}{}, nil
+// purchaseOrderResponse 将采购订单模型转换为响应结构
+func purchaseOrderResponse(order models.PurchaseOrder) schemas.PurchaseOrderResponse {
+	response := schemas.PurchaseOrderResponse{
+		ID:              order.ID,
+		OrderNo:         order.OrderNo,
+		VendorID:        order.VendorID,
+		OrderDate:       order.OrderDate.Format("2006-01-02"),
+		TotalAmount:     order.TotalAmount,
+		Currency:        order.Currency,
+		ExchangeRate:    order.ExchangeRate,
+		BaseTotalAmount: order.BaseTotalAmount,
+		Status:          order.Status,
+		PaymentTerms:    order.PaymentTerms,
+		Items:           make([]schemas.PurchaseOrderItemResponse, len(order.Items)),
+		Remarks:         order.Remarks,
+		CreatedBy:       order.CreatedBy,
+		CreatedAt:       order.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedBy:       order.UpdatedBy,
+		UpdatedAt:       order.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if order.DeliveryDate != nil {
+		response.DeliveryDate = order.DeliveryDate.Format("2006-01-02")
+	}
+	for i, item := range order.Items {
+		response.Items[i] = schemas.PurchaseOrderItemResponse{
+			ID:                item.ID,
+			OrderID:           item.OrderID,
+			ItemID:            item.ItemID,
+			Quantity:          item.Quantity,
+			UnitPrice:         item.UnitPrice,
+			Discount:          item.Discount,
+			Amount:            item.Amount,
+			ReceivedQuantity:  item.ReceivedQuantity,
+			WarehouseID:       item.WarehouseID,
+			RequisitionItemID: item.RequisitionItemID,
+			PlanItemID:        item.PlanItemID,
+		}
+	}
+	return response
 }
 
-func (s *purchaseService) ExportPurchaseReport(req map[string]interface{}) ([]byte, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+// purchaseReceiptResponse 将采购收货单模型转换为响应结构
+func purchaseReceiptResponse(receipt models.PurchaseReceipt) schemas.ReceiptResponse {
+	response := schemas.ReceiptResponse{
+		ID:            receipt.ID,
+		ReceiptNo:     receipt.ReceiptNo,
+		OrderID:       receipt.OrderID,
+		VendorID:      receipt.VendorID,
+		ReceiptDate:   receipt.ReceiptDate.Format("2006-01-02"),
+		TotalQuantity: receipt.TotalQuantity,
+		TotalAmount:   receipt.TotalAmount,
+		Status:        receipt.Status,
+		Items:         make([]schemas.ReceiptItemResponse, len(receipt.Items)),
+		Remarks:       receipt.Remarks,
+		CreatedBy:     receipt.CreatedBy,
+		CreatedAt:     receipt.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedBy:     receipt.UpdatedBy,
+		UpdatedAt:     receipt.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+	for i, item := range receipt.Items {
+		response.Items[i] = schemas.ReceiptItemResponse{
+			ID:          item.ID,
+			ReceiptID:   item.ReceiptID,
+			OrderItemID: item.OrderItemID,
+			ItemID:      item.ItemID,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Amount:      item.Amount,
+			WarehouseID: item.WarehouseID,
+			LocationID:  item.LocationID,
+		}
 	}
+	return response
+}
 
-	// 实际应用中，这里需要基于数据库数据导出采购报表
-	// 暂时返回空字节数组
-	return []byte{}, nil
+// purchaseInvoiceResponse 将采购发票模型转换为响应结构
+func purchaseInvoiceResponse(invoice models.PurchaseInvoice) schemas.PurchaseInvoiceResponse {
+	response := schemas.PurchaseInvoiceResponse{
+		ID:                 invoice.ID,
+		InvoiceNo:          invoice.InvoiceNo,
+		OrderID:            invoice.OrderID,
+		ReceiptID:          invoice.ReceiptID,
+		VendorID:           invoice.VendorID,
+		InvoiceDate:        invoice.InvoiceDate.Format("2006-01-02"),
+		DueDate:            invoice.DueDate.Format("2006-01-02"),
+		TotalAmount:        invoice.TotalAmount,
+		PaidAmount:         invoice.PaidAmount,
+		DiscountAmount:     invoice.DiscountAmount,
+		TaxAmount:          invoice.TaxAmount,
+		PriceIncludesTax:   invoice.PriceIncludesTax,
+		Currency:           invoice.Currency,
+		ExchangeRate:       invoice.ExchangeRate,
+		BaseTotalAmount:    invoice.BaseTotalAmount,
+		BaseTaxAmount:      invoice.BaseTaxAmount,
+		Status:             invoice.Status,
+		BlockReason:        invoice.BlockReason,
+		BlockReleasedBy:    invoice.BlockReleasedBy,
+		BlockReleaseReason: invoice.BlockReleaseReason,
+		PaymentTerms:       invoice.PaymentTerms,
+		Items:              make([]schemas.PurchaseInvoiceItemResponse, len(invoice.Items)),
+		Discrepancies:      make([]schemas.PurchaseInvoiceDiscrepancyResponse, len(invoice.Discrepancies)),
+		Remarks:            invoice.Remarks,
+		CreatedBy:          invoice.CreatedBy,
+		CreatedAt:          invoice.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedBy:          invoice.UpdatedBy,
+		UpdatedAt:          invoice.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+	for i, item := range invoice.Items {
+		response.Items[i] = schemas.PurchaseInvoiceItemResponse{
+			ID:          item.ID,
+			InvoiceID:   item.InvoiceID,
+			OrderItemID: item.OrderItemID,
+			ItemID:      item.ItemID,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Discount:    item.Discount,
+			Amount:      item.Amount,
+			TaxCodeID:   item.TaxCodeID,
+			TaxRate:     item.TaxRate,
+			NetAmount:   item.NetAmount,
+			TaxAmount:   item.TaxAmount,
+		}
+	}
+	for i, discrepancy := range invoice.Discrepancies {
+		response.Discrepancies[i] = schemas.PurchaseInvoiceDiscrepancyResponse{
+			ID:              discrepancy.ID,
+			InvoiceItemID:   discrepancy.InvoiceItemID,
+			OrderItemID:     discrepancy.OrderItemID,
+			Type:            discrepancy.Type,
+			Expected:        discrepancy.Expected,
+			Actual:          discrepancy.Actual,
+			Variance:        discrepancy.Variance,
+			VariancePercent: discrepancy.VariancePercent,
+			Tolerance:       discrepancy.Tolerance,
+			Reason:          discrepancy.Reason,
+		}
+	}
+	if invoice.BlockReleasedAt != nil {
+		response.BlockReleasedAt = invoice.BlockReleasedAt.Format("2006-01-02 15:04:05")
+	}
+	return response
+}
+
+// purchaseReturnResponse 将采购退货单模型转换为响应结构
+func purchaseReturnResponse(returnOrder models.PurchaseReturn) schemas.PurchaseReturnResponse {
+	response := schemas.PurchaseReturnResponse{
+		ID:            returnOrder.ID,
+		ReturnNo:      returnOrder.ReturnNo,
+		OrderID:       returnOrder.OrderID,
+		ReceiptID:     returnOrder.ReceiptID,
+		VendorID:      returnOrder.VendorID,
+		ReturnDate:    returnOrder.ReturnDate.Format("2006-01-02"),
+		TotalQuantity: returnOrder.TotalQuantity,
+		TotalAmount:   returnOrder.TotalAmount,
+		Status:        returnOrder.Status,
+		Reason:        returnOrder.Reason,
+		Items:         make([]schemas.PurchaseReturnItemResponse, len(returnOrder.Items)),
+		Remarks:       returnOrder.Remarks,
+		CreatedBy:     returnOrder.CreatedBy,
+		CreatedAt:     returnOrder.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedBy:     returnOrder.UpdatedBy,
+		UpdatedAt:     returnOrder.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+	for i, item := range returnOrder.Items {
+		response.Items[i] = schemas.PurchaseReturnItemResponse{
+			ID:          item.ID,
+			ReturnID:    item.ReturnID,
+			ItemID:      item.ItemID,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Amount:      item.Amount,
+			WarehouseID: item.WarehouseID,
+		}
+	}
+	return response
 }
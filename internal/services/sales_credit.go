@@ -0,0 +1,198 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wu136995/ginx/internal/config"
+	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/utils"
+	"gorm.io/gorm"
+)
+
+// ErrCreditHold 客户信用占用或逾期超限，订单审批被信用冻结
+var ErrCreditHold = errors.New("sales order is on credit hold")
+
+// creditExposureOrderStatuses 已审批尚未全部开票、占用信用额度的订单状态
+var creditExposureOrderStatuses = []string{"approved", "partially_shipped", "shipped"}
+
+// customerCreditExposure 客户信用占用明细
+type customerCreditExposure struct {
+	OpenOrderAmount     float64 // 已审批订单未开票金额
+	UnpaidInvoiceAmount float64 // 未收发票余额
+	OverdueAmount       float64 // 已过到期日的发票余额
+	OverdueDays         int     // 最长逾期天数
+}
+
+// Total 信用占用合计
+func (e customerCreditExposure) Total() float64 {
+	return roundAmount(e.OpenOrderAmount + e.UnpaidInvoiceAmount)
+}
+
+// calculateCreditExposure 按已审批订单未开票金额和未收发票余额计算客户信用占用，外币单据按单据汇率折算为本位币，
+// 逾期按asOf日期计算
+func calculateCreditExposure(db *gorm.DB, customerID string, asOf time.Time) (customerCreditExposure, error) {
+	var exposure customerCreditExposure
+
+	// 已审批订单金额扣除已开票金额
+	var orders []models.SalesOrder
+	result := db.Where("customer_id = ? AND status IN ?", customerID, creditExposureOrderStatuses).Find(&orders)
+	if result.Error != nil {
+		return exposure, result.Error
+	}
+	if len(orders) > 0 {
+		orderIDs := make([]string, len(orders))
+		for i, order := range orders {
+			orderIDs[i] = order.ID
+		}
+
+		var rows []struct {
+			OrderID string
+			Amount  float64
+		}
+		result := db.Model(&models.SalesInvoice{}).
+			Select("order_id, SUM(CASE WHEN price_includes_tax THEN total_amount ELSE total_amount - tax_amount END) AS amount").
+			Where("order_id IN ?", orderIDs).
+			Group("order_id").
+			Scan(&rows)
+		if result.Error != nil {
+			return exposure, result.Error
+		}
+		invoiced := make(map[string]float64, len(rows))
+		for _, row := range rows {
+			invoiced[row.OrderID] = row.Amount
+		}
+
+		for _, order := range orders {
+			if open := order.TotalAmount - invoiced[order.ID]; open > 0 {
+				exposure.OpenOrderAmount += toBaseAmount(open, order.ExchangeRate)
+			}
+		}
+	}
+
+	// 未收发票余额及逾期情况
+	var invoices []models.SalesInvoice
+	result = db.Where("customer_id = ? AND status <> ?", customerID, "paid").Find(&invoices)
+	if result.Error != nil {
+		return exposure, result.Error
+	}
+	for _, invoice := range invoices {
+		if salesInvoiceBalance(invoice) <= 0 {
+			continue
+		}
+		balance := toBaseAmount(salesInvoiceBalance(invoice), invoice.ExchangeRate)
+		exposure.UnpaidInvoiceAmount += balance
+		if invoice.DueDate.Before(asOf) {
+			exposure.OverdueAmount += balance
+			if days := int(asOf.Sub(invoice.DueDate).Hours() / 24); days > exposure.OverdueDays {
+				exposure.OverdueDays = days
+			}
+		}
+	}
+
+	exposure.OpenOrderAmount = roundAmount(exposure.OpenOrderAmount)
+	exposure.UnpaidInvoiceAmount = roundAmount(exposure.UnpaidInvoiceAmount)
+	exposure.OverdueAmount = roundAmount(exposure.OverdueAmount)
+	return exposure, nil
+}
+
+// checkCustomerCredit 校验审批新增金额后客户信用是否超限，信用额度为0表示不限额
+func checkCustomerCredit(db *gorm.DB, customer models.SalesCustomer, amount float64) error {
+	today := localDate(time.Now())
+	exposure, err := calculateCreditExposure(db, customer.ID, today)
+	if err != nil {
+		return err
+	}
+
+	if customer.CreditLimit > 0 && exposure.Total()+amount > customer.CreditLimit {
+		return fmt.Errorf("%w: exposure %.2f exceeds credit limit %.2f", ErrCreditHold, exposure.Total()+amount, customer.CreditLimit)
+	}
+	if overdueDays := config.GetAppConfig().Sales.CreditOverdueDays; exposure.OverdueDays > overdueDays {
+		return fmt.Errorf("%w: invoices overdue %d days exceed %d days", ErrCreditHold, exposure.OverdueDays, overdueDays)
+	}
+	return nil
+}
+
+// creditScore 按额度使用率、逾期天数和逾期金额占比计算信用评分（0-100）及风险等级
+func creditScore(creditLimit float64, exposure customerCreditExposure) (int, string) {
+	score := 100
+
+	// 额度使用率
+	if creditLimit > 0 {
+		switch utilization := exposure.Total() / creditLimit; {
+		case utilization > 1:
+			score -= 40
+		case utilization > 0.8:
+			score -= 20
+		case utilization > 0.5:
+			score -= 10
+		}
+	}
+
+	// 最长逾期天数
+	switch {
+	case exposure.OverdueDays > 90:
+		score -= 40
+	case exposure.OverdueDays > 60:
+		score -= 30
+	case exposure.OverdueDays > 30:
+		score -= 20
+	case exposure.OverdueDays > 0:
+		score -= 10
+	}
+
+	// 逾期金额占未收余额的比例
+	if exposure.UnpaidInvoiceAmount > 0 {
+		score -= int(20 * exposure.OverdueAmount / exposure.UnpaidInvoiceAmount)
+	}
+
+	if score < 0 {
+		score = 0
+	}
+	switch {
+	case score >= 80:
+		return score, "low"
+	case score >= 60:
+		return score, "medium"
+	default:
+		return score, "high"
+	}
+}
+
+// evaluateCustomerCredit 评估客户信用并保存评估记录，同时将信用占用回写为客户已用额度
+func evaluateCustomerCredit(tx *gorm.DB, customer models.SalesCustomer, operator string) (models.SalesCustomerCredit, customerCreditExposure, error) {
+	today := localDate(time.Now())
+	exposure, err := calculateCreditExposure(tx, customer.ID, today)
+	if err != nil {
+		return models.SalesCustomerCredit{}, exposure, err
+	}
+
+	score, riskLevel := creditScore(customer.CreditLimit, exposure)
+	evaluation := models.SalesCustomerCredit{
+		ID:             utils.GenerateID(),
+		CustomerID:     customer.ID,
+		EvaluationDate: today,
+		CreditScore:    score,
+		RiskLevel:      riskLevel,
+		CreditLimit:    customer.CreditLimit,
+		Exposure:       exposure.Total(),
+		OverdueAmount:  exposure.OverdueAmount,
+		OverdueDays:    exposure.OverdueDays,
+		CreatedBy:      operator,
+		CreatedAt:      time.Now(),
+		UpdatedBy:      operator,
+		UpdatedAt:      time.Now(),
+	}
+	if err := tx.Create(&evaluation).Error; err != nil {
+		return evaluation, exposure, err
+	}
+
+	result := tx.Model(&models.SalesCustomer{}).
+		Where("id = ?", customer.ID).
+		Updates(map[string]interface{}{
+			"credit_balance": exposure.Total(),
+			"updated_at":     time.Now(),
+		})
+	return evaluation, exposure, result.Error
+}
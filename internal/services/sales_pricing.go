@@ -0,0 +1,839 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/query"
+	"github.com/wu136995/ginx/internal/utils"
+	"gorm.io/gorm"
+)
+
+// ErrPriceNotFound 产品在指定币种下没有可用价格
+var ErrPriceNotFound = errors.New("no price found for product")
+
+// pricingContext 定价上下文，客户分组取客户类别
+type pricingContext struct {
+	CustomerID    string
+	CustomerGroup string
+	Currency      string
+	Date          time.Time
+}
+
+// linePrice 单行定价结果
+type linePrice struct {
+	ProductName     string
+	ListPrice       float64 // 价目表价或产品基础售价
+	UnitPrice       float64 // 成交单价，有合同价时取合同价
+	Source          string  // 价格来源：contract、price_list、base
+	PriceListID     string
+	CustomerPriceID string
+	PromotionID     string
+	DiscountPercent float64
+	Discount        float64
+	Amount          float64
+}
+
+// newPricingContext 读取客户构建定价上下文，币种为空时使用本位币
+func newPricingContext(db *gorm.DB, customerID, currency string, date time.Time) (pricingContext, error) {
+	var customer models.SalesCustomer
+	if err := db.First(&customer, "id = ?", customerID).Error; err != nil {
+		return pricingContext{}, err
+	}
+	if currency == "" {
+		currency = baseCurrency()
+	}
+	return pricingContext{
+		CustomerID:    customer.ID,
+		CustomerGroup: customer.Category,
+		Currency:      strings.ToUpper(currency),
+		Date:          date,
+	}, nil
+}
+
+// resolveLinePrice 按合同价、价目表阶梯价、产品基础售价的顺序确定单价，
+// 非合同价再取折扣最大的促销，合同价视为净价不叠加促销
+func resolveLinePrice(db *gorm.DB, ctx pricingContext, productID string, quantity float64) (linePrice, error) {
+	var price linePrice
+
+	var product models.SalesProduct
+	if err := db.First(&product, "id = ?", productID).Error; err != nil {
+		return price, err
+	}
+	if product.Status != "active" {
+		return price, fmt.Errorf("product %s is %s", product.ProductNo, product.Status)
+	}
+	price.ProductName = product.Name
+
+	// 价目表阶梯价，本位币下没有价目表时取产品基础售价
+	listPrice, err := priceListPrice(db, ctx, productID, quantity)
+	if err != nil {
+		return price, err
+	}
+	switch {
+	case listPrice != nil:
+		price.ListPrice = listPrice.UnitPrice
+		price.PriceListID = listPrice.PriceListID
+		price.Source = "price_list"
+	case ctx.Currency == baseCurrency():
+		price.ListPrice = product.Price
+		price.Source = "base"
+	}
+	price.UnitPrice = price.ListPrice
+
+	// 客户合同价优先
+	var contracts []models.SalesCustomerPrice
+	result := db.Where("customer_id = ? AND product_id = ? AND currency = ? AND status = ? AND min_quantity <= ?",
+		ctx.CustomerID, productID, ctx.Currency, "active", quantity).
+		Where("valid_from <= ? AND (valid_to IS NULL OR valid_to >= ?)", ctx.Date, ctx.Date).
+		Order("min_quantity DESC, valid_from DESC").
+		Limit(1).
+		Find(&contracts)
+	if result.Error != nil {
+		return price, result.Error
+	}
+	if len(contracts) > 0 {
+		price.UnitPrice = contracts[0].UnitPrice
+		price.CustomerPriceID = contracts[0].ID
+		price.Source = "contract"
+	}
+
+	if price.Source == "" || price.UnitPrice <= 0 {
+		return price, fmt.Errorf("%w %s in currency %s", ErrPriceNotFound, product.ProductNo, ctx.Currency)
+	}
+
+	// 促销折扣
+	if price.Source != "contract" {
+		var promotions []models.SalesPromotion
+		result := db.Where("status = ? AND min_quantity <= ?", "active", quantity).
+			Where("valid_from <= ? AND (valid_to IS NULL OR valid_to >= ?)", ctx.Date, ctx.Date).
+			Where("(product_id = '' OR product_id IS NULL OR product_id = ?)", productID).
+			Where("(customer_group = '' OR customer_group IS NULL OR customer_group = ?)", ctx.CustomerGroup).
+			Order("discount_percent DESC").
+			Limit(1).
+			Find(&promotions)
+		if result.Error != nil {
+			return price, result.Error
+		}
+		if len(promotions) > 0 {
+			price.PromotionID = promotions[0].ID
+			price.DiscountPercent = promotions[0].DiscountPercent
+		}
+	}
+
+	gross := roundAmount(quantity * price.UnitPrice)
+	price.Discount = roundAmount(gross * price.DiscountPercent / 100)
+	price.Amount = roundAmount(gross - price.Discount)
+	return price, nil
+}
+
+// priceListPrice 查找适用的价目表价格：客户分组专用价目表优先于通用价目表，其次按优先级，
+// 同一价目表取不超过数量的最高一档阶梯价，没有适用价目表时返回nil
+func priceListPrice(db *gorm.DB, ctx pricingContext, productID string, quantity float64) (*models.SalesPriceListItem, error) {
+	var rows []struct {
+		PriceListID   string
+		CustomerGroup string
+		MinQuantity   float64
+		UnitPrice     float64
+	}
+	result := db.Table("sales_price_list_items AS i").
+		Select("i.price_list_id AS price_list_id, l.customer_group AS customer_group, i.min_quantity AS min_quantity, i.unit_price AS unit_price").
+		Joins("JOIN sales_price_lists AS l ON l.id = i.price_list_id").
+		Where("i.product_id = ? AND i.min_quantity <= ? AND i.deleted_at IS NULL AND l.deleted_at IS NULL", productID, quantity).
+		Where("l.status = ? AND l.currency = ?", "active", ctx.Currency).
+		Where("l.valid_from <= ? AND (l.valid_to IS NULL OR l.valid_to >= ?)", ctx.Date, ctx.Date).
+		Where("(l.customer_group = '' OR l.customer_group IS NULL OR l.customer_group = ?)", ctx.CustomerGroup).
+		Order("l.priority DESC, i.min_quantity DESC").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	best := rows[0]
+	for _, row := range rows {
+		if ctx.CustomerGroup != "" && row.CustomerGroup == ctx.CustomerGroup {
+			best = row
+			break
+		}
+	}
+	return &models.SalesPriceListItem{
+		PriceListID: best.PriceListID,
+		ProductID:   productID,
+		MinQuantity: best.MinQuantity,
+		UnitPrice:   best.UnitPrice,
+	}, nil
+}
+
+// parseValidity 解析生效和失效日期，失效日期为空表示长期有效
+func parseValidity(validFrom, validTo string) (time.Time, *time.Time, error) {
+	from, err := time.Parse("2006-01-02", validFrom)
+	if err != nil {
+		return from, nil, err
+	}
+	if validTo == "" {
+		return from, nil, nil
+	}
+	to, err := time.Parse("2006-01-02", validTo)
+	if err != nil {
+		return from, nil, err
+	}
+	if to.Before(from) {
+		return from, nil, errors.New("valid to date is before valid from date")
+	}
+	return from, &to, nil
+}
+
+// mergeValidity 用请求中非空的生效和失效日期覆盖原有效期并校验
+func mergeValidity(validFrom time.Time, validTo *time.Time, reqFrom, reqTo string) (time.Time, *time.Time, error) {
+	from, to := validFrom.Format("2006-01-02"), reqTo
+	if reqFrom != "" {
+		from = reqFrom
+	}
+	if to == "" && validTo != nil {
+		to = validTo.Format("2006-01-02")
+	}
+	return parseValidity(from, to)
+}
+
+// QuotePrice 按报价和订单相同的定价规则模拟计算价格，不保存任何数据
+func (s *salesService) QuotePrice(req schemas.PriceQuoteRequest) (*schemas.PriceQuoteResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 解析定价日期，默认当天，均按本地时区的零点
+	date := localDate(time.Now())
+	if req.Date != "" {
+		var err error
+		if date, err = time.ParseInLocation("2006-01-02", req.Date, time.Local); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, err := newPricingContext(s.db, req.CustomerId, req.Currency, date)
+	if err != nil {
+		return nil, err
+	}
+
+	// 逐行定价
+	response := &schemas.PriceQuoteResponse{
+		CustomerId: ctx.CustomerID,
+		Currency:   ctx.Currency,
+		Date:       date.Format("2006-01-02"),
+		Items:      make([]schemas.PriceQuoteItem, len(req.Items)),
+	}
+	var total float64
+	for i, item := range req.Items {
+		price, err := resolveLinePrice(s.db, ctx, item.ProductId, item.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		response.Items[i] = schemas.PriceQuoteItem{
+			ProductId:       item.ProductId,
+			ProductName:     price.ProductName,
+			Quantity:        item.Quantity,
+			ListPrice:       price.ListPrice,
+			UnitPrice:       price.UnitPrice,
+			PriceSource:     price.Source,
+			PriceListId:     price.PriceListID,
+			CustomerPriceId: price.CustomerPriceID,
+			PromotionId:     price.PromotionID,
+			DiscountPercent: price.DiscountPercent,
+			Discount:        price.Discount,
+			Amount:          price.Amount,
+		}
+		total += price.Amount
+	}
+	response.TotalAmount = roundAmount(total)
+
+	return response, nil
+}
+
+// priceListListSpec 价目表列表查询白名单
+var priceListListSpec = query.NewSpec("-created_at",
+	query.Text("code"),
+	query.Text("name"),
+	query.Text("currency"),
+	query.Text("customer_group"),
+	query.Text("status"),
+	query.Number("priority"),
+	query.Date("valid_from"),
+	query.Date("valid_to"),
+	query.Date("created_at"),
+)
+
+// 价目表管理方法
+func (s *salesService) GetPriceListList(params query.Params) (*query.Page[schemas.PriceListResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取价目表数据
+	var priceLists []models.SalesPriceList
+	total, err := query.Find(s.db, params, priceListListSpec, &priceLists)
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	response := make([]schemas.PriceListResponse, len(priceLists))
+	for i, priceList := range priceLists {
+		response[i] = salesPriceListResponse(priceList)
+	}
+
+	return query.NewPage(response, total, params), nil
+}
+
+func (s *salesService) GetPriceListDetail(id string) (*schemas.PriceListResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取价目表及明细
+	var priceList models.SalesPriceList
+	result := s.db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("product_id, min_quantity")
+	}).Preload("Items.Product").First(&priceList, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := salesPriceListResponse(priceList)
+	return &response, nil
+}
+
+func (s *salesService) CreatePriceList(req schemas.CreatePriceListRequest) (*schemas.PriceListResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 解析有效期
+	validFrom, validTo, err := parseValidity(req.ValidFrom, req.ValidTo)
+	if err != nil {
+		return nil, err
+	}
+
+	// 创建价目表及明细
+	priceList := models.SalesPriceList{
+		ID:            utils.GenerateID(),
+		Code:          req.Code,
+		Name:          req.Name,
+		Currency:      baseCurrency(),
+		CustomerGroup: req.CustomerGroup,
+		ValidFrom:     validFrom,
+		ValidTo:       validTo,
+		Priority:      req.Priority,
+		Status:        "active",
+		Remarks:       req.Remarks,
+		CreatedBy:     req.CreatedBy,
+		CreatedAt:     time.Now(),
+		UpdatedBy:     req.CreatedBy,
+		UpdatedAt:     time.Now(),
+	}
+	if req.Currency != "" {
+		priceList.Currency = strings.ToUpper(req.Currency)
+	}
+	if req.Status != "" {
+		priceList.Status = req.Status
+	}
+	priceList.Items, err = salesPriceListItems(priceList.ID, req.Items, req.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.db.Create(&priceList)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return s.GetPriceListDetail(priceList.ID)
+}
+
+func (s *salesService) UpdatePriceList(id string, req schemas.UpdatePriceListRequest) (*schemas.PriceListResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取价目表
+	var priceList models.SalesPriceList
+	result := s.db.First(&priceList, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 更新字段
+	if req.Name != "" {
+		priceList.Name = req.Name
+	}
+	if req.CustomerGroup != nil {
+		priceList.CustomerGroup = *req.CustomerGroup
+	}
+	validFrom, validTo, err := mergeValidity(priceList.ValidFrom, priceList.ValidTo, req.ValidFrom, req.ValidTo)
+	if err != nil {
+		return nil, err
+	}
+	priceList.ValidFrom, priceList.ValidTo = validFrom, validTo
+	if req.Priority != nil {
+		priceList.Priority = *req.Priority
+	}
+	if req.Status != "" {
+		priceList.Status = req.Status
+	}
+	if req.Remarks != "" {
+		priceList.Remarks = req.Remarks
+	}
+	priceList.UpdatedAt = time.Now()
+	priceList.UpdatedBy = req.UpdatedBy
+
+	// 在同一事务中替换明细并保存价目表
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if len(req.Items) > 0 {
+			items, err := salesPriceListItems(priceList.ID, req.Items, req.UpdatedBy)
+			if err != nil {
+				return err
+			}
+			if err := tx.Where("price_list_id = ?", priceList.ID).Delete(&models.SalesPriceListItem{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Create(&items).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Omit("Items").Save(&priceList).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPriceListDetail(priceList.ID)
+}
+
+func (s *salesService) DeletePriceList(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 从数据库读取价目表
+	var priceList models.SalesPriceList
+	result := s.db.First(&priceList, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// 在同一事务中删除价目表及明细
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("price_list_id = ?", priceList.ID).Delete(&models.SalesPriceListItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&priceList).Error
+	})
+}
+
+// customerPriceListSpec 客户合同价列表查询白名单
+var customerPriceListSpec = query.NewSpec("-created_at",
+	query.Text("customer_id"),
+	query.Text("product_id"),
+	query.Text("currency"),
+	query.Text("contract_no"),
+	query.Text("status"),
+	query.Number("unit_price"),
+	query.Date("valid_from"),
+	query.Date("valid_to"),
+	query.Date("created_at"),
+)
+
+// 客户合同价管理方法
+func (s *salesService) GetCustomerPriceList(params query.Params) (*query.Page[schemas.CustomerPriceResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取客户合同价数据
+	var prices []models.SalesCustomerPrice
+	total, err := query.Find(s.db, params, customerPriceListSpec, &prices, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Customer").Preload("Product")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	response := make([]schemas.CustomerPriceResponse, len(prices))
+	for i, price := range prices {
+		response[i] = salesCustomerPriceResponse(price)
+	}
+
+	return query.NewPage(response, total, params), nil
+}
+
+func (s *salesService) CreateCustomerPrice(req schemas.CreateCustomerPriceRequest) (*schemas.CustomerPriceResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 解析有效期
+	validFrom, validTo, err := parseValidity(req.ValidFrom, req.ValidTo)
+	if err != nil {
+		return nil, err
+	}
+
+	// 创建客户合同价
+	price := models.SalesCustomerPrice{
+		ID:          utils.GenerateID(),
+		CustomerID:  req.CustomerId,
+		ProductID:   req.ProductId,
+		Currency:    baseCurrency(),
+		MinQuantity: req.MinQuantity,
+		UnitPrice:   req.UnitPrice,
+		ValidFrom:   validFrom,
+		ValidTo:     validTo,
+		ContractNo:  req.ContractNo,
+		Status:      "active",
+		CreatedBy:   req.CreatedBy,
+		CreatedAt:   time.Now(),
+		UpdatedBy:   req.CreatedBy,
+		UpdatedAt:   time.Now(),
+	}
+	if req.Currency != "" {
+		price.Currency = strings.ToUpper(req.Currency)
+	}
+	if req.Status != "" {
+		price.Status = req.Status
+	}
+
+	result := s.db.Create(&price)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return s.getCustomerPrice(price.ID)
+}
+
+func (s *salesService) UpdateCustomerPrice(id string, req schemas.UpdateCustomerPriceRequest) (*schemas.CustomerPriceResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取客户合同价
+	var price models.SalesCustomerPrice
+	result := s.db.First(&price, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 更新字段
+	if req.MinQuantity != nil {
+		price.MinQuantity = *req.MinQuantity
+	}
+	if req.UnitPrice > 0 {
+		price.UnitPrice = req.UnitPrice
+	}
+	validFrom, validTo, err := mergeValidity(price.ValidFrom, price.ValidTo, req.ValidFrom, req.ValidTo)
+	if err != nil {
+		return nil, err
+	}
+	price.ValidFrom, price.ValidTo = validFrom, validTo
+	if req.ContractNo != "" {
+		price.ContractNo = req.ContractNo
+	}
+	if req.Status != "" {
+		price.Status = req.Status
+	}
+	price.UpdatedAt = time.Now()
+	price.UpdatedBy = req.UpdatedBy
+
+	result = s.db.Save(&price)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return s.getCustomerPrice(price.ID)
+}
+
+func (s *salesService) DeleteCustomerPrice(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	result := s.db.Delete(&models.SalesCustomerPrice{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// getCustomerPrice 读取客户合同价及关联客户和产品
+func (s *salesService) getCustomerPrice(id string) (*schemas.CustomerPriceResponse, error) {
+	var price models.SalesCustomerPrice
+	result := s.db.Preload("Customer").Preload("Product").First(&price, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := salesCustomerPriceResponse(price)
+	return &response, nil
+}
+
+// promotionListSpec 促销列表查询白名单
+var promotionListSpec = query.NewSpec("-created_at",
+	query.Text("code"),
+	query.Text("name"),
+	query.Text("product_id"),
+	query.Text("customer_group"),
+	query.Text("status"),
+	query.Number("discount_percent"),
+	query.Date("valid_from"),
+	query.Date("valid_to"),
+	query.Date("created_at"),
+)
+
+// 促销管理方法
+func (s *salesService) GetPromotionList(params query.Params) (*query.Page[schemas.PromotionResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取促销数据
+	var promotions []models.SalesPromotion
+	total, err := query.Find(s.db, params, promotionListSpec, &promotions)
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	response := make([]schemas.PromotionResponse, len(promotions))
+	for i, promotion := range promotions {
+		response[i] = salesPromotionResponse(promotion)
+	}
+
+	return query.NewPage(response, total, params), nil
+}
+
+func (s *salesService) CreatePromotion(req schemas.CreatePromotionRequest) (*schemas.PromotionResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 解析有效期
+	validFrom, validTo, err := parseValidity(req.ValidFrom, req.ValidTo)
+	if err != nil {
+		return nil, err
+	}
+
+	// 创建促销
+	promotion := models.SalesPromotion{
+		ID:              utils.GenerateID(),
+		Code:            req.Code,
+		Name:            req.Name,
+		ProductID:       req.ProductId,
+		CustomerGroup:   req.CustomerGroup,
+		MinQuantity:     req.MinQuantity,
+		DiscountPercent: req.DiscountPercent,
+		ValidFrom:       validFrom,
+		ValidTo:         validTo,
+		Status:          "active",
+		CreatedBy:       req.CreatedBy,
+		CreatedAt:       time.Now(),
+		UpdatedBy:       req.CreatedBy,
+		UpdatedAt:       time.Now(),
+	}
+	if req.Status != "" {
+		promotion.Status = req.Status
+	}
+
+	result := s.db.Create(&promotion)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := salesPromotionResponse(promotion)
+	return &response, nil
+}
+
+func (s *salesService) UpdatePromotion(id string, req schemas.UpdatePromotionRequest) (*schemas.PromotionResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取促销
+	var promotion models.SalesPromotion
+	result := s.db.First(&promotion, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 更新字段
+	if req.Name != "" {
+		promotion.Name = req.Name
+	}
+	if req.MinQuantity != nil {
+		promotion.MinQuantity = *req.MinQuantity
+	}
+	if req.DiscountPercent > 0 {
+		promotion.DiscountPercent = req.DiscountPercent
+	}
+	validFrom, validTo, err := mergeValidity(promotion.ValidFrom, promotion.ValidTo, req.ValidFrom, req.ValidTo)
+	if err != nil {
+		return nil, err
+	}
+	promotion.ValidFrom, promotion.ValidTo = validFrom, validTo
+	if req.Status != "" {
+		promotion.Status = req.Status
+	}
+	promotion.UpdatedAt = time.Now()
+	promotion.UpdatedBy = req.UpdatedBy
+
+	result = s.db.Save(&promotion)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := salesPromotionResponse(promotion)
+	return &response, nil
+}
+
+func (s *salesService) DeletePromotion(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	result := s.db.Delete(&models.SalesPromotion{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// salesPriceListItems 根据请求构建价目表明细，同一产品的起订数量不能重复
+func salesPriceListItems(priceListID string, items []schemas.PriceListItem, operator string) ([]models.SalesPriceListItem, error) {
+	tiers := make(map[string]bool, len(items))
+	priceListItems := make([]models.SalesPriceListItem, len(items))
+	for i, item := range items {
+		tier := fmt.Sprintf("%s/%.4f", item.ProductId, item.MinQuantity)
+		if tiers[tier] {
+			return nil, fmt.Errorf("duplicate price tier for product %s at quantity %.4f", item.ProductId, item.MinQuantity)
+		}
+		tiers[tier] = true
+
+		priceListItems[i] = models.SalesPriceListItem{
+			ID:          utils.GenerateID(),
+			PriceListID: priceListID,
+			ProductID:   item.ProductId,
+			MinQuantity: item.MinQuantity,
+			UnitPrice:   item.UnitPrice,
+			CreatedBy:   operator,
+			CreatedAt:   time.Now(),
+			UpdatedBy:   operator,
+			UpdatedAt:   time.Now(),
+		}
+	}
+	return priceListItems, nil
+}
+
+// salesPriceListResponse 将价目表模型转换为响应格式，明细已预加载时一并转换
+func salesPriceListResponse(priceList models.SalesPriceList) schemas.PriceListResponse {
+	response := schemas.PriceListResponse{
+		ID:            priceList.ID,
+		Code:          priceList.Code,
+		Name:          priceList.Name,
+		Currency:      priceList.Currency,
+		CustomerGroup: priceList.CustomerGroup,
+		ValidFrom:     priceList.ValidFrom.Format("2006-01-02"),
+		Priority:      priceList.Priority,
+		Status:        priceList.Status,
+		Remarks:       priceList.Remarks,
+		Items:         make([]schemas.PriceListItem, len(priceList.Items)),
+		CreatedBy:     priceList.CreatedBy,
+		CreatedAt:     priceList.CreatedAt,
+		UpdatedBy:     priceList.UpdatedBy,
+		UpdatedAt:     priceList.UpdatedAt,
+	}
+	if priceList.ValidTo != nil {
+		response.ValidTo = priceList.ValidTo.Format("2006-01-02")
+	}
+	for i, item := range priceList.Items {
+		response.Items[i] = schemas.PriceListItem{
+			ID:          item.ID,
+			ProductId:   item.ProductID,
+			ProductName: item.Product.Name,
+			MinQuantity: item.MinQuantity,
+			UnitPrice:   item.UnitPrice,
+		}
+	}
+	return response
+}
+
+// salesCustomerPriceResponse 将客户合同价模型转换为响应格式
+func salesCustomerPriceResponse(price models.SalesCustomerPrice) schemas.CustomerPriceResponse {
+	response := schemas.CustomerPriceResponse{
+		ID:           price.ID,
+		CustomerId:   price.CustomerID,
+		CustomerName: price.Customer.Name,
+		ProductId:    price.ProductID,
+		ProductName:  price.Product.Name,
+		Currency:     price.Currency,
+		MinQuantity:  price.MinQuantity,
+		UnitPrice:    price.UnitPrice,
+		ValidFrom:    price.ValidFrom.Format("2006-01-02"),
+		ContractNo:   price.ContractNo,
+		Status:       price.Status,
+		CreatedBy:    price.CreatedBy,
+		CreatedAt:    price.CreatedAt,
+		UpdatedBy:    price.UpdatedBy,
+		UpdatedAt:    price.UpdatedAt,
+	}
+	if price.ValidTo != nil {
+		response.ValidTo = price.ValidTo.Format("2006-01-02")
+	}
+	return response
+}
+
+// salesPromotionResponse 将促销模型转换为响应格式
+func salesPromotionResponse(promotion models.SalesPromotion) schemas.PromotionResponse {
+	response := schemas.PromotionResponse{
+		ID:              promotion.ID,
+		Code:            promotion.Code,
+		Name:            promotion.Name,
+		ProductId:       promotion.ProductID,
+		CustomerGroup:   promotion.CustomerGroup,
+		MinQuantity:     promotion.MinQuantity,
+		DiscountPercent: promotion.DiscountPercent,
+		ValidFrom:       promotion.ValidFrom.Format("2006-01-02"),
+		Status:          promotion.Status,
+		CreatedBy:       promotion.CreatedBy,
+		CreatedAt:       promotion.CreatedAt,
+		UpdatedBy:       promotion.UpdatedBy,
+		UpdatedAt:       promotion.UpdatedAt,
+	}
+	if promotion.ValidTo != nil {
+		response.ValidTo = promotion.ValidTo.Format("2006-01-02")
+	}
+	return response
+}
@@ -0,0 +1,886 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/query"
+	"github.com/wu136995/ginx/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 销售退货单状态，待审核和已审核的退货单占用订单可退数量，收货处理后为processed
+const (
+	returnStatusPending   = "pending"
+	returnStatusApproved  = "approved"
+	returnStatusProcessed = "processed"
+	returnStatusCancelled = "cancelled"
+)
+
+// 贷项通知单状态，金额全部冲减发票后为applied，否则余额为客户贷项
+const (
+	creditNoteStatusOpen    = "open"
+	creditNoteStatusApplied = "applied"
+)
+
+// 销售退货的记账单据类型及事件，credit按贷项通知单本位币金额冲减收入、销项税和应收账款；
+// 退货入库按库存交易类型sales_return记账
+const (
+	postingDocumentSalesReturn = "sales_return"
+	salesReturnEventCredit     = "credit"
+	inventoryTypeSalesReturn   = "sales_return"
+)
+
+// locationTypeQuarantine 隔离库位类型，质检前的退货可收货到隔离库位
+const locationTypeQuarantine = "quarantine"
+
+// salesReturnListSpec 销售退货单列表查询白名单
+var salesReturnListSpec = query.NewSpec("-return_date",
+	query.Text("return_no"),
+	query.Text("order_id"),
+	query.Text("customer_id"),
+	query.Text("invoice_id"),
+	query.Text("status"),
+	query.Number("total_amount"),
+	query.Date("return_date"),
+	query.Date("created_at"),
+)
+
+// 销售退货管理方法
+func (s *salesService) GetReturnList(params query.Params) (*query.Page[schemas.ReturnResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取销售退货单数据
+	var returns []models.SalesReturn
+	total, err := query.Find(s.db, params, salesReturnListSpec, &returns, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Customer")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	response := make([]schemas.ReturnResponse, len(returns))
+	for i, returnOrder := range returns {
+		response[i] = salesReturnResponse(returnOrder)
+	}
+
+	return query.NewPage(response, total, params), nil
+}
+
+func (s *salesService) GetReturnDetail(id string) (*schemas.ReturnResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取销售退货单、明细及贷项通知单
+	var returnOrder models.SalesReturn
+	result := s.db.Preload("Customer").Preload("Items.Product").Preload("CreditNote.Items.Product").First(&returnOrder, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := salesReturnResponse(returnOrder)
+	return &response, nil
+}
+
+func (s *salesService) CreateReturn(req schemas.CreateReturnRequest) (*schemas.ReturnResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 解析退货日期
+	returnDate, err := time.Parse("2006-01-02", req.ReturnDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 从数据库读取销售订单及明细，客户以订单为准
+	var order models.SalesOrder
+	result := s.db.Preload("Items").First(&order, "id = ?", req.OrderId)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 退货沿用原发票的币种和汇率，未指定发票时按订单币种取退货日期的汇率
+	currency, rate, err := salesReturnCurrency(s.db, order, req.InvoiceId, returnDate)
+	if err != nil {
+		return nil, err
+	}
+
+	returnOrder := models.SalesReturn{
+		ID:           utils.GenerateID(),
+		ReturnNo:     req.ReturnNo,
+		OrderID:      order.ID,
+		CustomerID:   order.CustomerID,
+		InvoiceID:    req.InvoiceId,
+		ReturnDate:   returnDate,
+		Currency:     currency,
+		ExchangeRate: rate,
+		Status:       returnStatusPending,
+		Reason:       req.Reason,
+		Remarks:      req.Remarks,
+		CreatedBy:    req.CreatedBy,
+		CreatedAt:    time.Now(),
+		UpdatedBy:    req.CreatedBy,
+		UpdatedAt:    time.Now(),
+	}
+
+	// 退货数量不能超过订单明细已发未退数量
+	returnOrder.Items, returnOrder.TotalAmount, err = salesReturnItems(s.db, order, returnOrder.ID, req.Items, req.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// 保存退货单及明细到数据库
+	if err := s.db.Create(&returnOrder).Error; err != nil {
+		return nil, err
+	}
+
+	return s.GetReturnDetail(returnOrder.ID)
+}
+
+func (s *salesService) UpdateReturn(id string, req schemas.UpdateReturnRequest) (*schemas.ReturnResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取销售退货单
+	var returnOrder models.SalesReturn
+	result := s.db.First(&returnOrder, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 只有待审核的退货单可以修改
+	if returnOrder.Status != returnStatusPending {
+		return nil, fmt.Errorf("sales return in status %s cannot be modified", returnOrder.Status)
+	}
+
+	var order models.SalesOrder
+	result = s.db.Preload("Items").First(&order, "id = ?", returnOrder.OrderID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 更新字段，原发票或退货日期变化时重新确定币种和汇率
+	rateChanged := false
+	if req.ReturnDate != "" {
+		returnDate, err := time.Parse("2006-01-02", req.ReturnDate)
+		if err != nil {
+			return nil, err
+		}
+		rateChanged = !returnDate.Equal(returnOrder.ReturnDate)
+		returnOrder.ReturnDate = returnDate
+	}
+	if req.InvoiceId != "" && req.InvoiceId != returnOrder.InvoiceID {
+		returnOrder.InvoiceID = req.InvoiceId
+		rateChanged = true
+	}
+	if rateChanged {
+		currency, rate, err := salesReturnCurrency(s.db, order, returnOrder.InvoiceID, returnOrder.ReturnDate)
+		if err != nil {
+			return nil, err
+		}
+		returnOrder.Currency, returnOrder.ExchangeRate = currency, rate
+	}
+	if req.Reason != "" {
+		returnOrder.Reason = req.Reason
+	}
+	if req.Remarks != "" {
+		returnOrder.Remarks = req.Remarks
+	}
+	returnOrder.UpdatedAt = time.Now()
+	returnOrder.UpdatedBy = req.UpdatedBy
+
+	// 未传明细时只更新退货单头
+	if len(req.Items) == 0 {
+		if err := s.db.Omit(clause.Associations).Save(&returnOrder).Error; err != nil {
+			return nil, err
+		}
+		return s.GetReturnDetail(returnOrder.ID)
+	}
+
+	items, totalAmount, err := salesReturnItems(s.db, order, returnOrder.ID, req.Items, req.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+	returnOrder.TotalAmount = totalAmount
+
+	// 在同一事务中替换明细并保存退货单头
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("return_id = ?", returnOrder.ID).Delete(&models.SalesReturnItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&items).Error; err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Save(&returnOrder).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetReturnDetail(returnOrder.ID)
+}
+
+func (s *salesService) DeleteReturn(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 从数据库读取销售退货单
+	var returnOrder models.SalesReturn
+	result := s.db.First(&returnOrder, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// 已收货处理的退货单已生成库存交易和贷项通知单，不能删除
+	if returnOrder.Status != returnStatusPending && returnOrder.Status != returnStatusCancelled {
+		return fmt.Errorf("sales return in status %s cannot be deleted", returnOrder.Status)
+	}
+
+	// 在同一事务中删除退货单及明细
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("return_id = ?", returnOrder.ID).Delete(&models.SalesReturnItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&returnOrder).Error
+	})
+}
+
+// ApproveReturn 审核退货授权，按订单当前已发未退数量重新校验退货数量，operator为审核人
+func (s *salesService) ApproveReturn(id, operator string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 从数据库读取销售退货单及明细
+	var returnOrder models.SalesReturn
+	result := s.db.Preload("Items").First(&returnOrder, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if returnOrder.Status != returnStatusPending {
+		return fmt.Errorf("sales return in status %s cannot be approved", returnOrder.Status)
+	}
+
+	// 在同一事务中锁定订单校验可退数量，并按待审核状态条件更新，防止并发审核
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkSalesReturnQuantities(tx, returnOrder); err != nil {
+			return err
+		}
+		return updateSalesReturnStatus(tx, returnOrder.ID, []string{returnStatusPending}, returnStatusApproved, operator)
+	})
+}
+
+// CancelReturn 取消尚未收货的退货授权，取消后释放占用的可退数量，operator为取消人
+func (s *salesService) CancelReturn(id, operator string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 从数据库读取销售退货单
+	var returnOrder models.SalesReturn
+	result := s.db.First(&returnOrder, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if returnOrder.Status != returnStatusPending && returnOrder.Status != returnStatusApproved {
+		return fmt.Errorf("sales return in status %s cannot be cancelled", returnOrder.Status)
+	}
+
+	return updateSalesReturnStatus(s.db, returnOrder.ID, []string{returnStatusPending, returnStatusApproved}, returnStatusCancelled, operator)
+}
+
+// ReceiveReturn 已审核的退货收货：按原发货出库成本将退货入库到指定仓库库位，
+// 生成贷项通知单并冲减原发票余额，未冲减部分作为客户贷项余额
+func (s *salesService) ReceiveReturn(id string, req schemas.ReceiveReturnRequest) (*schemas.ReturnResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 解析收货日期
+	date, err := time.Parse("2006-01-02", req.ReceiveDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 从数据库读取销售退货单及明细
+	var returnOrder models.SalesReturn
+	result := s.db.Preload("Items.Product").First(&returnOrder, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if returnOrder.Status != returnStatusApproved {
+		return nil, fmt.Errorf("sales return in status %s cannot be received", returnOrder.Status)
+	}
+	if date.Before(returnOrder.ReturnDate) {
+		return nil, errors.New("receive date must not be earlier than the return date")
+	}
+
+	// 收货日期所在会计期间必须允许记账
+	if err := checkPeriodOpen(s.db, date, false); err != nil {
+		return nil, err
+	}
+
+	// 构建退货入库变动，产品需关联库存物料并指定收货仓库，入库成本取原发货出库成本
+	costs, err := salesReturnUnitCosts(s.db, returnOrder.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	movements := make([]inventoryMovement, len(returnOrder.Items))
+	for i, item := range returnOrder.Items {
+		if item.Product.ItemID == "" {
+			return nil, fmt.Errorf("product %s is not linked to an inventory item", item.ProductID)
+		}
+		if item.WarehouseID == "" {
+			return nil, fmt.Errorf("return item %s has no warehouse", item.ID)
+		}
+		movements[i] = inventoryMovement{
+			ItemID:          item.Product.ItemID,
+			WarehouseID:     item.WarehouseID,
+			LocationID:      item.LocationID,
+			Type:            inventoryTypeSalesReturn,
+			Quantity:        item.Quantity,
+			UnitCost:        costs[item.Product.ItemID],
+			ReferenceType:   "sales_return",
+			ReferenceID:     returnOrder.ID,
+			TransactionDate: date,
+			Remarks:         returnOrder.ReturnNo,
+			CreatedBy:       req.CreatedBy,
+		}
+	}
+
+	// 贷项通知单按原发票的含税方式和开票日期计税
+	var invoice *models.SalesInvoice
+	if returnOrder.InvoiceID != "" {
+		invoice = &models.SalesInvoice{}
+		if err := s.db.First(invoice, "id = ?", returnOrder.InvoiceID).Error; err != nil {
+			return nil, err
+		}
+	}
+	note, taxDate := newSalesCreditNote(returnOrder, invoice, date, req)
+	if err := applySalesCreditNoteTax(s.db, &note, taxDate); err != nil {
+		return nil, err
+	}
+
+	// 在同一事务中先按已审核状态条件更新退货单并锁定订单校验可退数量，防止并发重复收货，
+	// 再过账退货入库、冲减原发票、保存贷项通知单并生成凭证
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := updateSalesReturnStatus(tx, returnOrder.ID, []string{returnStatusApproved}, returnStatusProcessed, req.CreatedBy); err != nil {
+			return err
+		}
+		if err := checkSalesReturnQuantities(tx, returnOrder); err != nil {
+			return err
+		}
+
+		transactionNo := fmt.Sprintf("SRT%s", time.Now().Format("20060102030405"))
+		if _, err := newInventoryPoster(tx).Post(transactionNo, movements); err != nil {
+			return err
+		}
+
+		if note.InvoiceID != "" {
+			if err := creditSalesInvoice(tx, &note, req.CreatedBy); err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(&note).Error; err != nil {
+			return err
+		}
+		_, err := postDocument(tx, salesCreditNotePostingDocument(note))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetReturnDetail(returnOrder.ID)
+}
+
+// salesCreditNoteListSpec 贷项通知单列表查询白名单
+var salesCreditNoteListSpec = query.NewSpec("-credit_date",
+	query.Text("credit_note_no"),
+	query.Text("return_id"),
+	query.Text("order_id"),
+	query.Text("customer_id"),
+	query.Text("invoice_id"),
+	query.Text("currency"),
+	query.Text("status"),
+	query.Number("total_amount"),
+	query.Date("credit_date"),
+	query.Date("created_at"),
+)
+
+// 贷项通知单查询方法
+func (s *salesService) GetCreditNoteList(params query.Params) (*query.Page[schemas.CreditNoteResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取贷项通知单数据
+	var notes []models.SalesCreditNote
+	total, err := query.Find(s.db, params, salesCreditNoteListSpec, &notes, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Customer")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	response := make([]schemas.CreditNoteResponse, len(notes))
+	for i, note := range notes {
+		response[i] = salesCreditNoteResponse(note)
+	}
+
+	return query.NewPage(response, total, params), nil
+}
+
+func (s *salesService) GetCreditNoteDetail(id string) (*schemas.CreditNoteResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取贷项通知单及明细
+	var note models.SalesCreditNote
+	result := s.db.Preload("Customer").Preload("Items.Product").First(&note, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := salesCreditNoteResponse(note)
+	return &response, nil
+}
+
+// salesReturnCurrency 确定退货单的币种和汇率，指定原发票时校验发票属于该订单并沿用发票汇率
+func salesReturnCurrency(db *gorm.DB, order models.SalesOrder, invoiceID string, date time.Time) (string, float64, error) {
+	if invoiceID == "" {
+		return documentCurrency(db, order.Currency, "", 0, date)
+	}
+
+	var invoice models.SalesInvoice
+	if err := db.First(&invoice, "id = ?", invoiceID).Error; err != nil {
+		return "", 0, err
+	}
+	if invoice.OrderID != order.ID {
+		return "", 0, fmt.Errorf("sales invoice %s does not belong to sales order %s", invoice.InvoiceNo, order.OrderNo)
+	}
+	if invoice.Status == "cancelled" {
+		return "", 0, fmt.Errorf("sales invoice %s is cancelled", invoice.InvoiceNo)
+	}
+	return invoice.Currency, invoice.ExchangeRate, nil
+}
+
+// salesReturnableQuantities 计算订单各明细的可退数量，即已发数量减去未取消退货单占用的数量，
+// excludeReturnID不为空时不计该退货单的占用
+func salesReturnableQuantities(db *gorm.DB, order models.SalesOrder, excludeReturnID string) (map[string]float64, error) {
+	active := []string{returnStatusPending, returnStatusApproved, returnStatusProcessed}
+	query := db.Table("sales_return_items AS i").
+		Select("i.order_item_id AS order_item_id, SUM(i.quantity) AS quantity").
+		Joins("JOIN sales_returns AS r ON r.id = i.return_id").
+		Where("r.order_id = ? AND r.status IN ? AND r.deleted_at IS NULL AND i.deleted_at IS NULL", order.ID, active)
+	if excludeReturnID != "" {
+		query = query.Where("r.id <> ?", excludeReturnID)
+	}
+
+	var rows []struct {
+		OrderItemID string
+		Quantity    float64
+	}
+	if err := query.Group("i.order_item_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	returned := make(map[string]float64, len(rows))
+	for _, row := range rows {
+		returned[row.OrderItemID] = row.Quantity
+	}
+	return returnableQuantities(order.Items, returned), nil
+}
+
+// returnableQuantities 按订单明细已发数量减去已占用的退货数量计算可退数量
+func returnableQuantities(items []models.SalesOrderItem, returned map[string]float64) map[string]float64 {
+	returnable := make(map[string]float64, len(items))
+	for _, item := range items {
+		returnable[item.ID] = roundQuantity(item.ShippedQuantity - returned[item.ID])
+	}
+	return returnable
+}
+
+// salesReturnItems 根据请求构建退货明细并计算退货总额，校验明细属于该订单且不超过可退数量，
+// 未指定单价时按订单明细折后金额折算
+func salesReturnItems(db *gorm.DB, order models.SalesOrder, returnID string, items []schemas.ReturnItem, operator string) ([]models.SalesReturnItem, float64, error) {
+	returnable, err := salesReturnableQuantities(db, order, returnID)
+	if err != nil {
+		return nil, 0, err
+	}
+	orderItems := make(map[string]models.SalesOrderItem, len(order.Items))
+	for _, item := range order.Items {
+		orderItems[item.ID] = item
+	}
+
+	returnItems := make([]models.SalesReturnItem, len(items))
+	var total float64
+	for i, item := range items {
+		orderItem, ok := orderItems[item.OrderItemId]
+		if !ok {
+			return nil, 0, fmt.Errorf("order item %s does not belong to sales order %s", item.OrderItemId, order.OrderNo)
+		}
+		if item.Quantity > returnable[orderItem.ID] {
+			return nil, 0, fmt.Errorf("return quantity %.4f exceeds returnable quantity %.4f of order item %s", item.Quantity, returnable[orderItem.ID], orderItem.ID)
+		}
+		returnable[orderItem.ID] = roundQuantity(returnable[orderItem.ID] - item.Quantity)
+
+		unitPrice, amount := item.UnitPrice, roundAmount(item.Quantity*item.UnitPrice)
+		if unitPrice == 0 {
+			unitPrice = roundAmount(orderItem.Amount / orderItem.Quantity)
+			amount = roundAmount(item.Quantity * orderItem.Amount / orderItem.Quantity)
+		}
+
+		locationID, err := salesReturnLocation(db, item)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		returnItems[i] = models.SalesReturnItem{
+			ID:          utils.GenerateID(),
+			ReturnID:    returnID,
+			OrderItemID: orderItem.ID,
+			ProductID:   orderItem.ProductID,
+			Quantity:    item.Quantity,
+			UnitPrice:   unitPrice,
+			Amount:      amount,
+			WarehouseID: item.WarehouseId,
+			LocationID:  locationID,
+			Quarantine:  item.Quarantine,
+			CreatedBy:   operator,
+			CreatedAt:   time.Now(),
+			UpdatedBy:   operator,
+			UpdatedAt:   time.Now(),
+		}
+		total += amount
+	}
+	return returnItems, roundAmount(total), nil
+}
+
+// salesReturnLocation 确定退货收货库位，指定的库位必须属于收货仓库；
+// 隔离收货时库位必须为隔离库位，未指定库位时取仓库中编码最小的隔离库位
+func salesReturnLocation(db *gorm.DB, item schemas.ReturnItem) (string, error) {
+	if item.WarehouseId == "" {
+		if item.LocationId != "" || item.Quarantine {
+			return "", fmt.Errorf("return item of order item %s requires a warehouse", item.OrderItemId)
+		}
+		return "", nil
+	}
+
+	if item.LocationId != "" {
+		var location models.InventoryLocation
+		if err := db.First(&location, "id = ?", item.LocationId).Error; err != nil {
+			return "", err
+		}
+		if location.WarehouseID != item.WarehouseId {
+			return "", fmt.Errorf("location %s does not belong to warehouse %s", location.Code, item.WarehouseId)
+		}
+		if item.Quarantine && location.Type != locationTypeQuarantine {
+			return "", fmt.Errorf("location %s is not a quarantine location", location.Code)
+		}
+		return location.ID, nil
+	}
+
+	if !item.Quarantine {
+		return "", nil
+	}
+	var location models.InventoryLocation
+	result := db.Where("warehouse_id = ? AND type = ?", item.WarehouseId, locationTypeQuarantine).Order("code ASC").First(&location)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return "", fmt.Errorf("warehouse %s has no quarantine location", item.WarehouseId)
+	}
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return location.ID, nil
+}
+
+// checkSalesReturnQuantities 按订单当前可退数量校验退货单明细，防止审核后发生的其他退货超出已发数量；
+// 在事务中锁定订单，同一订单的退货审核和收货依次校验
+func checkSalesReturnQuantities(tx *gorm.DB, returnOrder models.SalesReturn) error {
+	var order models.SalesOrder
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items").First(&order, "id = ?", returnOrder.OrderID).Error; err != nil {
+		return err
+	}
+	returnable, err := salesReturnableQuantities(tx, order, returnOrder.ID)
+	if err != nil {
+		return err
+	}
+	return checkReturnableQuantities(returnable, returnOrder.Items)
+}
+
+// checkReturnableQuantities 逐行扣减可退数量，同一订单明细的多行退货合计不能超过可退数量
+func checkReturnableQuantities(returnable map[string]float64, items []models.SalesReturnItem) error {
+	remaining := make(map[string]float64, len(returnable))
+	for id, quantity := range returnable {
+		remaining[id] = quantity
+	}
+	for _, item := range items {
+		if item.Quantity > remaining[item.OrderItemID] {
+			return fmt.Errorf("return quantity %.4f exceeds returnable quantity %.4f of order item %s", item.Quantity, remaining[item.OrderItemID], item.OrderItemID)
+		}
+		remaining[item.OrderItemID] = roundQuantity(remaining[item.OrderItemID] - item.Quantity)
+	}
+	return nil
+}
+
+// newSalesCreditNote 按退货单明细构建贷项通知单并返回计税日期：有原发票时沿用发票的含税方式并按开票日期计税，
+// 未指定发票时按收货日期和默认含税方式计税
+func newSalesCreditNote(returnOrder models.SalesReturn, invoice *models.SalesInvoice, date time.Time, req schemas.ReceiveReturnRequest) (models.SalesCreditNote, time.Time) {
+	creditNoteNo := req.CreditNoteNo
+	if creditNoteNo == "" {
+		creditNoteNo = autoJournalNo("CN")
+	}
+	note := models.SalesCreditNote{
+		ID:               utils.GenerateID(),
+		CreditNoteNo:     creditNoteNo,
+		ReturnID:         returnOrder.ID,
+		OrderID:          returnOrder.OrderID,
+		CustomerID:       returnOrder.CustomerID,
+		InvoiceID:        returnOrder.InvoiceID,
+		CreditDate:       date,
+		PriceIncludesTax: pricesIncludeTax(nil),
+		Currency:         returnOrder.Currency,
+		ExchangeRate:     returnOrder.ExchangeRate,
+		Status:           creditNoteStatusOpen,
+		Remarks:          req.Remarks,
+		Items:            make([]models.SalesCreditNoteItem, len(returnOrder.Items)),
+		CreatedBy:        req.CreatedBy,
+		CreatedAt:        time.Now(),
+		UpdatedBy:        req.CreatedBy,
+		UpdatedAt:        time.Now(),
+	}
+	taxDate := date
+	if invoice != nil {
+		note.PriceIncludesTax, taxDate = invoice.PriceIncludesTax, invoice.InvoiceDate
+	}
+	for i, item := range returnOrder.Items {
+		note.Items[i] = models.SalesCreditNoteItem{
+			ID:           utils.GenerateID(),
+			CreditNoteID: note.ID,
+			ReturnItemID: item.ID,
+			ProductID:    item.ProductID,
+			Quantity:     item.Quantity,
+			UnitPrice:    item.UnitPrice,
+			Amount:       item.Amount,
+			CreatedBy:    req.CreatedBy,
+			CreatedAt:    time.Now(),
+			UpdatedBy:    req.CreatedBy,
+			UpdatedAt:    time.Now(),
+		}
+	}
+	return note, taxDate
+}
+
+// updateSalesReturnStatus 按当前状态条件更新退货单状态，退货单已被并发处理、状态不符时返回错误
+func updateSalesReturnStatus(tx *gorm.DB, id string, from []string, to, operator string) error {
+	result := tx.Model(&models.SalesReturn{}).
+		Where("id = ? AND status IN ?", id, from).
+		Updates(map[string]interface{}{
+			"status":     to,
+			"updated_by": operator,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("sales return has already been processed")
+	}
+	return nil
+}
+
+// salesReturnUnitCosts 按订单发货出库的平均单位成本确定各物料退货入库成本，
+// 没有出库记录的物料返回零，入库时沿用当前成本
+func salesReturnUnitCosts(db *gorm.DB, orderID string) (map[string]float64, error) {
+	var rows []struct {
+		ItemID    string
+		Quantity  float64
+		TotalCost float64
+	}
+	result := db.Table("inventory_transactions AS t").
+		Select("t.item_id AS item_id, SUM(t.quantity) AS quantity, SUM(t.total_cost) AS total_cost").
+		Joins("JOIN sales_deliveries AS d ON d.id = t.reference_id").
+		Where("t.reference_type = ? AND d.order_id = ? AND t.deleted_at IS NULL", "sales_delivery", orderID).
+		Group("t.item_id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	costs := make(map[string]float64, len(rows))
+	for _, row := range rows {
+		if row.Quantity != 0 {
+			costs[row.ItemID] = roundUnitCost(row.TotalCost / row.Quantity)
+		}
+	}
+	return costs, nil
+}
+
+// creditSalesInvoice 以贷项通知单冲减原发票余额，冲减金额不超过发票余额，
+// 同一事务中锁定发票防止与收款核销并发
+func creditSalesInvoice(tx *gorm.DB, note *models.SalesCreditNote, operator string) error {
+	var invoice models.SalesInvoice
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&invoice, "id = ?", note.InvoiceID).Error; err != nil {
+		return err
+	}
+	if invoice.Status == "cancelled" {
+		return fmt.Errorf("sales invoice %s is cancelled", invoice.InvoiceNo)
+	}
+
+	applied := math.Min(note.TotalAmount, math.Max(salesInvoiceBalance(invoice), 0))
+	note.AppliedAmount = roundAmount(applied)
+	if note.AppliedAmount >= note.TotalAmount {
+		note.Status = creditNoteStatusApplied
+	}
+	if note.AppliedAmount == 0 {
+		return nil
+	}
+
+	invoice.CreditedAmount = roundAmount(invoice.CreditedAmount + note.AppliedAmount)
+	settleSalesInvoice(&invoice)
+	return tx.Model(&models.SalesInvoice{}).
+		Where("id = ?", invoice.ID).
+		Updates(map[string]interface{}{
+			"credited_amount": invoice.CreditedAmount,
+			"status":          invoice.Status,
+			"updated_by":      operator,
+			"updated_at":      time.Now(),
+		}).Error
+}
+
+// salesCreditNotePostingDocument 贷项通知单按本位币金额生成凭证，tax为冲减的销项税额，net为不含税金额
+func salesCreditNotePostingDocument(note models.SalesCreditNote) postingDocument {
+	return postingDocument{
+		DocumentType: postingDocumentSalesReturn,
+		Event:        salesReturnEventCredit,
+		ReferenceID:  note.ID,
+		DocumentNo:   note.CreditNoteNo,
+		Date:         note.CreditDate,
+		Description:  fmt.Sprintf("销售贷项通知单%s", note.CreditNoteNo),
+		Currency:     note.Currency,
+		ExchangeRate: note.ExchangeRate,
+		Amounts: map[string]float64{
+			"total": note.BaseTotalAmount,
+			"tax":   note.BaseTaxAmount,
+			"net":   roundAmount(note.BaseTotalAmount - note.BaseTaxAmount),
+		},
+		CurrencyAmounts: map[string]float64{
+			"total": note.TotalAmount,
+			"tax":   note.TaxAmount,
+			"net":   roundAmount(note.TotalAmount - note.TaxAmount),
+		},
+		CreatedBy: note.CreatedBy,
+	}
+}
+
+// salesReturnResponse 将销售退货单模型转换为响应格式，明细和贷项通知单已预加载时一并转换
+func salesReturnResponse(returnOrder models.SalesReturn) schemas.ReturnResponse {
+	response := schemas.ReturnResponse{
+		ID:           returnOrder.ID,
+		ReturnNo:     returnOrder.ReturnNo,
+		OrderId:      returnOrder.OrderID,
+		CustomerId:   returnOrder.CustomerID,
+		CustomerName: returnOrder.Customer.Name,
+		InvoiceId:    returnOrder.InvoiceID,
+		ReturnDate:   returnOrder.ReturnDate.Format("2006-01-02"),
+		Reason:       returnOrder.Reason,
+		Remarks:      returnOrder.Remarks,
+		TotalAmount:  returnOrder.TotalAmount,
+		Currency:     returnOrder.Currency,
+		ExchangeRate: returnOrder.ExchangeRate,
+		Status:       returnOrder.Status,
+		Items:        make([]schemas.ReturnItem, len(returnOrder.Items)),
+		CreatedBy:    returnOrder.CreatedBy,
+		CreatedAt:    returnOrder.CreatedAt,
+		UpdatedBy:    returnOrder.UpdatedBy,
+		UpdatedAt:    returnOrder.UpdatedAt,
+	}
+	for i, item := range returnOrder.Items {
+		response.Items[i] = schemas.ReturnItem{
+			ID:          item.ID,
+			OrderItemId: item.OrderItemID,
+			ProductId:   item.ProductID,
+			ProductCode: item.Product.ProductNo,
+			ProductName: item.Product.Name,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Amount:      item.Amount,
+			WarehouseId: item.WarehouseID,
+			LocationId:  item.LocationID,
+			Quarantine:  item.Quarantine,
+		}
+	}
+	if returnOrder.CreditNote != nil {
+		note := salesCreditNoteResponse(*returnOrder.CreditNote)
+		response.CreditNote = &note
+	}
+	return response
+}
+
+// salesCreditNoteResponse 将贷项通知单模型转换为响应格式，明细已预加载时一并转换
+func salesCreditNoteResponse(note models.SalesCreditNote) schemas.CreditNoteResponse {
+	response := schemas.CreditNoteResponse{
+		ID:               note.ID,
+		CreditNoteNo:     note.CreditNoteNo,
+		ReturnId:         note.ReturnID,
+		OrderId:          note.OrderID,
+		CustomerId:       note.CustomerID,
+		CustomerName:     note.Customer.Name,
+		InvoiceId:        note.InvoiceID,
+		CreditDate:       note.CreditDate.Format("2006-01-02"),
+		TotalAmount:      note.TotalAmount,
+		TaxAmount:        note.TaxAmount,
+		PriceIncludesTax: note.PriceIncludesTax,
+		Currency:         note.Currency,
+		ExchangeRate:     note.ExchangeRate,
+		BaseTotalAmount:  note.BaseTotalAmount,
+		BaseTaxAmount:    note.BaseTaxAmount,
+		AppliedAmount:    note.AppliedAmount,
+		UnappliedAmount:  roundAmount(note.TotalAmount - note.AppliedAmount),
+		Status:           note.Status,
+		Remarks:          note.Remarks,
+		Items:            make([]schemas.CreditNoteItem, len(note.Items)),
+		CreatedBy:        note.CreatedBy,
+		CreatedAt:        note.CreatedAt,
+	}
+	for i, item := range note.Items {
+		response.Items[i] = schemas.CreditNoteItem{
+			ID:          item.ID,
+			ProductId:   item.ProductID,
+			ProductCode: item.Product.ProductNo,
+			ProductName: item.Product.Name,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Amount:      item.Amount,
+			TaxCodeId:   item.TaxCodeID,
+			TaxRate:     item.TaxRate,
+			NetAmount:   item.NetAmount,
+			TaxAmount:   item.TaxAmount,
+		}
+	}
+	return response
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/models"
+)
+
+// TestCheckReturnableQuantities 测试多张退货单累计占用可退数量，同一订单明细的退货合计不能超过已发数量
+func TestCheckReturnableQuantities(t *testing.T) {
+	orderItems := []models.SalesOrderItem{
+		{ID: "item-a", Quantity: 12, ShippedQuantity: 10},
+		{ID: "item-b", Quantity: 5, ShippedQuantity: 5},
+		{ID: "item-c", Quantity: 8},
+	}
+
+	tests := []struct {
+		name     string
+		returned map[string]float64
+		items    []models.SalesReturnItem
+		wantErr  bool
+	}{
+		{
+			name:  "首次退货不超过已发数量",
+			items: []models.SalesReturnItem{{OrderItemID: "item-a", Quantity: 10}, {OrderItemID: "item-b", Quantity: 5}},
+		},
+		{
+			name:     "其他退货单已占用部分数量",
+			returned: map[string]float64{"item-a": 6},
+			items:    []models.SalesReturnItem{{OrderItemID: "item-a", Quantity: 4}},
+		},
+		{
+			name:     "其他退货单占用后超出",
+			returned: map[string]float64{"item-a": 6},
+			items:    []models.SalesReturnItem{{OrderItemID: "item-a", Quantity: 4.5}},
+			wantErr:  true,
+		},
+		{
+			name:     "同一明细多行合计超出",
+			returned: map[string]float64{"item-a": 6},
+			items:    []models.SalesReturnItem{{OrderItemID: "item-a", Quantity: 3}, {OrderItemID: "item-a", Quantity: 2}},
+			wantErr:  true,
+		},
+		{
+			name:     "已全部退货",
+			returned: map[string]float64{"item-a": 4, "item-b": 5},
+			items:    []models.SalesReturnItem{{OrderItemID: "item-b", Quantity: 0.5}},
+			wantErr:  true,
+		},
+		{
+			name:    "未发货的明细不能退货",
+			items:   []models.SalesReturnItem{{OrderItemID: "item-c", Quantity: 1}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			returnable := returnableQuantities(orderItems, tt.returned)
+			before := returnable["item-a"]
+			err := checkReturnableQuantities(returnable, tt.items)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if returnable["item-a"] != before {
+				t.Errorf("Expected returnable quantities unchanged, item-a %.4f became %.4f", before, returnable["item-a"])
+			}
+		})
+	}
+}
+
+// TestSalesCreditNoteTax 测试贷项通知单沿用原发票的含税方式和开票日期计税，并按本位币金额生成凭证
+func TestSalesCreditNoteTax(t *testing.T) {
+	invoiceDate := time.Date(2024, 5, 10, 0, 0, 0, 0, time.Local)
+	receiveDate := time.Date(2024, 6, 3, 0, 0, 0, 0, time.Local)
+	returnOrder := models.SalesReturn{
+		ID:           "return-001",
+		OrderID:      "order-001",
+		CustomerID:   "customer-001",
+		InvoiceID:    "invoice-001",
+		Currency:     "USD",
+		ExchangeRate: 7,
+		Items:        []models.SalesReturnItem{{ID: "return-item-001", ProductID: "product-001", Quantity: 1, UnitPrice: 113, Amount: 113}},
+	}
+
+	tests := []struct {
+		name          string
+		inclusive     bool
+		wantTax       float64
+		wantTotal     float64
+		wantBaseTotal float64
+		wantBaseTax   float64
+		wantBaseNet   float64
+	}{
+		{name: "含税价发票", inclusive: true, wantTax: 13, wantTotal: 113, wantBaseTotal: 791, wantBaseTax: 91, wantBaseNet: 700},
+		{name: "不含税价发票", inclusive: false, wantTax: 14.69, wantTotal: 127.69, wantBaseTotal: 893.83, wantBaseTax: 102.83, wantBaseNet: 791},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invoice := models.SalesInvoice{ID: "invoice-001", InvoiceDate: invoiceDate, PriceIncludesTax: tt.inclusive}
+			note, taxDate := newSalesCreditNote(returnOrder, &invoice, receiveDate, schemas.ReceiveReturnRequest{CreditNoteNo: "CN001", CreatedBy: "user-001"})
+			if note.PriceIncludesTax != tt.inclusive || !taxDate.Equal(invoiceDate) {
+				t.Fatalf("Expected credit note to follow invoice (inclusive %v, tax date %s), got %v %s",
+					tt.inclusive, invoiceDate.Format("2006-01-02"), note.PriceIncludesTax, taxDate.Format("2006-01-02"))
+			}
+
+			lines := []taxLine{{Amount: note.Items[0].Amount, TaxCodeID: "VAT13", TaxRate: 13}}
+			taxed, tax := calculateTax(lines, note.PriceIncludesTax, "line")
+			setSalesCreditNoteTax(&note, documentTaxTotals(lines, taxed, tax, note.PriceIncludesTax, note.ExchangeRate))
+			if note.TaxAmount != tt.wantTax || note.TotalAmount != tt.wantTotal {
+				t.Errorf("Expected tax %.2f total %.2f, got %.2f %.2f", tt.wantTax, tt.wantTotal, note.TaxAmount, note.TotalAmount)
+			}
+			if note.Items[0].TaxCodeID != "VAT13" || note.Items[0].TaxAmount != tt.wantTax {
+				t.Errorf("Expected line tax code VAT13 tax %.2f, got %s %.2f", tt.wantTax, note.Items[0].TaxCodeID, note.Items[0].TaxAmount)
+			}
+
+			document := salesCreditNotePostingDocument(note)
+			if document.DocumentType != postingDocumentSalesReturn || document.ReferenceID != note.ID || !document.Date.Equal(receiveDate) {
+				t.Errorf("Unexpected posting document header %+v", document)
+			}
+			if document.Amounts["total"] != tt.wantBaseTotal || document.Amounts["tax"] != tt.wantBaseTax || document.Amounts["net"] != tt.wantBaseNet {
+				t.Errorf("Expected base total %.2f tax %.2f net %.2f, got %v", tt.wantBaseTotal, tt.wantBaseTax, tt.wantBaseNet, document.Amounts)
+			}
+			if document.CurrencyAmounts["total"] != tt.wantTotal || document.CurrencyAmounts["tax"] != tt.wantTax {
+				t.Errorf("Expected currency total %.2f tax %.2f, got %v", tt.wantTotal, tt.wantTax, document.CurrencyAmounts)
+			}
+		})
+	}
+
+	// 未指定原发票时按收货日期计税
+	returnOrder.InvoiceID = ""
+	note, taxDate := newSalesCreditNote(returnOrder, nil, receiveDate, schemas.ReceiveReturnRequest{CreatedBy: "user-001"})
+	if !taxDate.Equal(receiveDate) || note.CreditNoteNo == "" || note.InvoiceID != "" {
+		t.Errorf("Expected tax date %s and generated credit note number, got %s %q", receiveDate.Format("2006-01-02"), taxDate.Format("2006-01-02"), note.CreditNoteNo)
+	}
+}
@@ -2,69 +2,103 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"strings"
 	"time"
 
+	"github.com/wu136995/ginx/internal/api/schemas"
 	"github.com/wu136995/ginx/internal/database"
 	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/query"
+	"github.com/wu136995/ginx/internal/utils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // SalesService 销售服务接口
 type SalesService interface {
 	// 客户管理
-	GetCustomerList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetCustomerDetail(id string) (map[string]interface{}, error)
-	CreateCustomer(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateCustomer(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetCustomerList(params query.Params) (*query.Page[schemas.CustomerResponse], error)
+	GetCustomerDetail(id string) (*schemas.CustomerResponse, error)
+	CreateCustomer(req schemas.CreateCustomerRequest) (*schemas.CustomerResponse, error)
+	UpdateCustomer(id string, req schemas.UpdateCustomerRequest) (*schemas.CustomerResponse, error)
 	DeleteCustomer(id string) error
-	CustomerCreditEvaluate(id string) (map[string]interface{}, error)
+	CustomerCreditEvaluate(id string) (*schemas.CustomerCreditResponse, error)
+	EvaluateCustomerCredits() ([]schemas.CustomerCreditResponse, error)
+	GetCustomerCreditHistory(id string, params query.Params) (*query.Page[schemas.CustomerCreditEvaluationResponse], error)
 
 	// 销售报价管理
-	GetQuotationList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetQuotationDetail(id string) (map[string]interface{}, error)
-	CreateQuotation(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateQuotation(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetQuotationList(params query.Params) (*query.Page[schemas.QuotationResponse], error)
+	GetQuotationDetail(id string) (*schemas.QuotationResponse, error)
+	CreateQuotation(req schemas.CreateQuotationRequest) (*schemas.QuotationResponse, error)
+	UpdateQuotation(id string, req schemas.UpdateQuotationRequest) (*schemas.QuotationResponse, error)
 	DeleteQuotation(id string) error
 	ApproveQuotation(id string) error
-	GenerateOrderFromQuotation(id string) (map[string]interface{}, error)
+	GenerateOrderFromQuotation(id string) (*schemas.OrderResponse, error)
 
 	// 销售订单管理
-	GetOrderList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetOrderDetail(id string) (map[string]interface{}, error)
-	CreateOrder(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateOrder(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetOrderList(params query.Params) (*query.Page[schemas.OrderResponse], error)
+	GetOrderDetail(id string) (*schemas.OrderResponse, error)
+	CreateOrder(req schemas.CreateOrderRequest) (*schemas.OrderResponse, error)
+	UpdateOrder(id string, req schemas.UpdateOrderRequest) (*schemas.OrderResponse, error)
 	DeleteOrder(id string) error
-	ApproveOrder(id string) error
+	ApproveOrder(id, operator string) error
+	ReleaseCreditHold(id, operator string, req schemas.ReleaseCreditHoldRequest) error
 	CancelOrder(id string) error
-	GenerateDeliveryFromOrder(id string) (map[string]interface{}, error)
+	ReserveOrder(id, operator string) error
+	GenerateDeliveryFromOrder(id string) (*schemas.DeliveryResponse, error)
+	GenerateInvoiceFromOrder(id string) (*schemas.InvoiceResponse, error)
 
 	// 销售发货管理
-	GetDeliveryList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetDeliveryDetail(id string) (map[string]interface{}, error)
-	CreateDelivery(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateDelivery(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetDeliveryList(params query.Params) (*query.Page[schemas.DeliveryResponse], error)
+	GetDeliveryDetail(id string) (*schemas.DeliveryResponse, error)
+	CreateDelivery(req schemas.CreateDeliveryRequest) (*schemas.DeliveryResponse, error)
+	UpdateDelivery(id string, req schemas.UpdateDeliveryRequest) (*schemas.DeliveryResponse, error)
 	DeleteDelivery(id string) error
+	ShipDelivery(id string) error
 
 	// 销售发票管理
-	GetInvoiceList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetInvoiceDetail(id string) (map[string]interface{}, error)
-	CreateInvoice(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateInvoice(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetInvoiceList(params query.Params) (*query.Page[schemas.InvoiceResponse], error)
+	GetInvoiceDetail(id string) (*schemas.InvoiceResponse, error)
+	CreateInvoice(req schemas.CreateInvoiceRequest) (*schemas.InvoiceResponse, error)
+	UpdateInvoice(id string, req schemas.UpdateInvoiceRequest) (*schemas.InvoiceResponse, error)
 	DeleteInvoice(id string) error
-	ReceiveInvoicePayment(id string, req map[string]interface{}) error
+	ReceiveInvoicePayment(id string, req schemas.ReceiveInvoicePaymentRequest) error
 
 	// 销售退货管理
-	GetReturnList(req map[string]interface{}) ([]map[string]interface{}, error)
-	GetReturnDetail(id string) (map[string]interface{}, error)
-	CreateReturn(req map[string]interface{}) (map[string]interface{}, error)
-	UpdateReturn(id string, req map[string]interface{}) (map[string]interface{}, error)
+	GetReturnList(params query.Params) (*query.Page[schemas.ReturnResponse], error)
+	GetReturnDetail(id string) (*schemas.ReturnResponse, error)
+	CreateReturn(req schemas.CreateReturnRequest) (*schemas.ReturnResponse, error)
+	UpdateReturn(id string, req schemas.UpdateReturnRequest) (*schemas.ReturnResponse, error)
 	DeleteReturn(id string) error
+	ApproveReturn(id, operator string) error
+	CancelReturn(id, operator string) error
+	ReceiveReturn(id string, req schemas.ReceiveReturnRequest) (*schemas.ReturnResponse, error)
+	GetCreditNoteList(params query.Params) (*query.Page[schemas.CreditNoteResponse], error)
+	GetCreditNoteDetail(id string) (*schemas.CreditNoteResponse, error)
+
+	// 销售定价管理
+	QuotePrice(req schemas.PriceQuoteRequest) (*schemas.PriceQuoteResponse, error)
+	GetPriceListList(params query.Params) (*query.Page[schemas.PriceListResponse], error)
+	GetPriceListDetail(id string) (*schemas.PriceListResponse, error)
+	CreatePriceList(req schemas.CreatePriceListRequest) (*schemas.PriceListResponse, error)
+	UpdatePriceList(id string, req schemas.UpdatePriceListRequest) (*schemas.PriceListResponse, error)
+	DeletePriceList(id string) error
+	GetCustomerPriceList(params query.Params) (*query.Page[schemas.CustomerPriceResponse], error)
+	CreateCustomerPrice(req schemas.CreateCustomerPriceRequest) (*schemas.CustomerPriceResponse, error)
+	UpdateCustomerPrice(id string, req schemas.UpdateCustomerPriceRequest) (*schemas.CustomerPriceResponse, error)
+	DeleteCustomerPrice(id string) error
+	GetPromotionList(params query.Params) (*query.Page[schemas.PromotionResponse], error)
+	CreatePromotion(req schemas.CreatePromotionRequest) (*schemas.PromotionResponse, error)
+	UpdatePromotion(id string, req schemas.UpdatePromotionRequest) (*schemas.PromotionResponse, error)
+	DeletePromotion(id string) error
 
 	// 销售报表管理
-	GetOrderExecutionReport(req map[string]interface{}) (map[string]interface{}, error)
-	GetCustomerAnalysisReport(req map[string]interface{}) (map[string]interface{}, error)
-	GetProductTrendReport(req map[string]interface{}) (map[string]interface{}, error)
-	ExportSalesReport(req map[string]interface{}) ([]byte, error)
+	GetOrderExecutionReport(req schemas.SalesReportRequest) (*schemas.OrderExecutionReportResponse, error)
+	GetCustomerAnalysisReport(req schemas.SalesReportRequest) (*schemas.CustomerAnalysisReportResponse, error)
+	GetProductTrendReport(req schemas.SalesReportRequest) (*schemas.ProductTrendReportResponse, error)
+	ExportSalesReport(req schemas.ExportSalesReportRequest) ([]byte, error)
 }
 
 // salesService 销售服务实现
@@ -79,8 +113,22 @@ func NewSalesService() SalesService {
 	}
 }
 
+// customerListSpec 客户列表查询白名单
+var customerListSpec = query.NewSpec("-created_at",
+	query.Text("customer_no"),
+	query.Text("name"),
+	query.Text("contact_person"),
+	query.Text("phone"),
+	query.Text("category"),
+	query.Text("region"),
+	query.Text("status"),
+	query.Number("credit_limit"),
+	query.Number("credit_balance"),
+	query.Date("created_at"),
+)
+
 // 客户管理方法
-func (s *salesService) GetCustomerList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *salesService) GetCustomerList(params query.Params) (*query.Page[schemas.CustomerResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -88,42 +136,21 @@ func (s *salesService) GetCustomerList(req map[string]interface{}) ([]map[string
 
 	// 从数据库读取客户数据
 	var customers []models.SalesCustomer
-	result := s.db.Find(&customers)
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := query.Find(s.db, params, customerListSpec, &customers)
+	if err != nil {
+		return nil, err
 	}
 
-	// 将模型转换为map
-	customerList := make([]map[string]interface{}, len(customers))
+	// 将模型转换为响应格式
+	response := make([]schemas.CustomerResponse, len(customers))
 	for i, customer := range customers {
-		customerList[i] = map[string]interface{}{
-			"id":             customer.ID,
-			"customer_no":    customer.CustomerNo,
-			"name":           customer.Name,
-			"contact_person": customer.ContactPerson,
-			"phone":          customer.Phone,
-			"email":          customer.Email,
-			"address":        customer.Address,
-			"tax_no":         customer.TaxNo,
-			"bank_name":      customer.BankName,
-			"bank_account":   customer.BankAccount,
-			"credit_limit":   customer.CreditLimit,
-			"credit_balance": customer.CreditBalance,
-			"payment_terms":  customer.PaymentTerms,
-			"credit_days":    customer.CreditDays,
-			"status":         customer.Status,
-			"remarks":        customer.Remarks,
-			"created_at":     customer.CreatedAt,
-			"created_by":     customer.CreatedBy,
-			"updated_at":     customer.UpdatedAt,
-			"updated_by":     customer.UpdatedBy,
-		}
+		response[i] = salesCustomerResponse(customer)
 	}
 
-	return customerList, nil
+	return query.NewPage(response, total, params), nil
 }
 
-func (s *salesService) GetCustomerDetail(id string) (map[string]interface{}, error) {
+func (s *salesService) GetCustomerDetail(id string) (*schemas.CustomerResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -136,60 +163,49 @@ func (s *salesService) GetCustomerDetail(id string) (map[string]interface{}, err
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	customerDetail := map[string]interface{}{
-		"id":             customer.ID,
-		"customer_no":    customer.CustomerNo,
-		"name":           customer.Name,
-		"contact_person": customer.ContactPerson,
-		"phone":          customer.Phone,
-		"email":          customer.Email,
-		"address":        customer.Address,
-		"tax_no":         customer.TaxNo,
-		"bank_name":      customer.BankName,
-		"bank_account":   customer.BankAccount,
-		"credit_limit":   customer.CreditLimit,
-		"credit_balance": customer.CreditBalance,
-		"payment_terms":  customer.PaymentTerms,
-		"credit_days":    customer.CreditDays,
-		"status":         customer.Status,
-		"remarks":        customer.Remarks,
-		"created_at":     customer.CreatedAt,
-		"created_by":     customer.CreatedBy,
-		"updated_at":     customer.UpdatedAt,
-		"updated_by":     customer.UpdatedBy,
-	}
-
-	return customerDetail, nil
+	response := salesCustomerResponse(customer)
+	return &response, nil
 }
 
-func (s *salesService) CreateCustomer(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *salesService) CreateCustomer(req schemas.CreateCustomerRequest) (*schemas.CustomerResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 从请求中获取数据
+	// 创建客户模型
+	status := req.Status
+	if status == "" {
+		status = "active"
+	}
+	taxStatus := req.TaxStatus
+	if taxStatus == "" {
+		taxStatus = "taxable"
+	}
 	customer := models.SalesCustomer{
-		ID:            req["id"].(string),
-		CustomerNo:    req["customer_no"].(string),
-		Name:          req["name"].(string),
-		ContactPerson: req["contact_person"].(string),
-		Phone:         req["phone"].(string),
-		Email:         req["email"].(string),
-		Address:       req["address"].(string),
-		TaxNo:         req["tax_no"].(string),
-		BankName:      req["bank_name"].(string),
-		BankAccount:   req["bank_account"].(string),
-		CreditLimit:   req["credit_limit"].(float64),
-		CreditBalance: 0,
-		PaymentTerms:  req["payment_terms"].(string),
-		Status:        req["status"].(string),
-		Remarks:       req["remarks"].(string),
+		ID:            utils.GenerateID(),
+		CustomerNo:    req.CustomerNo,
+		Name:          req.Name,
+		ContactPerson: req.Contact,
+		Phone:         req.Phone,
+		Email:         req.Email,
+		Address:       req.Address,
+		TaxNo:         req.TaxNo,
+		BankName:      req.BankName,
+		BankAccount:   req.BankAccount,
+		Category:      req.Category,
+		Region:        req.Region,
+		CreditLimit:   req.CreditLimit,
+		CreditDays:    req.CreditDays,
+		TaxStatus:     taxStatus,
+		Currency:      strings.ToUpper(req.Currency),
+		PaymentTerms:  req.PaymentTerms,
+		Remarks:       req.Remarks,
+		Status:        status,
+		CreatedBy:     req.CreatedBy,
 		CreatedAt:     time.Now(),
-		CreatedBy:     req["created_by"].(string),
+		UpdatedBy:     req.CreatedBy,
 		UpdatedAt:     time.Now(),
-		UpdatedBy:     req["created_by"].(string),
 	}
 
 	// 保存到数据库
@@ -198,33 +214,11 @@ func (s *salesService) CreateCustomer(req map[string]interface{}) (map[string]in
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	createdCustomer := map[string]interface{}{
-		"id":             customer.ID,
-		"customer_no":    customer.CustomerNo,
-		"name":           customer.Name,
-		"contact_person": customer.ContactPerson,
-		"phone":          customer.Phone,
-		"email":          customer.Email,
-		"address":        customer.Address,
-		"tax_no":         customer.TaxNo,
-		"bank_name":      customer.BankName,
-		"bank_account":   customer.BankAccount,
-		"credit_limit":   customer.CreditLimit,
-		"credit_balance": customer.CreditBalance,
-		"payment_terms":  customer.PaymentTerms,
-		"status":         customer.Status,
-		"remarks":        customer.Remarks,
-		"created_at":     customer.CreatedAt,
-		"created_by":     customer.CreatedBy,
-		"updated_at":     customer.UpdatedAt,
-		"updated_by":     customer.UpdatedBy,
-	}
-
-	return createdCustomer, nil
+	response := salesCustomerResponse(customer)
+	return &response, nil
 }
 
-func (s *salesService) UpdateCustomer(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *salesService) UpdateCustomer(id string, req schemas.UpdateCustomerRequest) (*schemas.CustomerResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -238,20 +232,59 @@ func (s *salesService) UpdateCustomer(id string, req map[string]interface{}) (ma
 	}
 
 	// 更新字段
-	customer.Name = req["name"].(string)
-	customer.ContactPerson = req["contact_person"].(string)
-	customer.Phone = req["phone"].(string)
-	customer.Email = req["email"].(string)
-	customer.Address = req["address"].(string)
-	customer.TaxNo = req["tax_no"].(string)
-	customer.BankName = req["bank_name"].(string)
-	customer.BankAccount = req["bank_account"].(string)
-	customer.CreditLimit = req["credit_limit"].(float64)
-	customer.PaymentTerms = req["payment_terms"].(string)
-	customer.Status = req["status"].(string)
-	customer.Remarks = req["remarks"].(string)
+	if req.Name != "" {
+		customer.Name = req.Name
+	}
+	if req.Contact != "" {
+		customer.ContactPerson = req.Contact
+	}
+	if req.Phone != "" {
+		customer.Phone = req.Phone
+	}
+	if req.Email != "" {
+		customer.Email = req.Email
+	}
+	if req.Address != "" {
+		customer.Address = req.Address
+	}
+	if req.TaxNo != "" {
+		customer.TaxNo = req.TaxNo
+	}
+	if req.BankName != "" {
+		customer.BankName = req.BankName
+	}
+	if req.BankAccount != "" {
+		customer.BankAccount = req.BankAccount
+	}
+	if req.Category != "" {
+		customer.Category = req.Category
+	}
+	if req.Region != "" {
+		customer.Region = req.Region
+	}
+	if req.CreditLimit != nil {
+		customer.CreditLimit = *req.CreditLimit
+	}
+	if req.CreditDays != nil {
+		customer.CreditDays = *req.CreditDays
+	}
+	if req.TaxStatus != "" {
+		customer.TaxStatus = req.TaxStatus
+	}
+	if req.Currency != "" {
+		customer.Currency = strings.ToUpper(req.Currency)
+	}
+	if req.PaymentTerms != "" {
+		customer.PaymentTerms = req.PaymentTerms
+	}
+	if req.Remarks != "" {
+		customer.Remarks = req.Remarks
+	}
+	if req.Status != "" {
+		customer.Status = req.Status
+	}
+	customer.UpdatedBy = req.UpdatedBy
 	customer.UpdatedAt = time.Now()
-	customer.UpdatedBy = req["updated_by"].(string)
 
 	// 保存到数据库
 	result = s.db.Save(&customer)
@@ -259,31 +292,8 @@ func (s *salesService) UpdateCustomer(id string, req map[string]interface{}) (ma
 		return nil, result.Error
 	}
 
-	// 将模型转换为map
-	updatedCustomer := map[string]interface{}{
-		"id":             customer.ID,
-		"customer_no":    customer.CustomerNo,
-		"name":           customer.Name,
-		"contact_person": customer.ContactPerson,
-		"phone":          customer.Phone,
-		"email":          customer.Email,
-		"address":        customer.Address,
-		"tax_no":         customer.TaxNo,
-		"bank_name":      customer.BankName,
-		"bank_account":   customer.BankAccount,
-		"credit_limit":   customer.CreditLimit,
-		"credit_balance": customer.CreditBalance,
-		"payment_terms":  customer.PaymentTerms,
-		"credit_days":    customer.CreditDays,
-		"status":         customer.Status,
-		"remarks":        customer.Remarks,
-		"created_at":     customer.CreatedAt,
-		"created_by":     customer.CreatedBy,
-		"updated_at":     customer.UpdatedAt,
-		"updated_by":     customer.UpdatedBy,
-	}
-
-	return updatedCustomer, nil
+	response := salesCustomerResponse(customer)
+	return &response, nil
 }
 
 func (s *salesService) DeleteCustomer(id string) error {
@@ -301,7 +311,7 @@ func (s *salesService) DeleteCustomer(id string) error {
 	return nil
 }
 
-func (s *salesService) CustomerCreditEvaluate(id string) (map[string]interface{}, error) {
+func (s *salesService) CustomerCreditEvaluate(id string) (*schemas.CustomerCreditResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -314,400 +324,1641 @@ func (s *salesService) CustomerCreditEvaluate(id string) (map[string]interface{}
 		return nil, result.Error
 	}
 
-	// 计算信用评估结果
-	creditEvaluation := map[string]interface{}{
-		"customer_id":      customer.ID,
-		"customer_name":    customer.Name,
-		"credit_limit":     customer.CreditLimit,
-		"credit_balance":   customer.CreditBalance,
-		"available_credit": customer.CreditLimit - customer.CreditBalance, // 假设模型中已添加 CreditBalance 字段，若未添加需同步修改模型
-		"evaluation_date":  time.Now(),
+	// 计算信用占用和评分并保存评估记录
+	var response schemas.CustomerCreditResponse
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		evaluation, exposure, err := evaluateCustomerCredit(tx, customer, "system")
+		if err != nil {
+			return err
+		}
+		response = customerCreditResponse(customer, evaluation, exposure)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return creditEvaluation, nil
+	return &response, nil
 }
 
-// 销售报价管理方法
-func (s *salesService) GetQuotationList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *salesService) EvaluateCustomerCredits() ([]schemas.CustomerCreditResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 实际应用中，这里需要从数据库读取销售报价数据
-	// 由于模型中可能没有定义销售报价表，暂时返回空列表
-	return []map[string]interface{}{}, nil
-}
+	// 从数据库读取启用的客户
+	var customers []models.SalesCustomer
+	result := s.db.Where("status = ?", "active").Order("customer_no ASC").Find(&customers)
+	if result.Error != nil {
+		return nil, result.Error
+	}
 
-func (s *salesService) GetQuotationDetail(id string) (map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+	// 逐个客户评估，每个客户的评估记录独立保存
+	response := make([]schemas.CustomerCreditResponse, 0, len(customers))
+	for _, customer := range customers {
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			evaluation, exposure, err := evaluateCustomerCredit(tx, customer, "system")
+			if err != nil {
+				return err
+			}
+			response = append(response, customerCreditResponse(customer, evaluation, exposure))
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate customer %s: %w", customer.CustomerNo, err)
+		}
 	}
 
-	// 实际应用中，这里需要从数据库读取销售报价详情
-	// 由于模型中可能没有定义销售报价表，暂时返回空map
-	return map[string]interface{}{}, nil
+	return response, nil
 }
 
-func (s *salesService) CreateQuotation(req map[string]interface{}) (map[string]interface{}, error) {
+// customerCreditListSpec 客户信用评估历史查询白名单
+var customerCreditListSpec = query.NewSpec("-evaluation_date",
+	query.Text("risk_level"),
+	query.Number("credit_score"),
+	query.Number("exposure"),
+	query.Number("overdue_days"),
+	query.Date("evaluation_date"),
+	query.Date("created_at"),
+)
+
+func (s *salesService) GetCustomerCreditHistory(id string, params query.Params) (*query.Page[schemas.CustomerCreditEvaluationResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 实际应用中，这里需要创建销售报价
-	// 由于模型中可能没有定义销售报价表，暂时返回空map
-	return map[string]interface{}{}, nil
-}
+	// 从数据库读取客户信用评估记录
+	var evaluations []models.SalesCustomerCredit
+	total, err := query.Find(s.db, params, customerCreditListSpec, &evaluations, func(db *gorm.DB) *gorm.DB {
+		return db.Where("customer_id = ?", id)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-func (s *salesService) UpdateQuotation(id string, req map[string]interface{}) (map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+	// 将模型转换为响应格式
+	response := make([]schemas.CustomerCreditEvaluationResponse, len(evaluations))
+	for i, evaluation := range evaluations {
+		response[i] = schemas.CustomerCreditEvaluationResponse{
+			ID:             evaluation.ID,
+			CustomerId:     evaluation.CustomerID,
+			EvaluationDate: evaluation.EvaluationDate.Format("2006-01-02"),
+			CreditScore:    evaluation.CreditScore,
+			RiskLevel:      evaluation.RiskLevel,
+			CreditLimit:    evaluation.CreditLimit,
+			Exposure:       evaluation.Exposure,
+			OverdueAmount:  evaluation.OverdueAmount,
+			OverdueDays:    evaluation.OverdueDays,
+			Remarks:        evaluation.Remarks,
+			CreatedBy:      evaluation.CreatedBy,
+			CreatedAt:      evaluation.CreatedAt,
+		}
 	}
 
-	// 实际应用中，这里需要更新销售报价
-	// 由于模型中可能没有定义销售报价表，暂时返回空map
-	return map[string]interface{}{}, nil
+	return query.NewPage(response, total, params), nil
 }
 
-func (s *salesService) DeleteQuotation(id string) error {
+// quoteListSpec 销售报价列表查询白名单
+var quoteListSpec = query.NewSpec("-quote_date",
+	query.Text("quote_no"),
+	query.Text("customer_id"),
+	query.Text("status"),
+	query.Number("total_amount"),
+	query.Date("quote_date"),
+	query.Date("valid_until"),
+	query.Date("created_at"),
+)
+
+// 销售报价管理方法
+func (s *salesService) GetQuotationList(params query.Params) (*query.Page[schemas.QuotationResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
-		return errors.New("database connection is nil")
+		return nil, errors.New("database connection is nil")
 	}
 
-	// 实际应用中，这里需要删除销售报价
-	// 由于模型中可能没有定义销售报价表，暂时返回nil
-	return nil
-}
+	// 从数据库读取销售报价数据
+	var quotes []models.SalesQuote
+	total, err := query.Find(s.db, params, quoteListSpec, &quotes, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Customer")
+	})
+	if err != nil {
+		return nil, err
+	}
 
-func (s *salesService) ApproveQuotation(id string) error {
-	// 检查数据库连接
-	if s.db == nil {
-		return errors.New("database connection is nil")
+	// 将模型转换为响应格式
+	response := make([]schemas.QuotationResponse, len(quotes))
+	for i, quote := range quotes {
+		response[i] = salesQuotationResponse(quote)
 	}
 
-	// 实际应用中，这里需要批准销售报价
-	// 由于模型中可能没有定义销售报价表，暂时返回nil
-	return nil
+	return query.NewPage(response, total, params), nil
 }
 
-func (s *salesService) GenerateOrderFromQuotation(id string) (map[string]interface{}, error) {
+func (s *salesService) GetQuotationDetail(id string) (*schemas.QuotationResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 实际应用中，这里需要从销售报价生成销售订单
-	// 由于模型中可能没有定义销售报价表和销售订单表，暂时返回空map
-	return map[string]interface{}{}, nil
-}
-
-// 销售订单管理方法
-func (s *salesService) GetOrderList(req map[string]interface{}) ([]map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+	// 从数据库读取销售报价及明细
+	var quote models.SalesQuote
+	result := s.db.Preload("Customer").Preload("Items.Product").First(&quote, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
 	}
 
-	// 实际应用中，这里需要从数据库读取销售订单数据
-	// 由于模型中可能没有定义销售订单表，暂时返回空列表
-	return []map[string]interface{}{}, nil
+	response := salesQuotationResponse(quote)
+	return &response, nil
 }
 
-func (s *salesService) GetOrderDetail(id string) (map[string]interface{}, error) {
+func (s *salesService) CreateQuotation(req schemas.CreateQuotationRequest) (*schemas.QuotationResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 实际应用中，这里需要从数据库读取销售订单详情
-	// 由于模型中可能没有定义销售订单表，暂时返回空map
-	return map[string]interface{}{}, nil
-}
+	// 解析报价日期和有效期
+	quoteDate, err := time.Parse("2006-01-02", req.QuotationDate)
+	if err != nil {
+		return nil, err
+	}
+	validUntil, err := time.Parse("2006-01-02", req.ExpiryDate)
+	if err != nil {
+		return nil, err
+	}
+	if validUntil.Before(quoteDate) {
+		return nil, errors.New("quotation expiry date is before quotation date")
+	}
 
-func (s *salesService) CreateOrder(req map[string]interface{}) (map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+	// 创建销售报价及明细
+	quote := models.SalesQuote{
+		ID:         utils.GenerateID(),
+		QuoteNo:    req.QuotationNo,
+		CustomerID: req.CustomerId,
+		QuoteDate:  quoteDate,
+		ValidUntil: validUntil,
+		Status:     "draft",
+		Remarks:    req.Remarks,
+		CreatedBy:  req.CreatedBy,
+		CreatedAt:  time.Now(),
+		UpdatedBy:  req.CreatedBy,
+		UpdatedAt:  time.Now(),
+	}
+	quote.Currency, quote.ExchangeRate, err = salesDocumentCurrency(s.db, quote.CustomerID, req.Currency, req.ExchangeRate, quote.QuoteDate)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err := newPricingContext(s.db, quote.CustomerID, quote.Currency, quote.QuoteDate)
+	if err != nil {
+		return nil, err
+	}
+	quote.Items, quote.TotalAmount, err = salesQuoteItems(s.db, ctx, quote.ID, req.Items, req.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+	quote.BaseTotalAmount = toBaseAmount(quote.TotalAmount, quote.ExchangeRate)
+
+	result := s.db.Create(&quote)
+	if result.Error != nil {
+		return nil, result.Error
 	}
 
-	// 实际应用中，这里需要创建销售订单
-	// 由于模型中可能没有定义销售订单表，暂时返回空map
-	return map[string]interface{}{}, nil
+	return s.GetQuotationDetail(quote.ID)
 }
 
-func (s *salesService) UpdateOrder(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *salesService) UpdateQuotation(id string, req schemas.UpdateQuotationRequest) (*schemas.QuotationResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 实际应用中，这里需要更新销售订单
-	// 由于模型中可能没有定义销售订单表，暂时返回空map
-	return map[string]interface{}{}, nil
-}
+	// 从数据库读取销售报价
+	var quote models.SalesQuote
+	result := s.db.First(&quote, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
 
-func (s *salesService) DeleteOrder(id string) error {
-	// 检查数据库连接
-	if s.db == nil {
-		return errors.New("database connection is nil")
+	// 只有草稿状态的报价可以修改
+	if quote.Status != "draft" {
+		return nil, fmt.Errorf("quotation in status %s cannot be updated", quote.Status)
 	}
 
-	// 实际应用中，这里需要删除销售订单
-	// 由于模型中可能没有定义销售订单表，暂时返回nil
-	return nil
-}
+	// 更新字段
+	if req.CustomerId != "" {
+		quote.CustomerID = req.CustomerId
+	}
+	if req.QuotationDate != "" {
+		quoteDate, err := time.Parse("2006-01-02", req.QuotationDate)
+		if err != nil {
+			return nil, err
+		}
+		quote.QuoteDate = quoteDate
+	}
+	if req.ExpiryDate != "" {
+		validUntil, err := time.Parse("2006-01-02", req.ExpiryDate)
+		if err != nil {
+			return nil, err
+		}
+		quote.ValidUntil = validUntil
+	}
+	if quote.ValidUntil.Before(quote.QuoteDate) {
+		return nil, errors.New("quotation expiry date is before quotation date")
+	}
+	if req.Remarks != "" {
+		quote.Remarks = req.Remarks
+	}
+	quote.UpdatedAt = time.Now()
+	quote.UpdatedBy = req.UpdatedBy
+
+	// 币种或报价日期变化时重新取汇率
+	if req.Currency != "" || req.QuotationDate != "" || req.ExchangeRate > 0 {
+		currency := req.Currency
+		if currency == "" {
+			currency = quote.Currency
+		}
+		var err error
+		quote.Currency, quote.ExchangeRate, err = salesDocumentCurrency(s.db, quote.CustomerID, currency, req.ExchangeRate, quote.QuoteDate)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-func (s *salesService) ApproveOrder(id string) error {
-	// 检查数据库连接
-	if s.db == nil {
-		return errors.New("database connection is nil")
+	// 明细、客户或币种变化时重新定价，未传明细时按原明细数量计算
+	items := req.Items
+	if len(items) == 0 && (req.CustomerId != "" || req.Currency != "") {
+		var current []models.SalesQuoteItem
+		if err := s.db.Where("quote_id = ?", quote.ID).Find(&current).Error; err != nil {
+			return nil, err
+		}
+		for _, item := range current {
+			items = append(items, schemas.QuotationItem{ProductId: item.ProductID, Quantity: item.Quantity})
+		}
+	}
+	if len(items) > 0 {
+		ctx, err := newPricingContext(s.db, quote.CustomerID, quote.Currency, quote.QuoteDate)
+		if err != nil {
+			return nil, err
+		}
+		quote.Items, quote.TotalAmount, err = salesQuoteItems(s.db, ctx, quote.ID, items, req.UpdatedBy)
+		if err != nil {
+			return nil, err
+		}
+	}
+	quote.BaseTotalAmount = toBaseAmount(quote.TotalAmount, quote.ExchangeRate)
+
+	// 在同一事务中替换明细并保存报价
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if len(quote.Items) > 0 {
+			if err := tx.Where("quote_id = ?", quote.ID).Delete(&models.SalesQuoteItem{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Create(&quote.Items).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Omit("Items").Save(&quote).Error
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// 实际应用中，这里需要批准销售订单
-	// 由于模型中可能没有定义销售订单表，暂时返回nil
-	return nil
+	return s.GetQuotationDetail(quote.ID)
 }
 
-func (s *salesService) CancelOrder(id string) error {
+func (s *salesService) DeleteQuotation(id string) error {
 	// 检查数据库连接
 	if s.db == nil {
 		return errors.New("database connection is nil")
 	}
 
-	// 实际应用中，这里需要取消销售订单
-	// 由于模型中可能没有定义销售订单表，暂时返回nil
-	return nil
-}
+	// 从数据库读取销售报价
+	var quote models.SalesQuote
+	result := s.db.First(&quote, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
 
-func (s *salesService) GenerateDeliveryFromOrder(id string) (map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+	// 已生成订单的报价不能删除
+	if quote.Status == "ordered" {
+		return errors.New("quotation has been converted to an order")
 	}
 
-	// 实际应用中，这里需要从销售订单生成销售发货单
-	// 由于模型中可能没有定义销售订单表和销售发货单表，暂时返回空map
-	return map[string]interface{}{}, nil
+	// 在同一事务中删除报价及明细
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("quote_id = ?", quote.ID).Delete(&models.SalesQuoteItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&quote).Error
+	})
 }
 
-// 销售发货管理方法
-func (s *salesService) GetDeliveryList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *salesService) ApproveQuotation(id string) error {
 	// 检查数据库连接
 	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+		return errors.New("database connection is nil")
 	}
 
-	// 实际应用中，这里需要从数据库读取销售发货单数据
-	// 由于模型中可能没有定义销售发货单表，暂时返回空列表
-	return []map[string]interface{}{}, nil
-}
+	// 从数据库读取销售报价
+	var quote models.SalesQuote
+	result := s.db.First(&quote, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
 
-func (s *salesService) GetDeliveryDetail(id string) (map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+	if quote.Status != "draft" {
+		return fmt.Errorf("quotation in status %s cannot be approved", quote.Status)
 	}
 
-	// 实际应用中，这里需要从数据库读取销售发货单详情
-	// 由于模型中可能没有定义销售发货单表，暂时返回空map
-	return map[string]interface{}{}, nil
+	// 更新状态为approved
+	quote.Status = "approved"
+	quote.UpdatedAt = time.Now()
+	quote.UpdatedBy = "system"
+
+	return s.db.Save(&quote).Error
 }
 
-func (s *salesService) CreateDelivery(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *salesService) GenerateOrderFromQuotation(id string) (*schemas.OrderResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 实际应用中，这里需要创建销售发货单
-	// 由于模型中可能没有定义销售发货单表，暂时返回空map
-	return map[string]interface{}{}, nil
-}
+	// 从数据库读取销售报价及明细
+	var quote models.SalesQuote
+	result := s.db.Preload("Items").First(&quote, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
 
-func (s *salesService) UpdateDelivery(id string, req map[string]interface{}) (map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+	// 只有已审批且仍在有效期内的报价可以生成订单
+	if quote.Status != "approved" {
+		return nil, fmt.Errorf("quotation in status %s cannot generate an order", quote.Status)
+	}
+	today := localDate(time.Now())
+	if quote.ValidUntil.Before(today) {
+		return nil, errors.New("quotation has expired")
 	}
 
-	// 实际应用中，这里需要更新销售发货单
-	// 由于模型中可能没有定义销售发货单表，暂时返回空map
-	return map[string]interface{}{}, nil
-}
+	// 复制报价明细生成销售订单，订单沿用报价币种并按订单日期取汇率
+	currency, rate, err := salesDocumentCurrency(s.db, quote.CustomerID, quote.Currency, 0, today)
+	if err != nil {
+		return nil, err
+	}
+	order := models.SalesOrder{
+		ID:              utils.GenerateID(),
+		OrderNo:         fmt.Sprintf("SO%s", time.Now().Format("20060102030405")),
+		CustomerID:      quote.CustomerID,
+		QuoteID:         quote.ID,
+		OrderDate:       today,
+		TotalAmount:     quote.TotalAmount,
+		Currency:        currency,
+		ExchangeRate:    rate,
+		BaseTotalAmount: toBaseAmount(quote.TotalAmount, rate),
+		Status:          "pending",
+		Remarks:         quote.Remarks,
+		CreatedBy:       "system",
+		CreatedAt:       time.Now(),
+		UpdatedBy:       "system",
+		UpdatedAt:       time.Now(),
+	}
+	order.Items = make([]models.SalesOrderItem, len(quote.Items))
+	for i, item := range quote.Items {
+		order.Items[i] = models.SalesOrderItem{
+			ID:        utils.GenerateID(),
+			OrderID:   order.ID,
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+			Discount:  item.Discount,
+			Amount:    item.Amount,
+			CreatedBy: "system",
+			CreatedAt: time.Now(),
+			UpdatedBy: "system",
+			UpdatedAt: time.Now(),
+		}
+	}
 
-func (s *salesService) DeleteDelivery(id string) error {
-	// 检查数据库连接
-	if s.db == nil {
-		return errors.New("database connection is nil")
+	// 在同一事务中创建订单并将报价标记为已转订单
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&order).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.SalesQuote{}).
+			Where("id = ?", quote.ID).
+			Updates(map[string]interface{}{
+				"status":     "ordered",
+				"updated_by": "system",
+				"updated_at": time.Now(),
+			}).Error
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// 实际应用中，这里需要删除销售发货单
-	// 由于模型中可能没有定义销售发货单表，暂时返回nil
-	return nil
+	return s.GetOrderDetail(order.ID)
 }
 
-// 销售发票管理方法
-func (s *salesService) GetInvoiceList(req map[string]interface{}) ([]map[string]interface{}, error) {
+// salesOrderListSpec 销售订单列表查询白名单
+var salesOrderListSpec = query.NewSpec("-order_date",
+	query.Text("order_no"),
+	query.Text("customer_id"),
+	query.Text("quote_id"),
+	query.Text("status"),
+	query.Number("total_amount"),
+	query.Date("order_date"),
+	query.Date("delivery_date"),
+	query.Date("created_at"),
+)
+
+// 销售订单管理方法
+func (s *salesService) GetOrderList(params query.Params) (*query.Page[schemas.OrderResponse], error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 实际应用中，这里需要从数据库读取销售发票数据
-	// 由于模型中可能没有定义销售发票表，暂时返回空列表
-	return []map[string]interface{}{}, nil
-}
+	// 从数据库读取销售订单数据
+	var orders []models.SalesOrder
+	total, err := query.Find(s.db, params, salesOrderListSpec, &orders, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Customer")
+	})
+	if err != nil {
+		return nil, err
+	}
 
-func (s *salesService) GetInvoiceDetail(id string) (map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+	// 将模型转换为响应格式
+	response := make([]schemas.OrderResponse, len(orders))
+	for i, order := range orders {
+		response[i] = salesOrderResponse(order)
 	}
 
-	// 实际应用中，这里需要从数据库读取销售发票详情
-	// 由于模型中可能没有定义销售发票表，暂时返回空map
-	return map[string]interface{}{}, nil
+	return query.NewPage(response, total, params), nil
 }
 
-func (s *salesService) CreateInvoice(req map[string]interface{}) (map[string]interface{}, error) {
+func (s *salesService) GetOrderDetail(id string) (*schemas.OrderResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 实际应用中，这里需要创建销售发票
-	// 由于模型中可能没有定义销售发票表，暂时返回空map
-	return map[string]interface{}{}, nil
+	// 从数据库读取销售订单及明细
+	var order models.SalesOrder
+	result := s.db.Preload("Customer").Preload("Items.Product").First(&order, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := salesOrderResponse(order)
+	return &response, nil
 }
 
-func (s *salesService) UpdateInvoice(id string, req map[string]interface{}) (map[string]interface{}, error) {
+func (s *salesService) CreateOrder(req schemas.CreateOrderRequest) (*schemas.OrderResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 实际应用中，这里需要更新销售发票
-	// 由于模型中可能没有定义销售发票表，暂时返回空map
-	return map[string]interface{}{}, nil
-}
-
-func (s *salesService) DeleteInvoice(id string) error {
-	// 检查数据库连接
-	if s.db == nil {
-		return errors.New("database connection is nil")
+	// 解析订单日期和交货日期
+	orderDate, err := time.Parse("2006-01-02", req.OrderDate)
+	if err != nil {
+		return nil, err
+	}
+	var deliveryDate *time.Time
+	if req.DeliveryDate != "" {
+		date, err := time.Parse("2006-01-02", req.DeliveryDate)
+		if err != nil {
+			return nil, err
+		}
+		deliveryDate = &date
 	}
 
-	// 实际应用中，这里需要删除销售发票
-	// 由于模型中可能没有定义销售发票表，暂时返回nil
-	return nil
-}
+	// 创建销售订单及明细
+	order := models.SalesOrder{
+		ID:           utils.GenerateID(),
+		OrderNo:      req.OrderNo,
+		CustomerID:   req.CustomerId,
+		OrderDate:    orderDate,
+		DeliveryDate: deliveryDate,
+		Status:       "pending",
+		Remarks:      req.Remarks,
+		CreatedBy:    req.CreatedBy,
+		CreatedAt:    time.Now(),
+		UpdatedBy:    req.CreatedBy,
+		UpdatedAt:    time.Now(),
+	}
+	order.Currency, order.ExchangeRate, err = salesDocumentCurrency(s.db, order.CustomerID, req.Currency, req.ExchangeRate, order.OrderDate)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err := newPricingContext(s.db, order.CustomerID, order.Currency, order.OrderDate)
+	if err != nil {
+		return nil, err
+	}
+	order.Items, order.TotalAmount, err = salesOrderItems(s.db, ctx, order.ID, req.Items, req.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+	order.BaseTotalAmount = toBaseAmount(order.TotalAmount, order.ExchangeRate)
 
-func (s *salesService) ReceiveInvoicePayment(id string, req map[string]interface{}) error {
-	// 检查数据库连接
-	if s.db == nil {
-		return errors.New("database connection is nil")
+	result := s.db.Create(&order)
+	if result.Error != nil {
+		return nil, result.Error
 	}
 
-	// 实际应用中，这里需要处理销售发票收款
-	// 由于模型中可能没有定义销售发票表，暂时返回nil
-	return nil
+	return s.GetOrderDetail(order.ID)
 }
 
-// 销售退货管理方法
-func (s *salesService) GetReturnList(req map[string]interface{}) ([]map[string]interface{}, error) {
+func (s *salesService) UpdateOrder(id string, req schemas.UpdateOrderRequest) (*schemas.OrderResponse, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
 	}
 
-	// 实际应用中，这里需要从数据库读取销售退货单数据
-	// 由于模型中可能没有定义销售退货单表，暂时返回空列表
-	return []map[string]interface{}{}, nil
-}
+	// 从数据库读取销售订单
+	var order models.SalesOrder
+	result := s.db.First(&order, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
 
-func (s *salesService) GetReturnDetail(id string) (map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+	if order.Status == "cancelled" || order.Status == "completed" {
+		return nil, fmt.Errorf("sales order in status %s cannot be updated", order.Status)
 	}
 
-	// 实际应用中，这里需要从数据库读取销售退货单详情
-	// 由于模型中可能没有定义销售退货单表，暂时返回空map
-	return map[string]interface{}{}, nil
-}
+	// 更新字段
+	if req.CustomerId != "" {
+		order.CustomerID = req.CustomerId
+	}
+	if req.OrderDate != "" {
+		orderDate, err := time.Parse("2006-01-02", req.OrderDate)
+		if err != nil {
+			return nil, err
+		}
+		order.OrderDate = orderDate
+	}
+	if req.DeliveryDate != "" {
+		deliveryDate, err := time.Parse("2006-01-02", req.DeliveryDate)
+		if err != nil {
+			return nil, err
+		}
+		order.DeliveryDate = &deliveryDate
+	}
+	if req.Remarks != "" {
+		order.Remarks = req.Remarks
+	}
+	order.UpdatedAt = time.Now()
+	order.UpdatedBy = req.UpdatedBy
 
-func (s *salesService) CreateReturn(req map[string]interface{}) (map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+	// 审批后的订单只能修改订单头，客户、币种和明细需在审批前确定
+	if order.Status != "pending" && order.Status != "credit_hold" && (len(req.Items) > 0 || req.CustomerId != "" || req.Currency != "" || req.ExchangeRate > 0) {
+		return nil, fmt.Errorf("sales order in status %s cannot change customer, currency or items", order.Status)
 	}
 
-	// 实际应用中，这里需要创建销售退货单
-	// 由于模型中可能没有定义销售退货单表，暂时返回空map
-	return map[string]interface{}{}, nil
-}
+	// 币种变化或审批前修改订单日期时重新取汇率
+	if req.Currency != "" || req.ExchangeRate > 0 || (req.OrderDate != "" && (order.Status == "pending" || order.Status == "credit_hold")) {
+		currency := req.Currency
+		if currency == "" {
+			currency = order.Currency
+		}
+		var err error
+		order.Currency, order.ExchangeRate, err = salesDocumentCurrency(s.db, order.CustomerID, currency, req.ExchangeRate, order.OrderDate)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-func (s *salesService) UpdateReturn(id string, req map[string]interface{}) (map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+	// 明细、客户或币种变化时重新定价，未传明细时按原明细数量计算
+	items := req.Items
+	if len(items) == 0 && (req.CustomerId != "" || req.Currency != "") {
+		var current []models.SalesOrderItem
+		if err := s.db.Where("order_id = ?", order.ID).Find(&current).Error; err != nil {
+			return nil, err
+		}
+		for _, item := range current {
+			items = append(items, schemas.OrderItem{ProductId: item.ProductID, Quantity: item.Quantity, WarehouseId: item.WarehouseID})
+		}
+	}
+	if len(items) > 0 {
+		ctx, err := newPricingContext(s.db, order.CustomerID, order.Currency, order.OrderDate)
+		if err != nil {
+			return nil, err
+		}
+		order.Items, order.TotalAmount, err = salesOrderItems(s.db, ctx, order.ID, items, req.UpdatedBy)
+		if err != nil {
+			return nil, err
+		}
+	}
+	order.BaseTotalAmount = toBaseAmount(order.TotalAmount, order.ExchangeRate)
+
+	// 在同一事务中替换明细并保存订单
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if len(order.Items) > 0 {
+			if err := tx.Where("order_id = ?", order.ID).Delete(&models.SalesOrderItem{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Create(&order.Items).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Omit("Items").Save(&order).Error
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// 实际应用中，这里需要更新销售退货单
-	// 由于模型中可能没有定义销售退货单表，暂时返回空map
-	return map[string]interface{}{}, nil
+	return s.GetOrderDetail(order.ID)
 }
 
-func (s *salesService) DeleteReturn(id string) error {
+func (s *salesService) DeleteOrder(id string) error {
 	// 检查数据库连接
 	if s.db == nil {
 		return errors.New("database connection is nil")
 	}
 
-	// 实际应用中，这里需要删除销售退货单
-	// 由于模型中可能没有定义销售退货单表，暂时返回nil
-	return nil
-}
+	// 从数据库读取销售订单
+	var order models.SalesOrder
+	result := s.db.First(&order, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
 
-// 销售报表管理方法
-func (s *salesService) GetOrderExecutionReport(req map[string]interface{}) (map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+	// 审批后的订单可能已有下游单据，只能取消
+	if order.Status != "pending" && order.Status != "credit_hold" {
+		return fmt.Errorf("sales order in status %s cannot be deleted", order.Status)
 	}
 
-	// 实际应用中，这里需要基于数据库数据生成订单执行报表
-	// 暂时返回空map
-	return map[string]interface{}{}, nil
+	// 在同一事务中删除订单及明细，来源报价恢复为已审批
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("order_id = ?", order.ID).Delete(&models.SalesOrderItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&order).Error; err != nil {
+			return err
+		}
+		if order.QuoteID == "" {
+			return nil
+		}
+		return tx.Model(&models.SalesQuote{}).
+			Where("id = ? AND status = ?", order.QuoteID, "ordered").
+			Updates(map[string]interface{}{
+				"status":     "approved",
+				"updated_by": "system",
+				"updated_at": time.Now(),
+			}).Error
+	})
 }
 
-func (s *salesService) GetCustomerAnalysisReport(req map[string]interface{}) (map[string]interface{}, error) {
+// ApproveOrder 审批销售订单并预留库存，operator为审批人，取自当前登录用户
+func (s *salesService) ApproveOrder(id, operator string) error {
 	// 检查数据库连接
 	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+		return errors.New("database connection is nil")
 	}
 
-	// 实际应用中，这里需要基于数据库数据生成客户分析报表
-	// 暂时返回空map
-	return map[string]interface{}{}, nil
-}
+	// 从数据库读取销售订单及明细
+	var order models.SalesOrder
+	result := s.db.Preload("Items.Product").First(&order, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
 
-func (s *salesService) GetProductTrendReport(req map[string]interface{}) (map[string]interface{}, error) {
-	// 检查数据库连接
-	if s.db == nil {
-		return nil, errors.New("database connection is nil")
+	// 信用冻结的订单可在客户还款后重新审批
+	if order.Status != "pending" && order.Status != "credit_hold" {
+		return fmt.Errorf("sales order in status %s cannot be approved", order.Status)
 	}
 
-	// 实际应用中，这里需要基于数据库数据生成产品趋势报表
-	// 暂时返回空map
-	return map[string]interface{}{}, nil
+	// 在同一事务中锁定客户后校验信用，同一客户的订单审批依次计算信用占用，防止并发审批合计超出额度；
+	// 信用占用或逾期超限时订单转为信用冻结，需授权人员放行，否则更新状态为approved并预留库存。
+	// 均按读取时的状态条件更新，防止并发重复审批
+	var holdErr error
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var customer models.SalesCustomer
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&customer, "id = ?", order.CustomerID).Error; err != nil {
+			return err
+		}
+
+		status := map[string]interface{}{
+			"status":             "approved",
+			"credit_hold_reason": "",
+			"updated_by":         operator,
+			"updated_at":         time.Now(),
+		}
+		if err := checkCustomerCredit(tx, customer, toBaseAmount(order.TotalAmount, order.ExchangeRate)); err != nil {
+			if !errors.Is(err, ErrCreditHold) {
+				return err
+			}
+			holdErr = err
+			status["status"], status["credit_hold_reason"] = "credit_hold", err.Error()
+		}
+
+		result := tx.Model(&models.SalesOrder{}).
+			Where("id = ? AND status = ?", order.ID, order.Status).
+			Updates(status)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("sales order has already been processed")
+		}
+		if holdErr != nil {
+			return nil
+		}
+		return reserveSalesOrder(tx, order, operator)
+	})
+	if err != nil {
+		return err
+	}
+	return holdErr
 }
 
-func (s *salesService) ExportSalesReport(req map[string]interface{}) ([]byte, error) {
+// ReleaseCreditHold 放行信用冻结订单，operator为放行人，取自当前登录用户
+func (s *salesService) ReleaseCreditHold(id, operator string, req schemas.ReleaseCreditHoldRequest) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 从数据库读取销售订单及明细
+	var order models.SalesOrder
+	result := s.db.Preload("Items.Product").First(&order, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if order.Status != "credit_hold" {
+		return fmt.Errorf("sales order in status %s is not on credit hold", order.Status)
+	}
+
+	// 放行后订单直接审批并预留库存，保留冻结原因并记录放行人和原因
+	now := time.Now()
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.SalesOrder{}).
+			Where("id = ? AND status = ?", order.ID, "credit_hold").
+			Updates(map[string]interface{}{
+				"status":                "approved",
+				"credit_released_by":    operator,
+				"credit_released_at":    &now,
+				"credit_release_reason": req.Reason,
+				"updated_by":            operator,
+				"updated_at":            now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("sales order has already been processed")
+		}
+		return reserveSalesOrder(tx, order, operator)
+	})
+}
+
+func (s *salesService) CancelOrder(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 从数据库读取销售订单
+	var order models.SalesOrder
+	result := s.db.First(&order, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// 已发货的订单不能取消
+	if order.Status != "pending" && order.Status != "credit_hold" && order.Status != "approved" {
+		return fmt.Errorf("sales order in status %s cannot be cancelled", order.Status)
+	}
+
+	// 存在未发出的发货单时需先删除发货单
+	var pending int64
+	result = s.db.Model(&models.SalesDelivery{}).Where("order_id = ? AND status = ?", order.ID, "pending").Count(&pending)
+	if result.Error != nil {
+		return result.Error
+	}
+	if pending > 0 {
+		return errors.New("sales order has pending deliveries")
+	}
+
+	// 更新状态为cancelled
+	order.Status = "cancelled"
+	order.UpdatedAt = time.Now()
+	order.UpdatedBy = "system"
+
+	// 在同一事务中取消订单并释放库存预留
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Items").Save(&order).Error; err != nil {
+			return err
+		}
+		return releaseSalesOrderReservations(tx, order.ID, "system")
+	})
+}
+
+// ReserveOrder 重新预留订单库存，operator为操作人，取自当前登录用户
+func (s *salesService) ReserveOrder(id, operator string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 从数据库读取销售订单及明细
+	var order models.SalesOrder
+	result := s.db.Preload("Items.Product").First(&order, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// 到货后重新预留可将软预留转为硬预留
+	if order.Status != "approved" && order.Status != "partially_shipped" {
+		return fmt.Errorf("sales order in status %s cannot be reserved", order.Status)
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return reserveSalesOrder(tx, order, operator)
+	})
+}
+
+func (s *salesService) GenerateDeliveryFromOrder(id string) (*schemas.DeliveryResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取销售订单及明细
+	var order models.SalesOrder
+	result := s.db.Preload("Items.Product").First(&order, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	openQuantities, err := salesOpenQuantities(s.db, order, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// 按未发数量生成发货明细，发货仓库优先取硬预留的仓库，否则取现存量最多的仓库
+	delivery := models.SalesDelivery{
+		ID:           utils.GenerateID(),
+		DeliveryNo:   fmt.Sprintf("SD%s", time.Now().Format("20060102030405")),
+		OrderID:      order.ID,
+		CustomerID:   order.CustomerID,
+		DeliveryDate: time.Now(),
+		Status:       "pending",
+		Remarks:      order.OrderNo,
+		CreatedBy:    "system",
+		CreatedAt:    time.Now(),
+		UpdatedBy:    "system",
+		UpdatedAt:    time.Now(),
+	}
+	for _, item := range order.Items {
+		quantity := openQuantities[item.ID]
+		if quantity <= 0 {
+			continue
+		}
+
+		var onHand models.InventoryOnHand
+		if item.Product.ItemID != "" {
+			var reservation models.InventoryReservation
+			result := s.db.Where("source_type = ? AND source_item_id = ? AND type = ? AND status = ?", reservationSourceSalesOrder, item.ID, reservationTypeHard, reservationStatusActive).
+				Order("quantity - consumed_quantity DESC").
+				Limit(1).
+				Find(&reservation)
+			if result.Error != nil {
+				return nil, result.Error
+			}
+			stock := s.db.Where("item_id = ? AND quantity > 0", item.Product.ItemID)
+			if reservation.WarehouseID != "" {
+				stock = stock.Where("warehouse_id = ?", reservation.WarehouseID)
+			}
+			result = stock.Order("quantity DESC").
+				Limit(1).
+				Find(&onHand)
+			if result.Error != nil {
+				return nil, result.Error
+			}
+		}
+
+		delivery.Items = append(delivery.Items, models.SalesDeliveryItem{
+			ID:          utils.GenerateID(),
+			DeliveryID:  delivery.ID,
+			OrderItemID: item.ID,
+			ProductID:   item.ProductID,
+			Quantity:    quantity,
+			WarehouseID: onHand.WarehouseID,
+			LocationID:  onHand.LocationID,
+			CreatedBy:   "system",
+			CreatedAt:   time.Now(),
+			UpdatedBy:   "system",
+			UpdatedAt:   time.Now(),
+		})
+		delivery.TotalQuantity += quantity
+	}
+	if len(delivery.Items) == 0 {
+		return nil, errors.New("sales order has no open quantity to deliver")
+	}
+
+	result = s.db.Create(&delivery)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return s.GetDeliveryDetail(delivery.ID)
+}
+
+// deliveryListSpec 销售发货单列表查询白名单
+var deliveryListSpec = query.NewSpec("-delivery_date",
+	query.Text("delivery_no"),
+	query.Text("order_id"),
+	query.Text("customer_id"),
+	query.Text("status"),
+	query.Number("total_quantity"),
+	query.Date("delivery_date"),
+	query.Date("created_at"),
+)
+
+// 销售发货管理方法
+func (s *salesService) GetDeliveryList(params query.Params) (*query.Page[schemas.DeliveryResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取销售发货单数据
+	var deliveries []models.SalesDelivery
+	total, err := query.Find(s.db, params, deliveryListSpec, &deliveries, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Customer")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	response := make([]schemas.DeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		response[i] = salesDeliveryResponse(delivery)
+	}
+
+	return query.NewPage(response, total, params), nil
+}
+
+func (s *salesService) GetDeliveryDetail(id string) (*schemas.DeliveryResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取销售发货单及明细
+	var delivery models.SalesDelivery
+	result := s.db.Preload("Customer").Preload("Items.Product").First(&delivery, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := salesDeliveryResponse(delivery)
+	return &response, nil
+}
+
+func (s *salesService) CreateDelivery(req schemas.CreateDeliveryRequest) (*schemas.DeliveryResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 解析发货日期
+	deliveryDate, err := time.Parse("2006-01-02", req.DeliveryDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 从数据库读取销售订单及明细
+	var order models.SalesOrder
+	result := s.db.Preload("Items").First(&order, "id = ?", req.OrderId)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 创建销售发货单，明细数量不能超过订单未发数量
+	delivery := models.SalesDelivery{
+		ID:           utils.GenerateID(),
+		DeliveryNo:   req.DeliveryNo,
+		OrderID:      order.ID,
+		CustomerID:   order.CustomerID,
+		DeliveryDate: deliveryDate,
+		Status:       "pending",
+		Remarks:      req.Remarks,
+		CreatedBy:    req.CreatedBy,
+		CreatedAt:    time.Now(),
+		UpdatedBy:    req.CreatedBy,
+		UpdatedAt:    time.Now(),
+	}
+	delivery.Items, delivery.TotalQuantity, err = salesDeliveryItems(s.db, order, delivery.ID, req.Items, req.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	result = s.db.Create(&delivery)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return s.GetDeliveryDetail(delivery.ID)
+}
+
+func (s *salesService) UpdateDelivery(id string, req schemas.UpdateDeliveryRequest) (*schemas.DeliveryResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取销售发货单
+	var delivery models.SalesDelivery
+	result := s.db.First(&delivery, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 已发出的发货单不能修改
+	if delivery.Status != "pending" {
+		return nil, fmt.Errorf("delivery in status %s cannot be updated", delivery.Status)
+	}
+
+	// 更新字段
+	if req.DeliveryDate != "" {
+		deliveryDate, err := time.Parse("2006-01-02", req.DeliveryDate)
+		if err != nil {
+			return nil, err
+		}
+		delivery.DeliveryDate = deliveryDate
+	}
+	if req.Remarks != "" {
+		delivery.Remarks = req.Remarks
+	}
+	delivery.UpdatedAt = time.Now()
+	delivery.UpdatedBy = req.UpdatedBy
+
+	// 替换明细时按订单未发数量重新校验，不计本发货单原有数量
+	if len(req.Items) > 0 {
+		var order models.SalesOrder
+		result := s.db.Preload("Items").First(&order, "id = ?", delivery.OrderID)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		items, totalQuantity, err := salesDeliveryItems(s.db, order, delivery.ID, req.Items, req.UpdatedBy)
+		if err != nil {
+			return nil, err
+		}
+		delivery.Items, delivery.TotalQuantity = items, totalQuantity
+	}
+
+	// 在同一事务中替换明细并保存发货单
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if len(delivery.Items) > 0 {
+			if err := tx.Where("delivery_id = ?", delivery.ID).Delete(&models.SalesDeliveryItem{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Create(&delivery.Items).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Omit("Items").Save(&delivery).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetDeliveryDetail(delivery.ID)
+}
+
+func (s *salesService) DeleteDelivery(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 从数据库读取销售发货单
+	var delivery models.SalesDelivery
+	result := s.db.First(&delivery, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// 已发出的发货单已过账出库，不能删除
+	if delivery.Status != "pending" {
+		return fmt.Errorf("delivery in status %s cannot be deleted", delivery.Status)
+	}
+
+	// 在同一事务中删除发货单及明细
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("delivery_id = ?", delivery.ID).Delete(&models.SalesDeliveryItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&delivery).Error
+	})
+}
+
+func (s *salesService) ShipDelivery(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 从数据库读取销售发货单及明细
+	var delivery models.SalesDelivery
+	result := s.db.Preload("Items.Product").First(&delivery, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if delivery.Status != "pending" {
+		return fmt.Errorf("delivery in status %s cannot be shipped", delivery.Status)
+	}
+
+	// 构建出库变动，产品需关联库存物料并指定发货仓库
+	movements := make([]inventoryMovement, len(delivery.Items))
+	for i, item := range delivery.Items {
+		if item.Product.ItemID == "" {
+			return fmt.Errorf("product %s is not linked to an inventory item", item.ProductID)
+		}
+		if item.WarehouseID == "" {
+			return fmt.Errorf("delivery item %s has no warehouse", item.ID)
+		}
+		movements[i] = inventoryMovement{
+			ItemID:          item.Product.ItemID,
+			WarehouseID:     item.WarehouseID,
+			LocationID:      item.LocationID,
+			Type:            "sales_out",
+			Quantity:        -item.Quantity,
+			ReferenceType:   "sales_delivery",
+			ReferenceID:     delivery.ID,
+			TransactionDate: delivery.DeliveryDate,
+			Remarks:         delivery.DeliveryNo,
+			CreatedBy:       "system",
+		}
+	}
+
+	// 在同一事务中先按待发货状态条件更新发货单状态，防止并发重复发货，再过账出库、回写订单已发数量、
+	// 核销库存预留并刷新订单状态
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.SalesDelivery{}).
+			Where("id = ? AND status = ?", delivery.ID, "pending").
+			Updates(map[string]interface{}{
+				"status":     "shipped",
+				"updated_by": "system",
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("delivery has already been shipped")
+		}
+
+		transactionNo := fmt.Sprintf("SDL%s", time.Now().Format("20060102030405"))
+		if _, err := newInventoryPoster(tx).Post(transactionNo, movements); err != nil {
+			return err
+		}
+
+		for _, item := range delivery.Items {
+			result := tx.Model(&models.SalesOrderItem{}).
+				Where("id = ?", item.OrderItemID).
+				Updates(map[string]interface{}{
+					"shipped_quantity": gorm.Expr("shipped_quantity + ?", item.Quantity),
+					"updated_at":       time.Now(),
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+		}
+		if err := consumeSalesReservations(tx, delivery); err != nil {
+			return err
+		}
+
+		return refreshSalesOrderStatus(tx, delivery.OrderID)
+	})
+}
+
+// salesInvoiceListSpec 销售发票列表查询白名单
+var salesInvoiceListSpec = query.NewSpec("-invoice_date",
+	query.Text("invoice_no"),
+	query.Text("order_id"),
+	query.Text("customer_id"),
+	query.Text("status"),
+	query.Number("total_amount"),
+	query.Number("paid_amount"),
+	query.Date("invoice_date"),
+	query.Date("due_date"),
+	query.Date("created_at"),
+)
+
+// 销售发票管理方法
+func (s *salesService) GetInvoiceList(params query.Params) (*query.Page[schemas.InvoiceResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取销售发票数据
+	var invoices []models.SalesInvoice
+	total, err := query.Find(s.db, params, salesInvoiceListSpec, &invoices, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Customer")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	response := make([]schemas.InvoiceResponse, len(invoices))
+	for i, invoice := range invoices {
+		response[i] = salesInvoiceResponse(invoice)
+	}
+
+	return query.NewPage(response, total, params), nil
+}
+
+func (s *salesService) GetInvoiceDetail(id string) (*schemas.InvoiceResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取销售发票及明细
+	var invoice models.SalesInvoice
+	result := s.db.Preload("Customer").Preload("Items.Product").First(&invoice, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := salesInvoiceResponse(invoice)
+	return &response, nil
+}
+
+func (s *salesService) GenerateInvoiceFromOrder(id string) (*schemas.InvoiceResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取销售订单、客户及明细
+	var order models.SalesOrder
+	result := s.db.Preload("Customer").Preload("Items").First(&order, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	invoiceable, err := salesInvoiceableQuantities(s.db, order, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// 按已发未开票数量生成发票明细，折扣按数量比例分摊，到期日按客户信用天数计算，
+	// 发票沿用订单币种并按开票日期取汇率
+	today := localDate(time.Now())
+	currency, rate, err := documentCurrency(s.db, order.Currency, "", 0, today)
+	if err != nil {
+		return nil, err
+	}
+	invoice := models.SalesInvoice{
+		ID:               utils.GenerateID(),
+		InvoiceNo:        fmt.Sprintf("SI%s", time.Now().Format("20060102030405")),
+		OrderID:          order.ID,
+		CustomerID:       order.CustomerID,
+		InvoiceDate:      today,
+		DueDate:          today.AddDate(0, 0, order.Customer.CreditDays),
+		Status:           "unpaid",
+		PriceIncludesTax: pricesIncludeTax(nil),
+		Currency:         currency,
+		ExchangeRate:     rate,
+		Remarks:          order.OrderNo,
+		CreatedBy:        "system",
+		CreatedAt:        time.Now(),
+		UpdatedBy:        "system",
+		UpdatedAt:        time.Now(),
+	}
+	for _, item := range order.Items {
+		quantity := invoiceable[item.ID]
+		if quantity <= 0 {
+			continue
+		}
+
+		discount := roundAmount(item.Discount * quantity / item.Quantity)
+		amount := roundAmount(quantity*item.UnitPrice - discount)
+		invoice.Items = append(invoice.Items, models.SalesInvoiceItem{
+			ID:        utils.GenerateID(),
+			InvoiceID: invoice.ID,
+			ProductID: item.ProductID,
+			Quantity:  quantity,
+			UnitPrice: item.UnitPrice,
+			Discount:  discount,
+			Amount:    amount,
+			CreatedBy: "system",
+			CreatedAt: time.Now(),
+			UpdatedBy: "system",
+			UpdatedAt: time.Now(),
+		})
+	}
+	if len(invoice.Items) == 0 {
+		return nil, errors.New("sales order has no delivered quantity to invoice")
+	}
+
+	if err := s.issueInvoice(invoice); err != nil {
+		return nil, err
+	}
+
+	return s.GetInvoiceDetail(invoice.ID)
+}
+
+func (s *salesService) CreateInvoice(req schemas.CreateInvoiceRequest) (*schemas.InvoiceResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 解析发票日期和到期日
+	invoiceDate, err := time.Parse("2006-01-02", req.InvoiceDate)
+	if err != nil {
+		return nil, err
+	}
+	dueDate, err := time.Parse("2006-01-02", req.DueDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 从数据库读取销售订单及明细
+	var order models.SalesOrder
+	result := s.db.Preload("Items").First(&order, "id = ?", req.OrderId)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 创建销售发票，开票数量不能超过已发未开票数量，价格未指定是否含税时按配置，
+	// 发票沿用订单币种，未指定汇率时按发票日期取汇率
+	currency, rate, err := documentCurrency(s.db, order.Currency, "", req.ExchangeRate, invoiceDate)
+	if err != nil {
+		return nil, err
+	}
+	invoice := models.SalesInvoice{
+		ID:               utils.GenerateID(),
+		InvoiceNo:        req.InvoiceNo,
+		OrderID:          order.ID,
+		CustomerID:       order.CustomerID,
+		InvoiceDate:      invoiceDate,
+		DueDate:          dueDate,
+		Status:           "unpaid",
+		PriceIncludesTax: pricesIncludeTax(req.PriceIncludesTax),
+		Currency:         currency,
+		ExchangeRate:     rate,
+		Remarks:          req.Remarks,
+		CreatedBy:        req.CreatedBy,
+		CreatedAt:        time.Now(),
+		UpdatedBy:        req.CreatedBy,
+		UpdatedAt:        time.Now(),
+	}
+	invoice.Items, invoice.TotalAmount, err = salesInvoiceItems(s.db, order, invoice.ID, req.Items, req.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.issueInvoice(invoice); err != nil {
+		return nil, err
+	}
+
+	return s.GetInvoiceDetail(invoice.ID)
+}
+
+func (s *salesService) UpdateInvoice(id string, req schemas.UpdateInvoiceRequest) (*schemas.InvoiceResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取销售发票
+	var invoice models.SalesInvoice
+	result := s.db.First(&invoice, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 更新字段
+	if req.DueDate != "" {
+		dueDate, err := time.Parse("2006-01-02", req.DueDate)
+		if err != nil {
+			return nil, err
+		}
+		invoice.DueDate = dueDate
+	}
+	if req.Remarks != "" {
+		invoice.Remarks = req.Remarks
+	}
+	invoice.UpdatedAt = time.Now()
+	invoice.UpdatedBy = req.UpdatedBy
+
+	// 未传明细时只更新发票头
+	if len(req.Items) == 0 {
+		if err := s.db.Omit("Items").Save(&invoice).Error; err != nil {
+			return nil, err
+		}
+		return s.GetInvoiceDetail(invoice.ID)
+	}
+
+	// 已收款、核销或冲减贷项的发票不允许替换明细
+	if invoice.PaidAmount > 0 || invoice.WriteOffAmount > 0 || invoice.CreditedAmount > 0 {
+		return nil, errors.New("sales invoice has received payments or credit notes")
+	}
+
+	// 发票日期所在会计期间必须允许记账
+	if err := checkPeriodOpen(s.db, invoice.InvoiceDate, false); err != nil {
+		return nil, err
+	}
+
+	var order models.SalesOrder
+	result = s.db.Preload("Items").First(&order, "id = ?", invoice.OrderID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	items, totalAmount, err := salesInvoiceItems(s.db, order, invoice.ID, req.Items, req.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+	invoice.Items, invoice.TotalAmount = items, totalAmount
+	if err := applySalesInvoiceTax(s.db, &invoice); err != nil {
+		return nil, err
+	}
+
+	// 在同一事务中冲销原凭证、替换明细并按新金额重新生成凭证
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := reverseDocumentJournals(tx, postingDocumentSalesInvoice, invoice.ID, time.Now(), req.UpdatedBy); err != nil {
+			return err
+		}
+		if err := tx.Where("invoice_id = ?", invoice.ID).Delete(&models.SalesInvoiceItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&invoice.Items).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit("Items").Save(&invoice).Error; err != nil {
+			return err
+		}
+		if _, err := postDocument(tx, salesInvoicePostingDocument(invoice)); err != nil {
+			return err
+		}
+		return refreshSalesOrderStatus(tx, invoice.OrderID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetInvoiceDetail(invoice.ID)
+}
+
+func (s *salesService) DeleteInvoice(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 从数据库读取销售发票
+	var invoice models.SalesInvoice
+	result := s.db.First(&invoice, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// 已收款、核销或冲减贷项的发票不允许删除
+	if invoice.PaidAmount > 0 || invoice.WriteOffAmount > 0 || invoice.CreditedAmount > 0 {
+		return errors.New("sales invoice has received payments or credit notes")
+	}
+
+	// 发票日期所在会计期间必须允许记账
+	if err := checkPeriodOpen(s.db, invoice.InvoiceDate, false); err != nil {
+		return err
+	}
+
+	// 在同一事务中冲销开票生成的凭证、删除发票及明细并刷新订单状态
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := reverseDocumentJournals(tx, postingDocumentSalesInvoice, invoice.ID, time.Now(), "system"); err != nil {
+			return err
+		}
+		if err := tx.Where("invoice_id = ?", invoice.ID).Delete(&models.SalesInvoiceItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&invoice).Error; err != nil {
+			return err
+		}
+		return refreshSalesOrderStatus(tx, invoice.OrderID)
+	})
+}
+
+// ReceiveInvoicePayment 按发票登记客户收款并立即处理，超过发票余额的部分作为客户预收款
+func (s *salesService) ReceiveInvoicePayment(id string, req schemas.ReceiveInvoicePaymentRequest) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 解析收款日期
+	date, err := time.Parse("2006-01-02", req.ReceiveDate)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		// 从数据库读取销售发票
+		var invoice models.SalesInvoice
+		if err := tx.First(&invoice, "id = ?", id).Error; err != nil {
+			return err
+		}
+		balance := salesInvoiceBalance(invoice)
+		if balance <= 0 {
+			return fmt.Errorf("sales invoice %s is already settled", invoice.InvoiceNo)
+		}
+
+		// 收款币种与发票一致，汇率取收款日期的汇率
+		currency, rate, err := documentCurrency(tx, invoice.Currency, "", 0, date)
+		if err != nil {
+			return err
+		}
+		receipt := models.FinanceReceipt{
+			ID:            utils.GenerateID(),
+			ReceiptNo:     autoJournalNo("RC"),
+			ReceiptDate:   date,
+			CustomerID:    invoice.CustomerID,
+			Amount:        roundAmount(req.Amount),
+			Currency:      currency,
+			ExchangeRate:  rate,
+			BaseAmount:    toBaseAmount(req.Amount, rate),
+			PaymentMethod: req.PaymentMethod,
+			Description:   fmt.Sprintf("发票%s收款", invoice.InvoiceNo),
+			Remarks:       req.Remarks,
+			Status:        receiptStatusApproved,
+			CreatedBy:     req.CreatedBy,
+			UpdatedBy:     req.CreatedBy,
+		}
+		allocations := []models.FinanceReceiptAllocation{{
+			ID:             utils.GenerateID(),
+			ReceiptID:      receipt.ID,
+			InvoiceID:      invoice.ID,
+			AllocationDate: date,
+			Amount:         math.Min(receipt.Amount, balance),
+			CreatedBy:      req.CreatedBy,
+			UpdatedBy:      req.CreatedBy,
+		}}
+
+		if err := processReceipt(tx, &receipt, allocations, req.CreatedBy); err != nil {
+			return err
+		}
+		if err := tx.Create(&receipt).Error; err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Create(&allocations).Error
+	})
+}
+
+// 销售报表管理方法
+func (s *salesService) GetOrderExecutionReport(req schemas.SalesReportRequest) (*schemas.OrderExecutionReportResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 实际应用中，这里需要基于数据库数据生成订单执行报表
+	// 暂时返回空报表
+	return &schemas.OrderExecutionReportResponse{
+		Period: req.StartDate + " ~ " + req.EndDate,
+		Orders: []schemas.OrderExecutionReportItem{},
+	}, nil
+}
+
+func (s *salesService) GetCustomerAnalysisReport(req schemas.SalesReportRequest) (*schemas.CustomerAnalysisReportResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 实际应用中，这里需要基于数据库数据生成客户分析报表
+	// 暂时返回空报表
+	return &schemas.CustomerAnalysisReportResponse{
+		Period:    req.StartDate + " ~ " + req.EndDate,
+		Customers: []schemas.CustomerAnalysisReportItem{},
+	}, nil
+}
+
+func (s *salesService) GetProductTrendReport(req schemas.SalesReportRequest) (*schemas.ProductTrendReportResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 实际应用中，这里需要基于数据库数据生成产品趋势报表
+	// 暂时返回空报表
+	return &schemas.ProductTrendReportResponse{
+		Period: req.StartDate + " ~ " + req.EndDate,
+		Trends: []schemas.ProductTrendReportItem{},
+	}, nil
+}
+
+func (s *salesService) ExportSalesReport(req schemas.ExportSalesReportRequest) ([]byte, error) {
 	// 检查数据库连接
 	if s.db == nil {
 		return nil, errors.New("database connection is nil")
@@ -717,3 +1968,508 @@ func (s *salesService) ExportSalesReport(req map[string]interface{}) ([]byte, er
 	// 暂时返回空字节数组
 	return []byte{}, nil
 }
+
+// salesCustomerResponse 将客户模型转换为响应格式
+func salesCustomerResponse(customer models.SalesCustomer) schemas.CustomerResponse {
+	return schemas.CustomerResponse{
+		ID:            customer.ID,
+		CustomerNo:    customer.CustomerNo,
+		Name:          customer.Name,
+		Contact:       customer.ContactPerson,
+		Phone:         customer.Phone,
+		Email:         customer.Email,
+		Address:       customer.Address,
+		TaxNo:         customer.TaxNo,
+		BankName:      customer.BankName,
+		BankAccount:   customer.BankAccount,
+		Category:      customer.Category,
+		Region:        customer.Region,
+		CreditLimit:   customer.CreditLimit,
+		CreditBalance: customer.CreditBalance,
+		CreditDays:    customer.CreditDays,
+		TaxStatus:     customer.TaxStatus,
+		Currency:      customer.Currency,
+		PaymentTerms:  customer.PaymentTerms,
+		Remarks:       customer.Remarks,
+		Status:        customer.Status,
+		CreatedBy:     customer.CreatedBy,
+		CreatedAt:     customer.CreatedAt,
+		UpdatedBy:     customer.UpdatedBy,
+		UpdatedAt:     customer.UpdatedAt,
+	}
+}
+
+// customerCreditResponse 将客户信用评估结果转换为响应格式
+func customerCreditResponse(customer models.SalesCustomer, evaluation models.SalesCustomerCredit, exposure customerCreditExposure) schemas.CustomerCreditResponse {
+	return schemas.CustomerCreditResponse{
+		CustomerId:          customer.ID,
+		CustomerName:        customer.Name,
+		CreditLimit:         customer.CreditLimit,
+		CreditBalance:       exposure.Total(),
+		AvailableCredit:     roundAmount(customer.CreditLimit - exposure.Total()),
+		CreditDays:          customer.CreditDays,
+		OpenOrderAmount:     exposure.OpenOrderAmount,
+		UnpaidInvoiceAmount: exposure.UnpaidInvoiceAmount,
+		OverdueAmount:       exposure.OverdueAmount,
+		OverdueDays:         exposure.OverdueDays,
+		CreditScore:         evaluation.CreditScore,
+		RiskLevel:           evaluation.RiskLevel,
+		EvaluationDate:      evaluation.CreatedAt,
+	}
+}
+
+// salesQuotationResponse 将销售报价模型转换为响应格式，明细已预加载时一并转换
+func salesQuotationResponse(quote models.SalesQuote) schemas.QuotationResponse {
+	response := schemas.QuotationResponse{
+		ID:              quote.ID,
+		QuotationNo:     quote.QuoteNo,
+		CustomerId:      quote.CustomerID,
+		CustomerName:    quote.Customer.Name,
+		QuotationDate:   quote.QuoteDate.Format("2006-01-02"),
+		ExpiryDate:      quote.ValidUntil.Format("2006-01-02"),
+		Remarks:         quote.Remarks,
+		TotalAmount:     quote.TotalAmount,
+		Currency:        quote.Currency,
+		ExchangeRate:    quote.ExchangeRate,
+		BaseTotalAmount: quote.BaseTotalAmount,
+		Status:          quote.Status,
+		Items:           make([]schemas.QuotationItem, len(quote.Items)),
+		CreatedBy:       quote.CreatedBy,
+		CreatedAt:       quote.CreatedAt,
+		UpdatedBy:       quote.UpdatedBy,
+		UpdatedAt:       quote.UpdatedAt,
+	}
+	for i, item := range quote.Items {
+		response.Items[i] = schemas.QuotationItem{
+			ID:          item.ID,
+			ProductId:   item.ProductID,
+			ProductCode: item.Product.ProductNo,
+			ProductName: item.Product.Name,
+			Unit:        item.Product.Unit,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Discount:    item.Discount,
+			Amount:      item.Amount,
+		}
+	}
+	return response
+}
+
+// salesOrderResponse 将销售订单模型转换为响应格式，明细已预加载时一并转换
+func salesOrderResponse(order models.SalesOrder) schemas.OrderResponse {
+	response := schemas.OrderResponse{
+		ID:                  order.ID,
+		OrderNo:             order.OrderNo,
+		CustomerId:          order.CustomerID,
+		CustomerName:        order.Customer.Name,
+		QuotationId:         order.QuoteID,
+		OrderDate:           order.OrderDate.Format("2006-01-02"),
+		Remarks:             order.Remarks,
+		TotalAmount:         order.TotalAmount,
+		Currency:            order.Currency,
+		ExchangeRate:        order.ExchangeRate,
+		BaseTotalAmount:     order.BaseTotalAmount,
+		Status:              order.Status,
+		CreditHoldReason:    order.CreditHoldReason,
+		CreditReleasedBy:    order.CreditReleasedBy,
+		CreditReleaseReason: order.CreditReleaseReason,
+		Items:               make([]schemas.OrderItem, len(order.Items)),
+		CreatedBy:           order.CreatedBy,
+		CreatedAt:           order.CreatedAt,
+		UpdatedBy:           order.UpdatedBy,
+		UpdatedAt:           order.UpdatedAt,
+	}
+	if order.DeliveryDate != nil {
+		response.DeliveryDate = order.DeliveryDate.Format("2006-01-02")
+	}
+	if order.CreditReleasedAt != nil {
+		response.CreditReleasedAt = order.CreditReleasedAt.Format("2006-01-02 15:04:05")
+	}
+	for i, item := range order.Items {
+		response.Items[i] = schemas.OrderItem{
+			ID:              item.ID,
+			ProductId:       item.ProductID,
+			ProductCode:     item.Product.ProductNo,
+			ProductName:     item.Product.Name,
+			Unit:            item.Product.Unit,
+			Quantity:        item.Quantity,
+			UnitPrice:       item.UnitPrice,
+			Discount:        item.Discount,
+			Amount:          item.Amount,
+			ShippedQuantity: item.ShippedQuantity,
+			WarehouseId:     item.WarehouseID,
+		}
+	}
+	return response
+}
+
+// salesDeliveryResponse 将销售发货单模型转换为响应格式，明细已预加载时一并转换
+func salesDeliveryResponse(delivery models.SalesDelivery) schemas.DeliveryResponse {
+	response := schemas.DeliveryResponse{
+		ID:            delivery.ID,
+		DeliveryNo:    delivery.DeliveryNo,
+		OrderId:       delivery.OrderID,
+		CustomerId:    delivery.CustomerID,
+		CustomerName:  delivery.Customer.Name,
+		DeliveryDate:  delivery.DeliveryDate.Format("2006-01-02"),
+		TotalQuantity: delivery.TotalQuantity,
+		Remarks:       delivery.Remarks,
+		Status:        delivery.Status,
+		Items:         make([]schemas.DeliveryItemResponse, len(delivery.Items)),
+		CreatedBy:     delivery.CreatedBy,
+		CreatedAt:     delivery.CreatedAt,
+		UpdatedBy:     delivery.UpdatedBy,
+		UpdatedAt:     delivery.UpdatedAt,
+	}
+	for i, item := range delivery.Items {
+		response.Items[i] = schemas.DeliveryItemResponse{
+			ID:          item.ID,
+			OrderItemId: item.OrderItemID,
+			ProductId:   item.ProductID,
+			ProductCode: item.Product.ProductNo,
+			ProductName: item.Product.Name,
+			Quantity:    item.Quantity,
+			WarehouseId: item.WarehouseID,
+			LocationId:  item.LocationID,
+		}
+	}
+	return response
+}
+
+// salesInvoiceResponse 将销售发票模型转换为响应格式，明细已预加载时一并转换
+func salesInvoiceResponse(invoice models.SalesInvoice) schemas.InvoiceResponse {
+	response := schemas.InvoiceResponse{
+		ID:               invoice.ID,
+		InvoiceNo:        invoice.InvoiceNo,
+		OrderId:          invoice.OrderID,
+		CustomerId:       invoice.CustomerID,
+		CustomerName:     invoice.Customer.Name,
+		InvoiceDate:      invoice.InvoiceDate.Format("2006-01-02"),
+		DueDate:          invoice.DueDate.Format("2006-01-02"),
+		TotalAmount:      invoice.TotalAmount,
+		TaxAmount:        invoice.TaxAmount,
+		PriceIncludesTax: invoice.PriceIncludesTax,
+		Currency:         invoice.Currency,
+		ExchangeRate:     invoice.ExchangeRate,
+		BaseTotalAmount:  invoice.BaseTotalAmount,
+		BaseTaxAmount:    invoice.BaseTaxAmount,
+		PaidAmount:       invoice.PaidAmount,
+		WriteOffAmount:   invoice.WriteOffAmount,
+		CreditedAmount:   invoice.CreditedAmount,
+		BalanceAmount:    salesInvoiceBalance(invoice),
+		Remarks:          invoice.Remarks,
+		Status:           invoice.Status,
+		Items:            make([]schemas.InvoiceItem, len(invoice.Items)),
+		CreatedBy:        invoice.CreatedBy,
+		CreatedAt:        invoice.CreatedAt,
+		UpdatedBy:        invoice.UpdatedBy,
+		UpdatedAt:        invoice.UpdatedAt,
+	}
+	for i, item := range invoice.Items {
+		response.Items[i] = schemas.InvoiceItem{
+			ID:          item.ID,
+			ProductId:   item.ProductID,
+			ProductCode: item.Product.ProductNo,
+			ProductName: item.Product.Name,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Discount:    item.Discount,
+			Amount:      item.Amount,
+			TaxCodeId:   item.TaxCodeID,
+			TaxRate:     item.TaxRate,
+			NetAmount:   item.NetAmount,
+			TaxAmount:   item.TaxAmount,
+		}
+	}
+	return response
+}
+
+// salesQuoteItems 根据请求构建报价明细并计算报价总额，单价和折扣由定价服务按客户和报价日期确定
+func salesQuoteItems(db *gorm.DB, ctx pricingContext, quoteID string, items []schemas.QuotationItem, operator string) ([]models.SalesQuoteItem, float64, error) {
+	quoteItems := make([]models.SalesQuoteItem, len(items))
+	var total float64
+	for i, item := range items {
+		price, err := resolveLinePrice(db, ctx, item.ProductId, item.Quantity)
+		if err != nil {
+			return nil, 0, err
+		}
+		quoteItems[i] = models.SalesQuoteItem{
+			ID:        utils.GenerateID(),
+			QuoteID:   quoteID,
+			ProductID: item.ProductId,
+			Quantity:  item.Quantity,
+			UnitPrice: price.UnitPrice,
+			Discount:  price.Discount,
+			Amount:    price.Amount,
+			CreatedBy: operator,
+			CreatedAt: time.Now(),
+			UpdatedBy: operator,
+			UpdatedAt: time.Now(),
+		}
+		total += price.Amount
+	}
+	return quoteItems, roundAmount(total), nil
+}
+
+// salesOrderItems 根据请求构建订单明细并计算订单总额，单价和折扣由定价服务按客户和订单日期确定
+func salesOrderItems(db *gorm.DB, ctx pricingContext, orderID string, items []schemas.OrderItem, operator string) ([]models.SalesOrderItem, float64, error) {
+	orderItems := make([]models.SalesOrderItem, len(items))
+	var total float64
+	for i, item := range items {
+		price, err := resolveLinePrice(db, ctx, item.ProductId, item.Quantity)
+		if err != nil {
+			return nil, 0, err
+		}
+		orderItems[i] = models.SalesOrderItem{
+			ID:          utils.GenerateID(),
+			OrderID:     orderID,
+			ProductID:   item.ProductId,
+			Quantity:    item.Quantity,
+			UnitPrice:   price.UnitPrice,
+			Discount:    price.Discount,
+			Amount:      price.Amount,
+			WarehouseID: item.WarehouseId,
+			CreatedBy:   operator,
+			CreatedAt:   time.Now(),
+			UpdatedBy:   operator,
+			UpdatedAt:   time.Now(),
+		}
+		total += price.Amount
+	}
+	return orderItems, roundAmount(total), nil
+}
+
+// salesOpenQuantities 计算订单各明细的未发数量，即订购数量减去已发数量和未发出发货单占用的数量，
+// excludeDeliveryID不为空时不计该发货单的占用
+func salesOpenQuantities(db *gorm.DB, order models.SalesOrder, excludeDeliveryID string) (map[string]float64, error) {
+	if order.Status != "approved" && order.Status != "partially_shipped" {
+		return nil, fmt.Errorf("sales order in status %s cannot be delivered", order.Status)
+	}
+
+	query := db.Table("sales_delivery_items AS i").
+		Select("i.order_item_id AS order_item_id, SUM(i.quantity) AS quantity").
+		Joins("JOIN sales_deliveries AS d ON d.id = i.delivery_id").
+		Where("d.order_id = ? AND d.status = ? AND d.deleted_at IS NULL AND i.deleted_at IS NULL", order.ID, "pending")
+	if excludeDeliveryID != "" {
+		query = query.Where("d.id <> ?", excludeDeliveryID)
+	}
+
+	var rows []struct {
+		OrderItemID string
+		Quantity    float64
+	}
+	if err := query.Group("i.order_item_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	reserved := make(map[string]float64, len(rows))
+	for _, row := range rows {
+		reserved[row.OrderItemID] = row.Quantity
+	}
+
+	open := make(map[string]float64, len(order.Items))
+	for _, item := range order.Items {
+		open[item.ID] = roundQuantity(item.Quantity - item.ShippedQuantity - reserved[item.ID])
+	}
+	return open, nil
+}
+
+// salesDeliveryItems 根据请求构建发货明细，校验明细属于该订单且不超过未发数量
+func salesDeliveryItems(db *gorm.DB, order models.SalesOrder, deliveryID string, items []schemas.DeliveryItem, operator string) ([]models.SalesDeliveryItem, float64, error) {
+	open, err := salesOpenQuantities(db, order, deliveryID)
+	if err != nil {
+		return nil, 0, err
+	}
+	orderItems := make(map[string]models.SalesOrderItem, len(order.Items))
+	for _, item := range order.Items {
+		orderItems[item.ID] = item
+	}
+
+	deliveryItems := make([]models.SalesDeliveryItem, len(items))
+	var totalQuantity float64
+	for i, item := range items {
+		orderItem, ok := orderItems[item.OrderItemId]
+		if !ok {
+			return nil, 0, fmt.Errorf("order item %s does not belong to sales order %s", item.OrderItemId, order.OrderNo)
+		}
+		if item.Quantity > open[orderItem.ID] {
+			return nil, 0, fmt.Errorf("delivery quantity %.4f exceeds open quantity %.4f of order item %s", item.Quantity, open[orderItem.ID], orderItem.ID)
+		}
+		open[orderItem.ID] = roundQuantity(open[orderItem.ID] - item.Quantity)
+
+		deliveryItems[i] = models.SalesDeliveryItem{
+			ID:          utils.GenerateID(),
+			DeliveryID:  deliveryID,
+			OrderItemID: orderItem.ID,
+			ProductID:   orderItem.ProductID,
+			Quantity:    item.Quantity,
+			WarehouseID: item.WarehouseId,
+			LocationID:  item.LocationId,
+			CreatedBy:   operator,
+			CreatedAt:   time.Now(),
+			UpdatedBy:   operator,
+			UpdatedAt:   time.Now(),
+		}
+		totalQuantity += item.Quantity
+	}
+	return deliveryItems, roundQuantity(totalQuantity), nil
+}
+
+// salesInvoiceableQuantities 计算订单各明细已发未开票数量，发票明细按产品记录，
+// 已开票数量按订单明细顺序冲抵同一产品的已发数量，excludeInvoiceID不为空时不计该发票
+func salesInvoiceableQuantities(db *gorm.DB, order models.SalesOrder, excludeInvoiceID string) (map[string]float64, error) {
+	query := db.Table("sales_invoice_items AS i").
+		Select("i.product_id AS product_id, SUM(i.quantity) AS quantity").
+		Joins("JOIN sales_invoices AS v ON v.id = i.invoice_id").
+		Where("v.order_id = ? AND v.deleted_at IS NULL AND i.deleted_at IS NULL", order.ID)
+	if excludeInvoiceID != "" {
+		query = query.Where("v.id <> ?", excludeInvoiceID)
+	}
+
+	var rows []struct {
+		ProductID string
+		Quantity  float64
+	}
+	if err := query.Group("i.product_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	invoiced := make(map[string]float64, len(rows))
+	for _, row := range rows {
+		invoiced[row.ProductID] = row.Quantity
+	}
+
+	invoiceable := make(map[string]float64, len(order.Items))
+	for _, item := range order.Items {
+		covered := math.Min(invoiced[item.ProductID], item.ShippedQuantity)
+		invoiced[item.ProductID] -= covered
+		invoiceable[item.ID] = roundQuantity(item.ShippedQuantity - covered)
+	}
+	return invoiceable, nil
+}
+
+// salesInvoiceItems 根据请求构建发票明细并计算发票总额，各产品开票数量不能超过已发未开票数量
+func salesInvoiceItems(db *gorm.DB, order models.SalesOrder, invoiceID string, items []schemas.InvoiceItem, operator string) ([]models.SalesInvoiceItem, float64, error) {
+	invoiceable, err := salesInvoiceableQuantities(db, order, invoiceID)
+	if err != nil {
+		return nil, 0, err
+	}
+	available := make(map[string]float64)
+	for _, item := range order.Items {
+		available[item.ProductID] += invoiceable[item.ID]
+	}
+
+	invoiceItems := make([]models.SalesInvoiceItem, len(items))
+	var total float64
+	for i, item := range items {
+		quantity, ok := available[item.ProductId]
+		if !ok {
+			return nil, 0, fmt.Errorf("product %s does not belong to sales order %s", item.ProductId, order.OrderNo)
+		}
+		if item.Quantity > quantity {
+			return nil, 0, fmt.Errorf("invoice quantity %.4f exceeds delivered uninvoiced quantity %.4f of product %s", item.Quantity, quantity, item.ProductId)
+		}
+		available[item.ProductId] = roundQuantity(quantity - item.Quantity)
+
+		amount := roundAmount(item.Quantity*item.UnitPrice - item.Discount)
+		invoiceItems[i] = models.SalesInvoiceItem{
+			ID:        utils.GenerateID(),
+			InvoiceID: invoiceID,
+			ProductID: item.ProductId,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+			Discount:  item.Discount,
+			Amount:    amount,
+			CreatedBy: operator,
+			CreatedAt: time.Now(),
+			UpdatedBy: operator,
+			UpdatedAt: time.Now(),
+		}
+		total += amount
+	}
+	return invoiceItems, roundAmount(total), nil
+}
+
+// issueInvoice 计算税额后在同一事务中保存销售发票、按记账规则生成凭证并刷新订单状态
+func (s *salesService) issueInvoice(invoice models.SalesInvoice) error {
+	// 发票日期所在会计期间必须允许记账
+	if err := checkPeriodOpen(s.db, invoice.InvoiceDate, false); err != nil {
+		return err
+	}
+	if err := applySalesInvoiceTax(s.db, &invoice); err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&invoice).Error; err != nil {
+			return err
+		}
+		if _, err := postDocument(tx, salesInvoicePostingDocument(invoice)); err != nil {
+			return err
+		}
+		return refreshSalesOrderStatus(tx, invoice.OrderID)
+	})
+}
+
+// salesDocumentCurrency 确定销售报价和订单的币种及汇率，未指定币种时取客户默认币种
+func salesDocumentCurrency(db *gorm.DB, customerID, currency string, override float64, date time.Time) (string, float64, error) {
+	var customer models.SalesCustomer
+	if err := db.First(&customer, "id = ?", customerID).Error; err != nil {
+		return "", 0, err
+	}
+	return documentCurrency(db, currency, customer.Currency, override, date)
+}
+
+// refreshSalesOrderStatus 按已发和已开票数量回写订单状态：部分发货时保留欠交数量为partially_shipped，
+// 全部发货为shipped，全部发货且全部开票为completed
+func refreshSalesOrderStatus(tx *gorm.DB, orderID string) error {
+	var order models.SalesOrder
+	result := tx.Preload("Items").First(&order, "id = ?", orderID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if order.Status == "pending" || order.Status == "cancelled" {
+		return nil
+	}
+
+	invoiceable, err := salesInvoiceableQuantities(tx, order, "")
+	if err != nil {
+		return err
+	}
+
+	shipped, backordered, uninvoiced := false, false, false
+	for _, item := range order.Items {
+		if item.ShippedQuantity > 0 {
+			shipped = true
+		}
+		if roundQuantity(item.Quantity-item.ShippedQuantity) > 0 {
+			backordered = true
+		}
+		if invoiceable[item.ID] > 0 {
+			uninvoiced = true
+		}
+	}
+
+	status := "approved"
+	switch {
+	case shipped && backordered:
+		status = "partially_shipped"
+	case shipped && uninvoiced:
+		status = "shipped"
+	case shipped:
+		status = "completed"
+	}
+	if status == order.Status {
+		return nil
+	}
+
+	return tx.Model(&models.SalesOrder{}).
+		Where("id = ?", order.ID).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+// roundQuantity 数量保留四位小数，与数据库精度一致
+func roundQuantity(quantity float64) float64 {
+	return math.Round(quantity*10000) / 10000
+}
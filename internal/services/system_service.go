@@ -0,0 +1,481 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wu136995/ginx/internal/api/schemas"
+	"github.com/wu136995/ginx/internal/database"
+	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/query"
+	"github.com/wu136995/ginx/internal/utils"
+	"gorm.io/gorm"
+)
+
+// SystemService 系统管理服务接口
+type SystemService interface {
+	// 角色管理
+	GetRoleList(params query.Params) (*query.Page[schemas.RoleResponse], error)
+	GetRoleDetail(id string) (*schemas.RoleResponse, error)
+	CreateRole(req schemas.RoleCreateRequest) (*schemas.RoleResponse, error)
+	UpdateRole(id string, req schemas.RoleUpdateRequest) (*schemas.RoleResponse, error)
+	DeleteRole(id string) error
+	SetRolePermissions(id string, req schemas.RolePermissionsRequest) (*schemas.RoleResponse, error)
+
+	// 权限管理
+	GetPermissionList(params query.Params) (*query.Page[schemas.PermissionResponse], error)
+	SyncPermissions(permissions []models.SystemPermission) error
+
+	// 用户授权
+	GetUserRoles(userID string) (*schemas.UserRolesResponse, error)
+	SetUserRoles(userID string, req schemas.UserRolesRequest) (*schemas.UserRolesResponse, error)
+	GetUserPermissionCodes(userID string) ([]string, error)
+}
+
+// systemService 系统管理服务实现
+type systemService struct {
+	db *gorm.DB
+}
+
+// NewSystemService 创建系统管理服务实例
+func NewSystemService() SystemService {
+	return &systemService{
+		db: database.GetDB(),
+	}
+}
+
+// roleListSpec 角色列表查询白名单
+var roleListSpec = query.NewSpec("code",
+	query.Text("code"),
+	query.Text("name"),
+	query.Text("status"),
+	query.Date("created_at"),
+)
+
+// 角色管理方法
+func (s *systemService) GetRoleList(params query.Params) (*query.Page[schemas.RoleResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取角色及权限
+	var roles []models.SystemRole
+	total, err := query.Find(s.db, params, roleListSpec, &roles, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("RolePermissions.Permission")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	responses := make([]schemas.RoleResponse, len(roles))
+	for i, role := range roles {
+		responses[i] = roleResponse(role)
+	}
+
+	return query.NewPage(responses, total, params), nil
+}
+
+func (s *systemService) GetRoleDetail(id string) (*schemas.RoleResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取角色详情
+	var role models.SystemRole
+	result := s.db.Preload("RolePermissions.Permission").First(&role, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	response := roleResponse(role)
+	return &response, nil
+}
+
+func (s *systemService) CreateRole(req schemas.RoleCreateRequest) (*schemas.RoleResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 创建角色模型
+	role := models.SystemRole{
+		ID:          utils.GenerateID(),
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+		Status:      req.Status,
+		CreatedBy:   req.CreatedBy,
+		UpdatedBy:   req.CreatedBy,
+	}
+	if role.Status == "" {
+		role.Status = "active"
+	}
+
+	// 在同一事务中保存角色及权限
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&role).Error; err != nil {
+			return err
+		}
+		return replaceRolePermissions(tx, role.ID, req.PermissionCodes, req.CreatedBy)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetRoleDetail(role.ID)
+}
+
+func (s *systemService) UpdateRole(id string, req schemas.RoleUpdateRequest) (*schemas.RoleResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取角色
+	var role models.SystemRole
+	result := s.db.First(&role, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 更新字段，角色编码创建后不可修改
+	if req.Name != "" {
+		role.Name = req.Name
+	}
+	if req.Description != "" {
+		role.Description = req.Description
+	}
+	if req.Status != "" {
+		role.Status = req.Status
+	}
+	role.UpdatedBy = req.UpdatedBy
+
+	// 保存到数据库
+	result = s.db.Save(&role)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return s.GetRoleDetail(role.ID)
+}
+
+func (s *systemService) DeleteRole(id string) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 在同一事务中删除角色及其用户、权限关联
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role_id = ?", id).Delete(&models.SystemUserRole{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("role_id = ?", id).Delete(&models.SystemRolePermission{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.SystemRole{}, "id = ?", id).Error
+	})
+}
+
+func (s *systemService) SetRolePermissions(id string, req schemas.RolePermissionsRequest) (*schemas.RoleResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取角色
+	var role models.SystemRole
+	result := s.db.First(&role, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 整体替换角色权限
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := replaceRolePermissions(tx, role.ID, req.PermissionCodes, req.UpdatedBy); err != nil {
+			return err
+		}
+		return tx.Model(&role).Updates(map[string]interface{}{
+			"updated_by": req.UpdatedBy,
+			"updated_at": time.Now(),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetRoleDetail(role.ID)
+}
+
+// permissionListSpec 权限列表查询白名单
+var permissionListSpec = query.NewSpec("code",
+	query.Text("code"),
+	query.Text("name"),
+	query.Text("module"),
+)
+
+// 权限管理方法
+func (s *systemService) GetPermissionList(params query.Params) (*query.Page[schemas.PermissionResponse], error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取权限
+	var permissions []models.SystemPermission
+	total, err := query.Find(s.db, params, permissionListSpec, &permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	// 将模型转换为响应格式
+	responses := make([]schemas.PermissionResponse, len(permissions))
+	for i, permission := range permissions {
+		responses[i] = schemas.PermissionResponse{
+			ID:          permission.ID,
+			Code:        permission.Code,
+			Name:        permission.Name,
+			Description: permission.Description,
+			Module:      permission.Module,
+		}
+	}
+
+	return query.NewPage(responses, total, params), nil
+}
+
+func (s *systemService) SyncPermissions(permissions []models.SystemPermission) error {
+	// 检查数据库连接
+	if s.db == nil {
+		return errors.New("database connection is nil")
+	}
+
+	// 读取已有权限编码
+	var existing []string
+	if err := s.db.Model(&models.SystemPermission{}).Pluck("code", &existing).Error; err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(existing))
+	for _, code := range existing {
+		known[code] = true
+	}
+
+	// 只新增缺失的权限，已有权限的名称和描述保持不变
+	var missing []models.SystemPermission
+	for _, permission := range permissions {
+		if known[permission.Code] {
+			continue
+		}
+		known[permission.Code] = true
+		permission.ID = utils.GenerateID()
+		if permission.Name == "" {
+			permission.Name = permission.Code
+		}
+		if permission.CreatedBy == "" {
+			permission.CreatedBy = "system"
+		}
+		permission.UpdatedBy = permission.CreatedBy
+		missing = append(missing, permission)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return s.db.Create(&missing).Error
+}
+
+// 用户授权方法
+func (s *systemService) GetUserRoles(userID string) (*schemas.UserRolesResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 从数据库读取用户角色
+	var userRoles []models.SystemUserRole
+	result := s.db.Preload("Role.RolePermissions.Permission").Where("user_id = ?", userID).Find(&userRoles)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// 汇总有效角色的权限
+	response := &schemas.UserRolesResponse{
+		UserID: userID,
+		Roles:  make([]schemas.RoleResponse, 0, len(userRoles)),
+	}
+	seen := make(map[string]bool)
+	for _, userRole := range userRoles {
+		if userRole.Role.ID == "" {
+			continue
+		}
+		role := roleResponse(userRole.Role)
+		response.Roles = append(response.Roles, role)
+		if userRole.Role.Status != "active" {
+			continue
+		}
+		for _, code := range role.PermissionCodes {
+			if !seen[code] {
+				seen[code] = true
+				response.PermissionCodes = append(response.PermissionCodes, code)
+			}
+		}
+	}
+	sort.Strings(response.PermissionCodes)
+
+	return response, nil
+}
+
+func (s *systemService) SetUserRoles(userID string, req schemas.UserRolesRequest) (*schemas.UserRolesResponse, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 校验角色均存在
+	roleIDs := uniqueStrings(req.RoleIDs)
+	var count int64
+	if err := s.db.Model(&models.SystemRole{}).Where("id IN ?", roleIDs).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if int(count) != len(roleIDs) {
+		return nil, errors.New("one or more roles not found")
+	}
+
+	// 整体替换用户角色
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.SystemUserRole{}).Error; err != nil {
+			return err
+		}
+		for _, roleID := range roleIDs {
+			userRole := models.SystemUserRole{
+				ID:        utils.GenerateID(),
+				UserID:    userID,
+				RoleID:    roleID,
+				CreatedBy: req.CreatedBy,
+			}
+			if err := tx.Create(&userRole).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetUserRoles(userID)
+}
+
+func (s *systemService) GetUserPermissionCodes(userID string) ([]string, error) {
+	// 检查数据库连接
+	if s.db == nil {
+		return nil, errors.New("database connection is nil")
+	}
+
+	// 汇总用户所有有效角色的权限编码
+	var codes []string
+	result := s.db.Table("system_user_roles AS ur").
+		Joins("JOIN system_roles AS r ON r.id = ur.role_id AND r.deleted_at IS NULL AND r.status = ?", "active").
+		Joins("JOIN system_role_permissions AS rp ON rp.role_id = r.id").
+		Joins("JOIN system_permissions AS p ON p.id = rp.permission_id").
+		Where("ur.user_id = ?", userID).
+		Pluck("DISTINCT p.code", &codes)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return codes, nil
+}
+
+// replaceRolePermissions 用给定权限编码替换角色权限，通配编码不存在时自动创建
+func replaceRolePermissions(tx *gorm.DB, roleID string, codes []string, createdBy string) error {
+	codes = uniqueStrings(codes)
+
+	var permissions []models.SystemPermission
+	if len(codes) > 0 {
+		if err := tx.Where("code IN ?", codes).Find(&permissions).Error; err != nil {
+			return err
+		}
+	}
+	found := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		found[permission.Code] = true
+	}
+	for _, code := range codes {
+		if found[code] {
+			continue
+		}
+		if !strings.HasSuffix(code, ":*") {
+			return fmt.Errorf("permission %s not found", code)
+		}
+		permission := models.SystemPermission{
+			ID:        utils.GenerateID(),
+			Code:      code,
+			Name:      code,
+			Module:    strings.SplitN(code, ":", 2)[0],
+			CreatedBy: createdBy,
+			UpdatedBy: createdBy,
+		}
+		if err := tx.Create(&permission).Error; err != nil {
+			return err
+		}
+		permissions = append(permissions, permission)
+	}
+
+	if err := tx.Where("role_id = ?", roleID).Delete(&models.SystemRolePermission{}).Error; err != nil {
+		return err
+	}
+	for _, permission := range permissions {
+		rolePermission := models.SystemRolePermission{
+			ID:           utils.GenerateID(),
+			RoleID:       roleID,
+			PermissionID: permission.ID,
+			CreatedBy:    createdBy,
+		}
+		if err := tx.Create(&rolePermission).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// roleResponse 将角色模型转换为响应格式，需已加载权限
+func roleResponse(role models.SystemRole) schemas.RoleResponse {
+	response := schemas.RoleResponse{
+		ID:              role.ID,
+		Code:            role.Code,
+		Name:            role.Name,
+		Description:     role.Description,
+		Status:          role.Status,
+		PermissionCodes: make([]string, 0, len(role.RolePermissions)),
+		CreatedAt:       role.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:       role.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+	for _, rolePermission := range role.RolePermissions {
+		if rolePermission.Permission.Code != "" {
+			response.PermissionCodes = append(response.PermissionCodes, rolePermission.Permission.Code)
+		}
+	}
+	sort.Strings(response.PermissionCodes)
+	return response
+}
+
+// uniqueStrings 去除空值和重复值，保持原有顺序
+func uniqueStrings(values []string) []string {
+	result := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		result = append(result, value)
+	}
+	return result
+}
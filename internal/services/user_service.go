@@ -5,6 +5,7 @@ import (
 
 	"github.com/wu136995/ginx/internal/database"
 	"github.com/wu136995/ginx/internal/models"
+	"github.com/wu136995/ginx/internal/utils"
 	"gorm.io/gorm"
 )
 
@@ -52,6 +53,16 @@ func (s *userService) CreateUser(user *models.User) error {
 		return errors.New("database connection is nil")
 	}
 
+	// 密码只保存哈希
+	if user.Password == "" {
+		return errors.New("password is required")
+	}
+	hash, err := utils.HashPassword(user.Password)
+	if err != nil {
+		return err
+	}
+	user.Password = hash
+
 	// 保存到数据库
 	result := s.db.Create(user)
 	if result.Error != nil {
@@ -68,8 +79,8 @@ func (s *userService) UpdateUser(user *models.User) error {
 		return errors.New("database connection is nil")
 	}
 
-	// 保存到数据库
-	result := s.db.Save(user)
+	// 保存到数据库，密码只能通过修改或重置密码流程变更
+	result := s.db.Omit("password").Save(user)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// GenerateID 生成UUID v4格式的主键
+func GenerateID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	// 设置版本号和变体位
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -23,8 +23,13 @@ type Claims struct {
 
 // GenerateToken 生成JWT token
 func GenerateToken(userID uint, username, role string, secret string, expireHours int) (string, error) {
+	return GenerateAccessToken(userID, username, role, "", secret, time.Hour*time.Duration(expireHours))
+}
+
+// GenerateAccessToken 生成关联登录会话的访问令牌，会话ID保存在jti中
+func GenerateAccessToken(userID uint, username, role, sessionID, secret string, ttl time.Duration) (string, error) {
 	// 设置过期时间
-	expireTime := time.Now().Add(time.Hour * time.Duration(expireHours))
+	expireTime := time.Now().Add(ttl)
 
 	// 创建声明
 	claims := Claims{
@@ -32,6 +37,7 @@ func GenerateToken(userID uint, username, role string, secret string, expireHour
 		Username: username,
 		Role:     role,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        sessionID,
 			ExpiresAt: jwt.NewNumericDate(expireTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Subject:   username,
@@ -101,6 +101,13 @@ func main() {
 	migrateCtx, migrateCancel := context.WithCancel(context.Background())
 	migrator.Start(migrateCtx)
 
+	// 启动定时补货，仅在启动库存模块时运行；多实例部署时只应在一个实例上配置定时补货
+	replenishCtx, replenishCancel := context.WithCancel(context.Background())
+	replenishInterval := time.Duration(config.GetAppConfig().Inventory.ReplenishmentInterval) * time.Second
+	if replenishInterval > 0 && (*module == "all" || *module == "inventory") {
+		services.StartReplenishmentScheduler(replenishCtx, replenishInterval)
+	}
+
 	// 设置Gin模式
 	if config.GetAppConfig().Server.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
@@ -117,12 +124,21 @@ func main() {
 
 	// 初始化处理器
 	userHandler, salesHandler, inventoryHandler, purchaseHandler, financeHandler, productionHandler, hrHandler, crmHandler := initializeHandlersByModule(*module)
+	systemService := services.NewSystemService()
+	systemHandler := handlers.NewSystemHandler(systemService)
+	authService := services.NewAuthService()
+	authHandler := handlers.NewAuthHandler(authService)
 
 	// // 设置Swagger路由
 	// router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	// 设置路由
-	setupRoutesByModule(router, *module, userHandler, salesHandler, inventoryHandler, purchaseHandler, financeHandler, productionHandler, hrHandler, crmHandler)
+	setupRoutesByModule(router, *module, userHandler, salesHandler, inventoryHandler, purchaseHandler, financeHandler, productionHandler, hrHandler, crmHandler, systemHandler, systemService, authHandler, authService)
+
+	// 根据已注册的路由同步权限编码
+	if err := routes.SyncPermissions(router, systemService); err != nil {
+		log.Printf("同步权限编码失败: %v", err)
+	}
 
 	// 创建HTTP服务器
 	port := config.GetAppConfig().Server.Port
@@ -160,6 +176,9 @@ func main() {
 	log.Println("正在停止数据迁移服务...")
 	migrateCancel()
 
+	// 停止定时补货
+	replenishCancel()
+
 	// 关闭数据库连接
 	log.Println("正在关闭数据库连接...")
 	if err := database.Close(); err != nil {
@@ -215,7 +234,7 @@ func initializeHandlersByModule(module string) (*handlers.UserHandler, *handlers
 }
 
 // setupRoutesByModule 根据指定模块设置路由
-func setupRoutesByModule(router *gin.Engine, module string, userHandler *handlers.UserHandler, salesHandler *handlers.SalesHandler, inventoryHandler *handlers.InventoryHandler, purchaseHandler *handlers.PurchaseHandler, financeHandler *handlers.FinanceHandler, productionHandler *handlers.ProductionHandler, hrHandler *handlers.HRHandler, crmHandler *handlers.CRMHandler) {
+func setupRoutesByModule(router *gin.Engine, module string, userHandler *handlers.UserHandler, salesHandler *handlers.SalesHandler, inventoryHandler *handlers.InventoryHandler, purchaseHandler *handlers.PurchaseHandler, financeHandler *handlers.FinanceHandler, productionHandler *handlers.ProductionHandler, hrHandler *handlers.HRHandler, crmHandler *handlers.CRMHandler, systemHandler *handlers.SystemHandler, systemService services.SystemService, authHandler *handlers.AuthHandler, authService services.AuthService) {
 	// 公共路由组
 	public := router.Group("/")
 	{
@@ -229,35 +248,45 @@ func setupRoutesByModule(router *gin.Engine, module string, userHandler *handler
 
 	// 需要认证的路由组
 	protected := router.Group("/")
-	protected.Use(middlewares.Auth())
+	protected.Use(middlewares.Auth(authService))
 	{
 		// 用户信息
 		protected.GET("/user/info", userHandler.GetUserInfo)
 	}
 
+	// 认证路由
+	routes.SetupAuthRoutes(public, protected, authHandler)
+
+	// 业务模块路由需要认证并校验权限
+	authorized := router.Group("/")
+	authorized.Use(middlewares.Auth(authService), middlewares.Authorize(systemService))
+
+	// 系统管理路由在所有模块下启用
+	routes.SetupSystemRoutes(authorized, systemHandler, authHandler)
+
 	// 根据模块设置路由
 	switch module {
 	case "sales":
-		routes.SetupSalesRoutes(router, salesHandler)
+		routes.SetupSalesRoutes(authorized, salesHandler)
 	case "inventory":
-		routes.SetupInventoryRoutes(router, inventoryHandler)
+		routes.SetupInventoryRoutes(authorized, inventoryHandler)
 	case "purchase":
-		routes.SetupPurchaseRoutes(router, purchaseHandler)
+		routes.SetupPurchaseRoutes(authorized, purchaseHandler)
 	case "finance":
-		routes.SetupFinanceRoutes(router, financeHandler)
+		routes.SetupFinanceRoutes(authorized, financeHandler)
 	case "production":
-		routes.SetupProductionRoutes(router, productionHandler)
+		routes.SetupProductionRoutes(authorized, productionHandler)
 	case "hr":
-		routes.SetupHRRoutes(router, hrHandler)
+		routes.SetupHRRoutes(authorized, hrHandler)
 	case "crm":
-		routes.SetupCRMRoutes(router, crmHandler)
+		routes.SetupCRMRoutes(authorized, crmHandler)
 	default: // all
-		routes.SetupSalesRoutes(router, salesHandler)
-		routes.SetupInventoryRoutes(router, inventoryHandler)
-		routes.SetupPurchaseRoutes(router, purchaseHandler)
-		routes.SetupFinanceRoutes(router, financeHandler)
-		routes.SetupProductionRoutes(router, productionHandler)
-		routes.SetupHRRoutes(router, hrHandler)
-		routes.SetupCRMRoutes(router, crmHandler)
+		routes.SetupSalesRoutes(authorized, salesHandler)
+		routes.SetupInventoryRoutes(authorized, inventoryHandler)
+		routes.SetupPurchaseRoutes(authorized, purchaseHandler)
+		routes.SetupFinanceRoutes(authorized, financeHandler)
+		routes.SetupProductionRoutes(authorized, productionHandler)
+		routes.SetupHRRoutes(authorized, hrHandler)
+		routes.SetupCRMRoutes(authorized, crmHandler)
 	}
 }
@@ -182,6 +182,8 @@ CREATE TABLE IF NOT EXISTS `sales_customers` (
   `region` VARCHAR(50) COMMENT '地区',
   `credit_limit` DECIMAL(18,2) DEFAULT 0 COMMENT '信用额度',
   `credit_days` INT DEFAULT 0 COMMENT '信用天数',
+  `tax_status` VARCHAR(20) DEFAULT 'taxable' COMMENT '纳税状态（taxable, exempt, export）',
+  `currency` VARCHAR(3) COMMENT '默认交易币种（为空表示本位币）',
   `remarks` TEXT COMMENT '备注',
   `status` VARCHAR(20) DEFAULT 'active' COMMENT '状态',
   `created_by` VARCHAR(36) COMMENT '创建人',
@@ -197,8 +199,10 @@ CREATE TABLE IF NOT EXISTS `sales_products` (
   `name` VARCHAR(100) NOT NULL COMMENT '产品名称',
   `description` TEXT COMMENT '产品描述',
   `category_id` VARCHAR(36) COMMENT '产品类别ID',
+  `item_id` VARCHAR(36) COMMENT '库存物料ID',
   `unit` VARCHAR(10) NOT NULL COMMENT '单位',
   `price` DECIMAL(18,2) NOT NULL COMMENT '销售价格',
+  `tax_category` VARCHAR(20) DEFAULT 'standard' COMMENT '税收分类（standard, reduced, zero, exempt）',
   `status` VARCHAR(20) DEFAULT 'active' COMMENT '状态',
   `created_by` VARCHAR(36) COMMENT '创建人',
   `created_at` DATETIME NOT NULL COMMENT '创建时间',
@@ -227,6 +231,9 @@ CREATE TABLE IF NOT EXISTS `sales_quotes` (
   `quote_date` DATE NOT NULL COMMENT '报价日期',
   `valid_until` DATE NOT NULL COMMENT '有效期至',
   `total_amount` DECIMAL(18,2) NOT NULL COMMENT '总金额',
+  `currency` VARCHAR(3) COMMENT '币种',
+  `exchange_rate` DECIMAL(18,6) DEFAULT 1 COMMENT '汇率（1单位外币折合本位币）',
+  `base_total_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '本位币总金额',
   `status` VARCHAR(20) DEFAULT 'draft' COMMENT '状态（draft, submitted, approved, expired, cancelled）',
   `remarks` TEXT COMMENT '备注',
   `created_by` VARCHAR(36) COMMENT '创建人',
@@ -261,7 +268,14 @@ CREATE TABLE IF NOT EXISTS `sales_orders` (
   `order_date` DATE NOT NULL COMMENT '订单日期',
   `delivery_date` DATE COMMENT '交货日期',
   `total_amount` DECIMAL(18,2) NOT NULL COMMENT '总金额',
-  `status` VARCHAR(20) DEFAULT 'pending' COMMENT '状态（pending, confirmed, shipped, invoiced, cancelled）',
+  `currency` VARCHAR(3) COMMENT '币种',
+  `exchange_rate` DECIMAL(18,6) DEFAULT 1 COMMENT '汇率（1单位外币折合本位币）',
+  `base_total_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '本位币总金额',
+  `status` VARCHAR(20) DEFAULT 'pending' COMMENT '状态（pending, credit_hold, approved, partially_shipped, shipped, completed, cancelled）',
+  `credit_hold_reason` VARCHAR(255) COMMENT '信用冻结原因',
+  `credit_released_by` VARCHAR(36) COMMENT '信用放行人',
+  `credit_released_at` DATETIME COMMENT '信用放行时间',
+  `credit_release_reason` VARCHAR(255) COMMENT '信用放行原因',
   `remarks` TEXT COMMENT '备注',
   `created_by` VARCHAR(36) COMMENT '创建人',
   `created_at` DATETIME NOT NULL COMMENT '创建时间',
@@ -280,6 +294,7 @@ CREATE TABLE IF NOT EXISTS `sales_order_items` (
   `discount` DECIMAL(18,2) DEFAULT 0 COMMENT '折扣',
   `amount` DECIMAL(18,2) NOT NULL COMMENT '金额',
   `shipped_quantity` DECIMAL(18,4) DEFAULT 0 COMMENT '已发货数量',
+  `warehouse_id` VARCHAR(36) COMMENT '指定发货仓库ID',
   `created_by` VARCHAR(36) COMMENT '创建人',
   `created_at` DATETIME NOT NULL COMMENT '创建时间',
   `updated_by` VARCHAR(36) COMMENT '更新人',
@@ -313,6 +328,8 @@ CREATE TABLE IF NOT EXISTS `sales_delivery_items` (
   `order_item_id` VARCHAR(36) NOT NULL COMMENT '订单明细ID',
   `product_id` VARCHAR(36) NOT NULL COMMENT '产品ID',
   `quantity` DECIMAL(18,4) NOT NULL COMMENT '发货数量',
+  `warehouse_id` VARCHAR(36) COMMENT '发货仓库ID',
+  `location_id` VARCHAR(36) COMMENT '发货库位ID',
   `created_by` VARCHAR(36) COMMENT '创建人',
   `created_at` DATETIME NOT NULL COMMENT '创建时间',
   `updated_by` VARCHAR(36) COMMENT '更新人',
@@ -330,8 +347,16 @@ CREATE TABLE IF NOT EXISTS `sales_invoices` (
   `customer_id` VARCHAR(36) NOT NULL COMMENT '客户ID',
   `invoice_date` DATE NOT NULL COMMENT '开票日期',
   `due_date` DATE NOT NULL COMMENT '到期日期',
-  `total_amount` DECIMAL(18,2) NOT NULL COMMENT '总金额',
+  `total_amount` DECIMAL(18,2) NOT NULL COMMENT '价税合计',
+  `tax_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '税额',
+  `price_includes_tax` TINYINT(1) DEFAULT 0 COMMENT '单价是否含税',
+  `currency` VARCHAR(3) COMMENT '币种',
+  `exchange_rate` DECIMAL(18,6) DEFAULT 1 COMMENT '汇率（1单位外币折合本位币）',
+  `base_total_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '本位币价税合计',
+  `base_tax_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '本位币税额',
   `paid_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '已付金额',
+  `write_off_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '坏账核销金额',
+  `credited_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '退货贷项冲减金额',
   `status` VARCHAR(20) DEFAULT 'unpaid' COMMENT '状态（unpaid, partially_paid, paid, cancelled）',
   `remarks` TEXT COMMENT '备注',
   `created_by` VARCHAR(36) COMMENT '创建人',
@@ -351,6 +376,10 @@ CREATE TABLE IF NOT EXISTS `sales_invoice_items` (
   `unit_price` DECIMAL(18,2) NOT NULL COMMENT '单价',
   `discount` DECIMAL(18,2) DEFAULT 0 COMMENT '折扣',
   `amount` DECIMAL(18,2) NOT NULL COMMENT '金额',
+  `tax_code_id` VARCHAR(36) COMMENT '税码ID',
+  `tax_rate` DECIMAL(7,4) DEFAULT 0 COMMENT '税率（%）',
+  `net_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '不含税金额',
+  `tax_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '税额',
   `created_by` VARCHAR(36) COMMENT '创建人',
   `created_at` DATETIME NOT NULL COMMENT '创建时间',
   `updated_by` VARCHAR(36) COMMENT '更新人',
@@ -365,8 +394,11 @@ CREATE TABLE IF NOT EXISTS `sales_returns` (
   `return_no` VARCHAR(20) UNIQUE NOT NULL COMMENT '退货单编号',
   `order_id` VARCHAR(36) NOT NULL COMMENT '订单ID',
   `customer_id` VARCHAR(36) NOT NULL COMMENT '客户ID',
+  `invoice_id` VARCHAR(36) COMMENT '原发票ID（贷项冲减的发票）',
   `return_date` DATE NOT NULL COMMENT '退货日期',
   `total_amount` DECIMAL(18,2) NOT NULL COMMENT '总金额',
+  `currency` VARCHAR(3) COMMENT '币种',
+  `exchange_rate` DECIMAL(18,6) DEFAULT 1 COMMENT '汇率（1单位外币折合本位币）',
   `status` VARCHAR(20) DEFAULT 'pending' COMMENT '状态（pending, approved, processed, cancelled）',
   `reason` TEXT COMMENT '退货原因',
   `remarks` TEXT COMMENT '备注',
@@ -382,10 +414,14 @@ CREATE TABLE IF NOT EXISTS `sales_returns` (
 CREATE TABLE IF NOT EXISTS `sales_return_items` (
   `id` VARCHAR(36) PRIMARY KEY COMMENT '明细ID',
   `return_id` VARCHAR(36) NOT NULL COMMENT '退货单ID',
+  `order_item_id` VARCHAR(36) COMMENT '订单明细ID',
   `product_id` VARCHAR(36) NOT NULL COMMENT '产品ID',
   `quantity` DECIMAL(18,4) NOT NULL COMMENT '退货数量',
   `unit_price` DECIMAL(18,2) NOT NULL COMMENT '单价',
   `amount` DECIMAL(18,2) NOT NULL COMMENT '金额',
+  `warehouse_id` VARCHAR(36) COMMENT '收货仓库ID',
+  `location_id` VARCHAR(36) COMMENT '收货库位ID',
+  `quarantine` TINYINT(1) DEFAULT 0 COMMENT '是否收货到隔离库位',
   `created_by` VARCHAR(36) COMMENT '创建人',
   `created_at` DATETIME NOT NULL COMMENT '创建时间',
   `updated_by` VARCHAR(36) COMMENT '更新人',
@@ -401,6 +437,10 @@ CREATE TABLE IF NOT EXISTS `sales_customer_credits` (
   `evaluation_date` DATE NOT NULL COMMENT '评估日期',
   `credit_score` INT NOT NULL COMMENT '信用评分',
   `risk_level` VARCHAR(20) NOT NULL COMMENT '风险等级（low, medium, high）',
+  `credit_limit` DECIMAL(18,2) DEFAULT 0 COMMENT '评估时信用额度',
+  `exposure` DECIMAL(18,2) DEFAULT 0 COMMENT '信用占用（未开票订单金额+未收发票余额）',
+  `overdue_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '逾期金额',
+  `overdue_days` INT DEFAULT 0 COMMENT '最长逾期天数',
   `remarks` TEXT COMMENT '备注',
   `created_by` VARCHAR(36) COMMENT '创建人',
   `created_at` DATETIME NOT NULL COMMENT '创建时间',
@@ -409,6 +449,125 @@ CREATE TABLE IF NOT EXISTS `sales_customer_credits` (
   FOREIGN KEY (`customer_id`) REFERENCES `sales_customers` (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='客户信用评估表';
 
+-- 2.15 销售价目表（sales_price_lists）
+CREATE TABLE IF NOT EXISTS `sales_price_lists` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '价目表ID',
+  `code` VARCHAR(20) UNIQUE NOT NULL COMMENT '价目表编码',
+  `name` VARCHAR(100) NOT NULL COMMENT '价目表名称',
+  `currency` VARCHAR(3) NOT NULL DEFAULT 'CNY' COMMENT '币种',
+  `customer_group` VARCHAR(50) COMMENT '客户分组（对应客户类别，为空表示所有客户）',
+  `valid_from` DATE NOT NULL COMMENT '生效日期',
+  `valid_to` DATE COMMENT '失效日期（为空表示长期有效）',
+  `priority` INT DEFAULT 0 COMMENT '优先级（数值越大越优先）',
+  `status` VARCHAR(20) DEFAULT 'active' COMMENT '状态（active, inactive）',
+  `remarks` TEXT COMMENT '备注',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='销售价目表';
+
+-- 2.16 销售价目表明细（sales_price_list_items）
+CREATE TABLE IF NOT EXISTS `sales_price_list_items` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '明细ID',
+  `price_list_id` VARCHAR(36) NOT NULL COMMENT '价目表ID',
+  `product_id` VARCHAR(36) NOT NULL COMMENT '产品ID',
+  `min_quantity` DECIMAL(18,4) DEFAULT 0 COMMENT '起订数量（阶梯价下限）',
+  `unit_price` DECIMAL(18,2) NOT NULL COMMENT '单价',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`price_list_id`) REFERENCES `sales_price_lists` (`id`),
+  FOREIGN KEY (`product_id`) REFERENCES `sales_products` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='销售价目表明细';
+
+-- 2.17 客户合同价表（sales_customer_prices）
+CREATE TABLE IF NOT EXISTS `sales_customer_prices` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '合同价ID',
+  `customer_id` VARCHAR(36) NOT NULL COMMENT '客户ID',
+  `product_id` VARCHAR(36) NOT NULL COMMENT '产品ID',
+  `currency` VARCHAR(3) NOT NULL DEFAULT 'CNY' COMMENT '币种',
+  `min_quantity` DECIMAL(18,4) DEFAULT 0 COMMENT '起订数量（阶梯价下限）',
+  `unit_price` DECIMAL(18,2) NOT NULL COMMENT '合同单价',
+  `valid_from` DATE NOT NULL COMMENT '生效日期',
+  `valid_to` DATE COMMENT '失效日期（为空表示长期有效）',
+  `contract_no` VARCHAR(50) COMMENT '合同编号',
+  `status` VARCHAR(20) DEFAULT 'active' COMMENT '状态（active, inactive）',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`customer_id`) REFERENCES `sales_customers` (`id`),
+  FOREIGN KEY (`product_id`) REFERENCES `sales_products` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='客户合同价表';
+
+-- 2.18 销售促销表（sales_promotions）
+CREATE TABLE IF NOT EXISTS `sales_promotions` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '促销ID',
+  `code` VARCHAR(20) UNIQUE NOT NULL COMMENT '促销编码',
+  `name` VARCHAR(100) NOT NULL COMMENT '促销名称',
+  `product_id` VARCHAR(36) COMMENT '产品ID（为空表示所有产品）',
+  `customer_group` VARCHAR(50) COMMENT '客户分组（为空表示所有客户）',
+  `min_quantity` DECIMAL(18,4) DEFAULT 0 COMMENT '起订数量',
+  `discount_percent` DECIMAL(5,2) NOT NULL COMMENT '折扣百分比',
+  `valid_from` DATE NOT NULL COMMENT '生效日期',
+  `valid_to` DATE COMMENT '失效日期（为空表示长期有效）',
+  `status` VARCHAR(20) DEFAULT 'active' COMMENT '状态（active, inactive）',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='销售促销表';
+
+-- 2.19 销售贷项通知单表（sales_credit_notes）
+CREATE TABLE IF NOT EXISTS `sales_credit_notes` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '贷项通知单ID',
+  `credit_note_no` VARCHAR(20) UNIQUE NOT NULL COMMENT '贷项通知单编号',
+  `return_id` VARCHAR(36) NOT NULL COMMENT '退货单ID',
+  `order_id` VARCHAR(36) NOT NULL COMMENT '订单ID',
+  `customer_id` VARCHAR(36) NOT NULL COMMENT '客户ID',
+  `invoice_id` VARCHAR(36) COMMENT '冲减的原发票ID',
+  `credit_date` DATE NOT NULL COMMENT '开具日期',
+  `total_amount` DECIMAL(18,2) NOT NULL COMMENT '价税合计',
+  `tax_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '税额',
+  `price_includes_tax` TINYINT(1) DEFAULT 0 COMMENT '单价是否含税',
+  `currency` VARCHAR(3) COMMENT '币种',
+  `exchange_rate` DECIMAL(18,6) DEFAULT 1 COMMENT '汇率（1单位外币折合本位币）',
+  `base_total_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '本位币价税合计',
+  `base_tax_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '本位币税额',
+  `applied_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '已冲减发票金额',
+  `status` VARCHAR(20) DEFAULT 'open' COMMENT '状态（open, applied）',
+  `remarks` TEXT COMMENT '备注',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`return_id`) REFERENCES `sales_returns` (`id`),
+  FOREIGN KEY (`customer_id`) REFERENCES `sales_customers` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='销售贷项通知单表';
+
+-- 2.20 销售贷项通知单明细表（sales_credit_note_items）
+CREATE TABLE IF NOT EXISTS `sales_credit_note_items` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '明细ID',
+  `credit_note_id` VARCHAR(36) NOT NULL COMMENT '贷项通知单ID',
+  `return_item_id` VARCHAR(36) COMMENT '退货单明细ID',
+  `product_id` VARCHAR(36) NOT NULL COMMENT '产品ID',
+  `quantity` DECIMAL(18,4) NOT NULL COMMENT '数量',
+  `unit_price` DECIMAL(18,2) NOT NULL COMMENT '单价',
+  `amount` DECIMAL(18,2) NOT NULL COMMENT '金额',
+  `tax_code_id` VARCHAR(36) COMMENT '税码ID',
+  `tax_rate` DECIMAL(7,4) DEFAULT 0 COMMENT '税率（%）',
+  `net_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '不含税金额',
+  `tax_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '税额',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`credit_note_id`) REFERENCES `sales_credit_notes` (`id`),
+  FOREIGN KEY (`product_id`) REFERENCES `sales_products` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='销售贷项通知单明细表';
+
 -- 3. 库存模块
 
 -- 3.1 仓库表（inventory_warehouses）
@@ -424,6 +583,7 @@ CREATE TABLE IF NOT EXISTS `inventory_warehouses` (
   `description` TEXT COMMENT '仓库描述',
   `capacity` INT DEFAULT 0 COMMENT '容量',
   `used_capacity` INT DEFAULT 0 COMMENT '已使用容量',
+  `allow_negative_stock` TINYINT(1) DEFAULT 0 COMMENT '是否允许负库存',
   `status` VARCHAR(20) DEFAULT 'active' COMMENT '状态（active, inactive）',
   `created_by` VARCHAR(36) COMMENT '创建人',
   `created_at` DATETIME NOT NULL COMMENT '创建时间',
@@ -437,7 +597,7 @@ CREATE TABLE IF NOT EXISTS `inventory_locations` (
   `warehouse_id` VARCHAR(36) NOT NULL COMMENT '仓库ID',
   `code` VARCHAR(20) NOT NULL COMMENT '库位编码',
   `name` VARCHAR(100) NOT NULL COMMENT '库位名称',
-  `type` VARCHAR(20) COMMENT '库位类型',
+  `type` VARCHAR(20) COMMENT '库位类型（quarantine为隔离库位）',
   `capacity` DECIMAL(18,4) COMMENT '容量',
   `used_capacity` DECIMAL(18,4) DEFAULT 0 COMMENT '已使用容量',
   `status` VARCHAR(20) DEFAULT 'available' COMMENT '状态（available, occupied, blocked）',
@@ -457,6 +617,9 @@ CREATE TABLE IF NOT EXISTS `inventory_items` (
   `category_id` VARCHAR(36) COMMENT '物料类别ID',
   `unit` VARCHAR(10) NOT NULL COMMENT '单位',
   `type` VARCHAR(20) NOT NULL COMMENT '物料类型（raw_material, finished_goods, semi_finished, tool）',
+  `cost_method` VARCHAR(20) DEFAULT 'moving_average' COMMENT '计价方法（moving_average, fifo, standard）',
+  `standard_cost` DECIMAL(18,2) DEFAULT 0 COMMENT '标准成本',
+  `tax_category` VARCHAR(20) DEFAULT 'standard' COMMENT '税收分类（standard, reduced, zero, exempt）',
   `status` VARCHAR(20) DEFAULT 'active' COMMENT '状态（active, inactive, obsolete）',
   `created_by` VARCHAR(36) COMMENT '创建人',
   `created_at` DATETIME NOT NULL COMMENT '创建时间',
@@ -484,12 +647,13 @@ CREATE TABLE IF NOT EXISTS `inventory_on_hand` (
   `warehouse_id` VARCHAR(36) NOT NULL COMMENT '仓库ID',
   `location_id` VARCHAR(36) COMMENT '库位ID',
   `quantity` DECIMAL(18,4) NOT NULL COMMENT '数量',
-  `unit_cost` DECIMAL(18,2) NOT NULL COMMENT '单位成本',
+  `unit_cost` DECIMAL(18,6) NOT NULL COMMENT '单位成本',
   `total_cost` DECIMAL(18,2) NOT NULL COMMENT '总成本',
   `created_by` VARCHAR(36) COMMENT '创建人',
   `created_at` DATETIME NOT NULL COMMENT '创建时间',
   `updated_by` VARCHAR(36) COMMENT '更新人',
   `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  UNIQUE KEY `idx_inventory_on_hand_location` (`item_id`, `warehouse_id`, `location_id`),
   FOREIGN KEY (`item_id`) REFERENCES `inventory_items` (`id`),
   FOREIGN KEY (`warehouse_id`) REFERENCES `inventory_warehouses` (`id`),
   FOREIGN KEY (`location_id`) REFERENCES `inventory_locations` (`id`)
@@ -504,8 +668,11 @@ CREATE TABLE IF NOT EXISTS `inventory_transactions` (
   `location_id` VARCHAR(36) COMMENT '库位ID',
   `type` VARCHAR(20) NOT NULL COMMENT '交易类型（purchase, sales, transfer, adjustment, production）',
   `quantity` DECIMAL(18,4) NOT NULL COMMENT '数量',
-  `unit_cost` DECIMAL(18,2) NOT NULL COMMENT '单位成本',
+  `unit_cost` DECIMAL(18,6) NOT NULL COMMENT '单位成本',
   `total_cost` DECIMAL(18,2) NOT NULL COMMENT '总成本',
+  `balance_quantity` DECIMAL(18,4) DEFAULT 0 COMMENT '交易后结存数量',
+  `balance_cost` DECIMAL(18,2) DEFAULT 0 COMMENT '交易后结存成本',
+  `cost_variance` DECIMAL(18,2) DEFAULT 0 COMMENT '成本差异（标准成本法）',
   `reference_type` VARCHAR(50) COMMENT '参考类型（purchase_order, sales_order, transfer_order, etc.）',
   `reference_id` VARCHAR(36) COMMENT '参考ID',
   `transaction_date` DATETIME NOT NULL COMMENT '交易日期',
@@ -543,7 +710,7 @@ CREATE TABLE IF NOT EXISTS `inventory_count_items` (
   `system_quantity` DECIMAL(18,4) NOT NULL COMMENT '系统数量',
   `actual_quantity` DECIMAL(18,4) NOT NULL COMMENT '实际数量',
   `variance_quantity` DECIMAL(18,4) NOT NULL COMMENT '差异数量',
-  `unit_cost` DECIMAL(18,2) NOT NULL COMMENT '单位成本',
+  `unit_cost` DECIMAL(18,6) NOT NULL COMMENT '单位成本',
   `variance_amount` DECIMAL(18,2) NOT NULL COMMENT '差异金额',
   `remarks` TEXT COMMENT '备注',
   `created_by` VARCHAR(36) COMMENT '创建人',
@@ -555,6 +722,141 @@ CREATE TABLE IF NOT EXISTS `inventory_count_items` (
   FOREIGN KEY (`location_id`) REFERENCES `inventory_locations` (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='库存盘点明细表';
 
+-- 3.9 库存成本层表（inventory_cost_layers）
+CREATE TABLE IF NOT EXISTS `inventory_cost_layers` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '成本层ID',
+  `item_id` VARCHAR(36) NOT NULL COMMENT '物料ID',
+  `warehouse_id` VARCHAR(36) NOT NULL COMMENT '仓库ID',
+  `location_id` VARCHAR(36) COMMENT '库位ID',
+  `transaction_id` VARCHAR(36) NOT NULL COMMENT '入库交易ID',
+  `layer_date` DATETIME NOT NULL COMMENT '入库日期',
+  `original_quantity` DECIMAL(18,4) NOT NULL COMMENT '入库数量',
+  `remaining_quantity` DECIMAL(18,4) NOT NULL COMMENT '剩余数量',
+  `unit_cost` DECIMAL(18,6) NOT NULL COMMENT '单位成本',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`item_id`) REFERENCES `inventory_items` (`id`),
+  FOREIGN KEY (`warehouse_id`) REFERENCES `inventory_warehouses` (`id`),
+  FOREIGN KEY (`transaction_id`) REFERENCES `inventory_transactions` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='库存成本层表';
+
+-- 3.10 库存预留表（inventory_reservations）
+CREATE TABLE IF NOT EXISTS `inventory_reservations` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '预留ID',
+  `item_id` VARCHAR(36) NOT NULL COMMENT '物料ID',
+  `warehouse_id` VARCHAR(36) COMMENT '仓库ID，软预留可为空',
+  `type` VARCHAR(20) NOT NULL COMMENT '预留类型（hard, soft）',
+  `source_type` VARCHAR(30) NOT NULL COMMENT '来源类型（sales_order）',
+  `source_id` VARCHAR(36) NOT NULL COMMENT '来源单据ID',
+  `source_item_id` VARCHAR(36) NOT NULL COMMENT '来源明细ID',
+  `source_no` VARCHAR(50) COMMENT '来源单据编号',
+  `quantity` DECIMAL(18,4) NOT NULL COMMENT '预留数量',
+  `consumed_quantity` DECIMAL(18,4) DEFAULT 0 COMMENT '已核销数量',
+  `required_date` DATE COMMENT '需求日期',
+  `status` VARCHAR(20) DEFAULT 'active' COMMENT '状态（active, consumed, released）',
+  `released_at` DATETIME COMMENT '释放时间',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`item_id`) REFERENCES `inventory_items` (`id`),
+  FOREIGN KEY (`warehouse_id`) REFERENCES `inventory_warehouses` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='库存预留表';
+
+-- 3.11 补货参数表（inventory_replenishment_rules）
+CREATE TABLE IF NOT EXISTS `inventory_replenishment_rules` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '补货参数ID',
+  `item_id` VARCHAR(36) NOT NULL COMMENT '物料ID',
+  `warehouse_id` VARCHAR(36) NOT NULL COMMENT '仓库ID',
+  `method` VARCHAR(20) NOT NULL COMMENT '补货方法（reorder_point, min_max）',
+  `reorder_point` DECIMAL(18,4) DEFAULT 0 COMMENT '再订货点',
+  `safety_stock` DECIMAL(18,4) DEFAULT 0 COMMENT '安全库存',
+  `min_quantity` DECIMAL(18,4) DEFAULT 0 COMMENT '最小库存',
+  `max_quantity` DECIMAL(18,4) DEFAULT 0 COMMENT '最大库存',
+  `reorder_quantity` DECIMAL(18,4) DEFAULT 0 COMMENT '再订货批量',
+  `multiple_quantity` DECIMAL(18,4) DEFAULT 0 COMMENT '补货批量倍数',
+  `preferred_vendor_id` VARCHAR(36) COMMENT '首选供应商ID',
+  `source_warehouse_id` VARCHAR(36) COMMENT '供货仓库ID',
+  `lead_time_days` INT DEFAULT 0 COMMENT '补货提前期（天）',
+  `status` VARCHAR(20) DEFAULT 'active' COMMENT '状态（active, inactive）',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`item_id`) REFERENCES `inventory_items` (`id`),
+  FOREIGN KEY (`warehouse_id`) REFERENCES `inventory_warehouses` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='补货参数表';
+
+-- 3.12 补货运行表（inventory_replenishment_runs）
+CREATE TABLE IF NOT EXISTS `inventory_replenishment_runs` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '补货运行ID',
+  `run_no` VARCHAR(20) UNIQUE NOT NULL COMMENT '运行编号',
+  `trigger_type` VARCHAR(20) NOT NULL COMMENT '触发方式（manual, scheduled）',
+  `rule_count` INT DEFAULT 0 COMMENT '补货参数数',
+  `purchase_line_count` INT DEFAULT 0 COMMENT '请购行数',
+  `transfer_count` INT DEFAULT 0 COMMENT '调拨申请数',
+  `requisition_id` VARCHAR(36) COMMENT '生成的采购申请ID',
+  `started_at` DATETIME NOT NULL COMMENT '开始时间',
+  `finished_at` DATETIME NOT NULL COMMENT '结束时间',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='补货运行表';
+
+-- 3.13 补货运行明细表（inventory_replenishment_run_lines）
+CREATE TABLE IF NOT EXISTS `inventory_replenishment_run_lines` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '明细ID',
+  `run_id` VARCHAR(36) NOT NULL COMMENT '补货运行ID',
+  `rule_id` VARCHAR(36) NOT NULL COMMENT '补货参数ID',
+  `item_id` VARCHAR(36) NOT NULL COMMENT '物料ID',
+  `warehouse_id` VARCHAR(36) NOT NULL COMMENT '仓库ID',
+  `method` VARCHAR(20) NOT NULL COMMENT '补货方法',
+  `on_hand_quantity` DECIMAL(18,4) DEFAULT 0 COMMENT '现存量',
+  `reserved_quantity` DECIMAL(18,4) DEFAULT 0 COMMENT '预留量',
+  `scheduled_receipts` DECIMAL(18,4) DEFAULT 0 COMMENT '计划入库',
+  `open_requisitions` DECIMAL(18,4) DEFAULT 0 COMMENT '未转订单请购数量',
+  `inbound_transfers` DECIMAL(18,4) DEFAULT 0 COMMENT '调入在途',
+  `outbound_transfers` DECIMAL(18,4) DEFAULT 0 COMMENT '调出待发',
+  `projected_quantity` DECIMAL(18,4) DEFAULT 0 COMMENT '预计可用量',
+  `trigger_level` DECIMAL(18,4) DEFAULT 0 COMMENT '触发水位',
+  `target_level` DECIMAL(18,4) DEFAULT 0 COMMENT '目标水位',
+  `action` VARCHAR(20) NOT NULL COMMENT '处理结果（none, transfer, purchase, transfer_purchase, skipped）',
+  `transfer_quantity` DECIMAL(18,4) DEFAULT 0 COMMENT '调拨数量',
+  `purchase_quantity` DECIMAL(18,4) DEFAULT 0 COMMENT '请购数量',
+  `source_warehouse_id` VARCHAR(36) COMMENT '供货仓库ID',
+  `vendor_id` VARCHAR(36) COMMENT '供应商ID',
+  `transfer_request_id` VARCHAR(36) COMMENT '调拨申请ID',
+  `requisition_item_id` VARCHAR(36) COMMENT '采购申请明细ID',
+  `explanation` TEXT COMMENT '计算说明',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  FOREIGN KEY (`run_id`) REFERENCES `inventory_replenishment_runs` (`id`),
+  FOREIGN KEY (`item_id`) REFERENCES `inventory_items` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='补货运行明细表';
+
+-- 3.14 调拨申请表（inventory_transfer_requests）
+CREATE TABLE IF NOT EXISTS `inventory_transfer_requests` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '调拨申请ID',
+  `request_no` VARCHAR(20) UNIQUE NOT NULL COMMENT '申请编号',
+  `item_id` VARCHAR(36) NOT NULL COMMENT '物料ID',
+  `from_warehouse_id` VARCHAR(36) NOT NULL COMMENT '调出仓库ID',
+  `to_warehouse_id` VARCHAR(36) NOT NULL COMMENT '调入仓库ID',
+  `quantity` DECIMAL(18,4) NOT NULL COMMENT '调拨数量',
+  `required_date` DATE NOT NULL COMMENT '需求日期',
+  `status` VARCHAR(20) DEFAULT 'pending' COMMENT '状态（pending, completed, cancelled）',
+  `source_type` VARCHAR(30) COMMENT '来源类型（replenishment）',
+  `source_id` VARCHAR(36) COMMENT '来源单据ID',
+  `transaction_no` VARCHAR(20) COMMENT '调拨交易编号',
+  `remarks` TEXT COMMENT '备注',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`item_id`) REFERENCES `inventory_items` (`id`),
+  FOREIGN KEY (`from_warehouse_id`) REFERENCES `inventory_warehouses` (`id`),
+  FOREIGN KEY (`to_warehouse_id`) REFERENCES `inventory_warehouses` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='调拨申请表';
+
 -- 4. 采购模块
 
 -- 4.1 供应商表（purchase_vendors）
@@ -572,7 +874,9 @@ CREATE TABLE IF NOT EXISTS `purchase_vendors` (
   `category` VARCHAR(50) COMMENT '供应商类别',
   `credit_limit` DECIMAL(18,2) DEFAULT 0 COMMENT '信用额度',
   `lead_time` INT DEFAULT 0 COMMENT '前置时间（天）',
+  `tax_status` VARCHAR(20) DEFAULT 'taxable' COMMENT '纳税状态（taxable, small_scale, exempt）',
   `payment_terms` VARCHAR(50) COMMENT '付款条件',
+  `currency` VARCHAR(3) COMMENT '默认交易币种（为空表示本位币）',
   `remarks` TEXT COMMENT '备注',
   `status` VARCHAR(20) DEFAULT 'active' COMMENT '状态（active, inactive）',
   `created_by` VARCHAR(36) COMMENT '创建人',
@@ -590,7 +894,7 @@ CREATE TABLE IF NOT EXISTS `purchase_plans` (
   `start_date` DATE NOT NULL COMMENT '开始日期',
   `end_date` DATE NOT NULL COMMENT '结束日期',
   `total_amount` DECIMAL(18,2) NOT NULL COMMENT '总金额',
-  `status` VARCHAR(20) DEFAULT 'draft' COMMENT '状态（draft, approved, executed, cancelled）',
+  `status` VARCHAR(20) DEFAULT 'draft' COMMENT '状态（draft, approved, partially_ordered, ordered）',
   `created_by` VARCHAR(36) COMMENT '创建人',
   `created_at` DATETIME NOT NULL COMMENT '创建时间',
   `updated_by` VARCHAR(36) COMMENT '更新人',
@@ -602,7 +906,9 @@ CREATE TABLE IF NOT EXISTS `purchase_plan_items` (
   `id` VARCHAR(36) PRIMARY KEY COMMENT '明细ID',
   `plan_id` VARCHAR(36) NOT NULL COMMENT '计划ID',
   `item_id` VARCHAR(36) NOT NULL COMMENT '物料ID',
+  `vendor_id` VARCHAR(36) COMMENT '建议供应商ID',
   `quantity` DECIMAL(18,4) NOT NULL COMMENT '数量',
+  `ordered_quantity` DECIMAL(18,4) DEFAULT 0 COMMENT '已转采购订单数量',
   `estimated_price` DECIMAL(18,2) NOT NULL COMMENT '预估价格',
   `estimated_amount` DECIMAL(18,2) NOT NULL COMMENT '预估金额',
   `need_date` DATE NOT NULL COMMENT '需求日期',
@@ -622,6 +928,9 @@ CREATE TABLE IF NOT EXISTS `purchase_orders` (
   `order_date` DATE NOT NULL COMMENT '订单日期',
   `delivery_date` DATE COMMENT '交货日期',
   `total_amount` DECIMAL(18,2) NOT NULL COMMENT '总金额',
+  `currency` VARCHAR(3) COMMENT '币种',
+  `exchange_rate` DECIMAL(18,6) DEFAULT 1 COMMENT '汇率（1单位外币折合本位币）',
+  `base_total_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '本位币总金额',
   `status` VARCHAR(20) DEFAULT 'pending' COMMENT '状态（pending, approved, delivered, invoiced, cancelled）',
   `payment_terms` VARCHAR(50) COMMENT '付款条件',
   `remarks` TEXT COMMENT '备注',
@@ -642,6 +951,9 @@ CREATE TABLE IF NOT EXISTS `purchase_order_items` (
   `discount` DECIMAL(18,2) DEFAULT 0 COMMENT '折扣',
   `amount` DECIMAL(18,2) NOT NULL COMMENT '金额',
   `received_quantity` DECIMAL(18,4) DEFAULT 0 COMMENT '已收货数量',
+  `warehouse_id` VARCHAR(36) COMMENT '计划入库仓库ID',
+  `requisition_item_id` VARCHAR(36) COMMENT '来源采购申请明细ID',
+  `plan_item_id` VARCHAR(36) COMMENT '来源采购计划明细ID',
   `created_by` VARCHAR(36) COMMENT '创建人',
   `created_at` DATETIME NOT NULL COMMENT '创建时间',
   `updated_by` VARCHAR(36) COMMENT '更新人',
@@ -700,8 +1012,19 @@ CREATE TABLE IF NOT EXISTS `purchase_invoices` (
   `invoice_date` DATE NOT NULL COMMENT '开票日期',
   `due_date` DATE NOT NULL COMMENT '到期日期',
   `total_amount` DECIMAL(18,2) NOT NULL COMMENT '总金额',
+  `tax_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '税额',
+  `price_includes_tax` TINYINT(1) DEFAULT 0 COMMENT '单价是否含税',
+  `currency` VARCHAR(3) COMMENT '币种',
+  `exchange_rate` DECIMAL(18,6) DEFAULT 1 COMMENT '汇率（1单位外币折合本位币）',
+  `base_total_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '本位币价税合计',
+  `base_tax_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '本位币税额',
   `paid_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '已付金额',
-  `status` VARCHAR(20) DEFAULT 'unpaid' COMMENT '状态（unpaid, partially_paid, paid, cancelled）',
+  `discount_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '已享受提前付款折扣金额',
+  `status` VARCHAR(20) DEFAULT 'unpaid' COMMENT '状态（unpaid, blocked, verified, partially_paid, paid, cancelled）',
+  `block_reason` VARCHAR(500) COMMENT '三单匹配冻结原因',
+  `block_released_by` VARCHAR(36) COMMENT '冻结放行人',
+  `block_released_at` DATETIME COMMENT '冻结放行时间',
+  `block_release_reason` VARCHAR(255) COMMENT '冻结放行原因',
   `remarks` TEXT COMMENT '备注',
   `created_by` VARCHAR(36) COMMENT '创建人',
   `created_at` DATETIME NOT NULL COMMENT '创建时间',
@@ -715,11 +1038,16 @@ CREATE TABLE IF NOT EXISTS `purchase_invoices` (
 CREATE TABLE IF NOT EXISTS `purchase_invoice_items` (
   `id` VARCHAR(36) PRIMARY KEY COMMENT '明细ID',
   `invoice_id` VARCHAR(36) NOT NULL COMMENT '发票ID',
+  `order_item_id` VARCHAR(36) COMMENT '采购订单明细ID',
   `item_id` VARCHAR(36) NOT NULL COMMENT '物料ID',
   `quantity` DECIMAL(18,4) NOT NULL COMMENT '数量',
   `unit_price` DECIMAL(18,2) NOT NULL COMMENT '单价',
   `discount` DECIMAL(18,2) DEFAULT 0 COMMENT '折扣',
   `amount` DECIMAL(18,2) NOT NULL COMMENT '金额',
+  `tax_code_id` VARCHAR(36) COMMENT '税码ID',
+  `tax_rate` DECIMAL(7,4) DEFAULT 0 COMMENT '税率（%）',
+  `net_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '不含税金额',
+  `tax_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '税额',
   `created_by` VARCHAR(36) COMMENT '创建人',
   `created_at` DATETIME NOT NULL COMMENT '创建时间',
   `updated_by` VARCHAR(36) COMMENT '更新人',
@@ -765,6 +1093,124 @@ CREATE TABLE IF NOT EXISTS `purchase_return_items` (
   FOREIGN KEY (`warehouse_id`) REFERENCES `inventory_warehouses` (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='采购退货单明细表';
 
+-- 4.12 采购发票匹配差异表（purchase_invoice_discrepancies）
+CREATE TABLE IF NOT EXISTS `purchase_invoice_discrepancies` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '差异ID',
+  `invoice_id` VARCHAR(36) NOT NULL COMMENT '发票ID',
+  `invoice_item_id` VARCHAR(36) COMMENT '发票明细ID',
+  `order_item_id` VARCHAR(36) COMMENT '采购订单明细ID',
+  `type` VARCHAR(20) NOT NULL COMMENT '差异类型（price, quantity, unmatched, no_items）',
+  `expected` DECIMAL(18,4) DEFAULT 0 COMMENT '预期值（订单单价或待开票数量）',
+  `actual` DECIMAL(18,4) DEFAULT 0 COMMENT '发票值',
+  `variance` DECIMAL(18,4) DEFAULT 0 COMMENT '差异',
+  `variance_percent` DECIMAL(9,4) DEFAULT 0 COMMENT '差异百分比',
+  `tolerance` DECIMAL(9,4) DEFAULT 0 COMMENT '容差百分比',
+  `reason` VARCHAR(255) COMMENT '差异原因',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`invoice_id`) REFERENCES `purchase_invoices` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='采购发票匹配差异表';
+
+-- 4.13 采购申请表（purchase_requisitions）
+CREATE TABLE IF NOT EXISTS `purchase_requisitions` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '申请ID',
+  `requisition_no` VARCHAR(20) UNIQUE NOT NULL COMMENT '申请编号',
+  `department_id` VARCHAR(36) NOT NULL COMMENT '申请部门ID',
+  `applicant_id` VARCHAR(36) NOT NULL COMMENT '申请人ID',
+  `application_date` DATE NOT NULL COMMENT '申请日期',
+  `estimated_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '预估金额',
+  `status` VARCHAR(20) DEFAULT 'pending' COMMENT '状态（pending, submitted, approved, rejected, partially_ordered, ordered）',
+  `reason` TEXT COMMENT '申请原因',
+  `remarks` TEXT COMMENT '备注',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='采购申请表';
+
+-- 4.14 采购申请明细表（purchase_requisition_items）
+CREATE TABLE IF NOT EXISTS `purchase_requisition_items` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '明细ID',
+  `requisition_id` VARCHAR(36) NOT NULL COMMENT '申请ID',
+  `item_id` VARCHAR(36) NOT NULL COMMENT '物料ID',
+  `vendor_id` VARCHAR(36) COMMENT '建议供应商ID',
+  `warehouse_id` VARCHAR(36) COMMENT '收货仓库ID',
+  `quantity` DECIMAL(18,4) NOT NULL COMMENT '申请数量',
+  `ordered_quantity` DECIMAL(18,4) DEFAULT 0 COMMENT '已转采购订单数量',
+  `estimated_price` DECIMAL(18,2) DEFAULT 0 COMMENT '预估单价',
+  `estimated_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '预估金额',
+  `required_date` DATE NOT NULL COMMENT '需求日期',
+  `purpose` VARCHAR(255) COMMENT '用途',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`requisition_id`) REFERENCES `purchase_requisitions` (`id`),
+  FOREIGN KEY (`item_id`) REFERENCES `inventory_items` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='采购申请明细表';
+
+-- 4.15 供应商评分卡表（purchase_vendor_scorecards）
+CREATE TABLE IF NOT EXISTS `purchase_vendor_scorecards` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '评分卡ID',
+  `vendor_id` VARCHAR(36) NOT NULL COMMENT '供应商ID',
+  `period_start` DATE NOT NULL COMMENT '评估期间开始日期',
+  `period_end` DATE NOT NULL COMMENT '评估期间截止日期',
+  `receipt_count` INT DEFAULT 0 COMMENT '已完成收货单数',
+  `on_time_count` INT DEFAULT 0 COMMENT '准时收货单数',
+  `received_quantity` DECIMAL(18,4) DEFAULT 0 COMMENT '收货数量',
+  `returned_quantity` DECIMAL(18,4) DEFAULT 0 COMMENT '退货数量',
+  `invoiced_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '已匹配发票行按订单单价计算的金额',
+  `price_variance_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '发票超出订单单价的金额',
+  `avg_lead_time_days` DECIMAL(9,2) DEFAULT 0 COMMENT '平均实际交货天数',
+  `promised_lead_time_days` DECIMAL(9,2) DEFAULT 0 COMMENT '平均承诺交货天数',
+  `on_time_rate` DECIMAL(7,2) DEFAULT 0 COMMENT '准时交货率（%）',
+  `quality_reject_rate` DECIMAL(7,2) DEFAULT 0 COMMENT '质量退货率（%）',
+  `price_variance` DECIMAL(9,2) DEFAULT 0 COMMENT '价格偏差（%）',
+  `lead_time_accuracy` DECIMAL(7,2) DEFAULT 0 COMMENT '交期准确率（%）',
+  `overall_rating` DECIMAL(7,2) DEFAULT 0 COMMENT '综合评分',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`vendor_id`) REFERENCES `purchase_vendors` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='供应商评分卡表';
+
+-- 4.16 采购价格历史表（purchase_price_histories）
+CREATE TABLE IF NOT EXISTS `purchase_price_histories` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '价格历史ID',
+  `vendor_id` VARCHAR(36) NOT NULL COMMENT '供应商ID',
+  `item_id` VARCHAR(36) NOT NULL COMMENT '物料ID',
+  `source_type` VARCHAR(20) NOT NULL COMMENT '来源类型（order, invoice）',
+  `source_id` VARCHAR(36) NOT NULL COMMENT '来源单据ID',
+  `source_item_id` VARCHAR(36) COMMENT '来源明细ID',
+  `source_no` VARCHAR(50) COMMENT '来源单据编号',
+  `quantity` DECIMAL(18,4) NOT NULL COMMENT '数量',
+  `unit_price` DECIMAL(18,2) NOT NULL COMMENT '不含税折扣后单价（单据币种）',
+  `currency` VARCHAR(3) COMMENT '币种',
+  `exchange_rate` DECIMAL(18,6) DEFAULT 1 COMMENT '汇率',
+  `base_unit_price` DECIMAL(18,2) NOT NULL COMMENT '折合本位币单价',
+  `price_date` DATE NOT NULL COMMENT '价格日期',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  FOREIGN KEY (`vendor_id`) REFERENCES `purchase_vendors` (`id`),
+  FOREIGN KEY (`item_id`) REFERENCES `inventory_items` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='采购价格历史表';
+
+-- 4.17 采购目标价表（purchase_target_prices）
+CREATE TABLE IF NOT EXISTS `purchase_target_prices` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '目标价ID',
+  `item_id` VARCHAR(36) NOT NULL COMMENT '物料ID',
+  `target_price` DECIMAL(18,2) NOT NULL COMMENT '目标单价（本位币）',
+  `remarks` TEXT COMMENT '备注',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`item_id`) REFERENCES `inventory_items` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='采购目标价表';
+
 -- 5. 财务模块
 
 -- 5.1 会计科目表（finance_accounts）
@@ -776,6 +1222,7 @@ CREATE TABLE IF NOT EXISTS `finance_accounts` (
   `level` INT NOT NULL COMMENT '科目级别',
   `parent_id` VARCHAR(36) COMMENT '父科目ID',
   `category` VARCHAR(50) COMMENT '科目类别',
+  `cash_flow_category` VARCHAR(20) COMMENT '现金流量分类（cash, operating, investing, financing）',
   `description` TEXT COMMENT '科目描述',
   `status` VARCHAR(20) DEFAULT 'active' COMMENT '状态（active, inactive）',
   `created_by` VARCHAR(36) COMMENT '创建人',
@@ -791,10 +1238,22 @@ CREATE TABLE IF NOT EXISTS `finance_journals` (
   `journal_no` VARCHAR(20) UNIQUE NOT NULL COMMENT '凭证编号',
   `date` DATE NOT NULL COMMENT '凭证日期',
   `reference` VARCHAR(100) COMMENT '参考号',
+  `reference_type` VARCHAR(50) COMMENT '来源单据类型',
+  `reference_id` VARCHAR(36) COMMENT '来源单据ID',
+  `reversal_of` VARCHAR(36) COMMENT '被冲销凭证ID',
   `description` TEXT COMMENT '凭证描述',
+  `currency` VARCHAR(3) COMMENT '币种',
+  `exchange_rate` DECIMAL(18,6) DEFAULT 1 COMMENT '汇率（1单位外币折合本位币）',
   `total_debit` DECIMAL(18,2) NOT NULL COMMENT '借方合计',
   `total_credit` DECIMAL(18,2) NOT NULL COMMENT '贷方合计',
-  `status` VARCHAR(20) DEFAULT 'draft' COMMENT '状态（draft, posted, cancelled）',
+  `status` VARCHAR(20) DEFAULT 'draft' COMMENT '状态（draft, submitted, approved, posted, rejected）',
+  `remarks` TEXT COMMENT '备注',
+  `submitted_by` VARCHAR(36) COMMENT '提交人',
+  `submitted_at` DATETIME COMMENT '提交时间',
+  `approved_by` VARCHAR(36) COMMENT '审批人',
+  `approved_at` DATETIME COMMENT '审批时间',
+  `posted_by` VARCHAR(36) COMMENT '过账人',
+  `posted_at` DATETIME COMMENT '过账时间',
   `created_by` VARCHAR(36) COMMENT '创建人',
   `created_at` DATETIME NOT NULL COMMENT '创建时间',
   `updated_by` VARCHAR(36) COMMENT '更新人',
@@ -805,10 +1264,12 @@ CREATE TABLE IF NOT EXISTS `finance_journals` (
 CREATE TABLE IF NOT EXISTS `finance_journal_items` (
   `id` VARCHAR(36) PRIMARY KEY COMMENT '明细ID',
   `journal_id` VARCHAR(36) NOT NULL COMMENT '凭证ID',
+  `line_no` INT NOT NULL COMMENT '行号',
   `account_id` VARCHAR(36) NOT NULL COMMENT '科目ID',
   `description` TEXT COMMENT '明细描述',
   `debit` DECIMAL(18,2) DEFAULT 0 COMMENT '借方金额',
   `credit` DECIMAL(18,2) DEFAULT 0 COMMENT '贷方金额',
+  `currency_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '原币金额',
   `created_by` VARCHAR(36) COMMENT '创建人',
   `created_at` DATETIME NOT NULL COMMENT '创建时间',
   `updated_by` VARCHAR(36) COMMENT '更新人',
@@ -882,6 +1343,293 @@ CREATE TABLE IF NOT EXISTS `finance_cost_centers` (
   `updated_at` DATETIME NOT NULL COMMENT '更新时间'
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='成本中心表';
 
+-- 5.8 会计年度表（finance_fiscal_years）
+CREATE TABLE IF NOT EXISTS `finance_fiscal_years` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '会计年度ID',
+  `year` INT UNIQUE NOT NULL COMMENT '年度',
+  `name` VARCHAR(50) NOT NULL COMMENT '年度名称',
+  `start_date` DATE NOT NULL COMMENT '开始日期',
+  `end_date` DATE NOT NULL COMMENT '结束日期',
+  `status` VARCHAR(20) DEFAULT 'open' COMMENT '状态（open, closed）',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='会计年度表';
+
+-- 5.9 会计期间表（finance_periods）
+CREATE TABLE IF NOT EXISTS `finance_periods` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '会计期间ID',
+  `fiscal_year_id` VARCHAR(36) NOT NULL COMMENT '会计年度ID',
+  `period_no` INT NOT NULL COMMENT '期间序号',
+  `name` VARCHAR(20) NOT NULL COMMENT '期间名称',
+  `start_date` DATE NOT NULL COMMENT '开始日期',
+  `end_date` DATE NOT NULL COMMENT '结束日期',
+  `status` VARCHAR(20) DEFAULT 'open' COMMENT '状态（open, soft_closed, closed）',
+  `closing_journal_id` VARCHAR(36) COMMENT '期末结转凭证ID',
+  `closed_by` VARCHAR(36) COMMENT '结账人',
+  `closed_at` DATETIME COMMENT '结账时间',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`fiscal_year_id`) REFERENCES `finance_fiscal_years` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='会计期间表';
+
+-- 5.10 自动记账规则表（finance_posting_rules）
+CREATE TABLE IF NOT EXISTS `finance_posting_rules` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '记账规则ID',
+  `document_type` VARCHAR(50) NOT NULL COMMENT '单据类型（sales_invoice, purchase_invoice, inventory_transaction）',
+  `event` VARCHAR(50) NOT NULL COMMENT '业务事件',
+  `sequence` INT DEFAULT 0 COMMENT '分录顺序',
+  `amount_field` VARCHAR(20) NOT NULL COMMENT '金额字段',
+  `debit_account_code` VARCHAR(20) NOT NULL COMMENT '借方科目编码',
+  `credit_account_code` VARCHAR(20) NOT NULL COMMENT '贷方科目编码',
+  `description` VARCHAR(200) COMMENT '分录摘要',
+  `status` VARCHAR(20) DEFAULT 'active' COMMENT '状态（active, inactive）',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='自动记账规则表';
+
+-- 5.11 税码表（finance_tax_codes）
+CREATE TABLE IF NOT EXISTS `finance_tax_codes` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '税码ID',
+  `code` VARCHAR(20) UNIQUE NOT NULL COMMENT '税码',
+  `name` VARCHAR(100) NOT NULL COMMENT '税码名称',
+  `description` VARCHAR(200) COMMENT '描述',
+  `status` VARCHAR(20) DEFAULT 'active' COMMENT '状态（active, inactive）',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='税码表';
+
+-- 5.12 税率表（finance_tax_rates）
+CREATE TABLE IF NOT EXISTS `finance_tax_rates` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '税率ID',
+  `tax_code_id` VARCHAR(36) NOT NULL COMMENT '税码ID',
+  `rate` DECIMAL(7,4) NOT NULL COMMENT '税率（%）',
+  `valid_from` DATE NOT NULL COMMENT '生效日期',
+  `valid_to` DATE COMMENT '失效日期（为空表示长期有效）',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`tax_code_id`) REFERENCES `finance_tax_codes` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='税率表';
+
+-- 5.13 税码确定规则表（finance_tax_rules）
+CREATE TABLE IF NOT EXISTS `finance_tax_rules` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '规则ID',
+  `direction` VARCHAR(20) NOT NULL COMMENT '业务方向（sales, purchase）',
+  `party_tax_status` VARCHAR(20) COMMENT '往来单位纳税状态（为空表示不限）',
+  `item_tax_category` VARCHAR(20) COMMENT '物料税收分类（为空表示不限）',
+  `tax_code_id` VARCHAR(36) NOT NULL COMMENT '税码ID',
+  `priority` INT DEFAULT 0 COMMENT '优先级（数值越大越优先）',
+  `status` VARCHAR(20) DEFAULT 'active' COMMENT '状态（active, inactive）',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`tax_code_id`) REFERENCES `finance_tax_codes` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='税码确定规则表';
+
+-- 5.14 币种表（finance_currencies）
+CREATE TABLE IF NOT EXISTS `finance_currencies` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '币种ID',
+  `code` VARCHAR(3) UNIQUE NOT NULL COMMENT '币种代码（ISO 4217）',
+  `name` VARCHAR(50) NOT NULL COMMENT '币种名称',
+  `symbol` VARCHAR(10) COMMENT '货币符号',
+  `status` VARCHAR(20) DEFAULT 'active' COMMENT '状态（active, inactive）',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='币种表';
+
+-- 5.15 汇率表（finance_exchange_rates）
+CREATE TABLE IF NOT EXISTS `finance_exchange_rates` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '汇率ID',
+  `currency_code` VARCHAR(3) NOT NULL COMMENT '币种代码',
+  `rate_date` DATE NOT NULL COMMENT '汇率日期',
+  `rate` DECIMAL(18,6) NOT NULL COMMENT '汇率（1单位外币折合本位币）',
+  `source` VARCHAR(20) DEFAULT 'manual' COMMENT '来源（manual, import）',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  UNIQUE KEY `idx_exchange_rate_date` (`currency_code`, `rate_date`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='汇率表';
+
+-- 5.16 汇兑重估表（finance_fx_revaluations）
+CREATE TABLE IF NOT EXISTS `finance_fx_revaluations` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '重估ID',
+  `revaluation_no` VARCHAR(30) UNIQUE NOT NULL COMMENT '重估编号',
+  `period_id` VARCHAR(36) UNIQUE NOT NULL COMMENT '会计期间ID',
+  `revaluation_date` DATE NOT NULL COMMENT '重估日期',
+  `receivable_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '应收汇兑差额',
+  `payable_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '应付汇兑差额',
+  `journal_id` VARCHAR(36) COMMENT '重估凭证ID',
+  `reversal_journal_id` VARCHAR(36) COMMENT '冲回凭证ID',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`period_id`) REFERENCES `finance_periods` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='汇兑重估表';
+
+-- 5.17 汇兑重估明细表（finance_fx_revaluation_items）
+CREATE TABLE IF NOT EXISTS `finance_fx_revaluation_items` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '明细ID',
+  `revaluation_id` VARCHAR(36) NOT NULL COMMENT '重估ID',
+  `document_type` VARCHAR(50) NOT NULL COMMENT '单据类型（sales_invoice, purchase_invoice）',
+  `document_id` VARCHAR(36) NOT NULL COMMENT '单据ID',
+  `document_no` VARCHAR(20) COMMENT '单据编号',
+  `currency` VARCHAR(3) NOT NULL COMMENT '币种',
+  `open_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '未结清原币金额',
+  `original_rate` DECIMAL(18,6) DEFAULT 0 COMMENT '原汇率',
+  `revaluation_rate` DECIMAL(18,6) DEFAULT 0 COMMENT '期末汇率',
+  `carrying_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '账面本位币金额',
+  `revalued_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '重估后本位币金额',
+  `difference` DECIMAL(18,2) DEFAULT 0 COMMENT '汇兑差额',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`revaluation_id`) REFERENCES `finance_fx_revaluations` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='汇兑重估明细表';
+
+-- 5.18 客户收款单表（finance_receipts）
+CREATE TABLE IF NOT EXISTS `finance_receipts` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '收款单ID',
+  `receipt_no` VARCHAR(20) UNIQUE NOT NULL COMMENT '收款单编号',
+  `receipt_date` DATE NOT NULL COMMENT '收款日期',
+  `customer_id` VARCHAR(36) NOT NULL COMMENT '客户ID',
+  `amount` DECIMAL(18,2) NOT NULL COMMENT '收款金额',
+  `currency` VARCHAR(3) COMMENT '币种',
+  `exchange_rate` DECIMAL(18,6) DEFAULT 1 COMMENT '汇率（1单位外币折合本位币）',
+  `base_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '本位币收款金额',
+  `applied_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '已核销金额',
+  `payment_method` VARCHAR(50) COMMENT '收款方式',
+  `bank_account_id` VARCHAR(36) COMMENT '收款账户ID',
+  `reference` VARCHAR(100) COMMENT '参考号',
+  `description` TEXT COMMENT '摘要',
+  `remarks` TEXT COMMENT '备注',
+  `status` VARCHAR(20) DEFAULT 'draft' COMMENT '状态（draft, submitted, approved, rejected, processed）',
+  `processed_by` VARCHAR(36) COMMENT '处理人',
+  `processed_at` DATETIME COMMENT '处理时间',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`customer_id`) REFERENCES `sales_customers` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='客户收款单表';
+
+-- 5.19 收款核销明细表（finance_receipt_allocations）
+CREATE TABLE IF NOT EXISTS `finance_receipt_allocations` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '明细ID',
+  `receipt_id` VARCHAR(36) NOT NULL COMMENT '收款单ID',
+  `invoice_id` VARCHAR(36) NOT NULL COMMENT '销售发票ID',
+  `allocation_date` DATE NOT NULL COMMENT '核销日期',
+  `amount` DECIMAL(18,2) DEFAULT 0 COMMENT '核销收款金额',
+  `write_off_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '坏账核销金额',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`receipt_id`) REFERENCES `finance_receipts` (`id`),
+  FOREIGN KEY (`invoice_id`) REFERENCES `sales_invoices` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='收款核销明细表';
+
+-- 5.20 供应商付款单表（finance_payments）
+CREATE TABLE IF NOT EXISTS `finance_payments` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '付款单ID',
+  `payment_no` VARCHAR(20) UNIQUE NOT NULL COMMENT '付款单编号',
+  `payment_date` DATE NOT NULL COMMENT '付款日期',
+  `vendor_id` VARCHAR(36) NOT NULL COMMENT '供应商ID',
+  `amount` DECIMAL(18,2) NOT NULL COMMENT '付款金额',
+  `currency` VARCHAR(3) COMMENT '币种',
+  `exchange_rate` DECIMAL(18,6) DEFAULT 1 COMMENT '汇率（1单位外币折合本位币）',
+  `base_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '本位币付款金额',
+  `applied_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '已核销金额',
+  `payment_method` VARCHAR(50) COMMENT '付款方式',
+  `bank_account_id` VARCHAR(36) COMMENT '付款账户ID',
+  `reference` VARCHAR(100) COMMENT '参考号',
+  `description` TEXT COMMENT '摘要',
+  `remarks` TEXT COMMENT '备注',
+  `run_id` VARCHAR(36) COMMENT '来源付款运行ID',
+  `status` VARCHAR(20) DEFAULT 'draft' COMMENT '状态（draft, submitted, approved, rejected, processed）',
+  `processed_by` VARCHAR(36) COMMENT '处理人',
+  `processed_at` DATETIME COMMENT '处理时间',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`vendor_id`) REFERENCES `purchase_vendors` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='供应商付款单表';
+
+-- 5.21 付款核销明细表（finance_payment_allocations）
+CREATE TABLE IF NOT EXISTS `finance_payment_allocations` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '明细ID',
+  `payment_id` VARCHAR(36) NOT NULL COMMENT '付款单ID',
+  `invoice_id` VARCHAR(36) NOT NULL COMMENT '采购发票ID',
+  `allocation_date` DATE NOT NULL COMMENT '核销日期',
+  `amount` DECIMAL(18,2) DEFAULT 0 COMMENT '核销付款金额',
+  `discount_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '提前付款折扣金额',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`payment_id`) REFERENCES `finance_payments` (`id`),
+  FOREIGN KEY (`invoice_id`) REFERENCES `purchase_invoices` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='付款核销明细表';
+
+-- 5.22 付款运行表（finance_payment_runs）
+CREATE TABLE IF NOT EXISTS `finance_payment_runs` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '付款运行ID',
+  `run_no` VARCHAR(20) UNIQUE NOT NULL COMMENT '付款运行编号',
+  `payment_date` DATE NOT NULL COMMENT '付款日期',
+  `due_date` DATE NOT NULL COMMENT '到期日截止日期',
+  `vendor_id` VARCHAR(36) COMMENT '供应商ID（为空不限）',
+  `currency` VARCHAR(3) COMMENT '币种（为空不限）',
+  `payment_method` VARCHAR(50) COMMENT '付款方式',
+  `bank_account_id` VARCHAR(36) COMMENT '付款账户ID',
+  `total_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '本位币付款合计',
+  `discount_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '本位币折扣合计',
+  `status` VARCHAR(20) DEFAULT 'proposed' COMMENT '状态（proposed, approved, cancelled）',
+  `approved_by` VARCHAR(36) COMMENT '审批人',
+  `approved_at` DATETIME COMMENT '审批时间',
+  `remarks` TEXT COMMENT '备注',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='付款运行表';
+
+-- 5.23 付款运行明细表（finance_payment_run_items）
+CREATE TABLE IF NOT EXISTS `finance_payment_run_items` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '明细ID',
+  `run_id` VARCHAR(36) NOT NULL COMMENT '付款运行ID',
+  `invoice_id` VARCHAR(36) NOT NULL COMMENT '采购发票ID',
+  `vendor_id` VARCHAR(36) NOT NULL COMMENT '供应商ID',
+  `currency` VARCHAR(3) COMMENT '币种',
+  `due_date` DATE NOT NULL COMMENT '发票到期日',
+  `discount_date` DATE COMMENT '提前付款折扣截止日期',
+  `open_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '建议时发票余额',
+  `discount_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '提前付款折扣金额',
+  `payment_amount` DECIMAL(18,2) DEFAULT 0 COMMENT '付款金额',
+  `payment_id` VARCHAR(36) COMMENT '生成的付款单ID',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_by` VARCHAR(36) COMMENT '更新人',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  FOREIGN KEY (`run_id`) REFERENCES `finance_payment_runs` (`id`),
+  FOREIGN KEY (`invoice_id`) REFERENCES `purchase_invoices` (`id`),
+  FOREIGN KEY (`vendor_id`) REFERENCES `purchase_vendors` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='付款运行明细表';
+
 -- 6. 生产模块
 
 -- 6.1 物料清单表（production_boms）
@@ -981,6 +1729,8 @@ CREATE TABLE IF NOT EXISTS `production_orders` (
   `order_no` VARCHAR(20) UNIQUE NOT NULL COMMENT '生产订单编号',
   `product_id` VARCHAR(36) NOT NULL COMMENT '产品ID',
   `mps_item_id` VARCHAR(36) COMMENT 'MPS明细ID',
+  `item_id` VARCHAR(36) COMMENT '产出物料ID',
+  `warehouse_id` VARCHAR(36) COMMENT '计划入库仓库ID',
   `quantity` DECIMAL(18,4) NOT NULL COMMENT '数量',
   `start_date` DATE NOT NULL COMMENT '开始日期',
   `end_date` DATE NOT NULL COMMENT '结束日期',
@@ -1363,6 +2113,32 @@ CREATE TABLE IF NOT EXISTS `system_logs` (
   FOREIGN KEY (`user_id`) REFERENCES `system_users` (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='日志表';
 
+-- 8.7 用户会话表（system_user_sessions）
+CREATE TABLE IF NOT EXISTS `system_user_sessions` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT '会话ID',
+  `user_id` VARCHAR(36) NOT NULL COMMENT '用户ID',
+  `refresh_token_hash` VARCHAR(64) NOT NULL COMMENT '刷新令牌摘要',
+  `previous_token_hash` VARCHAR(64) COMMENT '上一次轮换前的刷新令牌摘要',
+  `expires_at` DATETIME NOT NULL COMMENT '过期时间',
+  `refreshed_at` DATETIME COMMENT '最后刷新时间',
+  `revoked_at` DATETIME COMMENT '撤销时间',
+  `client_ip` VARCHAR(50) COMMENT '客户端IP',
+  `user_agent` VARCHAR(255) COMMENT '用户代理',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='用户会话表';
+
+-- 8.8 密码重置表（system_password_resets）
+CREATE TABLE IF NOT EXISTS `system_password_resets` (
+  `id` VARCHAR(36) PRIMARY KEY COMMENT 'ID',
+  `user_id` VARCHAR(36) NOT NULL COMMENT '用户ID',
+  `token_hash` VARCHAR(64) UNIQUE NOT NULL COMMENT '重置令牌摘要',
+  `expires_at` DATETIME NOT NULL COMMENT '过期时间',
+  `used_at` DATETIME COMMENT '使用时间',
+  `created_by` VARCHAR(36) COMMENT '创建人',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='密码重置表';
+
 -- 9. 创建索引
 
 -- CRM模块索引
@@ -1404,6 +2180,16 @@ CREATE INDEX `idx_sales_returns_return_no` ON `sales_returns` (`return_no`);
 CREATE INDEX `idx_sales_returns_order_id` ON `sales_returns` (`order_id`);
 CREATE INDEX `idx_sales_return_items_return_id` ON `sales_return_items` (`return_id`);
 CREATE INDEX `idx_sales_customer_credits_customer_id` ON `sales_customer_credits` (`customer_id`);
+CREATE INDEX `idx_sales_price_list_items_price_list_id` ON `sales_price_list_items` (`price_list_id`);
+CREATE INDEX `idx_sales_price_list_items_product_id` ON `sales_price_list_items` (`product_id`);
+CREATE INDEX `idx_sales_customer_prices_customer_id` ON `sales_customer_prices` (`customer_id`);
+CREATE INDEX `idx_sales_customer_prices_product_id` ON `sales_customer_prices` (`product_id`);
+CREATE INDEX `idx_sales_promotions_product_id` ON `sales_promotions` (`product_id`);
+CREATE INDEX `idx_sales_return_items_order_item_id` ON `sales_return_items` (`order_item_id`);
+CREATE INDEX `idx_sales_credit_notes_return_id` ON `sales_credit_notes` (`return_id`);
+CREATE INDEX `idx_sales_credit_notes_customer_id` ON `sales_credit_notes` (`customer_id`);
+CREATE INDEX `idx_sales_credit_notes_invoice_id` ON `sales_credit_notes` (`invoice_id`);
+CREATE INDEX `idx_sales_credit_note_items_credit_note_id` ON `sales_credit_note_items` (`credit_note_id`);
 
 -- 库存模块索引
 CREATE INDEX `idx_inventory_warehouses_code` ON `inventory_warehouses` (`code`);
@@ -1424,6 +2210,14 @@ CREATE INDEX `idx_inventory_counts_count_no` ON `inventory_counts` (`count_no`);
 CREATE INDEX `idx_inventory_counts_warehouse_id` ON `inventory_counts` (`warehouse_id`);
 CREATE INDEX `idx_inventory_count_items_count_id` ON `inventory_count_items` (`count_id`);
 CREATE INDEX `idx_inventory_count_items_item_id` ON `inventory_count_items` (`item_id`);
+CREATE INDEX `idx_inventory_cost_layers_stock` ON `inventory_cost_layers` (`item_id`, `warehouse_id`, `location_id`);
+CREATE INDEX `idx_inventory_reservations_stock` ON `inventory_reservations` (`item_id`, `warehouse_id`);
+CREATE INDEX `idx_inventory_reservations_source_id` ON `inventory_reservations` (`source_id`);
+CREATE INDEX `idx_inventory_reservations_source_item_id` ON `inventory_reservations` (`source_item_id`);
+CREATE INDEX `idx_inventory_replenishment_rules_stock` ON `inventory_replenishment_rules` (`item_id`, `warehouse_id`);
+CREATE INDEX `idx_inventory_replenishment_run_lines_run_id` ON `inventory_replenishment_run_lines` (`run_id`);
+CREATE INDEX `idx_inventory_transfer_requests_item_id` ON `inventory_transfer_requests` (`item_id`);
+CREATE INDEX `idx_inventory_transfer_requests_status` ON `inventory_transfer_requests` (`status`);
 
 -- 采购模块索引
 CREATE INDEX `idx_purchase_vendors_vendor_no` ON `purchase_vendors` (`vendor_no`);
@@ -1435,6 +2229,8 @@ CREATE INDEX `idx_purchase_orders_order_no` ON `purchase_orders` (`order_no`);
 CREATE INDEX `idx_purchase_orders_vendor_id` ON `purchase_orders` (`vendor_id`);
 CREATE INDEX `idx_purchase_order_items_order_id` ON `purchase_order_items` (`order_id`);
 CREATE INDEX `idx_purchase_order_items_item_id` ON `purchase_order_items` (`item_id`);
+CREATE INDEX `idx_purchase_order_items_requisition_item_id` ON `purchase_order_items` (`requisition_item_id`);
+CREATE INDEX `idx_purchase_order_items_plan_item_id` ON `purchase_order_items` (`plan_item_id`);
 CREATE INDEX `idx_purchase_receipts_receipt_no` ON `purchase_receipts` (`receipt_no`);
 CREATE INDEX `idx_purchase_receipts_order_id` ON `purchase_receipts` (`order_id`);
 CREATE INDEX `idx_purchase_receipt_items_receipt_id` ON `purchase_receipt_items` (`receipt_id`);
@@ -1443,9 +2239,21 @@ CREATE INDEX `idx_purchase_invoices_invoice_no` ON `purchase_invoices` (`invoice
 CREATE INDEX `idx_purchase_invoices_order_id` ON `purchase_invoices` (`order_id`);
 CREATE INDEX `idx_purchase_invoices_vendor_id` ON `purchase_invoices` (`vendor_id`);
 CREATE INDEX `idx_purchase_invoice_items_invoice_id` ON `purchase_invoice_items` (`invoice_id`);
+CREATE INDEX `idx_purchase_invoice_items_order_item_id` ON `purchase_invoice_items` (`order_item_id`);
+CREATE INDEX `idx_purchase_invoice_discrepancies_invoice_id` ON `purchase_invoice_discrepancies` (`invoice_id`);
 CREATE INDEX `idx_purchase_returns_return_no` ON `purchase_returns` (`return_no`);
 CREATE INDEX `idx_purchase_returns_order_id` ON `purchase_returns` (`order_id`);
 CREATE INDEX `idx_purchase_return_items_return_id` ON `purchase_return_items` (`return_id`);
+CREATE INDEX `idx_purchase_requisitions_requisition_no` ON `purchase_requisitions` (`requisition_no`);
+CREATE INDEX `idx_purchase_requisitions_status` ON `purchase_requisitions` (`status`);
+CREATE INDEX `idx_purchase_requisition_items_requisition_id` ON `purchase_requisition_items` (`requisition_id`);
+CREATE INDEX `idx_purchase_requisition_items_item_id` ON `purchase_requisition_items` (`item_id`);
+CREATE INDEX `idx_purchase_requisition_items_stock` ON `purchase_requisition_items` (`item_id`, `warehouse_id`);
+CREATE INDEX `idx_purchase_vendor_scorecards_vendor_period` ON `purchase_vendor_scorecards` (`vendor_id`, `period_end`);
+CREATE INDEX `idx_purchase_price_histories_item_date` ON `purchase_price_histories` (`item_id`, `price_date`);
+CREATE INDEX `idx_purchase_price_histories_vendor_id` ON `purchase_price_histories` (`vendor_id`);
+CREATE INDEX `idx_purchase_price_histories_source` ON `purchase_price_histories` (`source_type`, `source_id`);
+CREATE INDEX `idx_purchase_target_prices_item_id` ON `purchase_target_prices` (`item_id`);
 
 -- 财务模块索引
 CREATE INDEX `idx_finance_accounts_code` ON `finance_accounts` (`code`);
@@ -1453,6 +2261,7 @@ CREATE INDEX `idx_finance_accounts_name` ON `finance_accounts` (`name`);
 CREATE INDEX `idx_finance_accounts_type` ON `finance_accounts` (`type`);
 CREATE INDEX `idx_finance_journals_journal_no` ON `finance_journals` (`journal_no`);
 CREATE INDEX `idx_finance_journals_date` ON `finance_journals` (`date`);
+CREATE INDEX `idx_finance_journals_status_date` ON `finance_journals` (`status`, `date`);
 CREATE INDEX `idx_finance_journal_items_journal_id` ON `finance_journal_items` (`journal_id`);
 CREATE INDEX `idx_finance_journal_items_account_id` ON `finance_journal_items` (`account_id`);
 CREATE INDEX `idx_finance_fixed_assets_asset_no` ON `finance_fixed_assets` (`asset_no`);
@@ -1462,6 +2271,23 @@ CREATE INDEX `idx_finance_budget_items_budget_id` ON `finance_budget_items` (`bu
 CREATE INDEX `idx_finance_budget_items_account_id` ON `finance_budget_items` (`account_id`);
 CREATE INDEX `idx_finance_cost_centers_code` ON `finance_cost_centers` (`code`);
 CREATE INDEX `idx_finance_cost_centers_name` ON `finance_cost_centers` (`name`);
+CREATE INDEX `idx_finance_journals_reference` ON `finance_journals` (`reference_type`, `reference_id`);
+CREATE INDEX `idx_finance_periods_fiscal_year_id` ON `finance_periods` (`fiscal_year_id`);
+CREATE INDEX `idx_finance_periods_dates` ON `finance_periods` (`start_date`, `end_date`);
+CREATE INDEX `idx_finance_journals_reversal_of` ON `finance_journals` (`reversal_of`);
+CREATE INDEX `idx_finance_posting_rules_event` ON `finance_posting_rules` (`document_type`, `event`);
+CREATE INDEX `idx_finance_tax_rates_tax_code_id` ON `finance_tax_rates` (`tax_code_id`);
+CREATE INDEX `idx_finance_tax_rules_direction` ON `finance_tax_rules` (`direction`);
+CREATE INDEX `idx_finance_fx_revaluation_items_revaluation_id` ON `finance_fx_revaluation_items` (`revaluation_id`);
+CREATE INDEX `idx_finance_receipts_customer_id` ON `finance_receipts` (`customer_id`);
+CREATE INDEX `idx_finance_receipt_allocations_receipt_id` ON `finance_receipt_allocations` (`receipt_id`);
+CREATE INDEX `idx_finance_receipt_allocations_invoice_id` ON `finance_receipt_allocations` (`invoice_id`);
+CREATE INDEX `idx_finance_payments_vendor_id` ON `finance_payments` (`vendor_id`);
+CREATE INDEX `idx_finance_payments_run_id` ON `finance_payments` (`run_id`);
+CREATE INDEX `idx_finance_payment_allocations_payment_id` ON `finance_payment_allocations` (`payment_id`);
+CREATE INDEX `idx_finance_payment_allocations_invoice_id` ON `finance_payment_allocations` (`invoice_id`);
+CREATE INDEX `idx_finance_payment_run_items_run_id` ON `finance_payment_run_items` (`run_id`);
+CREATE INDEX `idx_finance_payment_run_items_invoice_id` ON `finance_payment_run_items` (`invoice_id`);
 
 -- 生产模块索引
 CREATE INDEX `idx_production_boms_bom_no` ON `production_boms` (`bom_no`);
@@ -1476,6 +2302,7 @@ CREATE INDEX `idx_production_mrp_items_mrp_id` ON `production_mrp_items` (`mrp_i
 CREATE INDEX `idx_production_mrp_items_item_id` ON `production_mrp_items` (`item_id`);
 CREATE INDEX `idx_production_orders_order_no` ON `production_orders` (`order_no`);
 CREATE INDEX `idx_production_orders_product_id` ON `production_orders` (`product_id`);
+CREATE INDEX `idx_production_orders_item_id` ON `production_orders` (`item_id`);
 CREATE INDEX `idx_production_work_orders_work_order_no` ON `production_work_orders` (`work_order_no`);
 CREATE INDEX `idx_production_work_orders_production_order_id` ON `production_work_orders` (`production_order_id`);
 CREATE INDEX `idx_production_work_orders_work_center_id` ON `production_work_orders` (`work_center_id`);
@@ -1527,6 +2354,8 @@ CREATE INDEX `idx_system_role_permissions_role_id` ON `system_role_permissions`
 CREATE INDEX `idx_system_role_permissions_permission_id` ON `system_role_permissions` (`permission_id`);
 CREATE INDEX `idx_system_logs_user_id` ON `system_logs` (`user_id`);
 CREATE INDEX `idx_system_logs_created_at` ON `system_logs` (`created_at`);
+CREATE INDEX `idx_system_user_sessions_user_id` ON `system_user_sessions` (`user_id`);
+CREATE INDEX `idx_system_password_resets_user_id` ON `system_password_resets` (`user_id`);
 
 -- 10. 设置外键检查
 SET FOREIGN_KEY_CHECKS = 1;
\ No newline at end of file
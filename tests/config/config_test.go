@@ -30,4 +30,44 @@ func TestLoadConfig(t *testing.T) {
 	if cfg.Database.Port != "3306" {
 		t.Errorf("Expected database port 3306, got %s", cfg.Database.Port)
 	}
+
+	if cfg.JWT.AccessTokenMinutes != 15 {
+		t.Errorf("Expected access token minutes 15, got %d", cfg.JWT.AccessTokenMinutes)
+	}
+
+	if cfg.Finance.RetainedEarningsAccount != "4104" {
+		t.Errorf("Expected retained earnings account 4104, got %s", cfg.Finance.RetainedEarningsAccount)
+	}
+
+	if cfg.Finance.BaseCurrency != "CNY" {
+		t.Errorf("Expected base currency CNY, got %s", cfg.Finance.BaseCurrency)
+	}
+
+	if cfg.Finance.TaxRounding != "line" {
+		t.Errorf("Expected tax rounding line, got %s", cfg.Finance.TaxRounding)
+	}
+
+	if len(cfg.Finance.AgingBuckets) != 3 || cfg.Finance.AgingBuckets[2] != 90 {
+		t.Errorf("Expected aging buckets [30 60 90], got %v", cfg.Finance.AgingBuckets)
+	}
+
+	if cfg.Finance.PaymentBank.Name != "本公司" {
+		t.Errorf("Expected payment bank name 本公司, got %s", cfg.Finance.PaymentBank.Name)
+	}
+
+	if cfg.Sales.CreditOverdueDays != 30 {
+		t.Errorf("Expected credit overdue days 30, got %d", cfg.Sales.CreditOverdueDays)
+	}
+
+	if cfg.Purchase.PriceTolerance != 2 || cfg.Purchase.QuantityTolerance != 0 {
+		t.Errorf("Expected purchase tolerances 2/0, got %v/%v", cfg.Purchase.PriceTolerance, cfg.Purchase.QuantityTolerance)
+	}
+
+	if cfg.Purchase.ScorecardWeights.OnTime != 40 || cfg.Purchase.ScorecardWeights.LeadTime != 10 {
+		t.Errorf("Expected scorecard weights onTime 40 and leadTime 10, got %+v", cfg.Purchase.ScorecardWeights)
+	}
+
+	if cfg.Inventory.ReplenishmentInterval != 86400 {
+		t.Errorf("Expected replenishment interval 86400, got %d", cfg.Inventory.ReplenishmentInterval)
+	}
 }
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wu136995/ginx/internal/api/handlers"
+)
+
+// TestCreateSalesCustomerValidation 测试无效请求返回字段级400错误
+func TestCreateSalesCustomerValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/sales/customers", handlers.NewSalesHandler(nil).CreateSalesCustomer)
+
+	// 缺少客户编号，服务层不会被调用
+	body := `{"name":"ACME","creditLimit":1000}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/sales/customers", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	if !strings.Contains(w.Body.String(), "customerNo") {
+		t.Errorf("body = %s, want error mentioning customerNo", w.Body.String())
+	}
+}
@@ -0,0 +1,53 @@
+package middlewares
+
+import (
+	"testing"
+
+	"github.com/wu136995/ginx/internal/api/middlewares"
+)
+
+// TestPermissionCode 测试根据路由推导权限编码
+func TestPermissionCode(t *testing.T) {
+	cases := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{"GET", "/api/v1/purchase/orders", "purchase:orders:view"},
+		{"GET", "/api/v1/purchase/orders/:id", "purchase:orders:view"},
+		{"POST", "/api/v1/purchase/orders", "purchase:orders:create"},
+		{"PUT", "/api/v1/purchase/orders/:id", "purchase:orders:update"},
+		{"DELETE", "/api/v1/purchase/orders/:id", "purchase:orders:delete"},
+		{"POST", "/api/v1/purchase/orders/:id/approve", "purchase:orders:approve"},
+		{"GET", "/api/v1/finance/accounts/:id/transactions", "finance:accounts.transactions:view"},
+		{"GET", "/api/v1/finance/reports/balance", "finance:reports.balance:view"},
+		{"PUT", "/api/v1/system/roles/:id/permissions", "system:roles.permissions:update"},
+		{"GET", "/user/info", ""},
+		{"GET", "/health", ""},
+	}
+
+	for _, c := range cases {
+		if got := middlewares.PermissionCode(c.method, c.path); got != c.want {
+			t.Errorf("PermissionCode(%s, %s) = %q, want %q", c.method, c.path, got, c.want)
+		}
+	}
+}
+
+// TestHasPermission 测试权限匹配及通配
+func TestHasPermission(t *testing.T) {
+	granted := []string{"sales:orders:view", "purchase:orders:*", "finance:*"}
+
+	allowed := []string{"sales:orders:view", "purchase:orders:approve", "finance:reports.balance:view"}
+	for _, code := range allowed {
+		if !middlewares.HasPermission(granted, code) {
+			t.Errorf("HasPermission(%s) = false, want true", code)
+		}
+	}
+
+	denied := []string{"sales:orders:create", "purchase:invoices:view", "purchase:orders.items:view", "financex:accounts:view"}
+	for _, code := range denied {
+		if middlewares.HasPermission(granted, code) {
+			t.Errorf("HasPermission(%s) = true, want false", code)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package query
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/wu136995/ginx/internal/query"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// order 测试模型
+type order struct {
+	ID          string
+	OrderNo     string
+	Status      string
+	TotalAmount float64
+}
+
+// orderSpec 测试白名单
+var orderSpec = query.NewSpec("-order_date",
+	query.Text("order_no"),
+	query.Text("status"),
+	query.Number("total_amount"),
+	query.Date("order_date"),
+	query.Text("customer_id").As("customerId"),
+)
+
+// dryRunDB 创建不连接数据库、只生成SQL的连接
+func dryRunDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(mysql.New(mysql.Config{SkipInitializeWithVersion: true}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("open dry run db failed: %v", err)
+	}
+	return db
+}
+
+// buildSQL 生成应用查询参数后的SQL
+func buildSQL(t *testing.T, rawQuery string) (string, []interface{}, error) {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		t.Fatalf("parse query failed: %v", err)
+	}
+	params, err := query.Parse(values)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var orders []order
+	stmt := dryRunDB(t).Scopes(params.Filter(orderSpec), params.Order(orderSpec), params.Paginate()).Find(&orders)
+	return stmt.Statement.SQL.String(), stmt.Statement.Vars, stmt.Error
+}
+
+// TestParse 测试分页参数解析
+func TestParse(t *testing.T) {
+	params, err := query.Parse(url.Values{"page": {"3"}, "page_size": {"50"}, "status": {""}})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if params.Page != 3 || params.PageSize != 50 || params.Offset() != 100 {
+		t.Errorf("unexpected paging: %+v", params)
+	}
+	if len(params.Filters) != 0 {
+		t.Errorf("empty filter should be ignored: %+v", params.Filters)
+	}
+
+	for _, raw := range []string{"page=0", "page_size=101", "page=abc", "status[regex]=a"} {
+		values, _ := url.ParseQuery(raw)
+		if _, err := query.Parse(values); !errors.Is(err, query.ErrInvalidQuery) {
+			t.Errorf("Parse(%s) expected ErrInvalidQuery, got %v", raw, err)
+		}
+	}
+}
+
+// TestFilterAndSort 测试过滤和排序SQL
+func TestFilterAndSort(t *testing.T) {
+	sql, vars, err := buildSQL(t, "status[in]=draft,approved&total_amount[between]=100,500&order_no[like]=SO_1&customerId=C1&unknown=x&sort=-total_amount,order_no&page=2&page_size=10")
+	if err != nil {
+		t.Fatalf("build sql failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"`customer_id` = ?",
+		"`order_no` LIKE ?",
+		"`status` IN (?,?)",
+		"`total_amount` >= ? AND `total_amount` <= ?",
+		"ORDER BY `total_amount` DESC,`order_no`",
+		"LIMIT 10 OFFSET 10",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("sql %q missing %q", sql, want)
+		}
+	}
+	if strings.Contains(sql, "unknown") {
+		t.Errorf("unknown plain filter should be ignored: %s", sql)
+	}
+	if vars[1] != `%SO\_1%` {
+		t.Errorf("like value not escaped: %v", vars[1])
+	}
+}
+
+// TestDateFilter 测试日期按整天匹配
+func TestDateFilter(t *testing.T) {
+	sql, vars, err := buildSQL(t, "order_date=2024-03-31")
+	if err != nil {
+		t.Fatalf("build sql failed: %v", err)
+	}
+	if !strings.Contains(sql, "`order_date` >= ? AND `order_date` < ?") {
+		t.Errorf("unexpected date sql: %s", sql)
+	}
+	if len(vars) < 2 {
+		t.Fatalf("unexpected vars: %v", vars)
+	}
+	if !strings.Contains(sql, "ORDER BY `order_date` DESC") {
+		t.Errorf("default sort not applied: %s", sql)
+	}
+}
+
+// TestInvalidFilter 测试白名单校验
+func TestInvalidFilter(t *testing.T) {
+	for _, raw := range []string{
+		"password[like]=a",
+		"status[gte]=a",
+		"total_amount=abc",
+		"order_date[between]=2024-01-01",
+		"sort=password",
+	} {
+		if _, _, err := buildSQL(t, raw); !errors.Is(err, query.ErrInvalidQuery) {
+			t.Errorf("%s expected ErrInvalidQuery, got %v", raw, err)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/wu136995/ginx/internal/utils"
+)
+
+// TestGenerateID 测试主键生成
+func TestGenerateID(t *testing.T) {
+	id := utils.GenerateID()
+	if len(id) != 36 {
+		t.Errorf("GenerateID returned invalid length: %d", len(id))
+	}
+
+	if id[14] != '4' {
+		t.Errorf("GenerateID returned invalid version: %s", id)
+	}
+
+	if utils.GenerateID() == id {
+		t.Error("GenerateID returned duplicate id")
+	}
+}
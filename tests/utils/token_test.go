@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wu136995/ginx/internal/utils"
+)
+
+// TestGenerateSecureToken 测试随机令牌生成
+func TestGenerateSecureToken(t *testing.T) {
+	token := utils.GenerateSecureToken()
+	if len(token) != 64 {
+		t.Errorf("GenerateSecureToken returned invalid length: %d", len(token))
+	}
+
+	if utils.GenerateSecureToken() == token {
+		t.Error("GenerateSecureToken returned duplicate token")
+	}
+
+	if utils.HashToken(token) != utils.HashToken(token) || utils.HashToken(token) == token {
+		t.Error("HashToken returned unexpected digest")
+	}
+}
+
+// TestGenerateAccessToken 测试访问令牌携带会话ID
+func TestGenerateAccessToken(t *testing.T) {
+	secret := "testsecret"
+	token, err := utils.GenerateAccessToken(1, "testuser", "user", "session-1", secret, 15*time.Minute)
+	if err != nil {
+		t.Fatalf("GenerateAccessToken failed: %v", err)
+	}
+
+	claims, err := utils.ParseToken(token, secret)
+	if err != nil {
+		t.Fatalf("ParseToken failed: %v", err)
+	}
+
+	if claims.ID != "session-1" {
+		t.Errorf("Expected session ID session-1, got %s", claims.ID)
+	}
+}
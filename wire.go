@@ -19,6 +19,8 @@ var WireSet = wire.NewSet(
 	services.NewProductionService,
 	services.NewHRService,
 	services.NewCRMService,
+	services.NewSystemService,
+	services.NewAuthService,
 	handlers.NewUserHandler,
 	handlers.NewSalesHandler,
 	handlers.NewInventoryHandler,
@@ -27,6 +29,8 @@ var WireSet = wire.NewSet(
 	handlers.NewProductionHandler,
 	handlers.NewHRHandler,
 	handlers.NewCRMHandler,
+	handlers.NewSystemHandler,
+	handlers.NewAuthHandler,
 )
 
 // InitializeHandlers 初始化所有处理器
@@ -24,4 +24,4 @@ func InitializeHandlers() (*handlers.UserHandler, error) {
 // wire.go:
 
 // WireSet 依赖注入集合
-var WireSet = wire.NewSet(services.NewUserService, services.NewSalesService, services.NewInventoryService, services.NewPurchaseService, services.NewFinanceService, services.NewProductionService, services.NewHRService, services.NewCRMService, handlers.NewUserHandler, handlers.NewSalesHandler, handlers.NewInventoryHandler, handlers.NewPurchaseHandler, handlers.NewFinanceHandler, handlers.NewProductionHandler, handlers.NewHRHandler, handlers.NewCRMHandler)
+var WireSet = wire.NewSet(services.NewUserService, services.NewSalesService, services.NewInventoryService, services.NewPurchaseService, services.NewFinanceService, services.NewProductionService, services.NewHRService, services.NewCRMService, services.NewSystemService, services.NewAuthService, handlers.NewUserHandler, handlers.NewSalesHandler, handlers.NewInventoryHandler, handlers.NewPurchaseHandler, handlers.NewFinanceHandler, handlers.NewProductionHandler, handlers.NewHRHandler, handlers.NewCRMHandler, handlers.NewSystemHandler, handlers.NewAuthHandler)